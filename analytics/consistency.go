@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// ConsistencyWindow is how far back Consistency.RecentSessions counts
+const ConsistencyWindow = 30
+
+// Consistency measures how regularly a history was trained against a target
+// number of sessions a week. Weeks start on Monday.
+type Consistency struct {
+	Target int `json:"target"`
+
+	// CurrentStreak counts the weeks in a row, up to last week, that reached the
+	// target, plus this week once it has. A week in progress doesn't break it.
+	CurrentStreak int `json:"current_streak"`
+
+	// LongestStreak is the most weeks in a row that ever reached the target
+	LongestStreak int `json:"longest_streak"`
+
+	// ThisWeek counts the sessions so far this week
+	ThisWeek int `json:"this_week"`
+
+	// RecentSessions counts the sessions in the last ConsistencyWindow days
+	RecentSessions int `json:"recent_sessions"`
+}
+
+// MeasureConsistency measures a chronologically sorted history against target
+// sessions a week, as of now
+func MeasureConsistency(history []models.Workout, target int, now time.Time) Consistency {
+	consistency := Consistency{Target: target}
+	if len(history) == 0 {
+		return consistency
+	}
+
+	// Count the sessions in each week from the first workout's to this one
+	thisWeek := WeekStart(now)
+	first := WeekStart(history[0].EnteredAt.In(now.Location()))
+	sessions := make(map[time.Time]int)
+	recent := now.AddDate(0, 0, -ConsistencyWindow)
+	for _, workout := range history {
+		entered := workout.EnteredAt.In(now.Location())
+		if entered.After(now) {
+			continue
+		}
+		sessions[WeekStart(entered)]++
+		if entered.After(recent) {
+			consistency.RecentSessions++
+		}
+	}
+	consistency.ThisWeek = sessions[thisWeek]
+
+	streak := 0
+	for week := first; !week.After(thisWeek); week = week.AddDate(0, 0, 7) {
+		switch {
+		case sessions[week] >= target:
+			streak++
+		case week.Equal(thisWeek):
+			// This week can still reach the target
+		default:
+			streak = 0
+		}
+		consistency.LongestStreak = max(consistency.LongestStreak, streak)
+	}
+	consistency.CurrentStreak = streak
+	return consistency
+}
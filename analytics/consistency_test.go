@@ -0,0 +1,39 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureConsistency(t *testing.T) {
+	// Sessions on these days after summaryBase, a Monday: two full weeks, a
+	// week with one session, then two full weeks and one session this week
+	var history []models.Workout
+	for _, day := range []int{0, 2, 4, 7, 9, 11, 16, 21, 23, 25, 28, 30, 32, 35} {
+		history = append(history, models.Workout{EnteredAt: summaryBase.AddDate(0, 0, day)})
+	}
+	now := summaryBase.AddDate(0, 0, 36)
+
+	consistency := MeasureConsistency(history, 3, now)
+	assert.Equal(t, Consistency{Target: 3, CurrentStreak: 2, LongestStreak: 2, ThisWeek: 1, RecentSessions: 11}, consistency)
+
+	// Reaching the target this week extends the streak
+	history = append(history, models.Workout{EnteredAt: now}, models.Workout{EnteredAt: now.Add(time.Hour)})
+	consistency = MeasureConsistency(history, 3, now.Add(2*time.Hour))
+	assert.Equal(t, 3, consistency.CurrentStreak)
+	assert.Equal(t, 3, consistency.LongestStreak)
+	assert.Equal(t, 3, consistency.ThisWeek)
+
+	// A lower target counts the light week too
+	assert.Equal(t, 6, MeasureConsistency(history, 1, now).LongestStreak)
+
+	// A week without training ends the streak
+	consistency = MeasureConsistency(history[:3], 3, summaryBase.AddDate(0, 0, 14))
+	assert.Equal(t, 0, consistency.CurrentStreak)
+	assert.Equal(t, 1, consistency.LongestStreak)
+
+	assert.Equal(t, Consistency{Target: 3}, MeasureConsistency(nil, 3, now))
+}
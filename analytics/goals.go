@@ -0,0 +1,93 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// goalRateSessions is the number of recent AMRAP sessions the progression rate
+// toward a goal is measured over, so an early run of easy gains doesn't make
+// the ETA too optimistic once progress slows
+const goalRateSessions = 6
+
+// GoalProgress is how far a lift has come toward its goal weight
+type GoalProgress struct {
+	Lift    models.LiftName `json:"lift"`
+	Goal    float64         `json:"goal"`
+	Start   float64         `json:"start"`
+	Current float64         `json:"current"`
+
+	// Fraction is the share of the distance from Start to Goal covered, from 0 to 1
+	Fraction float64 `json:"fraction"`
+	Reached  bool    `json:"reached"`
+
+	// WeeklyRate is the weight gained per week over recent sessions; ETA is when
+	// the goal will be reached at that rate, and is zero when the lift isn't progressing
+	WeeklyRate float64   `json:"weekly_rate"`
+	ETA        time.Time `json:"eta,omitzero"`
+
+	// By is the date the goal is to be reached by, if set. RequiredRate is the
+	// weight to gain per week from now to reach it by then, and is zero once
+	// the goal is reached or the date has passed.
+	By           time.Time `json:"by,omitzero"`
+	RequiredRate float64   `json:"required_rate,omitempty"`
+}
+
+// Goals returns the progress toward each of a UserProgram's goals, ordered by
+// lift name. history is the program's chronologically sorted history, and now
+// is the time ETAs are projected from.
+func Goals(userProgram *models.UserProgram, history []models.Workout, now time.Time) []GoalProgress {
+	var progress []GoalProgress
+	for lift, goal := range userProgram.Goals {
+		progress = append(progress, GoalFor(lift, goal, userProgram, history, now))
+	}
+	slices.SortFunc(progress, func(a, b GoalProgress) int {
+		return cmp.Compare(a.Lift, b.Lift)
+	})
+	return progress
+}
+
+// GoalFor returns the progress of one lift toward a goal weight. Progress is
+// measured from the program's starting weight to its current working weight.
+func GoalFor(lift models.LiftName, goal float64, userProgram *models.UserProgram, history []models.Workout, now time.Time) GoalProgress {
+	progress := GoalProgress{
+		Lift:    lift,
+		Goal:    goal,
+		Start:   userProgram.StartingWeights[lift],
+		Current: userProgram.CurrentWeights[lift],
+		By:      userProgram.GoalDeadlines[lift],
+	}
+
+	if progress.Current >= goal {
+		progress.Fraction = 1
+		progress.Reached = true
+		return progress
+	}
+	if goal > progress.Start {
+		progress.Fraction = max(0, (progress.Current-progress.Start)/(goal-progress.Start))
+	}
+	if weeks := progress.By.Sub(now).Hours() / (24 * 7); weeks > 0 {
+		progress.RequiredRate = (goal - progress.Current) / weeks
+	}
+
+	points := LiftProgress(history, lift)
+	if len(points) > goalRateSessions {
+		points = points[len(points)-goalRateSessions:]
+	}
+	if len(points) < 2 {
+		return progress
+	}
+	first, last := points[0], points[len(points)-1]
+	weeks := last.Date.Sub(first.Date).Hours() / (24 * 7)
+	if weeks <= 0 || last.Weight <= first.Weight {
+		return progress
+	}
+
+	progress.WeeklyRate = (last.Weight - first.Weight) / weeks
+	remaining := (goal - progress.Current) / progress.WeeklyRate
+	progress.ETA = now.Add(time.Duration(remaining * 7 * 24 * float64(time.Hour)))
+	return progress
+}
@@ -0,0 +1,88 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoals(t *testing.T) {
+	// Squat gains 5 lbs a session three times a week: 15 lbs per week
+	var history []models.Workout
+	for i := range 8 {
+		weight := 135 + 5*float64(i)
+		history = append(history, models.Workout{
+			EnteredAt: summaryBase.Add(time.Duration(i) * 56 * time.Hour),
+			Exercises: []models.Lift{session(models.Squat, weight, 5), session(models.BenchPress, 100, 4)},
+		})
+	}
+	userProgram := &models.UserProgram{
+		StartingWeights: map[models.LiftName]float64{models.Squat: 135, models.BenchPress: 100, models.Deadlift: 185},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 175, models.BenchPress: 100, models.Deadlift: 200},
+		Goals:           map[models.LiftName]float64{models.Squat: 235, models.BenchPress: 135, models.Deadlift: 195},
+	}
+	now := summaryBase.AddDate(0, 0, 20)
+
+	goals := Goals(userProgram, history, now)
+	require.Len(t, goals, 3)
+	bench, deadlift, squat := goals[0], goals[1], goals[2]
+
+	assert.Equal(t, models.Squat, squat.Lift)
+	assert.InDelta(t, 0.4, squat.Fraction, 1e-9)
+	assert.False(t, squat.Reached)
+	assert.InDelta(t, 15, squat.WeeklyRate, 1e-9)
+	assert.Equal(t, now.AddDate(0, 0, 28), squat.ETA)
+
+	// A lift that isn't progressing has no ETA
+	assert.Equal(t, models.BenchPress, bench.Lift)
+	assert.Zero(t, bench.Fraction)
+	assert.True(t, bench.ETA.IsZero())
+
+	assert.Equal(t, models.Deadlift, deadlift.Lift)
+	assert.True(t, deadlift.Reached)
+	assert.Equal(t, 1.0, deadlift.Fraction)
+}
+
+func TestGoalFor_RecentRate(t *testing.T) {
+	// Early easy gains are left out of the rate once there are enough sessions
+	weights := []float64{100, 120, 140, 145, 150, 155, 160, 165}
+	var history []models.Workout
+	for i, weight := range weights {
+		history = append(history, models.Workout{
+			EnteredAt: summaryBase.AddDate(0, 0, 7*i),
+			Exercises: []models.Lift{session(models.Squat, weight, 5)},
+		})
+	}
+	userProgram := &models.UserProgram{
+		StartingWeights: map[models.LiftName]float64{models.Squat: 100},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 170},
+	}
+
+	goal := GoalFor(models.Squat, 200, userProgram, history, summaryBase)
+	assert.InDelta(t, 5, goal.WeeklyRate, 1e-9)
+	assert.InDelta(t, 0.7, goal.Fraction, 1e-9)
+
+	// Without at least two sessions there's no rate to project
+	goal = GoalFor(models.Squat, 200, userProgram, history[:1], summaryBase)
+	assert.Zero(t, goal.WeeklyRate)
+	assert.True(t, goal.ETA.IsZero())
+}
+
+func TestGoalFor_By(t *testing.T) {
+	userProgram := &models.UserProgram{
+		StartingWeights: map[models.LiftName]float64{models.Squat: 135},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 175},
+		GoalDeadlines:   map[models.LiftName]time.Time{models.Squat: summaryBase.AddDate(0, 0, 28)},
+	}
+
+	goal := GoalFor(models.Squat, 235, userProgram, nil, summaryBase)
+	assert.Equal(t, summaryBase.AddDate(0, 0, 28), goal.By)
+	assert.InDelta(t, 15, goal.RequiredRate, 1e-9)
+
+	// Once the date has passed, or the goal is reached, no rate is needed
+	assert.Zero(t, GoalFor(models.Squat, 235, userProgram, nil, summaryBase.AddDate(0, 0, 28)).RequiredRate)
+	assert.Zero(t, GoalFor(models.Squat, 175, userProgram, nil, summaryBase).RequiredRate)
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+)
+
+// LeaderboardMetric is what a leaderboard ranks users by
+type LeaderboardMetric string
+
+const (
+	// ByWeight ranks by the lift's current working weight in the active program
+	ByWeight LeaderboardMetric = "weight"
+
+	// ByE1RM ranks by the best estimated one-rep max in the active program
+	ByE1RM LeaderboardMetric = "e1rm"
+)
+
+// Standing is one user's place on a leaderboard. Weight is in the unit of the
+// user's active program.
+type Standing struct {
+	Username string            `json:"username"`
+	Weight   float64           `json:"weight"`
+	Unit     models.WeightUnit `json:"unit"`
+}
+
+// Leaderboard ranks users who opted in by a lift, heaviest first, comparing
+// weights across units. Only the active program counts, so every user is measured
+// on the same footing; users without an active program or a value for the lift
+// are left out. Variants of the lift aren't ranked.
+func Leaderboard(users []*models.User, lift models.LiftName, metric LeaderboardMetric) []Standing {
+	var standings []Standing
+	for _, user := range users {
+		if !user.Leaderboard || user.CurrentProgram == uuid.Nil {
+			continue
+		}
+		userProgram, exists := user.Programs[user.CurrentProgram]
+		if !exists {
+			continue
+		}
+
+		var weight float64
+		switch metric {
+		case ByE1RM:
+			weight = bestE1RM(user, userProgram, lift)
+		default:
+			weight = userProgram.CurrentWeights[lift]
+		}
+		if weight <= 0 {
+			continue
+		}
+		standings = append(standings, Standing{Username: user.Username, Weight: weight, Unit: userProgram.Unit.OrDefault()})
+	}
+
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return cmp.Compare(
+			models.ConvertWeight(b.Weight, b.Unit, models.Pounds),
+			models.ConvertWeight(a.Weight, a.Unit, models.Pounds))
+	})
+	return standings
+}
+
+// bestE1RM returns the best estimated one-rep max for a lift in a user's program
+func bestE1RM(user *models.User, userProgram *models.UserProgram, lift models.LiftName) float64 {
+	var history []models.Workout
+	for _, workout := range user.History() {
+		if workout.UserProgramID == userProgram.ID {
+			history = append(history, workout)
+		}
+	}
+
+	best, exists := records.Compute(history)[lift]
+	if !exists {
+		return 0
+	}
+	return best.BestE1RM.E1RM
+}
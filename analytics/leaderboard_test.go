@@ -0,0 +1,62 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// leaderboardUser builds an opted-in user whose active program has a squat
+// working weight and one logged squat session
+func leaderboardUser(name string, unit models.WeightUnit, weight float64, amrapReps int) *models.User {
+	userProgram := &models.UserProgram{
+		ID:             uuid.New(),
+		CurrentWeights: map[models.LiftName]float64{models.Squat: weight},
+		Unit:           unit,
+	}
+	return &models.User{
+		Username:       name,
+		Leaderboard:    true,
+		CurrentProgram: userProgram.ID,
+		Programs:       map[uuid.UUID]*models.UserProgram{userProgram.ID: userProgram},
+		WorkoutHistory: []models.Workout{
+			{UserProgramID: userProgram.ID, EnteredAt: summaryBase, Exercises: []models.Lift{session(models.Squat, weight, amrapReps)}},
+		},
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	alice := leaderboardUser("Alice", models.Pounds, 225, 5)
+	bob := leaderboardUser("Bob", models.Kilograms, 110, 5) // 242.5 lbs
+	carol := leaderboardUser("Carol", "", 200, 12)
+	private := leaderboardUser("Private", models.Pounds, 405, 5)
+	private.Leaderboard = false
+	idle := leaderboardUser("Idle", models.Pounds, 135, 5)
+	idle.CurrentProgram = uuid.Nil
+
+	users := []*models.User{alice, bob, carol, private, idle}
+
+	t.Run("by weight", func(t *testing.T) {
+		assert.Equal(t, []Standing{
+			{Username: "Bob", Weight: 110, Unit: models.Kilograms},
+			{Username: "Alice", Weight: 225, Unit: models.Pounds},
+			{Username: "Carol", Weight: 200, Unit: models.Pounds},
+		}, Leaderboard(users, models.Squat, ByWeight))
+	})
+
+	t.Run("by e1rm", func(t *testing.T) {
+		standings := Leaderboard(users, models.Squat, ByE1RM)
+		names := make([]string, len(standings))
+		for i, standing := range standings {
+			names[i] = standing.Username
+		}
+		// Carol's 200 x 12 (280) passes Alice but not Bob's 110 kg x 5 (about 283 lbs)
+		assert.Equal(t, []string{"Bob", "Carol", "Alice"}, names)
+	})
+
+	t.Run("lift without values", func(t *testing.T) {
+		assert.Empty(t, Leaderboard(users, models.Deadlift, ByWeight))
+	})
+}
@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+)
+
+// ProgressPoint is one session's AMRAP result for a lift
+type ProgressPoint struct {
+	Date   time.Time
+	Weight float64
+	Reps   int
+	E1RM   float64
+}
+
+// LiftProgress returns the AMRAP result of each session of a weight key in a
+// chronologically sorted history, with the estimated one-rep max for each.
+// Incomplete workouts are skipped since their AMRAP sets may not have been attempted.
+func LiftProgress(history []models.Workout, key models.LiftName) []ProgressPoint {
+	var points []ProgressPoint
+	for _, workout := range history {
+		if workout.Incomplete {
+			continue
+		}
+		for _, lift := range workout.Exercises {
+			if lift.Optional || lift.WeightKey() != key {
+				continue
+			}
+			for _, set := range lift.Sets {
+				if set.Type == models.AMRAPSet {
+					points = append(points, ProgressPoint{
+						Date:   workout.EnteredAt,
+						Weight: set.Weight,
+						Reps:   set.ActualReps,
+						E1RM:   records.EstimateOneRepMax(set.Weight, set.ActualReps),
+					})
+					break
+				}
+			}
+		}
+	}
+	return points
+}
@@ -0,0 +1,28 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiftProgress(t *testing.T) {
+	ssb := session(models.Squat, 120, 7)
+	ssb.Variant = "SSB"
+	history := []models.Workout{
+		{EnteredAt: summaryBase, Exercises: []models.Lift{session(models.Squat, 135, 8), session(models.OverheadPress, 95, 5)}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 2), Exercises: []models.Lift{ssb}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 4), Exercises: []models.Lift{session(models.Squat, 140, 1)}},
+		// Abandoned before the AMRAP set
+		{EnteredAt: summaryBase.AddDate(0, 0, 5), Exercises: []models.Lift{session(models.Squat, 140, 0)}, Incomplete: true},
+	}
+
+	assert.Equal(t, []ProgressPoint{
+		{Date: summaryBase, Weight: 135, Reps: 8, E1RM: 171},
+		{Date: summaryBase.AddDate(0, 0, 4), Weight: 140, Reps: 1, E1RM: 140},
+	}, LiftProgress(history, models.Squat))
+
+	assert.Len(t, LiftProgress(history, "Squat:SSB"), 1)
+	assert.Empty(t, LiftProgress(history, models.Deadlift))
+}
@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Scope narrows a history to recent workouts and a set of weight keys, such as
+// those of the active program. Zero values leave that dimension unrestricted.
+type Scope struct {
+	// Since excludes workouts entered before it
+	Since time.Time
+
+	// Lifts excludes exercises whose weight key isn't listed
+	Lifts []models.LiftName
+}
+
+// Apply returns the workouts in the scope, with out-of-scope exercises removed
+// and workouts left without exercises dropped. narrowed reports whether
+// anything was left out.
+func (s Scope) Apply(history []models.Workout) (scoped []models.Workout, narrowed bool) {
+	scoped = []models.Workout{}
+	for _, workout := range history {
+		if !s.Since.IsZero() && workout.EnteredAt.Before(s.Since) {
+			narrowed = true
+			continue
+		}
+
+		if s.Lifts != nil {
+			exercises := make([]models.Lift, 0, len(workout.Exercises))
+			for _, lift := range workout.Exercises {
+				if slices.Contains(s.Lifts, lift.WeightKey()) {
+					exercises = append(exercises, lift)
+				}
+			}
+			if len(exercises) < len(workout.Exercises) {
+				narrowed = true
+				workout.Exercises = exercises
+			}
+			if len(exercises) == 0 {
+				continue
+			}
+		}
+
+		scoped = append(scoped, workout)
+	}
+	return scoped, narrowed
+}
@@ -0,0 +1,46 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope_Apply(t *testing.T) {
+	chin := session("Chinup", 0, 8)
+	history := []models.Workout{
+		{EnteredAt: summaryBase, Exercises: []models.Lift{session(models.Squat, 135, 8)}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 2), Exercises: []models.Lift{session(models.Squat, 140, 6), chin}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 4), Exercises: []models.Lift{chin}},
+	}
+
+	t.Run("unrestricted", func(t *testing.T) {
+		scoped, narrowed := Scope{}.Apply(history)
+		assert.Equal(t, history, scoped)
+		assert.False(t, narrowed)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		scoped, narrowed := Scope{Since: summaryBase.AddDate(0, 0, 1)}.Apply(history)
+		assert.Equal(t, history[1:], scoped)
+		assert.True(t, narrowed)
+	})
+
+	t.Run("lifts", func(t *testing.T) {
+		scoped, narrowed := Scope{Lifts: []models.LiftName{models.Squat}}.Apply(history)
+		assert.True(t, narrowed)
+		if assert.Len(t, scoped, 2) {
+			assert.Len(t, scoped[1].Exercises, 1)
+			assert.Equal(t, models.Squat, scoped[1].Exercises[0].LiftName)
+		}
+		// The original history is left intact
+		assert.Len(t, history[1].Exercises, 2)
+	})
+
+	t.Run("nothing left out", func(t *testing.T) {
+		scoped, narrowed := Scope{Since: summaryBase, Lifts: []models.LiftName{models.Squat, "Chinup"}}.Apply(history)
+		assert.Equal(t, history, scoped)
+		assert.False(t, narrowed)
+	})
+}
@@ -0,0 +1,43 @@
+package analytics
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// StallThreshold is the number of deloads in a row, without getting past the
+// weight deloaded from, after which a lift is considered stalled
+const StallThreshold = 3
+
+// Stall is a lift on a deload streak
+type Stall struct {
+	Lift    models.LiftName `json:"lift"`
+	Deloads int             `json:"deloads"`
+	Weight  float64         `json:"weight"` // The heaviest weight deloaded from, which the lift hasn't got past since
+	Current float64         `json:"current"`
+}
+
+// Stalled reports whether the lift has deloaded StallThreshold times or more
+func (s Stall) Stalled() bool {
+	return s.Deloads >= StallThreshold
+}
+
+// Stalls returns each of a UserProgram's lifts on a deload streak, most
+// deloads first and then by lift name
+func Stalls(userProgram *models.UserProgram) []Stall {
+	var stalls []Stall
+	for lift, streak := range userProgram.DeloadStreaks {
+		stalls = append(stalls, Stall{
+			Lift:    lift,
+			Deloads: streak.Deloads,
+			Weight:  streak.Weight,
+			Current: userProgram.CurrentWeights[lift],
+		})
+	}
+	slices.SortFunc(stalls, func(a, b Stall) int {
+		return cmp.Or(cmp.Compare(b.Deloads, a.Deloads), cmp.Compare(a.Lift, b.Lift))
+	})
+	return stalls
+}
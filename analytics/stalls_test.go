@@ -0,0 +1,30 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStalls(t *testing.T) {
+	userProgram := &models.UserProgram{
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 180, models.BenchPress: 90, models.Deadlift: 200},
+		DeloadStreaks: map[models.LiftName]models.DeloadStreak{
+			models.Squat:      {Deloads: 1, Weight: 200},
+			models.BenchPress: {Deloads: 3, Weight: 100},
+			models.Deadlift:   {Deloads: 1, Weight: 225},
+		},
+	}
+
+	stalls := Stalls(userProgram)
+
+	assert.Equal(t, []Stall{
+		{Lift: models.BenchPress, Deloads: 3, Weight: 100, Current: 90},
+		{Lift: models.Deadlift, Deloads: 1, Weight: 225, Current: 200},
+		{Lift: models.Squat, Deloads: 1, Weight: 200, Current: 180},
+	}, stalls)
+	assert.True(t, stalls[0].Stalled())
+	assert.False(t, stalls[1].Stalled())
+	assert.Empty(t, Stalls(&models.UserProgram{}))
+}
@@ -0,0 +1,118 @@
+// Package analytics aggregates workout history into training statistics.
+// Functions take chronologically sorted workouts, as returned by User.History.
+package analytics
+
+import (
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// LiftSummary aggregates one weight key's training across a history
+type LiftSummary struct {
+	Lift models.LiftName `json:"lift"`
+
+	// Sessions is the number of workouts that included the lift
+	Sessions int `json:"sessions"`
+
+	// StartingWeight and LatestWeight are the weights of the first and last AMRAP sets
+	StartingWeight float64 `json:"starting_weight"`
+	LatestWeight   float64 `json:"latest_weight"`
+
+	// Tonnage is the total weight moved (weight × reps) across all sets, warmups included
+	Tonnage float64 `json:"tonnage"`
+
+	// Deloads counts AMRAP sets lighter than the one before
+	Deloads int `json:"deloads"`
+
+	// AverageAMRAPReps is the mean of the reps completed in AMRAP sets
+	AverageAMRAPReps float64 `json:"average_amrap_reps"`
+
+	amrapSets  int
+	amrapTotal int
+}
+
+// Summary aggregates a whole history
+type Summary struct {
+	Workouts        int                              `json:"workouts"`
+	First           time.Time                        `json:"first"`
+	Last            time.Time                        `json:"last"`
+	WorkoutsPerWeek float64                          `json:"workouts_per_week"`
+	Tonnage         float64                          `json:"tonnage"`
+	Lifts           map[models.LiftName]*LiftSummary `json:"lifts"`
+}
+
+// Summarize aggregates a chronologically sorted history. Optional accessories
+// aren't weight-tracked, so are left out of the lift summaries.
+func Summarize(history []models.Workout) *Summary {
+	summary := &Summary{
+		Workouts: len(history),
+		Lifts:    make(map[models.LiftName]*LiftSummary),
+	}
+	if len(history) == 0 {
+		return summary
+	}
+
+	summary.First = history[0].EnteredAt
+	summary.Last = history[len(history)-1].EnteredAt
+	summary.WorkoutsPerWeek = WorkoutsPerWeek(len(history), summary.First, summary.Last)
+
+	for _, workout := range history {
+		for _, lift := range workout.Exercises {
+			if lift.Optional {
+				continue
+			}
+			key := lift.WeightKey()
+			ls, exists := summary.Lifts[key]
+			if !exists {
+				ls = &LiftSummary{Lift: key}
+				summary.Lifts[key] = ls
+			}
+			ls.addSession(&lift)
+		}
+	}
+
+	for _, ls := range summary.Lifts {
+		if ls.amrapSets > 0 {
+			ls.AverageAMRAPReps = float64(ls.amrapTotal) / float64(ls.amrapSets)
+		}
+		summary.Tonnage += ls.Tonnage
+	}
+
+	return summary
+}
+
+// addSession folds one session of the lift into the summary
+func (ls *LiftSummary) addSession(lift *models.Lift) {
+	ls.Sessions++
+	for _, set := range lift.Sets {
+		ls.Tonnage += Tonnage(set)
+		if set.Type != models.AMRAPSet {
+			continue
+		}
+
+		ls.amrapSets++
+		ls.amrapTotal += set.ActualReps
+		if ls.amrapSets == 1 {
+			ls.StartingWeight = set.Weight
+		} else if set.Weight < ls.LatestWeight {
+			ls.Deloads++
+		}
+		ls.LatestWeight = set.Weight
+	}
+}
+
+// Tonnage returns the weight moved in a set
+func Tonnage(set models.Set) float64 {
+	return set.Weight * float64(set.ActualReps)
+}
+
+// WorkoutsPerWeek returns the average training frequency between the first and last
+// workout. Spans shorter than a week count as one week.
+func WorkoutsPerWeek(workouts int, first, last time.Time) float64 {
+	weeks := last.Sub(first).Hours()/24/7 + 1.0/7
+	if weeks < 1 {
+		weeks = 1
+	}
+	return float64(workouts) / weeks
+}
@@ -0,0 +1,84 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var summaryBase = time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+
+func session(name models.LiftName, weight float64, amrapReps int) models.Lift {
+	return models.Lift{LiftName: name, Sets: []models.Set{
+		{Weight: 45, TargetReps: 5, ActualReps: 5, Type: models.WarmupSet},
+		{Weight: weight, TargetReps: 5, ActualReps: 5, Type: models.WorkingSet},
+		{Weight: weight, TargetReps: 5, ActualReps: amrapReps, Type: models.AMRAPSet},
+	}}
+}
+
+func TestSummarize(t *testing.T) {
+	history := []models.Workout{
+		{EnteredAt: summaryBase, Exercises: []models.Lift{session(models.Squat, 135, 8), session(models.OverheadPress, 95, 4)}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 2), Exercises: []models.Lift{session(models.Squat, 140, 6)}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 4), Exercises: []models.Lift{session(models.Squat, 125, 10), session(models.OverheadPress, 85, 7)}},
+	}
+
+	summary := Summarize(history)
+
+	assert.Equal(t, 3, summary.Workouts)
+	assert.Equal(t, summaryBase, summary.First)
+	assert.Equal(t, summaryBase.AddDate(0, 0, 4), summary.Last)
+	assert.Equal(t, 3.0, summary.WorkoutsPerWeek)
+	require.Len(t, summary.Lifts, 2)
+
+	squat := summary.Lifts[models.Squat]
+	assert.Equal(t, 3, squat.Sessions)
+	assert.Equal(t, 135.0, squat.StartingWeight)
+	assert.Equal(t, 125.0, squat.LatestWeight)
+	assert.Equal(t, 1, squat.Deloads)
+	assert.Equal(t, 8.0, squat.AverageAMRAPReps)
+	// 3 warmups at 45x5, plus 135x13, 140x11, and 125x15
+	assert.Equal(t, 675.0+1755+1540+1875, squat.Tonnage)
+
+	ohp := summary.Lifts[models.OverheadPress]
+	assert.Equal(t, 2, ohp.Sessions)
+	assert.Equal(t, 5.5, ohp.AverageAMRAPReps)
+
+	assert.Equal(t, squat.Tonnage+ohp.Tonnage, summary.Tonnage)
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	summary := Summarize(nil)
+
+	assert.Zero(t, summary.Workouts)
+	assert.Empty(t, summary.Lifts)
+	assert.True(t, summary.First.IsZero())
+}
+
+func TestSummarize_VariantsAndNoAMRAP(t *testing.T) {
+	ssb := session(models.Squat, 120, 7)
+	ssb.Variant = "SSB"
+	history := []models.Workout{
+		{EnteredAt: summaryBase, Exercises: []models.Lift{
+			{LiftName: models.Deadlift, Sets: []models.Set{{Weight: 185, ActualReps: 5, Type: models.WorkingSet}}},
+			ssb,
+		}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 2), Exercises: []models.Lift{session(models.Deadlift, 195, 5)}},
+	}
+
+	summary := Summarize(history)
+
+	assert.Equal(t, 120.0, summary.Lifts["Squat:SSB"].StartingWeight)
+	deadlift := summary.Lifts[models.Deadlift]
+	assert.Equal(t, 195.0, deadlift.StartingWeight)
+	assert.Equal(t, 5.0, deadlift.AverageAMRAPReps)
+	assert.Zero(t, deadlift.Deloads)
+}
+
+func TestWorkoutsPerWeek(t *testing.T) {
+	assert.Equal(t, 1.0, WorkoutsPerWeek(1, summaryBase, summaryBase))
+	assert.Equal(t, 3.0, WorkoutsPerWeek(6, summaryBase, summaryBase.AddDate(0, 0, 13)))
+}
@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// LiftVolume is the volume of one weight key's training
+type LiftVolume struct {
+	// Tonnage is the weight moved (weight × reps) across all sets, warmups included
+	Tonnage float64 `json:"tonnage"`
+
+	// Sets counts the working and AMRAP sets performed
+	Sets int `json:"sets"`
+}
+
+// WeekVolume is the volume of a week's training, in total and by weight key
+type WeekVolume struct {
+	// Start is midnight on the Monday the week begins
+	Start    time.Time                       `json:"start"`
+	Workouts int                             `json:"workouts"`
+	Total    LiftVolume                      `json:"total"`
+	Lifts    map[models.LiftName]*LiftVolume `json:"lifts"`
+}
+
+// WeeklyVolume returns the volume of each of the last weeks weeks, oldest
+// first, ending with the week containing now. Weeks without workouts are
+// included with no volume, so gaps show. As in Summarize, optional accessories
+// are left out.
+func WeeklyVolume(history []models.Workout, weeks int, now time.Time) []WeekVolume {
+	if weeks <= 0 {
+		return []WeekVolume{}
+	}
+
+	volume := make([]WeekVolume, weeks)
+	first := WeekStart(now).AddDate(0, 0, -7*(weeks-1))
+	for i := range volume {
+		volume[i] = WeekVolume{Start: first.AddDate(0, 0, 7*i), Lifts: make(map[models.LiftName]*LiftVolume)}
+	}
+
+	for _, workout := range history {
+		entered := workout.EnteredAt.In(now.Location())
+		if entered.Before(first) || entered.After(now) {
+			continue
+		}
+		i := len(volume) - 1
+		for entered.Before(volume[i].Start) {
+			i--
+		}
+		week := &volume[i]
+		week.Workouts++
+
+		for _, lift := range workout.Exercises {
+			if lift.Optional {
+				continue
+			}
+			key := lift.WeightKey()
+			lv, exists := week.Lifts[key]
+			if !exists {
+				lv = &LiftVolume{}
+				week.Lifts[key] = lv
+			}
+			for _, set := range lift.Sets {
+				lv.Tonnage += Tonnage(set)
+				if set.Type == models.WorkingSet || set.Type == models.AMRAPSet {
+					lv.Sets++
+				}
+			}
+		}
+	}
+
+	for i := range volume {
+		for _, lv := range volume[i].Lifts {
+			volume[i].Total.Tonnage += lv.Tonnage
+			volume[i].Total.Sets += lv.Sets
+		}
+	}
+	return volume
+}
+
+// WeekStart returns midnight on the Monday of the week containing t, in t's location
+func WeekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,43 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyVolume(t *testing.T) {
+	accessory := models.Lift{LiftName: "ChinUp", Optional: true, Sets: []models.Set{{ActualReps: 8, Type: models.AMRAPSet}}}
+	history := []models.Workout{
+		{EnteredAt: summaryBase.AddDate(0, 0, -14), Exercises: []models.Lift{session(models.Squat, 125, 5)}},
+		{EnteredAt: summaryBase, Exercises: []models.Lift{session(models.Squat, 135, 8), session(models.OverheadPress, 95, 4)}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 2), Exercises: []models.Lift{session(models.Squat, 140, 6), accessory}},
+		{EnteredAt: summaryBase.AddDate(0, 0, 8), Exercises: []models.Lift{session(models.Squat, 145, 5)}},
+	}
+
+	volume := WeeklyVolume(history, 3, summaryBase.AddDate(0, 0, 9))
+	require.Len(t, volume, 3)
+
+	assert.Equal(t, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), volume[0].Start)
+	assert.Zero(t, volume[0].Workouts)
+	assert.Empty(t, volume[0].Lifts)
+
+	assert.Equal(t, summaryBase.Truncate(24*time.Hour), volume[1].Start)
+	assert.Equal(t, 2, volume[1].Workouts)
+	require.Len(t, volume[1].Lifts, 2)
+	assert.Equal(t, LiftVolume{Tonnage: 1980 + 1765, Sets: 4}, *volume[1].Lifts[models.Squat])
+	assert.Equal(t, LiftVolume{Tonnage: 1080, Sets: 2}, *volume[1].Lifts[models.OverheadPress])
+	assert.Equal(t, LiftVolume{Tonnage: 4825, Sets: 6}, volume[1].Total)
+
+	assert.Equal(t, 1, volume[2].Workouts)
+	assert.Equal(t, LiftVolume{Tonnage: 1675, Sets: 2}, volume[2].Total)
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), WeekStart(sunday))
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), WeekStart(sunday.Add(time.Hour)))
+}
@@ -0,0 +1,5 @@
+// Package greyskullv1 holds the Go code generated from greyskull.proto: the
+// API messages and the GreyskullService client and server
+package greyskullv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative greyskull.proto
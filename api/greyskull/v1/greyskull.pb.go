@@ -0,0 +1,3047 @@
+// Protocol buffer schema for greyskull's data and the API a companion app, such
+// as a mobile client, uses to work with the same core logic as the CLI.
+//
+// Messages mirror the models package and the JSON files written to the data
+// directory: IDs are UUID strings, weights are in the unit of the program they
+// belong to, and lift names are the models.LiftName values, e.g. "Squat" or
+// "BenchPress".
+//
+// 'greyskull serve' runs GreyskullService. After changing this file, regenerate
+// the Go code with 'go generate ./api/...', which needs protoc, protoc-gen-go,
+// and protoc-gen-go-grpc.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/greyskull/v1/greyskull.proto
+
+package greyskullv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WeightUnit int32
+
+const (
+	WeightUnit_WEIGHT_UNIT_UNSPECIFIED WeightUnit = 0 // Pounds, as in files written before units were stored
+	WeightUnit_WEIGHT_UNIT_POUNDS      WeightUnit = 1
+	WeightUnit_WEIGHT_UNIT_KILOGRAMS   WeightUnit = 2
+)
+
+// Enum value maps for WeightUnit.
+var (
+	WeightUnit_name = map[int32]string{
+		0: "WEIGHT_UNIT_UNSPECIFIED",
+		1: "WEIGHT_UNIT_POUNDS",
+		2: "WEIGHT_UNIT_KILOGRAMS",
+	}
+	WeightUnit_value = map[string]int32{
+		"WEIGHT_UNIT_UNSPECIFIED": 0,
+		"WEIGHT_UNIT_POUNDS":      1,
+		"WEIGHT_UNIT_KILOGRAMS":   2,
+	}
+)
+
+func (x WeightUnit) Enum() *WeightUnit {
+	p := new(WeightUnit)
+	*p = x
+	return p
+}
+
+func (x WeightUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeightUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_greyskull_v1_greyskull_proto_enumTypes[0].Descriptor()
+}
+
+func (WeightUnit) Type() protoreflect.EnumType {
+	return &file_api_greyskull_v1_greyskull_proto_enumTypes[0]
+}
+
+func (x WeightUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeightUnit.Descriptor instead.
+func (WeightUnit) EnumDescriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{0}
+}
+
+type SetType int32
+
+const (
+	SetType_SET_TYPE_UNSPECIFIED SetType = 0
+	SetType_SET_TYPE_WARMUP      SetType = 1
+	SetType_SET_TYPE_WORKING     SetType = 2
+	SetType_SET_TYPE_AMRAP       SetType = 3
+	SetType_SET_TYPE_FEELER      SetType = 4
+)
+
+// Enum value maps for SetType.
+var (
+	SetType_name = map[int32]string{
+		0: "SET_TYPE_UNSPECIFIED",
+		1: "SET_TYPE_WARMUP",
+		2: "SET_TYPE_WORKING",
+		3: "SET_TYPE_AMRAP",
+		4: "SET_TYPE_FEELER",
+	}
+	SetType_value = map[string]int32{
+		"SET_TYPE_UNSPECIFIED": 0,
+		"SET_TYPE_WARMUP":      1,
+		"SET_TYPE_WORKING":     2,
+		"SET_TYPE_AMRAP":       3,
+		"SET_TYPE_FEELER":      4,
+	}
+)
+
+func (x SetType) Enum() *SetType {
+	p := new(SetType)
+	*p = x
+	return p
+}
+
+func (x SetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_greyskull_v1_greyskull_proto_enumTypes[1].Descriptor()
+}
+
+func (SetType) Type() protoreflect.EnumType {
+	return &file_api_greyskull_v1_greyskull_proto_enumTypes[1]
+}
+
+func (x SetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SetType.Descriptor instead.
+func (SetType) EnumDescriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{1}
+}
+
+type User struct {
+	state             protoimpl.MessageState        `protogen:"open.v1"`
+	Id                string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username          string                        `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CurrentProgram    string                        `protobuf:"bytes,3,opt,name=current_program,json=currentProgram,proto3" json:"current_program,omitempty"`                                         // ID of the current UserProgram; empty if none
+	Programs          map[string]*UserProgram       `protobuf:"bytes,4,rep,name=programs,proto3" json:"programs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by UserProgram ID
+	WorkoutHistory    []*Workout                    `protobuf:"bytes,5,rep,name=workout_history,json=workoutHistory,proto3" json:"workout_history,omitempty"`
+	SkippedDays       []*SkippedDay                 `protobuf:"bytes,6,rep,name=skipped_days,json=skippedDays,proto3" json:"skipped_days,omitempty"`
+	CreatedAt         *timestamppb.Timestamp        `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RestTimes         *RestTimes                    `protobuf:"bytes,8,opt,name=rest_times,json=restTimes,proto3" json:"rest_times,omitempty"`                                                                                                    // Overrides the program's rest times
+	Unit              WeightUnit                    `protobuf:"varint,9,opt,name=unit,proto3,enum=greyskull.v1.WeightUnit" json:"unit,omitempty"`                                                                                                 // Unit for newly started programs
+	Leaderboard       bool                          `protobuf:"varint,10,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`                                                                                                               // Opted in to the shared leaderboard
+	WarmupPercentages map[string]*WarmupPercentages `protobuf:"bytes,11,rep,name=warmup_percentages,json=warmupPercentages,proto3" json:"warmup_percentages,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by lift name
+	ActivePrograms    []string                      `protobuf:"bytes,12,rep,name=active_programs,json=activePrograms,proto3" json:"active_programs,omitempty"`                                                                                    // IDs of programs trained alongside the current one
+	WeightResets      []*WeightReset                `protobuf:"bytes,13,rep,name=weight_resets,json=weightResets,proto3" json:"weight_resets,omitempty"`
+	Milestones        []*UnlockedMilestone          `protobuf:"bytes,14,rep,name=milestones,proto3" json:"milestones,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetCurrentProgram() string {
+	if x != nil {
+		return x.CurrentProgram
+	}
+	return ""
+}
+
+func (x *User) GetPrograms() map[string]*UserProgram {
+	if x != nil {
+		return x.Programs
+	}
+	return nil
+}
+
+func (x *User) GetWorkoutHistory() []*Workout {
+	if x != nil {
+		return x.WorkoutHistory
+	}
+	return nil
+}
+
+func (x *User) GetSkippedDays() []*SkippedDay {
+	if x != nil {
+		return x.SkippedDays
+	}
+	return nil
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetRestTimes() *RestTimes {
+	if x != nil {
+		return x.RestTimes
+	}
+	return nil
+}
+
+func (x *User) GetUnit() WeightUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *User) GetLeaderboard() bool {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return false
+}
+
+func (x *User) GetWarmupPercentages() map[string]*WarmupPercentages {
+	if x != nil {
+		return x.WarmupPercentages
+	}
+	return nil
+}
+
+func (x *User) GetActivePrograms() []string {
+	if x != nil {
+		return x.ActivePrograms
+	}
+	return nil
+}
+
+func (x *User) GetWeightResets() []*WeightReset {
+	if x != nil {
+		return x.WeightResets
+	}
+	return nil
+}
+
+func (x *User) GetMilestones() []*UnlockedMilestone {
+	if x != nil {
+		return x.Milestones
+	}
+	return nil
+}
+
+type UnlockedMilestone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UnlockedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=unlocked_at,json=unlockedAt,proto3" json:"unlocked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockedMilestone) Reset() {
+	*x = UnlockedMilestone{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockedMilestone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockedMilestone) ProtoMessage() {}
+
+func (x *UnlockedMilestone) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockedMilestone.ProtoReflect.Descriptor instead.
+func (*UnlockedMilestone) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UnlockedMilestone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnlockedMilestone) GetUnlockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnlockedAt
+	}
+	return nil
+}
+
+// UserProgram is a user's run of a program template
+type UserProgram struct {
+	state            protoimpl.MessageState            `protogen:"open.v1"`
+	Id               string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                            `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProgramId        string                            `protobuf:"bytes,3,opt,name=program_id,json=programId,proto3" json:"program_id,omitempty"`
+	StartingWeights  map[string]float64                `protobuf:"bytes,4,rep,name=starting_weights,json=startingWeights,proto3" json:"starting_weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	CurrentWeights   map[string]float64                `protobuf:"bytes,5,rep,name=current_weights,json=currentWeights,proto3" json:"current_weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	CurrentDay       int32                             `protobuf:"varint,6,opt,name=current_day,json=currentDay,proto3" json:"current_day,omitempty"`
+	StartedAt        *timestamppb.Timestamp            `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Holds            map[string]int32                  `protobuf:"bytes,8,rep,name=holds,proto3" json:"holds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Remaining sessions each lift's weight is held constant
+	Deload           *DeloadPlan                       `protobuf:"bytes,9,opt,name=deload,proto3" json:"deload,omitempty"`
+	Unit             WeightUnit                        `protobuf:"varint,10,opt,name=unit,proto3,enum=greyskull.v1.WeightUnit" json:"unit,omitempty"`
+	RepTargets       map[string]int32                  `protobuf:"bytes,11,rep,name=rep_targets,json=repTargets,proto3" json:"rep_targets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Goals            map[string]float64                `protobuf:"bytes,12,rep,name=goals,proto3" json:"goals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	DeloadStreaks    map[string]*DeloadStreak          `protobuf:"bytes,13,rep,name=deload_streaks,json=deloadStreaks,proto3" json:"deload_streaks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TrainingDays     []int32                           `protobuf:"varint,14,rep,packed,name=training_days,json=trainingDays,proto3" json:"training_days,omitempty"` // Weekdays, 0 for Sunday
+	GoalDeadlines    map[string]*timestamppb.Timestamp `protobuf:"bytes,15,rep,name=goal_deadlines,json=goalDeadlines,proto3" json:"goal_deadlines,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TrainingMaxes    map[string]float64                `protobuf:"bytes,16,rep,name=training_maxes,json=trainingMaxes,proto3" json:"training_maxes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Pauses           []*Pause                          `protobuf:"bytes,17,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	RoundingSteps    map[string]float64                `protobuf:"bytes,18,rep,name=rounding_steps,json=roundingSteps,proto3" json:"rounding_steps,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	ProgressionRules *ProgressionRules                 `protobuf:"bytes,19,opt,name=progression_rules,json=progressionRules,proto3" json:"progression_rules,omitempty"` // Overrides the program's rules
+	Program          *Program                          `protobuf:"bytes,20,opt,name=program,proto3" json:"program,omitempty"`                                           // The template as it was when started or last upgraded
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UserProgram) Reset() {
+	*x = UserProgram{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProgram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProgram) ProtoMessage() {}
+
+func (x *UserProgram) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProgram.ProtoReflect.Descriptor instead.
+func (*UserProgram) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserProgram) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserProgram) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserProgram) GetProgramId() string {
+	if x != nil {
+		return x.ProgramId
+	}
+	return ""
+}
+
+func (x *UserProgram) GetStartingWeights() map[string]float64 {
+	if x != nil {
+		return x.StartingWeights
+	}
+	return nil
+}
+
+func (x *UserProgram) GetCurrentWeights() map[string]float64 {
+	if x != nil {
+		return x.CurrentWeights
+	}
+	return nil
+}
+
+func (x *UserProgram) GetCurrentDay() int32 {
+	if x != nil {
+		return x.CurrentDay
+	}
+	return 0
+}
+
+func (x *UserProgram) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *UserProgram) GetHolds() map[string]int32 {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+func (x *UserProgram) GetDeload() *DeloadPlan {
+	if x != nil {
+		return x.Deload
+	}
+	return nil
+}
+
+func (x *UserProgram) GetUnit() WeightUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *UserProgram) GetRepTargets() map[string]int32 {
+	if x != nil {
+		return x.RepTargets
+	}
+	return nil
+}
+
+func (x *UserProgram) GetGoals() map[string]float64 {
+	if x != nil {
+		return x.Goals
+	}
+	return nil
+}
+
+func (x *UserProgram) GetDeloadStreaks() map[string]*DeloadStreak {
+	if x != nil {
+		return x.DeloadStreaks
+	}
+	return nil
+}
+
+func (x *UserProgram) GetTrainingDays() []int32 {
+	if x != nil {
+		return x.TrainingDays
+	}
+	return nil
+}
+
+func (x *UserProgram) GetGoalDeadlines() map[string]*timestamppb.Timestamp {
+	if x != nil {
+		return x.GoalDeadlines
+	}
+	return nil
+}
+
+func (x *UserProgram) GetTrainingMaxes() map[string]float64 {
+	if x != nil {
+		return x.TrainingMaxes
+	}
+	return nil
+}
+
+func (x *UserProgram) GetPauses() []*Pause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *UserProgram) GetRoundingSteps() map[string]float64 {
+	if x != nil {
+		return x.RoundingSteps
+	}
+	return nil
+}
+
+func (x *UserProgram) GetProgressionRules() *ProgressionRules {
+	if x != nil {
+		return x.ProgressionRules
+	}
+	return nil
+}
+
+func (x *UserProgram) GetProgram() *Program {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
+type DeloadPlan struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Percentage        float64                `protobuf:"fixed64,1,opt,name=percentage,proto3" json:"percentage,omitempty"` // Fraction of each lift's current weight, e.g. 0.8
+	Sets              int32                  `protobuf:"varint,2,opt,name=sets,proto3" json:"sets,omitempty"`
+	Reps              int32                  `protobuf:"varint,3,opt,name=reps,proto3" json:"reps,omitempty"`
+	SessionsRemaining int32                  `protobuf:"varint,4,opt,name=sessions_remaining,json=sessionsRemaining,proto3" json:"sessions_remaining,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeloadPlan) Reset() {
+	*x = DeloadPlan{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeloadPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeloadPlan) ProtoMessage() {}
+
+func (x *DeloadPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeloadPlan.ProtoReflect.Descriptor instead.
+func (*DeloadPlan) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeloadPlan) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *DeloadPlan) GetSets() int32 {
+	if x != nil {
+		return x.Sets
+	}
+	return 0
+}
+
+func (x *DeloadPlan) GetReps() int32 {
+	if x != nil {
+		return x.Reps
+	}
+	return 0
+}
+
+func (x *DeloadPlan) GetSessionsRemaining() int32 {
+	if x != nil {
+		return x.SessionsRemaining
+	}
+	return 0
+}
+
+type DeloadStreak struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deloads       int32                  `protobuf:"varint,1,opt,name=deloads,proto3" json:"deloads,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"` // The heaviest weight deloaded from during the streak
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeloadStreak) Reset() {
+	*x = DeloadStreak{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeloadStreak) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeloadStreak) ProtoMessage() {}
+
+func (x *DeloadStreak) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeloadStreak.ProtoReflect.Descriptor instead.
+func (*DeloadStreak) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeloadStreak) GetDeloads() int32 {
+	if x != nil {
+		return x.Deloads
+	}
+	return 0
+}
+
+func (x *DeloadStreak) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Pause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"` // Unset while the pause is open
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pause) Reset() {
+	*x = Pause{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pause) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Pause) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Pause) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+type Workout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserProgramId string                 `protobuf:"bytes,2,opt,name=user_program_id,json=userProgramId,proto3" json:"user_program_id,omitempty"`
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	Exercises     []*Lift                `protobuf:"bytes,4,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	EnteredAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=entered_at,json=enteredAt,proto3" json:"entered_at,omitempty"`
+	Quick         bool                   `protobuf:"varint,6,opt,name=quick,proto3" json:"quick,omitempty"`           // Warmups were trimmed to save time
+	Incomplete    bool                   `protobuf:"varint,7,opt,name=incomplete,proto3" json:"incomplete,omitempty"` // Abandoned partway; the day is repeated
+	Notes         string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	AdHoc         bool                   `protobuf:"varint,9,opt,name=ad_hoc,json=adHoc,proto3" json:"ad_hoc,omitempty"` // Logged outside any program
+	BodyWeight    float64                `protobuf:"fixed64,10,opt,name=body_weight,json=bodyWeight,proto3" json:"body_weight,omitempty"`
+	Held          []string               `protobuf:"bytes,11,rep,name=held,proto3" json:"held,omitempty"` // Lifts whose weight was held constant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workout) Reset() {
+	*x = Workout{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workout) ProtoMessage() {}
+
+func (x *Workout) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workout.ProtoReflect.Descriptor instead.
+func (*Workout) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Workout) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Workout) GetUserProgramId() string {
+	if x != nil {
+		return x.UserProgramId
+	}
+	return ""
+}
+
+func (x *Workout) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Workout) GetExercises() []*Lift {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *Workout) GetEnteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnteredAt
+	}
+	return nil
+}
+
+func (x *Workout) GetQuick() bool {
+	if x != nil {
+		return x.Quick
+	}
+	return false
+}
+
+func (x *Workout) GetIncomplete() bool {
+	if x != nil {
+		return x.Incomplete
+	}
+	return false
+}
+
+func (x *Workout) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Workout) GetAdHoc() bool {
+	if x != nil {
+		return x.AdHoc
+	}
+	return false
+}
+
+func (x *Workout) GetBodyWeight() float64 {
+	if x != nil {
+		return x.BodyWeight
+	}
+	return 0
+}
+
+func (x *Workout) GetHeld() []string {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+type SkippedDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserProgramId string                 `protobuf:"bytes,2,opt,name=user_program_id,json=userProgramId,proto3" json:"user_program_id,omitempty"`
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	SkippedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=skipped_at,json=skippedAt,proto3" json:"skipped_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkippedDay) Reset() {
+	*x = SkippedDay{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkippedDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkippedDay) ProtoMessage() {}
+
+func (x *SkippedDay) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkippedDay.ProtoReflect.Descriptor instead.
+func (*SkippedDay) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SkippedDay) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SkippedDay) GetUserProgramId() string {
+	if x != nil {
+		return x.UserProgramId
+	}
+	return ""
+}
+
+func (x *SkippedDay) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *SkippedDay) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SkippedDay) GetSkippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SkippedAt
+	}
+	return nil
+}
+
+type WeightReset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserProgramId string                 `protobuf:"bytes,2,opt,name=user_program_id,json=userProgramId,proto3" json:"user_program_id,omitempty"`
+	ResetAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reset_at,json=resetAt,proto3" json:"reset_at,omitempty"`
+	DaysOff       int32                  `protobuf:"varint,4,opt,name=days_off,json=daysOff,proto3" json:"days_off,omitempty"`
+	Previous      map[string]float64     `protobuf:"bytes,5,rep,name=previous,proto3" json:"previous,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Weights       map[string]float64     `protobuf:"bytes,6,rep,name=weights,proto3" json:"weights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeightReset) Reset() {
+	*x = WeightReset{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeightReset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeightReset) ProtoMessage() {}
+
+func (x *WeightReset) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeightReset.ProtoReflect.Descriptor instead.
+func (*WeightReset) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WeightReset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WeightReset) GetUserProgramId() string {
+	if x != nil {
+		return x.UserProgramId
+	}
+	return ""
+}
+
+func (x *WeightReset) GetResetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetAt
+	}
+	return nil
+}
+
+func (x *WeightReset) GetDaysOff() int32 {
+	if x != nil {
+		return x.DaysOff
+	}
+	return 0
+}
+
+func (x *WeightReset) GetPrevious() map[string]float64 {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *WeightReset) GetWeights() map[string]float64 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type Lift struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LiftName       string                 `protobuf:"bytes,2,opt,name=lift_name,json=liftName,proto3" json:"lift_name,omitempty"`
+	Variant        string                 `protobuf:"bytes,3,opt,name=variant,proto3" json:"variant,omitempty"`
+	Optional       bool                   `protobuf:"varint,4,opt,name=optional,proto3" json:"optional,omitempty"`
+	Bodyweight     bool                   `protobuf:"varint,5,opt,name=bodyweight,proto3" json:"bodyweight,omitempty"` // Set weights are added weight, negative for assistance
+	Sets           []*Set                 `protobuf:"bytes,6,rep,name=sets,proto3" json:"sets,omitempty"`
+	FixedWeight    bool                   `protobuf:"varint,7,opt,name=fixed_weight,json=fixedWeight,proto3" json:"fixed_weight,omitempty"`
+	Group          string                 `protobuf:"bytes,8,opt,name=group,proto3" json:"group,omitempty"`                                         // Lifts sharing a group are done as a superset
+	SubstitutedFor string                 `protobuf:"bytes,9,opt,name=substituted_for,json=substitutedFor,proto3" json:"substituted_for,omitempty"` // The lift this one replaced for the session
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Lift) Reset() {
+	*x = Lift{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lift) ProtoMessage() {}
+
+func (x *Lift) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lift.ProtoReflect.Descriptor instead.
+func (*Lift) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Lift) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Lift) GetLiftName() string {
+	if x != nil {
+		return x.LiftName
+	}
+	return ""
+}
+
+func (x *Lift) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Lift) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *Lift) GetBodyweight() bool {
+	if x != nil {
+		return x.Bodyweight
+	}
+	return false
+}
+
+func (x *Lift) GetSets() []*Set {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
+func (x *Lift) GetFixedWeight() bool {
+	if x != nil {
+		return x.FixedWeight
+	}
+	return false
+}
+
+func (x *Lift) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Lift) GetSubstitutedFor() string {
+	if x != nil {
+		return x.SubstitutedFor
+	}
+	return ""
+}
+
+type Set struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	TargetReps    int32                  `protobuf:"varint,3,opt,name=target_reps,json=targetReps,proto3" json:"target_reps,omitempty"`
+	ActualReps    int32                  `protobuf:"varint,4,opt,name=actual_reps,json=actualReps,proto3" json:"actual_reps,omitempty"`
+	Type          SetType                `protobuf:"varint,5,opt,name=type,proto3,enum=greyskull.v1.SetType" json:"type,omitempty"`
+	Order         int32                  `protobuf:"varint,6,opt,name=order,proto3" json:"order,omitempty"`
+	MaxReps       int32                  `protobuf:"varint,7,opt,name=max_reps,json=maxReps,proto3" json:"max_reps,omitempty"` // Top of a rep range starting at target_reps
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Set) Reset() {
+	*x = Set{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Set) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Set) ProtoMessage() {}
+
+func (x *Set) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Set.ProtoReflect.Descriptor instead.
+func (*Set) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Set) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Set) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Set) GetTargetReps() int32 {
+	if x != nil {
+		return x.TargetReps
+	}
+	return 0
+}
+
+func (x *Set) GetActualReps() int32 {
+	if x != nil {
+		return x.ActualReps
+	}
+	return 0
+}
+
+func (x *Set) GetType() SetType {
+	if x != nil {
+		return x.Type
+	}
+	return SetType_SET_TYPE_UNSPECIFIED
+}
+
+func (x *Set) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *Set) GetMaxReps() int32 {
+	if x != nil {
+		return x.MaxReps
+	}
+	return 0
+}
+
+type RestTimes struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WarmupSeconds  int32                  `protobuf:"varint,1,opt,name=warmup_seconds,json=warmupSeconds,proto3" json:"warmup_seconds,omitempty"`
+	WorkingSeconds int32                  `protobuf:"varint,2,opt,name=working_seconds,json=workingSeconds,proto3" json:"working_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RestTimes) Reset() {
+	*x = RestTimes{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestTimes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestTimes) ProtoMessage() {}
+
+func (x *RestTimes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestTimes.ProtoReflect.Descriptor instead.
+func (*RestTimes) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestTimes) GetWarmupSeconds() int32 {
+	if x != nil {
+		return x.WarmupSeconds
+	}
+	return 0
+}
+
+func (x *RestTimes) GetWorkingSeconds() int32 {
+	if x != nil {
+		return x.WorkingSeconds
+	}
+	return 0
+}
+
+type WarmupPercentages struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percentages   []float64              `protobuf:"fixed64,1,rep,packed,name=percentages,proto3" json:"percentages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmupPercentages) Reset() {
+	*x = WarmupPercentages{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmupPercentages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmupPercentages) ProtoMessage() {}
+
+func (x *WarmupPercentages) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmupPercentages.ProtoReflect.Descriptor instead.
+func (*WarmupPercentages) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WarmupPercentages) GetPercentages() []float64 {
+	if x != nil {
+		return x.Percentages
+	}
+	return nil
+}
+
+// Program is a program template, built in or imported
+type Program struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description      string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Version          string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Workouts         []*WorkoutTemplate     `protobuf:"bytes,5,rep,name=workouts,proto3" json:"workouts,omitempty"`
+	ProgressionRules *ProgressionRules      `protobuf:"bytes,6,opt,name=progression_rules,json=progressionRules,proto3" json:"progression_rules,omitempty"`
+	RestTimes        *RestTimes             `protobuf:"bytes,7,opt,name=rest_times,json=restTimes,proto3" json:"rest_times,omitempty"`
+	WarmupScheme     *WarmupScheme          `protobuf:"bytes,8,opt,name=warmup_scheme,json=warmupScheme,proto3" json:"warmup_scheme,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Program) Reset() {
+	*x = Program{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Program) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Program) ProtoMessage() {}
+
+func (x *Program) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Program.ProtoReflect.Descriptor instead.
+func (*Program) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Program) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Program) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Program) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Program) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Program) GetWorkouts() []*WorkoutTemplate {
+	if x != nil {
+		return x.Workouts
+	}
+	return nil
+}
+
+func (x *Program) GetProgressionRules() *ProgressionRules {
+	if x != nil {
+		return x.ProgressionRules
+	}
+	return nil
+}
+
+func (x *Program) GetRestTimes() *RestTimes {
+	if x != nil {
+		return x.RestTimes
+	}
+	return nil
+}
+
+func (x *Program) GetWarmupScheme() *WarmupScheme {
+	if x != nil {
+		return x.WarmupScheme
+	}
+	return nil
+}
+
+type WorkoutTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int32                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Lifts         []*LiftTemplate        `protobuf:"bytes,2,rep,name=lifts,proto3" json:"lifts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkoutTemplate) Reset() {
+	*x = WorkoutTemplate{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkoutTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkoutTemplate) ProtoMessage() {}
+
+func (x *WorkoutTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkoutTemplate.ProtoReflect.Descriptor instead.
+func (*WorkoutTemplate) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WorkoutTemplate) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *WorkoutTemplate) GetLifts() []*LiftTemplate {
+	if x != nil {
+		return x.Lifts
+	}
+	return nil
+}
+
+type LiftTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LiftName      string                 `protobuf:"bytes,1,opt,name=lift_name,json=liftName,proto3" json:"lift_name,omitempty"`
+	Variant       string                 `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
+	WarmupSets    []*SetTemplate         `protobuf:"bytes,3,rep,name=warmup_sets,json=warmupSets,proto3" json:"warmup_sets,omitempty"`
+	WorkingSets   []*SetTemplate         `protobuf:"bytes,4,rep,name=working_sets,json=workingSets,proto3" json:"working_sets,omitempty"`
+	Feeler        *FeelerTemplate        `protobuf:"bytes,5,opt,name=feeler,proto3" json:"feeler,omitempty"`
+	Bodyweight    bool                   `protobuf:"varint,6,opt,name=bodyweight,proto3" json:"bodyweight,omitempty"`
+	Optional      bool                   `protobuf:"varint,7,opt,name=optional,proto3" json:"optional,omitempty"`
+	FixedWeight   bool                   `protobuf:"varint,8,opt,name=fixed_weight,json=fixedWeight,proto3" json:"fixed_weight,omitempty"`
+	Group         string                 `protobuf:"bytes,9,opt,name=group,proto3" json:"group,omitempty"`
+	Cues          []string               `protobuf:"bytes,10,rep,name=cues,proto3" json:"cues,omitempty"`
+	Url           string                 `protobuf:"bytes,11,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiftTemplate) Reset() {
+	*x = LiftTemplate{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiftTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiftTemplate) ProtoMessage() {}
+
+func (x *LiftTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiftTemplate.ProtoReflect.Descriptor instead.
+func (*LiftTemplate) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LiftTemplate) GetLiftName() string {
+	if x != nil {
+		return x.LiftName
+	}
+	return ""
+}
+
+func (x *LiftTemplate) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *LiftTemplate) GetWarmupSets() []*SetTemplate {
+	if x != nil {
+		return x.WarmupSets
+	}
+	return nil
+}
+
+func (x *LiftTemplate) GetWorkingSets() []*SetTemplate {
+	if x != nil {
+		return x.WorkingSets
+	}
+	return nil
+}
+
+func (x *LiftTemplate) GetFeeler() *FeelerTemplate {
+	if x != nil {
+		return x.Feeler
+	}
+	return nil
+}
+
+func (x *LiftTemplate) GetBodyweight() bool {
+	if x != nil {
+		return x.Bodyweight
+	}
+	return false
+}
+
+func (x *LiftTemplate) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *LiftTemplate) GetFixedWeight() bool {
+	if x != nil {
+		return x.FixedWeight
+	}
+	return false
+}
+
+func (x *LiftTemplate) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *LiftTemplate) GetCues() []string {
+	if x != nil {
+		return x.Cues
+	}
+	return nil
+}
+
+func (x *LiftTemplate) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type FeelerTemplate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WeightPercentage float64                `protobuf:"fixed64,1,opt,name=weight_percentage,json=weightPercentage,proto3" json:"weight_percentage,omitempty"`
+	MinWeight        float64                `protobuf:"fixed64,2,opt,name=min_weight,json=minWeight,proto3" json:"min_weight,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FeelerTemplate) Reset() {
+	*x = FeelerTemplate{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeelerTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeelerTemplate) ProtoMessage() {}
+
+func (x *FeelerTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeelerTemplate.ProtoReflect.Descriptor instead.
+func (*FeelerTemplate) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FeelerTemplate) GetWeightPercentage() float64 {
+	if x != nil {
+		return x.WeightPercentage
+	}
+	return 0
+}
+
+func (x *FeelerTemplate) GetMinWeight() float64 {
+	if x != nil {
+		return x.MinWeight
+	}
+	return 0
+}
+
+type SetTemplate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Reps             int32                  `protobuf:"varint,1,opt,name=reps,proto3" json:"reps,omitempty"`
+	WeightPercentage float64                `protobuf:"fixed64,2,opt,name=weight_percentage,json=weightPercentage,proto3" json:"weight_percentage,omitempty"`
+	Type             SetType                `protobuf:"varint,3,opt,name=type,proto3,enum=greyskull.v1.SetType" json:"type,omitempty"`
+	OfTrainingMax    bool                   `protobuf:"varint,4,opt,name=of_training_max,json=ofTrainingMax,proto3" json:"of_training_max,omitempty"`
+	MinReps          int32                  `protobuf:"varint,5,opt,name=min_reps,json=minReps,proto3" json:"min_reps,omitempty"`
+	MaxReps          int32                  `protobuf:"varint,6,opt,name=max_reps,json=maxReps,proto3" json:"max_reps,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetTemplate) Reset() {
+	*x = SetTemplate{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTemplate) ProtoMessage() {}
+
+func (x *SetTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTemplate.ProtoReflect.Descriptor instead.
+func (*SetTemplate) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetTemplate) GetReps() int32 {
+	if x != nil {
+		return x.Reps
+	}
+	return 0
+}
+
+func (x *SetTemplate) GetWeightPercentage() float64 {
+	if x != nil {
+		return x.WeightPercentage
+	}
+	return 0
+}
+
+func (x *SetTemplate) GetType() SetType {
+	if x != nil {
+		return x.Type
+	}
+	return SetType_SET_TYPE_UNSPECIFIED
+}
+
+func (x *SetTemplate) GetOfTrainingMax() bool {
+	if x != nil {
+		return x.OfTrainingMax
+	}
+	return false
+}
+
+func (x *SetTemplate) GetMinReps() int32 {
+	if x != nil {
+		return x.MinReps
+	}
+	return 0
+}
+
+func (x *SetTemplate) GetMaxReps() int32 {
+	if x != nil {
+		return x.MaxReps
+	}
+	return 0
+}
+
+type ProgressionRules struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IncreaseRules    map[string]float64     `protobuf:"bytes,1,rep,name=increase_rules,json=increaseRules,proto3" json:"increase_rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	DeloadPercentage float64                `protobuf:"fixed64,2,opt,name=deload_percentage,json=deloadPercentage,proto3" json:"deload_percentage,omitempty"`
+	DoubleThreshold  int32                  `protobuf:"varint,3,opt,name=double_threshold,json=doubleThreshold,proto3" json:"double_threshold,omitempty"`
+	Unit             WeightUnit             `protobuf:"varint,4,opt,name=unit,proto3,enum=greyskull.v1.WeightUnit" json:"unit,omitempty"`
+	RepIncreases     map[string]int32       `protobuf:"bytes,5,rep,name=rep_increases,json=repIncreases,proto3" json:"rep_increases,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Strategy         string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"` // Linear if empty
+	Parameters       map[string]float64     `protobuf:"bytes,7,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProgressionRules) Reset() {
+	*x = ProgressionRules{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressionRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressionRules) ProtoMessage() {}
+
+func (x *ProgressionRules) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressionRules.ProtoReflect.Descriptor instead.
+func (*ProgressionRules) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ProgressionRules) GetIncreaseRules() map[string]float64 {
+	if x != nil {
+		return x.IncreaseRules
+	}
+	return nil
+}
+
+func (x *ProgressionRules) GetDeloadPercentage() float64 {
+	if x != nil {
+		return x.DeloadPercentage
+	}
+	return 0
+}
+
+func (x *ProgressionRules) GetDoubleThreshold() int32 {
+	if x != nil {
+		return x.DoubleThreshold
+	}
+	return 0
+}
+
+func (x *ProgressionRules) GetUnit() WeightUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *ProgressionRules) GetRepIncreases() map[string]int32 {
+	if x != nil {
+		return x.RepIncreases
+	}
+	return nil
+}
+
+func (x *ProgressionRules) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ProgressionRules) GetParameters() map[string]float64 {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type WarmupScheme struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategy      string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Steps         []*WarmupStep          `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmupScheme) Reset() {
+	*x = WarmupScheme{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmupScheme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmupScheme) ProtoMessage() {}
+
+func (x *WarmupScheme) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmupScheme.ProtoReflect.Descriptor instead.
+func (*WarmupScheme) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WarmupScheme) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *WarmupScheme) GetSteps() []*WarmupStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type WarmupStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reps          int32                  `protobuf:"varint,1,opt,name=reps,proto3" json:"reps,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Plates        int32                  `protobuf:"varint,3,opt,name=plates,proto3" json:"plates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmupStep) Reset() {
+	*x = WarmupStep{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmupStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmupStep) ProtoMessage() {}
+
+func (x *WarmupStep) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmupStep.ProtoReflect.Descriptor instead.
+func (*WarmupStep) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WarmupStep) GetReps() int32 {
+	if x != nil {
+		return x.Reps
+	}
+	return 0
+}
+
+func (x *WarmupStep) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *WarmupStep) GetPlates() int32 {
+	if x != nil {
+		return x.Plates
+	}
+	return 0
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{21}
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Current       string                 `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"` // The current user; empty if none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListUsersResponse) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetCurrent() string {
+	if x != nil {
+		return x.Current
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListProgramsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProgramsRequest) Reset() {
+	*x = ListProgramsRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProgramsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProgramsRequest) ProtoMessage() {}
+
+func (x *ListProgramsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProgramsRequest.ProtoReflect.Descriptor instead.
+func (*ListProgramsRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{24}
+}
+
+type ListProgramsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Programs      []*Program             `protobuf:"bytes,1,rep,name=programs,proto3" json:"programs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProgramsResponse) Reset() {
+	*x = ListProgramsResponse{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProgramsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProgramsResponse) ProtoMessage() {}
+
+func (x *ListProgramsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProgramsResponse.ProtoReflect.Descriptor instead.
+func (*ListProgramsResponse) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListProgramsResponse) GetPrograms() []*Program {
+	if x != nil {
+		return x.Programs
+	}
+	return nil
+}
+
+type GetProgramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // Program ID or name (case-insensitive)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProgramRequest) Reset() {
+	*x = GetProgramRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProgramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProgramRequest) ProtoMessage() {}
+
+func (x *GetProgramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProgramRequest.ProtoReflect.Descriptor instead.
+func (*GetProgramRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetProgramRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type GetNextWorkoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNextWorkoutRequest) Reset() {
+	*x = GetNextWorkoutRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextWorkoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextWorkoutRequest) ProtoMessage() {}
+
+func (x *GetNextWorkoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextWorkoutRequest.ProtoReflect.Descriptor instead.
+func (*GetNextWorkoutRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetNextWorkoutRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type LogWorkoutRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Completed workout, usually the one returned by GetNextWorkout with each
+	// set's actual reps filled in
+	Workout *Workout `protobuf:"bytes,2,opt,name=workout,proto3" json:"workout,omitempty"`
+	// Reports the result without saving it, as 'greyskull workout log --dry-run' does
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogWorkoutRequest) Reset() {
+	*x = LogWorkoutRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogWorkoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogWorkoutRequest) ProtoMessage() {}
+
+func (x *LogWorkoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogWorkoutRequest.ProtoReflect.Descriptor instead.
+func (*LogWorkoutRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LogWorkoutRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LogWorkoutRequest) GetWorkout() *Workout {
+	if x != nil {
+		return x.Workout
+	}
+	return nil
+}
+
+func (x *LogWorkoutRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type LogWorkoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workout       *Workout               `protobuf:"bytes,1,opt,name=workout,proto3" json:"workout,omitempty"`
+	UserProgram   *UserProgram           `protobuf:"bytes,2,opt,name=user_program,json=userProgram,proto3" json:"user_program,omitempty"` // With the weights and day for the next workout
+	Achievements  []*Achievement         `protobuf:"bytes,3,rep,name=achievements,proto3" json:"achievements,omitempty"`                  // Personal records the workout broke
+	Milestones    []string               `protobuf:"bytes,4,rep,name=milestones,proto3" json:"milestones,omitempty"`                      // IDs of milestones the workout unlocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogWorkoutResponse) Reset() {
+	*x = LogWorkoutResponse{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogWorkoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogWorkoutResponse) ProtoMessage() {}
+
+func (x *LogWorkoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogWorkoutResponse.ProtoReflect.Descriptor instead.
+func (*LogWorkoutResponse) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LogWorkoutResponse) GetWorkout() *Workout {
+	if x != nil {
+		return x.Workout
+	}
+	return nil
+}
+
+func (x *LogWorkoutResponse) GetUserProgram() *UserProgram {
+	if x != nil {
+		return x.UserProgram
+	}
+	return nil
+}
+
+func (x *LogWorkoutResponse) GetAchievements() []*Achievement {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+func (x *LogWorkoutResponse) GetMilestones() []string {
+	if x != nil {
+		return x.Milestones
+	}
+	return nil
+}
+
+// Achievement is a personal record broken by a workout
+type Achievement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lift          string                 `protobuf:"bytes,1,opt,name=lift,proto3" json:"lift,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	New           *Record                `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	Previous      *Record                `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Achievement) Reset() {
+	*x = Achievement{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Achievement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Achievement) ProtoMessage() {}
+
+func (x *Achievement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Achievement.ProtoReflect.Descriptor instead.
+func (*Achievement) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Achievement) GetLift() string {
+	if x != nil {
+		return x.Lift
+	}
+	return ""
+}
+
+func (x *Achievement) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Achievement) GetNew() *Record {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+func (x *Achievement) GetPrevious() *Record {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weight        float64                `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	Reps          int32                  `protobuf:"varint,2,opt,name=reps,proto3" json:"reps,omitempty"`
+	E1Rm          float64                `protobuf:"fixed64,3,opt,name=e1rm,proto3" json:"e1rm,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Record) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Record) GetReps() int32 {
+	if x != nil {
+		return x.Reps
+	}
+	return 0
+}
+
+func (x *Record) GetE1Rm() float64 {
+	if x != nil {
+		return x.E1Rm
+	}
+	return 0
+}
+
+func (x *Record) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+type ListWorkoutsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkoutsRequest) Reset() {
+	*x = ListWorkoutsRequest{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkoutsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkoutsRequest) ProtoMessage() {}
+
+func (x *ListWorkoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkoutsRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkoutsRequest) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListWorkoutsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ListWorkoutsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workouts      []*Workout             `protobuf:"bytes,1,rep,name=workouts,proto3" json:"workouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkoutsResponse) Reset() {
+	*x = ListWorkoutsResponse{}
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkoutsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkoutsResponse) ProtoMessage() {}
+
+func (x *ListWorkoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_greyskull_v1_greyskull_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkoutsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkoutsResponse) Descriptor() ([]byte, []int) {
+	return file_api_greyskull_v1_greyskull_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListWorkoutsResponse) GetWorkouts() []*Workout {
+	if x != nil {
+		return x.Workouts
+	}
+	return nil
+}
+
+var File_api_greyskull_v1_greyskull_proto protoreflect.FileDescriptor
+
+const file_api_greyskull_v1_greyskull_proto_rawDesc = "" +
+	"\n" +
+	" api/greyskull/v1/greyskull.proto\x12\fgreyskull.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9c\a\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12'\n" +
+	"\x0fcurrent_program\x18\x03 \x01(\tR\x0ecurrentProgram\x12<\n" +
+	"\bprograms\x18\x04 \x03(\v2 .greyskull.v1.User.ProgramsEntryR\bprograms\x12>\n" +
+	"\x0fworkout_history\x18\x05 \x03(\v2\x15.greyskull.v1.WorkoutR\x0eworkoutHistory\x12;\n" +
+	"\fskipped_days\x18\x06 \x03(\v2\x18.greyskull.v1.SkippedDayR\vskippedDays\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\n" +
+	"rest_times\x18\b \x01(\v2\x17.greyskull.v1.RestTimesR\trestTimes\x12,\n" +
+	"\x04unit\x18\t \x01(\x0e2\x18.greyskull.v1.WeightUnitR\x04unit\x12 \n" +
+	"\vleaderboard\x18\n" +
+	" \x01(\bR\vleaderboard\x12X\n" +
+	"\x12warmup_percentages\x18\v \x03(\v2).greyskull.v1.User.WarmupPercentagesEntryR\x11warmupPercentages\x12'\n" +
+	"\x0factive_programs\x18\f \x03(\tR\x0eactivePrograms\x12>\n" +
+	"\rweight_resets\x18\r \x03(\v2\x19.greyskull.v1.WeightResetR\fweightResets\x12?\n" +
+	"\n" +
+	"milestones\x18\x0e \x03(\v2\x1f.greyskull.v1.UnlockedMilestoneR\n" +
+	"milestones\x1aV\n" +
+	"\rProgramsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.greyskull.v1.UserProgramR\x05value:\x028\x01\x1ae\n" +
+	"\x16WarmupPercentagesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.greyskull.v1.WarmupPercentagesR\x05value:\x028\x01\"`\n" +
+	"\x11UnlockedMilestone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vunlocked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"unlockedAt\"\xa6\x0e\n" +
+	"\vUserProgram\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"program_id\x18\x03 \x01(\tR\tprogramId\x12Y\n" +
+	"\x10starting_weights\x18\x04 \x03(\v2..greyskull.v1.UserProgram.StartingWeightsEntryR\x0fstartingWeights\x12V\n" +
+	"\x0fcurrent_weights\x18\x05 \x03(\v2-.greyskull.v1.UserProgram.CurrentWeightsEntryR\x0ecurrentWeights\x12\x1f\n" +
+	"\vcurrent_day\x18\x06 \x01(\x05R\n" +
+	"currentDay\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12:\n" +
+	"\x05holds\x18\b \x03(\v2$.greyskull.v1.UserProgram.HoldsEntryR\x05holds\x120\n" +
+	"\x06deload\x18\t \x01(\v2\x18.greyskull.v1.DeloadPlanR\x06deload\x12,\n" +
+	"\x04unit\x18\n" +
+	" \x01(\x0e2\x18.greyskull.v1.WeightUnitR\x04unit\x12J\n" +
+	"\vrep_targets\x18\v \x03(\v2).greyskull.v1.UserProgram.RepTargetsEntryR\n" +
+	"repTargets\x12:\n" +
+	"\x05goals\x18\f \x03(\v2$.greyskull.v1.UserProgram.GoalsEntryR\x05goals\x12S\n" +
+	"\x0edeload_streaks\x18\r \x03(\v2,.greyskull.v1.UserProgram.DeloadStreaksEntryR\rdeloadStreaks\x12#\n" +
+	"\rtraining_days\x18\x0e \x03(\x05R\ftrainingDays\x12S\n" +
+	"\x0egoal_deadlines\x18\x0f \x03(\v2,.greyskull.v1.UserProgram.GoalDeadlinesEntryR\rgoalDeadlines\x12S\n" +
+	"\x0etraining_maxes\x18\x10 \x03(\v2,.greyskull.v1.UserProgram.TrainingMaxesEntryR\rtrainingMaxes\x12+\n" +
+	"\x06pauses\x18\x11 \x03(\v2\x13.greyskull.v1.PauseR\x06pauses\x12S\n" +
+	"\x0erounding_steps\x18\x12 \x03(\v2,.greyskull.v1.UserProgram.RoundingStepsEntryR\rroundingSteps\x12K\n" +
+	"\x11progression_rules\x18\x13 \x01(\v2\x1e.greyskull.v1.ProgressionRulesR\x10progressionRules\x12/\n" +
+	"\aprogram\x18\x14 \x01(\v2\x15.greyskull.v1.ProgramR\aprogram\x1aB\n" +
+	"\x14StartingWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1aA\n" +
+	"\x13CurrentWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"HoldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a=\n" +
+	"\x0fRepTargetsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"GoalsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a\\\n" +
+	"\x12DeloadStreaksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.greyskull.v1.DeloadStreakR\x05value:\x028\x01\x1a\\\n" +
+	"\x12GoalDeadlinesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05value:\x028\x01\x1a@\n" +
+	"\x12TrainingMaxesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a@\n" +
+	"\x12RoundingStepsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x83\x01\n" +
+	"\n" +
+	"DeloadPlan\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x01 \x01(\x01R\n" +
+	"percentage\x12\x12\n" +
+	"\x04sets\x18\x02 \x01(\x05R\x04sets\x12\x12\n" +
+	"\x04reps\x18\x03 \x01(\x05R\x04reps\x12-\n" +
+	"\x12sessions_remaining\x18\x04 \x01(\x05R\x11sessionsRemaining\"@\n" +
+	"\fDeloadStreak\x12\x18\n" +
+	"\adeloads\x18\x01 \x01(\x05R\adeloads\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\"\x91\x01\n" +
+	"\x05Pause\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\"\xd8\x02\n" +
+	"\aWorkout\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fuser_program_id\x18\x02 \x01(\tR\ruserProgramId\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\x120\n" +
+	"\texercises\x18\x04 \x03(\v2\x12.greyskull.v1.LiftR\texercises\x129\n" +
+	"\n" +
+	"entered_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tenteredAt\x12\x14\n" +
+	"\x05quick\x18\x06 \x01(\bR\x05quick\x12\x1e\n" +
+	"\n" +
+	"incomplete\x18\a \x01(\bR\n" +
+	"incomplete\x12\x14\n" +
+	"\x05notes\x18\b \x01(\tR\x05notes\x12\x15\n" +
+	"\x06ad_hoc\x18\t \x01(\bR\x05adHoc\x12\x1f\n" +
+	"\vbody_weight\x18\n" +
+	" \x01(\x01R\n" +
+	"bodyWeight\x12\x12\n" +
+	"\x04held\x18\v \x03(\tR\x04held\"\xa9\x01\n" +
+	"\n" +
+	"SkippedDay\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fuser_program_id\x18\x02 \x01(\tR\ruserProgramId\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"skipped_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tskippedAt\"\x97\x03\n" +
+	"\vWeightReset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fuser_program_id\x18\x02 \x01(\tR\ruserProgramId\x125\n" +
+	"\breset_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aresetAt\x12\x19\n" +
+	"\bdays_off\x18\x04 \x01(\x05R\adaysOff\x12C\n" +
+	"\bprevious\x18\x05 \x03(\v2'.greyskull.v1.WeightReset.PreviousEntryR\bprevious\x12@\n" +
+	"\aweights\x18\x06 \x03(\v2&.greyskull.v1.WeightReset.WeightsEntryR\aweights\x1a;\n" +
+	"\rPreviousEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a:\n" +
+	"\fWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x92\x02\n" +
+	"\x04Lift\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tlift_name\x18\x02 \x01(\tR\bliftName\x12\x18\n" +
+	"\avariant\x18\x03 \x01(\tR\avariant\x12\x1a\n" +
+	"\boptional\x18\x04 \x01(\bR\boptional\x12\x1e\n" +
+	"\n" +
+	"bodyweight\x18\x05 \x01(\bR\n" +
+	"bodyweight\x12%\n" +
+	"\x04sets\x18\x06 \x03(\v2\x11.greyskull.v1.SetR\x04sets\x12!\n" +
+	"\ffixed_weight\x18\a \x01(\bR\vfixedWeight\x12\x14\n" +
+	"\x05group\x18\b \x01(\tR\x05group\x12'\n" +
+	"\x0fsubstituted_for\x18\t \x01(\tR\x0esubstitutedFor\"\xcb\x01\n" +
+	"\x03Set\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\x12\x1f\n" +
+	"\vtarget_reps\x18\x03 \x01(\x05R\n" +
+	"targetReps\x12\x1f\n" +
+	"\vactual_reps\x18\x04 \x01(\x05R\n" +
+	"actualReps\x12)\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x15.greyskull.v1.SetTypeR\x04type\x12\x14\n" +
+	"\x05order\x18\x06 \x01(\x05R\x05order\x12\x19\n" +
+	"\bmax_reps\x18\a \x01(\x05R\amaxReps\"[\n" +
+	"\tRestTimes\x12%\n" +
+	"\x0ewarmup_seconds\x18\x01 \x01(\x05R\rwarmupSeconds\x12'\n" +
+	"\x0fworking_seconds\x18\x02 \x01(\x05R\x0eworkingSeconds\"5\n" +
+	"\x11WarmupPercentages\x12 \n" +
+	"\vpercentages\x18\x01 \x03(\x01R\vpercentages\"\xea\x02\n" +
+	"\aProgram\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x129\n" +
+	"\bworkouts\x18\x05 \x03(\v2\x1d.greyskull.v1.WorkoutTemplateR\bworkouts\x12K\n" +
+	"\x11progression_rules\x18\x06 \x01(\v2\x1e.greyskull.v1.ProgressionRulesR\x10progressionRules\x126\n" +
+	"\n" +
+	"rest_times\x18\a \x01(\v2\x17.greyskull.v1.RestTimesR\trestTimes\x12?\n" +
+	"\rwarmup_scheme\x18\b \x01(\v2\x1a.greyskull.v1.WarmupSchemeR\fwarmupScheme\"U\n" +
+	"\x0fWorkoutTemplate\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x05R\x03day\x120\n" +
+	"\x05lifts\x18\x02 \x03(\v2\x1a.greyskull.v1.LiftTemplateR\x05lifts\"\x90\x03\n" +
+	"\fLiftTemplate\x12\x1b\n" +
+	"\tlift_name\x18\x01 \x01(\tR\bliftName\x12\x18\n" +
+	"\avariant\x18\x02 \x01(\tR\avariant\x12:\n" +
+	"\vwarmup_sets\x18\x03 \x03(\v2\x19.greyskull.v1.SetTemplateR\n" +
+	"warmupSets\x12<\n" +
+	"\fworking_sets\x18\x04 \x03(\v2\x19.greyskull.v1.SetTemplateR\vworkingSets\x124\n" +
+	"\x06feeler\x18\x05 \x01(\v2\x1c.greyskull.v1.FeelerTemplateR\x06feeler\x12\x1e\n" +
+	"\n" +
+	"bodyweight\x18\x06 \x01(\bR\n" +
+	"bodyweight\x12\x1a\n" +
+	"\boptional\x18\a \x01(\bR\boptional\x12!\n" +
+	"\ffixed_weight\x18\b \x01(\bR\vfixedWeight\x12\x14\n" +
+	"\x05group\x18\t \x01(\tR\x05group\x12\x12\n" +
+	"\x04cues\x18\n" +
+	" \x03(\tR\x04cues\x12\x10\n" +
+	"\x03url\x18\v \x01(\tR\x03url\"\\\n" +
+	"\x0eFeelerTemplate\x12+\n" +
+	"\x11weight_percentage\x18\x01 \x01(\x01R\x10weightPercentage\x12\x1d\n" +
+	"\n" +
+	"min_weight\x18\x02 \x01(\x01R\tminWeight\"\xd7\x01\n" +
+	"\vSetTemplate\x12\x12\n" +
+	"\x04reps\x18\x01 \x01(\x05R\x04reps\x12+\n" +
+	"\x11weight_percentage\x18\x02 \x01(\x01R\x10weightPercentage\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.greyskull.v1.SetTypeR\x04type\x12&\n" +
+	"\x0fof_training_max\x18\x04 \x01(\bR\rofTrainingMax\x12\x19\n" +
+	"\bmin_reps\x18\x05 \x01(\x05R\aminReps\x12\x19\n" +
+	"\bmax_reps\x18\x06 \x01(\x05R\amaxReps\"\xf7\x04\n" +
+	"\x10ProgressionRules\x12X\n" +
+	"\x0eincrease_rules\x18\x01 \x03(\v21.greyskull.v1.ProgressionRules.IncreaseRulesEntryR\rincreaseRules\x12+\n" +
+	"\x11deload_percentage\x18\x02 \x01(\x01R\x10deloadPercentage\x12)\n" +
+	"\x10double_threshold\x18\x03 \x01(\x05R\x0fdoubleThreshold\x12,\n" +
+	"\x04unit\x18\x04 \x01(\x0e2\x18.greyskull.v1.WeightUnitR\x04unit\x12U\n" +
+	"\rrep_increases\x18\x05 \x03(\v20.greyskull.v1.ProgressionRules.RepIncreasesEntryR\frepIncreases\x12\x1a\n" +
+	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12N\n" +
+	"\n" +
+	"parameters\x18\a \x03(\v2..greyskull.v1.ProgressionRules.ParametersEntryR\n" +
+	"parameters\x1a@\n" +
+	"\x12IncreaseRulesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a?\n" +
+	"\x11RepIncreasesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"Z\n" +
+	"\fWarmupScheme\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12.\n" +
+	"\x05steps\x18\x02 \x03(\v2\x18.greyskull.v1.WarmupStepR\x05steps\"X\n" +
+	"\n" +
+	"WarmupStep\x12\x12\n" +
+	"\x04reps\x18\x01 \x01(\x05R\x04reps\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x02 \x01(\x01R\n" +
+	"percentage\x12\x16\n" +
+	"\x06plates\x18\x03 \x01(\x05R\x06plates\"\x12\n" +
+	"\x10ListUsersRequest\"K\n" +
+	"\x11ListUsersResponse\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\tR\acurrent\",\n" +
+	"\x0eGetUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x15\n" +
+	"\x13ListProgramsRequest\"I\n" +
+	"\x14ListProgramsResponse\x121\n" +
+	"\bprograms\x18\x01 \x03(\v2\x15.greyskull.v1.ProgramR\bprograms\")\n" +
+	"\x11GetProgramRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\"3\n" +
+	"\x15GetNextWorkoutRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"y\n" +
+	"\x11LogWorkoutRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12/\n" +
+	"\aworkout\x18\x02 \x01(\v2\x15.greyskull.v1.WorkoutR\aworkout\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\xe2\x01\n" +
+	"\x12LogWorkoutResponse\x12/\n" +
+	"\aworkout\x18\x01 \x01(\v2\x15.greyskull.v1.WorkoutR\aworkout\x12<\n" +
+	"\fuser_program\x18\x02 \x01(\v2\x19.greyskull.v1.UserProgramR\vuserProgram\x12=\n" +
+	"\fachievements\x18\x03 \x03(\v2\x19.greyskull.v1.AchievementR\fachievements\x12\x1e\n" +
+	"\n" +
+	"milestones\x18\x04 \x03(\tR\n" +
+	"milestones\"\x8f\x01\n" +
+	"\vAchievement\x12\x12\n" +
+	"\x04lift\x18\x01 \x01(\tR\x04lift\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12&\n" +
+	"\x03new\x18\x03 \x01(\v2\x14.greyskull.v1.RecordR\x03new\x120\n" +
+	"\bprevious\x18\x04 \x01(\v2\x14.greyskull.v1.RecordR\bprevious\"x\n" +
+	"\x06Record\x12\x16\n" +
+	"\x06weight\x18\x01 \x01(\x01R\x06weight\x12\x12\n" +
+	"\x04reps\x18\x02 \x01(\x05R\x04reps\x12\x12\n" +
+	"\x04e1rm\x18\x03 \x01(\x01R\x04e1rm\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"1\n" +
+	"\x13ListWorkoutsRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"I\n" +
+	"\x14ListWorkoutsResponse\x121\n" +
+	"\bworkouts\x18\x01 \x03(\v2\x15.greyskull.v1.WorkoutR\bworkouts*\\\n" +
+	"\n" +
+	"WeightUnit\x12\x1b\n" +
+	"\x17WEIGHT_UNIT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12WEIGHT_UNIT_POUNDS\x10\x01\x12\x19\n" +
+	"\x15WEIGHT_UNIT_KILOGRAMS\x10\x02*w\n" +
+	"\aSetType\x12\x18\n" +
+	"\x14SET_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSET_TYPE_WARMUP\x10\x01\x12\x14\n" +
+	"\x10SET_TYPE_WORKING\x10\x02\x12\x12\n" +
+	"\x0eSET_TYPE_AMRAP\x10\x03\x12\x13\n" +
+	"\x0fSET_TYPE_FEELER\x10\x042\xb0\x04\n" +
+	"\x10GreyskullService\x12L\n" +
+	"\tListUsers\x12\x1e.greyskull.v1.ListUsersRequest\x1a\x1f.greyskull.v1.ListUsersResponse\x12;\n" +
+	"\aGetUser\x12\x1c.greyskull.v1.GetUserRequest\x1a\x12.greyskull.v1.User\x12U\n" +
+	"\fListPrograms\x12!.greyskull.v1.ListProgramsRequest\x1a\".greyskull.v1.ListProgramsResponse\x12D\n" +
+	"\n" +
+	"GetProgram\x12\x1f.greyskull.v1.GetProgramRequest\x1a\x15.greyskull.v1.Program\x12L\n" +
+	"\x0eGetNextWorkout\x12#.greyskull.v1.GetNextWorkoutRequest\x1a\x15.greyskull.v1.Workout\x12O\n" +
+	"\n" +
+	"LogWorkout\x12\x1f.greyskull.v1.LogWorkoutRequest\x1a .greyskull.v1.LogWorkoutResponse\x12U\n" +
+	"\fListWorkouts\x12!.greyskull.v1.ListWorkoutsRequest\x1a\".greyskull.v1.ListWorkoutsResponseB<Z:github.com/mikowitz/greyskull/api/greyskull/v1;greyskullv1b\x06proto3"
+
+var (
+	file_api_greyskull_v1_greyskull_proto_rawDescOnce sync.Once
+	file_api_greyskull_v1_greyskull_proto_rawDescData []byte
+)
+
+func file_api_greyskull_v1_greyskull_proto_rawDescGZIP() []byte {
+	file_api_greyskull_v1_greyskull_proto_rawDescOnce.Do(func() {
+		file_api_greyskull_v1_greyskull_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_greyskull_v1_greyskull_proto_rawDesc), len(file_api_greyskull_v1_greyskull_proto_rawDesc)))
+	})
+	return file_api_greyskull_v1_greyskull_proto_rawDescData
+}
+
+var file_api_greyskull_v1_greyskull_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_greyskull_v1_greyskull_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_api_greyskull_v1_greyskull_proto_goTypes = []any{
+	(WeightUnit)(0),               // 0: greyskull.v1.WeightUnit
+	(SetType)(0),                  // 1: greyskull.v1.SetType
+	(*User)(nil),                  // 2: greyskull.v1.User
+	(*UnlockedMilestone)(nil),     // 3: greyskull.v1.UnlockedMilestone
+	(*UserProgram)(nil),           // 4: greyskull.v1.UserProgram
+	(*DeloadPlan)(nil),            // 5: greyskull.v1.DeloadPlan
+	(*DeloadStreak)(nil),          // 6: greyskull.v1.DeloadStreak
+	(*Pause)(nil),                 // 7: greyskull.v1.Pause
+	(*Workout)(nil),               // 8: greyskull.v1.Workout
+	(*SkippedDay)(nil),            // 9: greyskull.v1.SkippedDay
+	(*WeightReset)(nil),           // 10: greyskull.v1.WeightReset
+	(*Lift)(nil),                  // 11: greyskull.v1.Lift
+	(*Set)(nil),                   // 12: greyskull.v1.Set
+	(*RestTimes)(nil),             // 13: greyskull.v1.RestTimes
+	(*WarmupPercentages)(nil),     // 14: greyskull.v1.WarmupPercentages
+	(*Program)(nil),               // 15: greyskull.v1.Program
+	(*WorkoutTemplate)(nil),       // 16: greyskull.v1.WorkoutTemplate
+	(*LiftTemplate)(nil),          // 17: greyskull.v1.LiftTemplate
+	(*FeelerTemplate)(nil),        // 18: greyskull.v1.FeelerTemplate
+	(*SetTemplate)(nil),           // 19: greyskull.v1.SetTemplate
+	(*ProgressionRules)(nil),      // 20: greyskull.v1.ProgressionRules
+	(*WarmupScheme)(nil),          // 21: greyskull.v1.WarmupScheme
+	(*WarmupStep)(nil),            // 22: greyskull.v1.WarmupStep
+	(*ListUsersRequest)(nil),      // 23: greyskull.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),     // 24: greyskull.v1.ListUsersResponse
+	(*GetUserRequest)(nil),        // 25: greyskull.v1.GetUserRequest
+	(*ListProgramsRequest)(nil),   // 26: greyskull.v1.ListProgramsRequest
+	(*ListProgramsResponse)(nil),  // 27: greyskull.v1.ListProgramsResponse
+	(*GetProgramRequest)(nil),     // 28: greyskull.v1.GetProgramRequest
+	(*GetNextWorkoutRequest)(nil), // 29: greyskull.v1.GetNextWorkoutRequest
+	(*LogWorkoutRequest)(nil),     // 30: greyskull.v1.LogWorkoutRequest
+	(*LogWorkoutResponse)(nil),    // 31: greyskull.v1.LogWorkoutResponse
+	(*Achievement)(nil),           // 32: greyskull.v1.Achievement
+	(*Record)(nil),                // 33: greyskull.v1.Record
+	(*ListWorkoutsRequest)(nil),   // 34: greyskull.v1.ListWorkoutsRequest
+	(*ListWorkoutsResponse)(nil),  // 35: greyskull.v1.ListWorkoutsResponse
+	nil,                           // 36: greyskull.v1.User.ProgramsEntry
+	nil,                           // 37: greyskull.v1.User.WarmupPercentagesEntry
+	nil,                           // 38: greyskull.v1.UserProgram.StartingWeightsEntry
+	nil,                           // 39: greyskull.v1.UserProgram.CurrentWeightsEntry
+	nil,                           // 40: greyskull.v1.UserProgram.HoldsEntry
+	nil,                           // 41: greyskull.v1.UserProgram.RepTargetsEntry
+	nil,                           // 42: greyskull.v1.UserProgram.GoalsEntry
+	nil,                           // 43: greyskull.v1.UserProgram.DeloadStreaksEntry
+	nil,                           // 44: greyskull.v1.UserProgram.GoalDeadlinesEntry
+	nil,                           // 45: greyskull.v1.UserProgram.TrainingMaxesEntry
+	nil,                           // 46: greyskull.v1.UserProgram.RoundingStepsEntry
+	nil,                           // 47: greyskull.v1.WeightReset.PreviousEntry
+	nil,                           // 48: greyskull.v1.WeightReset.WeightsEntry
+	nil,                           // 49: greyskull.v1.ProgressionRules.IncreaseRulesEntry
+	nil,                           // 50: greyskull.v1.ProgressionRules.RepIncreasesEntry
+	nil,                           // 51: greyskull.v1.ProgressionRules.ParametersEntry
+	(*timestamppb.Timestamp)(nil), // 52: google.protobuf.Timestamp
+}
+var file_api_greyskull_v1_greyskull_proto_depIdxs = []int32{
+	36, // 0: greyskull.v1.User.programs:type_name -> greyskull.v1.User.ProgramsEntry
+	8,  // 1: greyskull.v1.User.workout_history:type_name -> greyskull.v1.Workout
+	9,  // 2: greyskull.v1.User.skipped_days:type_name -> greyskull.v1.SkippedDay
+	52, // 3: greyskull.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	13, // 4: greyskull.v1.User.rest_times:type_name -> greyskull.v1.RestTimes
+	0,  // 5: greyskull.v1.User.unit:type_name -> greyskull.v1.WeightUnit
+	37, // 6: greyskull.v1.User.warmup_percentages:type_name -> greyskull.v1.User.WarmupPercentagesEntry
+	10, // 7: greyskull.v1.User.weight_resets:type_name -> greyskull.v1.WeightReset
+	3,  // 8: greyskull.v1.User.milestones:type_name -> greyskull.v1.UnlockedMilestone
+	52, // 9: greyskull.v1.UnlockedMilestone.unlocked_at:type_name -> google.protobuf.Timestamp
+	38, // 10: greyskull.v1.UserProgram.starting_weights:type_name -> greyskull.v1.UserProgram.StartingWeightsEntry
+	39, // 11: greyskull.v1.UserProgram.current_weights:type_name -> greyskull.v1.UserProgram.CurrentWeightsEntry
+	52, // 12: greyskull.v1.UserProgram.started_at:type_name -> google.protobuf.Timestamp
+	40, // 13: greyskull.v1.UserProgram.holds:type_name -> greyskull.v1.UserProgram.HoldsEntry
+	5,  // 14: greyskull.v1.UserProgram.deload:type_name -> greyskull.v1.DeloadPlan
+	0,  // 15: greyskull.v1.UserProgram.unit:type_name -> greyskull.v1.WeightUnit
+	41, // 16: greyskull.v1.UserProgram.rep_targets:type_name -> greyskull.v1.UserProgram.RepTargetsEntry
+	42, // 17: greyskull.v1.UserProgram.goals:type_name -> greyskull.v1.UserProgram.GoalsEntry
+	43, // 18: greyskull.v1.UserProgram.deload_streaks:type_name -> greyskull.v1.UserProgram.DeloadStreaksEntry
+	44, // 19: greyskull.v1.UserProgram.goal_deadlines:type_name -> greyskull.v1.UserProgram.GoalDeadlinesEntry
+	45, // 20: greyskull.v1.UserProgram.training_maxes:type_name -> greyskull.v1.UserProgram.TrainingMaxesEntry
+	7,  // 21: greyskull.v1.UserProgram.pauses:type_name -> greyskull.v1.Pause
+	46, // 22: greyskull.v1.UserProgram.rounding_steps:type_name -> greyskull.v1.UserProgram.RoundingStepsEntry
+	20, // 23: greyskull.v1.UserProgram.progression_rules:type_name -> greyskull.v1.ProgressionRules
+	15, // 24: greyskull.v1.UserProgram.program:type_name -> greyskull.v1.Program
+	52, // 25: greyskull.v1.Pause.started_at:type_name -> google.protobuf.Timestamp
+	52, // 26: greyskull.v1.Pause.ended_at:type_name -> google.protobuf.Timestamp
+	11, // 27: greyskull.v1.Workout.exercises:type_name -> greyskull.v1.Lift
+	52, // 28: greyskull.v1.Workout.entered_at:type_name -> google.protobuf.Timestamp
+	52, // 29: greyskull.v1.SkippedDay.skipped_at:type_name -> google.protobuf.Timestamp
+	52, // 30: greyskull.v1.WeightReset.reset_at:type_name -> google.protobuf.Timestamp
+	47, // 31: greyskull.v1.WeightReset.previous:type_name -> greyskull.v1.WeightReset.PreviousEntry
+	48, // 32: greyskull.v1.WeightReset.weights:type_name -> greyskull.v1.WeightReset.WeightsEntry
+	12, // 33: greyskull.v1.Lift.sets:type_name -> greyskull.v1.Set
+	1,  // 34: greyskull.v1.Set.type:type_name -> greyskull.v1.SetType
+	16, // 35: greyskull.v1.Program.workouts:type_name -> greyskull.v1.WorkoutTemplate
+	20, // 36: greyskull.v1.Program.progression_rules:type_name -> greyskull.v1.ProgressionRules
+	13, // 37: greyskull.v1.Program.rest_times:type_name -> greyskull.v1.RestTimes
+	21, // 38: greyskull.v1.Program.warmup_scheme:type_name -> greyskull.v1.WarmupScheme
+	17, // 39: greyskull.v1.WorkoutTemplate.lifts:type_name -> greyskull.v1.LiftTemplate
+	19, // 40: greyskull.v1.LiftTemplate.warmup_sets:type_name -> greyskull.v1.SetTemplate
+	19, // 41: greyskull.v1.LiftTemplate.working_sets:type_name -> greyskull.v1.SetTemplate
+	18, // 42: greyskull.v1.LiftTemplate.feeler:type_name -> greyskull.v1.FeelerTemplate
+	1,  // 43: greyskull.v1.SetTemplate.type:type_name -> greyskull.v1.SetType
+	49, // 44: greyskull.v1.ProgressionRules.increase_rules:type_name -> greyskull.v1.ProgressionRules.IncreaseRulesEntry
+	0,  // 45: greyskull.v1.ProgressionRules.unit:type_name -> greyskull.v1.WeightUnit
+	50, // 46: greyskull.v1.ProgressionRules.rep_increases:type_name -> greyskull.v1.ProgressionRules.RepIncreasesEntry
+	51, // 47: greyskull.v1.ProgressionRules.parameters:type_name -> greyskull.v1.ProgressionRules.ParametersEntry
+	22, // 48: greyskull.v1.WarmupScheme.steps:type_name -> greyskull.v1.WarmupStep
+	15, // 49: greyskull.v1.ListProgramsResponse.programs:type_name -> greyskull.v1.Program
+	8,  // 50: greyskull.v1.LogWorkoutRequest.workout:type_name -> greyskull.v1.Workout
+	8,  // 51: greyskull.v1.LogWorkoutResponse.workout:type_name -> greyskull.v1.Workout
+	4,  // 52: greyskull.v1.LogWorkoutResponse.user_program:type_name -> greyskull.v1.UserProgram
+	32, // 53: greyskull.v1.LogWorkoutResponse.achievements:type_name -> greyskull.v1.Achievement
+	33, // 54: greyskull.v1.Achievement.new:type_name -> greyskull.v1.Record
+	33, // 55: greyskull.v1.Achievement.previous:type_name -> greyskull.v1.Record
+	52, // 56: greyskull.v1.Record.date:type_name -> google.protobuf.Timestamp
+	8,  // 57: greyskull.v1.ListWorkoutsResponse.workouts:type_name -> greyskull.v1.Workout
+	4,  // 58: greyskull.v1.User.ProgramsEntry.value:type_name -> greyskull.v1.UserProgram
+	14, // 59: greyskull.v1.User.WarmupPercentagesEntry.value:type_name -> greyskull.v1.WarmupPercentages
+	6,  // 60: greyskull.v1.UserProgram.DeloadStreaksEntry.value:type_name -> greyskull.v1.DeloadStreak
+	52, // 61: greyskull.v1.UserProgram.GoalDeadlinesEntry.value:type_name -> google.protobuf.Timestamp
+	23, // 62: greyskull.v1.GreyskullService.ListUsers:input_type -> greyskull.v1.ListUsersRequest
+	25, // 63: greyskull.v1.GreyskullService.GetUser:input_type -> greyskull.v1.GetUserRequest
+	26, // 64: greyskull.v1.GreyskullService.ListPrograms:input_type -> greyskull.v1.ListProgramsRequest
+	28, // 65: greyskull.v1.GreyskullService.GetProgram:input_type -> greyskull.v1.GetProgramRequest
+	29, // 66: greyskull.v1.GreyskullService.GetNextWorkout:input_type -> greyskull.v1.GetNextWorkoutRequest
+	30, // 67: greyskull.v1.GreyskullService.LogWorkout:input_type -> greyskull.v1.LogWorkoutRequest
+	34, // 68: greyskull.v1.GreyskullService.ListWorkouts:input_type -> greyskull.v1.ListWorkoutsRequest
+	24, // 69: greyskull.v1.GreyskullService.ListUsers:output_type -> greyskull.v1.ListUsersResponse
+	2,  // 70: greyskull.v1.GreyskullService.GetUser:output_type -> greyskull.v1.User
+	27, // 71: greyskull.v1.GreyskullService.ListPrograms:output_type -> greyskull.v1.ListProgramsResponse
+	15, // 72: greyskull.v1.GreyskullService.GetProgram:output_type -> greyskull.v1.Program
+	8,  // 73: greyskull.v1.GreyskullService.GetNextWorkout:output_type -> greyskull.v1.Workout
+	31, // 74: greyskull.v1.GreyskullService.LogWorkout:output_type -> greyskull.v1.LogWorkoutResponse
+	35, // 75: greyskull.v1.GreyskullService.ListWorkouts:output_type -> greyskull.v1.ListWorkoutsResponse
+	69, // [69:76] is the sub-list for method output_type
+	62, // [62:69] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
+}
+
+func init() { file_api_greyskull_v1_greyskull_proto_init() }
+func file_api_greyskull_v1_greyskull_proto_init() {
+	if File_api_greyskull_v1_greyskull_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_greyskull_v1_greyskull_proto_rawDesc), len(file_api_greyskull_v1_greyskull_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   50,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_greyskull_v1_greyskull_proto_goTypes,
+		DependencyIndexes: file_api_greyskull_v1_greyskull_proto_depIdxs,
+		EnumInfos:         file_api_greyskull_v1_greyskull_proto_enumTypes,
+		MessageInfos:      file_api_greyskull_v1_greyskull_proto_msgTypes,
+	}.Build()
+	File_api_greyskull_v1_greyskull_proto = out.File
+	file_api_greyskull_v1_greyskull_proto_goTypes = nil
+	file_api_greyskull_v1_greyskull_proto_depIdxs = nil
+}
@@ -0,0 +1,387 @@
+// Protocol buffer schema for greyskull's data and the API a companion app, such
+// as a mobile client, uses to work with the same core logic as the CLI.
+//
+// Messages mirror the models package and the JSON files written to the data
+// directory: IDs are UUID strings, weights are in the unit of the program they
+// belong to, and lift names are the models.LiftName values, e.g. "Squat" or
+// "BenchPress".
+//
+// 'greyskull serve' runs GreyskullService. After changing this file, regenerate
+// the Go code with 'go generate ./api/...', which needs protoc, protoc-gen-go,
+// and protoc-gen-go-grpc.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/greyskull/v1/greyskull.proto
+
+package greyskullv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GreyskullService_ListUsers_FullMethodName      = "/greyskull.v1.GreyskullService/ListUsers"
+	GreyskullService_GetUser_FullMethodName        = "/greyskull.v1.GreyskullService/GetUser"
+	GreyskullService_ListPrograms_FullMethodName   = "/greyskull.v1.GreyskullService/ListPrograms"
+	GreyskullService_GetProgram_FullMethodName     = "/greyskull.v1.GreyskullService/GetProgram"
+	GreyskullService_GetNextWorkout_FullMethodName = "/greyskull.v1.GreyskullService/GetNextWorkout"
+	GreyskullService_LogWorkout_FullMethodName     = "/greyskull.v1.GreyskullService/LogWorkout"
+	GreyskullService_ListWorkouts_FullMethodName   = "/greyskull.v1.GreyskullService/ListWorkouts"
+)
+
+// GreyskullServiceClient is the client API for GreyskullService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GreyskullService exposes the operations behind the CLI's user, program, and
+// workout commands. Requests naming no user act as the current user.
+type GreyskullServiceClient interface {
+	// ListUsers returns every username, as 'greyskull user list' does
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// GetUser returns a user by username (case-insensitive), with their programs
+	// but without workout history
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// ListPrograms returns the built-in and custom program templates
+	ListPrograms(ctx context.Context, in *ListProgramsRequest, opts ...grpc.CallOption) (*ListProgramsResponse, error)
+	// GetProgram returns a program template by ID or name
+	GetProgram(ctx context.Context, in *GetProgramRequest, opts ...grpc.CallOption) (*Program, error)
+	// GetNextWorkout returns the user's next workout with its calculated
+	// weights, as 'greyskull workout next' does
+	GetNextWorkout(ctx context.Context, in *GetNextWorkoutRequest, opts ...grpc.CallOption) (*Workout, error)
+	// LogWorkout records a completed workout, applies progression, and returns
+	// the user's program with its new weights, as 'greyskull workout log' does
+	LogWorkout(ctx context.Context, in *LogWorkoutRequest, opts ...grpc.CallOption) (*LogWorkoutResponse, error)
+	// ListWorkouts returns the user's workout history, oldest first
+	ListWorkouts(ctx context.Context, in *ListWorkoutsRequest, opts ...grpc.CallOption) (*ListWorkoutsResponse, error)
+}
+
+type greyskullServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGreyskullServiceClient(cc grpc.ClientConnInterface) GreyskullServiceClient {
+	return &greyskullServiceClient{cc}
+}
+
+func (c *greyskullServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, GreyskullService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, GreyskullService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) ListPrograms(ctx context.Context, in *ListProgramsRequest, opts ...grpc.CallOption) (*ListProgramsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProgramsResponse)
+	err := c.cc.Invoke(ctx, GreyskullService_ListPrograms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) GetProgram(ctx context.Context, in *GetProgramRequest, opts ...grpc.CallOption) (*Program, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Program)
+	err := c.cc.Invoke(ctx, GreyskullService_GetProgram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) GetNextWorkout(ctx context.Context, in *GetNextWorkoutRequest, opts ...grpc.CallOption) (*Workout, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workout)
+	err := c.cc.Invoke(ctx, GreyskullService_GetNextWorkout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) LogWorkout(ctx context.Context, in *LogWorkoutRequest, opts ...grpc.CallOption) (*LogWorkoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogWorkoutResponse)
+	err := c.cc.Invoke(ctx, GreyskullService_LogWorkout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greyskullServiceClient) ListWorkouts(ctx context.Context, in *ListWorkoutsRequest, opts ...grpc.CallOption) (*ListWorkoutsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkoutsResponse)
+	err := c.cc.Invoke(ctx, GreyskullService_ListWorkouts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GreyskullServiceServer is the server API for GreyskullService service.
+// All implementations must embed UnimplementedGreyskullServiceServer
+// for forward compatibility.
+//
+// GreyskullService exposes the operations behind the CLI's user, program, and
+// workout commands. Requests naming no user act as the current user.
+type GreyskullServiceServer interface {
+	// ListUsers returns every username, as 'greyskull user list' does
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// GetUser returns a user by username (case-insensitive), with their programs
+	// but without workout history
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// ListPrograms returns the built-in and custom program templates
+	ListPrograms(context.Context, *ListProgramsRequest) (*ListProgramsResponse, error)
+	// GetProgram returns a program template by ID or name
+	GetProgram(context.Context, *GetProgramRequest) (*Program, error)
+	// GetNextWorkout returns the user's next workout with its calculated
+	// weights, as 'greyskull workout next' does
+	GetNextWorkout(context.Context, *GetNextWorkoutRequest) (*Workout, error)
+	// LogWorkout records a completed workout, applies progression, and returns
+	// the user's program with its new weights, as 'greyskull workout log' does
+	LogWorkout(context.Context, *LogWorkoutRequest) (*LogWorkoutResponse, error)
+	// ListWorkouts returns the user's workout history, oldest first
+	ListWorkouts(context.Context, *ListWorkoutsRequest) (*ListWorkoutsResponse, error)
+	mustEmbedUnimplementedGreyskullServiceServer()
+}
+
+// UnimplementedGreyskullServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGreyskullServiceServer struct{}
+
+func (UnimplementedGreyskullServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedGreyskullServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedGreyskullServiceServer) ListPrograms(context.Context, *ListProgramsRequest) (*ListProgramsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrograms not implemented")
+}
+func (UnimplementedGreyskullServiceServer) GetProgram(context.Context, *GetProgramRequest) (*Program, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProgram not implemented")
+}
+func (UnimplementedGreyskullServiceServer) GetNextWorkout(context.Context, *GetNextWorkoutRequest) (*Workout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextWorkout not implemented")
+}
+func (UnimplementedGreyskullServiceServer) LogWorkout(context.Context, *LogWorkoutRequest) (*LogWorkoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogWorkout not implemented")
+}
+func (UnimplementedGreyskullServiceServer) ListWorkouts(context.Context, *ListWorkoutsRequest) (*ListWorkoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkouts not implemented")
+}
+func (UnimplementedGreyskullServiceServer) mustEmbedUnimplementedGreyskullServiceServer() {}
+func (UnimplementedGreyskullServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeGreyskullServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GreyskullServiceServer will
+// result in compilation errors.
+type UnsafeGreyskullServiceServer interface {
+	mustEmbedUnimplementedGreyskullServiceServer()
+}
+
+func RegisterGreyskullServiceServer(s grpc.ServiceRegistrar, srv GreyskullServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGreyskullServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GreyskullService_ServiceDesc, srv)
+}
+
+func _GreyskullService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_ListPrograms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProgramsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).ListPrograms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_ListPrograms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).ListPrograms(ctx, req.(*ListProgramsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_GetProgram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProgramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).GetProgram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_GetProgram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).GetProgram(ctx, req.(*GetProgramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_GetNextWorkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextWorkoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).GetNextWorkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_GetNextWorkout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).GetNextWorkout(ctx, req.(*GetNextWorkoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_LogWorkout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogWorkoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).LogWorkout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_LogWorkout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).LogWorkout(ctx, req.(*LogWorkoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GreyskullService_ListWorkouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreyskullServiceServer).ListWorkouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GreyskullService_ListWorkouts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreyskullServiceServer).ListWorkouts(ctx, req.(*ListWorkoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GreyskullService_ServiceDesc is the grpc.ServiceDesc for GreyskullService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GreyskullService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "greyskull.v1.GreyskullService",
+	HandlerType: (*GreyskullServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _GreyskullService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _GreyskullService_GetUser_Handler,
+		},
+		{
+			MethodName: "ListPrograms",
+			Handler:    _GreyskullService_ListPrograms_Handler,
+		},
+		{
+			MethodName: "GetProgram",
+			Handler:    _GreyskullService_GetProgram_Handler,
+		},
+		{
+			MethodName: "GetNextWorkout",
+			Handler:    _GreyskullService_GetNextWorkout_Handler,
+		},
+		{
+			MethodName: "LogWorkout",
+			Handler:    _GreyskullService_LogWorkout_Handler,
+		},
+		{
+			MethodName: "ListWorkouts",
+			Handler:    _GreyskullService_ListWorkouts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/greyskull/v1/greyskull.proto",
+}
@@ -0,0 +1,233 @@
+// Package audit describes the changes between two versions of a user's stored
+// data as entries for the user's audit log, so a wrong weight or a missing
+// workout can be traced back to the command that changed it.
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// workoutDateFormat is how workouts are dated in entries
+const workoutDateFormat = "2006-01-02"
+
+// ProgramNamer returns the name of the program a user program follows, or an
+// empty string if it can't be found
+type ProgramNamer func(programID uuid.UUID) string
+
+// Diff returns the changes from before to after: the user's current program, their workouts, each of their programs, then their other settings.
+// before is nil for a new user. Workouts are only compared when after's history
+// has been loaded, as history isn't saved otherwise. The entries' times and
+// commands are left for the caller to set.
+func Diff(before, after *models.User, name ProgramNamer) []models.AuditEntry {
+	if before == nil {
+		// A new user's settings are part of creating it
+		entries := []models.AuditEntry{{Action: models.UserCreated, After: after.Username}}
+		return append(entries, diffUser(&models.User{}, after, name, false)...)
+	}
+	return diffUser(before, after, name, true)
+}
+
+func diffUser(before, after *models.User, name ProgramNamer, compareSettings bool) []models.AuditEntry {
+	var entries []models.AuditEntry
+	programName := func(user *models.User, id uuid.UUID) string {
+		if up, exists := user.Programs[id]; exists {
+			return userProgramName(up, name)
+		}
+		return ""
+	}
+	// Starting a first program is described by its own entry
+	if before.CurrentProgram != uuid.Nil && before.CurrentProgram != after.CurrentProgram {
+		entries = append(entries, models.AuditEntry{
+			Action: models.ProgramSwitched,
+			Before: programName(before, before.CurrentProgram),
+			After:  programName(after, after.CurrentProgram),
+		})
+	}
+
+	if after.HistoryLoaded() && before.LoadHistory() == nil {
+		entries = append(entries, diffWorkouts(before, after, name)...)
+	}
+
+	for _, up := range after.ProgramList() {
+		previous, exists := before.Programs[up.ID]
+		if !exists {
+			entries = append(entries, models.AuditEntry{Action: models.ProgramStarted, After: userProgramName(up, name)})
+			previous = &models.UserProgram{}
+		}
+		entries = append(entries, diffProgram(previous, up, userProgramName(up, name))...)
+	}
+	for _, up := range before.ProgramList() {
+		if _, exists := after.Programs[up.ID]; !exists {
+			entries = append(entries, models.AuditEntry{Action: models.ProgramRemoved, Before: userProgramName(up, name)})
+		}
+	}
+
+	if compareSettings {
+		if changed := changedFields(userSettings(before), userSettings(after)); changed != "" {
+			entries = append(entries, models.AuditEntry{Action: models.UserUpdated, After: changed})
+		}
+	}
+	return entries
+}
+
+// diffProgram describes changes to a user program's weights and day, and
+// notes any other change to its settings
+func diffProgram(before, after *models.UserProgram, program string) []models.AuditEntry {
+	var entries []models.AuditEntry
+	unit := after.Unit.OrDefault()
+
+	lifts := slices.Sorted(maps.Keys(after.CurrentWeights))
+	for lift := range before.CurrentWeights {
+		if _, exists := after.CurrentWeights[lift]; !exists {
+			lifts = append(lifts, lift)
+		}
+	}
+	for _, lift := range lifts {
+		old, hadOld := before.CurrentWeights[lift]
+		weight, hasNew := after.CurrentWeights[lift]
+		if hadOld == hasNew && old == weight {
+			continue
+		}
+		entry := models.AuditEntry{Action: models.WeightChanged, Program: program, Lift: lift}
+		if hadOld {
+			entry.Before = formatWeight(old, before.Unit.OrDefault())
+		}
+		if hasNew {
+			entry.After = formatWeight(weight, unit)
+		}
+		entries = append(entries, entry)
+	}
+
+	if before.CurrentDay != 0 && before.CurrentDay != after.CurrentDay {
+		entries = append(entries, models.AuditEntry{
+			Action:  models.DayChanged,
+			Program: program,
+			Before:  fmt.Sprintf("Day %d", before.CurrentDay),
+			After:   fmt.Sprintf("Day %d", after.CurrentDay),
+		})
+	}
+
+	// A new program's settings are part of starting it
+	if before.ID != uuid.Nil {
+		if changed := changedFields(programSettings(before), programSettings(after)); changed != "" {
+			entries = append(entries, models.AuditEntry{Action: models.ProgramUpdated, Program: program, After: changed})
+		}
+	}
+	return entries
+}
+
+// diffWorkouts describes workouts added, changed, and removed, in date order
+func diffWorkouts(before, after *models.User, name ProgramNamer) []models.AuditEntry {
+	previous := make(map[uuid.UUID]models.Workout)
+	for _, workout := range before.WorkoutHistory {
+		previous[workout.ID] = workout
+	}
+
+	var entries []models.AuditEntry
+	for _, workout := range after.History() {
+		old, exists := previous[workout.ID]
+		delete(previous, workout.ID)
+		switch {
+		case !exists:
+			entries = append(entries, workoutEntry(models.WorkoutLogged, after, workout, name))
+		case !sameJSON(old, workout):
+			entries = append(entries, workoutEntry(models.WorkoutEdited, after, workout, name))
+		}
+	}
+	for _, workout := range before.History() {
+		if _, removed := previous[workout.ID]; removed {
+			entry := workoutEntry(models.WorkoutRemoved, before, workout, name)
+			entry.Before, entry.After = entry.After, ""
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// workoutEntry describes a workout by its day and date
+func workoutEntry(action models.AuditAction, user *models.User, workout models.Workout, name ProgramNamer) models.AuditEntry {
+	entry := models.AuditEntry{
+		Action: action,
+		After:  fmt.Sprintf("Day %d on %s", workout.Day, workout.EnteredAt.Format(workoutDateFormat)),
+	}
+	if up, exists := user.Programs[workout.UserProgramID]; exists {
+		entry.Program = userProgramName(up, name)
+	}
+	return entry
+}
+
+// userSettings returns the user without the fields Diff describes itself
+func userSettings(user *models.User) models.User {
+	settings := *user
+	settings.Username = ""
+	settings.CurrentProgram = uuid.Nil
+	settings.Programs = nil
+	settings.WorkoutHistory = nil
+	settings.SchemaVersion = 0
+	return settings
+}
+
+// programSettings returns the user program without the fields diffProgram
+// describes itself
+func programSettings(up *models.UserProgram) *models.UserProgram {
+	settings := up.Clone()
+	settings.CurrentWeights = nil
+	settings.CurrentDay = 0
+	return settings
+}
+
+// sameJSON reports whether two values are stored the same way
+func sameJSON(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// changedFields lists the stored fields that differ between two versions of
+// a struct, e.g. "goals, holds", or returns an empty string if none do
+func changedFields(before, after any) string {
+	fieldsBefore, fieldsAfter := storedFields(before), storedFields(after)
+	var changed []string
+	for field, value := range fieldsAfter {
+		if !bytes.Equal(fieldsBefore[field], value) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range fieldsBefore {
+		if _, exists := fieldsAfter[field]; !exists {
+			changed = append(changed, field)
+		}
+	}
+	slices.Sort(changed)
+	return strings.ReplaceAll(strings.Join(changed, ", "), "_", " ")
+}
+
+// storedFields returns each stored field of a struct as JSON
+func storedFields(v any) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	if data, err := json.Marshal(v); err == nil {
+		json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+// userProgramName names a user program by the program it follows
+func userProgramName(up *models.UserProgram, name ProgramNamer) string {
+	if programName := name(up.ProgramID); programName != "" {
+		return programName
+	}
+	return up.ProgramID.String()
+}
+
+func formatWeight(weight float64, unit models.WeightUnit) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64) + " " + string(unit)
+}
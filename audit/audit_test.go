@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var programID = uuid.New()
+
+func names(id uuid.UUID) string {
+	if id == programID {
+		return "OG Greyskull LP"
+	}
+	return ""
+}
+
+func testUser() *models.User {
+	upID := uuid.New()
+	return &models.User{
+		ID:             uuid.New(),
+		Username:       "Alice",
+		CurrentProgram: upID,
+		Programs: map[uuid.UUID]*models.UserProgram{upID: {
+			ID:             upID,
+			ProgramID:      programID,
+			CurrentWeights: map[models.LiftName]float64{models.Squat: 135, models.BenchPress: 95},
+			CurrentDay:     1,
+		}},
+		WorkoutHistory: []models.Workout{},
+	}
+}
+
+func TestDiff_NewUser(t *testing.T) {
+	user := testUser()
+	user.Programs = map[uuid.UUID]*models.UserProgram{}
+	user.CurrentProgram = uuid.Nil
+
+	assert.Equal(t, []models.AuditEntry{{Action: models.UserCreated, After: "Alice"}}, Diff(nil, user, names))
+}
+
+func TestDiff_WorkoutLogged(t *testing.T) {
+	before := testUser()
+	after := testUser()
+	after.ID, after.CurrentProgram = before.ID, before.CurrentProgram
+	after.Programs = map[uuid.UUID]*models.UserProgram{before.CurrentProgram: before.Programs[before.CurrentProgram].Clone()}
+
+	up := after.Programs[after.CurrentProgram]
+	up.CurrentWeights[models.Squat] = 140
+	up.CurrentDay = 2
+	up.Goals = map[models.LiftName]float64{models.Squat: 315}
+	after.AddWorkout(models.Workout{ID: uuid.New(), UserProgramID: up.ID, Day: 1, EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)})
+	after.Milestones = []models.UnlockedMilestone{{ID: "workouts-1"}}
+
+	assert.Equal(t, []models.AuditEntry{
+		{Action: models.WorkoutLogged, Program: "OG Greyskull LP", After: "Day 1 on 2024-03-04"},
+		{Action: models.WeightChanged, Program: "OG Greyskull LP", Lift: models.Squat, Before: "135 lbs", After: "140 lbs"},
+		{Action: models.DayChanged, Program: "OG Greyskull LP", Before: "Day 1", After: "Day 2"},
+		{Action: models.ProgramUpdated, Program: "OG Greyskull LP", After: "goals"},
+		{Action: models.UserUpdated, After: "milestones"},
+	}, Diff(before, after, names))
+
+	// Removing the workout again
+	assert.Equal(t, []models.AuditEntry{
+		{Action: models.WorkoutRemoved, Program: "OG Greyskull LP", Before: "Day 1 on 2024-03-04"},
+	}, Diff(after, &models.User{
+		ID: after.ID, Username: "Alice", CurrentProgram: after.CurrentProgram, Programs: after.Programs,
+		Milestones: after.Milestones, WorkoutHistory: []models.Workout{},
+	}, names))
+}
+
+func TestDiff_ProgramStartedAndSwitched(t *testing.T) {
+	before := testUser()
+	after := testUser()
+	after.ID = before.ID
+	for id, up := range before.Programs {
+		after.Programs[id] = up
+	}
+
+	entries := Diff(before, after, names)
+	assert.Equal(t, models.ProgramSwitched, entries[0].Action)
+	assert.Equal(t, "OG Greyskull LP", entries[0].Before)
+	assert.Contains(t, entries, models.AuditEntry{Action: models.ProgramStarted, After: "OG Greyskull LP"})
+	assert.Contains(t, entries, models.AuditEntry{Action: models.WeightChanged, Program: "OG Greyskull LP", Lift: models.Squat, After: "135 lbs"})
+	assert.NotContains(t, entries, models.AuditEntry{Action: models.DayChanged, Program: "OG Greyskull LP", After: "Day 1"})
+
+	// Histories that weren't loaded aren't compared
+	unloaded := testUser()
+	unloaded.ID, unloaded.CurrentProgram, unloaded.Programs = before.ID, before.CurrentProgram, before.Programs
+	unloaded.SetHistoryLoader(func() ([]models.Workout, error) {
+		return []models.Workout{{ID: uuid.New()}}, nil
+	})
+	assert.Empty(t, Diff(before, unloaded, names))
+}
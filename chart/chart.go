@@ -0,0 +1,186 @@
+// Package chart renders weight progression charts to image files. Renderers
+// implement a small interface so the output formats don't leak into commands.
+package chart
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+)
+
+// Point is a lift's working weight on a date
+type Point struct {
+	Date   time.Time
+	Weight float64
+}
+
+// Series is a labelled line on a chart, with points in date order
+type Series struct {
+	Label  string
+	Points []Point
+}
+
+// Chart is a weight-over-time chart with one or more series
+type Chart struct {
+	Title  string
+	Series []Series
+}
+
+// Renderer writes a chart in an image format
+type Renderer interface {
+	Render(w io.Writer, c *Chart) error
+}
+
+// Default image size in pixels
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 480
+)
+
+// RendererFor returns a renderer for a file based on its extension (.svg or .png)
+func RendererFor(filename string) (Renderer, error) {
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".svg":
+		return &SVGRenderer{Width: DefaultWidth, Height: DefaultHeight}, nil
+	case ".png":
+		return &PNGRenderer{Width: DefaultWidth, Height: DefaultHeight}, nil
+	default:
+		return nil, fmt.Errorf("unsupported chart format %q: use a .svg or .png file", ext)
+	}
+}
+
+// LiftSeries builds a series of a lift's AMRAP set weight in each workout of a
+// chronologically sorted history. key is a weight key, so variants are charted separately.
+func LiftSeries(history []models.Workout, key models.LiftName, label string) Series {
+	series := Series{Label: label}
+	for _, workout := range history {
+		for _, lift := range workout.Exercises {
+			if lift.Optional || lift.WeightKey() != key {
+				continue
+			}
+			for _, set := range lift.Sets {
+				if set.Type == models.AMRAPSet {
+					series.Points = append(series.Points, Point{Date: workout.EnteredAt, Weight: set.Weight})
+					break
+				}
+			}
+		}
+	}
+	return series
+}
+
+// ProjectionSeries builds a series of a lift's projected working weight, from
+// its starting weight on start through each simulated session
+func ProjectionSeries(projection *workout.Projection, start time.Time, key models.LiftName, label string) Series {
+	series := Series{Label: label}
+	if weight, exists := projection.Start[key]; exists {
+		series.Points = append(series.Points, Point{Date: start, Weight: weight})
+	}
+	for _, point := range projection.Points {
+		if weight, exists := point.Weights[key]; exists {
+			series.Points = append(series.Points, Point{Date: point.Date, Weight: weight})
+		}
+	}
+	return series
+}
+
+// palette holds the series colors, used in order
+var palette = []string{"#1f77b4", "#d62728", "#2ca02c", "#ff7f0e", "#9467bd"}
+
+// layout maps dates and weights onto image coordinates
+type layout struct {
+	width, height            int
+	left, right, top, bottom int
+	minX, maxX               time.Time
+	minY, maxY               float64
+	yTicks                   []float64
+	xTicks                   []time.Time
+}
+
+func newLayout(c *Chart, width, height int) (*layout, error) {
+	l := &layout{width: width, height: height, left: 70, right: 30, top: 50, bottom: 50}
+
+	first := true
+	for _, series := range c.Series {
+		for _, p := range series.Points {
+			if first {
+				l.minX, l.maxX, l.minY, l.maxY = p.Date, p.Date, p.Weight, p.Weight
+				first = false
+				continue
+			}
+			if p.Date.Before(l.minX) {
+				l.minX = p.Date
+			}
+			if p.Date.After(l.maxX) {
+				l.maxX = p.Date
+			}
+			l.minY = math.Min(l.minY, p.Weight)
+			l.maxY = math.Max(l.maxY, p.Weight)
+		}
+	}
+	if first {
+		return nil, fmt.Errorf("no data to chart")
+	}
+
+	// Round the weight axis out to whole tick steps
+	step := tickStep(l.maxY - l.minY)
+	l.minY = math.Floor(l.minY/step) * step
+	l.maxY = math.Ceil(l.maxY/step) * step
+	if l.maxY == l.minY {
+		l.minY -= step
+		l.maxY += step
+	}
+	for v := l.minY; v <= l.maxY+step/2; v += step {
+		l.yTicks = append(l.yTicks, v)
+	}
+
+	const xTickCount = 5
+	span := l.maxX.Sub(l.minX)
+	if span == 0 {
+		l.xTicks = []time.Time{l.minX}
+	} else {
+		for i := range xTickCount {
+			l.xTicks = append(l.xTicks, l.minX.Add(span*time.Duration(i)/(xTickCount-1)))
+		}
+	}
+
+	return l, nil
+}
+
+// tickStep picks a round weight interval that gives at most 6 ticks over span
+func tickStep(span float64) float64 {
+	for _, step := range []float64{5, 10, 25, 50, 100, 250, 500} {
+		if span/step <= 6 {
+			return step
+		}
+	}
+	return 1000
+}
+
+func (l *layout) plotWidth() float64  { return float64(l.width - l.left - l.right) }
+func (l *layout) plotHeight() float64 { return float64(l.height - l.top - l.bottom) }
+
+// x returns the horizontal position of a date; a single date is centered
+func (l *layout) x(t time.Time) float64 {
+	span := l.maxX.Sub(l.minX)
+	if span == 0 {
+		return float64(l.left) + l.plotWidth()/2
+	}
+	return float64(l.left) + l.plotWidth()*float64(t.Sub(l.minX))/float64(span)
+}
+
+// y returns the vertical position of a weight
+func (l *layout) y(weight float64) float64 {
+	return float64(l.top) + l.plotHeight()*(1-(weight-l.minY)/(l.maxY-l.minY))
+}
+
+// formatTick formats a weight axis label without trailing zeros
+func formatTick(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
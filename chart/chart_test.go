@@ -0,0 +1,126 @@
+package chart
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var chartBase = time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+
+func sampleChart() *Chart {
+	return &Chart{
+		Title: "Squat & Friends",
+		Series: []Series{{
+			Label: "Squat",
+			Points: []Point{
+				{Date: chartBase, Weight: 135},
+				{Date: chartBase.AddDate(0, 0, 2), Weight: 140},
+				{Date: chartBase.AddDate(0, 0, 4), Weight: 150},
+			},
+		}},
+	}
+}
+
+func TestLiftSeries(t *testing.T) {
+	amrap := func(name models.LiftName, variant string, weight float64) models.Lift {
+		return models.Lift{LiftName: name, Variant: variant, Sets: []models.Set{
+			{Weight: 45, Type: models.WarmupSet},
+			{Weight: weight, Type: models.AMRAPSet, ActualReps: 5},
+		}}
+	}
+	history := []models.Workout{
+		{EnteredAt: chartBase, Exercises: []models.Lift{amrap(models.Squat, "", 135), amrap(models.OverheadPress, "", 95)}},
+		{EnteredAt: chartBase.AddDate(0, 0, 2), Exercises: []models.Lift{amrap(models.Squat, "SSB", 120)}},
+		{EnteredAt: chartBase.AddDate(0, 0, 4), Exercises: []models.Lift{amrap(models.Squat, "", 140)}},
+	}
+
+	series := LiftSeries(history, models.Squat, "Squat")
+	assert.Equal(t, Series{Label: "Squat", Points: []Point{
+		{Date: chartBase, Weight: 135},
+		{Date: chartBase.AddDate(0, 0, 4), Weight: 140},
+	}}, series)
+
+	assert.Len(t, LiftSeries(history, "Squat:SSB", "Squat (SSB)").Points, 1)
+	assert.Empty(t, LiftSeries(history, models.Deadlift, "Deadlift").Points)
+}
+
+func TestRendererFor(t *testing.T) {
+	renderer, err := RendererFor("squat.SVG")
+	require.NoError(t, err)
+	assert.IsType(t, &SVGRenderer{}, renderer)
+
+	renderer, err = RendererFor("out/squat.png")
+	require.NoError(t, err)
+	assert.IsType(t, &PNGRenderer{}, renderer)
+
+	_, err = RendererFor("squat.jpg")
+	assert.EqualError(t, err, `unsupported chart format ".jpg": use a .svg or .png file`)
+}
+
+func TestLayout(t *testing.T) {
+	l, err := newLayout(sampleChart(), 800, 480)
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{135, 140, 145, 150}, l.yTicks)
+	assert.Len(t, l.xTicks, 5)
+	assert.Equal(t, float64(l.left), l.x(chartBase))
+	assert.Equal(t, float64(800-l.right), l.x(chartBase.AddDate(0, 0, 4)))
+	assert.Equal(t, float64(l.top), l.y(150))
+	assert.Equal(t, float64(480-l.bottom), l.y(135))
+}
+
+func TestLayout_SinglePoint(t *testing.T) {
+	c := &Chart{Series: []Series{{Points: []Point{{Date: chartBase, Weight: 95}}}}}
+
+	l, err := newLayout(c, 800, 480)
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{90, 95, 100}, l.yTicks)
+	assert.Equal(t, []time.Time{chartBase}, l.xTicks)
+	assert.Equal(t, float64(l.left)+l.plotWidth()/2, l.x(chartBase))
+}
+
+func TestRender_NoData(t *testing.T) {
+	for _, renderer := range []Renderer{&SVGRenderer{Width: 800, Height: 480}, &PNGRenderer{Width: 800, Height: 480}} {
+		err := renderer.Render(&bytes.Buffer{}, &Chart{Series: []Series{{Label: "Squat"}}})
+		assert.EqualError(t, err, "no data to chart")
+	}
+}
+
+func TestSVGRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	err := (&SVGRenderer{Width: 800, Height: 480}).Render(&buf, sampleChart())
+	require.NoError(t, err)
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="800" height="480"`))
+	assert.Contains(t, svg, ">Squat &amp; Friends</text>")
+	assert.Contains(t, svg, `points="70.0,430.0 420.0,303.3 770.0,50.0"`)
+	assert.Contains(t, svg, ">2024-03-04</text>")
+	assert.Contains(t, svg, ">145</text>")
+	// A single series needs no legend
+	assert.NotContains(t, svg, `width="12" height="12"`)
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+}
+
+func TestPNGRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	err := (&PNGRenderer{Width: 400, Height: 300}).Render(&buf, sampleChart())
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 400, img.Bounds().Dx())
+	assert.Equal(t, 300, img.Bounds().Dy())
+
+	// The first point is drawn in the first palette color
+	assert.Equal(t, parseHexColor(palette[0]), color.RGBAModel.Convert(img.At(70, 250)))
+}
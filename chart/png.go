@@ -0,0 +1,158 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+// PNGRenderer renders charts as PNG images using only the standard library.
+// Without a font library, text is limited to the numeric axis labels, drawn
+// with a small built-in bitmap font; the title and legend are left out.
+type PNGRenderer struct {
+	Width  int
+	Height int
+}
+
+// Render writes the chart as a PNG image
+func (r *PNGRenderer) Render(w io.Writer, c *Chart) error {
+	l, err := newLayout(c, r.Width, r.Height)
+	if err != nil {
+		return err
+	}
+
+	canvas := &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))}
+	canvas.fill(color.White)
+
+	grid := color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	for _, tick := range l.yTicks {
+		y := l.y(tick)
+		canvas.line(float64(l.left), y, float64(r.Width-l.right), y, 1, grid)
+		label := formatTick(tick)
+		canvas.text(l.left-8-textWidth(label), int(y)-glyphHeight/2, label, color.Black)
+	}
+	for _, tick := range l.xTicks {
+		label := tick.Format("2006-01-02")
+		canvas.text(int(l.x(tick))-textWidth(label)/2, r.Height-l.bottom+10, label, color.Black)
+	}
+
+	bottom := float64(r.Height - l.bottom)
+	canvas.line(float64(l.left), float64(l.top), float64(l.left), bottom, 1, color.Black)
+	canvas.line(float64(l.left), bottom, float64(r.Width-l.right), bottom, 1, color.Black)
+
+	for i, series := range c.Series {
+		lineColor := parseHexColor(palette[i%len(palette)])
+		for j := 1; j < len(series.Points); j++ {
+			from, to := series.Points[j-1], series.Points[j]
+			canvas.line(l.x(from.Date), l.y(from.Weight), l.x(to.Date), l.y(to.Weight), 2, lineColor)
+		}
+		for _, point := range series.Points {
+			canvas.dot(l.x(point.Date), l.y(point.Weight), 3, lineColor)
+		}
+	}
+
+	return png.Encode(w, canvas.img)
+}
+
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func (c *pngCanvas) fill(col color.Color) {
+	bounds := c.img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c.img.Set(x, y, col)
+		}
+	}
+}
+
+// dot draws a filled square of the given radius centered on (x, y)
+func (c *pngCanvas) dot(x, y float64, radius int, col color.Color) {
+	cx, cy := int(math.Round(x)), int(math.Round(y))
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			c.img.Set(cx+dx, cy+dy, col)
+		}
+	}
+}
+
+// line draws a straight line of the given thickness by stepping along its longer axis
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, thickness int, col color.Color) {
+	steps := int(math.Max(math.Abs(x2-x1), math.Abs(y2-y1)))
+	if steps == 0 {
+		steps = 1
+	}
+	radius := (thickness - 1) / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := x1 + (x2-x1)*t
+		y := y1 + (y2-y1)*t
+		if thickness <= 1 {
+			c.img.Set(int(math.Round(x)), int(math.Round(y)), col)
+		} else {
+			c.dot(x, y, max(radius, 1), col)
+		}
+	}
+}
+
+// Bitmap font metrics: glyphs are 3x5 cells drawn at glyphScale pixels per cell
+const (
+	glyphScale  = 2
+	glyphWidth  = 3 * glyphScale
+	glyphHeight = 5 * glyphScale
+	glyphGap    = glyphScale
+)
+
+// glyphs covers the characters used in axis labels: digits, '-', and '.'
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'-': {"...", "...", "###", "...", "..."},
+	'.': {"...", "...", "...", "...", ".#."},
+}
+
+func textWidth(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(s)*(glyphWidth+glyphGap) - glyphGap
+}
+
+// text draws s with its top-left corner at (x, y), skipping unsupported characters
+func (c *pngCanvas) text(x, y int, s string, col color.Color) {
+	for _, ch := range s {
+		if glyph, ok := glyphs[ch]; ok {
+			for row, bits := range glyph {
+				for column, bit := range bits {
+					if bit != '#' {
+						continue
+					}
+					for dy := range glyphScale {
+						for dx := range glyphScale {
+							c.img.Set(x+column*glyphScale+dx, y+row*glyphScale+dy, col)
+						}
+					}
+				}
+			}
+		}
+		x += glyphWidth + glyphGap
+	}
+}
+
+// parseHexColor parses a "#rrggbb" palette color
+func parseHexColor(hex string) color.RGBA {
+	value, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xff}
+}
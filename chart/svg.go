@@ -0,0 +1,76 @@
+package chart
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// SVGRenderer renders charts as SVG images
+type SVGRenderer struct {
+	Width  int
+	Height int
+}
+
+// Render writes the chart as an SVG document
+func (r *SVGRenderer) Render(w io.Writer, c *Chart) error {
+	l, err := newLayout(c, r.Width, r.Height)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	p := func(format string, a ...any) {
+		fmt.Fprintf(out, format, a...)
+	}
+
+	p(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		r.Width, r.Height, r.Width, r.Height)
+	p(`<rect width="100%%" height="100%%" fill="white"/>` + "\n")
+	p(`<text x="%d" y="28" text-anchor="middle" font-size="18">%s</text>`+"\n", r.Width/2, html.EscapeString(c.Title))
+
+	// Weight gridlines and labels
+	for _, tick := range l.yTicks {
+		y := l.y(tick)
+		p(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#dddddd"/>`+"\n", l.left, y, r.Width-l.right, y)
+		p(`<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", l.left-8, y, formatTick(tick))
+	}
+
+	// Date labels
+	for _, tick := range l.xTicks {
+		p(`<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", l.x(tick), r.Height-l.bottom+20, tick.Format("2006-01-02"))
+	}
+
+	// Axes
+	p(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", l.left, l.top, l.left, r.Height-l.bottom)
+	p(`<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", l.left, r.Height-l.bottom, r.Width-l.right, r.Height-l.bottom)
+	p(`<text x="18" y="%d" text-anchor="middle" transform="rotate(-90 18 %d)">Weight (lbs)</text>`+"\n", r.Height/2, r.Height/2)
+
+	for i, series := range c.Series {
+		color := palette[i%len(palette)]
+		p(`<polyline fill="none" stroke="%s" stroke-width="2" points="`, color)
+		for j, point := range series.Points {
+			if j > 0 {
+				p(" ")
+			}
+			p("%.1f,%.1f", l.x(point.Date), l.y(point.Weight))
+		}
+		p(`"/>` + "\n")
+		for _, point := range series.Points {
+			p(`<circle cx="%.1f" cy="%.1f" r="3" fill="%s"/>`+"\n", l.x(point.Date), l.y(point.Weight), color)
+		}
+	}
+
+	// Legend, only needed to tell several series apart
+	if len(c.Series) > 1 {
+		for i, series := range c.Series {
+			y := l.top + 10 + i*18
+			p(`<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`+"\n", l.left+10, y-6, palette[i%len(palette)])
+			p(`<text x="%d" y="%d" dominant-baseline="middle">%s</text>`+"\n", l.left+28, y, html.EscapeString(series.Label))
+		}
+	}
+
+	p("</svg>\n")
+	return out.Flush()
+}
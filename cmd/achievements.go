@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/milestones"
+	"github.com/spf13/cobra"
+)
+
+var achievementsCmd = &cobra.Command{
+	Use:   "achievements",
+	Short: "List the training milestones you've unlocked",
+	Long: `List the milestones you've unlocked, with the date of the workout that reached
+each, followed by the ones still to unlock. Milestones include your first sets
+at landmark weights such as a 225 lb squat, the 1000 lb club (squat, bench
+press, and deadlift adding up to 1000 lbs), workout counts such as your 100th,
+and training every week for weeks in a row.
+
+'greyskull workout log' announces milestones as you reach them. Milestones
+reached by workouts logged before they were tracked are unlocked here too.`,
+	Args: cobra.NoArgs,
+	RunE: showAchievements,
+}
+
+func init() {
+	rootCmd.AddCommand(achievementsCmd)
+}
+
+func showAchievements(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	// Catch up on milestones reached before they were tracked
+	if len(milestones.Unlock(user)) > 0 {
+		if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+	}
+
+	formatter := display.NewMilestoneFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.DisplayMilestones(user.Milestones)
+	outputFor(cmd).Result(nonNil(user.Milestones))
+	return nil
+}
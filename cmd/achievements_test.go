@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAchievements(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "achievements")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Achievements (0 of ")
+	assert.Contains(t, output, "  None yet. Log a workout to unlock your first.\n")
+	assert.Contains(t, output, "  1000 lb Club: Reach a combined 1000 lbs across your heaviest squat, bench press, and deadlift\n")
+
+	output, err = executePiped(t, "8\n8\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "\nMilestone unlocked! 135 lb Squat: Complete a working set of Squat at 135 lbs or more\n"+
+		"Milestone unlocked! 95 lb Overhead Press: Complete a working set of Overhead Press at 95 lbs or more\n"+
+		"Milestone unlocked! First Workout: Log your first workout\n\nWorkout logged successfully!")
+	assert.Equal(t, []string{"squat-135", "overheadpress-95", "workouts-1"}, milestoneIDs(loadTestUser(t).Milestones))
+
+	// Each milestone is only announced once
+	output, err = executePiped(t, "8\n8\n", "workout", "log")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Milestone unlocked!")
+
+	output, err = executePiped(t, "", "achievements")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Achievements (3 of ")
+	assert.Regexp(t, `  \d{4}-\d{2}-\d{2}  135 lb Squat\n`, output)
+	assert.NotContains(t, output, "  135 lb Squat:")
+}
+
+func TestAchievements_UnlocksEarlierHistory(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "achievements")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  2024-03-04  135 lb Squat\n")
+	assert.Contains(t, output, "  2024-03-04  95 lb Overhead Press\n")
+	assert.Contains(t, output, "  2024-03-04  First Workout\n")
+	assert.Contains(t, output, "  225 lb Deadlift: ")
+	assert.Len(t, loadTestUser(t).Milestones, 3)
+}
+
+func milestoneIDs(unlocked []models.UnlockedMilestone) []string {
+	ids := make([]string, len(unlocked))
+	for i, m := range unlocked {
+		ids[i] = m.ID
+	}
+	return ids
+}
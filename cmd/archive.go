@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Archive old workouts to keep everyday commands fast",
+	Long: `Move workouts older than a given age out of your active history into a
+compressed archive file.
+
+Archived workouts aren't loaded by everyday commands such as 'workout log' and
+'workout next', so they no longer count toward "New PR!" banners, but they can
+still be included in history and statistics with --include-archived on 'stats',
+'stats chart', 'pr', and 'export csv'.
+
+Ages are a number followed by d (days), w (weeks), m (months), or y (years).`,
+	Example: "  greyskull archive --older-than 2y",
+	Args:    cobra.NoArgs,
+	RunE:    archiveWorkouts,
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	archiveCmd.Flags().String("older-than", "1y", "Archive workouts older than this age, e.g. 90d, 26w, 6m, or 2y")
+}
+
+func archiveWorkouts(cmd *cobra.Command, args []string) error {
+	ageInput, err := cmd.Flags().GetString("older-than")
+	if err != nil {
+		return fmt.Errorf("failed to get older-than flag: %w", err)
+	}
+
+	cutoff, err := archiveCutoff(ageInput, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+	if ctx.ArchiveRepo == nil {
+		return fmt.Errorf("archiving is not supported by this storage backend")
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	if err := user.LoadHistory(); err != nil {
+		return fmt.Errorf("failed to load workout history: %w", err)
+	}
+
+	archived := user.HistoryBetween(time.Time{}, cutoff)
+	if len(archived) == 0 {
+		printf(cmd, "No workouts older than %s to archive.\n", cutoff.Format("2006-01-02"))
+		return nil
+	}
+	remaining := user.HistoryBetween(cutoff, time.Time{})
+
+	if err := backupUser(cmd, ctx, user.Username, "archive"); err != nil {
+		return err
+	}
+
+	// Write the archive before removing anything from the active history
+	name, err := ctx.ArchiveRepo.Save(user.ID, archived)
+	if err != nil {
+		return err
+	}
+
+	user.WorkoutHistory = remaining
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		// Don't leave the workouts in both places
+		ctx.ArchiveRepo.Delete(user.ID, name)
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "Archived %d workout(s) from %s to %s.\n", len(archived),
+		archived[0].EnteredAt.Format("2006-01-02"), archived[len(archived)-1].EnteredAt.Format("2006-01-02"))
+	printf(cmd, "%d workout(s) remain in your active history.\n", len(remaining))
+
+	return nil
+}
+
+// archiveCutoff returns the time an age such as "90d" or "2y" before now
+func archiveCutoff(age string, now time.Time) (time.Time, error) {
+	age = strings.ToLower(strings.TrimSpace(age))
+	invalid := fmt.Errorf("invalid age %q: expected a number followed by d, w, m, or y, e.g. 2y", age)
+	if len(age) < 2 {
+		return time.Time{}, invalid
+	}
+
+	count, err := strconv.Atoi(age[:len(age)-1])
+	if err != nil || count <= 0 {
+		return time.Time{}, invalid
+	}
+
+	switch age[len(age)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -count), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*count), nil
+	case 'm':
+		return now.AddDate(0, -count, 0), nil
+	case 'y':
+		return now.AddDate(-count, 0, 0), nil
+	}
+	return time.Time{}, invalid
+}
+
+// addIncludeArchivedFlag adds the --include-archived flag read by historyUser
+func addIncludeArchivedFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("include-archived", false, "Include archived workouts")
+}
+
+// historyUser returns the user whose history a command should read: the user
+// itself, or a copy whose history also contains archived workouts when
+// --include-archived is set. The copy must not be saved.
+func historyUser(cmd *cobra.Command, ctx *services.CommandContext, user *models.User) (*models.User, error) {
+	includeArchived, err := cmd.Flags().GetBool("include-archived")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get include-archived flag: %w", err)
+	}
+	if !includeArchived {
+		return user, nil
+	}
+	if ctx.ArchiveRepo == nil {
+		return nil, fmt.Errorf("archived workouts are not supported by this storage backend")
+	}
+
+	archived, err := ctx.ArchiveRepo.Load(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived workouts: %w", err)
+	}
+
+	if err := user.LoadHistory(); err != nil {
+		return nil, fmt.Errorf("failed to load workout history: %w", err)
+	}
+	combined := *user
+	combined.WorkoutHistory = slices.Concat(archived, user.WorkoutHistory)
+	return &combined, nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runArchive(t *testing.T, olderThan string) (string, error) {
+	var buf bytes.Buffer
+	cmd := archiveCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("older-than", olderThan))
+	t.Cleanup(func() { cmd.Flags().Set("older-than", "1y") })
+
+	err := cmd.RunE(cmd, []string{})
+	return buf.String(), err
+}
+
+func TestArchive(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createUserWithHistory(t, env)
+
+	// Add a recent workout that should stay in the active history
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{
+		ID: uuid.New(), UserProgramID: user.CurrentProgram, Day: 3, EnteredAt: time.Now().AddDate(0, 0, -1),
+	})
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err := runArchive(t, "1y")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Archived 2 workout(s) from 2024-03-04 to 2024-03-06.")
+	assert.Contains(t, output, "1 workout(s) remain in your active history.")
+
+	active := loadTestUser(t)
+	require.Len(t, active.WorkoutHistory, 1)
+	assert.Equal(t, 3, active.WorkoutHistory[0].Day)
+
+	// A second run has nothing left to archive
+	output, err = runArchive(t, "1y")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No workouts older than")
+}
+
+func TestArchive_IncludeArchived(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	_, err := runArchive(t, "1y")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Equal(t, "No workouts logged yet.\n", output)
+
+	output, err = executePiped(t, "", "stats", "--include-archived")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workouts: 2 from 2024-03-04 to 2024-03-06")
+}
+
+func TestArchiveCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		age      string
+		expected time.Time
+	}{
+		{"90d", time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{"6M", time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)},
+		{"2y", time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			cutoff, err := archiveCutoff(tt.age, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cutoff)
+		})
+	}
+
+	for _, age := range []string{"", "y", "0y", "-1y", "2h", "twoy"} {
+		_, err := archiveCutoff(age, now)
+		assert.ErrorContains(t, err, "invalid age", age)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the log of changes to your data",
+	Long: `Show the latest changes made to the current user's data, oldest first, with
+when each was made and the command that made it: workouts logged, edited, or
+removed, working weights changed, programs started, switched, or removed, the
+next day changing, and other changes to your settings and programs.
+
+Every change is appended to the log as it's saved, so a surprising weight can be
+traced back to the workout or command that set it. Use --lift to follow a
+single lift's weight.`,
+	Example: `  greyskull audit
+  greyskull audit --lift squat --limit 0`,
+	Args: cobra.NoArgs,
+	RunE: showAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().Int("limit", 20, "Number of changes to show, or 0 for all")
+	auditCmd.Flags().String("lift", "", "Only show changes to this lift's weight")
+	auditCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+}
+
+func showAudit(cmd *cobra.Command, args []string) error {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get limit flag: %w", err)
+	}
+	if limit < 0 {
+		return fmt.Errorf("limit can't be negative, got: %d", limit)
+	}
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+	var lift models.LiftName
+	if liftInput != "" {
+		if lift, err = models.ParseLiftName(liftInput); err != nil {
+			return err
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+	if ctx.AuditRepo == nil {
+		return fmt.Errorf("the audit log is not supported by this storage backend")
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	entries, err := ctx.AuditRepo.Load(user.ID)
+	if err != nil {
+		return err
+	}
+	if lift != "" {
+		var matching []models.AuditEntry
+		for _, entry := range entries {
+			if entry.Lift == lift {
+				matching = append(matching, entry)
+			}
+		}
+		entries = matching
+	}
+	total := len(entries)
+	if limit > 0 && total > limit {
+		entries = entries[total-limit:]
+	}
+
+	formatter := display.NewAuditFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.DisplayAudit(user.Username, entries, total)
+	outputFor(cmd).Result(nonNil(entries))
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "8\n4\n", "workout", "log")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "audit")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  workout log  workout logged (OG Greyskull LP): Day 1 on ")
+	assert.Contains(t, output, "  workout log  weight changed Squat (OG Greyskull LP): 135 lbs → 120 lbs\n")
+	assert.Contains(t, output, "  workout log  next day changed (OG Greyskull LP): Day 1 → Day 2\n")
+
+	output, err = executePiped(t, "", "audit", "--lift", "squat")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Audit log for TestUser (1 change):\n")
+	assert.Contains(t, output, "weight changed Squat (OG Greyskull LP): 135 lbs → 120 lbs\n")
+	assert.NotContains(t, output, "Overhead Press")
+
+	output, err = executePiped(t, "", "audit", "--limit", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Audit log for TestUser (latest 1 of ")
+
+	_, err = executePiped(t, "", "audit", "--limit", "-1")
+	assert.ErrorContains(t, err, "limit can't be negative")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var backupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "Find automatic backups of your data",
+	Long: `Commands that rewrite a user's history or program, such as 'import' and
+restarting a program with 'program start', first save a copy of the user's
+data file. The path of each copy is printed when it's taken; use
+'greyskull backups list' to find them later.
+
+To roll back, copy a backup over the user's file in the users directory.`,
+}
+
+var backupsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List automatic backups, newest first",
+	Example: "  greyskull backups list\n  greyskull backups list --user alice",
+	Args:    cobra.NoArgs,
+	RunE:    listBackups,
+}
+
+func init() {
+	rootCmd.AddCommand(backupsCmd)
+	backupsCmd.AddCommand(backupsListCmd)
+	backupsListCmd.Flags().String("user", "", "Only list backups for this user")
+}
+
+func listBackups(cmd *cobra.Command, args []string) error {
+	username, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return fmt.Errorf("failed to get user flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+	if ctx.BackupRepo == nil {
+		return fmt.Errorf("backups are not supported by this storage backend")
+	}
+
+	backups, err := ctx.BackupRepo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	found := false
+	for _, backup := range backups {
+		if username != "" && !strings.EqualFold(backup.Username, username) {
+			continue
+		}
+		if !found {
+			printf(cmd, "Backups:\n")
+			found = true
+		}
+		printf(cmd, "  %s  %-8s  %-8s  %s\n", backup.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			backup.Username, backup.Reason, backup.Path)
+	}
+	if !found {
+		printf(cmd, "No backups found.\n")
+	}
+
+	return nil
+}
+
+// backupUser saves a copy of the user's stored data before a destructive
+// command changes it and prints where the copy was written. Storage backends
+// without backup support, and users with nothing stored yet, are skipped.
+func backupUser(cmd *cobra.Command, ctx *services.CommandContext, username, reason string) error {
+	if ctx.BackupRepo == nil {
+		return nil
+	}
+
+	backup, err := ctx.BackupRepo.Snapshot(username, reason)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up user data: %w", err)
+	}
+
+	printf(cmd, "Backed up %s's data to %s\n", username, backup.Path)
+	return nil
+}
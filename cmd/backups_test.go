@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runBackupsList(t *testing.T, user string) string {
+	var buf bytes.Buffer
+	cmd := backupsListCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("user", user))
+	t.Cleanup(func() { cmd.Flags().Set("user", "") })
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	return buf.String()
+}
+
+func TestBackups_Import(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	assert.Contains(t, runBackupsList(t, ""), "No backups found.")
+
+	output, err := runImport(t, importHistoryCSV, "history.csv", false)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up TestUser's data to ")
+
+	// The backup holds the user as it was before the import
+	path := strings.TrimSpace(strings.SplitN(strings.SplitAfter(output, "Backed up TestUser's data to ")[1], "\n", 2)[0])
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"workout_history": []`)
+
+	listing := runBackupsList(t, "")
+	assert.Contains(t, listing, "testuser")
+	assert.Contains(t, listing, "import")
+	assert.Contains(t, listing, path)
+
+	assert.Contains(t, runBackupsList(t, "someone-else"), "No backups found.")
+	assert.Contains(t, runBackupsList(t, "TESTUSER"), path)
+}
+
+func TestBackups_DryRunImport(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := runImport(t, importHistoryCSV, "history.csv", true)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Backed up")
+	assert.Contains(t, runBackupsList(t, ""), "No backups found.")
+}
+
+func TestBackups_ProgramRestart(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := programStartCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("1\n135\n185\n125\n95\n"))
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "Backed up TestUser's data to ")
+	assert.Contains(t, runBackupsList(t, ""), "restart")
+}
+
+func TestBackups_HistoryEdits(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := runArchive(t, "1y")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up TestUser's data to ")
+
+	output, err = executePiped(t, "", "program", "reset-weights", "--yes")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up TestUser's data to ")
+
+	listing := runBackupsList(t, "")
+	assert.Contains(t, listing, "archive")
+	assert.Contains(t, listing, "reset-weights")
+}
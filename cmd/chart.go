@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var chartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Chart a lift's progression in the terminal",
+	Long: `Draw a chart of a lift's working weight and estimated one-rep max over time,
+taken from the AMRAP set of each logged workout. Each column is one session;
+longer histories are sampled down to --width sessions.
+
+To save a chart as an image instead, use "greyskull stats chart".`,
+	Example: "  greyskull chart --lift squat",
+	Args:    cobra.NoArgs,
+	RunE:    showChart,
+}
+
+func init() {
+	rootCmd.AddCommand(chartCmd)
+	chartCmd.Flags().String("lift", "", "Lift to chart (squat, deadlift, bench, ohp)")
+	chartCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+	chartCmd.Flags().Int("width", display.DefaultChartWidth, "Maximum number of sessions to plot")
+	chartCmd.Flags().Int("height", display.DefaultChartHeight, "Height of the chart in rows")
+	chartCmd.MarkFlagRequired("lift")
+	addIncludeArchivedFlag(chartCmd)
+}
+
+func showChart(cmd *cobra.Command, args []string) error {
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+	width, err := cmd.Flags().GetInt("width")
+	if err != nil {
+		return fmt.Errorf("failed to get width flag: %w", err)
+	}
+	height, err := cmd.Flags().GetInt("height")
+	if err != nil {
+		return fmt.Errorf("failed to get height flag: %w", err)
+	}
+	if width < 2 || height < 2 {
+		return fmt.Errorf("chart width and height must be at least 2")
+	}
+
+	lift, err := models.ParseLiftName(liftInput)
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	user, err = historyUser(cmd, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	unit := user.Unit
+	if userProgram, exists := user.Programs[user.CurrentProgram]; exists {
+		unit = userProgram.Unit
+	}
+
+	formatter := display.NewChartFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, unit))
+	formatter.DisplayProgress(lift, analytics.LiftProgress(user.History(), lift), width, height)
+	return nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runChart(t *testing.T, lift string) (string, error) {
+	var buf bytes.Buffer
+	cmd := chartCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("lift", lift))
+	t.Cleanup(func() {
+		cmd.Flags().Set("lift", "")
+	})
+
+	err := cmd.RunE(cmd, []string{})
+	return buf.String(), err
+}
+
+func TestChart(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := runChart(t, "ohp")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Overhead Press progression: 2024-03-04 to 2024-03-04 (1 session)\n")
+	assert.Contains(t, output, "● Working weight: 95 → 95 lbs\n")
+	assert.Contains(t, output, "95 ┤●\n")
+
+	output, err = runChart(t, "deadlift")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Deadlift progression: 2024-03-06 to 2024-03-06")
+}
+
+func TestChart_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := runChart(t, "curl")
+	assert.ErrorContains(t, err, `unknown lift "curl"`)
+
+	output, err := runChart(t, "squat")
+	require.NoError(t, err)
+	assert.Equal(t, "No Squat workouts logged yet.\n", output)
+}
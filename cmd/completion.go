@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a tab completion script for your shell. Completion covers commands and
+flags, and also the data you've stored: usernames for 'user switch', lift names
+(including lifts added with 'greyskull lift define') wherever a lift is expected,
+and config keys.
+
+Bash (requires the bash-completion package):
+  greyskull completion bash > /etc/bash_completion.d/greyskull
+  # or, for the current shell only:
+  source <(greyskull completion bash)
+
+Zsh (with compinit enabled):
+  greyskull completion zsh > "${fpath[1]}/_greyskull"
+
+Fish:
+  greyskull completion fish > ~/.config/fish/completions/greyskull.fish
+
+PowerShell:
+  greyskull completion powershell | Out-String | Invoke-Expression
+
+Start a new shell for the completions to take effect.`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE:      generateCompletion,
+}
+
+// builtinLiftInputs are the shortest names accepted for each built-in lift
+var builtinLiftInputs = map[models.LiftName]string{
+	models.Squat:         "squat",
+	models.Deadlift:      "deadlift",
+	models.BenchPress:    "bench",
+	models.OverheadPress: "ohp",
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+func generateCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	root := cmd.Root()
+
+	var err error
+	switch args[0] {
+	case "bash":
+		err = root.GenBashCompletionV2(out, true)
+	case "zsh":
+		err = root.GenZshCompletion(out)
+	case "fish":
+		err = root.GenFishCompletion(out, true)
+	case "powershell":
+		err = root.GenPowerShellCompletionWithDesc(out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate %s completion: %w", args[0], err)
+	}
+	return nil
+}
+
+// completeFirstArg offers complete's suggestions for a command's first argument
+// only, leaving later arguments, such as weights, without suggestions
+func completeFirstArg(complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeUsernames suggests the stored usernames
+func completeUsernames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	usernames, err := ctx.UserRepo.List(contextFor(cmd))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []cobra.Completion
+	for _, username := range usernames {
+		if hasPrefixFold(username, toComplete) {
+			completions = append(completions, username)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLiftNames suggests the built-in lifts and the registered custom
+// lifts, described by their display names
+func completeLiftNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, def := range models.Lifts() {
+		input, builtin := builtinLiftInputs[def.Name]
+		if !builtin {
+			input = liftInput(def.Name)
+		}
+		if hasPrefixFold(input, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(input, display.FormatLiftName(def.Name)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigKeys suggests the config keys
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, key := range services.ConfigKeys() {
+		if strings.HasPrefix(key, toComplete) {
+			completions = append(completions, key)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// liftInput writes a custom lift's name the way it's typed, e.g. "front-squat"
+// for FrontSquat
+func liftInput(name models.LiftName) string {
+	var input strings.Builder
+	for i, r := range string(name) {
+		if i > 0 && unicode.IsUpper(r) {
+			input.WriteRune('-')
+		}
+		input.WriteRune(unicode.ToLower(r))
+	}
+	return input.String()
+}
+
+// isCompletionRequest reports whether cmd is cobra's hidden command that the
+// completion scripts call to ask for suggestions
+func isCompletionRequest(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completions returns the suggestions from a completion request, without the
+// directive line that ends it
+func completions(t *testing.T, args ...string) []string {
+	output, err := executePiped(t, "", append([]string{"__complete"}, args...)...)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if strings.HasPrefix(line, ":") || strings.HasPrefix(line, "Completion ended") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestCompletion_Scripts(t *testing.T) {
+	setupTestEnv(t)
+
+	for shell, want := range map[string]string{
+		"bash":       "__start_greyskull",
+		"zsh":        "#compdef greyskull",
+		"fish":       "complete -c greyskull",
+		"powershell": "Register-ArgumentCompleter",
+	} {
+		output, err := executePiped(t, "", "completion", shell)
+		require.NoError(t, err, shell)
+		assert.Contains(t, output, want, shell)
+	}
+
+	_, err := executePiped(t, "", "completion", "tcsh")
+	assert.Error(t, err)
+}
+
+func TestCompletion_Usernames(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	_, err := executePiped(t, "Alice\n", "user", "create")
+	require.NoError(t, err)
+	_, err = executePiped(t, "Adam\n", "user", "create")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"Adam", "Alice", "TestUser"}, completions(t, "user", "switch", ""))
+	assert.ElementsMatch(t, []string{"Adam", "Alice"}, completions(t, "user", "switch", "a"))
+	assert.Empty(t, completions(t, "user", "switch", "Alice", ""))
+	assert.ElementsMatch(t, []string{"Adam", "Alice"}, completions(t, "goal", "set", "squat", "--user", "a"))
+}
+
+func TestCompletion_LiftNames(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	assert.Equal(t, []string{"squat\tSquat", "deadlift\tDeadlift", "bench\tBench Press", "ohp\tOverhead Press"},
+		completions(t, "goal", "set", ""))
+	assert.Equal(t, []string{"squat\tSquat"}, completions(t, "lift", "hold", "sq"))
+	assert.Empty(t, completions(t, "goal", "set", "squat", ""))
+	assert.Equal(t, []string{"deadlift\tDeadlift"}, completions(t, "pr", "--lift", "d"))
+
+	// Lifts added with 'lift define' are offered too
+	_, err := executePiped(t, "", "lift", "define", "FrontSquat", "--display", "Front Squat")
+	require.NoError(t, err)
+	t.Cleanup(func() { models.RegisterLifts(nil) })
+
+	assert.Contains(t, completions(t, "stats", "chart", "--lift", ""), "front-squat\tFront Squat")
+	assert.Equal(t, []string{"front-squat\tFront Squat"}, completions(t, "goal", "set", "fr"))
+}
+
+func TestCompletion_ConfigKeysAndUnits(t *testing.T) {
+	setupTestEnv(t)
+
+	keys := completions(t, "config", "get", "")
+	assert.NotEmpty(t, keys)
+	for _, key := range keys {
+		assert.NotContains(t, key, " ")
+	}
+	assert.ElementsMatch(t, []string{"kg", "lbs"}, completions(t, "user", "unit", ""))
+}
+
+func TestCompletion_SkipsMigrationPrompt(t *testing.T) {
+	env := setupTestEnv(t)
+	writeLegacyUser(t, env, "Legacy")
+
+	output, err := executePiped(t, "", "__complete", "user", "switch", "")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "existing data was found")
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Customize your settings and how your workouts are calculated",
+	Long: `Customize your settings and how your workouts are calculated, overriding program
+defaults for the current user. 'greyskull config get' lists every setting.`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configWarmupCmd)
+	configWarmupCmd.AddCommand(configWarmupSetCmd)
+	configWarmupCmd.AddCommand(configWarmupResetCmd)
+	configWarmupCmd.AddCommand(configWarmupShowCmd)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show your settings",
+	Long: `Show every setting, or just the value of one key. Settings you haven't changed
+are marked "(default)".`,
+	Example:           "  greyskull config get\n  greyskull config get bar_weight",
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              getConfig,
+	ValidArgsFunction: completeFirstArg(completeConfigKeys),
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting",
+	Long: `Change a setting for the current user. Use "default" as the value to go back to
+the built-in default.
+
+Keys:
+  unit             Weight unit for newly started programs (lbs or kg)
+  bar_weight       Empty bar weight for warmups, e.g. 35 or 15kg; custom lifts
+                   with their own bar keep it
+  plates           Plates available to load the bar, e.g. 45x4,25x2,10x2,5x2,2.5x2;
+                   end with "kg" for kilogram plates
+  plate_hints      on to show the plates to add or take off each side of the
+                   bar between sets, e.g. "add 10/side"; off by default
+  warmup_strategy  How warmup sets are built for every program: percent ramps
+                   through each lift's warmup percentages, and plate_jump adds
+                   a pair of your heaviest plates per set after the empty bar
+  timer.warmup     Rest after warmup sets, e.g. 90s or 1m30s
+  timer.working    Rest after working, AMRAP, and feeler sets, e.g. 3m
+  date_format      How dates are shown in workout history and stats: iso
+                   (2024-03-04), us (03/04/2024), eu (04/03/2024), or long
+                   (Mar 4, 2024)
+  dual_units       on to show weights in workouts and stats in both lbs and
+                   kg, e.g. "100 kg / 220.5 lbs"; off by default
+  locale           Language messages are shown in: en or es; defaults to the
+                   locale in LC_ALL, LC_MESSAGES, or LANG
+  weekly_target    Sessions a week that keep your consistency streak going,
+                   from 1 to 7; defaults to your program's training days
+  hooks.post_log   Hooks run after each logged workout, one per argument: a URL
+                   is sent the workout's JSON in a POST request, and anything
+                   else is run as a shell command with the JSON on its input
+  strava.client_id Client ID of your Strava API application, used by
+                   'greyskull push strava'
+  strava.client_secret
+                   Client secret of your Strava API application
+  smtp.server      Mail server 'greyskull summary --send' sends through, as
+                   host:port; the port defaults to 587
+  smtp.username    Username to sign in to the mail server with, if it needs one
+  smtp.password    Password to sign in to the mail server with
+  smtp.from        Address summaries are sent from
+  smtp.to          Addresses summaries are sent to, one per argument`,
+	Example: `  greyskull config set bar_weight 35
+  greyskull config set plates 45x4 25x2 10x2 5x2 2.5x2
+  greyskull config set hooks.post_log https://example.com/greyskull 'jq .workout >> ~/workouts.jsonl'`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              setConfig,
+	ValidArgsFunction: completeFirstArg(completeConfigKeys),
+}
+
+func getConfig(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		setting, err := ctx.Config.Get(user, args[0])
+		if err != nil {
+			return err
+		}
+		printf(cmd, "%s\n", setting.Value)
+		return nil
+	}
+
+	settings, err := ctx.Config.List(user)
+	if err != nil {
+		return err
+	}
+	width := 0
+	for _, setting := range settings {
+		width = max(width, len(setting.Key))
+	}
+	printf(cmd, "Settings for %s:\n", user.Username)
+	for _, setting := range settings {
+		printf(cmd, "  %-*s  %s\n", width, setting.Key, formatSettingValue(setting))
+	}
+	return nil
+}
+
+func setConfig(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	// Values such as a plate list may be given as several arguments
+	setting, err := ctx.Config.Set(contextFor(cmd), user, args[0], args[1:]...)
+	if err != nil {
+		return err
+	}
+
+	// Secrets are never echoed, not even as "set"
+	switch {
+	case setting.Secret && setting.Default:
+		printf(cmd, "%s cleared.\n", setting.Key)
+	case setting.Secret:
+		printf(cmd, "%s updated.\n", setting.Key)
+	default:
+		printf(cmd, "%s set to %s.\n", setting.Key, formatSettingValue(setting))
+	}
+	return nil
+}
+
+// formatSettingValue formats a setting's value, marking built-in defaults
+func formatSettingValue(setting services.Setting) string {
+	if setting.Default {
+		return setting.Value + " (default)"
+	}
+	return setting.Value
+}
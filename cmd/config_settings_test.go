@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_GetAndSet(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "config", "get")
+	require.NoError(t, err)
+	assert.Equal(t, "Settings for TestUser:\n"+
+		"  unit                  lbs (default)\n"+
+		"  bar_weight            45 lbs (default)\n"+
+		"  plates                not set (default)\n"+
+		"  plate_hints           off (default)\n"+
+		"  warmup_strategy       from program (default)\n"+
+		"  timer.warmup          from program (default)\n"+
+		"  timer.working         from program (default)\n"+
+		"  date_format           iso (default)\n"+
+		"  dual_units            off (default)\n"+
+		"  locale                en (default)\n"+
+		"  weekly_target         from training days (default)\n"+
+		"  hooks.post_log        none (default)\n"+
+		"  strava.client_id      not set (default)\n"+
+		"  strava.client_secret  not set (default)\n"+
+		"  smtp.server           not set (default)\n"+
+		"  smtp.username         not set (default)\n"+
+		"  smtp.password         not set (default)\n"+
+		"  smtp.from             not set (default)\n"+
+		"  smtp.to               not set (default)\n", output)
+
+	output, err = executePiped(t, "", "config", "set", "plates", "45x4", "25x2", "2.5x2")
+	require.NoError(t, err)
+	assert.Equal(t, "plates set to 45x4,25x2,2.5x2 lbs.\n", output)
+
+	output, err = executePiped(t, "", "config", "set", "timer.warmup", "1m")
+	require.NoError(t, err)
+	assert.Equal(t, "timer.warmup set to 1:00.\n", output)
+	assert.Equal(t, &models.RestTimes{WarmupSeconds: 60}, loadTestUser(t).RestTimes)
+
+	output, err = executePiped(t, "", "config", "get", "plates")
+	require.NoError(t, err)
+	assert.Equal(t, "45x4,25x2,2.5x2 lbs\n", output)
+
+	output, err = executePiped(t, "", "config", "set", "timer.warmup", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "timer.warmup set to from program (default).\n", output)
+
+	_, err = executePiped(t, "", "config", "set", "bar_weight", "light")
+	assert.ErrorContains(t, err, `invalid weight "light"`)
+	_, err = executePiped(t, "", "config", "get", "colour")
+	assert.ErrorContains(t, err, `unknown config key "colour"`)
+}
+
+func TestConfig_SetSecret(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "config", "set", "smtp.password", "hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.password updated.\n", output)
+
+	output, err = executePiped(t, "", "config", "get", "smtp.password")
+	require.NoError(t, err)
+	assert.Equal(t, "set\n", output)
+
+	output, err = executePiped(t, "", "config", "set", "strava.client_secret", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "strava.client_secret updated.\n", output)
+
+	output, err = executePiped(t, "", "config", "set", "smtp.password", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.password cleared.\n", output)
+}
+
+func TestConfig_BarWeightWarmups(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "config", "set", "bar_weight", "35")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "5 reps @ 35 lbs")
+	assert.NotContains(t, output, "@ 45 lbs")
+}
+
+func TestConfig_PlateHints(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "/side")
+
+	output, err = executePiped(t, "", "config", "set", "plate_hints", "on")
+	require.NoError(t, err)
+	assert.Equal(t, "plate_hints set to on.\n", output)
+
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "    Set 1: 5 reps @ 95 lbs (add ")
+	assert.Contains(t, output, "/side")
+
+	_, err = executePiped(t, "", "config", "set", "plate_hints", "maybe")
+	assert.ErrorContains(t, err, `invalid plate hints "maybe"`)
+}
+
+func TestConfig_DualUnits(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "config", "set", "dual_units", "on")
+	require.NoError(t, err)
+	assert.Equal(t, "dual_units set to on.\n", output)
+
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "    Set 3: 5+ reps @ 95 lbs / 43.1 kg (AMRAP)\n")
+
+	output = runStatus(t, false)
+	assert.Contains(t, output, "Weights: Overhead Press 95 lbs / 43.1 kg, Bench Press 125 lbs / 56.7 kg")
+
+	// Booleans are accepted too
+	output, err = executePiped(t, "", "config", "set", "dual_units", "false")
+	require.NoError(t, err)
+	assert.Equal(t, "dual_units set to off (default).\n", output)
+	output, err = executePiped(t, "", "config", "set", "dual_units", "true")
+	require.NoError(t, err)
+	assert.Equal(t, "dual_units set to on.\n", output)
+
+	_, err = executePiped(t, "", "config", "set", "dual_units", "maybe")
+	assert.ErrorContains(t, err, `invalid dual units "maybe"`)
+}
+
+func TestConfig_Locale(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "config", "set", "locale", "es_ES.UTF-8")
+	require.NoError(t, err)
+	assert.Equal(t, "locale set to es.\n", output)
+
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Entrenamiento del día 1:\n")
+	assert.Contains(t, output, "Press militar:\n  Calentamiento:\n")
+	assert.Contains(t, output, "    Serie 3: 5+ reps @ 95 lbs (AMRAP)\n")
+
+	_, err = executePiped(t, "", "config", "set", "locale", "default")
+	require.NoError(t, err)
+
+	// Without a setting, the locale comes from the environment
+	t.Setenv("LANG", "es_MX.UTF-8")
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Entrenamiento del día 1:\n")
+
+	t.Setenv("LANG", "C")
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Day 1 Workout:\n")
+
+	_, err = executePiped(t, "", "config", "set", "locale", "xx")
+	assert.ErrorContains(t, err, `unsupported locale "xx" (expected one of en, es)`)
+}
+
+func TestConfig_DateFormat(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	_, err := executePiped(t, "", "config", "set", "date_format", "long")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "workout", "history")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Mar 6, 2024  Day 2\n")
+
+	output, err = executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workouts: 2 from Mar 4, 2024 to Mar 6, 2024")
+}
+
+func TestConfig_DualUnitsInEveryWeightOutput(t *testing.T) {
+	env := setupTestEnv(t)
+	addSummaryWeek(t, createUserWithHistory(t, env))
+	_, err := executePiped(t, "", "goal", "set", "squat", "315")
+	require.NoError(t, err)
+	_, err = executePiped(t, "", "config", "set", "dual_units", "on")
+	require.NoError(t, err)
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"pr"}, "  Heaviest AMRAP: 140 lbs / 63.5 kg x 9 (2024-03-11)"},
+		{[]string{"summary", "--week", "--date", "2024-03-15"}, "  Squat: 135 lbs / 61.2 kg → 140 lbs / 63.5 kg (+5)"},
+		{[]string{"share"}, "  Squat 140 lbs / 63.5 kg: 9+"},
+		{[]string{"report"}, "| Squat | 2 | 135 lbs / 61.2 kg → 140 lbs / 63.5 kg | 2,340 lbs / 1,061 kg |"},
+		{[]string{"goal", "list"}, "Squat: 135 / 315 lbs (61.2 / 142.9 kg)"},
+		{[]string{"status"}, "Squat: 135 / 315 lbs (61.2 / 142.9 kg)"},
+		{[]string{"program", "preview"}, "  Squat: 135 lbs / 61.2 kg (45 per side)"},
+		{[]string{"simulate", "--weeks", "1"}, "  Squat: 135 lbs / 61.2 kg → 145 lbs / 65.8 kg (+10)"},
+		{[]string{"chart", "--lift", "squat"}, "Working weight: 135 lbs / 61.2 kg → 140 lbs / 63.5 kg"},
+		{[]string{"stats"}, "  Weight: 135 lbs / 61.2 kg → 140 lbs / 63.5 kg (+5)"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output, err := executePiped(t, "", tt.args...)
+			require.NoError(t, err)
+			assert.Contains(t, output, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var configWarmupCmd = &cobra.Command{
+	Use:   "warmup",
+	Short: "Customize warmup percentages per lift",
+	Long: `Customize the warmup ramp for individual lifts. Programs warm up from the empty
+bar through sets at fixed percentages of the working weight, e.g. 55%, 70%, and
+85%. Custom percentages replace the sets after the empty bar for every program
+you run; reps follow the program's warmup sets.`,
+}
+
+var configWarmupSetCmd = &cobra.Command{
+	Use:   "set <lift> <percentages>",
+	Short: "Set a lift's warmup percentages",
+	Long: `Set a lift's warmup percentages as a comma-separated list of percentages of the
+working weight. Percentages must be ascending and under 100.`,
+	Example:           "  greyskull config warmup set deadlift 60,75,90",
+	Args:              cobra.ExactArgs(2),
+	RunE:              setWarmupPercentages,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+var configWarmupResetCmd = &cobra.Command{
+	Use:               "reset <lift>",
+	Short:             "Go back to the program's warmups for a lift",
+	Args:              cobra.ExactArgs(1),
+	RunE:              resetWarmupPercentages,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+var configWarmupShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show custom warmup percentages",
+	Args:  cobra.NoArgs,
+	RunE:  showWarmupPercentages,
+}
+
+func setWarmupPercentages(cmd *cobra.Command, args []string) error {
+	lift, err := models.ParseLiftName(args[0])
+	if err != nil {
+		return err
+	}
+	percentages, err := models.ParseWarmupPercentages(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if user.WarmupPercentages == nil {
+		user.WarmupPercentages = make(map[models.LiftName]models.WarmupPercentages)
+	}
+	user.WarmupPercentages[lift] = percentages
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "%s warmups set to %s of the working weight.\n",
+		display.FormatLiftName(lift), display.FormatPercentages(percentages))
+	return nil
+}
+
+func resetWarmupPercentages(cmd *cobra.Command, args []string) error {
+	lift, err := models.ParseLiftName(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if _, exists := user.WarmupPercentages[lift]; !exists {
+		printf(cmd, "%s already uses the program's warmups.\n", display.FormatLiftName(lift))
+		return nil
+	}
+
+	delete(user.WarmupPercentages, lift)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "%s warmups reset to the program's defaults.\n", display.FormatLiftName(lift))
+	return nil
+}
+
+func showWarmupPercentages(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	display.NewProgramFormatter(cmd.OutOrStdout()).DisplayWarmupPercentages(user.WarmupPercentages)
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWarmup(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	for _, cmd := range []*cobra.Command{configWarmupSetCmd, configWarmupResetCmd, configWarmupShowCmd, workoutNextCmd} {
+		cmd.SetOut(&buf)
+	}
+
+	require.NoError(t, configWarmupSetCmd.RunE(configWarmupSetCmd, []string{"squat", "60,75,90"}))
+	assert.Equal(t, "Squat warmups set to 60%, 75%, 90% of the working weight.\n", buf.String())
+
+	user := loadTestUser(t)
+	assert.InDeltaSlice(t, []float64{0.6, 0.75, 0.9}, user.WarmupPercentages[models.Squat], 1e-9)
+
+	buf.Reset()
+	require.NoError(t, configWarmupShowCmd.RunE(configWarmupShowCmd, []string{}))
+	assert.Equal(t, "Custom warmup percentages:\n  Squat: 60%, 75%, 90%\n", buf.String())
+
+	// The next workout uses the custom ramp
+	buf.Reset()
+	require.NoError(t, workoutNextCmd.RunE(workoutNextCmd, []string{}))
+	assert.Contains(t, buf.String(), "Squat:\n  Warmup:\n    5 reps @ 45 lbs\n    4 reps @ 80 lbs\n    3 reps @ 100 lbs\n    2 reps @ 120 lbs\n")
+
+	buf.Reset()
+	require.NoError(t, configWarmupResetCmd.RunE(configWarmupResetCmd, []string{"squat"}))
+	assert.Equal(t, "Squat warmups reset to the program's defaults.\n", buf.String())
+	assert.Empty(t, loadTestUser(t).WarmupPercentages)
+
+	buf.Reset()
+	require.NoError(t, configWarmupResetCmd.RunE(configWarmupResetCmd, []string{"squat"}))
+	assert.Equal(t, "Squat already uses the program's warmups.\n", buf.String())
+}
+
+func TestConfigWarmupSet_Invalid(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	err := configWarmupSetCmd.RunE(configWarmupSetCmd, []string{"squat", "90,75"})
+	assert.EqualError(t, err, "warmup percentages must be ascending, got 75 after 90")
+
+	err = configWarmupSetCmd.RunE(configWarmupSetCmd, []string{"curl", "60"})
+	assert.ErrorContains(t, err, `unknown lift "curl"`)
+
+	assert.Empty(t, loadTestUser(t).WarmupPercentages)
+}
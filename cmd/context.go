@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+// contextFor returns the context a command runs under, which repositories and
+// services honor for cancellation. Commands run without one, as in tests, get
+// a background context.
+func contextFor(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// newCommandContext creates the services a command uses, with the default
+// repositories, acting as the user named by the global --user flag if given
+func newCommandContext(cmd *cobra.Command) (*services.CommandContext, error) {
+	ctx, err := services.NewCommandContextWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+	ctx.UserService.SetUserOverride(userOverride(cmd))
+	return ctx, nil
+}
+
+// userOverride returns the user named by the global --user flag, or "" to act
+// as the stored current user
+func userOverride(cmd *cobra.Command) string {
+	if flag := cmd.Flag("user"); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
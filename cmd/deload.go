@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var deloadCmd = &cobra.Command{
+	Use:   "deload",
+	Short: "Plan lighter recovery sessions",
+}
+
+var deloadWeekCmd = &cobra.Command{
+	Use:   "week",
+	Short: "Schedule a one-week deload",
+	Long: `Replace the next week of workouts with lighter straight sets, 2x5 at 80% of your
+current weights by default, with no AMRAP sets. Your program continues through its
+days as usual, but weights do not progress until the deload is over. Normal
+programming resumes automatically afterward at your pre-deload weights.`,
+	Example: "  greyskull deload week --percent 70 --sets 3",
+	Args:    cobra.NoArgs,
+	RunE:    scheduleDeloadWeek,
+}
+
+var deloadCancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "End a deload early and resume normal programming",
+	Args:  cobra.NoArgs,
+	RunE:  cancelDeload,
+}
+
+func init() {
+	rootCmd.AddCommand(deloadCmd)
+	deloadCmd.AddCommand(deloadWeekCmd)
+	deloadCmd.AddCommand(deloadCancelCmd)
+
+	deloadWeekCmd.Flags().Float64("percent", workout.DefaultDeloadPercentage*100, "Percentage of current weights to lift")
+	deloadWeekCmd.Flags().Int("sets", workout.DefaultDeloadSets, "Number of sets per lift")
+	deloadWeekCmd.Flags().Int("reps", workout.DefaultDeloadReps, "Number of reps per set")
+}
+
+func scheduleDeloadWeek(cmd *cobra.Command, args []string) error {
+	percent, err := cmd.Flags().GetFloat64("percent")
+	if err != nil {
+		return fmt.Errorf("failed to get percent flag: %w", err)
+	}
+	sets, err := cmd.Flags().GetInt("sets")
+	if err != nil {
+		return fmt.Errorf("failed to get sets flag: %w", err)
+	}
+	reps, err := cmd.Flags().GetInt("reps")
+	if err != nil {
+		return fmt.Errorf("failed to get reps flag: %w", err)
+	}
+
+	plan, err := workout.NewDeloadWeek(percent/100, sets, reps)
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if userProgram.Deload != nil {
+		return services.NewError("deload_in_progress", "run 'greyskull deload cancel' to end it",
+			"a deload is already in progress with %d session(s) left", userProgram.Deload.SessionsRemaining)
+	}
+	userProgram.Deload = plan
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayDeloadPlan(plan, userProgram.CurrentWeights, workout.DeloadWeights(userProgram, plan))
+	return nil
+}
+
+func cancelDeload(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if userProgram.Deload == nil {
+		return fmt.Errorf("no deload is in progress")
+	}
+	userProgram.Deload = nil
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "Deload cancelled; normal programming resumes next session.\n")
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runDeloadWeek(t *testing.T, percent string) (string, error) {
+	var buf bytes.Buffer
+	cmd := deloadWeekCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("percent", percent))
+	t.Cleanup(func() { cmd.Flags().Set("percent", "80") })
+
+	err := cmd.RunE(cmd, []string{})
+	return buf.String(), err
+}
+
+func TestDeloadWeek(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := runDeloadWeek(t, "80")
+	require.NoError(t, err)
+	expected := "Deload planned for the next 3 sessions: 2x5 @ 80%, no AMRAP sets\n" +
+		"  Overhead Press: 75 lbs (normally 95 lbs)\n" +
+		"  Bench Press: 100 lbs (normally 125 lbs)\n" +
+		"  Squat: 107.5 lbs (normally 135 lbs)\n" +
+		"  Deadlift: 147.5 lbs (normally 185 lbs)\n" +
+		"Weights will not progress until the deload is over.\n"
+	assert.Equal(t, expected, output)
+
+	user := loadTestUser(t)
+	assert.Equal(t, &models.DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5, SessionsRemaining: 3},
+		user.Programs[user.CurrentProgram].Deload)
+
+	_, err = runDeloadWeek(t, "80")
+	assert.ErrorContains(t, err, "a deload is already in progress with 3 session(s) left")
+}
+
+func TestDeloadWeek_ReplacesWorkoutsThenResumes(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	_, err := runDeloadWeek(t, "80")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	workoutNextCmd.SetOut(&buf)
+	require.NoError(t, workoutNextCmd.RunE(workoutNextCmd, []string{}))
+	assert.Contains(t, buf.String(), "Squat:\n  Warmup:\n    5 reps @ 45 lbs\n")
+	assert.Contains(t, buf.String(), "    Set 1: 5 reps @ 107.5 lbs\n    Set 2: 5 reps @ 107.5 lbs\n\n")
+	assert.NotContains(t, buf.String(), "AMRAP")
+	assert.Contains(t, buf.String(), "Deload: 2x5 @ 80%, 3 sessions left before normal programming resumes")
+
+	// Deload sessions have no AMRAP sets, so logging them needs no input
+	var output string
+	for range 3 {
+		buf.Reset()
+		workoutLogCmd.SetOut(&buf)
+		workoutLogCmd.SetIn(strings.NewReader(""))
+		require.NoError(t, workoutLogCmd.RunE(workoutLogCmd, []string{}))
+		output = buf.String()
+	}
+	assert.Contains(t, output, "Deload complete; normal programming resumes next session.")
+	assert.Contains(t, output, "Next workout: Day 4")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 3)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Nil(t, userProgram.Deload)
+	assert.Equal(t, 4, userProgram.CurrentDay)
+	assert.Equal(t, 95.0, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 125.0, userProgram.CurrentWeights[models.BenchPress])
+	assert.Equal(t, 185.0, userProgram.CurrentWeights[models.Deadlift])
+}
+
+func TestDeloadWeek_InvalidInput(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := runDeloadWeek(t, "100")
+	assert.EqualError(t, err, "deload percentage must be between 0 and 100, got: 100")
+
+	require.NoError(t, deloadWeekCmd.Flags().Set("sets", "0"))
+	t.Cleanup(func() { deloadWeekCmd.Flags().Set("sets", "2") })
+	_, err = runDeloadWeek(t, "80")
+	assert.EqualError(t, err, "deload sets must be positive, got: 0")
+}
+
+func TestDeloadCancel(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := deloadCancelCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	assert.EqualError(t, err, "no deload is in progress")
+
+	deloadWeekCmd.SetOut(io.Discard)
+	require.NoError(t, deloadWeekCmd.RunE(deloadWeekCmd, []string{}))
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "Deload cancelled; normal programming resumes next session.\n", buf.String())
+
+	user := loadTestUser(t)
+	assert.Nil(t, user.Programs[user.CurrentProgram].Deload)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/demo"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+var demoCmd = &cobra.Command{
+	Use:   "demo",
+	Short: "Generate a demo user with sample workout history",
+	Long: `Generate a demo user running OG Greyskull LP with a realistic workout history,
+including steady progress, occasional failed AMRAP sets, and deloads.
+
+The same --seed always produces the same history, which makes the demo data
+suitable for screenshots, testing, and tutorials. The generated user is set
+as the current user.`,
+	Example: "  greyskull demo --seed 42 --weeks 12",
+	RunE:    runDemo,
+}
+
+func init() {
+	rootCmd.AddCommand(demoCmd)
+	demoCmd.Flags().Int64("seed", 1, "Seed for the random generator")
+	demoCmd.Flags().Int("weeks", 12, "Number of weeks of history to generate")
+	demoCmd.Flags().String("username", "demo", "Username for the generated user")
+}
+
+func runDemo(cmd *cobra.Command, args []string) error {
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return fmt.Errorf("failed to get seed flag: %w", err)
+	}
+	weeks, err := cmd.Flags().GetInt("weeks")
+	if err != nil {
+		return fmt.Errorf("failed to get weeks flag: %w", err)
+	}
+	username, err := cmd.Flags().GetString("username")
+	if err != nil {
+		return fmt.Errorf("failed to get username flag: %w", err)
+	}
+
+	if err := validateUsername(username); err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Refuse to overwrite an existing user
+	if _, err := ctx.UserRepo.Get(contextFor(cmd), username); err == nil {
+		return fmt.Errorf("user %q already exists (case-insensitive)", username)
+	} else if !errors.Is(err, repository.ErrUserNotFound) {
+		return fmt.Errorf("failed to check for existing user: %w", err)
+	}
+
+	// History ends this week, starting the requested number of weeks ago
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	user, err := demo.Generate(demo.Options{
+		Seed:     seed,
+		Weeks:    weeks,
+		Username: username,
+		Start:    today.AddDate(0, 0, -7*weeks),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate demo data: %w", err)
+	}
+
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("invalid user data: %w", err)
+	}
+
+	if err := ctx.UserRepo.Create(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := ctx.UserRepo.SetCurrent(contextFor(cmd), username); err != nil {
+		return fmt.Errorf("failed to set current user: %w", err)
+	}
+
+	userProgram := user.Programs[user.CurrentProgram]
+	printf(cmd, "Demo user %q created with %d workouts over %d weeks (seed %d).\n",
+		username, len(user.WorkoutHistory), weeks, seed)
+	printf(cmd, "Current weights:\n")
+	for _, lift := range coreLifts {
+		printf(cmd, "  %s: %s lbs\n", display.FormatLiftName(lift), display.FormatWeight(userProgram.CurrentWeights[lift]))
+	}
+
+	return nil
+}
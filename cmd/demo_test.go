@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikowitz/greyskull/demo"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDemo_CreatesCurrentUserWithHistory(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	var buf bytes.Buffer
+	cmd := demoCmd
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	require.NoError(t, cmd.Flags().Set("seed", "42"))
+	require.NoError(t, cmd.Flags().Set("weeks", "2"))
+	t.Cleanup(func() {
+		cmd.Flags().Set("seed", "1")
+		cmd.Flags().Set("weeks", "12")
+	})
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `Demo user "demo" created with 6 workouts over 2 weeks (seed 42)`)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+
+	current, err := repo.GetCurrent(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "demo", current)
+
+	user, err := repo.Get(t.Context(), "demo")
+	require.NoError(t, err)
+	require.NoError(t, user.LoadHistory())
+	assert.Len(t, user.WorkoutHistory, 2*demo.SessionsPerWeek)
+	assert.Contains(t, user.Programs, user.CurrentProgram)
+}
+
+func TestDemo_ExistingUser(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"demo"})
+
+	cmd := demoCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := cmd.RunE(cmd, []string{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mikowitz/greyskull/docs"
+	"github.com/spf13/cobra"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate documentation for greyskull",
+}
+
+var docsGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate the command reference",
+	Long: `Generate the complete command reference, including every command's flags and
+examples, from the commands themselves.
+
+Markdown is written to standard output as a single document, or with --dir as
+one page per command. Man pages are always written one per command, so --dir is
+required for them.
+
+Man pages are dated by SOURCE_DATE_EPOCH when it is set, for reproducible builds.`,
+	Example: "  greyskull docs generate > REFERENCE.md\n  greyskull docs generate --format man --dir man/man1",
+	Args:    cobra.NoArgs,
+	RunE:    generateDocs,
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+	docsCmd.AddCommand(docsGenerateCmd)
+
+	docsGenerateCmd.Flags().String("format", string(docs.Markdown), "Output format: markdown or man")
+	docsGenerateCmd.Flags().String("dir", "", "Write one page per command into this directory")
+}
+
+func generateDocs(cmd *cobra.Command, args []string) error {
+	formatInput, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+	format, err := docs.ParseFormat(formatInput)
+	if err != nil {
+		return err
+	}
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return fmt.Errorf("failed to get dir flag: %w", err)
+	}
+
+	if dir == "" {
+		if format == docs.Man {
+			return fmt.Errorf("man pages are written one per command; use --dir to choose where")
+		}
+		return docs.WriteMarkdown(cmd.OutOrStdout(), cmd.Root())
+	}
+
+	date, err := docsDate()
+	if err != nil {
+		return err
+	}
+	paths, err := docs.WriteFiles(cmd.Root(), format, dir, date)
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "Wrote %d %s pages to %s\n", len(paths), format, dir)
+	return nil
+}
+
+// docsDate returns the date to stamp on man pages: SOURCE_DATE_EPOCH if set,
+// otherwise today
+func docsDate() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return time.Now(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsGenerate_Markdown(t *testing.T) {
+	setupTestEnv(t)
+
+	output, err := executePiped(t, "", "docs", "generate")
+	require.NoError(t, err)
+	assert.Contains(t, output, "# greyskull command reference\n")
+	assert.Contains(t, output, "- [greyskull workout log quick](#greyskull-workout-log-quick)\n")
+	assert.Contains(t, output, "## greyskull workout log\n\nLog a completed workout\n")
+	assert.Contains(t, output, "--dry-run")
+	assert.NotContains(t, output, "## greyskull help")
+}
+
+func TestDocsGenerate_ManPages(t *testing.T) {
+	setupTestEnv(t)
+	t.Setenv("SOURCE_DATE_EPOCH", "1709510400")
+	dir := filepath.Join(t.TempDir(), "man1")
+
+	output, err := executePiped(t, "", "docs", "generate", "--format", "man", "--dir", dir)
+	require.NoError(t, err)
+	assert.Regexp(t, `^Wrote \d+ man pages to `, output)
+
+	data, err := os.ReadFile(filepath.Join(dir, "greyskull-goal-set.1"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `.TH "GREYSKULL\-GOAL\-SET" "1" "Mar 2024" "greyskull 0.1.0" "Greyskull Manual"`)
+
+	_, err = executePiped(t, "", "docs", "generate", "--format", "man")
+	assert.ErrorContains(t, err, "use --dir")
+	_, err = executePiped(t, "", "docs", "generate", "--format", "pdf")
+	assert.ErrorContains(t, err, `unknown docs format "pdf"`)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/doctor"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check your data for problems",
+	Long: `Check the data directory for problems: user files that can't be read, a current
+user that no longer exists, programs that follow a program template greyskull
+doesn't know, workouts belonging to programs that no longer exist, negative
+weights, and IDs shared by more than one user, workout, or skipped day.
+
+With --fix, problems that can be repaired without losing data are fixed, e.g. an
+unreadable user file is restored from its backup. Each user's data is backed up
+before it's changed. Other problems are listed for fixing by hand.`,
+	Example: "  greyskull doctor\n  greyskull doctor --fix",
+	Args:    cobra.NoArgs,
+	RunE:    runDoctor,
+}
+
+// doctorResult is the JSON result of 'greyskull doctor'
+type doctorResult struct {
+	Reports []doctor.Report `json:"reports"`
+	Fixed   bool            `json:"fixed"`
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Repair the problems that can be fixed safely")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	fix, _ := cmd.Flags().GetBool("fix")
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	files, err := repository.InspectUserFiles()
+	if err != nil {
+		return fmt.Errorf("failed to inspect user files: %w", err)
+	}
+	currentUser, err := repository.StoredCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	fileIssues := doctor.CheckFiles(files, currentUser)
+	if fix {
+		for _, issue := range fileIssues {
+			if err := issue.Fix(); err != nil {
+				return fmt.Errorf("failed to fix %s: %w", issue.Problem, err)
+			}
+		}
+	}
+	reports := []doctor.Report{{Subject: "Data directory", Issues: nonNil(fileIssues)}}
+
+	// Users whose files can only be read from their backups are checked too,
+	// as that's the data they'll be restored to
+	for _, file := range files {
+		if file.Username == "" {
+			continue
+		}
+
+		user, err := ctx.UserRepo.Get(contextFor(cmd), file.Username)
+		if err != nil {
+			return fmt.Errorf("failed to load user %s: %w", file.Username, err)
+		}
+		if err := user.LoadHistory(); err != nil {
+			reports = append(reports, doctor.Report{Subject: user.Username, Issues: []doctor.Issue{{
+				Problem: i18n.Sprintf("workout history can't be read: %v", err),
+			}}})
+			continue
+		}
+
+		issues := doctor.CheckUser(contextFor(cmd), user, ctx.Programs)
+		reports = append(reports, doctor.Report{Subject: user.Username, Issues: nonNil(issues)})
+		if !fix || !hasFixableIssue(issues) {
+			continue
+		}
+
+		if err := backupUser(cmd, ctx, user.Username, "doctor"); err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			issue.Fix()
+		}
+		if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+	}
+
+	display.NewDoctorFormatter(cmd.OutOrStdout()).DisplayReports(reports, fix)
+	outputFor(cmd).Result(doctorResult{Reports: reports, Fixed: fix})
+	return nil
+}
+
+func hasFixableIssue(issues []doctor.Issue) bool {
+	for _, issue := range issues {
+		if issue.Fixable() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctor_NoProblems(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "doctor")
+	require.NoError(t, err)
+	assert.Equal(t, "No problems found.\n", output)
+}
+
+func TestDoctor_Fix(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createUserWithHistory(t, env)
+
+	// Break the user's data: a negative goal, a workout stored twice, and a
+	// workout for a program that no longer exists
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.Programs[user.CurrentProgram].Goals = map[models.LiftName]float64{models.Squat: -315}
+	orphan := user.WorkoutHistory[1]
+	orphan.ID = uuid.New()
+	orphan.UserProgramID = uuid.New()
+	user.WorkoutHistory = append(user.WorkoutHistory, user.WorkoutHistory[0], orphan)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// ...and point the current user at someone who doesn't exist
+	dataDir, err := repository.DataDir()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "current_user.txt"), []byte("Ghost"), 0644))
+
+	output, err := executePiped(t, "", "doctor")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Data directory:\n  - the current user Ghost doesn't exist\n    Fix: clear the current user")
+	assert.Contains(t, output, "TestUser:\n  - program started 2024-03-01 has a negative Squat goal (-315)\n    Fix: remove the goal\n")
+	assert.Contains(t, output, "doesn't exist but is referenced by 1 workout\n")
+	assert.Contains(t, output, "  - workout on 2024-03-06 is stored twice\n    Fix: remove the copy\n")
+	assert.Contains(t, output, "Found 4 problems. Run 'greyskull doctor --fix' to fix 3 of them.\n")
+
+	// Checking changes nothing
+	stored, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Len(t, stored.History(), 4)
+
+	output, err = executePiped(t, "", "doctor", "--fix")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up TestUser's data to ")
+	assert.Contains(t, output, "    Fixed: remove the goal\n")
+	assert.Contains(t, output, "Found 4 problems and fixed 3.\n")
+
+	stored, err = repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Len(t, stored.History(), 3)
+	assert.Empty(t, stored.Programs[stored.CurrentProgram].Goals)
+	_, err = os.Stat(filepath.Join(dataDir, "current_user.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Only the workout without a program is left, for fixing by hand
+	output, err = executePiped(t, "", "doctor")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Found 1 problem. None can be fixed automatically.\n")
+}
+
+func TestDoctor_RestoresUnreadableUserFile(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user)) // Leaves a backup
+	dataDir, err := repository.DataDir()
+	require.NoError(t, err)
+	userFile := filepath.Join(dataDir, "users", "testuser.json")
+	require.NoError(t, os.WriteFile(userFile, []byte("{\"username\": "), 0644))
+
+	output, err := executePiped(t, "", "doctor", "--fix")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  - testuser.json can't be read: ")
+	assert.Contains(t, output, "    Fixed: restore it from its backup\n")
+	assert.Contains(t, output, "Found 1 problem and fixed 1.\n")
+
+	data, err := os.ReadFile(userFile)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(data))
+}
+
+func TestDoctor_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	dataDir, err := repository.DataDir()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "current_user.txt"), []byte("Ghost"), 0644))
+
+	stdout, _, err := executeJSON(t, "", "doctor")
+	require.NoError(t, err)
+
+	var result struct {
+		Reports []struct {
+			Subject string `json:"subject"`
+			Issues  []struct {
+				Problem string `json:"problem"`
+				Repair  string `json:"repair"`
+			} `json:"issues"`
+		} `json:"reports"`
+		Fixed bool `json:"fixed"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.False(t, result.Fixed)
+	require.Len(t, result.Reports, 2)
+	assert.Equal(t, "Data directory", result.Reports[0].Subject)
+	require.Len(t, result.Reports[0].Issues, 1)
+	assert.Equal(t, "the current user Ghost doesn't exist", result.Reports[0].Issues[0].Problem)
+	assert.Equal(t, "TestUser", result.Reports[1].Subject)
+	assert.Empty(t, result.Reports[1].Issues)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt your data with a passphrase",
+	Long: `Encrypt the user data in the data directory with AES-256-GCM, using a key
+derived from the passphrase in the ` + repository.PassphraseEnv + ` environment
+variable. Users, workout history, backups, archives, unfinished workout logs,
+and audit logs are encrypted; settings, custom programs, and custom lifts are
+not.
+
+Once encrypted, every greyskull command needs ` + repository.PassphraseEnv + `
+set to the same passphrase. There is no way to recover the data without it.
+Use 'greyskull decrypt' to store the data unencrypted again.`,
+	Example: "  GREYSKULL_PASSPHRASE='correct horse battery staple' greyskull encrypt",
+	Args:    cobra.NoArgs,
+	RunE:    encryptData,
+}
+
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Store your data unencrypted again",
+	Long: `Decrypt the user data encrypted by 'greyskull encrypt', using the passphrase in
+the ` + repository.PassphraseEnv + ` environment variable, and turn encryption off.`,
+	Example: "  GREYSKULL_PASSPHRASE='correct horse battery staple' greyskull decrypt",
+	Args:    cobra.NoArgs,
+	RunE:    decryptData,
+}
+
+func init() {
+	rootCmd.AddCommand(encryptCmd)
+	rootCmd.AddCommand(decryptCmd)
+}
+
+func encryptData(cmd *cobra.Command, args []string) error {
+	passphrase := os.Getenv(repository.PassphraseEnv)
+	if passphrase == "" {
+		return fmt.Errorf("set %s to the passphrase to encrypt with", repository.PassphraseEnv)
+	}
+
+	count, err := repository.EnableEncryption(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	printf(cmd, "Encrypted %d file(s).\n", count)
+	printf(cmd, "Keep %s set to use greyskull; the data can't be read without it.\n", repository.PassphraseEnv)
+	return nil
+}
+
+func decryptData(cmd *cobra.Command, args []string) error {
+	passphrase := os.Getenv(repository.PassphraseEnv)
+	if passphrase == "" {
+		return repository.ErrPassphraseRequired
+	}
+
+	count, err := repository.DisableEncryption(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt data: %w", err)
+	}
+
+	printf(cmd, "Decrypted %d file(s).\n", count)
+	return nil
+}
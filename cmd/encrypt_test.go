@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	_, err := executePiped(t, "", "encrypt")
+	assert.ErrorContains(t, err, "set GREYSKULL_PASSPHRASE to the passphrase to encrypt with")
+
+	t.Setenv(repository.PassphraseEnv, "hunter2")
+	output, err := executePiped(t, "", "encrypt")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Encrypted 3 file(s).\n")
+
+	stored, err := os.ReadFile(filepath.Join(env.tempDir, "greyskull", "users", "testuser.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "TestUser")
+
+	// Commands work as before with the passphrase set
+	output, err = executePiped(t, "8\n8\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workout logged successfully!")
+	assert.Len(t, loadTestUser(t).History(), 3)
+
+	t.Setenv(repository.PassphraseEnv, "")
+	_, err = executePiped(t, "", "status")
+	assert.ErrorIs(t, err, repository.ErrPassphraseRequired)
+
+	t.Setenv(repository.PassphraseEnv, "hunter2")
+	output, err = executePiped(t, "", "decrypt")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Decrypted")
+
+	t.Setenv(repository.PassphraseEnv, "")
+	stored, err = os.ReadFile(filepath.Join(env.tempDir, "greyskull", "users", "testuser.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(stored), "TestUser")
+	assert.Len(t, loadTestUser(t).History(), 3)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+// genericErrorCode identifies errors in --json output that have no code of their own
+const genericErrorCode = "error"
+
+// errorResult is the --json output of a command that failed
+type errorResult struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// describeError returns the code, message, and hint shown for a command's error
+func describeError(err error) errorDetail {
+	detail := errorDetail{Code: genericErrorCode, Message: err.Error()}
+	var serviceErr *services.Error
+	if errors.As(err, &serviceErr) {
+		detail.Code = serviceErr.Code
+		detail.Hint = serviceErr.Hint
+	}
+	return detail
+}
+
+// usageError is a mistake in how a command was run, such as an unknown flag or
+// the wrong number of arguments, which is followed by the command's usage
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+
+func (e *usageError) Unwrap() error { return e.err }
+
+// markArgErrors makes sure commands' argument checks are only wrapped once
+var markArgErrors sync.Once
+
+// markUsageErrors wraps the argument checks of cmd and its subcommands so that
+// their errors are usageErrors. Flag errors are marked by the root command's
+// flag error func.
+func markUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markUsageErrors(child)
+	}
+}
+
+// reportError shows the error a command failed with. Text output is written
+// to errOut as "error: ..." with any hint on the line after. With --json, the
+// error is written to out as {"error": {"code": ..., "message": ..., "hint": ...}}
+// so that programs reading stdout see why there's no result. Only the text
+// output is translated, so programs can rely on the JSON messages.
+func reportError(cmd *cobra.Command, err error, out, errOut io.Writer) {
+	detail := describeError(err)
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		data, marshalErr := json.MarshalIndent(errorResult{Error: detail}, "", "  ")
+		if marshalErr == nil {
+			fmt.Fprintf(out, "%s\n", data)
+			return
+		}
+	}
+
+	fmt.Fprintf(errOut, "error: %s\n", i18n.T(detail.Message))
+	if detail.Hint != "" {
+		fmt.Fprintf(errOut, "hint: %s\n", i18n.T(detail.Hint))
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportError(t *testing.T) {
+	newCmd := func(asJSON bool) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().Bool("json", false, "")
+		if asJSON {
+			require.NoError(t, cmd.Flags().Set("json", "true"))
+		}
+		return cmd
+	}
+
+	t.Run("text with hint", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		reportError(newCmd(false), fmt.Errorf("failed to load: %w", services.ErrNoActiveProgram), &out, &errOut)
+		assert.Empty(t, out.String())
+		assert.Equal(t, "error: failed to load: no active program\nhint: run 'greyskull program start' to begin a program\n", errOut.String())
+	})
+
+	t.Run("text without hint", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		reportError(newCmd(false), errors.New("something broke"), &out, &errOut)
+		assert.Equal(t, "error: something broke\n", errOut.String())
+	})
+
+	t.Run("translated text", func(t *testing.T) {
+		i18n.SetLocale("es")
+		t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+
+		var out, errOut bytes.Buffer
+		reportError(newCmd(false), services.ErrNoActiveProgram, &out, &errOut)
+		assert.Equal(t, "error: no hay ningún programa activo\nhint: ejecuta 'greyskull program start' para empezar un programa\n", errOut.String())
+
+		// JSON messages stay in English for the programs reading them
+		reportError(newCmd(true), services.ErrNoActiveProgram, &out, &errOut)
+		assert.Contains(t, out.String(), `"message": "no active program"`)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		reportError(newCmd(true), services.ErrNoCurrentUser, &out, &errOut)
+		assert.Empty(t, errOut.String())
+		assert.JSONEq(t, `{"error": {"code": "no_current_user", "message": "no current user set",
+			"hint": "run 'greyskull user create' or 'greyskull user switch' first"}}`, out.String())
+
+		out.Reset()
+		reportError(newCmd(true), errors.New("something broke"), &out, &errOut)
+		assert.JSONEq(t, `{"error": {"code": "error", "message": "something broke"}}`, out.String())
+	})
+}
+
+func TestExecute_Usage(t *testing.T) {
+	_ = setupTestEnv(t)
+	execute := func(args ...string) string {
+		resetCommands(rootCmd)
+		t.Cleanup(func() { resetCommands(rootCmd) })
+
+		var buf bytes.Buffer
+		rootCmd.SetIn(bytes.NewReader(nil))
+		rootCmd.SetOut(&buf)
+		rootCmd.SetErr(&buf)
+		rootCmd.SetArgs(args)
+		assert.Error(t, Execute())
+		return buf.String()
+	}
+
+	output := execute("workout", "fix-amrap", "squat")
+	assert.Contains(t, output, "error: accepts 2 arg(s), received 1\nUsage:\n  greyskull workout fix-amrap <lift> <reps>")
+
+	output = execute("workout", "next", "--bogus")
+	assert.Contains(t, output, "error: unknown flag: --bogus\nUsage:\n  greyskull workout next")
+
+	// Errors from running the command don't show its usage
+	output = execute("workout", "next")
+	assert.Contains(t, output, "error: ")
+	assert.NotContains(t, output, "Usage:")
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export workout data",
+	Long:  "Export your workout history to other formats for analysis in other tools.",
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportCSVCmd)
+	exportCmd.AddCommand(exportHealthKitCmd)
+	exportCmd.AddCommand(exportGoogleFitCmd)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mikowitz/greyskull/export"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var exportCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Export workout history as CSV",
+	Long: `Export the current user's workout history as CSV, one row per set, with the
+columns date, day, lift, variant, set_type, weight, target_reps, actual_reps, and
+notes. Rows are ordered by workout date. A workout's notes are on its first row.
+
+The CSV is written to stdout unless --out is given.`,
+	Example: `  greyskull export csv --out history.csv
+  greyskull export csv --lift squat --since 2024-01-01`,
+	Args: cobra.NoArgs,
+	RunE: exportCSV,
+}
+
+func init() {
+	exportCSVCmd.Flags().StringP("out", "o", "", "File to write the CSV to (default stdout)")
+	exportCSVCmd.Flags().String("lift", "", "Only export sets for this lift (squat, deadlift, bench, ohp)")
+	exportCSVCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+	exportCSVCmd.Flags().String("since", "", "Only export workouts on or after this date (YYYY-MM-DD)")
+	addIncludeArchivedFlag(exportCSVCmd)
+}
+
+func exportCSV(cmd *cobra.Command, args []string) error {
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+	sinceInput, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get since flag: %w", err)
+	}
+
+	var filter export.Filter
+	if liftInput != "" {
+		filter.Lift, err = models.ParseLiftName(liftInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	var since time.Time
+	if sinceInput != "" {
+		since, err = time.ParseInLocation(export.DateFormat, sinceInput, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", sinceInput)
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	user, err = historyUser(cmd, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	workouts := user.HistoryBetween(since, time.Time{})
+
+	if outPath == "" {
+		_, err := export.WriteCSV(cmd.OutOrStdout(), workouts, filter)
+		return err
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	rows, err := export.WriteCSV(file, workouts, filter)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close output file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "Exported %d set(s) to %s\n", rows, outPath)
+	return nil
+}
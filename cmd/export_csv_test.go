@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setExportCSVFlags(t *testing.T, flags map[string]string) {
+	for name, value := range flags {
+		require.NoError(t, exportCSVCmd.Flags().Set(name, value))
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"out", "lift", "since"} {
+			exportCSVCmd.Flags().Set(name, "")
+		}
+	})
+}
+
+func createUserWithHistory(t *testing.T, env *testEnv) *models.User {
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].StartedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+
+	workoutOn := func(date time.Time, day int, lifts ...models.Lift) models.Workout {
+		return models.Workout{ID: uuid.New(), UserProgramID: user.CurrentProgram, Day: day, Exercises: lifts, EnteredAt: date}
+	}
+	amrap := func(name models.LiftName, weight float64, reps int) models.Lift {
+		return models.Lift{ID: uuid.New(), LiftName: name, Sets: []models.Set{
+			{ID: uuid.New(), Weight: weight, TargetReps: 5, ActualReps: reps, Type: models.AMRAPSet, Order: 1},
+		}}
+	}
+
+	// Entered out of order to check that the export is sorted
+	user.WorkoutHistory = []models.Workout{
+		workoutOn(time.Date(2024, 3, 6, 18, 0, 0, 0, time.Local), 2, amrap(models.BenchPress, 125, 6), amrap(models.Deadlift, 185, 5)),
+		workoutOn(time.Date(2024, 3, 4, 18, 0, 0, 0, time.Local), 1, amrap(models.OverheadPress, 95, 7), amrap(models.Squat, 135, 8)),
+	}
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+	return user
+}
+
+func TestExportCSV(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	setExportCSVFlags(t, nil)
+
+	var buf bytes.Buffer
+	cmd := exportCSVCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "date,day,lift,variant,set_type,weight,target_reps,actual_reps,notes\n"+
+		"2024-03-04,1,OverheadPress,,AMRAPSet,95,5,7,\n"+
+		"2024-03-04,1,Squat,,AMRAPSet,135,5,8,\n"+
+		"2024-03-06,2,BenchPress,,AMRAPSet,125,5,6,\n"+
+		"2024-03-06,2,Deadlift,,AMRAPSet,185,5,5,\n", buf.String())
+}
+
+func TestExportCSV_Filters(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	setExportCSVFlags(t, map[string]string{"lift": "dl", "since": "2024-03-05"})
+
+	var buf bytes.Buffer
+	cmd := exportCSVCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"date,day,lift,variant,set_type,weight,target_reps,actual_reps,notes",
+		"2024-03-06,2,Deadlift,,AMRAPSet,185,5,5,",
+	}, lines)
+}
+
+func TestExportCSV_OutFile(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	outPath := filepath.Join(t.TempDir(), "history.csv")
+	setExportCSVFlags(t, map[string]string{"out": outPath})
+
+	var buf bytes.Buffer
+	cmd := exportCSVCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Exported 4 set(s) to "+outPath+"\n", buf.String())
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "2024-03-04,1,Squat,,AMRAPSet,135,5,8,\n")
+}
+
+func TestExportCSV_InvalidFlags(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	tests := []struct {
+		flag     string
+		value    string
+		expected string
+	}{
+		{"lift", "curl", `unknown lift "curl"`},
+		{"since", "03/05/2024", `invalid --since date "03/05/2024": expected YYYY-MM-DD`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			setExportCSVFlags(t, map[string]string{tt.flag: tt.value})
+
+			cmd := exportCSVCmd
+			cmd.SetOut(&bytes.Buffer{})
+
+			err := cmd.RunE(cmd, []string{})
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/export"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var exportHealthKitCmd = &cobra.Command{
+	Use:   "healthkit",
+	Short: "Export workouts for Apple Health",
+	Long: `Export the current user's workouts as traditional strength training workouts in
+the format of Apple Health's export.xml, for health data import apps to add to
+Apple Health. Each workout's lifts, program, and notes are kept as metadata.
+
+Workouts record when they were logged, not when they began, so each is taken to
+have lasted --duration and ended when it was logged.
+
+The XML is written to stdout unless --out is given.`,
+	Example: `  greyskull export healthkit --out workouts.xml
+  greyskull export healthkit --since 2024-01-01 --duration 75m --out workouts.xml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportHealth(cmd, export.WriteHealthKit)
+	},
+}
+
+var exportGoogleFitCmd = &cobra.Command{
+	Use:   "googlefit",
+	Short: "Export workouts for Google Fit",
+	Long: `Export the current user's workouts as Google Fit strength training sessions, in
+the JSON of the Fitness REST API's session and data point resources. Each set is
+a com.google.activity.exercise data point, with its weight in kilograms.
+
+Workouts record when they were logged, not when they began, so each is taken to
+have lasted --duration and ended when it was logged. Sets are spread evenly over
+that time.
+
+The JSON is written to stdout unless --out is given.`,
+	Example: `  greyskull export googlefit --out sessions.json
+  greyskull export googlefit --since 2024-01-01 --duration 75m --out sessions.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportHealth(cmd, export.WriteGoogleFit)
+	},
+}
+
+// healthWriter writes workouts in a health app's format, returning how many
+// were written
+type healthWriter func(w io.Writer, workouts []models.Workout, opts export.HealthOptions) (int, error)
+
+func init() {
+	for _, cmd := range []*cobra.Command{exportHealthKitCmd, exportGoogleFitCmd} {
+		cmd.Flags().StringP("out", "o", "", "File to write the export to (default stdout)")
+		cmd.Flags().String("since", "", "Only export workouts on or after this date (YYYY-MM-DD)")
+		cmd.Flags().Duration("duration", time.Hour, "How long each workout is taken to have lasted")
+		addIncludeArchivedFlag(cmd)
+	}
+}
+
+func exportHealth(cmd *cobra.Command, write healthWriter) error {
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+	sinceInput, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("failed to get since flag: %w", err)
+	}
+	duration, err := cmd.Flags().GetDuration("duration")
+	if err != nil {
+		return fmt.Errorf("failed to get duration flag: %w", err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("--duration must be positive, got %s", duration)
+	}
+
+	var since time.Time
+	if sinceInput != "" {
+		since, err = time.ParseInLocation(export.DateFormat, sinceInput, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", sinceInput)
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	user, err = historyUser(cmd, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	opts := export.HealthOptions{Duration: duration, Programs: map[uuid.UUID]export.ProgramInfo{}}
+	for id, userProgram := range user.Programs {
+		info := export.ProgramInfo{Unit: userProgram.Unit}
+		if prog, err := ctx.UserService.ProgramFor(contextFor(cmd), userProgram); err == nil {
+			info.Name = prog.Name
+		}
+		opts.Programs[id] = info
+	}
+	workouts := user.HistoryBetween(since, time.Time{})
+
+	if outPath == "" {
+		_, err := write(cmd.OutOrStdout(), workouts, opts)
+		return err
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	count, err := write(file, workouts, opts)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close output file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "Exported %d workout(s) to %s\n", count, outPath)
+	return nil
+}
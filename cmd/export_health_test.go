@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHealthKit(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "export", "healthkit", "--since", "2024-03-05")
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(output, "<Workout "))
+	assert.Contains(t, output, `<MetadataEntry key="GreyskullWorkout" value="OG Greyskull LP: Day 2"></MetadataEntry>`)
+	assert.Contains(t, output, `<MetadataEntry key="GreyskullLifts" value="Bench Press, Deadlift"></MetadataEntry>`)
+
+	outPath := filepath.Join(env.tempDir, "workouts.xml")
+	output, err = executePiped(t, "", "export", "healthkit", "--out", outPath, "--duration", "45m")
+	require.NoError(t, err)
+	assert.Equal(t, "Exported 2 workout(s) to "+outPath+"\n", output)
+
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `duration="45"`)
+}
+
+func TestExportGoogleFit(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	outPath := filepath.Join(env.tempDir, "sessions.json")
+	output, err := executePiped(t, "", "export", "googlefit", "-o", outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Exported 2 workout(s) to "+outPath+"\n", output)
+
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name": "OG Greyskull LP: Day 1"`)
+	assert.Contains(t, string(data), `"stringVal": "back_squat"`)
+}
+
+func TestExportHealth_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	_, err := executePiped(t, "", "export", "googlefit", "--since", "May 1")
+	assert.EqualError(t, err, `invalid --since date "May 1": expected YYYY-MM-DD`)
+
+	_, err = executePiped(t, "", "export", "healthkit", "--duration", "0s")
+	assert.EqualError(t, err, "--duration must be positive, got 0s")
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Set goal weights and track progress toward them",
+	Long: `Set a goal working weight for a lift in your current program and track how
+close you are. Progress is measured from the program's starting weight, and the
+ETA projects your recent rate of progress (over the last few sessions) forward.
+
+Give a goal a date with --by to see the weekly progress it needs: 'goal set squat
+315 --by 2025-06-01' shows how many pounds a week the squat has to gain to get
+there in time.
+
+Goals are shown by 'greyskull goal list', in 'greyskull status' and 'greyskull
+stats', and after logging a workout that includes the lift.`,
+}
+
+var goalSetCmd = &cobra.Command{
+	Use:               "set <lift> <weight>",
+	Short:             "Set a goal weight for a lift",
+	Example:           "  greyskull goal set squat 315\n  greyskull goal set squat 315 --by 2025-06-01",
+	Args:              cobra.ExactArgs(2),
+	RunE:              setGoal,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+var goalClearCmd = &cobra.Command{
+	Use:               "clear <lift>",
+	Short:             "Remove a lift's goal",
+	Args:              cobra.ExactArgs(1),
+	RunE:              clearGoal,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+var goalListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show progress toward each goal",
+	Args:  cobra.NoArgs,
+	RunE:  listGoals,
+}
+
+func init() {
+	goalSetCmd.Flags().String("by", "", "Date to reach the goal by (YYYY-MM-DD)")
+	rootCmd.AddCommand(goalCmd)
+	goalCmd.AddCommand(goalSetCmd)
+	goalCmd.AddCommand(goalClearCmd)
+	goalCmd.AddCommand(goalListCmd)
+}
+
+func setGoal(cmd *cobra.Command, args []string) error {
+	weight, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || weight <= 0 {
+		return fmt.Errorf("invalid goal weight %q: must be a positive number", args[1])
+	}
+	byInput, err := cmd.Flags().GetString("by")
+	if err != nil {
+		return fmt.Errorf("failed to get by flag: %w", err)
+	}
+	var by time.Time
+	if byInput != "" {
+		by, err = time.ParseInLocation("2006-01-02", byInput, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --by %q: expected YYYY-MM-DD", byInput)
+		}
+		if !by.After(time.Now()) {
+			return fmt.Errorf("invalid --by %q: the date must be in the future", byInput)
+		}
+	}
+
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if userProgram.Goals == nil {
+		userProgram.Goals = make(map[models.LiftName]float64)
+	}
+	userProgram.Goals[lift] = weight
+	delete(userProgram.GoalDeadlines, lift)
+	if !by.IsZero() {
+		if userProgram.GoalDeadlines == nil {
+			userProgram.GoalDeadlines = make(map[models.LiftName]time.Time)
+		}
+		userProgram.GoalDeadlines[lift] = by
+	}
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	weights := weightFormat(config, userProgram.Unit)
+	if by.IsZero() {
+		printf(cmd, "Goal set: %s %s\n", display.FormatLiftName(lift), weights.Format(weight))
+	} else {
+		printf(cmd, "Goal set: %s %s by %s\n", display.FormatLiftName(lift), weights.Format(weight), by.Format("2006-01-02"))
+	}
+	goal := analytics.GoalFor(lift, weight, userProgram, user.HistoryFor(userProgram.ID), time.Now())
+	printf(cmd, "%s\n", display.FormatGoalProgress(goal, weights))
+	return nil
+}
+
+func clearGoal(cmd *cobra.Command, args []string) error {
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if _, exists := userProgram.Goals[lift]; !exists {
+		return fmt.Errorf("%s has no goal", display.FormatLiftName(lift))
+	}
+	delete(userProgram.Goals, lift)
+	delete(userProgram.GoalDeadlines, lift)
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "Cleared the goal for %s.\n", display.FormatLiftName(lift))
+	return nil
+}
+
+func listGoals(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	goals := analytics.Goals(userProgram, user.HistoryFor(userProgram.ID), time.Now())
+	formatter := display.NewGoalFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayGoals(goals)
+	outputFor(cmd).Result(nonNil(goals))
+	return nil
+}
+
+// displayTrainedGoals shows progress toward the goals of the lifts in a logged
+// workout, celebrating any goal its progression just reached
+func displayTrainedGoals(cmd *cobra.Command, user *models.User, userProgram *models.UserProgram, completed *models.Workout, oldWeights map[models.LiftName]float64, weights display.WeightFormat) {
+	history := user.HistoryFor(userProgram.ID)
+	var goals []analytics.GoalProgress
+	for _, lift := range completed.Exercises {
+		key := lift.WeightKey()
+		if target, exists := userProgram.Goals[key]; exists {
+			goals = append(goals, analytics.GoalFor(key, target, userProgram, history, time.Now()))
+		}
+	}
+	if len(goals) == 0 {
+		return
+	}
+
+	formatter := display.NewGoalFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weights)
+	formatter.Printf("\n")
+	formatter.DisplayGoals(goals)
+	for _, goal := range goals {
+		if goal.Reached && oldWeights[goal.Lift] < goal.Goal {
+			formatter.Printf("Goal reached: %s %s!\n", display.FormatLiftName(goal.Lift), weights.Format(goal.Goal))
+		}
+	}
+}
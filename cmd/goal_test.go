@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoal_SetListClear(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	goalSetCmd.SetOut(&buf)
+	require.NoError(t, goalSetCmd.RunE(goalSetCmd, []string{"squat", "315"}))
+	assert.Equal(t, "Goal set: Squat 315 lbs\n"+
+		"Squat: 135 / 315 lbs [--------------------] 0%, ETA unknown until the lift progresses\n", buf.String())
+
+	user := loadTestUser(t)
+	assert.Equal(t, map[models.LiftName]float64{models.Squat: 315}, user.Programs[user.CurrentProgram].Goals)
+
+	buf.Reset()
+	goalListCmd.SetOut(&buf)
+	require.NoError(t, goalListCmd.RunE(goalListCmd, []string{}))
+	assert.Equal(t, "Goals:\n  Squat: 135 / 315 lbs [--------------------] 0%, ETA unknown until the lift progresses\n", buf.String())
+
+	buf.Reset()
+	goalClearCmd.SetOut(&buf)
+	require.NoError(t, goalClearCmd.RunE(goalClearCmd, []string{"squat"}))
+	assert.Equal(t, "Cleared the goal for Squat.\n", buf.String())
+	assert.ErrorContains(t, goalClearCmd.RunE(goalClearCmd, []string{"squat"}), "Squat has no goal")
+
+	buf.Reset()
+	require.NoError(t, goalListCmd.RunE(goalListCmd, []string{}))
+	assert.Equal(t, "No goals set. Set one with 'greyskull goal set <lift> <weight>'.\n", buf.String())
+}
+
+func TestGoal_SetInvalid(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	assert.ErrorContains(t, goalSetCmd.RunE(goalSetCmd, []string{"squat", "heavy"}), `invalid goal weight "heavy"`)
+	assert.ErrorContains(t, goalSetCmd.RunE(goalSetCmd, []string{"squat", "-5"}), "must be a positive number")
+	assert.ErrorContains(t, goalSetCmd.RunE(goalSetCmd, []string{"curl", "50"}), "unknown lift")
+}
+
+func TestGoal_Stats(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createUserWithHistory(t, env)
+	user.Programs[user.CurrentProgram].Goals = map[models.LiftName]float64{models.BenchPress: 225}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err := executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, output, "\nGoals:\n  Bench Press: 125 / 225 lbs [--------------------] 0%")
+}
+
+func TestGoal_WorkoutLogReached(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].Goals = map[models.LiftName]float64{models.Squat: 140, models.Deadlift: 300}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("7\n8\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	output := buf.String()
+	assert.Contains(t, output, "\nGoals:\n  Squat: 140 / 140 lbs [####################] 100%, reached!\n")
+	assert.Contains(t, output, "Goal reached: Squat 140 lbs!\n")
+	assert.NotContains(t, output, "Deadlift: ")
+}
+
+func TestGoal_SetBy(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	by := time.Now().AddDate(0, 0, 7*18).Format("2006-01-02")
+	output, err := executePiped(t, "", "goal", "set", "squat", "315", "--by", by)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Goal set: Squat 315 lbs by "+by+"\n")
+	assert.Contains(t, output, ", needs +10 lbs/week to reach by "+by+"\n")
+
+	user := loadTestUser(t)
+	assert.Equal(t, by, user.Programs[user.CurrentProgram].GoalDeadlines[models.Squat].Format("2006-01-02"))
+
+	output, err = executePiped(t, "", "status")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Goal: Squat: 135 / 315 lbs [--------------------] 0%, ETA unknown until the lift progresses, needs +10 lbs/week")
+
+	// Setting the goal again without a date drops the old one
+	_, err = executePiped(t, "", "goal", "set", "squat", "300")
+	require.NoError(t, err)
+	user = loadTestUser(t)
+	assert.Empty(t, user.Programs[user.CurrentProgram].GoalDeadlines)
+
+	_, err = executePiped(t, "", "goal", "set", "squat", "315", "--by", "2020-01-01")
+	assert.ErrorContains(t, err, "the date must be in the future")
+	_, err = executePiped(t, "", "goal", "set", "squat", "315", "--by", "June")
+	assert.ErrorContains(t, err, `invalid --by "June": expected YYYY-MM-DD`)
+}
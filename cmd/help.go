@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// helpCmd replaces cobra's default help command so help topics such as the
+// tutorial can be registered beneath it. It shows help for any command path.
+var helpCmd = &cobra.Command{
+	Use:   "help [command]",
+	Short: "Help about any command",
+	Long: `Help provides help for any command in the application.
+Simply type greyskull help [path to command] for full details.
+
+Run 'greyskull help tutorial' for an interactive walkthrough.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		target, _, err := cmd.Root().Find(args)
+		if target == nil || err != nil {
+			printf(cmd, "Unknown help topic %#q\n", args)
+			cobra.CheckErr(cmd.Root().Usage())
+			return
+		}
+		target.InitDefaultHelpFlag()
+		target.InitDefaultVersionFlag()
+		cobra.CheckErr(target.Help())
+	},
+}
+
+func init() {
+	rootCmd.SetHelpCommand(helpCmd)
+}
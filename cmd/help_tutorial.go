@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var helpTutorialCmd = &cobra.Command{
+	Use:   "tutorial",
+	Short: "Walk through the training loop with a practice lifter",
+	Long: `Walk through the core training loop step by step: view the next workout, log it,
+and see how your weights progress. The tutorial uses a practice lifter that only
+exists while it runs, so none of your users or workouts are touched.
+
+When input runs out (for example when piped), sample AMRAP reps are used so the
+walkthrough still runs to the end.`,
+	Args: cobra.NoArgs,
+	RunE: runTutorial,
+}
+
+// tutorialWeights are the practice lifter's starting weights, heavy enough that
+// every lift gets warmup sets
+var tutorialWeights = map[models.LiftName]float64{
+	models.OverheadPress: 95,
+	models.BenchPress:    125,
+	models.Squat:         135,
+	models.Deadlift:      185,
+}
+
+// tutorialSampleReps are used in turn for AMRAP sets once input runs out, showing
+// a normal increase followed by a doubled one
+var tutorialSampleReps = []int{7, 11}
+
+func init() {
+	helpCmd.AddCommand(helpTutorialCmd)
+}
+
+func runTutorial(cmd *cobra.Command, args []string) error {
+	prog := program.GreyskullLP
+	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+
+	// A throwaway lifter held only in memory; nothing is ever saved
+	user := &models.User{ID: uuid.New(), Username: "tutorial", CreatedAt: time.Now()}
+	userProgram := &models.UserProgram{
+		ID:              uuid.New(),
+		UserID:          user.ID,
+		ProgramID:       prog.ID,
+		StartingWeights: maps.Clone(tutorialWeights),
+		CurrentWeights:  maps.Clone(tutorialWeights),
+		CurrentDay:      1,
+		StartedAt:       time.Now(),
+		Unit:            models.Pounds,
+	}
+	user.Programs = map[uuid.UUID]*models.UserProgram{userProgram.ID: userProgram}
+	user.CurrentProgram = userProgram.ID
+
+	printf(cmd, "Welcome to greyskull! This tutorial walks through the core training loop\n")
+	printf(cmd, "with a practice lifter. Nothing you do here is saved.\n")
+	if err := tutorialPause(inputReader); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nStep 1 of 4: Start a program\n")
+	printf(cmd, "  (for real: greyskull user create, then greyskull program start)\n\n")
+	printf(cmd, "Every program starts from weights you choose. The practice lifter is\n")
+	printf(cmd, "starting %s with:\n", prog.Name)
+	for _, lift := range prog.WeightKeys() {
+		printf(cmd, "  %s: %s lbs\n", display.FormatLiftName(lift), display.FormatWeight(userProgram.CurrentWeights[lift]))
+	}
+	if err := tutorialPause(inputReader); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nStep 2 of 4: View your next workout\n")
+	printf(cmd, "  (for real: greyskull workout next)\n\n")
+	nextWorkout, err := workout.CalculateNextWorkout(user, prog)
+	if err != nil {
+		return fmt.Errorf("failed to calculate next workout: %w", err)
+	}
+	formatter.DisplayWorkout(nextWorkout)
+	printf(cmd, "Warmups ramp up from the empty bar. The last set of each lift is an AMRAP\n")
+	printf(cmd, "set: do as many reps as possible, and at least the target.\n")
+	if err := tutorialPause(inputReader); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nStep 3 of 4: Log the workout\n")
+	printf(cmd, "  (for real: greyskull workout log)\n\n")
+	printf(cmd, "Other sets are assumed complete, so you only enter your AMRAP reps. Try 10 or\n")
+	printf(cmd, "more for one lift and fewer than 5 for another to see how progression reacts.\n\n")
+	amrapReps, err := tutorialAMRAPReps(cmd, inputReader, nextWorkout)
+	if err != nil {
+		return err
+	}
+	completed := buildCompletedWorkout(nextWorkout, amrapReps)
+
+	oldWeights := userProgram.CurrentWeights
+	if err := workout.ApplyWorkout(userProgram, completed, prog); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nStep 4 of 4: See your progression\n")
+	formatter.DisplayWeightChanges(oldWeights, userProgram.CurrentWeights)
+	rules := prog.ProgressionRules
+	printf(cmd, "\nFewer than %.0f AMRAP reps deloads a lift to %.0f%% of its weight, %.0f or more adds\n",
+		rules.Parameter("deload_below"), rules.DeloadPercentage*100, rules.Parameter("deload_below"))
+	printf(cmd, "the lift's increment, and %d or more doubles it.\n", rules.DoubleThreshold)
+	printf(cmd, "Next workout: Day %d\n", userProgram.CurrentDay)
+
+	printf(cmd, "\nThat's the whole loop. To start training for real:\n")
+	printf(cmd, "  greyskull user create\n")
+	printf(cmd, "  greyskull program start\n")
+	printf(cmd, "  greyskull workout next\n")
+	printf(cmd, "  greyskull workout log\n")
+	printf(cmd, "\nRun 'greyskull help <command>' for details on any command, or 'greyskull demo'\n")
+	printf(cmd, "to explore a generated workout history.\n")
+
+	return nil
+}
+
+// tutorialPause waits for Enter between steps; running out of input just moves on
+func tutorialPause(inputReader InputReader) error {
+	_, err := inputReader.ReadLine("\nPress Enter to continue...")
+	if err != nil && !errors.Is(err, ErrNoInput) {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	return nil
+}
+
+// tutorialAMRAPReps prompts for each AMRAP set like 'workout log', re-prompting on
+// bad answers and falling back to sample reps once input runs out
+func tutorialAMRAPReps(cmd *cobra.Command, inputReader InputReader, nextWorkout *models.Workout) (map[models.LiftName]int, error) {
+	amrapReps := make(map[models.LiftName]int)
+	samples := 0
+
+	for _, exercise := range nextWorkout.Exercises {
+		for _, set := range exercise.Sets {
+			if set.Type != models.AMRAPSet {
+				continue
+			}
+
+			prompt := i18n.Sprintf("How many reps did you complete for %s AMRAP set (%d+)? ",
+				display.FormatLiftName(exercise.WeightKey()), set.TargetReps)
+			for {
+				value, err := inputReader.ReadPositiveInt(prompt)
+				if errors.Is(err, ErrNoInput) {
+					value = tutorialSampleReps[samples%len(tutorialSampleReps)]
+					samples++
+					printf(cmd, "%d (sample)\n", value)
+				} else if err != nil {
+					var invalid *InvalidInputError
+					if !errors.As(err, &invalid) {
+						return nil, fmt.Errorf("failed to read AMRAP reps for %s: %w", display.FormatLiftName(exercise.WeightKey()), err)
+					}
+					printf(cmd, "Invalid input: %v. Please try again.\n", err)
+					continue
+				}
+				amrapReps[exercise.WeightKey()] = value
+				break
+			}
+			break // Only one AMRAP set per exercise
+		}
+	}
+
+	return amrapReps, nil
+}
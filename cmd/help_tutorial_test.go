@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpTutorial(t *testing.T) {
+	setupTestEnv(t)
+
+	var buf bytes.Buffer
+	cmd := helpTutorialCmd
+	cmd.SetOut(&buf)
+	// Three pauses, a bad answer, then a double increase for OHP and a deload for Squat
+	cmd.SetIn(strings.NewReader("\n\n\nlots\n12\n3\n"))
+	t.Cleanup(func() {
+		cmd.SetOut(nil)
+		cmd.SetIn(nil)
+	})
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Step 1 of 4: Start a program")
+	assert.Contains(t, output, "Day 1 Workout:")
+	assert.Contains(t, output, "Invalid input: invalid integer: lots. Please try again.")
+	assert.Contains(t, output, "Overhead Press: 95 → 100 lbs (+5.0)")
+	assert.Contains(t, output, "Squat: 135 → 120 lbs (-15.0)")
+	assert.Contains(t, output, "Next workout: Day 2")
+	assert.NotContains(t, output, "(sample)")
+
+	// The practice lifter is never saved
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	users, err := repo.List(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestHelpTutorial_Piped(t *testing.T) {
+	setupTestEnv(t)
+
+	output, err := executePiped(t, "", "help", "tutorial")
+	require.NoError(t, err)
+	assert.Contains(t, output, "How many reps did you complete for Overhead Press AMRAP set (5+)? 7 (sample)")
+	assert.Contains(t, output, "How many reps did you complete for Squat AMRAP set (5+)? 11 (sample)")
+	assert.Contains(t, output, "Squat: 135 → 145 lbs (+10.0)")
+	assert.Contains(t, output, "That's the whole loop.")
+}
+
+func TestHelp_ShowsCommandHelp(t *testing.T) {
+	setupTestEnv(t)
+
+	output, err := executePiped(t, "", "help", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Log a completed workout for your current program.")
+}
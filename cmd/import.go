@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/importer"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import historical workouts from CSV or JSON",
+	Long: `Import workouts recorded elsewhere into your current program's history.
+
+Files have one record per set with the fields date, day, lift, variant (optional),
+set_type, weight, target_reps, actual_reps, and notes (optional). CSV files need a header row naming
+the columns; .json files hold an array of objects with the same keys. Files written
+by 'greyskull export csv' can be imported directly.
+
+  date,day,lift,set_type,weight,target_reps,actual_reps
+  2024-03-04,1,ohp,amrap,95,5,7
+
+Sets with the same date and day are grouped into one workout. Workouts already in
+your history for the same date and day are skipped. When the imported workouts are
+newer than your existing history, current weights are recalculated from each lift's
+most recent AMRAP set and the next workout day follows the last imported workout.
+
+Every invalid row is reported and nothing is imported until all rows are valid.
+Use --dry-run to check a file and preview the result without saving.`,
+	Example: `  greyskull import history.csv --dry-run
+  greyskull import history.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: importHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().Bool("dry-run", false, "Validate the file and show what would be imported without saving")
+}
+
+func importHistory(cmd *cobra.Command, args []string) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	rows, err := importer.ReadFile(args[0])
+	if err != nil {
+		return reportImportErrors(cmd, args[0], err)
+	}
+
+	imported, err := importer.BuildWorkouts(rows, userProgram.ID, len(program.Workouts))
+	if err != nil {
+		return reportImportErrors(cmd, args[0], err)
+	}
+
+	if err := user.LoadHistory(); err != nil {
+		return fmt.Errorf("failed to load workout history: %w", err)
+	}
+
+	// Skip workouts that are already in the history, e.g. from an earlier import
+	existing := user.HistoryFor(userProgram.ID)
+	var workouts []models.Workout
+	for _, w := range imported {
+		if !hasWorkoutOn(existing, w) {
+			workouts = append(workouts, w)
+		}
+	}
+	skipped := len(imported) - len(workouts)
+
+	if len(workouts) == 0 {
+		printf(cmd, "Nothing to import: all %d workout(s) in %s are already in your history.\n", len(imported), args[0])
+		return nil
+	}
+
+	sets := 0
+	for _, w := range workouts {
+		for _, lift := range w.Exercises {
+			sets += len(lift.Sets)
+		}
+	}
+	first, last := workouts[0], workouts[len(workouts)-1]
+	printf(cmd, "Importing %d workout(s) (%d sets) from %s to %s.\n",
+		len(workouts), sets, first.EnteredAt.Format("2006-01-02"), last.EnteredAt.Format("2006-01-02"))
+	if skipped > 0 {
+		printf(cmd, "Skipped %d workout(s) already in your history.\n", skipped)
+	}
+
+	user.WorkoutHistory = append(user.WorkoutHistory, workouts...)
+
+	// Only recalculate when the import brings the history up to date; backfilled
+	// older workouts shouldn't override progress logged since
+	oldWeights := maps.Clone(userProgram.CurrentWeights)
+	if len(existing) == 0 || last.EnteredAt.After(existing[len(existing)-1].EnteredAt) {
+		maps.Copy(userProgram.CurrentWeights, workout.WeightsFromHistory(user.HistoryFor(userProgram.ID), program.ProgressionRules.ForUserProgram(userProgram)))
+		userProgram.CurrentDay = workout.NextDay(last.Day, len(program.Workouts))
+	} else {
+		printf(cmd, "Imported workouts are older than your existing history; current weights are unchanged.\n")
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayWeightChanges(oldWeights, userProgram.CurrentWeights)
+	printf(cmd, "\nNext workout: Day %d\n", userProgram.CurrentDay)
+
+	if dryRun {
+		printf(cmd, "\nDry run: nothing was saved.\n")
+		return nil
+	}
+
+	if err := backupUser(cmd, ctx, user.Username, "import"); err != nil {
+		return err
+	}
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save imported workouts: %w", err)
+	}
+
+	printf(cmd, "\nImport complete!\n")
+	return nil
+}
+
+// reportImportErrors prints each invalid row before returning a summary error
+func reportImportErrors(cmd *cobra.Command, filename string, err error) error {
+	var validationErr *importer.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	for _, rowErr := range validationErr.Errors {
+		printf(cmd, "%s\n", rowErr)
+	}
+	return fmt.Errorf("%s has %d invalid row(s); nothing was imported", filename, len(validationErr.Errors))
+}
+
+// hasWorkoutOn reports whether history has a workout for the same date and day as w
+func hasWorkoutOn(history []models.Workout, w models.Workout) bool {
+	date := w.EnteredAt.Local().Format("2006-01-02")
+	for _, h := range history {
+		if h.Day == w.Day && h.EnteredAt.Local().Format("2006-01-02") == date {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importHistoryCSV = `date,day,lift,set_type,weight,target_reps,actual_reps
+2030-03-04,1,ohp,working,95,5,5
+2030-03-04,1,ohp,amrap,95,5,8
+2030-03-04,1,squat,amrap,135,5,10
+2030-03-06,2,bench,amrap,125,5,4
+2030-03-06,2,deadlift,amrap,185,5,7
+`
+
+func runImport(t *testing.T, content, name string, dryRun bool) (string, error) {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	var buf bytes.Buffer
+	cmd := importCmd
+	cmd.SetOut(&buf)
+	if dryRun {
+		require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+		t.Cleanup(func() { cmd.Flags().Set("dry-run", "false") })
+	}
+
+	err := cmd.RunE(cmd, []string{path})
+	return buf.String(), err
+}
+
+func TestImport(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := runImport(t, importHistoryCSV, "history.csv", false)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Importing 2 workout(s) (5 sets) from 2030-03-04 to 2030-03-06.")
+	assert.Contains(t, output, "Squat: 135 → 145 lbs (+10.0)")
+	assert.Contains(t, output, "Bench Press: 125 → 112.5 lbs (-12.5)")
+	assert.Contains(t, output, "Next workout: Day 3")
+	assert.Contains(t, output, "Import complete!")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 2)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 3, userProgram.CurrentDay)
+	assert.Equal(t, 97.5, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 190.0, userProgram.CurrentWeights[models.Deadlift])
+
+	// Importing the same file again skips every workout
+	output, err = runImport(t, importHistoryCSV, "history.csv", false)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Nothing to import: all 2 workout(s)")
+	assert.Len(t, loadTestUser(t).WorkoutHistory, 2)
+}
+
+func TestImport_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	content := `[{"date": "2030-03-04", "day": 1, "lift": "squat", "set_type": "amrap", "weight": 135, "target_reps": 5, "actual_reps": 6}]`
+	output, err := runImport(t, content, "history.json", false)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Importing 1 workout(s) (1 sets)")
+	assert.Equal(t, 140.0, loadTestUser(t).Programs[loadTestUser(t).CurrentProgram].CurrentWeights[models.Squat])
+}
+
+func TestImport_DryRun(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := runImport(t, importHistoryCSV, "history.csv", true)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Squat: 135 → 145 lbs (+10.0)")
+	assert.Contains(t, output, "Dry run: nothing was saved.")
+
+	user := loadTestUser(t)
+	assert.Empty(t, user.WorkoutHistory)
+	assert.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+}
+
+func TestImport_Backfill(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	content := "date,day,lift,set_type,weight,target_reps,actual_reps\n2020-01-06,1,squat,amrap,95,5,8\n"
+	output, err := runImport(t, content, "old.csv", false)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "current weights are unchanged")
+	user := loadTestUser(t)
+	assert.Len(t, user.WorkoutHistory, 3)
+	assert.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+}
+
+func TestImport_InvalidRows(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	content := "date,day,lift,set_type,weight,target_reps,actual_reps\n" +
+		"2030-03-04,1,ohp,amrap,95,5,8\n" +
+		"2030-03-04,7,ohp,amrap,95,5,8\n" +
+		"2030-03-04,1,curl,amrap,30,5,8\n"
+	output, err := runImport(t, content, "history.csv", false)
+
+	assert.ErrorContains(t, err, "has 2 invalid row(s); nothing was imported")
+	assert.Contains(t, output, "row 3: invalid day 7: the program has days 1-6\n")
+	assert.Contains(t, output, `row 4: unknown lift "curl"`)
+	assert.Empty(t, loadTestUser(t).WorkoutHistory)
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/mikowitz/greyskull/i18n"
 )
 
 // InputReader provides an abstraction for user input operations,
@@ -25,6 +28,32 @@ type InputReader interface {
 
 	// ReadPositiveInt reads a positive integer, rejecting negative values and zero
 	ReadPositiveInt(prompt string) (int, error)
+
+	// ReadNonNegativeInt reads an integer that may be zero, rejecting negative values
+	ReadNonNegativeInt(prompt string) (int, error)
+
+	// ReadConfirm reads a yes/no answer, treating an empty answer as no
+	ReadConfirm(prompt string) (bool, error)
+
+	// ReadMultiLine reads lines of text until a blank line or a line containing only "."
+	ReadMultiLine(prompt string) (string, error)
+}
+
+// ErrNoInput is returned when input runs out, e.g. at the end of piped input
+var ErrNoInput = errors.New("no input available")
+
+// InvalidInputError reports input that was read but could not be accepted.
+// Unlike read failures or ErrNoInput, the user can be prompted again.
+type InvalidInputError struct {
+	Message string
+}
+
+func (e *InvalidInputError) Error() string {
+	return e.Message
+}
+
+func invalidInput(format string, a ...any) error {
+	return &InvalidInputError{Message: fmt.Sprintf(format, a...)}
 }
 
 // CLIInputReader implements InputReader for command-line interface usage
@@ -45,9 +74,9 @@ func NewCLIInputReader(in io.Reader, out io.Writer) *CLIInputReader {
 
 // ReadLine reads a single line of input after displaying the prompt
 func (r *CLIInputReader) ReadLine(prompt string) (string, error) {
-	// Display the prompt if provided
+	// Display the prompt, translated, if provided
 	if prompt != "" {
-		if _, err := r.out.Write([]byte(prompt)); err != nil {
+		if _, err := r.out.Write([]byte(i18n.T(prompt))); err != nil {
 			// Continue even if writing the prompt fails
 		}
 	}
@@ -57,7 +86,7 @@ func (r *CLIInputReader) ReadLine(prompt string) (string, error) {
 		if err := r.scanner.Err(); err != nil {
 			return "", fmt.Errorf("failed to read input: %w", err)
 		}
-		return "", fmt.Errorf("no input available")
+		return "", ErrNoInput
 	}
 
 	// Trim whitespace and return
@@ -73,13 +102,13 @@ func (r *CLIInputReader) ReadFloat(prompt string) (float64, error) {
 
 	// Check for empty input
 	if input == "" {
-		return 0, fmt.Errorf("input cannot be empty")
+		return 0, invalidInput("input cannot be empty")
 	}
 
 	// Parse the float
 	value, err := strconv.ParseFloat(input, 64)
 	if err != nil {
-		return 0, fmt.Errorf("invalid number: %s", input)
+		return 0, invalidInput("invalid number: %s", input)
 	}
 
 	return value, nil
@@ -94,13 +123,13 @@ func (r *CLIInputReader) ReadInt(prompt string) (int, error) {
 
 	// Check for empty input
 	if input == "" {
-		return 0, fmt.Errorf("input cannot be empty")
+		return 0, invalidInput("input cannot be empty")
 	}
 
 	// Parse the integer
 	value, err := strconv.Atoi(input)
 	if err != nil {
-		return 0, fmt.Errorf("invalid integer: %s", input)
+		return 0, invalidInput("invalid integer: %s", input)
 	}
 
 	return value, nil
@@ -115,7 +144,7 @@ func (r *CLIInputReader) ReadPositiveFloat(prompt string) (float64, error) {
 
 	// Check if positive
 	if value <= 0 {
-		return 0, fmt.Errorf("number must be positive, got: %g", value)
+		return 0, invalidInput("number must be positive, got: %g", value)
 	}
 
 	return value, nil
@@ -130,9 +159,65 @@ func (r *CLIInputReader) ReadPositiveInt(prompt string) (int, error) {
 
 	// Check if positive
 	if value <= 0 {
-		return 0, fmt.Errorf("number must be positive, got: %d", value)
+		return 0, invalidInput("number must be positive, got: %d", value)
+	}
+
+	return value, nil
+}
+
+// ReadNonNegativeInt reads an integer that may be zero, rejecting negative values
+func (r *CLIInputReader) ReadNonNegativeInt(prompt string) (int, error) {
+	value, err := r.ReadInt(prompt)
+	if err != nil {
+		return 0, err
+	}
+
+	if value < 0 {
+		return 0, invalidInput("number cannot be negative, got: %d", value)
 	}
 
 	return value, nil
 }
 
+// ReadConfirm reads a yes/no answer, treating an empty answer as no
+func (r *CLIInputReader) ReadConfirm(prompt string) (bool, error) {
+	input, err := r.ReadLine(prompt)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(input) {
+	case "y", "yes":
+		return true, nil
+	case "", "n", "no":
+		return false, nil
+	}
+	return false, invalidInput("invalid answer: %s (expected yes or no)", input)
+}
+
+// ReadMultiLine reads lines of text after displaying the prompt, until a blank
+// line, a line containing only ".", or the end of input. Lines keep their
+// indentation but lose trailing whitespace, and are joined with newlines. An
+// immediately blank answer returns an empty string; ErrNoInput is only returned
+// when input had already run out.
+func (r *CLIInputReader) ReadMultiLine(prompt string) (string, error) {
+	if prompt != "" {
+		r.out.Write([]byte(i18n.T(prompt)))
+	}
+
+	var lines []string
+	for r.scanner.Scan() {
+		line := strings.TrimRight(r.scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "." {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+	if err := r.scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	if len(lines) == 0 {
+		return "", ErrNoInput
+	}
+	return strings.Join(lines, "\n"), nil
+}
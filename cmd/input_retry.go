@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxAttempts is how many answers a RetryingInputReader accepts for a
+// prompt before giving up
+const DefaultMaxAttempts = 3
+
+// RetryingInputReader wraps an InputReader, re-prompting when an answer is
+// invalid instead of returning the error, so a typo doesn't throw away
+// everything entered before it. Read failures and running out of input are
+// returned straight away.
+type RetryingInputReader struct {
+	InputReader
+	out         io.Writer
+	maxAttempts int
+}
+
+// NewRetryingInputReader creates a RetryingInputReader that reports invalid
+// answers to out and asks up to maxAttempts times per prompt. A maxAttempts
+// below 1 keeps asking until a valid answer is given or input runs out.
+func NewRetryingInputReader(reader InputReader, out io.Writer, maxAttempts int) *RetryingInputReader {
+	return &RetryingInputReader{
+		InputReader: reader,
+		out:         out,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// ReadFloat reads a floating-point number, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadFloat(prompt string) (float64, error) {
+	return retryRead(r, func() (float64, error) { return r.InputReader.ReadFloat(prompt) })
+}
+
+// ReadInt reads an integer, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadInt(prompt string) (int, error) {
+	return retryRead(r, func() (int, error) { return r.InputReader.ReadInt(prompt) })
+}
+
+// ReadPositiveFloat reads a positive floating-point number, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadPositiveFloat(prompt string) (float64, error) {
+	return retryRead(r, func() (float64, error) { return r.InputReader.ReadPositiveFloat(prompt) })
+}
+
+// ReadPositiveInt reads a positive integer, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadPositiveInt(prompt string) (int, error) {
+	return retryRead(r, func() (int, error) { return r.InputReader.ReadPositiveInt(prompt) })
+}
+
+// ReadNonNegativeInt reads an integer that may be zero, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadNonNegativeInt(prompt string) (int, error) {
+	return retryRead(r, func() (int, error) { return r.InputReader.ReadNonNegativeInt(prompt) })
+}
+
+// ReadConfirm reads a yes/no answer, re-prompting on invalid answers
+func (r *RetryingInputReader) ReadConfirm(prompt string) (bool, error) {
+	return retryRead(r, func() (bool, error) { return r.InputReader.ReadConfirm(prompt) })
+}
+
+// retryRead calls read until it succeeds, fails with something other than an
+// InvalidInputError, or the reader's attempts are used up
+func retryRead[T any](r *RetryingInputReader, read func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		value, err := read()
+		var invalid *InvalidInputError
+		if err == nil || !errors.As(err, &invalid) {
+			return value, err
+		}
+		if r.maxAttempts > 0 && attempt >= r.maxAttempts {
+			return value, fmt.Errorf("gave up after %d invalid answers: %w", attempt, err)
+		}
+		fprintf(r.out, "Invalid input: %v. Please try again.\n", err)
+	}
+}
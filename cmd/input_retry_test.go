@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryingInputReader(t *testing.T) {
+	newReader := func(input string, maxAttempts int) (*RetryingInputReader, *bytes.Buffer) {
+		var output bytes.Buffer
+		cli := NewCLIInputReader(strings.NewReader(input), &output)
+		return NewRetryingInputReader(cli, &output, maxAttempts), &output
+	}
+
+	t.Run("re-prompts until valid", func(t *testing.T) {
+		reader, output := newReader("8a\n-2\n8\n", 3)
+		value, err := reader.ReadPositiveInt("Reps? ")
+		require.NoError(t, err)
+		assert.Equal(t, 8, value)
+		assert.Equal(t, "Reps? Invalid input: invalid integer: 8a. Please try again.\n"+
+			"Reps? Invalid input: number must be positive, got: -2. Please try again.\n"+
+			"Reps? ", output.String())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		reader, _ := newReader("x\ny\n5\n", 2)
+		_, err := reader.ReadInt("Reps? ")
+		var invalid *InvalidInputError
+		require.ErrorAs(t, err, &invalid)
+		assert.EqualError(t, err, "gave up after 2 invalid answers: invalid integer: y")
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		reader, _ := newReader("x\ny\nmaybe\nyes\n", 0)
+		confirmed, err := reader.ReadConfirm("Sure? ")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("stops when input runs out", func(t *testing.T) {
+		reader, _ := newReader("x\n", 0)
+		_, err := reader.ReadNonNegativeInt("Reps? ")
+		assert.ErrorIs(t, err, ErrNoInput)
+	})
+
+	t.Run("passes other reads through", func(t *testing.T) {
+		reader, _ := newReader("hello\n", 3)
+		line, err := reader.ReadLine("")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", line)
+	})
+}
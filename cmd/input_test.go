@@ -566,6 +566,88 @@ func TestCLIInputReader_WriteError(t *testing.T) {
 	assert.Equal(t, "test", result)
 }
 
+// TestCLIInputReader_ReadNonNegativeInt tests integers that may be zero
+func TestCLIInputReader_ReadNonNegativeInt(t *testing.T) {
+	for input, expected := range map[string]int{"0\n": 0, "5\n": 5} {
+		reader := NewCLIInputReader(strings.NewReader(input), &bytes.Buffer{})
+		result, err := reader.ReadNonNegativeInt("Reps: ")
+		require.NoError(t, err, "input %q", input)
+		assert.Equal(t, expected, result, "input %q", input)
+	}
+
+	reader := NewCLIInputReader(strings.NewReader("-1\n"), &bytes.Buffer{})
+	_, err := reader.ReadNonNegativeInt("Reps: ")
+	var invalid *InvalidInputError
+	assert.ErrorAs(t, err, &invalid)
+	assert.ErrorContains(t, err, "number cannot be negative, got: -1")
+}
+
+// TestCLIInputReader_ReadConfirm tests yes/no answers
+func TestCLIInputReader_ReadConfirm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"no\n", false},
+		{"\n", false},
+	}
+
+	for _, tt := range tests {
+		reader := NewCLIInputReader(strings.NewReader(tt.input), &bytes.Buffer{})
+		result, err := reader.ReadConfirm("Continue? ")
+		require.NoError(t, err, "input %q", tt.input)
+		assert.Equal(t, tt.expected, result, "input %q", tt.input)
+	}
+
+	reader := NewCLIInputReader(strings.NewReader("sure\n"), &bytes.Buffer{})
+	_, err := reader.ReadConfirm("Continue? ")
+	assert.ErrorContains(t, err, "invalid answer: sure")
+
+	reader = NewCLIInputReader(strings.NewReader(""), &bytes.Buffer{})
+	_, err = reader.ReadConfirm("Continue? ")
+	assert.ErrorContains(t, err, "no input available")
+}
+
+// TestCLIInputReader_ReadMultiLine tests reading notes that span several lines
+func TestCLIInputReader_ReadMultiLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"blank line ends", "Left knee sore\n  felt fine after warmups\n\nnext\n", "Left knee sore\n  felt fine after warmups"},
+		{"dot ends", "Belt on last set  \n.\n", "Belt on last set"},
+		{"immediately blank", "\n", ""},
+		{"end of input", "One line", "One line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			reader := NewCLIInputReader(strings.NewReader(tt.input), &out)
+			result, err := reader.ReadMultiLine("Notes:\n")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, "Notes:\n", out.String())
+		})
+	}
+
+	// The line after the terminator is left for the next prompt
+	reader := NewCLIInputReader(strings.NewReader("a\n.\n5\n"), &bytes.Buffer{})
+	_, err := reader.ReadMultiLine("")
+	require.NoError(t, err)
+	next, err := reader.ReadInt("")
+	require.NoError(t, err)
+	assert.Equal(t, 5, next)
+
+	reader = NewCLIInputReader(strings.NewReader(""), &bytes.Buffer{})
+	_, err = reader.ReadMultiLine("Notes: ")
+	assert.ErrorIs(t, err, ErrNoInput)
+}
+
 // Helper types for testing error conditions
 
 type erroringReader struct {
@@ -32,7 +32,7 @@ func TestUserWorkflow_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test 1: Initially no users should exist
-	usernames, err := repo.List()
+	usernames, err := repo.List(t.Context())
 	require.NoError(t, err)
 	assert.Empty(t, usernames)
 
@@ -45,15 +45,15 @@ func TestUserWorkflow_Integration(t *testing.T) {
 		WorkoutHistory: []models.Workout{},
 		CreatedAt:      time.Now(),
 	}
-	err = repo.Create(user1)
+	err = repo.Create(t.Context(), user1)
 	require.NoError(t, err)
 
 	// Test 3: Set as current user
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Test 4: Verify current user is set
-	currentUser, err := repo.GetCurrent()
+	currentUser, err := repo.GetCurrent(t.Context())
 	require.NoError(t, err)
 	assert.Equal(t, "TestUser", currentUser)
 
@@ -66,7 +66,7 @@ func TestUserWorkflow_Integration(t *testing.T) {
 		WorkoutHistory: []models.Workout{},
 		CreatedAt:      time.Now(),
 	}
-	err = repo.Create(user2)
+	err = repo.Create(t.Context(), user2)
 	require.NoError(t, err)
 
 	// Test 6: Test user listing functionality
@@ -91,7 +91,7 @@ func TestUserWorkflow_Integration(t *testing.T) {
 	assert.Contains(t, output, "Switched to user \"alice\"")
 
 	// Test 8: Verify current user changed
-	currentUser, err = repo.GetCurrent()
+	currentUser, err = repo.GetCurrent(t.Context())
 	require.NoError(t, err)
 	assert.Equal(t, "alice", currentUser)
 
@@ -118,7 +118,7 @@ func TestUserWorkflow_Integration(t *testing.T) {
 		WorkoutHistory: []models.Workout{},
 		CreatedAt:      time.Now(),
 	}
-	err = repo.Create(duplicateUser)
+	err = repo.Create(t.Context(), duplicateUser)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, repository.ErrUserAlreadyExists)
 
@@ -131,7 +131,7 @@ func TestUserWorkflow_Integration(t *testing.T) {
 		WorkoutHistory: []models.Workout{},
 		CreatedAt:      time.Now(),
 	}
-	err = repo.Create(duplicateUser2)
+	err = repo.Create(t.Context(), duplicateUser2)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, repository.ErrUserAlreadyExists)
 }
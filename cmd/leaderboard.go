@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+var leaderboardCmd = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "Rank everyone sharing this data directory on a lift",
+	Long: `Rank the users in this data directory on a lift, for households or friends
+sharing a machine or a synced data directory. Users are ranked by the lift's
+current working weight, or with --by e1rm by their best estimated one-rep max
+(Epley formula), counting only their active program.
+
+The leaderboard is opt-in: only users who have run 'greyskull user leaderboard on'
+are listed. Weights are shown in each user's own unit and compared across units.`,
+	Example: "  greyskull leaderboard --lift squat --by e1rm",
+	Args:    cobra.NoArgs,
+	RunE:    showLeaderboard,
+}
+
+func init() {
+	rootCmd.AddCommand(leaderboardCmd)
+	leaderboardCmd.Flags().String("lift", "", "Lift to rank (squat, deadlift, bench, ohp)")
+	leaderboardCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+	leaderboardCmd.Flags().String("by", string(analytics.ByWeight), "Rank by working weight (weight) or estimated one-rep max (e1rm)")
+	leaderboardCmd.MarkFlagRequired("lift")
+}
+
+func showLeaderboard(cmd *cobra.Command, args []string) error {
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+	lift, err := models.ParseLiftName(liftInput)
+	if err != nil {
+		return err
+	}
+
+	byInput, err := cmd.Flags().GetString("by")
+	if err != nil {
+		return fmt.Errorf("failed to get by flag: %w", err)
+	}
+	metric := analytics.LeaderboardMetric(strings.ToLower(strings.TrimSpace(byInput)))
+	if metric != analytics.ByWeight && metric != analytics.ByE1RM {
+		return fmt.Errorf("invalid --by %q (expected weight or e1rm)", byInput)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	users, err := ctx.UserService.ListUsers(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	standings := analytics.Leaderboard(users, lift, metric)
+	display.NewLeaderboardFormatter(cmd.OutOrStdout()).DisplayLeaderboard(lift, metric, standings)
+	outputFor(cmd).Result(nonNil(standings))
+
+	// Remind the current user how to join if they haven't
+	current, err := ctx.UserService.CurrentUsername(contextFor(cmd))
+	if errors.Is(err, repository.ErrNoCurrentUser) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if strings.EqualFold(user.Username, current) && !user.Leaderboard {
+			printf(cmd, "\nYou aren't on the leaderboard. Join with 'greyskull user leaderboard on'.\n")
+		}
+	}
+	return nil
+}
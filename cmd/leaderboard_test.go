@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRival adds an opted-in user squatting 185 lbs alongside the test user
+func createRival(t *testing.T) {
+	userProgram := &models.UserProgram{
+		ID:             uuid.New(),
+		ProgramID:      uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 185},
+		CurrentDay:     1,
+		StartedAt:      time.Now(),
+	}
+	rival := &models.User{
+		ID:             uuid.New(),
+		Username:       "Rival",
+		CurrentProgram: userProgram.ID,
+		Programs:       map[uuid.UUID]*models.UserProgram{userProgram.ID: userProgram},
+		WorkoutHistory: []models.Workout{},
+		CreatedAt:      time.Now(),
+		Leaderboard:    true,
+	}
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(t.Context(), rival))
+}
+
+func TestLeaderboard(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	createRival(t)
+
+	var buf bytes.Buffer
+	cmd := leaderboardCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("lift", "squat"))
+	t.Cleanup(func() {
+		cmd.Flags().Set("lift", "")
+		cmd.Flags().Set("by", "weight")
+	})
+
+	// The current user hasn't opted in, so only the rival is ranked
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "Squat Leaderboard (working weight):\n"+
+		"  1. Rival: 185 lbs\n"+
+		"\nYou aren't on the leaderboard. Join with 'greyskull user leaderboard on'.\n", buf.String())
+
+	buf.Reset()
+	userLeaderboardCmd.SetOut(&buf)
+	require.NoError(t, userLeaderboardCmd.RunE(userLeaderboardCmd, []string{"on"}))
+	assert.Equal(t, "You've joined the leaderboard.\n", buf.String())
+	assert.True(t, loadTestUser(t).Leaderboard)
+
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "Squat Leaderboard (working weight):\n"+
+		"  1. Rival: 185 lbs\n"+
+		"  2. TestUser: 135 lbs\n", buf.String())
+
+	// Neither user has logged a squat, so there is no e1RM to rank
+	buf.Reset()
+	require.NoError(t, cmd.Flags().Set("by", "e1rm"))
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "No one to rank for Squat yet. Users join the leaderboard with 'greyskull user leaderboard on'.\n", buf.String())
+
+	require.NoError(t, cmd.Flags().Set("by", "tonnage"))
+	assert.ErrorContains(t, cmd.RunE(cmd, []string{}), `invalid --by "tonnage"`)
+}
+
+func TestUserLeaderboard(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := userLeaderboardCmd
+	cmd.SetOut(&buf)
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "You aren't on the leaderboard.\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{"on"}))
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "You've joined the leaderboard.\nYou're on the leaderboard.\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{"OFF"}))
+	assert.Equal(t, "You've left the leaderboard.\n", buf.String())
+	assert.False(t, loadTestUser(t).Leaderboard)
+
+	assert.ErrorContains(t, cmd.RunE(cmd, []string{"maybe"}), `invalid setting "maybe"`)
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var liftCmd = &cobra.Command{
+	Use:   "lift",
+	Short: "Manage individual lifts",
+	Long:  "Manage individual lifts, such as temporarily holding a lift's weight, setting a training max or rounding step, or defining custom lifts for your own programs.",
+}
+
+func init() {
+	rootCmd.AddCommand(liftCmd)
+	liftCmd.AddCommand(liftHoldCmd)
+	liftCmd.AddCommand(liftReleaseCmd)
+	liftCmd.AddCommand(liftDefineCmd)
+	liftCmd.AddCommand(liftListCmd)
+	liftCmd.AddCommand(liftTrainingMaxCmd)
+	liftCmd.AddCommand(liftRoundingCmd)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var liftDefineCmd = &cobra.Command{
+	Use:   "define <name>",
+	Short: "Add a custom lift for use in custom programs",
+	Long: `Add a lift beyond squat, deadlift, bench, and overhead press, such as FrontSquat
+or RomanianDeadlift. Lifts are shared by every user on this machine, and
+defining a lift that already exists updates it.
+
+Custom programs name the lift in lift_name. Its increment is used when the
+program's increase_rules has no entry for it, and its bar weight replaces the
+standard barbell in empty bar warmups, e.g. for a 35 lb women's bar. Once
+defined, the lift's name, with or without dashes, and its aliases are accepted
+anywhere a lift is given, e.g. 'greyskull lift hold front-squat'.
+
+Coaching cues and a demonstration link are shown by 'greyskull workout next
+--cues' for programs whose templates don't give their own.`,
+	Example: `  greyskull lift define FrontSquat --display "Front Squat" --increment 5 --alias fsq
+  greyskull lift define FrontSquat --cue "Elbows high" --cue "Sit straight down" --url https://example.com/front-squat
+  greyskull lift define RomanianDeadlift --display "Romanian Deadlift" --increment 10 --alias rdl`,
+	Args: cobra.ExactArgs(1),
+	RunE: defineLift,
+}
+
+var liftListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in and custom lifts",
+	Args:  cobra.NoArgs,
+	RunE:  listLifts,
+}
+
+func init() {
+	liftDefineCmd.Flags().String("display", "", "Name shown in workouts and stats (defaults to the lift name)")
+	liftDefineCmd.Flags().Float64("increment", 0, "Default progression increment")
+	liftDefineCmd.Flags().Float64("bar", 0, "Empty bar weight for warmups (defaults to a standard barbell)")
+	liftDefineCmd.Flags().StringSlice("alias", nil, "Short name accepted on the command line (repeatable)")
+	liftDefineCmd.Flags().String("unit", "", "Unit of the increment and bar weight (defaults to the current user's unit, or lbs)")
+	liftDefineCmd.Flags().StringArray("cue", nil, "Coaching cue shown with the lift (repeatable)")
+	liftDefineCmd.Flags().String("url", "", "Link to a demonstration of the lift")
+}
+
+func defineLift(cmd *cobra.Command, args []string) error {
+	displayName, err := cmd.Flags().GetString("display")
+	if err != nil {
+		return fmt.Errorf("failed to get display flag: %w", err)
+	}
+	increment, err := cmd.Flags().GetFloat64("increment")
+	if err != nil {
+		return fmt.Errorf("failed to get increment flag: %w", err)
+	}
+	barWeight, err := cmd.Flags().GetFloat64("bar")
+	if err != nil {
+		return fmt.Errorf("failed to get bar flag: %w", err)
+	}
+	aliases, err := cmd.Flags().GetStringSlice("alias")
+	if err != nil {
+		return fmt.Errorf("failed to get alias flag: %w", err)
+	}
+	unitInput, err := cmd.Flags().GetString("unit")
+	if err != nil {
+		return fmt.Errorf("failed to get unit flag: %w", err)
+	}
+	cues, err := cmd.Flags().GetStringArray("cue")
+	if err != nil {
+		return fmt.Errorf("failed to get cue flag: %w", err)
+	}
+	link, err := cmd.Flags().GetString("url")
+	if err != nil {
+		return fmt.Errorf("failed to get url flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+	if ctx.LiftRepo == nil {
+		return fmt.Errorf("custom lifts are not supported by this storage backend")
+	}
+
+	name := models.LiftName(strings.TrimSpace(args[0]))
+	if models.IsBuiltinLift(name) {
+		return fmt.Errorf("%s is a built-in lift and can't be redefined", name)
+	}
+	if displayName == "" {
+		displayName = string(name)
+	}
+
+	var unit models.WeightUnit
+	if unitInput != "" {
+		if unit, err = models.ParseWeightUnit(unitInput); err != nil {
+			return err
+		}
+	} else if user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd)); err == nil {
+		unit = user.Unit.OrDefault()
+	} else {
+		unit = models.Pounds
+	}
+
+	// Aliases must not shadow another lift's name or alias
+	for _, alias := range aliases {
+		if existing, err := models.ParseLiftName(alias); err == nil && existing != name {
+			return fmt.Errorf("alias %q is already used by %s", alias, display.FormatLiftName(existing))
+		}
+	}
+
+	definition := models.LiftDefinition{
+		Name:        name,
+		DisplayName: displayName,
+		Aliases:     aliases,
+		Increment:   increment,
+		BarWeight:   barWeight,
+		Unit:        unit,
+		Cues:        cues,
+		URL:         strings.TrimSpace(link),
+	}
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+
+	if err := ctx.LiftRepo.Save(definition); err != nil {
+		return fmt.Errorf("failed to save lift: %w", err)
+	}
+
+	printf(cmd, "Defined %s (%s).\n", definition.DisplayName, definition.Name)
+	return nil
+}
+
+func listLifts(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	if _, err := newCommandContext(cmd); err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	printf(cmd, "Lifts:\n")
+	for _, def := range models.Lifts() {
+		if models.IsBuiltinLift(def.Name) {
+			printf(cmd, "  %s (%s)\n", def.DisplayName, def.Name)
+			continue
+		}
+
+		var details []string
+		if len(def.Aliases) > 0 {
+			details = append(details, "aliases: "+strings.Join(def.Aliases, ", "))
+		}
+		if def.Increment > 0 {
+			details = append(details, i18n.Sprintf("increment: %s %s", display.FormatWeight(def.Increment), def.Unit.OrDefault()))
+		}
+		if def.BarWeight > 0 {
+			details = append(details, i18n.Sprintf("bar: %s %s", display.FormatWeight(def.BarWeight), def.Unit.OrDefault()))
+		}
+		line := fmt.Sprintf("  %s (%s)", def.DisplayName, def.Name)
+		if len(details) > 0 {
+			line += " - " + strings.Join(details, "; ")
+		}
+		printf(cmd, "%s\n", line)
+	}
+	return nil
+}
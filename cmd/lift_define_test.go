@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runLiftDefine(t *testing.T, name string, flags map[string]string) (string, error) {
+	var buf bytes.Buffer
+	cmd := liftDefineCmd
+	cmd.SetOut(&buf)
+	for flag, value := range flags {
+		require.NoError(t, cmd.Flags().Set(flag, value))
+	}
+	t.Cleanup(func() {
+		for flag := range flags {
+			resetFlag(cmd.Flags().Lookup(flag))
+		}
+	})
+
+	err := cmd.RunE(cmd, []string{name})
+	return buf.String(), err
+}
+
+func TestLiftDefine(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := runLiftDefine(t, "FrontSquat", map[string]string{
+		"display": "Front Squat", "increment": "5", "bar": "35", "alias": "fsq",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Defined Front Squat (FrontSquat).")
+
+	var buf bytes.Buffer
+	liftListCmd.SetOut(&buf)
+	require.NoError(t, liftListCmd.RunE(liftListCmd, []string{}))
+	assert.Contains(t, buf.String(), "  Bench Press (BenchPress)\n")
+	assert.Contains(t, buf.String(), "  Front Squat (FrontSquat) - aliases: fsq; increment: 5 lbs; bar: 35 lbs\n")
+
+	// A program can use the lift without its own increase rule
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Front Squats"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = slices.Clone(prog.Workouts[0].Lifts)
+	for i, lift := range prog.Workouts[0].Lifts {
+		if lift.LiftName == models.Squat {
+			prog.Workouts[0].Lifts[i].LiftName = "FrontSquat"
+		}
+	}
+	user.Programs[user.CurrentProgram].CurrentWeights["FrontSquat"] = 115
+	useCustomProgram(t, user, &prog)
+
+	buf.Reset()
+	workoutNextCmd.SetOut(&buf)
+	require.NoError(t, workoutNextCmd.RunE(workoutNextCmd, []string{}))
+	assert.Contains(t, buf.String(), "Front Squat:")
+	assert.Contains(t, buf.String(), "5 reps @ 35 lbs")
+
+	// The alias is accepted wherever a lift is named, even before a command
+	// loads anything, as in a new process
+	models.RegisterLifts(nil)
+	output, err = executePiped(t, "", "lift", "hold", "fsq")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Holding Front Squat at 115 lbs for 1 session(s).")
+}
+
+func TestLiftDefine_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	liftDefineCmd.SetOut(io.Discard)
+
+	_, err := runLiftDefine(t, "Squat", nil)
+	assert.ErrorContains(t, err, "Squat is a built-in lift")
+
+	_, err = runLiftDefine(t, "Front Squat", nil)
+	assert.ErrorContains(t, err, "invalid lift name")
+
+	_, err = runLiftDefine(t, "Pendlay", map[string]string{"alias": "bench"})
+	assert.ErrorContains(t, err, `alias "bench" is already used by Bench Press`)
+
+	_, err = runLiftDefine(t, "Pendlay", map[string]string{"unit": "stone"})
+	assert.ErrorContains(t, err, "unknown weight unit")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var liftHoldCmd = &cobra.Command{
+	Use:   "hold <lift>",
+	Short: "Hold a lift's weight constant for a number of sessions",
+	Long: `Hold a lift's weight constant for the next N sessions in which it is performed,
+for example while managing an injury. Other lifts continue to progress normally.
+AMRAP reps are still recorded, but held lifts neither increase nor deload.`,
+	Example:           "  greyskull lift hold squat --sessions 3",
+	Args:              cobra.ExactArgs(1),
+	RunE:              holdLift,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+var liftReleaseCmd = &cobra.Command{
+	Use:               "release <lift>",
+	Short:             "Release a held lift so it progresses again",
+	Args:              cobra.ExactArgs(1),
+	RunE:              releaseLift,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+func init() {
+	liftHoldCmd.Flags().Int("sessions", 1, "Number of sessions to hold the weight")
+}
+
+func holdLift(cmd *cobra.Command, args []string) error {
+	sessions, err := cmd.Flags().GetInt("sessions")
+	if err != nil {
+		return fmt.Errorf("failed to get sessions flag: %w", err)
+	}
+	if sessions <= 0 {
+		return fmt.Errorf("sessions must be positive, got: %d", sessions)
+	}
+
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if userProgram.Holds == nil {
+		userProgram.Holds = make(map[models.LiftName]int)
+	}
+	userProgram.Holds[lift] = sessions
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "Holding %s at %s lbs for %d session(s).\n",
+		display.FormatLiftName(lift), display.FormatWeight(userProgram.CurrentWeights[lift]), sessions)
+	return nil
+}
+
+func releaseLift(cmd *cobra.Command, args []string) error {
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if _, held := userProgram.Holds[lift]; !held {
+		return fmt.Errorf("%s is not being held", display.FormatLiftName(lift))
+	}
+	delete(userProgram.Holds, lift)
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "Released %s; it will progress normally again.\n", display.FormatLiftName(lift))
+	return nil
+}
+
+// loadLiftTarget loads the current user's active program and resolves a lift argument against it
+func loadLiftTarget(cmd *cobra.Command, input string) (*services.CommandContext, *models.User, *models.UserProgram, models.LiftName, error) {
+	lift, err := models.ParseLiftName(input)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return nil, nil, nil, "", fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	if _, exists := userProgram.CurrentWeights[lift]; !exists {
+		return nil, nil, nil, "", fmt.Errorf("%s is not part of your current program", display.FormatLiftName(lift))
+	}
+
+	return ctx, user, userProgram, lift, nil
+}
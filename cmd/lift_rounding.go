@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var liftRoundingCmd = &cobra.Command{
+	Use:   "rounding <lift> [step]",
+	Short: "Set the step a lift's weights are rounded down to",
+	Long: `Set the rounding step of a lift in your current program. Warmup sets,
+working sets, and the weight a lift progresses to are rounded down to a weight
+loadable with your plates: 2.5 lbs or 1.25 kg with standard plates. With
+microplates, a smaller step lets a lift such as the overhead press keep
+progressing in smaller jumps. Variants use their base lift's step unless they
+have their own.
+
+Without a step, show the lift's rounding step. Use --clear to go back to the
+standard step.`,
+	Example: `  greyskull lift rounding press 1.25
+  greyskull lift rounding press --clear`,
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              setRoundingStep,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+func init() {
+	liftRoundingCmd.Flags().Bool("clear", false, "Remove the rounding step and use the standard step")
+}
+
+func setRoundingStep(cmd *cobra.Command, args []string) error {
+	clearStep, err := cmd.Flags().GetBool("clear")
+	if err != nil {
+		return fmt.Errorf("failed to get clear flag: %w", err)
+	}
+	if clearStep && len(args) == 2 {
+		return fmt.Errorf("cannot set a step and --clear together")
+	}
+
+	var step float64
+	if len(args) == 2 {
+		step, err = strconv.ParseFloat(args[1], 64)
+		if err != nil || step <= 0 {
+			return fmt.Errorf("invalid rounding step %q: must be a positive number", args[1])
+		}
+	}
+
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	unit := userProgram.Unit.OrDefault()
+
+	switch {
+	case clearStep:
+		if _, exists := userProgram.RoundingSteps[lift]; !exists {
+			return fmt.Errorf("%s has no rounding step", display.FormatLiftName(lift))
+		}
+		delete(userProgram.RoundingSteps, lift)
+	case len(args) == 2:
+		if userProgram.RoundingSteps == nil {
+			userProgram.RoundingSteps = make(map[models.LiftName]float64)
+		}
+		userProgram.RoundingSteps[lift] = step
+	default:
+		printf(cmd, "%s rounding step: %s %s\n", display.FormatLiftName(lift), display.FormatWeight(userProgram.RoundingStepFor(lift)), unit)
+		return nil
+	}
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if clearStep {
+		printf(cmd, "Cleared the rounding step for %s; weights are rounded to %s %s.\n",
+			display.FormatLiftName(lift), display.FormatWeight(userProgram.RoundingStepFor(lift)), unit)
+	} else {
+		printf(cmd, "%s rounding step set to %s %s.\n", display.FormatLiftName(lift), display.FormatWeight(step), unit)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiftRounding(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "lift", "rounding", "press")
+	require.NoError(t, err)
+	assert.Equal(t, "Overhead Press rounding step: 2.5 lbs\n", output)
+
+	output, err = executePiped(t, "", "lift", "rounding", "press", "1.25")
+	require.NoError(t, err)
+	assert.Equal(t, "Overhead Press rounding step set to 1.25 lbs.\n", output)
+
+	user := loadTestUser(t)
+	assert.Equal(t, map[models.LiftName]float64{models.OverheadPress: 1.25}, user.Programs[user.CurrentProgram].RoundingSteps)
+
+	output, err = executePiped(t, "", "lift", "rounding", "press", "--clear")
+	require.NoError(t, err)
+	assert.Equal(t, "Cleared the rounding step for Overhead Press; weights are rounded to 2.5 lbs.\n", output)
+	user = loadTestUser(t)
+	assert.Empty(t, user.Programs[user.CurrentProgram].RoundingSteps)
+
+	_, err = executePiped(t, "", "lift", "rounding", "press", "--clear")
+	assert.EqualError(t, err, "Overhead Press has no rounding step")
+}
+
+func TestLiftRounding_Invalid(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "lift", "rounding", "press", "0")
+	assert.EqualError(t, err, `invalid rounding step "0": must be a positive number`)
+	_, err = executePiped(t, "", "lift", "rounding", "press", "1.25", "--clear")
+	assert.EqualError(t, err, "cannot set a step and --clear together")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiftHold_SetsHold(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := liftHoldCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("sessions", "3"))
+	t.Cleanup(func() { cmd.Flags().Set("sessions", "1") })
+
+	err := cmd.RunE(cmd, []string{"squat"})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Holding Squat at 135 lbs for 3 session(s).")
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, map[models.LiftName]int{models.Squat: 3}, user.Programs[user.CurrentProgram].Holds)
+}
+
+func TestLiftHold_InvalidInput(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	cmd := liftHoldCmd
+	cmd.SetOut(io.Discard)
+
+	err := cmd.RunE(cmd, []string{"curl"})
+	assert.ErrorContains(t, err, `unknown lift "curl"`)
+
+	require.NoError(t, cmd.Flags().Set("sessions", "0"))
+	t.Cleanup(func() { cmd.Flags().Set("sessions", "1") })
+	err = cmd.RunE(cmd, []string{"squat"})
+	assert.ErrorContains(t, err, "sessions must be positive")
+}
+
+func TestLiftRelease(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	cmd := liftReleaseCmd
+	cmd.SetOut(io.Discard)
+
+	err := cmd.RunE(cmd, []string{"squat"})
+	assert.ErrorContains(t, err, "Squat is not being held")
+
+	liftHoldCmd.SetOut(io.Discard)
+	require.NoError(t, liftHoldCmd.RunE(liftHoldCmd, []string{"squat"}))
+	require.NoError(t, cmd.RunE(cmd, []string{"squat"}))
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Empty(t, user.Programs[user.CurrentProgram].Holds)
+}
+
+func TestWorkoutLog_HeldLiftDoesNotProgress(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	liftHoldCmd.SetOut(io.Discard)
+	require.NoError(t, liftHoldCmd.Flags().Set("sessions", "2"))
+	t.Cleanup(func() { liftHoldCmd.Flags().Set("sessions", "1") })
+	require.NoError(t, liftHoldCmd.RunE(liftHoldCmd, []string{"squat"}))
+
+	// Day 1: OverheadPress, Squat
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("8\n8\n"))
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Squat: weight held for 1 more session")
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 97.5, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 1, userProgram.Holds[models.Squat])
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var liftTrainingMaxCmd = &cobra.Command{
+	Use:   "training-max <lift> [weight]",
+	Short: "Set the training max a lift's percentage-based sets are calculated from",
+	Long: `Set the training max of a lift in your current program. Percentage-based
+programs, such as 5/3/1, prescribe sets as a percentage of each lift's training
+max rather than its working weight. A lift without a training max uses its
+current weight.
+
+Without a weight, show the lift's training max. Use --clear to go back to
+using the current weight.`,
+	Example: `  greyskull lift training-max squat 285
+  greyskull lift training-max squat --clear`,
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              setTrainingMax,
+	ValidArgsFunction: completeFirstArg(completeLiftNames),
+}
+
+func init() {
+	liftTrainingMaxCmd.Flags().Bool("clear", false, "Remove the training max and use the current weight")
+}
+
+func setTrainingMax(cmd *cobra.Command, args []string) error {
+	clearMax, err := cmd.Flags().GetBool("clear")
+	if err != nil {
+		return fmt.Errorf("failed to get clear flag: %w", err)
+	}
+	if clearMax && len(args) == 2 {
+		return fmt.Errorf("cannot set a weight and --clear together")
+	}
+
+	var weight float64
+	if len(args) == 2 {
+		weight, err = strconv.ParseFloat(args[1], 64)
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("invalid training max %q: must be a positive number", args[1])
+		}
+	}
+
+	ctx, user, userProgram, lift, err := loadLiftTarget(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	unit := userProgram.Unit.OrDefault()
+
+	switch {
+	case clearMax:
+		if _, exists := userProgram.TrainingMaxes[lift]; !exists {
+			return fmt.Errorf("%s has no training max", display.FormatLiftName(lift))
+		}
+		delete(userProgram.TrainingMaxes, lift)
+	case len(args) == 2:
+		if userProgram.TrainingMaxes == nil {
+			userProgram.TrainingMaxes = make(map[models.LiftName]float64)
+		}
+		userProgram.TrainingMaxes[lift] = weight
+	default:
+		if _, exists := userProgram.TrainingMaxes[lift]; !exists {
+			printf(cmd, "%s has no training max; its current weight of %s %s is used.\n",
+				display.FormatLiftName(lift), display.FormatWeight(userProgram.CurrentWeights[lift]), unit)
+			return nil
+		}
+		printf(cmd, "%s training max: %s %s\n", display.FormatLiftName(lift), display.FormatWeight(userProgram.TrainingMaxFor(lift)), unit)
+		return nil
+	}
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if clearMax {
+		printf(cmd, "Cleared the training max for %s; its current weight of %s %s is used.\n",
+			display.FormatLiftName(lift), display.FormatWeight(userProgram.CurrentWeights[lift]), unit)
+	} else {
+		printf(cmd, "%s training max set to %s %s.\n", display.FormatLiftName(lift), display.FormatWeight(weight), unit)
+	}
+	return nil
+}
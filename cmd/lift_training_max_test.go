@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiftTrainingMax(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "lift", "training-max", "squat")
+	require.NoError(t, err)
+	assert.Equal(t, "Squat has no training max; its current weight of 135 lbs is used.\n", output)
+
+	output, err = executePiped(t, "", "lift", "training-max", "squat", "155")
+	require.NoError(t, err)
+	assert.Equal(t, "Squat training max set to 155 lbs.\n", output)
+
+	user := loadTestUser(t)
+	assert.Equal(t, map[models.LiftName]float64{models.Squat: 155}, user.Programs[user.CurrentProgram].TrainingMaxes)
+
+	output, err = executePiped(t, "", "lift", "training-max", "squat")
+	require.NoError(t, err)
+	assert.Equal(t, "Squat training max: 155 lbs\n", output)
+
+	output, err = executePiped(t, "", "lift", "training-max", "squat", "--clear")
+	require.NoError(t, err)
+	assert.Equal(t, "Cleared the training max for Squat; its current weight of 135 lbs is used.\n", output)
+	user = loadTestUser(t)
+	assert.Empty(t, user.Programs[user.CurrentProgram].TrainingMaxes)
+
+	_, err = executePiped(t, "", "lift", "training-max", "squat", "--clear")
+	assert.EqualError(t, err, "Squat has no training max")
+}
+
+func TestLiftTrainingMax_Invalid(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "lift", "training-max", "squat", "heavy")
+	assert.EqualError(t, err, `invalid training max "heavy": must be a positive number`)
+	_, err = executePiped(t, "", "lift", "training-max", "squat", "200", "--clear")
+	assert.EqualError(t, err, "cannot set a weight and --clear together")
+	_, err = executePiped(t, "", "lift", "training-max", "curl", "50")
+	assert.ErrorContains(t, err, "unknown lift")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+// offerMigration runs before every command. When the data directory is missing
+// but greyskull data exists elsewhere (an older location, a renamed directory,
+// or a backup archive), it asks which data to migrate instead of letting the
+// command silently start from an empty store.
+func offerMigration(cmd *cobra.Command, args []string) error {
+	needed, err := repository.NeedsMigration()
+	if err != nil || !needed {
+		return err
+	}
+
+	sources, err := repository.FindMigrationSources()
+	if err != nil {
+		return fmt.Errorf("failed to look for existing data: %w", err)
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	dataDir, err := repository.DataDir()
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "No greyskull data found at %s, but existing data was found:\n", dataDir)
+	for i, source := range sources {
+		kind := "directory"
+		if source.Archive {
+			kind = "backup archive"
+		}
+		printf(cmd, "  %d. %s (%s, %d user(s))\n", i+1, source.Path, kind, source.Users)
+	}
+
+	// Never guess on behalf of a script: piped input is meant for the command itself
+	notMigrated := fmt.Errorf("existing data was not migrated; run greyskull from a terminal to choose, or create %s to start fresh", dataDir)
+	if !isTerminal(cmd.InOrStdin()) {
+		return notMigrated
+	}
+
+	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+	for {
+		input, err := inputReader.ReadLine("Migrate which data? (enter number, or press Enter to start fresh): ")
+		if errors.Is(err, ErrNoInput) {
+			return notMigrated
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read migration choice: %w", err)
+		}
+
+		if input == "" {
+			if err := os.MkdirAll(dataDir, 0755); err != nil {
+				return fmt.Errorf("failed to create data directory: %w", err)
+			}
+			printf(cmd, "Starting with an empty data directory.\n\n")
+			return nil
+		}
+
+		num, err := strconv.Atoi(input)
+		if err != nil || num < 1 || num > len(sources) {
+			printf(cmd, "Invalid selection. Please enter a number between 1 and %d, or press Enter.\n", len(sources))
+			continue
+		}
+
+		source := sources[num-1]
+		if err := repository.Migrate(source); err != nil {
+			return fmt.Errorf("failed to migrate data: %w", err)
+		}
+		printf(cmd, "Migrated %d user(s) from %s to %s.\n\n", source.Users, source.Path, dataDir)
+		return nil
+	}
+}
+
+// isTerminal reports whether r is interactive. Readers other than files, such as
+// those used in tests, are treated as interactive.
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLegacyUser stores a user in the legacy ~/.greyskull data directory
+func writeLegacyUser(t *testing.T, env *testEnv, username string) string {
+	legacy := filepath.Join(env.tempDir, ".greyskull")
+	data, err := json.Marshal(&models.User{ID: uuid.New(), Username: username})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(legacy, "users"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(legacy, "users", strings.ToLower(username)+".json"), data, 0644))
+
+	// Point the data directory somewhere that doesn't exist yet
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(env.tempDir, "config"))
+	return legacy
+}
+
+func TestOfferMigration(t *testing.T) {
+	env := setupTestEnv(t)
+	legacy := writeLegacyUser(t, env, "Legacy")
+	dataDir := filepath.Join(env.tempDir, "config", "greyskull")
+
+	output, err := executePiped(t, "7\n1\n", "user", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No greyskull data found at "+dataDir+", but existing data was found:\n  1. "+legacy+" (directory, 1 user(s))\n")
+	assert.Contains(t, output, "Invalid selection. Please enter a number between 1 and 1, or press Enter.")
+	assert.Contains(t, output, "Migrated 1 user(s) from "+legacy+" to "+dataDir+".")
+	assert.Contains(t, output, "Users:\n    Legacy\n")
+
+	// Once migrated, there's nothing left to ask about
+	output, err = executePiped(t, "", "user", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "existing data was found")
+}
+
+func TestOfferMigration_StartFresh(t *testing.T) {
+	env := setupTestEnv(t)
+	writeLegacyUser(t, env, "Legacy")
+
+	output, err := executePiped(t, "\n", "user", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Starting with an empty data directory.")
+	assert.Contains(t, output, "No users found.")
+
+	output, err = executePiped(t, "", "user", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "existing data was found")
+}
+
+func TestOfferMigration_NoInput(t *testing.T) {
+	env := setupTestEnv(t)
+	writeLegacyUser(t, env, "Legacy")
+
+	_, err := executePiped(t, "", "user", "list")
+	assert.ErrorContains(t, err, "existing data was not migrated")
+
+	needed, err := repository.NeedsMigration()
+	require.NoError(t, err)
+	assert.True(t, needed)
+}
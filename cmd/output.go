@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+// outputKey is the context key of a running command's OutputFormatter
+type outputKey struct{}
+
+// verbosityKey is the context key of a running command's display.Verbosity
+type verbosityKey struct{}
+
+// setupOutput chooses the command's OutputFormatter from the --json flag, and
+// its verbosity from --quiet and --verbose. With --json, the command's text
+// output, including prompts, is sent to stderr so that stdout holds only the
+// JSON result.
+func setupOutput(cmd *cobra.Command) error {
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return fmt.Errorf("failed to get json flag: %w", err)
+	}
+	quiet, err := cmd.Flags().GetBool("quiet")
+	if err != nil {
+		return fmt.Errorf("failed to get quiet flag: %w", err)
+	}
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return fmt.Errorf("failed to get verbose flag: %w", err)
+	}
+
+	var output display.OutputFormatter = display.NewTextOutput(cmd.OutOrStdout())
+	if asJSON {
+		output = display.NewJSONOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		cmd.SetOut(output.Text())
+	}
+
+	verbosity := display.Normal
+	switch {
+	case quiet:
+		verbosity = display.Quiet
+	case verbose:
+		verbosity = display.Verbose
+	}
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx := context.WithValue(parent, outputKey{}, output)
+	cmd.SetContext(context.WithValue(ctx, verbosityKey{}, verbosity))
+
+	// Report every file written when verbose
+	var writeLog io.Writer
+	if verbosity == display.Verbose {
+		writeLog = output.Text()
+	}
+	repository.SetWriteLog(writeLog)
+	return nil
+}
+
+// outputFor returns the command's OutputFormatter. Commands run without
+// setupOutput, as in tests, get text output.
+func outputFor(cmd *cobra.Command) display.OutputFormatter {
+	if ctx := cmd.Context(); ctx != nil {
+		if output, ok := ctx.Value(outputKey{}).(display.OutputFormatter); ok {
+			return output
+		}
+	}
+	return display.NewTextOutput(cmd.OutOrStdout())
+}
+
+// textAt returns the writer for the command's text shown at a verbosity level,
+// which discards the text when the command runs with less detail
+func textAt(cmd *cobra.Command, level display.Verbosity) io.Writer {
+	verbosity := display.Normal
+	if ctx := cmd.Context(); ctx != nil {
+		if v, ok := ctx.Value(verbosityKey{}).(display.Verbosity); ok {
+			verbosity = v
+		}
+	}
+	return display.NewLeveledWriter(cmd.OutOrStdout(), verbosity).At(level)
+}
+
+// flushOutput writes the command's held-back output once it has finished
+func flushOutput(cmd *cobra.Command, args []string) error {
+	return outputFor(cmd).Flush()
+}
+
+// nonNil returns an empty slice in place of nil, so JSON results show an empty
+// list rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// printf prints a message as cmd.Printf does, translated into the user's locale
+func printf(cmd *cobra.Command, format string, a ...any) {
+	cmd.Print(i18n.Sprintf(format, a...))
+}
+
+// fprintf writes a message to w, translated into the user's locale
+func fprintf(w io.Writer, format string, a ...any) {
+	fmt.Fprint(w, i18n.Sprintf(format, a...))
+}
+
+// weightFormat returns how weights recorded in unit are shown, with both units
+// when the config turns dual units on
+func weightFormat(config *models.Config, unit models.WeightUnit) display.WeightFormat {
+	return display.WeightFormat{Unit: unit, Dual: config.DualUnits}
+}
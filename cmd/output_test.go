@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeJSON runs greyskull with --json, returning stdout and stderr separately
+func executeJSON(t *testing.T, input string, args ...string) (string, string, error) {
+	resetCommands(rootCmd)
+	t.Cleanup(func() { resetCommands(rootCmd) })
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs(append(args, "--json"))
+
+	_, err := rootCmd.ExecuteC()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestJSON_UserList(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	stdout, stderr, err := executeJSON(t, "", "user", "list")
+	require.NoError(t, err)
+
+	var result struct {
+		Users   []string `json:"users"`
+		Current string   `json:"current"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, []string{"TestUser"}, result.Users)
+	assert.Equal(t, "TestUser", result.Current)
+	assert.Contains(t, stderr, "* Current user: TestUser", "text output moves to stderr")
+}
+
+func TestJSON_WorkoutNextAndLog(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	stdout, _, err := executeJSON(t, "", "workout", "next")
+	require.NoError(t, err)
+	var next struct {
+		Day       int `json:"day"`
+		Exercises []struct {
+			LiftName string `json:"lift_name"`
+		} `json:"exercises"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &next))
+	assert.Equal(t, 1, next.Day)
+	require.Len(t, next.Exercises, 2)
+	assert.Equal(t, "OverheadPress", next.Exercises[0].LiftName)
+
+	stdout, stderr, err := executeJSON(t, "7\n8\n", "workout", "log")
+	require.NoError(t, err)
+	var logged struct {
+		Weights      map[string]float64 `json:"weights"`
+		NextDay      int                `json:"next_day"`
+		Achievements []any              `json:"achievements"`
+		DryRun       bool               `json:"dry_run"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &logged), stdout)
+	assert.Equal(t, 2, logged.NextDay)
+	assert.Equal(t, 97.5, logged.Weights["OverheadPress"])
+	assert.NotNil(t, logged.Achievements)
+	assert.False(t, logged.DryRun)
+	assert.Contains(t, stderr, "Workout logged successfully!")
+}
+
+func TestJSON_Stats(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	stdout, _, err := executeJSON(t, "", "stats")
+	require.NoError(t, err)
+
+	var result struct {
+		Summary struct {
+			Workouts int `json:"workouts"`
+			Lifts    map[string]struct {
+				Sessions int `json:"sessions"`
+			} `json:"lifts"`
+		} `json:"summary"`
+		Goals []any `json:"goals"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, 2, result.Summary.Workouts)
+	assert.Equal(t, 1, result.Summary.Lifts["Squat"].Sessions)
+	assert.NotNil(t, result.Goals)
+}
+
+func TestJSON_Status(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	stdout, _, err := executeJSON(t, "", "status")
+	require.NoError(t, err)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "TestUser", result["username"])
+	assert.Equal(t, float64(1), result["day"])
+}
+
+func TestJSON_TextFallback(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	stdout, _, err := executeJSON(t, "", "config", "get", "unit")
+	require.NoError(t, err)
+
+	var result map[string]string
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "lbs\n", result["output"])
+}
+
+func TestJSON_LaterRunsPrintText(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, _, err := executeJSON(t, "", "user", "list")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "user", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "Users:\n  * TestUser\n\n* Current user: TestUser\n", output)
+}
+
+func TestWorkoutLog_Quiet(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "7\n8\n", "workout", "log", "--quiet")
+	require.NoError(t, err)
+
+	assert.NotContains(t, output, "Day 1 Workout:", "the workout isn't displayed again")
+	assert.Contains(t, output, "How many reps did you complete for Overhead Press AMRAP set (5+)?")
+	assert.Contains(t, output, "Overhead Press: 95 → 97.5 lbs (+2.5)")
+	assert.Contains(t, output, "Workout logged successfully!")
+	assert.NotContains(t, output, "Wrote ")
+}
+
+func TestWorkoutLog_Verbose(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "7\n4\n", "workout", "log", "--verbose")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Day 1 Workout:")
+	assert.Contains(t, output, "Progression:\n"+
+		"  Overhead Press: 7 reps at 95 lbs (linear, increment 2.5): 97.5 lbs\n"+
+		"  Squat: 4 reps at 135 lbs (linear, increment 5): 121.5 rounded down to 120 lbs\n")
+	assert.Regexp(t, `Wrote \S+/users/testuser\.json\n`, output)
+
+	_, err = executePiped(t, "", "workout", "next", "--quiet", "--verbose")
+	assert.Error(t, err, "quiet and verbose can't be combined")
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetCommands restores every command's flags to their defaults and clears
+// input and output set by other tests, so commands inherit the root's streams
+func resetCommands(cmd *cobra.Command) {
+	cmd.SetIn(nil)
+	cmd.SetOut(nil)
+	cmd.SetErr(nil)
+	cmd.Flags().VisitAll(resetFlag)
+	for _, child := range cmd.Commands() {
+		resetCommands(child)
+	}
+}
+
+// resetFlag restores a flag's default value. Setting a slice flag appends to
+// it, so slice flags are cleared instead.
+func resetFlag(f *pflag.Flag) {
+	if slice, ok := f.Value.(pflag.SliceValue); ok {
+		slice.Replace(nil)
+	} else {
+		f.Value.Set(f.DefValue)
+	}
+	f.Changed = false
+}
+
+// executePiped runs the CLI with args and piped input, as a script would
+func executePiped(t *testing.T, input string, args ...string) (string, error) {
+	resetCommands(rootCmd)
+	t.Cleanup(func() { resetCommands(rootCmd) })
+
+	var buf bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs(args)
+
+	_, err := rootCmd.ExecuteC()
+	return buf.String(), err
+}
+
+func TestPipedWorkflow(t *testing.T) {
+	setupTestEnv(t)
+
+	output, err := executePiped(t, "Piper\n", "user", "create")
+	require.NoError(t, err)
+	assert.Equal(t, "Enter username: User \"Piper\" created successfully and set as current user.\n", output)
+
+	output, err = executePiped(t, "1\n135\n185\n125\n95\n", "program", "start")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Select a program (enter number): Enter starting weight for Squat (lbs): ")
+	assert.Contains(t, output, "Program started!")
+
+	output, err = executePiped(t, "8\n6\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workout logged successfully!")
+	assert.Contains(t, output, "Next workout: Day 2")
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user, err := repo.Get(t.Context(), "Piper")
+	require.NoError(t, err)
+	require.NoError(t, user.LoadHistory())
+	require.Len(t, user.WorkoutHistory, 1)
+}
+
+func TestPipedInput_EndsEarly(t *testing.T) {
+	setupTestEnv(t)
+
+	_, err := executePiped(t, "", "user", "create")
+	assert.ErrorIs(t, err, ErrNoInput)
+
+	_, err = executePiped(t, "Piper\n", "user", "create")
+	require.NoError(t, err)
+
+	// Invalid selections are re-prompted, but running out of input ends the command
+	output, err := executePiped(t, "9\nabc\n", "program", "start")
+	assert.ErrorIs(t, err, ErrNoInput)
+	assert.EqualError(t, err, "failed to read program selection: no input available")
+	assert.Contains(t, output, "Invalid selection. Please enter a number between 1 and")
+	assert.Contains(t, output, "Invalid input: invalid integer: abc. Please try again.")
+
+	_, err = executePiped(t, "1\n135\n", "program", "start")
+	assert.ErrorIs(t, err, ErrNoInput)
+
+	// Lifts are named as they're shown, not by their internal keys
+	_, err = executePiped(t, "1\n135\n185\n125\n95\n", "program", "start")
+	require.NoError(t, err)
+	_, err = executePiped(t, "", "workout", "log")
+	assert.EqualError(t, err, "failed to collect AMRAP reps: failed to read AMRAP reps for Overhead Press: no input available")
+}
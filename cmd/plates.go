@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var platesCmd = &cobra.Command{
+	Use:   "plates",
+	Short: "Manage the plates you have to load the bar",
+	Long: `Manage your plate inventory: the plates you have to load the bar, as
+<weight>x<count> pairs. Workouts and program previews work out the plates to
+load each side of the bar from it, and warn about any working weight it can't
+make up exactly, with the nearest weights it can.
+
+Without an inventory, as many standard plates as needed are assumed and no
+weights are warned about. The inventory is the "plates" setting of
+'greyskull config'.`,
+}
+
+var platesSetCmd = &cobra.Command{
+	Use:   "set <weight>x<count>... [unit]",
+	Short: "Set the plates you have",
+	Long: `Set the plates you have as <weight>x<count> pairs, counting every plate rather
+than every pair. End with "kg" for kilogram plates; plates are in your unit
+otherwise.`,
+	Example: `  greyskull plates set 45x4 25x2 10x2 5x2 2.5x2
+  greyskull plates set 20x6 10x2 5x2 2.5x2 1.25x2 kg`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: setPlates,
+}
+
+var platesShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the plates you have",
+	Args:  cobra.NoArgs,
+	RunE:  showPlates,
+}
+
+var platesClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Go back to assuming as many standard plates as needed",
+	Args:  cobra.NoArgs,
+	RunE:  clearPlates,
+}
+
+func init() {
+	rootCmd.AddCommand(platesCmd)
+	platesCmd.AddCommand(platesSetCmd)
+	platesCmd.AddCommand(platesShowCmd)
+	platesCmd.AddCommand(platesClearCmd)
+}
+
+func setPlates(cmd *cobra.Command, args []string) error {
+	setting, err := setPlatesSetting(cmd, args...)
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "Plates set to %s.\n", setting.Value)
+	return nil
+}
+
+func showPlates(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	setting, err := ctx.Config.Get(user, "plates")
+	if err != nil {
+		return err
+	}
+	if err := reportPlates(cmd, ctx, user); err != nil {
+		return err
+	}
+	if setting.Default {
+		printf(cmd, "No plates set; as many standard plates as needed are assumed.\n")
+		return nil
+	}
+	printf(cmd, "Plates: %s\n", setting.Value)
+	return nil
+}
+
+func clearPlates(cmd *cobra.Command, args []string) error {
+	if _, err := setPlatesSetting(cmd, "default"); err != nil {
+		return err
+	}
+
+	printf(cmd, "Plates cleared; as many standard plates as needed are assumed.\n")
+	return nil
+}
+
+// setPlatesSetting saves the current user's "plates" setting
+func setPlatesSetting(cmd *cobra.Command, values ...string) (services.Setting, error) {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return services.Setting{}, fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return services.Setting{}, err
+	}
+	setting, err := ctx.Config.Set(contextFor(cmd), user, "plates", values...)
+	if err != nil {
+		return services.Setting{}, err
+	}
+	return setting, reportPlates(cmd, ctx, user)
+}
+
+// platesResult is the --json result of the plates commands: the plates
+// available for each side of the bar, heaviest first. Without an inventory,
+// Assumed is set and PerSide is empty.
+type platesResult struct {
+	Unit    models.WeightUnit `json:"unit,omitempty"`
+	PerSide []plateResult     `json:"per_side"`
+	Assumed bool              `json:"assumed"`
+}
+
+// plateResult is the number of plates of one weight for each side of the bar;
+// an odd plate out can't be loaded and isn't counted
+type plateResult struct {
+	Weight float64 `json:"weight"`
+	Count  int     `json:"count"`
+}
+
+// reportPlates sets the user's plate inventory as the command's result
+func reportPlates(cmd *cobra.Command, ctx *services.CommandContext, user *models.User) error {
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	result := platesResult{PerSide: []plateResult{}, Assumed: len(config.Plates) == 0}
+	if !result.Assumed {
+		result.Unit = config.PlateUnit.OrDefault()
+	}
+	for _, plate := range config.Plates {
+		if plate.Count >= 2 {
+			result.PerSide = append(result.PerSide, plateResult{Weight: plate.Weight, Count: plate.Count / 2})
+		}
+	}
+	outputFor(cmd).Result(result)
+	return nil
+}
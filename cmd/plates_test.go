@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlates_SetShowClear(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "plates", "show")
+	require.NoError(t, err)
+	assert.Equal(t, "No plates set; as many standard plates as needed are assumed.\n", output)
+
+	output, err = executePiped(t, "", "plates", "set", "45x4", "25x2", "10x2", "5x2", "2.5x2")
+	require.NoError(t, err)
+	assert.Equal(t, "Plates set to 45x4,25x2,10x2,5x2,2.5x2 lbs.\n", output)
+
+	output, err = executePiped(t, "", "plates", "show")
+	require.NoError(t, err)
+	assert.Equal(t, "Plates: 45x4,25x2,10x2,5x2,2.5x2 lbs\n", output)
+
+	output, err = executePiped(t, "", "plates", "set", "20x6", "1.25x2", "kg")
+	require.NoError(t, err)
+	assert.Equal(t, "Plates set to 20x6,1.25x2 kg.\n", output)
+
+	_, err = executePiped(t, "", "plates", "set", "45")
+	assert.ErrorContains(t, err, `invalid plates "45"`)
+
+	output, err = executePiped(t, "", "plates", "clear")
+	require.NoError(t, err)
+	assert.Equal(t, "Plates cleared; as many standard plates as needed are assumed.\n", output)
+	output, err = executePiped(t, "", "config", "get", "plates")
+	require.NoError(t, err)
+	assert.Equal(t, "not set\n", output)
+}
+
+func TestPlates_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var result platesResult
+	stdout, _, err := executeJSON(t, "", "plates", "show")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, platesResult{PerSide: []plateResult{}, Assumed: true}, result)
+
+	// Counts are per side, leaving out an odd plate
+	stdout, _, err = executeJSON(t, "", "plates", "set", "20x6", "5x3", "1.25x1", "kg")
+	require.NoError(t, err)
+	result = platesResult{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, platesResult{
+		Unit:    models.Kilograms,
+		PerSide: []plateResult{{Weight: 20, Count: 3}, {Weight: 5, Count: 1}},
+	}, result)
+
+	stdout, _, err = executeJSON(t, "", "plates", "show")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"unit": "kg", "per_side": [{"weight": 20, "count": 3}, {"weight": 5, "count": 1}], "assumed": false}`, stdout)
+}
+
+func TestPlates_WorkoutWarnings(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Plate Warnings", "no inventory, no warnings")
+
+	_, err = executePiped(t, "", "plates", "set", "45x2", "10x2", "5x2", "2.5x2")
+	require.NoError(t, err)
+
+	output, err = executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Plate Warnings:\n"+
+		"  Overhead Press 95 lbs can't be loaded with your plates; nearest loadable: 80 lbs\n")
+	assert.NotContains(t, output, "  Squat 135 lbs can't be loaded")
+}
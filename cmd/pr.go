@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/spf13/cobra"
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Show your personal records",
+	Long: `Show personal records from your workout history for each lift: the heaviest
+AMRAP set, the best estimated one-rep max (Epley formula), and the most reps
+completed at each weight. Bar variants have their own records.
+
+With an active program, only its lifts since the program started are included.
+Use --all-time and --all-lifts to include the rest of your history, and --program
+to show records for another active program.`,
+	Example: "  greyskull pr --lift squat",
+	Args:    cobra.NoArgs,
+	RunE:    showRecords,
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+	prCmd.Flags().String("lift", "", "Only show records for this lift (squat, deadlift, bench, ohp)")
+	prCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+	addScopeFlags(prCmd.Flags())
+}
+
+func showRecords(cmd *cobra.Command, args []string) error {
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+
+	var lift models.LiftName
+	if liftInput != "" {
+		lift, err = models.ParseLiftName(liftInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+
+	all := records.Compute(scope.History)
+	if lift != "" {
+		for key := range all {
+			if base, _ := key.SplitVariant(); base != lift {
+				delete(all, key)
+			}
+		}
+	}
+
+	config, err := ctx.Config.Load(scope.User.Username)
+	if err != nil {
+		return err
+	}
+	unit := models.Pounds
+	if scope.UserProgram != nil {
+		unit = scope.UserProgram.Unit
+	}
+
+	formatter := display.NewRecordsFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, unit))
+	formatter.DisplayRecords(all)
+	outputFor(cmd).Result(all)
+	if scope.Note != "" {
+		printf(cmd, "\n%s\n", scope.Note)
+	}
+	return nil
+}
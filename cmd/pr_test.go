@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPR(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	var buf bytes.Buffer
+	cmd := prCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Overhead Press:\n  Heaviest AMRAP: 95 lbs x 7 (2024-03-04)")
+	assert.Contains(t, output, "Squat:\n  Heaviest AMRAP: 135 lbs x 8 (2024-03-04)\n  Best e1RM: 171 lbs from 135 lbs x 8")
+	assert.Contains(t, output, "Deadlift:")
+}
+
+func TestPR_LiftFilter(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	var buf bytes.Buffer
+	cmd := prCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("lift", "Squat"))
+	t.Cleanup(func() { cmd.Flags().Set("lift", "") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Squat:")
+	assert.NotContains(t, output, "Deadlift:")
+	assert.NotContains(t, output, "Overhead Press:")
+}
+
+func TestPR_NoHistory(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := prCmd
+	cmd.SetOut(&buf)
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "No personal records yet")
+}
+
+func TestWorkoutLog_NewPRBanner(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutLogQuickCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{"ohp 95x5,5,6; squat 135x5,5,12"})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "New PR! Squat: estimated 1RM 189 lbs (previous 171 lbs)\n")
+	assert.Contains(t, output, "New PR! Squat: 12 reps at 135 lbs (previous 8)\n")
+	assert.NotContains(t, output, "New PR! Overhead Press")
+}
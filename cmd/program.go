@@ -15,4 +15,16 @@ func init() {
 	
 	// Child commands will be added here
 	programCmd.AddCommand(programStartCmd)
+	programCmd.AddCommand(programImportCmd)
+	programCmd.AddCommand(programExportCmd)
+	programCmd.AddCommand(programListCmd)
+	programCmd.AddCommand(programShowCmd)
+	programCmd.AddCommand(programPreviewCmd)
+	programCmd.AddCommand(programSwitchCmd)
+	programCmd.AddCommand(programActivateCmd)
+	programCmd.AddCommand(programDeactivateCmd)
+	programCmd.AddCommand(programPauseCmd)
+	programCmd.AddCommand(programResumeCmd)
+	programCmd.AddCommand(programResetWeightsCmd)
+	programCmd.AddCommand(programUpgradeCmd)
 }
\ No newline at end of file
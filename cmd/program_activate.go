@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var programActivateCmd = &cobra.Command{
+	Use:   "activate <id|index>",
+	Short: "Train another of your programs alongside the current one",
+	Long: `Train a program you've started alongside your current program, such as a
+conditioning template next to Greyskull. Both programs keep their own weights
+and days. The program can be given by its index or ID as shown by
+'greyskull program list --mine'.
+
+Use --program with 'workout next' and 'workout log' to pick which active
+program a session is for; without it they use your current program.`,
+	Example: `  greyskull program activate 2
+  greyskull workout log --program 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: activateProgram,
+}
+
+var programDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <id|index>",
+	Short: "Stop training a program alongside the current one",
+	Long: `Stop training a program alongside your current program. Its weights and day
+are kept, so it can be activated again or switched to later. Your current
+program can't be deactivated; use 'greyskull program switch' to change it.`,
+	Example: "  greyskull program deactivate 2",
+	Args:    cobra.ExactArgs(1),
+	RunE:    deactivateProgram,
+}
+
+func activateProgram(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	if user.CurrentProgram == uuid.Nil {
+		return services.ErrNoActiveProgram
+	}
+
+	userProgram, err := resolveUserProgram(user, args[0])
+	if err != nil {
+		return err
+	}
+	prog, _ := ctx.UserService.ProgramFor(contextFor(cmd), userProgram)
+	name := display.FormatProgramName(userProgram, prog)
+	if user.IsActive(userProgram.ID) {
+		printf(cmd, "%s is already active.\n", name)
+		return nil
+	}
+
+	user.ActivateProgram(userProgram.ID)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "%s is now active alongside your current program. Next workout: Day %d\n", name, userProgram.CurrentDay)
+	printf(cmd, "Use --program %s with 'workout next' and 'workout log' to train it.\n", args[0])
+	return nil
+}
+
+func deactivateProgram(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	userProgram, err := resolveUserProgram(user, args[0])
+	if err != nil {
+		return err
+	}
+	prog, _ := ctx.UserService.ProgramFor(contextFor(cmd), userProgram)
+	name := display.FormatProgramName(userProgram, prog)
+	if userProgram.ID == user.CurrentProgram {
+		return services.NewError("current_program", "run 'greyskull program switch' to change your current program",
+			"%s is your current program", name)
+	}
+	if !user.IsActive(userProgram.ID) {
+		printf(cmd, "%s isn't active.\n", name)
+		return nil
+	}
+
+	user.DeactivateProgram(userProgram.ID)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	printf(cmd, "%s is no longer active. Its weights and day are kept.\n", name)
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startAlongside starts the 3-day template alongside the test user's current program
+func startAlongside(t *testing.T) {
+	output, err := executePiped(t, "2\n140\n190\n130\n100\nn\n", "program", "start", "--alongside")
+	require.NoError(t, err)
+	require.Contains(t, output, "Program started! Greyskull LP (3-Day A/B)")
+}
+
+func TestProgramStart_Alongside(t *testing.T) {
+	env := setupTestEnv(t)
+	current := createTestUserWithProgram(t, env).CurrentProgram
+	startAlongside(t)
+
+	user := loadTestUser(t)
+	assert.Equal(t, current, user.CurrentProgram)
+	require.Len(t, user.ActivePrograms, 1)
+	assert.Equal(t, 140.0, user.Programs[user.ActivePrograms[0]].CurrentWeights["Squat"])
+
+	output, err := executePiped(t, "", "program", "list", "--mine")
+	require.NoError(t, err)
+	assert.Contains(t, output, "* 1. OG Greyskull LP\n")
+	assert.Contains(t, output, "+ 2. Greyskull LP (3-Day A/B)\n")
+
+	output, err = executePiped(t, "", "user", "status")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TestUser: OG Greyskull LP, Day 1 of 6\n")
+	assert.Contains(t, output, "Also active: Greyskull LP (3-Day A/B), Day 1 of 2\n")
+}
+
+func TestWorkout_ProgramFlag(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	startAlongside(t)
+
+	output, err := executePiped(t, "", "workout", "next", "--program", "2")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Set 1: 5 reps @ 100 lbs")
+
+	output, err = executePiped(t, "n\n8\n6\n", "workout", "log", "--program", "greyskull lp (3-day a/b)")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Squat: 140 → 145 lbs (+5.0)")
+	assert.Contains(t, output, "Next workout: Day 2")
+
+	user := loadTestUser(t)
+	alongside := user.Programs[user.ActivePrograms[0]]
+	assert.Equal(t, 2, alongside.CurrentDay)
+	assert.Equal(t, 1, user.Programs[user.CurrentProgram].CurrentDay, "the current program is untouched")
+	require.Len(t, user.WorkoutHistory, 1)
+	assert.Equal(t, alongside.ID, user.WorkoutHistory[0].UserProgramID)
+
+	output, err = executePiped(t, "", "status")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Also active: Greyskull LP (3-Day A/B), Day 2 of 2\n")
+
+	output, err = executePiped(t, "", "workout", "next", "--program", "2", "--ahead", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Day 1 Workout:")
+	assert.Contains(t, output, "Set 1: 5 reps @ 102.5 lbs")
+}
+
+func TestProgramActivate(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	older := addOlderThreeDayProgram(t, user)
+
+	_, err := executePiped(t, "", "workout", "next", "--program", "1")
+	assert.EqualError(t, err, `program "1" is not active`)
+
+	output, err := executePiped(t, "", "program", "activate", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Greyskull LP (3-Day A/B) is now active alongside your current program. Next workout: Day 2\n"+
+		"Use --program 1 with 'workout next' and 'workout log' to train it.\n", output)
+	assert.Equal(t, []uuid.UUID{older.ID}, loadTestUser(t).ActivePrograms)
+
+	output, err = executePiped(t, "", "program", "activate", older.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Greyskull LP (3-Day A/B) is already active.\n", output)
+
+	_, err = executePiped(t, "", "program", "deactivate", "2")
+	assert.EqualError(t, err, "OG Greyskull LP is your current program")
+
+	output, err = executePiped(t, "", "program", "deactivate", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Greyskull LP (3-Day A/B) is no longer active. Its weights and day are kept.\n", output)
+	assert.Empty(t, loadTestUser(t).ActivePrograms)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var programExportCmd = &cobra.Command{
+	Use:   "export <name|id>",
+	Short: "Export a program as a JSON or YAML template",
+	Long: `Export a built-in or custom program in the schema read by 'greyskull program
+import', as a starting point for a variant of your own. The program can be
+given by name (case-insensitive) or by ID.
+
+The template is written as JSON to stdout unless --out is given; files ending
+in .yaml or .yml are written as YAML. The exported template has no ID, so
+importing it creates a new program. Program names must be unique, so give the
+copy a new name with --name or by editing the file before importing it.`,
+	Example: `  greyskull program export "OG Greyskull LP" --name "My Greyskull" --out my-greyskull.json
+  greyskull program import my-greyskull.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportProgram,
+}
+
+func init() {
+	programExportCmd.Flags().StringP("out", "o", "", "File to write the template to (default stdout)")
+	programExportCmd.Flags().String("name", "", "Name for the exported copy")
+}
+
+func exportProgram(cmd *cobra.Command, args []string) error {
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("failed to get name flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	prog, err := ctx.Programs.Find(contextFor(cmd), args[0])
+	if err != nil {
+		if errors.Is(err, program.ErrProgramNotFound) {
+			return services.ProgramNotFound(args[0])
+		}
+		return err
+	}
+
+	// Export a copy without the ID so importing it creates a new program
+	exported := *prog
+	exported.ID = uuid.Nil
+	if name = strings.TrimSpace(name); name != "" {
+		exported.Name = name
+	}
+
+	data, err := repository.EncodeProgram(&exported, repository.IsYAMLFile(outPath))
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	printf(cmd, "Exported program %q to %s\n", exported.Name, outPath)
+	return nil
+}
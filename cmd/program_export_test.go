@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramExport_Stdout(t *testing.T) {
+	setupTestEnv(t)
+
+	output, err := executePiped(t, "", "program", "export", "og greyskull lp")
+	require.NoError(t, err)
+
+	var exported map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &exported))
+	assert.Equal(t, "OG Greyskull LP", exported["name"])
+	assert.NotContains(t, exported, "id")
+	assert.Contains(t, exported, "progression_rules")
+}
+
+func TestProgramExport_ImportsAsNewProgram(t *testing.T) {
+	setupTestEnv(t)
+
+	for _, name := range []string{"copy.json", "copy.yaml"} {
+		path := filepath.Join(t.TempDir(), name)
+		variant := "My Greyskull " + filepath.Ext(name)
+
+		output, err := executePiped(t, "", "program", "export", "OG Greyskull LP", "--name", variant, "--out", path)
+		require.NoError(t, err)
+		assert.Equal(t, "Exported program \""+variant+"\" to "+path+"\n", output)
+
+		output, err = executePiped(t, "", "program", "import", path)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Imported program \""+variant+"\" (6 days")
+	}
+
+	output, err := executePiped(t, "", "program", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "My Greyskull .json")
+	assert.Contains(t, output, "My Greyskull .yaml")
+}
+
+func TestProgramExport_Errors(t *testing.T) {
+	setupTestEnv(t)
+
+	_, err := executePiped(t, "", "program", "export", "Nonexistent")
+	assert.ErrorContains(t, err, "Nonexistent")
+
+	_, err = executePiped(t, "", "program", "export", "OG Greyskull LP", "--out", filepath.Join(t.TempDir(), "missing", "copy.json"))
+	assert.ErrorContains(t, err, "failed to write output file")
+}
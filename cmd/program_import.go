@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/spf13/cobra"
+)
+
+var programImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a custom program from a JSON or YAML file",
+	Long: `Import a custom program template from a JSON or YAML file. The file uses the
+same schema as the built-in programs (name, version, workouts, progression_rules).
+If the file has no id, one is generated. 'greyskull program export' writes any
+existing program in this schema as a starting point.
+
+Accessory lifts such as chin-ups or curls can be added to any day with
+"optional": true. Optional lifts list only working sets with reps and no weight
+percentage; they need no starting weight or progression rule, and workout log
+asks whether they were performed.
+
+Bodyweight movements such as chin-ups or dips are marked "bodyweight": true and
+track added weight instead: 0 for bodyweight alone, or negative for assistance.
+They progress by their increase rule, or by reps when listed in
+progression_rules.rep_increases, e.g. {"Chinup": 1} adds a rep per session.
+
+Lifts that shouldn't progress, such as back-off or technique work, are marked
+"fixed_weight": true. They keep their starting weight until it is changed by
+hand, and need no AMRAP set or increase rule.
+
+Barbell lifts progress linearly by default: the increment after an AMRAP set
+of 5 or more reps, twice that at double_threshold reps, and a deload below 5.
+Set progression_rules.strategy to change that, with optional
+progression_rules.parameters:
+  linear              deload_below (5)
+  double_progression  rep_max (8): add the increment once the AMRAP set
+                      reaches rep_max reps; never deload
+  percentage          increase (0.025), deload_below (5): add a fraction of
+                      the current weight, at least the increment
+  rep_ladder          deload_below (5), rung (2), max_increments (3): add one
+                      increment at deload_below reps and another for every
+                      rung reps beyond, up to max_increments
+
+Warmups ramp through each lift's warmup_sets percentages by default. A
+top-level "warmup_scheme" changes that for the whole program:
+{"strategy": "plate_jump"} starts with the empty bar and adds a pair of the
+heaviest plates per set, and {"strategy": "custom", "steps": [...]} lists each
+warmup set as {"reps": 5} for the empty bar, {"reps": 5, "plates": 1} for
+plates per side, or {"reps": 3, "percentage": 0.8}. Lifts without warmup_sets
+are never warmed up. Users can override the strategy with
+'greyskull config set warmup_strategy'.
+
+Lifts added with 'greyskull lift define' can be used by name and need no
+increase rule when they were defined with a default increment.
+
+Use --describe to write a description, shown by 'greyskull program show', after
+the file is read. Descriptions can span several lines; finish with a blank line
+or a line containing only ".". A description in the file's "description" field
+is replaced.
+
+The program is validated before import; validation errors name the offending
+field, e.g. "workouts[1].lifts[0].working_sets[2].reps: must be positive".
+Imported programs are available to 'greyskull program start'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importProgram,
+}
+
+func init() {
+	programImportCmd.Flags().Bool("describe", false, "Write a description for the program")
+}
+
+func importProgram(cmd *cobra.Command, args []string) error {
+	filename := args[0]
+	describe, err := cmd.Flags().GetBool("describe")
+	if err != nil {
+		return fmt.Errorf("failed to get describe flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+	if ctx.ProgramRepo == nil {
+		return fmt.Errorf("custom programs are not supported by the current storage backend")
+	}
+
+	prog, err := repository.ParseProgramFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if describe {
+		inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+		prog.Description, err = inputReader.ReadMultiLine("Description (finish with a blank line or '.'):\n")
+		if err != nil && !errors.Is(err, ErrNoInput) {
+			return fmt.Errorf("failed to read description: %w", err)
+		}
+	}
+
+	// Generate an ID for hand-written programs
+	if prog.ID == uuid.Nil {
+		prog.ID = uuid.Must(uuid.NewV7())
+	}
+
+	if err := prog.Validate(); err != nil {
+		return fmt.Errorf("invalid program in %s: %w", filename, err)
+	}
+
+	// Reject programs that would be ambiguous in the program list
+	for _, existing := range ctx.Programs.List() {
+		if existing.ID == prog.ID {
+			return fmt.Errorf("a program with ID %s already exists (%s)", prog.ID, existing.Name)
+		}
+		if strings.EqualFold(existing.Name, prog.Name) {
+			return fmt.Errorf("a program named %q already exists", existing.Name)
+		}
+	}
+
+	if err := ctx.ProgramRepo.Save(prog); err != nil {
+		if errors.Is(err, repository.ErrProgramExists) {
+			return fmt.Errorf("a program with ID %s already exists", prog.ID)
+		}
+		return fmt.Errorf("failed to save program: %w", err)
+	}
+
+	printf(cmd, "Imported program %q (%d days, ID %s).\n", prog.Name, len(prog.Workouts), prog.ID)
+	return nil
+}
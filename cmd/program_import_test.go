@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importTestProgram = `{
+  "name": "My Variant",
+  "version": "0.1.0",
+  "workouts": [
+    {
+      "day": 1,
+      "lifts": [
+        {
+          "lift_name": "Squat",
+          "warmup_sets": [],
+          "working_sets": [
+            {"reps": 5, "weight_percentage": 1.0, "type": "WorkingSet"},
+            {"reps": 5, "weight_percentage": 1.0, "type": "AMRAPSet"}
+          ]
+        }
+      ]
+    }
+  ],
+  "progression_rules": {
+    "increase_rules": {"Squat": 5},
+    "deload_percentage": 0.9,
+    "double_threshold": 10
+  }
+}`
+
+func writeImportFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestProgramImport_Success(t *testing.T) {
+	_ = setupTestEnv(t)
+	path := writeImportFile(t, "variant.json", importTestProgram)
+
+	var buf bytes.Buffer
+	cmd := programImportCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{path})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `Imported program "My Variant" (1 days`)
+
+	// Imported program shows up alongside the built-ins
+	ctx, err := services.NewCommandContextWithDefaults()
+	require.NoError(t, err)
+
+	programs := ctx.Programs.List()
+	require.Len(t, programs, len(program.List())+1)
+	assert.Equal(t, "My Variant", programs[len(programs)-1].Name)
+}
+
+func TestProgramImport_Describe(t *testing.T) {
+	_ = setupTestEnv(t)
+	path := writeImportFile(t, "variant.json", importTestProgram)
+
+	var buf bytes.Buffer
+	cmd := programImportCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("Squat-only variant.\nDeload after a missed week.\n.\n"))
+	require.NoError(t, cmd.Flags().Set("describe", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("describe", "false") })
+
+	require.NoError(t, cmd.RunE(cmd, []string{path}))
+	assert.Contains(t, buf.String(), "Description (finish with a blank line or '.'):\n")
+
+	ctx, err := services.NewCommandContextWithDefaults()
+	require.NoError(t, err)
+	prog, err := ctx.Programs.Find(t.Context(), "My Variant")
+	require.NoError(t, err)
+	assert.Equal(t, "Squat-only variant.\nDeload after a missed week.", prog.Description)
+}
+
+func TestProgramImport_ValidationErrorNamesField(t *testing.T) {
+	_ = setupTestEnv(t)
+	path := writeImportFile(t, "bad.json",
+		`{"name": "Bad", "workouts": [{"day": 1, "lifts": [{"lift_name": "Squat", "working_sets": [{"reps": 0, "type": "AMRAPSet"}]}]}]}`)
+
+	cmd := programImportCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := cmd.RunE(cmd, []string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workouts[0].lifts[0].working_sets[0].reps: must be positive")
+}
+
+func TestProgramImport_DuplicateName(t *testing.T) {
+	_ = setupTestEnv(t)
+	path := writeImportFile(t, "variant.json", importTestProgram)
+
+	cmd := programImportCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	require.NoError(t, cmd.RunE(cmd, []string{path}))
+
+	err := cmd.RunE(cmd, []string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `a program named "My Variant" already exists`)
+}
+
+func TestProgramImport_MissingFile(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	cmd := programImportCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := cmd.RunE(cmd, []string{filepath.Join(t.TempDir(), "nope.json")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read program file")
+
+	// Nothing was written to the programs directory
+	repo, err := repository.NewJSONProgramRepository()
+	require.NoError(t, err)
+	programs, err := repo.List()
+	require.NoError(t, err)
+	assert.Empty(t, programs)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var programListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available programs",
+	Long: `List all available programs, built-in and imported, with their IDs, versions, and cycle lengths.
+
+With --mine, list the programs the current user has started instead, showing each
+one's start date, current day, and current weights. The current program is marked
+with an asterisk, and programs trained alongside it with a plus.`,
+	RunE: listPrograms,
+}
+
+func init() {
+	programListCmd.Flags().Bool("mine", false, "List the current user's programs")
+}
+
+func listPrograms(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	mine, err := cmd.Flags().GetBool("mine")
+	if err != nil {
+		return fmt.Errorf("failed to get mine flag: %w", err)
+	}
+
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	if !mine {
+		formatter.DisplayProgramList(ctx.Programs.List())
+		outputFor(cmd).Result(ctx.Programs.List())
+		return nil
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	programs := make(map[uuid.UUID]*models.Program)
+	for _, prog := range ctx.Programs.List() {
+		programs[prog.ID] = prog
+	}
+	formatter.DisplayUserPrograms(user.ProgramList(), user.CurrentProgram, user.ActivePrograms, programs)
+	outputFor(cmd).Result(nonNil(user.ProgramList()))
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramList(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	var buf bytes.Buffer
+	cmd := programListCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "1. OG Greyskull LP (v1.0.0, 6 days)")
+	assert.Contains(t, output, "ID: 550e8400-e29b-41d4-a716-446655440000")
+	assert.Contains(t, output, "2. Greyskull LP (3-Day A/B) (v1.0.0, 2 days)")
+}
+
+func TestProgramShow(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	for _, query := range []string{"og greyskull lp", "550e8400-e29b-41d4-a716-446655440000"} {
+		t.Run(query, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := programShowCmd
+			cmd.SetOut(&buf)
+
+			err := cmd.RunE(cmd, []string{query})
+			require.NoError(t, err)
+
+			output := buf.String()
+			assert.Contains(t, output, "OG Greyskull LP (v1.0.0)")
+			assert.Contains(t, output, "Day 6:\n  Bench Press:")
+			assert.Contains(t, output, "Working: 2x5, 1x5+ (AMRAP)")
+			assert.Contains(t, output, "Squat: +5 lbs per session")
+		})
+	}
+}
+
+func TestProgramShow_NotFound(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	cmd := programShowCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	err := cmd.RunE(cmd, []string{"5/3/1"})
+	assert.ErrorContains(t, err, `program "5/3/1" not found`)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var programPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Set your program aside for a vacation or injury",
+	Long: `Pause your current program while you're away from training, e.g. on vacation or
+recovering from an injury. Your weights and next day are kept as they are.
+
+While the program is paused, 'greyskull status' doesn't report it as overdue,
+reminders aren't shown, and 'greyskull workout calendar' doesn't count the paused
+dates as missed. Run 'greyskull program resume' when you're back.`,
+	Example: `  greyskull program pause --reason "vacation"`,
+	Args:    cobra.NoArgs,
+	RunE:    pauseProgram,
+}
+
+var programResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused program",
+	Long: fmt.Sprintf(`Resume your paused program where you left off.
+
+After a pause of %d days or more, easing back in at lighter weights is suggested:
+90%% of your weights after two weeks away, and 80%% after four. Use --reduce to
+lower your weights to the suggestion.`, workout.LongPauseDays),
+	Example: "  greyskull program resume --reduce",
+	Args:    cobra.NoArgs,
+	RunE:    resumeProgram,
+}
+
+func init() {
+	programPauseCmd.Flags().String("reason", "", "Why the program is paused")
+	programResumeCmd.Flags().Bool("reduce", false, "Lower your weights to the suggested percentage")
+}
+
+func pauseProgram(cmd *cobra.Command, args []string) error {
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return fmt.Errorf("failed to get reason flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if pause := userProgram.ActivePause(); pause != nil {
+		return services.NewError("program_paused", "run 'greyskull program resume' to continue",
+			"program is already paused since %s", pause.StartedAt.Format("2006-01-02"))
+	}
+	pause := models.Pause{Reason: strings.TrimSpace(reason), StartedAt: time.Now()}
+	userProgram.Pauses = append(userProgram.Pauses, pause)
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if pause.Reason != "" {
+		printf(cmd, "Program paused (%s). Weights and your next day are unchanged.\n", pause.Reason)
+	} else {
+		printf(cmd, "Program paused. Weights and your next day are unchanged.\n")
+	}
+	printf(cmd, "Run 'greyskull program resume' when you're back.\n")
+
+	outputFor(cmd).Result(pause)
+	return nil
+}
+
+func resumeProgram(cmd *cobra.Command, args []string) error {
+	reduce, err := cmd.Flags().GetBool("reduce")
+	if err != nil {
+		return fmt.Errorf("failed to get reduce flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	pause := userProgram.ActivePause()
+	if pause == nil {
+		return fmt.Errorf("program is not paused")
+	}
+	now := time.Now()
+	pause.EndedAt = now
+
+	days := pause.Days(now)
+	percentage := workout.ResumePercentage(days)
+	current := userProgram.CurrentWeights
+	resumed := workout.ResumeWeights(userProgram, percentage)
+	applied := reduce && percentage < 1
+	if applied {
+		// Recorded as a weight reset so that recomputing the program replays it
+		user.WeightResets = append(user.WeightResets, models.WeightReset{
+			ID:            uuid.Must(uuid.NewV7()),
+			UserProgramID: userProgram.ID,
+			ResetAt:       now,
+			DaysOff:       workout.DaysSince(workout.LastTrainedAt(user, userProgram), now),
+			Previous:      maps.Clone(current),
+			Weights:       maps.Clone(resumed),
+		})
+		userProgram.CurrentWeights = resumed
+	}
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayResume(days, percentage, current, resumed, applied)
+	printf(cmd, "Next workout: Day %d\n", userProgram.CurrentDay)
+
+	outputFor(cmd).Result(struct {
+		Pause      models.Pause                `json:"pause"`
+		Days       int                         `json:"days"`
+		Percentage float64                     `json:"suggested_percentage"`
+		Reduced    bool                        `json:"reduced"`
+		Weights    map[models.LiftName]float64 `json:"weights"`
+	}{*pause, days, percentage, applied, userProgram.CurrentWeights})
+	return nil
+}
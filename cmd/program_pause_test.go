@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backdatePause moves the start of the current program's open pause back by days
+func backdatePause(t *testing.T, days int) {
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user := loadTestUser(t)
+	pause := user.Programs[user.CurrentProgram].ActivePause()
+	require.NotNil(t, pause)
+	pause.StartedAt = pause.StartedAt.AddDate(0, 0, -days)
+	require.NoError(t, repo.Update(t.Context(), user))
+}
+
+func TestProgramPause(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "program", "pause", "--reason", " vacation ")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Program paused (vacation). Weights and your next day are unchanged.")
+
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	pause := userProgram.ActivePause()
+	require.NotNil(t, pause)
+	assert.Equal(t, "vacation", pause.Reason)
+	assert.Equal(t, 1, userProgram.CurrentDay)
+
+	_, err = executePiped(t, "", "program", "pause")
+	assert.ErrorContains(t, err, "program is already paused since")
+}
+
+func TestProgramPause_Status(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{
+		ID:            uuid.New(),
+		UserProgramID: user.CurrentProgram,
+		Day:           1,
+		EnteredAt:     time.Now().AddDate(0, 0, -10),
+	})
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	_, err = executePiped(t, "", "program", "pause", "--reason", "injury")
+	require.NoError(t, err)
+	backdatePause(t, 8)
+
+	assert.Contains(t, runStatus(t, true), "overdue=0")
+	assert.Contains(t, runStatus(t, false), "Paused since "+time.Now().AddDate(0, 0, -8).Format("2006-01-02")+" (injury)")
+
+	_, err = executePiped(t, "", "program", "resume")
+	require.NoError(t, err)
+	assert.Contains(t, runStatus(t, true), "overdue=0", "overdue counts from when the program was resumed")
+}
+
+func TestProgramResume(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "program", "resume")
+	assert.EqualError(t, err, "program is not paused")
+
+	_, err = executePiped(t, "", "program", "pause")
+	require.NoError(t, err)
+	backdatePause(t, 3)
+
+	output, err := executePiped(t, "", "program", "resume", "--reduce")
+	require.NoError(t, err)
+	assert.Equal(t, "Resumed after 3 days away.\nNext workout: Day 1\n", output)
+
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Nil(t, userProgram.ActivePause())
+	require.Len(t, userProgram.Pauses, 1)
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat], "short pauses aren't reduced")
+}
+
+func TestProgramResume_LongPause(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "program", "pause")
+	require.NoError(t, err)
+	backdatePause(t, 21)
+
+	output, err := executePiped(t, "", "program", "resume")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Resumed after 21 days away.\nConsider easing back in at 90% of your weights:\n")
+	assert.Contains(t, output, "  Squat: 120 lbs (now 135 lbs)\n")
+	assert.Contains(t, output, "Run 'greyskull deload week --percent 90' for a lighter week.\n")
+	user := loadTestUser(t)
+	assert.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+
+	_, err = executePiped(t, "", "program", "pause")
+	require.NoError(t, err)
+	backdatePause(t, 30)
+
+	output, err = executePiped(t, "", "program", "resume", "--reduce")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Weights reduced to 80% to ease back in:\n")
+	assert.Contains(t, output, "  Squat: 107.5 lbs (was 135 lbs)\n")
+	user = loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 107.5, userProgram.CurrentWeights[models.Squat])
+	assert.Len(t, userProgram.Pauses, 2)
+	require.Len(t, user.WeightResets, 1, "only the reduced resume is recorded")
+	assert.Equal(t, 135.0, user.WeightResets[0].Previous[models.Squat])
+	assert.Equal(t, 107.5, user.WeightResets[0].Weights[models.Squat])
+}
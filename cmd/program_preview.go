@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var programPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview the weights of the upcoming cycle",
+	Long: `Show every day of the upcoming cycle of your current program, starting with
+your next workout, with each lift's working weight and the plates to load on
+each side of the bar. Weights assume every session is completed successfully,
+so each lift progresses by its usual increment.
+
+Plates come from your configured plate inventory, or standard plates if you
+haven't set one (see 'greyskull config set plates').`,
+	Example: `  greyskull program preview
+  greyskull program preview --cycles 2`,
+	Args: cobra.NoArgs,
+	RunE: previewProgram,
+}
+
+func init() {
+	programPreviewCmd.Flags().Int("cycles", 1, "Number of cycles to preview")
+}
+
+func previewProgram(cmd *cobra.Command, args []string) error {
+	cycles, err := cmd.Flags().GetInt("cycles")
+	if err != nil {
+		return fmt.Errorf("failed to get cycles flag: %w", err)
+	}
+	if cycles < 1 {
+		return fmt.Errorf("invalid --cycles %d: must be at least 1", cycles)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	workouts, err := workout.PreviewSessions(user, program, config, cycles*len(program.Workouts))
+	if err != nil {
+		return fmt.Errorf("failed to preview the upcoming cycle: %w", err)
+	}
+
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayCyclePreview(program, workouts, config)
+	outputFor(cmd).Result(workouts)
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramPreview(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "program", "preview")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Upcoming cycle of OG Greyskull LP, assuming each session succeeds:\n")
+	assert.Contains(t, output, "\nDay 1:\n  Overhead Press: 95 lbs (25 per side)\n  Squat: 135 lbs (45 per side)\n")
+	assert.Contains(t, output, "\nDay 2:\n  Bench Press: 125 lbs (35, 5 per side)\n  Deadlift: 185 lbs (45, 25 per side)\n")
+	assert.Contains(t, output, "\nDay 3:\n  Overhead Press: 97.5 lbs (25, 1.25 per side)")
+	assert.Contains(t, output, "\nDay 6:\n")
+	assert.NotContains(t, output, "Day 7")
+
+	user := loadTestUser(t)
+	assert.Equal(t, 1, user.Programs[user.CurrentProgram].CurrentDay)
+}
+
+func TestProgramPreview_Plates(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "config", "set", "plates", "45x2", "10x2")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "program", "preview", "--cycles", "2")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Overhead Press: 95 lbs (10 per side, 15 lbs per side short; nearest loadable: 65 lbs)\n")
+	assert.Equal(t, 2, strings.Count(output, "\nDay 6:\n"))
+
+	_, err = executePiped(t, "", "program", "preview", "--cycles", "0")
+	assert.EqualError(t, err, "invalid --cycles 0: must be at least 1")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var programResetWeightsCmd = &cobra.Command{
+	Use:   "reset-weights",
+	Short: "Reset your weights after a layoff",
+	Long: `Walk through resetting each lift's weight after time away from training.
+
+The gap since your last workout decides the suggested weights: 10% less for
+every full month off, but never below half your current weights. For each lift,
+press Enter to accept the suggestion or type a fresh weight. Use --yes to accept
+every suggestion without being asked.
+
+The reset is recorded in your history along with the weights it replaced.`,
+	Example: `  greyskull program reset-weights
+  greyskull program reset-weights --yes`,
+	Args: cobra.NoArgs,
+	RunE: resetWeights,
+}
+
+func init() {
+	programResetWeightsCmd.Flags().BoolP("yes", "y", false, "Accept every suggested weight without asking")
+}
+
+func resetWeights(cmd *cobra.Command, args []string) error {
+	acceptAll, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	lastTrained := workout.LastTrainedAt(user, userProgram)
+	daysOff := workout.DaysSince(lastTrained, now)
+	percentage := workout.LayoffPercentage(daysOff)
+	suggested := workout.ResumeWeights(userProgram, percentage)
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	weights := weightFormat(config, userProgram.Unit)
+
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weights)
+	formatter.DisplayLayoff(lastTrained, daysOff, percentage)
+
+	reset := models.WeightReset{
+		ID:            uuid.Must(uuid.NewV7()),
+		UserProgramID: userProgram.ID,
+		ResetAt:       now,
+		DaysOff:       daysOff,
+		Previous:      maps.Clone(userProgram.CurrentWeights),
+		Weights:       maps.Clone(userProgram.CurrentWeights),
+	}
+
+	if acceptAll {
+		maps.Copy(reset.Weights, suggested)
+	} else {
+		printf(cmd, "Press Enter to accept each suggestion, or type a new weight.\n")
+		inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+		for _, lift := range resetWeightKeys(program, userProgram.CurrentWeights) {
+			prompt := i18n.Sprintf("%s: %s, suggested %s: ", display.FormatLiftName(lift),
+				weights.Format(userProgram.CurrentWeights[lift]), weights.Format(suggested[lift]))
+			for {
+				weight, err := readResetWeight(inputReader, prompt, suggested[lift], program.IsBodyweight(lift))
+				if err != nil {
+					// Only re-prompt for bad answers; stop once input runs out or fails
+					var invalid *InvalidInputError
+					if !errors.As(err, &invalid) {
+						return fmt.Errorf("failed to get weight for %s: %w", lift, err)
+					}
+					printf(cmd, "Invalid input: %v. Please try again.\n", err)
+					continue
+				}
+				reset.Weights[lift] = weight
+				break
+			}
+		}
+	}
+
+	if err := backupUser(cmd, ctx, user.Username, "reset-weights"); err != nil {
+		return err
+	}
+	userProgram.CurrentWeights = maps.Clone(reset.Weights)
+	user.WeightResets = append(user.WeightResets, reset)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	formatter.DisplayWeightReset(reset)
+
+	outputFor(cmd).Result(reset)
+	return nil
+}
+
+// readResetWeight reads a lift's new weight, or its suggested weight if the
+// answer is blank. Bodyweight lifts take any added weight; others must be
+// positive.
+func readResetWeight(inputReader *CLIInputReader, prompt string, suggested float64, bodyweight bool) (float64, error) {
+	input, err := inputReader.ReadLine(prompt)
+	if err != nil {
+		return 0, err
+	}
+	if input == "" {
+		return suggested, nil
+	}
+
+	weight, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, invalidInput("invalid number: %s", input)
+	}
+	if !bodyweight && weight <= 0 {
+		return 0, invalidInput("number must be positive, got: %g", weight)
+	}
+	return weight, nil
+}
+
+// resetWeightKeys returns the lifts with current weights in the order they're
+// asked for when starting the program, followed by any others
+func resetWeightKeys(prog *models.Program, weights map[models.LiftName]float64) []models.LiftName {
+	var keys []models.LiftName
+	for _, key := range startingWeightKeys(prog) {
+		if _, exists := weights[key]; exists {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(weights)) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
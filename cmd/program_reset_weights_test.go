@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startProgramDaysAgo moves the start of the test user's program, which has no
+// workouts, back by days
+func startProgramDaysAgo(t *testing.T, user *models.User, days int) {
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.Programs[user.CurrentProgram].StartedAt = time.Now().AddDate(0, 0, -days)
+	require.NoError(t, repo.Update(t.Context(), user))
+}
+
+func TestProgramResetWeights(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	startProgramDaysAgo(t, user, 65)
+
+	// Accept the squat suggestion, enter a fresh deadlift, retry a bad bench
+	output, err := executePiped(t, "\n200\nheavy\n\n\n", "program", "reset-weights")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "(65 days ago).\nSuggesting 80% of your current weights, 10% less for each month off.\n")
+	assert.Contains(t, output, "Squat: 135 lbs, suggested 107.5 lbs: ")
+	assert.Contains(t, output, "Invalid input: invalid number: heavy. Please try again.\n")
+	assert.Contains(t, output, "Weights reset after 65 days off:\n"+
+		"  Overhead Press: 75 lbs (was 95 lbs)\n"+
+		"  Bench Press: 100 lbs (was 125 lbs)\n"+
+		"  Squat: 107.5 lbs (was 135 lbs)\n"+
+		"  Deadlift: 200 lbs (was 185 lbs)\n")
+
+	user = loadTestUser(t)
+	weights := user.Programs[user.CurrentProgram].CurrentWeights
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat:         107.5,
+		models.Deadlift:      200,
+		models.BenchPress:    100,
+		models.OverheadPress: 75,
+	}, weights)
+
+	require.Len(t, user.WeightResets, 1)
+	reset := user.WeightResets[0]
+	assert.Equal(t, user.CurrentProgram, reset.UserProgramID)
+	assert.Equal(t, 65, reset.DaysOff)
+	assert.Equal(t, 135.0, reset.Previous[models.Squat])
+	assert.Equal(t, weights, reset.Weights)
+}
+
+func TestProgramResetWeights_Yes(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "program", "reset-weights", "--yes")
+	require.NoError(t, err)
+	assert.Contains(t, output, "That's less than a month off, so your current weights are suggested.\n")
+	assert.Contains(t, output, "  Squat: 135 lbs (unchanged)\n")
+
+	user := loadTestUser(t)
+	assert.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+	assert.Len(t, user.WeightResets, 1)
+}
+
+func TestProgramResetWeights_NoInput(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "program", "reset-weights")
+	assert.ErrorIs(t, err, ErrNoInput)
+	assert.Empty(t, loadTestUser(t).WeightResets)
+}
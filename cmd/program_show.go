@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var programShowCmd = &cobra.Command{
+	Use:   "show <name|id>",
+	Short: "Show a program's full structure",
+	Long: `Show the full weekly structure of a program: each day's lifts, warmup and
+working set schemes, and the progression rules. The program can be given by
+name (case-insensitive) or by ID.`,
+	Example: `  greyskull program show "OG Greyskull LP"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    showProgram,
+}
+
+func showProgram(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	prog, err := ctx.Programs.Find(contextFor(cmd), args[0])
+	if err != nil {
+		if errors.Is(err, program.ErrProgramNotFound) {
+			return services.ProgramNotFound(args[0])
+		}
+		return err
+	}
+
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.DisplayProgram(prog)
+	outputFor(cmd).Result(prog)
+
+	return nil
+}
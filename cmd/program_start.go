@@ -1,22 +1,50 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
 	"github.com/mikowitz/greyskull/models"
-	"github.com/mikowitz/greyskull/program"
-	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
+// coreLifts lists the core lifts in display order
+var coreLifts = []models.LiftName{
+	models.Squat,
+	models.Deadlift,
+	models.BenchPress,
+	models.OverheadPress,
+}
+
 var programStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start a new workout program",
-	Long:  "Initialize a new workout program for the current user, setting starting weights for all lifts.",
-	RunE:  startProgram,
+	Long: `Initialize a new workout program for the current user, setting starting weights for all lifts.
+
+By default the program begins on day 1. Use --start-day to begin on any other
+day of the program template (e.g. --start-day 2 to start with bench day).
+
+After the starting weights you can customize the program's progression rules:
+each lift's increment, the percentage to deload to, and the AMRAP reps that
+double the increment. Blank answers keep the template's values.
+
+Use --alongside to train the new program alongside your current one, such as a
+conditioning template next to Greyskull, instead of replacing it. Pick it with
+'workout next --program' and 'workout log --program'.`,
+	RunE: startProgram,
+}
+
+func init() {
+	programStartCmd.Flags().Int("start-day", 1, "Program day to start on")
+	programStartCmd.Flags().Bool("alongside", false, "Train the new program alongside the current one instead of replacing it")
 }
 
 func startProgram(cmd *cobra.Command, args []string) error {
@@ -24,26 +52,26 @@ func startProgram(cmd *cobra.Command, args []string) error {
 	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
 	
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
 
 	// Load current user
-	user, err := ctx.UserService.RequireCurrentUser()
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
 	if err != nil {
 		return err
 	}
 
 	// List available programs
-	programs := program.List()
+	programs := ctx.Programs.List()
 	if len(programs) == 0 {
 		return fmt.Errorf("no programs available")
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "Available programs:")
+	fprintf(cmd.OutOrStdout(), "Available programs:\n")
 	for i, prog := range programs {
-		fmt.Fprintf(cmd.OutOrStdout(), "%d. %s\n", i+1, prog.Name)
+		fprintf(cmd.OutOrStdout(), "%d. %s\n", i+1, prog.Name)
 	}
 
 	// Prompt for program selection
@@ -51,11 +79,16 @@ func startProgram(cmd *cobra.Command, args []string) error {
 	for {
 		num, err := inputReader.ReadInt("Select a program (enter number): ")
 		if err != nil {
-			fmt.Fprintf(cmd.OutOrStdout(), "Invalid input: %v. Please try again.\n", err)
+			// Only re-prompt for bad answers; stop once input runs out or fails
+			var invalid *InvalidInputError
+			if !errors.As(err, &invalid) {
+				return fmt.Errorf("failed to read program selection: %w", err)
+			}
+			fprintf(cmd.OutOrStdout(), "Invalid input: %v. Please try again.\n", err)
 			continue
 		}
 		if num < 1 || num > len(programs) {
-			fmt.Fprintf(cmd.OutOrStdout(), "Invalid selection. Please enter a number between 1 and %d.\n", len(programs))
+			fprintf(cmd.OutOrStdout(), "Invalid selection. Please enter a number between 1 and %d.\n", len(programs))
 			continue
 		}
 		selection = num
@@ -64,34 +97,51 @@ func startProgram(cmd *cobra.Command, args []string) error {
 
 	selectedProgram := programs[selection-1]
 
-	// Define core lifts in display order
-	lifts := []models.LiftName{
-		models.Squat,
-		models.Deadlift,
-		models.BenchPress,
-		models.OverheadPress,
+	// Validate the requested start day against the selected program
+	startDay, err := cmd.Flags().GetInt("start-day")
+	if err != nil {
+		return fmt.Errorf("failed to get start-day flag: %w", err)
+	}
+	if err := validateStartDay(startDay, selectedProgram); err != nil {
+		return err
 	}
 
-	// Prompt for starting weights
+	// Prompt for starting weights: the core lifts, then any variant weight tracks the program uses
 	startingWeights := make(map[models.LiftName]float64)
-	for _, lift := range lifts {
-		prompt := fmt.Sprintf("Enter starting weight for %s (lbs): ", liftDisplayName(lift))
-		weight, err := inputReader.ReadPositiveFloat(prompt)
+	for _, lift := range startingWeightKeys(selectedProgram) {
+		weight, err := readStartingWeight(inputReader, selectedProgram, lift, user.Unit)
 		if err != nil {
-			return fmt.Errorf("failed to get weight for %s: %v", lift, err)
+			return fmt.Errorf("failed to get weight for %s: %w", lift, err)
 		}
 		startingWeights[lift] = weight
 	}
 
+	// Offer to tailor the template's progression rules; keep them as they are
+	// if input runs out
+	customize, err := inputReader.ReadConfirm("Customize progression rules (increments, deload, double threshold)? [y/N] ")
+	if err != nil && !errors.Is(err, ErrNoInput) {
+		return fmt.Errorf("failed to read answer: %w", err)
+	}
+	var rules *models.ProgressionRules
+	if customize {
+		rules, err = readProgressionRules(inputReader, cmd.OutOrStdout(), selectedProgram, user.Unit)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create UserProgram
 	userProgram := &models.UserProgram{
-		ID:              uuid.Must(uuid.NewV7()),
-		UserID:          user.ID,
-		ProgramID:       selectedProgram.ID,
-		StartingWeights: startingWeights,
-		CurrentWeights:  make(map[models.LiftName]float64),
-		CurrentDay:      1,
-		StartedAt:       time.Now(),
+		ID:               uuid.Must(uuid.NewV7()),
+		UserID:           user.ID,
+		ProgramID:        selectedProgram.ID,
+		StartingWeights:  startingWeights,
+		CurrentWeights:   make(map[models.LiftName]float64),
+		CurrentDay:       startDay,
+		StartedAt:        time.Now(),
+		Unit:             user.Unit.OrDefault(),
+		ProgressionRules: rules,
+		Program:          selectedProgram,
 	}
 
 	// Copy starting weights to current weights
@@ -99,43 +149,175 @@ func startProgram(cmd *cobra.Command, args []string) error {
 		userProgram.CurrentWeights[lift] = weight
 	}
 
+	alongside, err := cmd.Flags().GetBool("alongside")
+	if err != nil {
+		return fmt.Errorf("failed to get alongside flag: %w", err)
+	}
+	alongside = alongside && user.CurrentProgram != uuid.Nil
+
+	// Restarting replaces the active program, so keep a copy of the old state
+	if user.CurrentProgram != uuid.Nil && !alongside {
+		if err := backupUser(cmd, ctx, user.Username, "restart"); err != nil {
+			return err
+		}
+	}
+
 	// Update user
 	if user.Programs == nil {
 		user.Programs = make(map[uuid.UUID]*models.UserProgram)
 	}
 	user.Programs[userProgram.ID] = userProgram
-	user.CurrentProgram = userProgram.ID
+	if alongside {
+		user.ActivateProgram(userProgram.ID)
+	} else {
+		user.CurrentProgram = userProgram.ID
+	}
 
 	// Save user
-	if err := ctx.UserRepo.Update(user); err != nil {
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
 		return fmt.Errorf("failed to save user: %w", err)
 	}
 
 	// Show success message with day 1 preview
-	fmt.Fprintf(cmd.OutOrStdout(), "Program started! %s\n", selectedProgram.Name)
+	fprintf(cmd.OutOrStdout(), "Program started! %s\n", selectedProgram.Name)
 	
-	// Get day 1 exercises for preview
-	if len(selectedProgram.Workouts) > 0 {
-		day1 := selectedProgram.Workouts[0]
-		exercises := make([]string, len(day1.Lifts))
-		for i, lift := range day1.Lifts {
-			exercises[i] = liftDisplayName(lift.LiftName)
+	fprintf(cmd.OutOrStdout(), "Day %d will be: %s\n", startDay, dayPreview(selectedProgram, startDay))
+
+	return nil
+}
+
+// startingWeightKeys returns the weight keys to prompt for when starting a program
+func startingWeightKeys(prog *models.Program) []models.LiftName {
+	keys := slices.Clone(coreLifts)
+	for _, key := range prog.WeightKeys() {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Day 1 will be: %s\n", strings.Join(exercises, ", "))
 	}
+	return keys
+}
 
-	return nil
+// readStartingWeight asks for a lift's starting weight in unit: its added
+// weight for bodyweight lifts, or its training max for lifts with sets
+// calculated from one
+func readStartingWeight(inputReader *CLIInputReader, prog *models.Program, lift models.LiftName, unit models.WeightUnit) (float64, error) {
+	if prog.IsBodyweight(lift) {
+		// Added weight: none for bodyweight alone, or negative for assistance
+		prompt := i18n.Sprintf("Enter starting added weight for %s (%s, 0 for bodyweight, negative for assistance): ",
+			display.FormatLiftName(lift), unit.OrDefault())
+		return inputReader.ReadFloat(prompt)
+	}
+	if prog.UsesTrainingMax(lift) {
+		// Percentage-based sets are calculated from the lift's training max,
+		// which is its current weight until one is set
+		prompt := i18n.Sprintf("Enter training max for %s (%s): ", display.FormatLiftName(lift), unit.OrDefault())
+		return inputReader.ReadPositiveFloat(prompt)
+	}
+	prompt := i18n.Sprintf("Enter starting weight for %s (%s): ", display.FormatLiftName(lift), unit.OrDefault())
+	return inputReader.ReadPositiveFloat(prompt)
+}
+
+// readProgressionRules asks for each of the program's increments, and the
+// deload percentage and double threshold if its rules use them, in unit. Blank
+// answers keep the template's values, and invalid ones are asked again.
+func readProgressionRules(inputReader InputReader, out io.Writer, prog *models.Program, unit models.WeightUnit) (*models.ProgressionRules, error) {
+	rules := prog.ProgressionRules.ForUnit(unit).Clone()
+	reader := NewRetryingInputReader(inputReader, out, DefaultMaxAttempts)
+
+	for _, lift := range resetWeightKeys(prog, rules.IncreaseRules) {
+		prompt := i18n.Sprintf("Increment for %s (%s) [%s]: ", display.FormatLiftName(lift), rules.Unit, display.FormatWeight(rules.IncreaseRules[lift]))
+		increment, err := retryRead(reader, func() (float64, error) {
+			return readFloatOrDefault(inputReader, prompt, rules.IncreaseRules[lift])
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read increment for %s: %w", lift, err)
+		}
+		rules.IncreaseRules[lift] = increment
+	}
+
+	if rules.DeloadPercentage > 0 {
+		prompt := i18n.Sprintf("Deload to what percentage of the weight [%g]: ", rules.DeloadPercentage*100)
+		percentage, err := retryRead(reader, func() (float64, error) {
+			percentage, err := readFloatOrDefault(inputReader, prompt, rules.DeloadPercentage*100)
+			if err == nil && percentage >= 100 {
+				return 0, invalidInput("deload percentage must be below 100, got: %g", percentage)
+			}
+			return percentage, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read deload percentage: %w", err)
+		}
+		rules.DeloadPercentage = percentage / 100
+	}
+
+	if rules.DoubleThreshold > 0 {
+		prompt := i18n.Sprintf("AMRAP reps that double the increment [%d]: ", rules.DoubleThreshold)
+		threshold, err := retryRead(reader, func() (int, error) {
+			return readIntOrDefault(inputReader, prompt, rules.DoubleThreshold)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read double threshold: %w", err)
+		}
+		rules.DoubleThreshold = threshold
+	}
+
+	return rules, nil
+}
+
+// readFloatOrDefault reads a positive number, or returns value if the answer is blank
+func readFloatOrDefault(inputReader InputReader, prompt string, value float64) (float64, error) {
+	input, err := inputReader.ReadLine(prompt)
+	if err != nil || input == "" {
+		return value, err
+	}
+
+	number, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, invalidInput("invalid number: %s", input)
+	}
+	if number <= 0 {
+		return 0, invalidInput("number must be positive, got: %g", number)
+	}
+	return number, nil
+}
+
+// readIntOrDefault reads a positive integer, or returns value if the answer is blank
+func readIntOrDefault(inputReader InputReader, prompt string, value int) (int, error) {
+	input, err := inputReader.ReadLine(prompt)
+	if err != nil || input == "" {
+		return value, err
+	}
+
+	number, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, invalidInput("invalid integer: %s", input)
+	}
+	if number <= 0 {
+		return 0, invalidInput("number must be positive, got: %d", number)
+	}
+	return number, nil
 }
 
+// validateStartDay ensures the start day falls within the program's days
+func validateStartDay(day int, prog *models.Program) error {
+	if len(prog.Workouts) == 0 {
+		return fmt.Errorf("program %s has no workout days", prog.Name)
+	}
+	if day < 1 || day > len(prog.Workouts) {
+		return fmt.Errorf("invalid start day %d: %s has days 1-%d", day, prog.Name, len(prog.Workouts))
+	}
+	return nil
+}
 
-// liftDisplayName converts LiftName to display-friendly format
-func liftDisplayName(lift models.LiftName) string {
-	switch lift {
-	case models.BenchPress:
-		return "Bench Press"
-	case models.OverheadPress:
-		return "Overhead Press"
-	default:
-		return string(lift)
+// dayPreview lists the lifts of a program day, e.g. "Squat, Chin-up (optional)"
+func dayPreview(prog *models.Program, day int) string {
+	lifts := prog.Workouts[day-1].Lifts
+	names := make([]string, len(lifts))
+	for i, lift := range lifts {
+		names[i] = display.FormatLiftName(lift.WeightKey())
+		if lift.Optional {
+			names[i] = i18n.Sprintf("%s (optional)", names[i])
+		}
 	}
+	return strings.Join(names, ", ")
 }
\ No newline at end of file
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -41,10 +43,10 @@ func TestStartProgram_FullWorkflow(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 	
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 	
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 	
 	// Mock user input for program selection and weights
@@ -80,11 +82,11 @@ func TestStartProgram_FullWorkflow(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 	
-	err = repo.Update(user)
+	err = repo.Update(t.Context(), user)
 	require.NoError(t, err)
 	
 	// Verify user was updated correctly
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
 	
 	assert.Equal(t, userProgram.ID, updatedUser.CurrentProgram)
@@ -99,23 +101,16 @@ func TestStartProgram_FullWorkflow(t *testing.T) {
 }
 
 
-func TestLiftDisplayName(t *testing.T) {
-	tests := []struct {
-		lift     models.LiftName
-		expected string
-	}{
-		{models.Squat, "Squat"},
-		{models.Deadlift, "Deadlift"},
-		{models.BenchPress, "Bench Press"},
-		{models.OverheadPress, "Overhead Press"},
-	}
-	
-	for _, tt := range tests {
-		t.Run(string(tt.lift), func(t *testing.T) {
-			result := liftDisplayName(tt.lift)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+func TestDayPreview(t *testing.T) {
+	models.RegisterLifts([]models.LiftDefinition{{Name: "FrontSquat", DisplayName: "Front Squat"}})
+	t.Cleanup(func() { models.RegisterLifts(nil) })
+
+	prog := &models.Program{Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{
+		{LiftName: models.OverheadPress},
+		{LiftName: "FrontSquat"},
+		{LiftName: "Chinup", Optional: true},
+	}}}}
+	assert.Equal(t, "Overhead Press, Front Squat, Chinup (optional)", dayPreview(prog, 1))
 }
 
 func TestStartProgram_InvalidWeights(t *testing.T) {
@@ -134,10 +129,10 @@ func TestStartProgram_InvalidWeights(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 	
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 	
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 	
 }
@@ -200,4 +195,141 @@ func TestStartProgram_UserProgramCreation(t *testing.T) {
 		assert.Equal(t, weight, userProgram.StartingWeights[lift])
 		assert.Equal(t, weight, userProgram.CurrentWeights[lift])
 	}
-}
\ No newline at end of file
+}
+func TestValidateStartDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		day     int
+		wantErr bool
+	}{
+		{name: "first day", day: 1},
+		{name: "middle day", day: 3},
+		{name: "last day", day: 6},
+		{name: "zero", day: 0, wantErr: true},
+		{name: "negative", day: -1, wantErr: true},
+		{name: "past end of program", day: 7, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStartDay(tt.day, program.GreyskullLP)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "has days 1-6")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStartProgram_StartDay(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	var buf bytes.Buffer
+	cmd := programStartCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("1\n135\n185\n125\n95\n"))
+	require.NoError(t, cmd.Flags().Set("start-day", "2"))
+	t.Cleanup(func() { cmd.Flags().Set("start-day", "1") })
+
+	err = cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Day 2 will be: Bench Press, Deadlift")
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, 2, user.Programs[user.CurrentProgram].CurrentDay)
+}
+
+func TestStartProgram_InvalidStartDay(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	cmd := programStartCmd
+	cmd.SetOut(io.Discard)
+	cmd.SetIn(strings.NewReader("1\n135\n185\n125\n95\n"))
+	require.NoError(t, cmd.Flags().Set("start-day", "7"))
+	t.Cleanup(func() { cmd.Flags().Set("start-day", "1") })
+
+	err = cmd.RunE(cmd, []string{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid start day 7")
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Empty(t, user.Programs)
+}
+
+func TestStartProgram_CustomProgressionRules(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	// Keep squat and deadlift, take 1.25 lb jumps on the presses, deload to 85%, and keep the double threshold
+	output, err := executePiped(t, "1\n135\n185\n125\n95\ny\n\n\n1.25\nabc\n1.25\n100\n85\n\n", "program", "start")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Increment for Overhead Press (lbs) [2.5]: ")
+	assert.Contains(t, output, "Invalid input: invalid number: abc. Please try again.")
+	assert.Contains(t, output, "Invalid input: deload percentage must be below 100, got: 100. Please try again.")
+	assert.Contains(t, output, "AMRAP reps that double the increment [10]: ")
+	assert.Contains(t, output, "Program started!")
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	rules := user.Programs[user.CurrentProgram].ProgressionRules
+	require.NotNil(t, rules)
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat: 5, models.Deadlift: 5, models.BenchPress: 1.25, models.OverheadPress: 1.25,
+	}, rules.IncreaseRules)
+	assert.Equal(t, 0.85, rules.DeloadPercentage)
+	assert.Equal(t, 10, rules.DoubleThreshold)
+	assert.Equal(t, 2.5, program.GreyskullLP.ProgressionRules.IncreaseRules[models.BenchPress], "the template is unchanged")
+}
+
+func TestStartProgram_TemplateProgressionRules(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	_, err = executePiped(t, "1\n135\n185\n125\n95\nn\n", "program", "start")
+	require.NoError(t, err)
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Nil(t, user.Programs[user.CurrentProgram].ProgressionRules)
+}
+
+func TestStartingWeightKeys(t *testing.T) {
+	assert.Equal(t, coreLifts, startingWeightKeys(program.GreyskullLP))
+
+	prog := &models.Program{
+		Workouts: []models.WorkoutTemplate{
+			{Day: 1, Lifts: []models.LiftTemplate{
+				{LiftName: models.Squat, Variant: "SSB"},
+				{LiftName: models.Deadlift, Variant: "TrapBar"},
+				{LiftName: models.Squat},
+			}},
+		},
+	}
+
+	assert.Equal(t, []models.LiftName{
+		models.Squat, models.Deadlift, models.BenchPress, models.OverheadPress,
+		"Squat:SSB", "Deadlift:TrapBar",
+	}, startingWeightKeys(prog))
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var programSwitchCmd = &cobra.Command{
+	Use:   "switch <id|index>",
+	Short: "Switch to another of your programs",
+	Long: `Switch the active program to another program you've started, resuming it where
+you left off. The program can be given by its index or ID as shown by
+'greyskull program list --mine'.
+
+Before switching, a preview shows the program's current weights, its next session,
+and when it was last trained, and asks for confirmation. Use --yes to skip the prompt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: switchProgram,
+}
+
+func init() {
+	programSwitchCmd.Flags().BoolP("yes", "y", false, "Switch without asking for confirmation")
+}
+
+func switchProgram(cmd *cobra.Command, args []string) error {
+	skipConfirm, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	userProgram, err := resolveUserProgram(user, args[0])
+	if err != nil {
+		return err
+	}
+
+	if userProgram.ID == user.CurrentProgram {
+		printf(cmd, "Program %s is already active.\n", userProgram.ID)
+		return nil
+	}
+
+	preview, err := ctx.UserService.PreviewSwitch(contextFor(cmd), user, userProgram, time.Now())
+	if err != nil {
+		return err
+	}
+	display.NewProgramFormatter(cmd.OutOrStdout()).DisplaySwitchPreview(preview)
+
+	if !skipConfirm {
+		inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+		confirmed, err := inputReader.ReadConfirm("\nSwitch programs? [y/N] ")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			printf(cmd, "Switch cancelled.\n")
+			return nil
+		}
+	}
+
+	if err := ctx.UserService.SwitchProgram(contextFor(cmd), user, userProgram); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nSwitched to %s (started %s). Next workout: Day %d\n",
+		display.FormatProgramName(userProgram, preview.ToProgram), userProgram.StartedAt.Format("2006-01-02"), userProgram.CurrentDay)
+	return nil
+}
+
+// resolveUserProgram finds one of the user's programs by its 1-based index in
+// start-date order or by its ID
+func resolveUserProgram(user *models.User, ref string) (*models.UserProgram, error) {
+	userPrograms := user.ProgramList()
+	if len(userPrograms) == 0 {
+		return nil, services.NewError("no_programs", "run 'greyskull program start' to begin a program", "no programs found")
+	}
+
+	ref = strings.TrimSpace(ref)
+	if index, err := strconv.Atoi(ref); err == nil {
+		if index < 1 || index > len(userPrograms) {
+			return nil, fmt.Errorf("invalid program index %d: expected a number between 1 and %d", index, len(userPrograms))
+		}
+		return userPrograms[index-1], nil
+	}
+
+	id, err := uuid.Parse(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program reference %q: expected an index or ID from 'greyskull program list --mine'", ref)
+	}
+	userProgram, exists := user.Programs[id]
+	if !exists {
+		return nil, fmt.Errorf("program %s not found", id)
+	}
+	return userProgram, nil
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addOlderThreeDayProgram gives the test user a second, earlier-started program on the 3-day template
+func addOlderThreeDayProgram(t *testing.T, user *models.User) *models.UserProgram {
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+
+	userProgram := &models.UserProgram{
+		ID:              uuid.Must(uuid.NewV7()),
+		UserID:          user.ID,
+		ProgramID:       uuid.Must(uuid.Parse("550e8400-e29b-41d4-a716-446655440001")),
+		StartingWeights: map[models.LiftName]float64{models.Squat: 95.0},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 105.0},
+		CurrentDay:      2,
+		StartedAt:       time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+	}
+	user.Programs[userProgram.ID] = userProgram
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	return userProgram
+}
+
+func loadTestUser(t *testing.T) *models.User {
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	require.NoError(t, user.LoadHistory())
+	return user
+}
+
+func TestProgramListMine(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	older := addOlderThreeDayProgram(t, user)
+
+	var buf bytes.Buffer
+	cmd := programListCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("mine", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("mine", "false") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Your programs:")
+	assert.Contains(t, output, "  1. Greyskull LP (3-Day A/B)\n     ID: "+older.ID.String())
+	assert.Contains(t, output, "Started: 2024-01-15, Day 2 of 2")
+	assert.Contains(t, output, "Weights: Squat 105")
+	assert.Contains(t, output, "* 2. OG Greyskull LP\n     ID: "+user.CurrentProgram.String())
+	assert.Contains(t, output, "Weights: Overhead Press 95, Bench Press 125, Squat 135, Deadlift 185")
+}
+
+func TestProgramListMine_NoUser(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	cmd := programListCmd
+	cmd.SetOut(&bytes.Buffer{})
+	require.NoError(t, cmd.Flags().Set("mine", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("mine", "false") })
+
+	err := cmd.RunE(cmd, []string{})
+	assert.Error(t, err)
+}
+
+func runProgramSwitch(t *testing.T, ref, input string) (string, error) {
+	var buf bytes.Buffer
+	cmd := programSwitchCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader(input))
+
+	err := cmd.RunE(cmd, []string{ref})
+	return buf.String(), err
+}
+
+func TestProgramSwitch(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	original := user.CurrentProgram
+	older := addOlderThreeDayProgram(t, user)
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected uuid.UUID
+	}{
+		{"by index", "1", older.ID},
+		{"by id", original.String(), original},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := runProgramSwitch(t, tt.ref, "y\n")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, loadTestUser(t).CurrentProgram)
+			assert.Contains(t, output, "Switched to")
+		})
+	}
+}
+
+func TestProgramSwitch_Output(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	older := addOlderThreeDayProgram(t, user)
+
+	output, err := runProgramSwitch(t, "1", "yes\n")
+	require.NoError(t, err)
+
+	days := workout.DaysSince(older.StartedAt, time.Now())
+	expected := "Switching from OG Greyskull LP to Greyskull LP (3-Day A/B)\n" +
+		"  Started: 2024-01-15\n" +
+		"  Last trained: never\n" +
+		"  Weights: Squat 105\n" +
+		"  Next session: Day 2\n" +
+		"\n" +
+		fmt.Sprintf("Warning: Greyskull LP (3-Day A/B) hasn't been touched in %d days. Consider lowering its weights before resuming.\n", days) +
+		"\n" +
+		"Switch programs? [y/N] \n" +
+		"Switched to Greyskull LP (3-Day A/B) (started 2024-01-15). Next workout: Day 2\n"
+	assert.Equal(t, expected, output)
+
+	output, err = runProgramSwitch(t, "1", "")
+	require.NoError(t, err)
+	assert.Contains(t, output, "is already active")
+
+	// Switching back previews the next session and gives no warning for a recent program
+	output, err = runProgramSwitch(t, "2", "y\n")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Switching from Greyskull LP (3-Day A/B) to OG Greyskull LP\n")
+	assert.Contains(t, output, "  Next session: Day 1 (Overhead Press 95, Squat 135)\n")
+	assert.NotContains(t, output, "Warning")
+}
+
+func TestProgramSwitch_Confirmation(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	original := user.CurrentProgram
+	older := addOlderThreeDayProgram(t, user)
+
+	output, err := runProgramSwitch(t, "1", "\n")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Switch cancelled.\n")
+	assert.Equal(t, original, loadTestUser(t).CurrentProgram)
+
+	_, err = runProgramSwitch(t, "1", "maybe\n")
+	assert.ErrorContains(t, err, "invalid answer: maybe")
+	assert.Equal(t, original, loadTestUser(t).CurrentProgram)
+
+	require.NoError(t, programSwitchCmd.Flags().Set("yes", "true"))
+	t.Cleanup(func() { programSwitchCmd.Flags().Set("yes", "false") })
+	output, err = runProgramSwitch(t, "1", "")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "[y/N]")
+	assert.Equal(t, older.ID, loadTestUser(t).CurrentProgram)
+}
+
+func TestProgramSwitch_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{"index out of range", "3", "invalid program index 3: expected a number between 1 and 1"},
+		{"zero index", "0", "invalid program index 0"},
+		{"unknown id", "0190a8d4-0000-7000-8000-000000000000", "program 0190a8d4-0000-7000-8000-000000000000 not found"},
+		{"garbage", "latest", `invalid program reference "latest"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := programSwitchCmd
+			cmd.SetOut(&bytes.Buffer{})
+
+			err := cmd.RunE(cmd, []string{tt.ref})
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestProgramSwitch_NoPrograms(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	cmd := programSwitchCmd
+	cmd.SetOut(&bytes.Buffer{})
+
+	err = cmd.RunE(cmd, []string{"1"})
+	assert.ErrorContains(t, err, "no programs found")
+}
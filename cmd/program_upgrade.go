@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var programUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [id|index]",
+	Short: "Move a program onto the latest version of its template",
+	Long: `Move your current program, or another of your programs given by its index or ID
+as shown by 'greyskull program list --mine', onto the latest version of its
+template.
+
+A program keeps a copy of its template from when it was started, so changes to
+the template, such as a new version of a built-in program, don't change a
+program in progress until you upgrade it. Programs started before copies were
+kept follow the template as it is; upgrading them keeps them on this version.
+
+Before upgrading, a preview shows the versions and what changes and asks for
+confirmation. Use --yes to skip the prompt. Lifts the new version adds are asked
+for starting weights, and a program on a day the new version doesn't have
+continues on day 1. Your weights and history are kept.`,
+	Example: `  greyskull program upgrade
+  greyskull program upgrade 2 --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: upgradeProgram,
+}
+
+func init() {
+	programUpgradeCmd.Flags().BoolP("yes", "y", false, "Upgrade without asking for confirmation")
+}
+
+func upgradeProgram(cmd *cobra.Command, args []string) error {
+	skipConfirm, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	var userProgram *models.UserProgram
+	if len(args) > 0 {
+		if userProgram, err = resolveUserProgram(user, args[0]); err != nil {
+			return err
+		}
+	} else if userProgram = user.Programs[user.CurrentProgram]; userProgram == nil {
+		return services.ErrNoActiveProgram
+	}
+
+	preview, err := ctx.UserService.PreviewUpgrade(contextFor(cmd), userProgram)
+	if err != nil {
+		return err
+	}
+	display.NewProgramFormatter(cmd.OutOrStdout()).DisplayUpgradePreview(preview)
+	if preview.UpToDate {
+		return nil
+	}
+
+	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+	if !skipConfirm {
+		confirmed, err := inputReader.ReadConfirm("\nUpgrade program? [y/N] ")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			printf(cmd, "Upgrade cancelled.\n")
+			return nil
+		}
+	}
+
+	weights := make(map[models.LiftName]float64)
+	for _, lift := range preview.NewLifts {
+		weight, err := readStartingWeight(inputReader, preview.To, lift, userProgram.Unit)
+		if err != nil {
+			return fmt.Errorf("failed to get weight for %s: %w", lift, err)
+		}
+		weights[lift] = weight
+	}
+
+	if err := backupUser(cmd, ctx, user.Username, "upgrade"); err != nil {
+		return err
+	}
+	if err := ctx.UserService.UpgradeProgram(contextFor(cmd), user, preview, weights); err != nil {
+		return err
+	}
+
+	printf(cmd, "\nUpgraded %s to v%s. Next workout: Day %d\n", preview.To.Name, preview.To.Version, userProgram.CurrentDay)
+	return nil
+}
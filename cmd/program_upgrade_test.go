@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotOldVersion pins the test user's program to an older version of the
+// OG Greyskull LP template, with a seventh day whose only lift is squats and
+// without a deadlift weight, and puts the program on that day
+func snapshotOldVersion(t *testing.T, user *models.User) {
+	data, err := json.Marshal(program.GreyskullLP)
+	require.NoError(t, err)
+	var old models.Program
+	require.NoError(t, json.Unmarshal(data, &old))
+	old.Version = "0.9.0"
+	old.Workouts = append(old.Workouts, models.WorkoutTemplate{Day: 7, Lifts: old.Workouts[0].Lifts[1:2]})
+
+	userProgram := user.Programs[user.CurrentProgram]
+	userProgram.Program = &old
+	userProgram.CurrentDay = 7
+	delete(userProgram.StartingWeights, models.Deadlift)
+	delete(userProgram.CurrentWeights, models.Deadlift)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+}
+
+func TestProgramStart_SnapshotsProgram(t *testing.T) {
+	_ = setupTestEnv(t)
+	_, err := executePiped(t, "TestUser\n", "user", "create")
+	require.NoError(t, err)
+
+	_, err = executePiped(t, "1\n95\n135\n185\n225\n", "program", "start")
+	require.NoError(t, err)
+
+	user := loadTestUser(t)
+	snapshot := user.Programs[user.CurrentProgram].Program
+	require.NotNil(t, snapshot)
+	assert.Equal(t, program.GreyskullLP.ID, snapshot.ID)
+	assert.Equal(t, program.GreyskullLP.Version, snapshot.Version)
+}
+
+func TestProgramUpgrade(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	snapshotOldVersion(t, user)
+
+	// The program follows its snapshot rather than the template
+	output, err := executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Day 7 Workout:\n")
+
+	output, err = executePiped(t, "n\n", "program", "upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, "Upgrading OG Greyskull LP from v0.9.0 to v1.0.0\n"+
+		"  Days: 7 → 6\n"+
+		"  New lifts: Deadlift\n"+
+		"  Next session: Day 1 (Day 7 no longer exists)\n"+
+		"\nUpgrade program? [y/N] Upgrade cancelled.\n", output)
+	assert.Equal(t, "0.9.0", loadTestUser(t).Programs[user.CurrentProgram].Program.Version)
+
+	output, err = executePiped(t, "y\n185\n", "program", "upgrade")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Enter starting weight for Deadlift (lbs): ")
+	assert.Contains(t, output, "\nUpgraded OG Greyskull LP to v1.0.0. Next workout: Day 1\n")
+
+	userProgram := loadTestUser(t).Programs[user.CurrentProgram]
+	assert.Equal(t, program.GreyskullLP.Version, userProgram.Program.Version)
+	assert.Len(t, userProgram.Program.Workouts, 6)
+	assert.Equal(t, 1, userProgram.CurrentDay)
+	assert.Equal(t, 185.0, userProgram.CurrentWeights[models.Deadlift])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+
+	output, err = executePiped(t, "", "program", "upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, "OG Greyskull LP is already on the latest version (v1.0.0).\n", output)
+}
+
+func TestProgramUpgrade_PinsProgramWithoutSnapshot(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "program", "upgrade", "--yes")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Pinning OG Greyskull LP to v1.0.0\n")
+	assert.Contains(t, output, "Upgraded OG Greyskull LP to v1.0.0. Next workout: Day 1\n")
+	assert.Equal(t, program.GreyskullLP.Version, loadTestUser(t).Programs[user.CurrentProgram].Program.Version)
+}
+
+func TestProgramUpgrade_NoProgram(t *testing.T) {
+	_ = setupTestEnv(t)
+	_, err := executePiped(t, "TestUser\n", "user", "create")
+	require.NoError(t, err)
+
+	_, err = executePiped(t, "", "program", "upgrade")
+	assert.ErrorContains(t, err, "no active program")
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/integrations"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Send workouts to fitness services",
+	Long:  `Send logged workouts to fitness services, such as Strava, as activities.`,
+}
+
+var pushStravaCmd = &cobra.Command{
+	Use:   "strava",
+	Short: "Upload a workout to Strava",
+	Long: `Upload a logged workout to Strava as a weight training activity, described by
+the sets you performed. The most recent workout is uploaded unless --date picks
+another.
+
+Strava needs an API application of your own to upload with:
+  1. Create one at https://www.strava.com/settings/api, with "localhost" as its
+     authorization callback domain.
+  2. greyskull config set strava.client_id <client id>
+     greyskull config set strava.client_secret <client secret>
+  3. greyskull push strava --authorize, and follow the instructions to let the
+     application upload activities.
+
+The tokens Strava grants are kept in your config file and renewed as needed.`,
+	Example: "  greyskull push strava\n  greyskull push strava --date 2024-03-04 --duration 75m",
+	Args:    cobra.NoArgs,
+	RunE:    pushStrava,
+}
+
+// newStrava creates the Strava exporter, replaced in tests to use a fake server
+var newStrava = integrations.NewStrava
+
+// pushResult is the JSON result of 'greyskull push'
+type pushResult struct {
+	Service        string    `json:"service"`
+	Name           string    `json:"name"`
+	Start          time.Time `json:"start"`
+	ElapsedSeconds int       `json:"elapsed_seconds"`
+	Description    string    `json:"description"`
+	URL            string    `json:"url,omitempty"`
+	DryRun         bool      `json:"dry_run"`
+}
+
+func init() {
+	pushStravaCmd.Flags().String("date", "", "Upload the workout logged on this date (YYYY-MM-DD) instead of the most recent")
+	pushStravaCmd.Flags().Duration("duration", time.Hour, "How long the workout took")
+	pushStravaCmd.Flags().Bool("dry-run", false, "Show the activity without uploading it")
+	pushStravaCmd.Flags().Bool("authorize", false, "Let your Strava API application upload activities")
+
+	pushCmd.AddCommand(pushStravaCmd)
+	rootCmd.AddCommand(pushCmd)
+}
+
+func pushStrava(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	if config.Strava.ClientID == "" || config.Strava.ClientSecret == "" {
+		return services.NewError("strava_not_configured",
+			"create an API application at https://www.strava.com/settings/api, "+
+				"then set strava.client_id and strava.client_secret with 'greyskull config set'",
+			"Strava isn't set up")
+	}
+	strava := newStrava(config.Strava)
+
+	authorize, _ := cmd.Flags().GetBool("authorize")
+	if authorize {
+		return authorizeStrava(cmd, ctx, user, config, strava)
+	}
+
+	dateInput, _ := cmd.Flags().GetString("date")
+	w, err := workoutToPush(user, dateInput)
+	if err != nil {
+		return err
+	}
+	duration, _ := cmd.Flags().GetDuration("duration")
+	if duration <= 0 {
+		return fmt.Errorf("--duration must be positive, got %s", duration)
+	}
+
+	programName, unit := "Workout", models.WeightUnit("")
+	if userProgram, exists := user.Programs[w.UserProgramID]; exists {
+		unit = userProgram.Unit
+		if prog, err := ctx.UserService.ProgramFor(contextFor(cmd), userProgram); err == nil {
+			programName = prog.Name
+		}
+	}
+	activity := integrations.NewActivity(w, programName, unit, duration)
+	result := pushResult{
+		Service:        strava.Name(),
+		Name:           activity.Name,
+		Start:          activity.Start,
+		ElapsedSeconds: int(activity.Elapsed.Seconds()),
+		Description:    activity.Description,
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		printf(cmd, "%s\n", activity.Name)
+		printf(cmd, "Started %s, lasting %s\n", activity.Start.Format("2006-01-02 15:04"), activity.Elapsed)
+		printf(cmd, "\n%s\n", activity.Description)
+		printf(cmd, "\nDry run: not uploaded to %s.\n", strava.Name())
+		result.DryRun = true
+		outputFor(cmd).Result(result)
+		return nil
+	}
+
+	url, pushErr := strava.Push(activity)
+	// A renewed token replaces the stored one even if the upload then failed
+	if err := saveStravaTokens(ctx, user, config, strava); err != nil {
+		return err
+	}
+	if errors.Is(pushErr, integrations.ErrNotAuthorized) {
+		return services.NewError("strava_not_authorized", "run 'greyskull push strava --authorize' first",
+			"Strava isn't authorized yet")
+	}
+	if pushErr != nil {
+		return fmt.Errorf("failed to push to Strava: %w", pushErr)
+	}
+
+	printf(cmd, "Uploaded %s (%s) to %s: %s\n", display.FormatWorkoutDay(&w), w.EnteredAt.Format("2006-01-02"), strava.Name(), url)
+	result.URL = url
+	outputFor(cmd).Result(result)
+	return nil
+}
+
+// authorizeStrava walks the user through granting their Strava application
+// permission to upload activities and stores the tokens it's granted
+func authorizeStrava(cmd *cobra.Command, ctx *services.CommandContext, user *models.User, config *models.Config, strava *integrations.Strava) error {
+	printf(cmd, "Open this address in your browser and authorize the application:\n\n  %s\n\n", strava.AuthorizationURL())
+	printf(cmd, "Your browser will then be sent to a localhost address that doesn't load.\n")
+
+	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+	input, err := inputReader.ReadLine("Paste that address (or just its code): ")
+	if err != nil {
+		return fmt.Errorf("failed to read authorization code: %w", err)
+	}
+	code, err := integrations.ParseAuthorizationCode(input)
+	if err != nil {
+		return err
+	}
+
+	if err := strava.Authorize(code); err != nil {
+		return fmt.Errorf("failed to authorize with Strava: %w", err)
+	}
+	if err := saveStravaTokens(ctx, user, config, strava); err != nil {
+		return err
+	}
+
+	printf(cmd, "Strava authorized. Upload workouts with 'greyskull push strava'.\n")
+	return nil
+}
+
+// saveStravaTokens stores the exporter's tokens if they have changed
+func saveStravaTokens(ctx *services.CommandContext, user *models.User, config *models.Config, strava *integrations.Strava) error {
+	if strava.Config() == config.Strava {
+		return nil
+	}
+	config.Strava = strava.Config()
+	return ctx.Config.Save(user.Username, config)
+}
+
+// workoutToPush finds the most recent workout, or the last one logged on the
+// date given as YYYY-MM-DD
+func workoutToPush(user *models.User, dateInput string) (models.Workout, error) {
+	history := user.History()
+	if len(history) == 0 {
+		return models.Workout{}, fmt.Errorf("no workouts logged yet")
+	}
+	if dateInput == "" {
+		return history[len(history)-1], nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", dateInput, time.Local)
+	if err != nil {
+		return models.Workout{}, fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", dateInput)
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if sameDate(history[i].EnteredAt.In(time.Local), date) {
+			return history[i], nil
+		}
+	}
+	return models.Workout{}, fmt.Errorf("no workout logged on %s", dateInput)
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/integrations"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useFakeStrava points the Strava exporter at a test server, which grants
+// tokens and records the activities uploaded to it
+func useFakeStrava(t *testing.T) *[]url.Values {
+	var uploaded []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_at":4102444800}`))
+		case "/api/v3/activities":
+			uploaded = append(uploaded, r.PostForm)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":987}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	original := newStrava
+	newStrava = func(config models.StravaConfig) *integrations.Strava {
+		strava := original(config)
+		strava.TokenURL = server.URL + "/oauth/token"
+		strava.APIURL = server.URL + "/api/v3"
+		return strava
+	}
+	t.Cleanup(func() { newStrava = original })
+	return &uploaded
+}
+
+func setStravaCredentials(t *testing.T) {
+	_, err := executePiped(t, "", "config", "set", "strava.client_id", "42")
+	require.NoError(t, err)
+	_, err = executePiped(t, "", "config", "set", "strava.client_secret", "secret")
+	require.NoError(t, err)
+}
+
+func TestPushStrava(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	uploaded := useFakeStrava(t)
+	setStravaCredentials(t)
+
+	output, err := executePiped(t, "http://localhost/exchange_token?state=&code=abc&scope=read,activity:write\n",
+		"push", "strava", "--authorize")
+	require.NoError(t, err)
+	assert.Contains(t, output, "client_id=42")
+	assert.Contains(t, output, "Strava authorized.")
+
+	repo, err := repository.NewJSONConfigRepository()
+	require.NoError(t, err)
+	config, err := repo.Get("TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", config.Strava.RefreshToken)
+
+	output, err = executePiped(t, "", "push", "strava")
+	require.NoError(t, err)
+	assert.Equal(t, "Uploaded Day 2 (2024-03-06) to Strava: https://www.strava.com/activities/987\n", output)
+
+	output, err = executePiped(t, "", "push", "strava", "--date", "2024-03-04", "--duration", "90m")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Uploaded Day 1 (2024-03-04)")
+
+	require.Len(t, *uploaded, 2)
+	first := (*uploaded)[1]
+	assert.Equal(t, "OG Greyskull LP: Day 1", first.Get("name"))
+	assert.Equal(t, "2024-03-04T16:30:00", first.Get("start_date_local"))
+	assert.Equal(t, "5400", first.Get("elapsed_time"))
+	assert.Equal(t, "Overhead Press: 95 lbs × 7\nSquat: 135 lbs × 8", first.Get("description"))
+}
+
+func TestPushStrava_DryRun(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	uploaded := useFakeStrava(t)
+	setStravaCredentials(t)
+
+	stdout, _, err := executeJSON(t, "", "push", "strava", "--dry-run")
+	require.NoError(t, err)
+	assert.Empty(t, *uploaded)
+
+	var result pushResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "Strava", result.Service)
+	assert.Equal(t, "OG Greyskull LP: Day 2", result.Name)
+	assert.Equal(t, time.Date(2024, 3, 6, 17, 0, 0, 0, time.Local), result.Start.Local())
+	assert.Equal(t, 3600, result.ElapsedSeconds)
+	assert.True(t, result.DryRun)
+	assert.Empty(t, result.URL)
+}
+
+func TestPushStrava_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+	useFakeStrava(t)
+
+	_, err := executePiped(t, "", "push", "strava")
+	assert.ErrorContains(t, err, "Strava isn't set up")
+
+	setStravaCredentials(t)
+	_, err = executePiped(t, "", "push", "strava")
+	assert.EqualError(t, err, "Strava isn't authorized yet")
+
+	_, err = executePiped(t, "", "push", "strava", "--date", "2024-03-05")
+	assert.EqualError(t, err, "no workout logged on 2024-03-05")
+
+	_, err = executePiped(t, "", "push", "strava", "--date", "March 4")
+	assert.EqualError(t, err, `invalid --date "March 4": expected YYYY-MM-DD`)
+
+	_, err = executePiped(t, "http://localhost/exchange_token?state=&error=access_denied\n", "push", "strava", "--authorize")
+	assert.EqualError(t, err, "authorization failed: access_denied")
+}
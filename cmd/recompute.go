@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var recomputeCmd = &cobra.Command{
+	Use:   "recompute",
+	Short: "Rebuild your weights and next day from your workout history",
+	Long: `Replay your current program's history from its starting weights to rebuild each
+lift's current weight and your next day, and report anything that differs from
+the stored values. This catches weights that drifted after workouts were edited,
+imported, or removed.
+
+Workouts, skipped days, and weight resets, including weights reduced when
+resuming a paused program, are replayed in the order they happened. Lifts held
+during a workout keep their weight, as they did when it was logged. Holds and
+reduced weights from before greyskull recorded them can't be replayed, so lifts
+affected by them may differ for good reason.
+
+With --apply, the stored weights, rep targets, deload streaks, and next day are
+replaced with the recomputed ones. Your data is backed up first.`,
+	Example: "  greyskull recompute\n  greyskull recompute --apply",
+	Args:    cobra.NoArgs,
+	RunE:    runRecompute,
+}
+
+// recomputeResult is the JSON result of 'greyskull recompute'
+type recomputeResult struct {
+	Divergences   []workout.WeightDivergence `json:"divergences"`
+	StoredDay     int                        `json:"stored_day"`
+	RecomputedDay int                        `json:"recomputed_day"`
+	Applied       bool                       `json:"applied"`
+}
+
+func init() {
+	recomputeCmd.Flags().Bool("apply", false, "Replace the stored values with the recomputed ones")
+	rootCmd.AddCommand(recomputeCmd)
+}
+
+func runRecompute(cmd *cobra.Command, args []string) error {
+	apply, err := cmd.Flags().GetBool("apply")
+	if err != nil {
+		return fmt.Errorf("failed to get apply flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	if err := user.LoadHistory(); err != nil {
+		return fmt.Errorf("failed to load workout history: %w", err)
+	}
+
+	result, err := workout.Recompute(user, userProgram, program)
+	if err != nil {
+		return fmt.Errorf("failed to recompute %s: %w", program.Name, err)
+	}
+	rebuilt := result.UserProgram
+	divergences := workout.WeightDivergences(userProgram.CurrentWeights, rebuilt.CurrentWeights)
+	storedDay := userProgram.CurrentDay
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayRecompute(result, divergences, storedDay)
+
+	changed := len(divergences) > 0 || storedDay != rebuilt.CurrentDay
+	applied := apply && changed
+	if applied {
+		if err := backupUser(cmd, ctx, user.Username, "recompute"); err != nil {
+			return err
+		}
+		userProgram.CurrentWeights = rebuilt.CurrentWeights
+		userProgram.CurrentDay = rebuilt.CurrentDay
+		userProgram.RepTargets = rebuilt.RepTargets
+		userProgram.DeloadStreaks = rebuilt.DeloadStreaks
+		if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+		printf(cmd, "\nStored values replaced with the recomputed ones.\n")
+	} else if changed {
+		printf(cmd, "\nRun 'greyskull recompute --apply' to replace the stored values.\n")
+	}
+
+	outputFor(cmd).Result(recomputeResult{
+		Divergences:   nonNil(divergences),
+		StoredDay:     storedDay,
+		RecomputedDay: rebuilt.CurrentDay,
+		Applied:       applied,
+	})
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecompute(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "8\n6\n", "workout", "log")
+	require.NoError(t, err)
+	_, err = executePiped(t, "7\n9\n", "workout", "log")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "recompute")
+	require.NoError(t, err)
+	assert.Equal(t, "Replayed 2 workouts, 0 skipped days, and 0 weight resets.\n"+
+		"Your stored weights and next day match your history.\n", output)
+
+	// Drift the stored values as a hand edit might
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	recomputed := userProgram.CurrentWeights[models.Squat]
+	userProgram.CurrentWeights[models.Squat] = 200
+	userProgram.CurrentDay = 1
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err = executePiped(t, "", "recompute")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Differences from your stored values:\n"+
+		"  Squat: stored 200 lbs, recomputed 140 lbs\n"+
+		"  Next day: stored Day 1, recomputed Day 3\n")
+	assert.Contains(t, output, "Run 'greyskull recompute --apply' to replace the stored values.\n")
+	assert.Equal(t, 200.0, loadTestUser(t).Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+
+	output, err = executePiped(t, "", "recompute", "--apply")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Stored values replaced with the recomputed ones.\n")
+	userProgram = loadTestUser(t).Programs[user.CurrentProgram]
+	assert.Equal(t, recomputed, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 3, userProgram.CurrentDay)
+}
+
+func TestRecompute_HoldsAndResume(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "lift", "hold", "squat", "--sessions", "1")
+	require.NoError(t, err)
+	_, err = executePiped(t, "8\n6\n", "workout", "log")
+	require.NoError(t, err)
+
+	_, err = executePiped(t, "", "program", "pause")
+	require.NoError(t, err)
+	backdatePause(t, 30)
+	_, err = executePiped(t, "", "program", "resume", "--reduce")
+	require.NoError(t, err)
+
+	// Neither the held squat nor the reduced weights count as drift
+	output, err := executePiped(t, "", "recompute")
+	require.NoError(t, err)
+	assert.Equal(t, "Replayed 1 workout, 0 skipped days, and 1 weight reset.\n"+
+		"Your stored weights and next day match your history.\n", output)
+}
+
+func TestJSON_Recompute(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	user.Programs[user.CurrentProgram].CurrentWeights[models.Deadlift] = 205
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	stdout, _, err := executeJSON(t, "", "recompute")
+	require.NoError(t, err)
+
+	var result recomputeResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, recomputeResult{
+		Divergences:   []workout.WeightDivergence{{Lift: models.Deadlift, Stored: 205, Recomputed: 185}},
+		StoredDay:     1,
+		RecomputedDay: 1,
+	}, result)
+}
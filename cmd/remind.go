@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/remind"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "Get reminded on your training days",
+	Long: `Set up reminders on the training days of your current program, as chosen with
+'greyskull workout calendar --days' (Monday, Wednesday, and Friday by default).`,
+}
+
+var remindSetupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Write a calendar file or schedule a reminder job",
+	Long: `Set up reminders at a time of day on each of your training days.
+
+With --ical, write an iCalendar file with a repeating event to import into a
+calendar app. Otherwise, schedule a job that runs 'greyskull remind notify' to
+show a desktop notification: a launchd agent on macOS, or a crontab entry
+elsewhere. Use --cron or --launchd to choose the scheduler yourself.
+
+Setting up reminders again replaces the job scheduled before. Run this again
+after changing your training days.`,
+	Example: `  greyskull remind setup --time 17:30
+  greyskull remind setup --ical greyskull.ics`,
+	Args: cobra.NoArgs,
+	RunE: setupReminders,
+}
+
+var remindNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Show a notification if today is a training day",
+	Long: `Show a desktop notification naming your next workout, if today is one of your
+training days and you haven't logged a workout yet today. Nothing is shown while
+your program is paused. Reminder jobs scheduled by 'greyskull remind setup' run
+this command.`,
+	Args: cobra.NoArgs,
+	RunE: notifyReminder,
+}
+
+// remindRunner runs the scheduler and notification commands; tests replace it
+var remindRunner remind.Runner = remind.ExecRunner
+
+// remindGOOS picks the default scheduler and notifier; tests replace it
+var remindGOOS = runtime.GOOS
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+	remindCmd.AddCommand(remindSetupCmd)
+	remindCmd.AddCommand(remindNotifyCmd)
+
+	remindSetupCmd.Flags().String("time", "18:00", "Time of day to be reminded, as HH:MM")
+	remindSetupCmd.Flags().String("ical", "", "iCalendar file to write instead of scheduling a job")
+	remindSetupCmd.Flags().Bool("cron", false, "Schedule the reminder with cron")
+	remindSetupCmd.Flags().Bool("launchd", false, "Schedule the reminder with launchd")
+	remindSetupCmd.MarkFlagsMutuallyExclusive("ical", "cron", "launchd")
+}
+
+func setupReminders(cmd *cobra.Command, args []string) error {
+	timeInput, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return fmt.Errorf("failed to get time flag: %w", err)
+	}
+	icalPath, err := cmd.Flags().GetString("ical")
+	if err != nil {
+		return fmt.Errorf("failed to get ical flag: %w", err)
+	}
+	useCron, err := cmd.Flags().GetBool("cron")
+	if err != nil {
+		return fmt.Errorf("failed to get cron flag: %w", err)
+	}
+	useLaunchd, err := cmd.Flags().GetBool("launchd")
+	if err != nil {
+		return fmt.Errorf("failed to get launchd flag: %w", err)
+	}
+
+	hour, minute, err := remind.ParseTimeOfDay(timeInput)
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	_, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	schedule := remind.Schedule{Days: userProgram.TrainingDaysOrDefault(), Hour: hour, Minute: minute}
+
+	if icalPath != "" {
+		calendar := remind.ICalendar(schedule, remind.EventUID(userProgram.ID), program.Name+" workout", time.Now())
+		if err := os.WriteFile(icalPath, calendar, 0644); err != nil {
+			return fmt.Errorf("failed to write calendar file: %w", err)
+		}
+		printf(cmd, "Wrote reminders for %s to %s. Import it into your calendar app.\n", schedule, icalPath)
+		return nil
+	}
+
+	if !useCron && !useLaunchd {
+		switch remindGOOS {
+		case "darwin":
+			useLaunchd = true
+		case "windows":
+			return errors.New("scheduled reminders aren't supported on Windows; use --ical to write a calendar file instead")
+		default:
+			useCron = true
+		}
+	}
+
+	job, err := reminderJob()
+	if err != nil {
+		return err
+	}
+
+	if useLaunchd {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to find home directory: %w", err)
+		}
+		path, err := remind.InstallLaunchd(remindRunner, filepath.Join(home, "Library", "LaunchAgents"), schedule, job)
+		if err != nil {
+			return err
+		}
+		printf(cmd, "Scheduled reminders for %s with launchd (%s).\n", schedule, path)
+	} else {
+		if err := remind.InstallCron(remindRunner, schedule, job); err != nil {
+			return err
+		}
+		printf(cmd, "Scheduled reminders for %s with cron.\n", schedule)
+	}
+	printf(cmd, "Run 'greyskull remind setup' again after changing your training days.\n")
+	return nil
+}
+
+// reminderJob returns the command reminder jobs run: this executable's notify
+// command, with the data directory it's using now
+func reminderJob() (remind.Job, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return remind.Job{}, fmt.Errorf("failed to find the greyskull executable: %w", err)
+	}
+
+	job := remind.Job{Args: []string{executable, "remind", "notify"}}
+	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+		job.Env = map[string]string{"XDG_CONFIG_HOME": xdgConfig}
+	}
+	return job, nil
+}
+
+func notifyReminder(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	history := user.HistoryFor(userProgram.ID)
+	trainedToday := len(history) > 0 && workout.DaysSince(history[len(history)-1].EnteredAt, now) == 0
+	if trainedToday || userProgram.ActivePause() != nil || !slices.Contains(userProgram.TrainingDaysOrDefault(), now.Weekday()) {
+		return nil
+	}
+
+	message := i18n.Sprintf("Day %d of %s is up next.", userProgram.CurrentDay, program.Name)
+	if err := remind.Notify(remindRunner, remindGOOS, "Time to train", message); err != nil {
+		// Print the reminder instead, which cron mails to the user
+		printf(cmd, "Time to train: %s\n", message)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/remind"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemindRunner replaces the commands run by the remind commands for the
+// test, recording each one and failing those whose name is in failing
+func fakeRemindRunner(t *testing.T, goos string, failing ...string) *[]string {
+	var calls []string
+	originalRunner, originalGOOS := remindRunner, remindGOOS
+	remindRunner = func(stdin []byte, name string, args ...string) ([]byte, error) {
+		call := strings.Join(append([]string{name}, args...), " ")
+		if stdin != nil {
+			call += " <<< " + string(stdin)
+		}
+		calls = append(calls, call)
+		for _, failure := range failing {
+			if failure == name {
+				return nil, errors.New("exit status 1")
+			}
+		}
+		return nil, nil
+	}
+	remindGOOS = goos
+	t.Cleanup(func() { remindRunner, remindGOOS = originalRunner, originalGOOS })
+	return &calls
+}
+
+func TestRemindSetup_ICal(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	calls := fakeRemindRunner(t, "linux")
+	path := filepath.Join(t.TempDir(), "greyskull.ics")
+
+	output, err := executePiped(t, "", "remind", "setup", "--ical", path, "--time", "6:30")
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote reminders for Mon, Wed, Fri at 06:30 to "+path+". Import it into your calendar app.\n", output)
+	assert.Empty(t, *calls)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR\r\n")
+	assert.Contains(t, string(data), "T063000\r\n")
+}
+
+func TestRemindSetup_Cron(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	calls := fakeRemindRunner(t, "linux")
+
+	_, err := executePiped(t, "", "workout", "calendar", "--days", "tue,thu")
+	require.NoError(t, err)
+	output, err := executePiped(t, "", "remind", "setup")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Scheduled reminders for Tue, Thu at 18:00 with cron.\n")
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "crontab -l", (*calls)[0])
+	assert.Contains(t, (*calls)[1], "crontab - <<< 0 18 * * 2,4 XDG_CONFIG_HOME=")
+	assert.Contains(t, (*calls)[1], " remind notify # greyskull reminder\n")
+}
+
+func TestRemindSetup_Launchd(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	calls := fakeRemindRunner(t, "darwin")
+
+	output, err := executePiped(t, "", "remind", "setup")
+	require.NoError(t, err)
+
+	path := filepath.Join(home, "Library", "LaunchAgents", remind.LaunchdLabel+".plist")
+	assert.Contains(t, output, "with launchd ("+path+")")
+	assert.FileExists(t, path)
+	assert.Equal(t, []string{"launchctl unload " + path, "launchctl load " + path}, *calls)
+}
+
+func TestRemindSetup_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	fakeRemindRunner(t, "windows")
+
+	_, err := executePiped(t, "", "remind", "setup")
+	assert.ErrorContains(t, err, "use --ical to write a calendar file instead")
+
+	_, err = executePiped(t, "", "remind", "setup", "--time", "25:00")
+	assert.EqualError(t, err, `invalid time "25:00": expected HH:MM, e.g. 18:00`)
+
+	_, err = executePiped(t, "", "remind", "setup", "--cron", "--launchd")
+	assert.Error(t, err)
+}
+
+func TestRemindNotify(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].TrainingDays = []time.Weekday{time.Now().Weekday()}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	calls := fakeRemindRunner(t, "linux")
+	output, err := executePiped(t, "", "remind", "notify")
+	require.NoError(t, err)
+	assert.Empty(t, output)
+	require.Len(t, *calls, 1)
+	assert.True(t, strings.HasPrefix((*calls)[0], "notify-send Time to train Day 1 of "), (*calls)[0])
+
+	t.Run("prints the reminder when notifications fail", func(t *testing.T) {
+		fakeRemindRunner(t, "linux", "notify-send")
+		output, err := executePiped(t, "", "remind", "notify")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output, "Time to train: Day 1 of "), output)
+	})
+
+	t.Run("stays quiet once today's workout is logged", func(t *testing.T) {
+		_, err := executePiped(t, "7\n8\n", "workout", "log")
+		require.NoError(t, err)
+
+		calls := fakeRemindRunner(t, "linux")
+		output, err := executePiped(t, "", "remind", "notify")
+		require.NoError(t, err)
+		assert.Empty(t, output)
+		assert.Empty(t, *calls)
+	})
+}
+
+func TestRemindNotify_RestDay(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].TrainingDays = []time.Weekday{(time.Now().Weekday() + 1) % 7}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	calls := fakeRemindRunner(t, "linux")
+	_, err = executePiped(t, "", "remind", "notify")
+	require.NoError(t, err)
+	assert.Empty(t, *calls)
+}
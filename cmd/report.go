@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write a training log to share",
+	Long: `Write your training log as Markdown or HTML, ready to post to a blog or forum
+thread. Each month opens with a summary of its training, followed by a table of
+each workout's lifts, the personal records it broke, and its notes.
+
+The whole history is included unless --month picks a single month.`,
+	Example: `  greyskull report > log.md
+  greyskull report --format html --month 2024-05 > may.html`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+// reportMonthFormat is the format of the --month flag
+const reportMonthFormat = "2006-01"
+
+func init() {
+	reportCmd.Flags().String("format", string(display.ReportMarkdown), "Report format: md or html")
+	reportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{string(display.ReportMarkdown), string(display.ReportHTML)}, cobra.ShellCompDirectiveNoFileComp))
+	reportCmd.Flags().String("month", "", "Only include this month (YYYY-MM)")
+	addIncludeArchivedFlag(reportCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	formatInput, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+	format, err := display.ParseReportFormat(formatInput)
+	if err != nil {
+		return err
+	}
+	monthInput, err := cmd.Flags().GetString("month")
+	if err != nil {
+		return fmt.Errorf("failed to get month flag: %w", err)
+	}
+
+	var from, to time.Time
+	if monthInput != "" {
+		from, err = time.ParseInLocation(reportMonthFormat, monthInput, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --month %q: expected YYYY-MM", monthInput)
+		}
+		to = from.AddDate(0, 1, 0)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	user, err = historyUser(cmd, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	report := display.Report{Username: user.Username}
+	// Records are tracked across the whole history, so a month's PRs are
+	// measured against every workout before it
+	existing := make(map[models.LiftName]*records.LiftRecords)
+	for _, w := range user.History() {
+		achievements := records.Broken(existing, &w)
+		if (!from.IsZero() && w.EnteredAt.Before(from)) || (!to.IsZero() && !w.EnteredAt.Before(to)) {
+			continue
+		}
+		report.Workouts = append(report.Workouts, display.ReportWorkout{Workout: w, Achievements: achievements})
+	}
+
+	unit := user.Unit
+	if userProgram, exists := user.Programs[user.CurrentProgram]; exists {
+		unit = userProgram.Unit
+	}
+
+	formatter := display.NewReportFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.SetWeightFormat(weightFormat(config, unit))
+	return formatter.DisplayReport(report, format)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "report")
+	require.NoError(t, err)
+	assert.Contains(t, output, "# Training log: TestUser\n\n## March 2024\n")
+	assert.Contains(t, output, "### 2024-03-04: Day 1\n")
+	assert.Contains(t, output, "| Squat | 135 lbs | 8 |\n")
+	assert.Contains(t, output, "### 2024-03-06: Day 2\n")
+
+	output, err = executePiped(t, "", "report", "--format", "html", "--month", "2024-04")
+	require.NoError(t, err)
+	assert.Contains(t, output, "<!DOCTYPE html>")
+	assert.Contains(t, output, "<p>No workouts logged.</p>")
+}
+
+func TestReport_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	_, err := executePiped(t, "", "report", "--format", "pdf")
+	assert.EqualError(t, err, `unknown report format "pdf" (expected md or html)`)
+
+	_, err = executePiped(t, "", "report", "--month", "May")
+	assert.EqualError(t, err, `invalid --month "May": expected YYYY-MM`)
+}
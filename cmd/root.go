@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +16,10 @@ var rootCmd = &cobra.Command{
 It helps you manage users, track workout programs, log completed workouts, and automatically 
 calculate weight progressions based on your AMRAP performance.`,
 	Version: "0.1.0",
+	PersistentPreRunE: prepareCommand,
+	PersistentPostRunE: flushOutput,
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Show help when no subcommand is provided
 		cmd.Help()
@@ -19,11 +28,57 @@ calculate weight progressions based on your AMRAP performance.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Errors are shown by reportError rather than cobra, so they get their hints,
+// and a command's usage is only shown after a mistake in its flags or arguments.
 func Execute() error {
-	return rootCmd.Execute()
+	markArgErrors.Do(func() { markUsageErrors(rootCmd) })
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		reportError(cmd, err, rootCmd.OutOrStdout(), rootCmd.ErrOrStderr())
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprint(rootCmd.ErrOrStderr(), cmd.UsageString())
+		}
+	}
+	return err
+}
+
+// prepareCommand runs before every command. It sets up the command's output,
+// the user it acts as, and the name its changes are audited under, offers to
+// migrate existing data before a command creates an empty store, then registers
+// custom lifts so lift arguments can name them before the command loads
+// anything else, and picks the locale its messages are shown in.
+func prepareCommand(cmd *cobra.Command, args []string) error {
+	if err := setupOutput(cmd); err != nil {
+		return err
+	}
+	services.SetAuditCommand(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+
+	// Tab completion can't answer the migration prompt, so only offer it to
+	// commands run directly
+	if !isCompletionRequest(cmd) {
+		if err := offerMigration(cmd, args); err != nil {
+			return err
+		}
+	}
+	if _, err := services.RegisterCustomLifts(services.GetDefaultRepositoryFactory()); err != nil {
+		return err
+	}
+	services.SelectLocale(contextFor(cmd), services.GetDefaultRepositoryFactory(), userOverride(cmd))
+	return nil
 }
 
 func init() {
+	rootCmd.PersistentFlags().Bool("json", false, "Print results as JSON on stdout, and everything else on stderr")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Print only prompts, warnings, and summaries")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Also print debug detail, such as files written and how weights were calculated")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().String("user", "", "Act as this user for this command only, instead of the current user")
+	rootCmd.RegisterFlagCompletionFunc("user", completeUsernames)
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+
 	// Add child commands
 	rootCmd.AddCommand(userCmd)
 }
\ No newline at end of file
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// addScopeFlags adds the flags that choose what a stats command reports on to
+// flags: the program, archived workouts, and widening beyond the program. The
+// stats command adds them as persistent flags so every subcommand shares them.
+func addScopeFlags(flags *pflag.FlagSet) {
+	flags.String("program", "", "Active program to report on, by index, ID, or name (defaults to the current program)")
+	flags.Bool("include-archived", false, "Include archived workouts")
+	flags.Bool("all-time", false, "Include workouts from before the program started")
+	flags.Bool("all-lifts", false, "Include lifts that aren't in the program")
+}
+
+// statsScope is what a stats command reports on: the current user, the active
+// program picked by --program or their current program, and their history
+// narrowed to that program
+type statsScope struct {
+	User        *models.User
+	UserProgram *models.UserProgram // nil when the user has no active program
+	Program     *models.Program
+	History     []models.Workout
+
+	// Note describes what was left out of History and how to include it, or is
+	// empty when nothing was
+	Note string
+}
+
+// resolveScope loads what a stats command reports on from the scope flags.
+// History is the user's sorted history, including archived workouts with
+// --include-archived, limited to the program's lifts and the time since it
+// started unless widened by --all-time or --all-lifts. Users without an active
+// program see their whole history.
+func resolveScope(cmd *cobra.Command, ctx *services.CommandContext) (*statsScope, error) {
+	ref, err := cmd.Flags().GetString("program")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program flag: %w", err)
+	}
+	allTime, err := cmd.Flags().GetBool("all-time")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all-time flag: %w", err)
+	}
+	allLifts, err := cmd.Flags().GetBool("all-lifts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all-lifts flag: %w", err)
+	}
+
+	scope := &statsScope{}
+	scope.User, err = ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return nil, err
+	}
+	if scope.User.CurrentProgram != uuid.Nil || ref != "" {
+		scope.User, scope.UserProgram, scope.Program, err = ctx.UserService.GetCurrentUserWithActiveProgram(contextFor(cmd), ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scope.User, err = historyUser(cmd, ctx, scope.User)
+	if err != nil {
+		return nil, err
+	}
+
+	scope.History = scope.User.History()
+	if (allTime && allLifts) || scope.UserProgram == nil {
+		return scope, nil
+	}
+
+	var narrow analytics.Scope
+	if !allLifts {
+		narrow.Lifts = scope.Program.WeightKeys()
+	}
+	if !allTime {
+		narrow.Since = scope.UserProgram.StartedAt
+	}
+
+	var narrowed bool
+	scope.History, narrowed = narrow.Apply(scope.History)
+	if narrowed {
+		scope.Note = scopeNote(display.FormatProgramName(scope.UserProgram, scope.Program), narrow)
+	}
+	return scope, nil
+}
+
+// scopeNote describes how a stats command narrowed the history to a program's
+// lifts, the time since it started, or both, and how to include the rest
+func scopeNote(programName string, narrow analytics.Scope) string {
+	since := narrow.Since.Format("2006-01-02")
+	switch {
+	case narrow.Lifts == nil:
+		return i18n.Sprintf("Showing workouts since %s. Use --all-time to include the rest of your history.", since)
+	case narrow.Since.IsZero():
+		return i18n.Sprintf("Showing %s lifts. Use --all-lifts to include the rest of your history.", programName)
+	default:
+		return i18n.Sprintf("Showing %s lifts since %s. Use --all-lifts or --all-time to include the rest of your history.",
+			programName, since)
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	greyskullv1 "github.com/mikowitz/greyskull/api/greyskull/v1"
+	"github.com/mikowitz/greyskull/server"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the greyskull API over gRPC",
+	Long: `Serve GreyskullService, defined in api/greyskull/v1/greyskull.proto, so a
+companion app such as a mobile client can list users and programs, calculate
+the next workout, and log workouts with the same data and logic as the CLI.
+
+Requests naming no user act as the current user, or the user given with --user.
+Workouts logged through the API don't run post_log hooks. The server runs until
+interrupted.
+
+The API has no authentication, so it only listens on localhost unless --addr
+says otherwise.`,
+	Example: "  greyskull serve\n  greyskull serve --addr :50051",
+	Args:    cobra.NoArgs,
+	RunE:    serve,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", "localhost:50051", "Address to listen on, as host:port")
+}
+
+func serve(cmd *cobra.Command, args []string) error {
+	addr, err := cmd.Flags().GetString("addr")
+	if err != nil {
+		return fmt.Errorf("failed to get addr flag: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	ctx, stop := signal.NotifyContext(contextFor(cmd), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serveAPI(ctx, cmd, listener)
+}
+
+// serveAPI serves GreyskullService on listener until ctx is done, then stops
+// once the requests in progress finish
+func serveAPI(ctx context.Context, cmd *cobra.Command, listener net.Listener) error {
+	commandCtx, err := newCommandContext(cmd)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+	greyskullv1.RegisterGreyskullServiceServer(grpcServer, server.New(commandCtx))
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			grpcServer.GracefulStop()
+		case <-done:
+		}
+	}()
+
+	printf(cmd, "Serving the greyskull API on %s\n", listener.Addr())
+	if err := grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	greyskullv1 "github.com/mikowitz/greyskull/api/greyskull/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestServe(t *testing.T) {
+	setupTestEnv(t)
+	_, err := executePiped(t, "Piper\n", "user", "create")
+	require.NoError(t, err)
+	_, err = executePiped(t, "1\n135\n185\n125\n95\n", "program", "start")
+	require.NoError(t, err)
+
+	resetCommands(rootCmd)
+	t.Cleanup(func() { resetCommands(rootCmd) })
+	var buf bytes.Buffer
+	serveCmd.SetOut(&buf)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- serveAPI(ctx, serveCmd, listener) }()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := greyskullv1.NewGreyskullServiceClient(conn)
+
+	user, err := client.GetUser(t.Context(), &greyskullv1.GetUserRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "Piper", user.GetUsername())
+
+	next, err := client.GetNextWorkout(t.Context(), &greyskullv1.GetNextWorkoutRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), next.GetDay())
+
+	cancel()
+	require.NoError(t, <-served)
+	assert.Equal(t, "Serving the greyskull API on "+listener.Addr().String()+"\n", buf.String())
+}
+
+func TestServe_InvalidAddr(t *testing.T) {
+	setupTestEnv(t)
+
+	_, err := executePiped(t, "", "serve", "--addr", "not-an-address")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to listen on not-an-address")
+}
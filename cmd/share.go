@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/spf13/cobra"
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Share your recent workouts with a coach",
+	Long: `Print your most recent workouts as a compact block to paste into a message to a
+coach or a forum post: each lift's working weight and reps, with AMRAP sets
+marked "+", and the trend of any body weights recorded with
+'workout log --bodyweight'.
+
+Nothing identifying you is included: no username, notes, or IDs. Use
+--format md for Markdown, ready for a gist or forum that renders it.`,
+	Example: `  greyskull share
+  greyskull share --last 8 --format md > recent.md`,
+	Args: cobra.NoArgs,
+	RunE: runShare,
+}
+
+func init() {
+	shareCmd.Flags().Int("last", 4, "Number of recent workouts to share")
+	shareCmd.Flags().String("format", string(display.ShareText), "Share format: text or md")
+	shareCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{string(display.ShareText), string(display.ShareMarkdown)}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(shareCmd)
+}
+
+func runShare(cmd *cobra.Command, args []string) error {
+	last, err := cmd.Flags().GetInt("last")
+	if err != nil {
+		return fmt.Errorf("failed to get last flag: %w", err)
+	}
+	if last <= 0 {
+		return fmt.Errorf("last must be positive, got: %d", last)
+	}
+	formatInput, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+	format, err := display.ParseShareFormat(formatInput)
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	history := user.History()
+	if len(history) > last {
+		history = history[len(history)-last:]
+	}
+
+	unit := user.Unit
+	if userProgram, exists := user.Programs[user.CurrentProgram]; exists {
+		unit = userProgram.Unit
+	}
+
+	formatter := display.NewShareFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.SetWeightFormat(weightFormat(config, unit))
+	formatter.DisplayShare(history, format)
+	outputFor(cmd).Result(display.ShareTraining(history, unit))
+	return nil
+}
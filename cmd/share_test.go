@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShare(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "share")
+	require.NoError(t, err)
+	assert.Equal(t, "Recent training: 2 workouts\n\n"+
+		"2024-03-04 · Day 1\n  Overhead Press 95 lbs: 7+\n  Squat 135 lbs: 8+\n\n"+
+		"2024-03-06 · Day 2\n  Bench Press 125 lbs: 6+\n  Deadlift 185 lbs: 5+\n\n", output)
+	assert.NotContains(t, output, "TestUser")
+
+	output, err = executePiped(t, "", "share", "--last", "1", "--format", "md")
+	require.NoError(t, err)
+	assert.Equal(t, "### Recent training: 1 workout\n\n"+
+		"**2024-03-06 · Day 2**\n\n- Bench Press 125 lbs: 6+\n- Deadlift 185 lbs: 5+\n\n", output)
+
+	_, err = executePiped(t, "", "share", "--last", "0")
+	assert.EqualError(t, err, "last must be positive, got: 0")
+	_, err = executePiped(t, "", "share", "--format", "pdf")
+	assert.EqualError(t, err, `unknown share format "pdf" (expected text or md)`)
+}
+
+func TestShare_BodyWeight(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "8\n6\n", "workout", "log", "--bodyweight", "182")
+	require.NoError(t, err)
+	_, err = executePiped(t, "8\n6\n", "workout", "log", "--bodyweight", "180.5")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "share")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Body weight: 182 → 180.5 lbs (-1.5 over 2 weigh-ins)\n")
+
+	output, err = executePiped(t, "", "share", "--format", "md")
+	require.NoError(t, err)
+	assert.Contains(t, output, "**Body weight:** 182 → 180.5 lbs (-1.5 over 2 weigh-ins)\n")
+
+	stdout, _, err := executeJSON(t, "", "share")
+	require.NoError(t, err)
+	var result display.SharedTraining
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, []float64{182, 180.5}, result.BodyWeights)
+
+	_, err = executePiped(t, "8\n6\n", "workout", "log", "--bodyweight", "-1")
+	assert.EqualError(t, err, "bodyweight must not be negative, got: -1")
+}
+
+func TestShare_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	stdout, _, err := executeJSON(t, "", "share", "--last", "1")
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "TestUser")
+
+	var result display.SharedTraining
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, display.SharedTraining{
+		Unit: models.Pounds,
+		Workouts: []display.SharedWorkout{{
+			Date: "2024-03-06",
+			Day:  "Day 2",
+			Lifts: []display.SharedLift{
+				{Name: "Bench Press", Weight: 125, Sets: []display.SharedSet{{Reps: 6, AMRAP: true}}},
+				{Name: "Deadlift", Weight: 185, Sets: []display.SharedSet{{Reps: 5, AMRAP: true}}},
+			},
+		}},
+		BodyWeights: []float64{},
+	}, result)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mikowitz/greyskull/chart"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Project your weights weeks ahead",
+	Long: `Project the working weight of each lift in your current program a number of
+weeks ahead, running the program's progression rules over every scheduled
+training day. Nothing is saved.
+
+By default every AMRAP set reaches its target. Use --amrap-avg to assume an
+average number of AMRAP reps instead, and --deload-chance to give each AMRAP
+set a chance of falling short and deloading the lift. Random choices follow
+--seed, so the same seed gives the same projection.
+
+Use --out to also save a chart of the projection as an SVG or PNG image.`,
+	Example: `  greyskull simulate --weeks 12
+  greyskull simulate --weeks 12 --amrap-avg 7 --deload-chance 10
+  greyskull simulate --weeks 26 --out projection.svg`,
+	Args: cobra.NoArgs,
+	RunE: runSimulation,
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+	simulateCmd.Flags().Int("weeks", 12, "Number of weeks to project")
+	simulateCmd.Flags().Float64("amrap-avg", 0, "Average AMRAP reps (0 reaches each set's target)")
+	simulateCmd.Flags().Float64("deload-chance", 0, "Percentage chance that an AMRAP set falls short")
+	simulateCmd.Flags().Int64("seed", 1, "Seed for the random choices")
+	simulateCmd.Flags().StringP("out", "o", "", "Image file to chart the projection to (.svg or .png)")
+}
+
+func runSimulation(cmd *cobra.Command, args []string) error {
+	weeks, err := cmd.Flags().GetInt("weeks")
+	if err != nil {
+		return fmt.Errorf("failed to get weeks flag: %w", err)
+	}
+	if weeks <= 0 {
+		return fmt.Errorf("weeks must be positive, got: %d", weeks)
+	}
+	amrapAverage, err := cmd.Flags().GetFloat64("amrap-avg")
+	if err != nil {
+		return fmt.Errorf("failed to get amrap-avg flag: %w", err)
+	}
+	if amrapAverage < 0 {
+		return fmt.Errorf("amrap-avg must not be negative, got: %g", amrapAverage)
+	}
+	deloadChance, err := cmd.Flags().GetFloat64("deload-chance")
+	if err != nil {
+		return fmt.Errorf("failed to get deload-chance flag: %w", err)
+	}
+	if deloadChance < 0 || deloadChance > 100 {
+		return fmt.Errorf("deload-chance must be between 0 and 100, got: %g", deloadChance)
+	}
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return fmt.Errorf("failed to get seed flag: %w", err)
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+	var renderer chart.Renderer
+	if outPath != "" {
+		if renderer, err = chart.RendererFor(outPath); err != nil {
+			return err
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	// Simulate a session on every upcoming training day
+	now := time.Now()
+	var dates []time.Time
+	for _, entry := range workout.Calendar(userProgram, workout.LastTrainedAt(user, userProgram), len(program.Workouts), now, weeks) {
+		if !entry.Missed {
+			dates = append(dates, entry.Date)
+		}
+	}
+
+	assumptions := workout.SimulationAssumptions{AMRAPAverage: amrapAverage, DeloadChance: deloadChance / 100, Seed: seed}
+	projection, err := workout.ProjectWeights(user, program, config, dates, assumptions)
+	if err != nil {
+		return fmt.Errorf("failed to simulate: %w", err)
+	}
+
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	formatter := display.NewProgramFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayProjection(projection, start)
+	outputFor(cmd).Result(projection)
+
+	if renderer == nil {
+		return nil
+	}
+	c := &chart.Chart{Title: i18n.Sprintf("Projected Weights: %d Weeks", weeks)}
+	for _, key := range resetWeightKeys(program, projection.Start) {
+		c.Series = append(c.Series, chart.ProjectionSeries(projection, start, key, display.FormatLiftName(key)))
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create chart file: %w", err)
+	}
+	err = renderer.Render(file, c)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	printf(cmd, "\nSaved projection chart to %s\n", outPath)
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "simulate", "--weeks", "4")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Week 1: ")
+	assert.Contains(t, output, "Week 4: ")
+	assert.NotContains(t, output, "Week 5")
+	assert.Contains(t, output, "\nAfter 4 weeks (")
+	assert.Contains(t, output, "  Overhead Press: 95 → ")
+
+	user := loadTestUser(t)
+	assert.Equal(t, 1, user.Programs[user.CurrentProgram].CurrentDay)
+	assert.Equal(t, 95.0, user.Programs[user.CurrentProgram].CurrentWeights[models.OverheadPress])
+}
+
+func TestSimulate_Assumptions(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	first, err := executePiped(t, "", "simulate", "--amrap-avg", "7", "--deload-chance", "20", "--seed", "3")
+	require.NoError(t, err)
+	second, err := executePiped(t, "", "simulate", "--amrap-avg", "7", "--deload-chance", "20", "--seed", "3")
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "the same seed gives the same projection")
+
+	_, err = executePiped(t, "", "simulate", "--weeks", "0")
+	assert.EqualError(t, err, "weeks must be positive, got: 0")
+	_, err = executePiped(t, "", "simulate", "--deload-chance", "150")
+	assert.EqualError(t, err, "deload-chance must be between 0 and 100, got: 150")
+}
+
+func TestSimulate_Chart(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	out := filepath.Join(t.TempDir(), "projection.svg")
+	output, err := executePiped(t, "", "simulate", "--weeks", "2", "--out", out)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Saved projection chart to "+out)
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(contents), "<svg"))
+	assert.Contains(t, string(contents), "Squat")
+}
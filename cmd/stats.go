@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "View training statistics",
+	Long: `Summarize your workout history: how often you train, total tonnage, and for each
+lift the starting and latest AMRAP weight, number of sessions, average AMRAP reps,
+deloads, and tonnage. Tonnage includes warmup sets. Progress toward any goals set
+with 'greyskull goal set' follows the summary.
+
+With an active program, only its lifts since the program started are included.
+Use --all-time and --all-lifts to widen the summary to the rest of your history,
+and --program to report on another active program. These flags apply to every
+stats subcommand.
+
+Subcommands provide other views, such as charts of each lift's progression,
+lifts that have stalled, weekly volume, and training streaks.`,
+	Args: cobra.NoArgs,
+	RunE: showStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsChartCmd)
+	statsCmd.AddCommand(statsStallsCmd)
+	statsCmd.AddCommand(statsVolumeCmd)
+	statsCmd.AddCommand(statsConsistencyCmd)
+	addScopeFlags(statsCmd.PersistentFlags())
+}
+
+func showStats(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(scope.User.Username)
+	if err != nil {
+		return err
+	}
+
+	formatter := display.NewStatsFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	unit := models.Pounds
+	if scope.UserProgram != nil {
+		unit = scope.UserProgram.Unit
+	}
+	formatter.SetWeightFormat(weightFormat(config, unit))
+	summary := analytics.Summarize(scope.History)
+	formatter.DisplaySummary(summary)
+	goals := []analytics.GoalProgress{}
+	if userProgram := scope.UserProgram; userProgram != nil && len(userProgram.Goals) > 0 {
+		goals = analytics.Goals(userProgram, scope.User.HistoryFor(userProgram.ID), time.Now())
+		printf(cmd, "\n")
+		goalFormatter := display.NewGoalFormatter(cmd.OutOrStdout())
+		goalFormatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+		goalFormatter.DisplayGoals(goals)
+	}
+	outputFor(cmd).Result(struct {
+		Summary *analytics.Summary       `json:"summary"`
+		Goals   []analytics.GoalProgress `json:"goals"`
+	}{summary, goals})
+	if scope.Note != "" {
+		printf(cmd, "\n%s\n", scope.Note)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mikowitz/greyskull/chart"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+var statsChartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Export a lift's progression chart as an SVG or PNG image",
+	Long: `Export a chart of a lift's working weight over time, taken from the AMRAP set
+of each logged workout. Variants of the lift are drawn as separate lines.
+
+The image format is chosen by the --out file extension: .svg or .png. PNG charts
+include axis values but no title or legend.
+
+With an active program, only workouts since the program started are charted.
+Use --all-time to chart the rest of your history, and --all-lifts to chart a
+lift or variant the program doesn't use.`,
+	Example: "  greyskull stats chart --lift squat --out squat.svg",
+	Args:    cobra.NoArgs,
+	RunE:    exportChart,
+}
+
+func init() {
+	statsChartCmd.Flags().String("lift", "", "Lift to chart (squat, deadlift, bench, ohp)")
+	statsChartCmd.RegisterFlagCompletionFunc("lift", completeLiftNames)
+	statsChartCmd.Flags().StringP("out", "o", "", "Image file to write (.svg or .png)")
+	statsChartCmd.MarkFlagRequired("lift")
+	statsChartCmd.MarkFlagRequired("out")
+}
+
+func exportChart(cmd *cobra.Command, args []string) error {
+	liftInput, err := cmd.Flags().GetString("lift")
+	if err != nil {
+		return fmt.Errorf("failed to get lift flag: %w", err)
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+
+	lift, err := models.ParseLiftName(liftInput)
+	if err != nil {
+		return err
+	}
+	renderer, err := chart.RendererFor(outPath)
+	if err != nil {
+		return err
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	history, note := scope.History, scope.Note
+
+	c := &chart.Chart{Title: display.FormatLiftName(lift) + " Progression"}
+	for _, key := range liftWeightKeys(history, lift) {
+		c.Series = append(c.Series, chart.LiftSeries(history, key, display.FormatLiftName(key)))
+	}
+	if len(c.Series) == 0 {
+		if note != "" {
+			return fmt.Errorf("no %s workouts in scope. %s", display.FormatLiftName(lift), note)
+		}
+		return fmt.Errorf("no %s workouts logged yet", display.FormatLiftName(lift))
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create chart file: %w", err)
+	}
+	err = renderer.Render(file, c)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+
+	printf(cmd, "Saved %s chart to %s\n", display.FormatLiftName(lift), outPath)
+	if note != "" {
+		cmd.Println(note)
+	}
+	return nil
+}
+
+// liftWeightKeys returns the weight keys of a lift that appear in history:
+// the base lift first, followed by its variants in name order
+func liftWeightKeys(history []models.Workout, lift models.LiftName) []models.LiftName {
+	var keys []models.LiftName
+	for _, workout := range history {
+		for _, exercise := range workout.Exercises {
+			if !exercise.Optional && exercise.LiftName == lift && !slices.Contains(keys, exercise.WeightKey()) {
+				keys = append(keys, exercise.WeightKey())
+			}
+		}
+	}
+	slices.SortFunc(keys, func(a, b models.LiftName) int {
+		switch {
+		case a == lift:
+			return -1
+		case b == lift:
+			return 1
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+	return keys
+}
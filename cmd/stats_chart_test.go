@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runStatsChart(t *testing.T, lift, out string) (string, error) {
+	return executePiped(t, "", "stats", "chart", "--lift", lift, "--out", out)
+}
+
+func TestStatsChart(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	for _, name := range []string{"squat.svg", "squat.png"} {
+		t.Run(name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), name)
+
+			output, err := runStatsChart(t, "squat", out)
+			require.NoError(t, err)
+			assert.Equal(t, "Saved Squat chart to "+out+"\n", output)
+
+			info, err := os.Stat(out)
+			require.NoError(t, err)
+			assert.Positive(t, info.Size())
+		})
+	}
+
+	out := filepath.Join(t.TempDir(), "bench.svg")
+	_, err := runStatsChart(t, "bench", out)
+	require.NoError(t, err)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), ">Bench Press Progression</text>")
+}
+
+func TestStatsChart_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	dir := t.TempDir()
+
+	_, err := runStatsChart(t, "squat", filepath.Join(dir, "squat.gif"))
+	assert.ErrorContains(t, err, `unsupported chart format ".gif"`)
+
+	_, err = runStatsChart(t, "curl", filepath.Join(dir, "curl.svg"))
+	assert.ErrorContains(t, err, `unknown lift "curl"`)
+
+	_, err = runStatsChart(t, "squat", filepath.Join(dir, "squat.svg"))
+	assert.EqualError(t, err, "no Squat workouts logged yet")
+	assert.NoFileExists(t, filepath.Join(dir, "squat.svg"))
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/spf13/cobra"
+)
+
+var statsConsistencyCmd = &cobra.Command{
+	Use:   "consistency",
+	Short: "Show your weekly training streaks",
+	Long: fmt.Sprintf(`Show how consistently you train: your current and longest streaks of weeks
+reaching your target number of sessions, this week's sessions so far, and the
+sessions in the last %d days. Weeks start on Monday, and a week in progress
+doesn't end a streak until it's over.
+
+The target is one session per training day of the program unless set with
+'greyskull config set weekly_target <sessions>'. With an active program, only
+workouts since it started count. Use --all-time to count the rest of your
+history, and --all-lifts to also count workouts of lifts it doesn't use.`, analytics.ConsistencyWindow),
+	Example: `  greyskull stats consistency
+  greyskull config set weekly_target 2`,
+	Args: cobra.NoArgs,
+	RunE: showConsistency,
+}
+
+func showConsistency(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(scope.User.Username)
+	if err != nil {
+		return err
+	}
+
+	consistency := analytics.MeasureConsistency(scope.History, config.WeeklyTargetFor(scope.UserProgram), time.Now())
+	display.NewStatsFormatter(cmd.OutOrStdout()).DisplayConsistency(consistency)
+	outputFor(cmd).Result(consistency)
+	if scope.Note != "" {
+		printf(cmd, "\n%s\n", scope.Note)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsConsistency(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	// Three sessions in each of the last two weeks, and two the week before
+	lastWeek := analytics.WeekStart(time.Now()).AddDate(0, 0, -7)
+	user.Programs[user.CurrentProgram].StartedAt = lastWeek.AddDate(0, 0, -14)
+	for _, day := range []int{-7, -5, 0, 2, 4, 7, 9, 11} {
+		user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{
+			ID: uuid.New(), UserProgramID: user.CurrentProgram, Day: 1, EnteredAt: lastWeek.AddDate(0, 0, day-7).Add(18 * time.Hour),
+			Exercises: []models.Lift{{ID: uuid.New(), LiftName: models.Squat}},
+		})
+	}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err := executePiped(t, "", "stats", "consistency")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Consistency (target: 3 sessions a week):\n")
+	assert.Contains(t, output, "  Current streak: 2 weeks\n")
+	assert.Contains(t, output, "  Longest streak: 2 weeks\n")
+	assert.Contains(t, output, "  This week: 0 of 3 sessions\n")
+
+	// A lower target counts the lighter week too
+	_, err = executePiped(t, "", "config", "set", "weekly_target", "2")
+	require.NoError(t, err)
+	output, err = executePiped(t, "", "stats", "consistency")
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Current streak: 3 weeks\n")
+
+	_, err = executePiped(t, "", "config", "set", "weekly_target", "8")
+	assert.ErrorContains(t, err, "weekly target must be a whole number of sessions from 1 to 7")
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+var statsStallsCmd = &cobra.Command{
+	Use:   "stalls",
+	Short: "List lifts that keep deloading",
+	Long: fmt.Sprintf(`List the lifts in your current program that have deloaded and not yet got back
+past the weight they deloaded from, with how many times each has deloaded in a row.
+
+A lift that has deloaded %d times in a row is stalled, and 'greyskull workout log'
+warns about it. Restarting the program or switching the lift to a different rep
+range usually gets it moving again. Use --program to list the stalls of another
+active program.`, analytics.StallThreshold),
+	Args: cobra.NoArgs,
+	RunE: showStalls,
+}
+
+func showStalls(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	userProgram := scope.UserProgram
+	if userProgram == nil {
+		return services.ErrNoActiveProgram
+	}
+
+	stalls := analytics.Stalls(userProgram)
+	config, err := ctx.Config.Load(scope.User.Username)
+	if err != nil {
+		return err
+	}
+
+	formatter := display.NewStatsFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayStalls(stalls)
+	outputFor(cmd).Result(nonNil(stalls))
+	return nil
+}
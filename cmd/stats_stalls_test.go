@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsStalls(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "stats", "stalls")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No stalled lifts.")
+
+	user.Programs[user.CurrentProgram].DeloadStreaks = map[models.LiftName]models.DeloadStreak{
+		models.Squat:      {Deloads: 3, Weight: 150},
+		models.BenchPress: {Deloads: 1, Weight: 135},
+	}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err = executePiped(t, "", "stats", "stalls")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Squat: 3 deloads, stuck below 150 lbs (now 135 lbs) - stalled\n")
+	assert.Contains(t, output, "Bench Press: 1 deload, stuck below 135 lbs (now 125 lbs)\n")
+	assert.Less(t, strings.Index(output, "Squat"), strings.Index(output, "Bench Press"))
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workouts: 2 from 2024-03-04 to 2024-03-06 (2.0 per week)")
+	assert.Contains(t, output, "Total tonnage: 3,420 lbs")
+	assert.Contains(t, output, "Squat:\n  Weight: 135 → 135 lbs (±0)\n  Sessions: 1, average AMRAP reps: 8.0")
+}
+
+func TestStats_NoHistory(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Equal(t, "No workouts logged yet.\n", output)
+}
+
+func TestStats_Scope(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createUserWithHistory(t, env)
+	user.Programs[user.CurrentProgram].StartedAt = time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err := executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workouts: 1 from 2024-03-06 to 2024-03-06")
+	assert.NotContains(t, output, "Squat:")
+	assert.Contains(t, output, "\nShowing OG Greyskull LP lifts since 2024-03-05. Use --all-lifts or --all-time to include the rest of your history.\n")
+
+	output, err = executePiped(t, "", "stats", "--all-lifts")
+	require.NoError(t, err)
+	assert.Contains(t, output, "\nShowing workouts since 2024-03-05. Use --all-time to include the rest of your history.\n")
+
+	output, err = executePiped(t, "", "stats", "--all-time")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workouts: 2 from 2024-03-04 to 2024-03-06")
+	assert.NotContains(t, output, "Showing")
+}
+
+func TestStats_Spanish(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createUserWithHistory(t, env)
+	user.Programs[user.CurrentProgram].StartedAt = time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+	_, err = executePiped(t, "", "config", "set", "locale", "es")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "stats", "--all-time")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Resumen de entrenamiento:\n  Entrenamientos: 2 del 2024-03-04 al 2024-03-06")
+
+	output, err = executePiped(t, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Se muestran los levantamientos de OG Greyskull LP desde el 2024-03-05. "+
+		"Usa --all-lifts o --all-time para incluir el resto de tu historial.\n")
+
+	output, err = executePiped(t, "", "pr", "--all-time")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Récords personales:\n")
+	assert.Contains(t, output, "  AMRAP más pesado: ")
+	assert.NotContains(t, output, "Heaviest AMRAP")
+
+	output, err = executePiped(t, "", "stats", "volume", "--all-time")
+	require.NoError(t, err)
+	assert.Equal(t, "No hay entrenamientos registrados en las últimas 8 semanas.\n", output)
+}
+
+func TestStats_ScopeFlagsShared(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	startAlongside(t)
+
+	user := loadTestUser(t)
+	alongside := user.Programs[user.ActivePrograms[0]]
+	alongside.DeloadStreaks = map[models.LiftName]models.DeloadStreak{models.Squat: {Deloads: 3, Weight: 150}}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// Every subcommand accepts the scope flags
+	for _, subcommand := range []string{"chart", "volume", "consistency", "stalls"} {
+		_, err := executePiped(t, "", "stats", subcommand, "--all-time", "--all-lifts", "--include-archived", "--program", "1")
+		if subcommand == "chart" {
+			assert.ErrorContains(t, err, `required flag(s) "lift", "out" not set`)
+		} else {
+			assert.NoError(t, err, subcommand)
+		}
+	}
+
+	output, err := executePiped(t, "", "stats", "stalls")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No stalled lifts.")
+
+	output, err = executePiped(t, "", "stats", "stalls", "--program", "2")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Squat: 3 deloads, stuck below 150 lbs (now 140 lbs) - stalled\n")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/spf13/cobra"
+)
+
+var statsVolumeCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "Show weekly tonnage and working sets per lift",
+	Long: `Show how much you've lifted each week: for each lift, the tonnage (weight × reps,
+warmups included) and the number of working and AMRAP sets, with the week's
+total. Weeks start on Monday, and weeks you didn't train are shown with "-".
+
+A week far above the ones before it can be a sign of overreaching, and a run
+of light weeks of detraining. Use --sparkline to add a bar per week showing each
+lift's tonnage trend at a glance.
+
+With an active program, only its lifts since the program started are included.
+Use --all-time and --all-lifts to include the rest of your history.`,
+	Example: `  greyskull stats volume
+  greyskull stats volume --weeks 12 --sparkline`,
+	Args: cobra.NoArgs,
+	RunE: showVolume,
+}
+
+func init() {
+	statsVolumeCmd.Flags().Int("weeks", 8, "Number of weeks to show, ending with this one")
+	statsVolumeCmd.Flags().Bool("sparkline", false, "Show a sparkline of each lift's weekly tonnage")
+}
+
+func showVolume(cmd *cobra.Command, args []string) error {
+	weeks, err := cmd.Flags().GetInt("weeks")
+	if err != nil {
+		return fmt.Errorf("failed to get weeks flag: %w", err)
+	}
+	if weeks <= 0 {
+		return fmt.Errorf("weeks must be positive, got: %d", weeks)
+	}
+	sparkline, err := cmd.Flags().GetBool("sparkline")
+	if err != nil {
+		return fmt.Errorf("failed to get sparkline flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	scope, err := resolveScope(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(scope.User.Username)
+	if err != nil {
+		return err
+	}
+
+	unit := scope.User.Unit
+	if scope.UserProgram != nil {
+		unit = scope.UserProgram.Unit
+	}
+
+	volume := analytics.WeeklyVolume(scope.History, weeks, time.Now())
+	formatter := display.NewStatsFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.DisplayVolume(volume, unit, sparkline)
+	outputFor(cmd).Result(volume)
+	if scope.Note != "" {
+		printf(cmd, "\n%s\n", scope.Note)
+	}
+	return nil
+}
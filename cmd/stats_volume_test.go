@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsVolume(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "stats", "volume")
+	require.NoError(t, err)
+	assert.Equal(t, "No workouts logged in the last 8 weeks.\n", output)
+
+	user.WorkoutHistory = []models.Workout{{
+		ID: uuid.New(), UserProgramID: user.CurrentProgram, Day: 1, EnteredAt: time.Now(),
+		Exercises: []models.Lift{{ID: uuid.New(), LiftName: models.Squat, Sets: []models.Set{
+			{ID: uuid.New(), Weight: 135, TargetReps: 5, ActualReps: 5, Type: models.WorkingSet, Order: 1},
+			{ID: uuid.New(), Weight: 135, TargetReps: 5, ActualReps: 8, Type: models.AMRAPSet, Order: 2},
+		}}},
+	}}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err = executePiped(t, "", "stats", "volume", "--weeks", "2", "--sparkline")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Weekly Volume (lbs tonnage / working sets):\n")
+	assert.Contains(t, output, "  Squat      Total\n")
+	assert.Contains(t, output, "  -          -\n")
+	assert.Contains(t, output, "  1,755 / 2  1,755 / 2\n")
+	assert.Contains(t, output, "  Squat  █\n")
+
+	_, err = executePiped(t, "", "stats", "volume", "--weeks", "0")
+	assert.ErrorContains(t, err, "weeks must be positive, got: 0")
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show a quick summary of where you are in your program",
+	Long: `Show the current user, program day, next workout's lifts, every lift's current
+weight, when you last trained, and whether a session is overdue (more than 3
+days since the last workout). Scheduled training days missed since then and
+lifts stalled on repeated deloads are called out too, along with your weekly
+training streak from 'greyskull stats consistency' and progress toward goals
+set with 'greyskull goal set'. A paused program is never overdue.
+
+With --porcelain, print a single machine-readable line for embedding in shell
+prompts or tmux status lines. The format is guaranteed to stay stable:
+
+  v1 user=<name> day=<day>/<total> next=<lift>@<weight>[,...] overdue=<0|1>
+
+Missing values are printed as "-", and having no current user or active
+program is not treated as an error.
+
+Programs trained alongside the current one are listed with their next day.
+'greyskull user status' shows the same summary.`,
+	Example: `  greyskull status --porcelain
+  v1 user=adam day=3/6 next=OverheadPress@95,Deadlift@185 overdue=0`,
+	RunE: showStatus,
+}
+
+// userStatusCmd shows the status under 'greyskull user', next to the other
+// commands about the current user
+var userStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where you are in each of your active programs",
+	Long: `Show the current user, the next day of each active program, the current
+program's next workout, and whether a session is overdue. This is the same
+summary as 'greyskull status'.`,
+	RunE: showStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	userCmd.AddCommand(userStatusCmd)
+	for _, cmd := range []*cobra.Command{statusCmd, userStatusCmd} {
+		cmd.Flags().Bool("porcelain", false, "Print a stable, machine-readable single-line status")
+	}
+}
+
+func showStatus(cmd *cobra.Command, args []string) error {
+	porcelain, err := cmd.Flags().GetBool("porcelain")
+	if err != nil {
+		return fmt.Errorf("failed to get porcelain flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	status, err := buildStatus(contextFor(cmd), ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	outputFor(cmd).Result(status)
+	if porcelain {
+		cmd.Println(display.FormatPorcelain(status))
+		return nil
+	}
+
+	formatter := display.NewStatusFormatter(cmd.OutOrStdout())
+	if status.Username != "" {
+		config, err := ctx.Config.Load(status.Username)
+		if err != nil {
+			return err
+		}
+		formatter.SetWeightFormat(weightFormat(config, status.Unit))
+	}
+	formatter.DisplayStatus(status)
+	return nil
+}
+
+// buildStatus gathers the current user's status as of now. Having no current
+// user or no active program yields a partially filled status rather than an error.
+func buildStatus(runCtx context.Context, ctx *services.CommandContext, now time.Time) (*display.Status, error) {
+	status := &display.Status{}
+
+	if _, err := ctx.UserService.CurrentUsername(runCtx); errors.Is(err, repository.ErrNoCurrentUser) {
+		return status, nil
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(runCtx)
+	if err != nil {
+		return nil, err
+	}
+	status.Username = user.Username
+
+	if user.CurrentProgram == uuid.Nil {
+		return status, nil
+	}
+
+	user, userProgram, prog, err := ctx.UserService.GetCurrentUserWithProgram(runCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := workout.CalculateNextWorkout(user, prog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next workout: %w", err)
+	}
+
+	status.ProgramName = prog.Name
+	status.Weights = userProgram.CurrentWeights
+	status.Unit = userProgram.Unit.OrDefault()
+	status.Day = next.Day
+	status.TotalDays = len(prog.Workouts)
+	for _, lift := range next.Exercises {
+		if lift.Optional {
+			continue
+		}
+		status.NextLifts = append(status.NextLifts, display.StatusLift{
+			Key:    lift.WeightKey(),
+			Weight: userProgram.CurrentWeights[lift.WeightKey()],
+		})
+	}
+	status.LastTrained = workout.LastTrainedAt(user, userProgram)
+	status.DaysSince = workout.DaysSince(status.LastTrained, now)
+
+	// A recorded skip accounts for the missed session, so overdue counts from it
+	lastActive := status.LastTrained
+	if skips := user.SkipsFor(userProgram.ID); len(skips) > 0 {
+		status.LastSkipped = &skips[len(skips)-1]
+		if status.LastSkipped.SkippedAt.After(lastActive) {
+			lastActive = status.LastSkipped.SkippedAt
+		}
+	}
+	// Time spent paused isn't missed training either
+	if resumedAt := userProgram.LastResumedAt(); resumedAt.After(lastActive) {
+		lastActive = resumedAt
+	}
+	status.Paused = userProgram.ActivePause()
+	status.Overdue = status.Paused == nil && workout.IsOverdue(lastActive, now)
+	for _, entry := range workout.Calendar(userProgram, lastActive, len(prog.Workouts), now, 0) {
+		if entry.Missed {
+			status.MissedDays++
+		}
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return nil, err
+	}
+	consistency := analytics.MeasureConsistency(user.History(), config.WeeklyTargetFor(userProgram), now)
+	status.Consistency = &consistency
+	status.Goals = analytics.Goals(userProgram, user.HistoryFor(userProgram.ID), now)
+	for _, stall := range analytics.Stalls(userProgram) {
+		if stall.Stalled() {
+			status.Stalls = append(status.Stalls, stall)
+		}
+	}
+
+	for _, alongside := range user.ActiveProgramList()[1:] {
+		program := display.StatusProgram{Name: display.FormatProgramName(alongside, nil), Day: alongside.CurrentDay}
+		if prog, err := ctx.UserService.ProgramFor(runCtx, alongside); err == nil {
+			program.Name = prog.Name
+			program.Day = workout.GetWorkoutDay(alongside.CurrentDay, len(prog.Workouts))
+			program.TotalDays = len(prog.Workouts)
+		}
+		status.Alongside = append(status.Alongside, program)
+	}
+
+	return status, nil
+}
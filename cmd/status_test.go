@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runStatus(t *testing.T, porcelain bool) string {
+	var buf bytes.Buffer
+	cmd := statusCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("porcelain", strconv.FormatBool(porcelain)))
+	t.Cleanup(func() { cmd.Flags().Set("porcelain", "false") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func TestStatusPorcelain(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	assert.Equal(t, "v1 user=TestUser day=1/6 next=OverheadPress@95,Squat@135 overdue=0\n", runStatus(t, true))
+}
+
+func TestStatusPorcelain_Overdue(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{
+		ID:            uuid.New(),
+		UserProgramID: user.CurrentProgram,
+		Day:           1,
+		EnteredAt:     time.Now().AddDate(0, 0, -5),
+	})
+	// Training every day makes the missed sessions the same whatever today is
+	user.Programs[user.CurrentProgram].TrainingDays = []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	assert.Contains(t, runStatus(t, true), "overdue=1")
+	output := runStatus(t, false)
+	assert.Contains(t, output, "(5 days ago)\nMissed: 4 scheduled sessions since your last workout\nOverdue: time to train!")
+	assert.Contains(t, output, "Streak: 0 weeks at 7 sessions a week (longest 0), 1 session in the last 30 days\n")
+}
+
+func TestStatusPorcelain_NoUserOrProgram(t *testing.T) {
+	env := setupTestEnv(t)
+	assert.Equal(t, "v1 user=- day=- next=- overdue=0\n", runStatus(t, true))
+
+	env.createUsersDirectly([]string{"TestUser"})
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	assert.Equal(t, "v1 user=TestUser day=- next=- overdue=0\n", runStatus(t, true))
+}
+
+func TestStatus(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output := runStatus(t, false)
+	assert.Contains(t, output, "TestUser: OG Greyskull LP, Day 1 of 6")
+	assert.Contains(t, output, "Next: Overhead Press 95, Squat 135")
+	assert.Contains(t, output, "Weights: Overhead Press 95, Bench Press 125, Squat 135, Deadlift 185\n")
+	assert.Contains(t, output, "(today)")
+	assert.NotContains(t, output, "Missed")
+	assert.NotContains(t, output, "Warning")
+}
+
+func TestStatus_Stalled(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.Programs[user.CurrentProgram].DeloadStreaks = map[models.LiftName]models.DeloadStreak{
+		models.Squat:         {Deloads: 3, Weight: 200},
+		models.OverheadPress: {Deloads: 1, Weight: 100},
+	}
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output := runStatus(t, false)
+	assert.Contains(t, output, "Warning: Squat has deloaded 3 times in a row without getting past 200 lbs.")
+	assert.NotContains(t, output, "Overhead Press has deloaded")
+}
+
+func TestStatus_Skipped(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{
+		ID:            uuid.New(),
+		UserProgramID: user.CurrentProgram,
+		Day:           1,
+		EnteredAt:     time.Now().AddDate(0, 0, -5),
+	})
+	user.SkippedDays = append(user.SkippedDays, models.SkippedDay{
+		ID:            uuid.New(),
+		UserProgramID: user.CurrentProgram,
+		Day:           2,
+		Reason:        "sick",
+		SkippedAt:     time.Now().AddDate(0, 0, -1),
+	})
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// The recorded skip accounts for the missed session
+	output := runStatus(t, false)
+	assert.Contains(t, output, "(5 days ago)\nLast skipped: Day 2 on "+time.Now().AddDate(0, 0, -1).Format("2006-01-02")+" (sick)\n")
+	assert.NotContains(t, output, "Overdue")
+	assert.Contains(t, runStatus(t, true), "overdue=0")
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/mail"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/spf13/cobra"
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary --week",
+	Short: "Summarize a week of training",
+	Long: `Summarize a week of training, Monday to Sunday: the sessions completed against
+your weekly target, how each lift's weight moved since the week before, the
+personal records broken, and the trend of any body weights recorded with
+'workout log --bodyweight'.
+
+The summary covers this week unless --date picks a day in another. It is
+written as plain text to stdout unless --format html is given. Use --out to
+write it to a file for an external mailer, or --send to email it through the
+mail server set up with 'greyskull config set smtp.server', from smtp.from to
+each address in smtp.to.`,
+	Example: `  greyskull summary --week
+  greyskull summary --week --date 2024-03-04 --format html --out week.html
+  greyskull summary --week --format html --send`,
+	Args: cobra.NoArgs,
+	RunE: runSummary,
+}
+
+func init() {
+	summaryCmd.Flags().Bool("week", false, "Summarize a week, Monday to Sunday")
+	summaryCmd.Flags().String("date", "", "A day in the week to summarize (YYYY-MM-DD, default today)")
+	summaryCmd.Flags().String("format", string(display.SummaryText), "Summary format: text or html")
+	summaryCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{string(display.SummaryText), string(display.SummaryHTML)}, cobra.ShellCompDirectiveNoFileComp))
+	summaryCmd.Flags().StringP("out", "o", "", "File to write the summary to")
+	summaryCmd.Flags().Bool("send", false, "Email the summary through the configured mail server")
+	rootCmd.AddCommand(summaryCmd)
+}
+
+func runSummary(cmd *cobra.Command, args []string) error {
+	week, err := cmd.Flags().GetBool("week")
+	if err != nil {
+		return fmt.Errorf("failed to get week flag: %w", err)
+	}
+	if !week {
+		return fmt.Errorf("choose the period to summarize with --week")
+	}
+	dateInput, err := cmd.Flags().GetString("date")
+	if err != nil {
+		return fmt.Errorf("failed to get date flag: %w", err)
+	}
+	formatInput, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+	format, err := display.ParseSummaryFormat(formatInput)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return fmt.Errorf("failed to get out flag: %w", err)
+	}
+	send, err := cmd.Flags().GetBool("send")
+	if err != nil {
+		return fmt.Errorf("failed to get send flag: %w", err)
+	}
+
+	date := time.Now()
+	if dateInput != "" {
+		date, err = time.ParseInLocation("2006-01-02", dateInput, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", dateInput)
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	// The summary is written once, then to wherever it's going
+	summary := weeklySummary(user, config, analytics.WeekStart(date))
+	var buf bytes.Buffer
+	formatter := display.NewSummaryFormatter(&buf)
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.SetWeightFormat(weightFormat(config, summary.Unit))
+	if err := formatter.DisplayWeeklySummary(summary, format); err != nil {
+		return err
+	}
+	outputFor(cmd).Result(summary.Result())
+
+	if outPath == "" && !send {
+		_, err := cmd.OutOrStdout().Write(buf.Bytes())
+		return err
+	}
+	if outPath != "" {
+		if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write summary file: %w", err)
+		}
+		printf(cmd, "Wrote weekly summary to %s\n", outPath)
+	}
+	if send {
+		message := mail.Message{
+			Subject: formatter.SummaryTitle(summary),
+			Date:    time.Now(),
+			Body:    buf.Bytes(),
+			HTML:    format == display.SummaryHTML,
+		}
+		if err := mail.Send(config.SMTP, message); err != nil {
+			return err
+		}
+		printf(cmd, "Sent weekly summary to %s\n", strings.Join(config.SMTP.To, ", "))
+	}
+	return nil
+}
+
+// weeklySummary collects the user's training in the week starting at start,
+// measuring each lift's weight and records against the workouts before it
+func weeklySummary(user *models.User, config *models.Config, start time.Time) display.WeeklySummary {
+	end := start.AddDate(0, 0, 7)
+	userProgram := user.Programs[user.CurrentProgram]
+	summary := display.WeeklySummary{
+		Username: user.Username,
+		Start:    start,
+		Target:   config.WeeklyTargetFor(userProgram),
+		Unit:     user.Unit,
+	}
+	if userProgram != nil {
+		summary.Unit = userProgram.Unit
+	}
+
+	var before []models.Workout
+	existing := make(map[models.LiftName]*records.LiftRecords)
+	for _, w := range user.History() {
+		achievements := records.Broken(existing, &w)
+		switch {
+		case w.EnteredAt.Before(start):
+			before = append(before, w)
+		case w.EnteredAt.Before(end):
+			summary.Workouts = append(summary.Workouts, display.ReportWorkout{Workout: w, Achievements: achievements})
+		}
+	}
+
+	summary.Previous = make(map[models.LiftName]float64)
+	for lift, previous := range analytics.Summarize(before).Lifts {
+		summary.Previous[lift] = previous.LatestWeight
+	}
+	return summary
+}
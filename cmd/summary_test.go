@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addSummaryWeek adds a second week to the user's history: a heavier squat
+// with a rep PR, and two weigh-ins
+func addSummaryWeek(t *testing.T, user *models.User) {
+	for _, w := range []models.Workout{
+		{Day: 3, EnteredAt: time.Date(2024, 3, 11, 18, 0, 0, 0, time.Local), BodyWeight: 182, Exercises: []models.Lift{
+			{ID: uuid.New(), LiftName: models.Squat, Sets: []models.Set{{ID: uuid.New(), Weight: 140, TargetReps: 5, ActualReps: 9, Type: models.AMRAPSet}}},
+		}},
+		{Day: 4, EnteredAt: time.Date(2024, 3, 13, 18, 0, 0, 0, time.Local), BodyWeight: 180.5, Exercises: []models.Lift{
+			{ID: uuid.New(), LiftName: models.BenchPress, Sets: []models.Set{{ID: uuid.New(), Weight: 125, TargetReps: 5, ActualReps: 5, Type: models.AMRAPSet}}},
+		}},
+	} {
+		w.ID, w.UserProgramID = uuid.New(), user.CurrentProgram
+		user.WorkoutHistory = append(user.WorkoutHistory, w)
+	}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+}
+
+func TestSummary_Week(t *testing.T) {
+	env := setupTestEnv(t)
+	addSummaryWeek(t, createUserWithHistory(t, env))
+
+	output, err := executePiped(t, "", "summary", "--week", "--date", "2024-03-15")
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly summary for TestUser: 2024-03-11 to 2024-03-17\n"+
+		"\n"+
+		"Sessions: 2 of 3 planned\n"+
+		"  2024-03-11  Day 3\n"+
+		"  2024-03-13  Day 4\n"+
+		"Tonnage: 1,885 lbs\n"+
+		"\n"+
+		"Weights:\n"+
+		"  Bench Press: 125 lbs (no change)\n"+
+		"  Squat: 135 → 140 lbs (+5)\n"+
+		"\n"+
+		"Personal records:\n"+
+		"  Squat: heaviest AMRAP 140 lbs x 9 (previous 135 lbs x 8)\n"+
+		"  Squat: estimated 1RM 182 lbs (previous 171 lbs)\n"+
+		"\n"+
+		"Body weight: 182 → 180.5 lbs (-1.5 over 2 weigh-ins)\n", output)
+}
+
+func TestSummary_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	addSummaryWeek(t, createUserWithHistory(t, env))
+
+	stdout, stderr, err := executeJSON(t, "", "summary", "--week", "--date", "2024-03-15")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Weekly summary for TestUser")
+
+	var result display.WeeklySummaryResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "2024-03-11", result.Start)
+	assert.Equal(t, "2024-03-17", result.End)
+	assert.Equal(t, models.Pounds, result.Unit)
+	assert.Equal(t, 2, result.Sessions)
+	assert.Equal(t, 3, result.Target)
+	assert.Equal(t, 1885.0, result.Tonnage)
+	assert.Equal(t, []display.WeightChange{
+		{Lift: models.BenchPress, Previous: 125, Weight: 125},
+		{Lift: models.Squat, Previous: 135, Weight: 140},
+	}, result.Weights)
+	require.Len(t, result.PRs, 2)
+	assert.Equal(t, models.Squat, result.PRs[0].Lift)
+	assert.Equal(t, records.HeaviestAMRAP, result.PRs[0].Kind)
+	assert.Equal(t, []float64{182, 180.5}, result.BodyWeights)
+}
+
+func TestSummary_EmptyWeek(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	output, err := executePiped(t, "", "summary", "--week", "--date", "2024-02-01")
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly summary for TestUser: 2024-01-29 to 2024-02-04\n"+
+		"\n"+
+		"Sessions: 0 of 3 planned\n"+
+		"\n"+
+		"Personal records: none\n"+
+		"\n"+
+		"Body weight: not recorded\n", output)
+
+	_, err = executePiped(t, "", "summary")
+	assert.EqualError(t, err, "choose the period to summarize with --week")
+	_, err = executePiped(t, "", "summary", "--week", "--format", "pdf")
+	assert.ErrorContains(t, err, `unknown summary format "pdf"`)
+}
+
+func TestSummary_OutAndSend(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	out := filepath.Join(t.TempDir(), "week.html")
+	output, err := executePiped(t, "", "summary", "--week", "--date", "2024-03-04", "--format", "html", "--out", out)
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote weekly summary to "+out+"\n", output)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<h1>Weekly summary for TestUser: 2024-03-04 to 2024-03-10</h1>")
+
+	_, err = executePiped(t, "", "summary", "--week", "--send")
+	assert.ErrorContains(t, err, "no mail server configured")
+}
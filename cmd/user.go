@@ -22,4 +22,7 @@ func init() {
 	userCmd.AddCommand(createCmd)
 	userCmd.AddCommand(switchCmd) 
 	userCmd.AddCommand(listCmd)
+	userCmd.AddCommand(userTimerCmd)
+	userCmd.AddCommand(userUnitCmd)
+	userCmd.AddCommand(userLeaderboardCmd)
 }
\ No newline at end of file
@@ -9,7 +9,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
 	"github.com/mikowitz/greyskull/repository"
-	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
@@ -27,7 +26,7 @@ func createUser(cmd *cobra.Command, args []string) error {
 	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
 	
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
@@ -44,7 +43,7 @@ func createUser(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check for case-insensitive duplicates
-	if _, err := ctx.UserRepo.Get(username); err == nil {
+	if _, err := ctx.UserRepo.Get(contextFor(cmd), username); err == nil {
 		return fmt.Errorf("user %q already exists (case-insensitive)", username)
 	} else if !errors.Is(err, repository.ErrUserNotFound) {
 		return fmt.Errorf("failed to check for existing user: %w", err)
@@ -66,16 +65,16 @@ func createUser(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save user
-	if err := ctx.UserRepo.Create(user); err != nil {
+	if err := ctx.UserRepo.Create(contextFor(cmd), user); err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
 	// Set as current user
-	if err := ctx.UserRepo.SetCurrent(username); err != nil {
+	if err := ctx.UserRepo.SetCurrent(contextFor(cmd), username); err != nil {
 		return fmt.Errorf("failed to set current user: %w", err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "User %q created successfully and set as current user.\n", username)
+	fprintf(cmd.OutOrStdout(), "User %q created successfully and set as current user.\n", username)
 	return nil
 }
 
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// userLeaderboardCmd represents the user leaderboard command
+var userLeaderboardCmd = &cobra.Command{
+	Use:   "leaderboard [on|off]",
+	Short: "Show or set whether you appear on the leaderboard",
+	Long: `Show or set whether you appear on 'greyskull leaderboard', which ranks the users
+in this data directory on a lift. Users are left off until they opt in.
+
+Joining shares your current working weights and estimated one-rep maxes with
+anyone using the leaderboard on this machine or data directory.`,
+	Example:   "  greyskull user leaderboard on",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      setUserLeaderboard,
+}
+
+func setUserLeaderboard(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		if user.Leaderboard {
+			printf(cmd, "You're on the leaderboard.\n")
+		} else {
+			printf(cmd, "You aren't on the leaderboard.\n")
+		}
+		return nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		user.Leaderboard = true
+	case "off":
+		user.Leaderboard = false
+	default:
+		return fmt.Errorf("invalid setting %q (expected on or off)", args[0])
+	}
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if user.Leaderboard {
+		printf(cmd, "You've joined the leaderboard.\n")
+	} else {
+		printf(cmd, "You've left the leaderboard.\n")
+	}
+	return nil
+}
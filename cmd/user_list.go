@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/mikowitz/greyskull/repository"
-	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
@@ -18,48 +17,57 @@ Original username casing is preserved in the display.`,
 	RunE: listUsers,
 }
 
+// userListResult is the user list printed by --json
+type userListResult struct {
+	Users   []string `json:"users"`
+	Current string   `json:"current,omitempty"`
+}
+
 func listUsers(cmd *cobra.Command, args []string) error {
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
 
 	// Get all users
-	usernames, err := ctx.UserRepo.List()
+	usernames, err := ctx.UserRepo.List(contextFor(cmd))
 	if err != nil {
 		return fmt.Errorf("failed to list users: %w", err)
 	}
 
 	// Check if no users exist
 	if len(usernames) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No users found. Use 'greyskull user create' to create your first user.")
+		fprintf(cmd.OutOrStdout(), "No users found. Use 'greyskull user create' to create your first user.\n")
+		outputFor(cmd).Result(userListResult{Users: []string{}})
 		return nil
 	}
 
 	// Get current user
-	currentUser, err := ctx.UserRepo.GetCurrent()
+	currentUser, err := ctx.UserService.CurrentUsername(contextFor(cmd))
 	var hasCurrentUser bool
 	if err != nil && !errors.Is(err, repository.ErrNoCurrentUser) {
-		return fmt.Errorf("failed to get current user: %w", err)
+		return err
 	}
 	hasCurrentUser = err == nil
 
 	// Display users
-	fmt.Fprintln(cmd.OutOrStdout(), "Users:")
+	fprintf(cmd.OutOrStdout(), "Users:\n")
 	for _, username := range usernames {
 		marker := " "
 		if hasCurrentUser && username == currentUser {
 			marker = "*"
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "  %s %s\n", marker, username)
+		fprintf(cmd.OutOrStdout(), "  %s %s\n", marker, username)
 	}
 
 	if hasCurrentUser {
-		fmt.Fprintf(cmd.OutOrStdout(), "\n* Current user: %s\n", currentUser)
+		fprintf(cmd.OutOrStdout(), "\n* Current user: %s\n", currentUser)
 	} else {
-		fmt.Fprintln(cmd.OutOrStdout(), "\nNo current user set. Use 'greyskull user switch <username>' to set one.")
+		fprintf(cmd.OutOrStdout(), "\nNo current user set. Use 'greyskull user switch <username>' to set one.\n")
+		currentUser = ""
 	}
+	outputFor(cmd).Result(userListResult{Users: usernames, Current: currentUser})
 
 	return nil
 }
\ No newline at end of file
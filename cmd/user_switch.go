@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/mikowitz/greyskull/repository"
-	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
@@ -15,21 +14,22 @@ var switchCmd = &cobra.Command{
 	Short: "Switch to a different user",
 	Long: `Switch to a different user (case-insensitive). The specified user becomes
 the current active user for all workout tracking operations.`,
-	Args: cobra.ExactArgs(1),
-	RunE: switchUser,
+	Args:              cobra.ExactArgs(1),
+	RunE:              switchUser,
+	ValidArgsFunction: completeFirstArg(completeUsernames),
 }
 
 func switchUser(cmd *cobra.Command, args []string) error {
 	username := args[0]
 
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
 
 	// Validate user exists (case-insensitive lookup)
-	user, err := ctx.UserRepo.Get(username)
+	user, err := ctx.UserRepo.Get(contextFor(cmd), username)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return fmt.Errorf("user %q not found", username)
@@ -38,11 +38,11 @@ func switchUser(cmd *cobra.Command, args []string) error {
 	}
 
 	// Set as current user
-	if err := ctx.UserRepo.SetCurrent(username); err != nil {
+	if err := ctx.UserRepo.SetCurrent(contextFor(cmd), username); err != nil {
 		return fmt.Errorf("failed to set current user: %w", err)
 	}
 
 	// Show confirmation with actual username casing
-	fmt.Fprintf(cmd.OutOrStdout(), "Switched to user %q.\n", user.Username)
+	fprintf(cmd.OutOrStdout(), "Switched to user %q.\n", user.Username)
 	return nil
 }
\ No newline at end of file
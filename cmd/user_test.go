@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
 	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +30,12 @@ func setupTestEnv(t *testing.T) *testEnv {
 	}
 	
 	os.Setenv("XDG_CONFIG_HOME", env.tempDir)
+	// Keep legacy data locations in the real home directory out of the tests
+	t.Setenv("HOME", env.tempDir)
+	// Show messages in English whatever the locale the tests run under
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "C")
 	
 	t.Cleanup(func() {
 		if env.originalConfigDir != "" {
@@ -54,7 +61,7 @@ func (env *testEnv) createUsersDirectly(usernames []string) {
 			WorkoutHistory: []models.Workout{},
 			CreatedAt:      time.Now(),
 		}
-		err := repo.Create(user)
+		err := repo.Create(env.t.Context(), user)
 		require.NoError(env.t, err)
 	}
 }
@@ -92,27 +99,15 @@ func TestUserCreate(t *testing.T) {
 			// Setup isolated environment for each subtest
 			setupTestEnv(t)
 
-			// Mock stdin
-			oldStdin := os.Stdin
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			// Setup output capture
+			// Setup input and output capture
 			var buf bytes.Buffer
+			createCmd.SetIn(strings.NewReader(tt.input))
 			createCmd.SetOut(&buf)
 			createCmd.SetErr(&buf)
 
-			go func() {
-				defer w.Close()
-				w.Write([]byte(tt.input))
-			}()
-
 			// Execute command
 			err := createCmd.RunE(createCmd, []string{})
 
-			// Restore stdin
-			os.Stdin = oldStdin
-
 			// Check results
 			output := buf.String()
 			if tt.shouldSucceed {
@@ -123,7 +118,7 @@ func TestUserCreate(t *testing.T) {
 				repo, err := repository.NewJSONUserRepository()
 				require.NoError(t, err)
 
-				currentUser, err := repo.GetCurrent()
+				currentUser, err := repo.GetCurrent(t.Context())
 				assert.NoError(t, err)
 				assert.Equal(t, strings.TrimSpace(strings.Split(tt.input, "\n")[0]), currentUser)
 			} else {
@@ -142,21 +137,12 @@ func TestUserCreateDuplicate(t *testing.T) {
 	env.createUsersDirectly([]string{"TestUser"})
 
 	// Try to create duplicate (case-insensitive)
-	oldStdin := os.Stdin
-	r2, w2, _ := os.Pipe()
-	os.Stdin = r2
-
 	var buf2 bytes.Buffer
+	createCmd.SetIn(strings.NewReader("testuser\n"))
 	createCmd.SetOut(&buf2)
 	createCmd.SetErr(&buf2)
 
-	go func() {
-		defer w2.Close()
-		w2.Write([]byte("testuser\n"))
-	}()
-
 	err := createCmd.RunE(createCmd, []string{})
-	os.Stdin = oldStdin
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "already exists")
@@ -264,7 +250,7 @@ func TestUserList(t *testing.T) {
 			if tt.currentUser != "" {
 				repo, err := repository.NewJSONUserRepository()
 				require.NoError(t, err)
-				err = repo.SetCurrent(tt.currentUser)
+				err = repo.SetCurrent(t.Context(), tt.currentUser)
 				require.NoError(t, err)
 			}
 
@@ -283,6 +269,35 @@ func TestUserList(t *testing.T) {
 	}
 }
 
+func TestUserFlag(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	// Creating Alice makes her the current user
+	_, err := executePiped(t, "Alice\n", "user", "create")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "workout", "next", "--user", "testuser")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Day 1")
+
+	output, err = executePiped(t, "", "user", "list", "--user", "TESTUSER")
+	require.NoError(t, err)
+	assert.Contains(t, output, "* Current user: TestUser")
+
+	// The stored current user is unchanged
+	output, err = executePiped(t, "", "user", "list")
+	require.NoError(t, err)
+	assert.Contains(t, output, "* Current user: Alice")
+
+	_, err = executePiped(t, "", "workout", "next")
+	assert.ErrorContains(t, err, "no active program")
+
+	_, err = executePiped(t, "", "workout", "next", "--user", "Bob")
+	assert.EqualError(t, err, `user "Bob" not found`)
+	assert.ErrorIs(t, err, services.ErrUserNotFound)
+}
+
 func TestValidateUsername(t *testing.T) {
 	tests := []struct {
 		name     string
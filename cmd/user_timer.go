@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/timer"
+	"github.com/spf13/cobra"
+)
+
+// userTimerCmd represents the user timer command
+var userTimerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Show or set your rest timer durations",
+	Long: `Show or set the rest periods counted down by 'greyskull workout log --timer'.
+Your settings take precedence over your program's rest times, which in turn
+override the defaults of 1:30 after warmups and 3:00 after other sets.
+
+Setting a duration of 0 clears your setting for it.`,
+	Example: "  greyskull user timer --warmup 1m --working 2m30s",
+	Args:    cobra.NoArgs,
+	RunE:    setUserTimer,
+}
+
+func init() {
+	userTimerCmd.Flags().Duration("warmup", 0, "Rest after warmup sets")
+	userTimerCmd.Flags().Duration("working", 0, "Rest after working, AMRAP, and feeler sets")
+}
+
+func setUserTimer(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	// Check every duration before saving any, so a bad flag changes nothing
+	rests := map[string]time.Duration{}
+	for _, name := range []string{"warmup", "working"} {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		rest, err := cmd.Flags().GetDuration(name)
+		if err != nil {
+			return fmt.Errorf("failed to get %s flag: %w", name, err)
+		}
+		if rest < 0 || rest%time.Second != 0 {
+			return fmt.Errorf("%s rest must be a non-negative whole number of seconds, got: %s", name, rest)
+		}
+		rests[name] = rest
+	}
+
+	// These are the timer.warmup and timer.working config settings
+	for _, name := range []string{"warmup", "working"} {
+		rest, changed := rests[name]
+		if !changed {
+			continue
+		}
+		if _, err := ctx.Config.Set(contextFor(cmd), user, "timer."+name, rest.String()); err != nil {
+			return err
+		}
+	}
+
+	// Show the rest times that will actually be used, including the program's
+	var program *models.Program
+	if userProgram, exists := user.Programs[user.CurrentProgram]; exists {
+		program, _ = ctx.UserService.ProgramFor(contextFor(cmd), userProgram)
+	}
+	effective := timer.RestsFor(user, program)
+
+	printf(cmd, "Rest timer:\n")
+	printf(cmd, "  After warmup sets: %s\n", timer.FormatDuration(effective.Warmup))
+	printf(cmd, "  After working sets: %s\n", timer.FormatDuration(effective.Working))
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runUserTimer(t *testing.T, flags map[string]string) (string, error) {
+	var buf bytes.Buffer
+	cmd := userTimerCmd
+	cmd.SetOut(&buf)
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"warmup", "working"} {
+			cmd.Flags().Set(name, "0s")
+			cmd.Flags().Lookup(name).Changed = false
+		}
+	})
+
+	err := cmd.RunE(cmd, []string{})
+	return buf.String(), err
+}
+
+func TestUserTimer(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := runUserTimer(t, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Rest timer:\n  After warmup sets: 1:30\n  After working sets: 3:00\n", output)
+	assert.Nil(t, loadTestUser(t).RestTimes)
+
+	output, err = runUserTimer(t, map[string]string{"working": "2m30s"})
+	require.NoError(t, err)
+	assert.Equal(t, "Rest timer:\n  After warmup sets: 1:30\n  After working sets: 2:30\n", output)
+	assert.Equal(t, &models.RestTimes{WorkingSeconds: 150}, loadTestUser(t).RestTimes)
+
+	// Clearing every setting removes the user's rest times entirely
+	_, err = runUserTimer(t, map[string]string{"working": "0s"})
+	require.NoError(t, err)
+	assert.Nil(t, loadTestUser(t).RestTimes)
+}
+
+func TestUserTimer_InvalidDuration(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := runUserTimer(t, map[string]string{"warmup": "1.5s"})
+	assert.EqualError(t, err, "warmup rest must be a non-negative whole number of seconds, got: 1.5s")
+
+	_, err = runUserTimer(t, map[string]string{"warmup": "-1m"})
+	assert.ErrorContains(t, err, "warmup rest must be a non-negative")
+}
+
+func TestUserTimer_MatchesConfig(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := runUserTimer(t, map[string]string{"warmup": "1m"})
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "", "config", "get", "timer.warmup")
+	require.NoError(t, err)
+	assert.Equal(t, "1:00\n", output)
+}
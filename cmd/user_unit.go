@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/spf13/cobra"
+)
+
+// userUnitCmd represents the user unit command
+var userUnitCmd = &cobra.Command{
+	Use:   "unit [lbs|kg]",
+	Short: "Show or set your weight unit",
+	Long: `Show or set the weight unit used by programs you start from now on. Programs
+started in kilograms progress by kilogram increments: templates that declare
+increments in pounds are converted to plate-friendly equivalents, e.g. 5 lbs
+becomes 2.5 kg and 2.5 lbs becomes 1.25 kg.
+
+Programs you have already started keep the unit they were started with.`,
+	Example:   "  greyskull user unit kg",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{string(models.Pounds), string(models.Kilograms)},
+	RunE:      setUserUnit,
+}
+
+func setUserUnit(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	// This is the unit config setting
+	if len(args) == 0 {
+		setting, err := ctx.Config.Get(user, "unit")
+		if err != nil {
+			return err
+		}
+		printf(cmd, "Weight unit: %s\n", setting.Value)
+		return nil
+	}
+
+	setting, err := ctx.Config.Set(contextFor(cmd), user, "unit", args[0])
+	if err != nil {
+		return err
+	}
+
+	printf(cmd, "Weight unit set to %s for newly started programs.\n", setting.Value)
+	return nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUnit(t *testing.T) {
+	env := setupTestEnv(t)
+	env.createUsersDirectly([]string{"TestUser"})
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCurrent(t.Context(), "TestUser"))
+
+	var buf bytes.Buffer
+	cmd := userUnitCmd
+	cmd.SetOut(&buf)
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "Weight unit: lbs\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{"KG"}))
+	assert.Equal(t, "Weight unit set to kg for newly started programs.\n", buf.String())
+
+	err = cmd.RunE(cmd, []string{"stone"})
+	assert.ErrorContains(t, err, `unknown weight unit "stone"`)
+
+	// Programs started afterwards record the unit and prompt in it
+	buf.Reset()
+	programStartCmd.SetOut(&buf)
+	programStartCmd.SetIn(strings.NewReader("1\n60\n80\n50\n40\n"))
+	require.NoError(t, programStartCmd.RunE(programStartCmd, []string{}))
+	assert.Contains(t, buf.String(), "Enter starting weight for Squat (kg): ")
+
+	user, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, models.Kilograms, user.Unit)
+	assert.Equal(t, models.Kilograms, user.Programs[user.CurrentProgram].Unit)
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
 	"github.com/spf13/cobra"
 )
 
@@ -14,5 +18,27 @@ func init() {
 	rootCmd.AddCommand(workoutCmd)
 	workoutCmd.AddCommand(workoutNextCmd)
 	workoutCmd.AddCommand(workoutLogCmd)
+	workoutCmd.AddCommand(workoutSkipCmd)
+	workoutCmd.AddCommand(workoutRepeatCmd)
+	workoutCmd.AddCommand(workoutHistoryCmd)
+	workoutCmd.AddCommand(workoutCalendarCmd)
+	workoutCmd.AddCommand(workoutAdhocCmd)
+	workoutCmd.AddCommand(workoutFixAMRAPCmd)
+	workoutLogCmd.AddCommand(workoutLogQuickCmd)
+}
+
+// addProgramFlag adds the --program flag that picks which active program a
+// workout command works on
+func addProgramFlag(cmd *cobra.Command) {
+	cmd.Flags().String("program", "", "Active program to use, by index, ID, or name (defaults to the current program)")
 }
 
+// selectedProgram loads the current user and the active program picked by
+// --program, or their current program if it isn't given
+func selectedProgram(cmd *cobra.Command, ctx *services.CommandContext) (*models.User, *models.UserProgram, *models.Program, error) {
+	ref, err := cmd.Flags().GetString("program")
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get program flag: %w", err)
+	}
+	return ctx.UserService.GetCurrentUserWithActiveProgram(contextFor(cmd), ref)
+}
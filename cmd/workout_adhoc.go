@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var workoutAdhocCmd = &cobra.Command{
+	Use:   "adhoc <shorthand>",
+	Short: "Log an extra workout outside your program",
+	Long: `Log an ad-hoc workout, such as an extra session or a max test, to your history
+without touching your program: its weights, progression, and next day stay as
+they are. No program is needed.
+
+Describe the workout in the same shorthand as 'workout log quick': each lift is
+written as the lift name, the weight, and the reps completed in every set, with
+lifts separated by semicolons. Any lifts can be listed, in any order, and a lift
+can be listed more than once to record sets at different weights:
+
+  deadlift 315x3; deadlift 365x1; deadlift 385x1`,
+	Example: `  greyskull workout adhoc "squat 225x1; bench 185x3,3,3"
+  greyskull workout adhoc "deadlift 405x1" --date 2024-05-04 --notes`,
+	Args: cobra.ExactArgs(1),
+	RunE: logAdHocWorkout,
+}
+
+func init() {
+	workoutAdhocCmd.Flags().String("date", "", "Date the workout was performed (YYYY-MM-DD), defaults to today")
+	workoutAdhocCmd.Flags().Bool("notes", false, "Write notes about the workout")
+}
+
+func logAdHocWorkout(cmd *cobra.Command, args []string) error {
+	// Parse before loading anything so syntax errors are reported immediately
+	entries, err := workout.ParseShorthand(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid shorthand: %w", err)
+	}
+	dateInput, err := cmd.Flags().GetString("date")
+	if err != nil {
+		return fmt.Errorf("failed to get date flag: %w", err)
+	}
+	withNotes, err := cmd.Flags().GetBool("notes")
+	if err != nil {
+		return fmt.Errorf("failed to get notes flag: %w", err)
+	}
+
+	now := time.Now()
+	enteredAt := now
+	if dateInput != "" {
+		enteredAt, err = adHocWorkoutDate(dateInput, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, err := ctx.UserService.RequireCurrentUser(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	completedWorkout := workout.BuildAdHoc(entries, enteredAt)
+	if withNotes {
+		inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+		completedWorkout.Notes, err = inputReader.ReadMultiLine("Notes (finish with a blank line or '.'):\n")
+		if err != nil && !errors.Is(err, ErrNoInput) {
+			return fmt.Errorf("failed to read notes: %w", err)
+		}
+	}
+
+	user.AddWorkout(*completedWorkout)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.DisplayHistory([]models.Workout{*completedWorkout})
+	printf(cmd, "\nExtra workout logged. Your program is unchanged.\n")
+
+	outputFor(cmd).Result(completedWorkout)
+	return nil
+}
+
+// adHocWorkoutDate returns when a workout performed on the date given as
+// YYYY-MM-DD happened, at the current time of day. Ad-hoc workouts don't
+// affect the program, so unlike program workouts they can fall anywhere in
+// the past.
+func adHocWorkoutDate(input string, now time.Time) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", input, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", input)
+	}
+	if date.After(now) {
+		return time.Time{}, fmt.Errorf("--date %s is in the future", input)
+	}
+
+	enteredAt := time.Date(date.Year(), date.Month(), date.Day(),
+		now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), now.Location())
+	if enteredAt.After(now) {
+		enteredAt = now
+	}
+	return enteredAt, nil
+}
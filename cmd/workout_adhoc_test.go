@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutAdhoc(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "workout", "adhoc", "deadlift 315x3; deadlift 365x1; squat 225x2,2")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Extra workout")
+	assert.Contains(t, output, "Extra workout logged. Your program is unchanged.")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	logged := user.WorkoutHistory[0]
+	assert.True(t, logged.AdHoc)
+	assert.Equal(t, uuid.Nil, logged.UserProgramID)
+	require.Len(t, logged.Exercises, 3)
+	assert.Equal(t, models.Deadlift, logged.Exercises[1].LiftName)
+	assert.Equal(t, 365.0, logged.Exercises[1].Sets[0].Weight)
+
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 1, userProgram.CurrentDay)
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 185.0, userProgram.CurrentWeights[models.Deadlift])
+}
+
+func TestWorkoutAdhoc_Date(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "Tested a new single\n\n", "workout", "adhoc", "deadlift 405x1", "--date", "2024-05-04", "--notes")
+	require.NoError(t, err)
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	logged := user.WorkoutHistory[0]
+	assert.Equal(t, "2024-05-04", logged.EnteredAt.Format("2006-01-02"))
+	assert.Equal(t, "Tested a new single", logged.Notes)
+}
+
+func TestWorkoutAdhoc_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"syntax error", []string{"deadlift 405"}, `invalid shorthand: entry 1 ("deadlift 405"): expected sets as <weight>x<reps>`},
+		{"bad date", []string{"deadlift 405x1", "--date", "May 4"}, `invalid --date "May 4": expected YYYY-MM-DD`},
+		{"future date", []string{"deadlift 405x1", "--date", tomorrow}, "--date " + tomorrow + " is in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executePiped(t, "", append([]string{"workout", "adhoc"}, tt.args...)...)
+			assert.ErrorContains(t, err, tt.expected)
+
+			assert.Empty(t, loadTestUser(t).WorkoutHistory)
+		})
+	}
+}
+
+func TestAdHocWorkoutDate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 14, 30, 0, 0, time.UTC)
+
+	enteredAt, err := adHocWorkoutDate("2023-11-02", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 11, 2, 14, 30, 0, 0, time.UTC), enteredAt)
+
+	enteredAt, err = adHocWorkoutDate("2024-05-10", now)
+	require.NoError(t, err)
+	assert.Equal(t, now, enteredAt)
+}
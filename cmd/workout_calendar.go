@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var workoutCalendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Show which workout falls on each upcoming training day",
+	Long: `Show the dates of your upcoming training days and the program day that falls on
+each, along with any training days missed since your last workout. Days your
+program was paused on aren't counted as missed.
+
+Training days default to Monday, Wednesday, and Friday. Use --days to choose
+your own; they're saved with your current program.`,
+	Example: `  greyskull workout calendar --weeks 2
+  greyskull workout calendar --days tue,thu,sat`,
+	Args: cobra.NoArgs,
+	RunE: showWorkoutCalendar,
+}
+
+func init() {
+	workoutCalendarCmd.Flags().Int("weeks", 4, "Number of weeks to show")
+	workoutCalendarCmd.Flags().String("days", "", "Training days to save, e.g. mon,wed,fri")
+}
+
+func showWorkoutCalendar(cmd *cobra.Command, args []string) error {
+	weeks, err := cmd.Flags().GetInt("weeks")
+	if err != nil {
+		return fmt.Errorf("failed to get weeks flag: %w", err)
+	}
+	if weeks <= 0 {
+		return fmt.Errorf("weeks must be positive, got: %d", weeks)
+	}
+	daysInput, err := cmd.Flags().GetString("days")
+	if err != nil {
+		return fmt.Errorf("failed to get days flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("days") {
+		days, err := models.ParseTrainingDays(daysInput)
+		if err != nil {
+			return err
+		}
+		userProgram.TrainingDays = days
+		if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries := workout.Calendar(userProgram, workout.LastTrainedAt(user, userProgram), len(program.Workouts), now, weeks)
+
+	if pause := userProgram.ActivePause(); pause != nil {
+		printf(cmd, "Paused since %s; upcoming dates assume you resume today.\n\n", config.DateFormat.Format(pause.StartedAt))
+	}
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.DisplayCalendar(entries, userProgram.TrainingDaysOrDefault(), now)
+
+	var trainingDays []string
+	for _, day := range userProgram.TrainingDaysOrDefault() {
+		trainingDays = append(trainingDays, day.String())
+	}
+	outputFor(cmd).Result(struct {
+		TrainingDays []string                `json:"training_days"`
+		Entries      []workout.CalendarEntry `json:"entries"`
+	}{trainingDays, nonNil(entries)})
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutCalendar(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "workout", "calendar", "--weeks", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Training days: Mon, Wed, Fri\n\n")
+	assert.Contains(t, output, "  Day 1")
+	assert.NotContains(t, output, "missed")
+
+	output, err = executePiped(t, "", "workout", "calendar", "--days", "sun,tue,thu,sat", "--weeks", "2")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Training days: Sun, Tue, Thu, Sat\n\n")
+	assert.Contains(t, output, "Day 6")
+	assert.Contains(t, output, "Day 1", "the calendar wraps back to the first day")
+
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	saved, err := repo.Get(t.Context(), user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Tuesday, time.Thursday, time.Saturday},
+		saved.Programs[user.CurrentProgram].TrainingDays)
+}
+
+func TestWorkoutCalendar_MissedDays(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].StartedAt = time.Now().AddDate(0, 0, -15)
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	output, err := executePiped(t, "", "workout", "calendar", "--days", "mon,tue,wed,thu,fri,sat,sun")
+	require.NoError(t, err)
+	assert.Contains(t, output, "missed\n")
+	assert.Contains(t, output, "14 missed sessions since your last workout.")
+}
+
+func TestWorkoutCalendar_InvalidFlags(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "workout", "calendar", "--weeks", "0")
+	assert.EqualError(t, err, "weeks must be positive, got: 0")
+
+	_, err = executePiped(t, "", "workout", "calendar", "--days", "someday")
+	assert.EqualError(t, err, `unknown training day "someday" (expected a weekday such as mon or monday)`)
+}
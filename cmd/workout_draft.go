@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/spf13/cobra"
+)
+
+// draftInputReader answers rep prompts from an interrupted session's draft until
+// its saved answers run out, then prompts as usual, recording each new answer to
+// the draft and saving it
+type draftInputReader struct {
+	InputReader
+	out   io.Writer
+	draft *models.LogDraft
+	next  int                          // Index of the next answer to replay
+	save  func(*models.LogDraft) error // nil when nothing should be saved
+}
+
+func newDraftInputReader(reader InputReader, out io.Writer, draft *models.LogDraft, save func(*models.LogDraft) error) *draftInputReader {
+	return &draftInputReader{InputReader: reader, out: out, draft: draft, save: save}
+}
+
+// ReadInt replays or reads and records an integer
+func (r *draftInputReader) ReadInt(prompt string) (int, error) {
+	return r.readReps(prompt, r.InputReader.ReadInt)
+}
+
+// ReadPositiveInt replays or reads and records a positive integer
+func (r *draftInputReader) ReadPositiveInt(prompt string) (int, error) {
+	return r.readReps(prompt, r.InputReader.ReadPositiveInt)
+}
+
+// ReadNonNegativeInt replays or reads and records an integer that may be zero
+func (r *draftInputReader) ReadNonNegativeInt(prompt string) (int, error) {
+	return r.readReps(prompt, r.InputReader.ReadNonNegativeInt)
+}
+
+// replaying reports whether saved answers are still being replayed
+func (r *draftInputReader) replaying() bool {
+	return r.next < len(r.draft.Answers)
+}
+
+func (r *draftInputReader) readReps(prompt string, read func(string) (int, error)) (int, error) {
+	if r.replaying() {
+		value := r.draft.Answers[r.next]
+		r.next++
+		fprintf(r.out, "%s%d (saved)\n", prompt, value)
+		return value, nil
+	}
+
+	value, err := read(prompt)
+	if err != nil {
+		return 0, err
+	}
+	r.draft.Answers = append(r.draft.Answers, value)
+	r.next++
+	if r.save != nil {
+		if err := r.save(r.draft); err != nil {
+			return 0, fmt.Errorf("failed to save progress: %w", err)
+		}
+	}
+	return value, nil
+}
+
+// skipReplayed wraps a rest timer so no rest is counted down between replayed answers
+func (r *draftInputReader) skipReplayed(rest func(models.SetType)) func(models.SetType) {
+	if rest == nil {
+		return nil
+	}
+	return func(setType models.SetType) {
+		if !r.replaying() {
+			rest(setType)
+		}
+	}
+}
+
+// resumableDraft returns the user's interrupted 'workout log' session if they
+// choose to resume it. Drafts that are declined or no longer match the current
+// day are discarded.
+func resumableDraft(cmd *cobra.Command, ctx *services.CommandContext, username string, userProgram *models.UserProgram, inputReader InputReader) (*models.LogDraft, error) {
+	if ctx.DraftRepo == nil {
+		return nil, nil
+	}
+	draft, err := ctx.DraftRepo.Get(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load unfinished workout: %w", err)
+	}
+	if draft == nil {
+		return nil, nil
+	}
+
+	if draft.Matches(userProgram) {
+		answers := i18n.T("answers")
+		if len(draft.Answers) == 1 {
+			answers = i18n.T("answer")
+		}
+		resume, err := inputReader.ReadConfirm(i18n.Sprintf("Resume your unfinished Day %d workout (%d %s saved)? [y/N] ",
+			draft.Workout.Day, len(draft.Answers), answers))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answer: %w", err)
+		}
+		if resume {
+			printf(cmd, "\n")
+			return draft, nil
+		}
+	}
+
+	return nil, discardDraft(ctx, username)
+}
+
+// draftSaver returns the function that saves a session's draft after each
+// answer, or nil when drafts aren't stored or nothing is being saved
+func draftSaver(ctx *services.CommandContext, username string, dryRun bool) func(*models.LogDraft) error {
+	if ctx.DraftRepo == nil || dryRun {
+		return nil
+	}
+	return func(draft *models.LogDraft) error {
+		draft.UpdatedAt = time.Now()
+		return ctx.DraftRepo.Save(username, draft)
+	}
+}
+
+// discardDraft removes the user's unfinished session, if any
+func discardDraft(ctx *services.CommandContext, username string) error {
+	if ctx.DraftRepo == nil {
+		return nil
+	}
+	if err := ctx.DraftRepo.Delete(username); err != nil {
+		return fmt.Errorf("failed to discard unfinished workout: %w", err)
+	}
+	return nil
+}
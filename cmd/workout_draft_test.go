@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestDraft(t *testing.T) *models.LogDraft {
+	t.Helper()
+	repo, err := repository.NewJSONDraftRepository()
+	require.NoError(t, err)
+	draft, err := repo.Get("TestUser")
+	require.NoError(t, err)
+	return draft
+}
+
+func amrapReps(workout models.Workout, lift models.LiftName) int {
+	return findSetsByType(findLiftByName(workout.Exercises, lift).Sets, models.AMRAPSet)[0].ActualReps
+}
+
+func TestWorkoutLog_ResumeInterruptedSession(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	// Input runs out after the OHP AMRAP, as if the terminal had closed
+	_, err := executePiped(t, "7\n", "workout", "log")
+	require.ErrorIs(t, err, ErrNoInput)
+	assert.Empty(t, loadTestUser(t).WorkoutHistory)
+
+	draft := loadTestDraft(t)
+	require.NotNil(t, draft)
+	assert.Equal(t, []int{7}, draft.Answers)
+	assert.Equal(t, 1, draft.Workout.Day)
+
+	output, err := executePiped(t, "y\n6\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Resume your unfinished Day 1 workout (1 answer saved)? [y/N] ")
+	assert.Contains(t, output, "How many reps did you complete for Overhead Press AMRAP set (5+)? 7 (saved)\n")
+	assert.Contains(t, output, "Workout logged successfully!")
+
+	workout := loadTestUser(t).WorkoutHistory[0]
+	assert.Equal(t, 7, amrapReps(workout, models.OverheadPress))
+	assert.Equal(t, 6, amrapReps(workout, models.Squat))
+	assert.Nil(t, loadTestDraft(t))
+}
+
+func TestWorkoutLog_ResumeKeepsSessionChoices(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "5\n5\n", "workout", "log", "--fail", "--quick")
+	require.Error(t, err)
+
+	// Resumed without flags, the session is still quick and asks for every set
+	output, err := executePiped(t, "y\n5\n5\n5\n5\n5\n5\n5\n5\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "(2 answers saved)")
+
+	workout := loadTestUser(t).WorkoutHistory[0]
+	assert.True(t, workout.Quick)
+	assert.Equal(t, 5, findLiftByName(workout.Exercises, models.OverheadPress).Sets[0].ActualReps)
+}
+
+func TestWorkoutLog_DeclineResume(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "7\n", "workout", "log")
+	require.Error(t, err)
+
+	_, err = executePiped(t, "n\n9\n6\n", "workout", "log")
+	require.NoError(t, err)
+
+	workout := loadTestUser(t).WorkoutHistory[0]
+	assert.Equal(t, 9, amrapReps(workout, models.OverheadPress))
+	assert.Nil(t, loadTestDraft(t))
+}
+
+func TestWorkoutLog_StaleDraftDiscarded(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	repo, err := repository.NewJSONDraftRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(user.Username, &models.LogDraft{
+		Workout: models.Workout{UserProgramID: user.CurrentProgram, Day: 2},
+		Answers: []int{12},
+	}))
+
+	output, err := executePiped(t, "8\n6\n", "workout", "log")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Resume")
+	assert.Equal(t, 8, amrapReps(loadTestUser(t).WorkoutHistory[0], models.OverheadPress))
+	assert.Nil(t, loadTestDraft(t))
+}
+
+func TestWorkoutLog_DryRunSavesNoDraft(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "7\n", "workout", "log", "--dry-run")
+	require.Error(t, err)
+	assert.Nil(t, loadTestDraft(t))
+}
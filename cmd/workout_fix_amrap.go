@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var workoutFixAMRAPCmd = &cobra.Command{
+	Use:   "fix-amrap <lift> <reps>",
+	Short: "Correct the AMRAP reps of your last workout",
+	Long: `Correct the reps of one lift's AMRAP set in the most recent workout of your
+current program, and update that lift's weight to what the corrected reps earn.
+
+Only the one set and the one lift change: the rest of the workout, your other
+lifts, and your next day are left as they are. The weight moves by the
+difference the correction makes, so a hold or other change made since the
+workout is kept, and a lift that was held in the workout keeps its weight.
+Your data is backed up first.`,
+	Example: `  greyskull workout fix-amrap squat 8
+  greyskull workout fix-amrap ohp 4 --program 2`,
+	Args: cobra.ExactArgs(2),
+	RunE: fixAMRAP,
+}
+
+func init() {
+	addProgramFlag(workoutFixAMRAPCmd)
+}
+
+func fixAMRAP(cmd *cobra.Command, args []string) error {
+	lift, err := models.ParseLiftName(args[0])
+	if err != nil {
+		return err
+	}
+	reps, err := strconv.Atoi(args[1])
+	if err != nil || reps < 0 {
+		return fmt.Errorf("invalid reps %q: must be a whole number, 0 or more", args[1])
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	user, userProgram, program, err := selectedProgram(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	if err := user.LoadHistory(); err != nil {
+		return fmt.Errorf("failed to load workout history: %w", err)
+	}
+
+	fix, err := workout.FixAMRAP(user, userProgram, program, lift, reps)
+	if err != nil {
+		return err
+	}
+	if err := backupUser(cmd, ctx, user.Username, "fix-amrap"); err != nil {
+		return err
+	}
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save workout: %w", err)
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	weights := weightFormat(config, userProgram.Unit)
+	printf(cmd, "Corrected %s AMRAP reps for Day %d on %s: %d → %d\n", display.FormatLiftName(fix.Lift),
+		fix.Day, config.DateFormat.Format(fix.EnteredAt), fix.PreviousReps, fix.Reps)
+	if fix.Weight != fix.PreviousWeight {
+		formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+		formatter.SetWeightFormat(weights)
+		formatter.DisplayWeightChanges(
+			map[models.LiftName]float64{fix.Lift: fix.PreviousWeight},
+			map[models.LiftName]float64{fix.Lift: fix.Weight})
+	} else {
+		printf(cmd, "%s stays at %s.\n", display.FormatLiftName(fix.Lift), weights.Format(fix.Weight))
+	}
+
+	outputFor(cmd).Result(fix)
+	return nil
+}
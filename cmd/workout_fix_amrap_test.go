@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutFixAMRAP(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	// Day 1 logged with 4 squat reps instead of 8
+	_, err := executePiped(t, "6\n4\n", "workout", "log")
+	require.NoError(t, err)
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	require.Equal(t, 120.0, userProgram.CurrentWeights[models.Squat])
+
+	output, err := executePiped(t, "", "workout", "fix-amrap", "squat", "8")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Corrected Squat AMRAP reps for Day 1 on ")
+	assert.Contains(t, output, ": 4 → 8\n\nWeight Updates:\nSquat: 120 → 140 lbs (+20.0)\n")
+
+	user = loadTestUser(t)
+	userProgram = user.Programs[user.CurrentProgram]
+	assert.Equal(t, 140.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 97.5, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 2, userProgram.CurrentDay)
+	assert.Equal(t, 8, amrapReps(user.WorkoutHistory[0], models.Squat))
+	assert.Equal(t, 6, amrapReps(user.WorkoutHistory[0], models.OverheadPress))
+
+	// Reps that earn the same weight leave it alone
+	output, err = executePiped(t, "", "workout", "fix-amrap", "squat", "7")
+	require.NoError(t, err)
+	assert.Contains(t, output, ": 8 → 7\nSquat stays at 140 lbs.\n")
+}
+
+func TestWorkoutFixAMRAP_HeldLift(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "lift", "hold", "squat", "--sessions", "1")
+	require.NoError(t, err)
+	_, err = executePiped(t, "6\n8\n", "workout", "log")
+	require.NoError(t, err)
+	user := loadTestUser(t)
+	require.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+
+	output, err := executePiped(t, "", "workout", "fix-amrap", "squat", "3")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up TestUser's data to ")
+	assert.Contains(t, output, ": 8 → 3\nSquat stays at 135 lbs.\n")
+
+	user = loadTestUser(t)
+	assert.Equal(t, 135.0, user.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+	assert.Equal(t, 3, amrapReps(user.WorkoutHistory[0], models.Squat))
+	assert.Contains(t, runBackupsList(t, ""), "fix-amrap")
+}
+
+func TestWorkoutFixAMRAP_JSON(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	_, err := executePiped(t, "6\n4\n", "workout", "log")
+	require.NoError(t, err)
+
+	stdout, _, err := executeJSON(t, "", "workout", "fix-amrap", "ohp", "12")
+	require.NoError(t, err)
+	var fix workout.AMRAPFix
+	require.NoError(t, json.Unmarshal([]byte(stdout), &fix))
+	assert.Equal(t, models.OverheadPress, fix.Lift)
+	assert.Equal(t, 6, fix.PreviousReps)
+	assert.Equal(t, 12, fix.Reps)
+	assert.Equal(t, 97.5, fix.PreviousWeight)
+	assert.Equal(t, 100.0, fix.Weight)
+}
+
+func TestWorkoutFixAMRAP_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "workout", "fix-amrap", "squat", "8")
+	assert.ErrorContains(t, err, "no workouts logged for this program yet")
+
+	_, err = executePiped(t, "6\n4\n", "workout", "log")
+	require.NoError(t, err)
+
+	_, err = executePiped(t, "", "workout", "fix-amrap", "bench", "8")
+	assert.ErrorContains(t, err, "has no AMRAP set for BenchPress")
+
+	_, err = executePiped(t, "", "workout", "fix-amrap", "squat", "lots")
+	assert.ErrorContains(t, err, `invalid reps "lots"`)
+
+	_, err = executePiped(t, "", "workout", "fix-amrap", "curls", "8")
+	assert.ErrorContains(t, err, "unknown lift")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/spf13/cobra"
+)
+
+var workoutHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recently logged workouts and their notes",
+	Long: `Show the most recently logged workouts in your current program, newest first,
+with each lift's working weight, the reps completed on its working sets, and any
+notes recorded with 'workout log --notes'.`,
+	Example: "  greyskull workout history --limit 10",
+	Args:    cobra.NoArgs,
+	RunE:    showWorkoutHistory,
+}
+
+func init() {
+	workoutHistoryCmd.Flags().Int("limit", 5, "Number of workouts to show")
+}
+
+func showWorkoutHistory(cmd *cobra.Command, args []string) error {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return fmt.Errorf("failed to get limit flag: %w", err)
+	}
+	if limit <= 0 {
+		return fmt.Errorf("limit must be positive, got: %d", limit)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, _, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+
+	history := user.HistoryFor(userProgram.ID)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetDateFormat(config.DateFormat)
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.DisplayHistory(history)
+	outputFor(cmd).Result(nonNil(history))
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutLog_Notes(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("7\n8\nLeft knee sore on squats\n  better after the second warmup\n\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+	require.NoError(t, cmd.Flags().Set("notes", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("notes", "false") })
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "\nNotes (finish with a blank line or '.'):\n")
+	assert.Contains(t, buf.String(), "Workout logged successfully!")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	assert.Equal(t, "Left knee sore on squats\n  better after the second warmup", user.WorkoutHistory[0].Notes)
+
+	buf.Reset()
+	historyCmd := workoutHistoryCmd
+	historyCmd.SetOut(&buf)
+	require.NoError(t, historyCmd.RunE(historyCmd, []string{}))
+	assert.Contains(t, buf.String(), "  Notes:\n    Left knee sore on squats\n      better after the second warmup\n")
+}
+
+func TestWorkoutHistory_Limit(t *testing.T) {
+	env := setupTestEnv(t)
+	createUserWithHistory(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutHistoryCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("limit", "1"))
+	t.Cleanup(func() { cmd.Flags().Set("limit", "5") })
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "2024-03-06  Day 2\n  Bench Press 125 lbs: 6\n  Deadlift 185 lbs: 5\n", buf.String())
+
+	require.NoError(t, cmd.Flags().Set("limit", "0"))
+	assert.ErrorContains(t, cmd.RunE(cmd, []string{}), "limit must be positive")
+}
@@ -1,13 +1,25 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/analytics"
 	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/hooks"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/milestones"
 	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
 	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/timer"
 	"github.com/mikowitz/greyskull/workout"
 	"github.com/spf13/cobra"
 )
@@ -18,116 +30,597 @@ var workoutLogCmd = &cobra.Command{
 	Long:  `Log a completed workout for your current program.
 
 By default, assumes all non-AMRAP sets were completed successfully.
-Use --fail flag to record individual reps for each set.`,
+Use --fail flag to record individual reps for each set.
+Use --dry-run to see what your AMRAP reps would do to your weights without
+saving the workout or advancing to the next day.
+
+Use --timer to count down a rest period after each prompted set: 1:30 after
+warmups and 3:00 after other sets unless your program or 'greyskull user timer'
+sets different times.
+
+Use --quick when you're short on time or feeling beat up: each lift's warmups are
+trimmed to the empty bar and the heaviest warmup, and the session is flagged as
+quick in your history. Working sets are unchanged.
+
+Use --date YYYY-MM-DD to log a workout on the day it actually happened, such as
+logging the next morning. You'll be asked to confirm the date unless --yes is
+given. The date can't be in the future or before your last logged workout.
+
+If the workout includes optional accessories, such as chin-ups, you'll be asked
+whether you did each one. Accessories you skip are left out of the workout.
+
+Use --notes to write notes about the session after entering your reps, such as
+how an injury felt or a technique cue. Notes can span several lines; finish with
+a blank line or a line containing only ".". They're shown by 'workout history'
+and included in 'export csv'.
+
+Use --bodyweight to record your body weight that day, in your program's unit.
+'greyskull share' shows its trend across recent workouts.
+
+Use --substitute LIFT=REPLACEMENT to perform another lift in place of one of
+the session's barbell lifts, such as --substitute squat=fsq for front squats on
+a day your back is sore. The replacement can be any built-in lift or one added
+with 'greyskull lift define'. Add @WEIGHT for its working weight, as in
+squat=fsq@115; otherwise you'll be asked for it. Give only the lift, as in
+--substitute squat, to be asked for the replacement too. The replacement is
+recorded in your history, and the original lift keeps its weight for next time.
+
+Use --program to log a workout of a program you train alongside your current
+one, by its index or ID from 'greyskull program list --mine' or its name.
+
+Your reps are saved after each answer. If logging is interrupted, such as by
+Ctrl-C or a closed terminal, the next 'workout log' offers to resume where you
+stopped, with the same date, --fail, and --quick choices as before.`,
 	RunE:  logWorkout,
 }
 
+// restSleep waits between rest timer updates; tests replace it to avoid waiting
+var restSleep = time.Sleep
+
 func init() {
 	workoutLogCmd.Flags().Bool("fail", false, "Record individual reps for each set")
+	workoutLogCmd.Flags().Bool("timer", false, "Count down a rest period after each prompted set")
+	workoutLogCmd.Flags().Bool("beep", false, "Ring the terminal bell when a rest period ends (with --timer)")
+	workoutLogCmd.Flags().Bool("quick", false, "Trim warmups to two sets for a short session")
+	workoutLogCmd.Flags().String("date", "", "Date the workout was performed (YYYY-MM-DD), defaults to today")
+	workoutLogCmd.Flags().BoolP("yes", "y", false, "Log a backdated workout without asking for confirmation")
+	workoutLogCmd.Flags().Bool("dry-run", false, "Show the resulting weight changes without saving the workout")
+	workoutLogCmd.Flags().Bool("notes", false, "Write notes about the workout after entering reps")
+	workoutLogCmd.Flags().StringArray("substitute", nil, "Perform a lift in place of one in the session, e.g. squat=fsq@115 (repeatable)")
+	workoutLogCmd.Flags().Float64("bodyweight", 0, "Your body weight today, in your program's unit")
+	workoutLogCmd.Flags().Int("max-attempts", DefaultMaxAttempts, "Attempts allowed per prompt before an invalid answer aborts (0 for no limit)")
+	addProgramFlag(workoutLogCmd)
 }
 
 func logWorkout(cmd *cobra.Command, args []string) error {
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
 
-	// Load current user, program, and user program in one call
-	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram()
+	// Load current user and the selected program in one call
+	user, userProgram, program, err := selectedProgram(cmd, ctx)
 	if err != nil {
 		return err
 	}
 
-	// Calculate and display the next workout
-	nextWorkout, err := workout.CalculateNextWorkout(user, program)
+	// A single reader serves every prompt so buffered input isn't lost between them
+	inputReader, err := promptReader(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to calculate next workout: %w", err)
+		return err
 	}
 
-	// Display the workout like the "next" command
-	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
-	formatter.DisplayWorkout(nextWorkout)
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+	bodyWeight, err := cmd.Flags().GetFloat64("bodyweight")
+	if err != nil {
+		return fmt.Errorf("failed to get bodyweight flag: %w", err)
+	}
+	if bodyWeight < 0 {
+		return fmt.Errorf("bodyweight must not be negative, got: %g", bodyWeight)
+	}
 
-	// Check for --fail flag to determine collection mode
-	failMode, err := cmd.Flags().GetBool("fail")
+	// Offer to pick up an interrupted session before asking anything else
+	draft, err := resumableDraft(cmd, ctx, user.Username, userProgram, inputReader)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
 	if err != nil {
-		return fmt.Errorf("failed to get fail flag: %w", err)
+		return err
+	}
+	weights := weightFormat(config, userProgram.Unit)
+	if draft != nil {
+		// The session is logged as it was first set up, whatever the flags now say
+		formatter := display.NewWorkoutFormatter(textAt(cmd, display.Normal))
+		formatter.SetWeightFormat(weights)
+		formatter.SetPlateHints(config, userProgram.Unit)
+		formatter.DisplayWorkout(&draft.Workout)
+	} else {
+		draft, err = prepareSession(cmd, ctx, user, userProgram, program, inputReader)
+		if err != nil || draft == nil {
+			return err
+		}
 	}
+	nextWorkout := &draft.Workout
+
+	rest, err := restTimer(cmd, user, program)
+	if err != nil {
+		return err
+	}
+
+	// Save each answer so an interrupted session can be resumed
+	answers := newDraftInputReader(inputReader, cmd.OutOrStdout(), draft, draftSaver(ctx, user.Username, dryRun))
+	rest = answers.skipReplayed(rest)
 
 	var completedWorkout *models.Workout
-	if failMode {
+	if draft.FailMode {
 		// Collect reps for every set individually
-		completedWorkout, err = collectWithFailure(cmd, nextWorkout)
+		completedWorkout, err = collectWithFailure(cmd, answers, nextWorkout, weights, rest)
 		if err != nil {
 			return fmt.Errorf("failed to collect workout data: %w", err)
 		}
 	} else {
 		// Collect AMRAP reps only (normal mode)
-		amrapReps, err := collectAMRAPReps(cmd, nextWorkout)
+		amrapReps, err := collectAMRAPReps(cmd, answers, nextWorkout, rest)
 		if err != nil {
 			return fmt.Errorf("failed to collect AMRAP reps: %w", err)
 		}
 		// Create completed workout with auto-completed sets
 		completedWorkout = buildCompletedWorkout(nextWorkout, amrapReps)
 	}
+	if !draft.EnteredAt.IsZero() {
+		completedWorkout.EnteredAt = draft.EnteredAt
+	}
+
+	withNotes, err := cmd.Flags().GetBool("notes")
+	if err != nil {
+		return fmt.Errorf("failed to get notes flag: %w", err)
+	}
+	if withNotes {
+		completedWorkout.Notes, err = inputReader.ReadMultiLine("\nNotes (finish with a blank line or '.'):\n")
+		if err != nil && !errors.Is(err, ErrNoInput) {
+			return fmt.Errorf("failed to read notes: %w", err)
+		}
+	}
+
+	completedWorkout.BodyWeight = bodyWeight
+
+	if err := recordWorkout(cmd, ctx, user, userProgram, program, completedWorkout, dryRun); err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	return discardDraft(ctx, user.Username)
+}
+
+// prepareSession sets up a new session to log: it settles the date, calculates
+// the workout with any --quick changes, displays it, and leaves out skipped
+// accessories. It returns the session as an empty draft, or nil if a backdated
+// log was declined.
+func prepareSession(cmd *cobra.Command, ctx *services.CommandContext, user *models.User, userProgram *models.UserProgram, program *models.Program, inputReader InputReader) (*models.LogDraft, error) {
+	dateInput, err := cmd.Flags().GetString("date")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date flag: %w", err)
+	}
+	skipConfirm, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	// Work out when the workout happened before asking for any reps
+	var enteredAt time.Time
+	if dateInput != "" {
+		enteredAt, err = workoutDate(dateInput, time.Now(), user)
+		if err != nil {
+			return nil, err
+		}
+		if !skipConfirm {
+			confirmed, err := inputReader.ReadConfirm(i18n.Sprintf("Log this workout for %s? [y/N] ", enteredAt.Format("Monday, 2006-01-02")))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read confirmation: %w", err)
+			}
+			if !confirmed {
+				printf(cmd, "Workout not logged.\n")
+				return nil, nil
+			}
+			printf(cmd, "\n")
+		}
+	}
+
+	// Calculate and display the next workout
+	nextWorkout, err := nextWorkoutFor(ctx, user, userProgram, program)
+	if err != nil {
+		return nil, err
+	}
+	// Catch templates that can't be progressed before asking for any reps
+	if err := workout.ValidateWorkout(nextWorkout, userProgram.Deload != nil); err != nil {
+		return nil, fmt.Errorf("can't log Day %d: %w", nextWorkout.Day, err)
+	}
 
-	// Add to user's workout history
-	user.WorkoutHistory = append(user.WorkoutHistory, *completedWorkout)
+	// Adjust the session before it is displayed and collected
+	modifiers, err := sessionModifiers(cmd)
+	if err != nil {
+		return nil, err
+	}
+	workout.ApplyModifiers(nextWorkout, modifiers...)
+	if err := applySubstitutions(cmd, inputReader, nextWorkout, userProgram.Unit); err != nil {
+		return nil, err
+	}
+
+	// Display the workout like the "next" command, unless quiet
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return nil, err
+	}
+	formatter := display.NewWorkoutFormatter(textAt(cmd, display.Normal))
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.SetPlateHints(config, userProgram.Unit)
+	formatter.DisplayWorkout(nextWorkout)
+	formatter.DisplayLoadWarnings(workout.LoadWarnings(nextWorkout, config, userProgram.Unit), userProgram.Unit)
+
+	// Leave out skipped accessories before asking for reps
+	if err := confirmOptionalLifts(inputReader, nextWorkout); err != nil {
+		return nil, err
+	}
 
-	// Calculate weight progression based on AMRAP performance
-	newWeights, err := workout.CalculateProgression(completedWorkout, userProgram.CurrentWeights, &program.ProgressionRules)
+	// Check for --fail flag to determine collection mode
+	failMode, err := cmd.Flags().GetBool("fail")
 	if err != nil {
-		return fmt.Errorf("failed to calculate progression: %w", err)
+		return nil, fmt.Errorf("failed to get fail flag: %w", err)
 	}
 
-	// Display weight changes
-	formatter.DisplayWeightChanges(userProgram.CurrentWeights, newWeights)
+	return &models.LogDraft{Workout: *nextWorkout, FailMode: failMode, EnteredAt: enteredAt}, nil
+}
 
-	// Update current weights
-	userProgram.CurrentWeights = newWeights
+// workoutLogResult is the result of logging a workout printed by --json
+type workoutLogResult struct {
+	Workout      *models.Workout             `json:"workout"`
+	Weights      map[models.LiftName]float64 `json:"weights"` // Working weights for the next session
+	NextDay      int                         `json:"next_day"`
+	Achievements []records.Achievement       `json:"achievements"`
+	Milestones   []milestones.Milestone      `json:"milestones"` // Milestones unlocked by the workout
+	DryRun       bool                        `json:"dry_run"`
+}
+
+// recordWorkout adds a completed workout to the user's history, applies progression,
+// saves the user, and displays the resulting weight changes and next workout day.
+// A dry run applies progression to a copy of the UserProgram and saves nothing.
+func recordWorkout(cmd *cobra.Command, ctx *services.CommandContext, user *models.User, userProgram *models.UserProgram, program *models.Program, completedWorkout *models.Workout, dryRun bool) error {
+	// Check for broken personal records before the workout joins the history
+	achievements := records.Broken(records.Compute(user.History()), completedWorkout)
+
+	if dryRun {
+		userProgram = userProgram.Clone()
+	}
 
-	// Increment CurrentDay (with wrapping)
-	nextDay := userProgram.CurrentDay + 1
-	if nextDay > len(program.Workouts) {
-		nextDay = 1
+	// Apply weight progression based on AMRAP performance and advance the day
+	oldWeights := userProgram.CurrentWeights
+	oldRepTargets := prescribedRepTargets(completedWorkout, userProgram.RepTargets)
+	deloading := userProgram.Deload != nil
+	var steps []workout.ProgressionStep
+	if !deloading {
+		rules := program.ProgressionRules.ForUserProgram(userProgram)
+		steps = workout.ExplainProgression(completedWorkout, oldWeights, rules, userProgram.Holds)
+	}
+	if err := workout.ApplyWorkout(userProgram, completedWorkout, program); err != nil {
+		return err
+	}
+	var unlocked []milestones.Milestone
+	if !dryRun {
+		// Add to user's workout history in date order, with the lifts it held
+		user.AddWorkout(*completedWorkout)
+		unlocked = milestones.Unlock(user)
+	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	weights := weightFormat(config, userProgram.Unit)
+	stepsFormatter := display.NewWorkoutFormatter(textAt(cmd, display.Verbose))
+	stepsFormatter.SetWeightFormat(weights)
+	stepsFormatter.DisplayProgressionSteps(steps)
+
+	// Display weight changes and any holds or deload still in effect
+	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weights)
+	formatter.DisplayWeightChanges(oldWeights, userProgram.CurrentWeights)
+	formatter.DisplayRepTargetChanges(oldRepTargets, userProgram.RepTargets)
+	if len(userProgram.Holds) > 0 {
+		formatter.Printf("\n")
+		formatter.DisplayHolds(userProgram.Holds)
+	}
+	if userProgram.Deload != nil {
+		formatter.Printf("\n")
+		formatter.DisplayDeload(userProgram.Deload)
+	} else if deloading {
+		formatter.Printf("\nDeload complete; normal programming resumes next session.\n")
+	}
+	displayTrainedGoals(cmd, user, userProgram, completedWorkout, oldWeights, weights)
+	displayStallWarnings(cmd, userProgram, oldWeights, weights)
+	result := workoutLogResult{
+		Workout:      completedWorkout,
+		Weights:      userProgram.CurrentWeights,
+		NextDay:      userProgram.CurrentDay,
+		Achievements: nonNil(achievements),
+		Milestones:   nonNil(unlocked),
+		DryRun:       dryRun,
+	}
+	outputFor(cmd).Result(result)
+
+	prs := display.NewRecordsFormatter(cmd.OutOrStdout())
+	prs.SetWeightFormat(weights)
+	if dryRun {
+		prs.DisplayAchievements(achievements)
+		printf(cmd, "\nDry run: workout not saved.\n")
+		printf(cmd, "Next workout would be: Day %d\n", userProgram.CurrentDay)
+		return nil
 	}
-	userProgram.CurrentDay = nextDay
 
 	// Save user
-	err = ctx.UserRepo.Update(user)
+	err = ctx.UserRepo.Update(contextFor(cmd), user)
 	if err != nil {
 		return fmt.Errorf("failed to save workout: %w", err)
 	}
 
+	// Celebrate any personal records and milestones
+	prs.DisplayAchievements(achievements)
+	display.NewMilestoneFormatter(cmd.OutOrStdout()).DisplayUnlocked(unlocked)
+
 	// Show completion summary
-	cmd.Printf("\nWorkout logged successfully!\n")
-	cmd.Printf("Next workout: Day %d\n", nextDay)
+	printf(cmd, "\nWorkout logged successfully!\n")
+	printf(cmd, "Next workout: Day %d\n", userProgram.CurrentDay)
 
+	return runPostLogHooks(cmd, ctx, user, result)
+}
+
+// postLogPayload is the JSON sent to post_log hooks
+type postLogPayload struct {
+	Event    string `json:"event"`
+	Username string `json:"username"`
+	workoutLogResult
+}
+
+// runPostLogHooks runs the user's post_log hooks for a saved workout. The
+// workout is already saved, so a failing hook is only warned about.
+func runPostLogHooks(cmd *cobra.Command, ctx *services.CommandContext, user *models.User, result workoutLogResult) error {
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	if len(config.Hooks.PostLog) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(postLogPayload{Event: hooks.PostLog, Username: user.Username, workoutLogResult: result})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+	for _, hook := range config.Hooks.PostLog {
+		if err := hooks.Run(hook, hooks.PostLog, payload); err != nil {
+			printf(cmd, "Warning: post_log hook %q failed: %v\n", hook, err)
+			continue
+		}
+		fprintf(textAt(cmd, display.Verbose), "Ran post_log hook %q\n", hook)
+	}
 	return nil
 }
 
+// displayStallWarnings warns about each stalled lift that deloaded in this session
+func displayStallWarnings(cmd *cobra.Command, userProgram *models.UserProgram, oldWeights map[models.LiftName]float64, weights display.WeightFormat) {
+	for _, stall := range analytics.Stalls(userProgram) {
+		if stall.Stalled() && userProgram.CurrentWeights[stall.Lift] < oldWeights[stall.Lift] {
+			printf(cmd, "\n%s\n", display.FormatStallWarning(stall, weights))
+		}
+	}
+}
 
-// collectAMRAPReps prompts user for AMRAP set completion
-func collectAMRAPReps(cmd *cobra.Command, nextWorkout *models.Workout) (map[models.LiftName]int, error) {
-	amrapReps := make(map[models.LiftName]int)
+// prescribedRepTargets returns the rep targets a workout was prescribed: the
+// stored targets, plus the AMRAP reps of bodyweight lifts without one yet
+func prescribedRepTargets(completed *models.Workout, targets map[models.LiftName]int) map[models.LiftName]int {
+	prescribed := maps.Clone(targets)
+	for _, lift := range completed.Exercises {
+		if _, exists := prescribed[lift.WeightKey()]; exists || !lift.Bodyweight {
+			continue
+		}
+		for _, set := range lift.Sets {
+			if set.Type == models.AMRAPSet {
+				if prescribed == nil {
+					prescribed = make(map[models.LiftName]int)
+				}
+				prescribed[lift.WeightKey()] = set.TargetReps
+			}
+		}
+	}
+	return prescribed
+}
+
+// workoutDate parses a --date value into the time a backdated workout is entered at.
+// The date keeps the current time of day so workouts logged for the same day stay
+// in order, and a workout on the same day as the last logged one is placed after it.
+func workoutDate(input string, now time.Time, user *models.User) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", input, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", input)
+	}
+
+	enteredAt := time.Date(date.Year(), date.Month(), date.Day(),
+		now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), now.Location())
+	if enteredAt.After(now) {
+		if date.After(now) {
+			return time.Time{}, fmt.Errorf("--date %s is in the future", input)
+		}
+		enteredAt = now
+	}
+
+	if last, ok := user.LastWorkout(); ok && !enteredAt.After(last.EnteredAt) {
+		lastDate := last.EnteredAt.In(now.Location())
+		if date.Before(time.Date(lastDate.Year(), lastDate.Month(), lastDate.Day(), 0, 0, 0, 0, now.Location())) {
+			return time.Time{}, fmt.Errorf("--date %s is before your last logged workout on %s",
+				input, lastDate.Format("2006-01-02"))
+		}
+		enteredAt = last.EnteredAt.Add(time.Second)
+	}
+
+	return enteredAt, nil
+}
 
-	// Create input reader for user interaction
-	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
+// sessionModifiers returns the modifiers selected by flags for this session
+func sessionModifiers(cmd *cobra.Command) ([]workout.SessionModifier, error) {
+	var modifiers []workout.SessionModifier
+
+	quick, err := cmd.Flags().GetBool("quick")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quick flag: %w", err)
+	}
+	if quick {
+		modifiers = append(modifiers, workout.QuickSession)
+	}
+
+	return modifiers, nil
+}
+
+// applySubstitutions swaps the lifts named by --substitute flags in a session
+// for their replacements. Flags give "lift=replacement@weight"; the replacement
+// and weight are asked for when left out.
+func applySubstitutions(cmd *cobra.Command, inputReader InputReader, session *models.Workout, unit models.WeightUnit) error {
+	substitutions, err := cmd.Flags().GetStringArray("substitute")
+	if err != nil {
+		return fmt.Errorf("failed to get substitute flag: %w", err)
+	}
+
+	unit = unit.OrDefault()
+	for _, input := range substitutions {
+		originalInput, replacementInput, _ := strings.Cut(input, "=")
+		replacementInput, weightInput, hasWeight := strings.Cut(replacementInput, "@")
+
+		original, err := models.ParseLiftName(originalInput)
+		if err != nil {
+			return fmt.Errorf("invalid substitution %q: %w", input, err)
+		}
+		index, err := workout.FindSubstitutable(session, original)
+		if err != nil {
+			return err
+		}
+		name := display.FormatLiftName(session.Exercises[index].WeightKey())
+
+		if strings.TrimSpace(replacementInput) == "" {
+			replacementInput, err = inputReader.ReadLine(i18n.Sprintf("What are you doing in place of %s? ", name))
+			if err != nil {
+				return fmt.Errorf("failed to read substitute for %s: %w", name, err)
+			}
+		}
+		replacement, err := models.ParseLiftName(replacementInput)
+		if err != nil {
+			return fmt.Errorf("invalid substitution %q: %w", input, err)
+		}
+		if replacement == session.Exercises[index].LiftName {
+			return fmt.Errorf("can't substitute %s for itself", name)
+		}
+		if slices.ContainsFunc(session.Exercises, func(lift models.Lift) bool { return lift.WeightKey() == replacement }) {
+			return fmt.Errorf("can't substitute %s for %s: it's already in the session", display.FormatLiftName(replacement), name)
+		}
+
+		var weight float64
+		if hasWeight {
+			weight, err = strconv.ParseFloat(strings.TrimSpace(weightInput), 64)
+			if err != nil || weight <= 0 {
+				return fmt.Errorf("invalid substitution %q: weight must be a positive number", input)
+			}
+		} else {
+			weight, err = inputReader.ReadPositiveFloat(i18n.Sprintf("Working weight for %s (%s): ", display.FormatLiftName(replacement), unit))
+			if err != nil {
+				return fmt.Errorf("failed to read weight for %s: %w", display.FormatLiftName(replacement), err)
+			}
+		}
+
+		workout.ApplyModifiers(session, workout.Substitute(index, replacement, weight, unit))
+	}
+	return nil
+}
+
+// promptReader returns the reader for a workout's prompts, which re-prompts on
+// invalid answers up to the --max-attempts flag's limit
+func promptReader(cmd *cobra.Command) (InputReader, error) {
+	maxAttempts, err := cmd.Flags().GetInt("max-attempts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max-attempts flag: %w", err)
+	}
+	return NewRetryingInputReader(NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout()), cmd.OutOrStdout(), maxAttempts), nil
+}
+
+// confirmOptionalLifts asks whether each optional accessory in a session was
+// performed, removing the ones that weren't
+func confirmOptionalLifts(inputReader InputReader, session *models.Workout) error {
+	performed := make([]models.Lift, 0, len(session.Exercises))
+	for _, exercise := range session.Exercises {
+		if exercise.Optional {
+			done, err := inputReader.ReadConfirm(i18n.Sprintf("Did you do %s? [y/N] ", display.FormatLiftName(exercise.WeightKey())))
+			if err != nil {
+				return fmt.Errorf("failed to read answer for %s: %w", display.FormatLiftName(exercise.WeightKey()), err)
+			}
+			if !done {
+				continue
+			}
+		}
+		performed = append(performed, exercise)
+	}
+	session.Exercises = performed
+	return nil
+}
+
+// restTimer returns the function that counts down a rest period after a prompted
+// set of the given type, or nil when --timer is not set
+func restTimer(cmd *cobra.Command, user *models.User, program *models.Program) (func(models.SetType), error) {
+	enabled, err := cmd.Flags().GetBool("timer")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timer flag: %w", err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+	beep, err := cmd.Flags().GetBool("beep")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get beep flag: %w", err)
+	}
+
+	rests := timer.RestsFor(user, program)
+	countdown := timer.NewCountdown(cmd.OutOrStdout(), beep, restSleep)
+	return func(setType models.SetType) {
+		countdown.Run(rests.After(setType))
+	}, nil
+}
+
+// collectAMRAPReps prompts user for AMRAP set completion, resting between prompts
+// when rest is non-nil
+func collectAMRAPReps(cmd *cobra.Command, inputReader InputReader, nextWorkout *models.Workout, rest func(models.SetType)) (map[models.LiftName]int, error) {
+	amrapReps := make(map[models.LiftName]int)
+	prompted := false
 
 	for _, exercise := range nextWorkout.Exercises {
 		// Find AMRAP sets
 		for _, set := range exercise.Sets {
 			if set.Type == models.AMRAPSet {
-				prompt := fmt.Sprintf("How many reps did you complete for %s AMRAP set (%d+)? ", 
-					display.FormatLiftName(exercise.LiftName), set.TargetReps)
+				// Rest after the previous prompted set; none follows the last one
+				if prompted && rest != nil {
+					rest(models.AMRAPSet)
+				}
+				prompted = true
+
+				prompt := i18n.Sprintf("How many reps did you complete for %s AMRAP set (%s+)? ", 
+					display.FormatLiftName(exercise.WeightKey()), display.FormatTargetReps(set))
 				
 				value, err := inputReader.ReadPositiveInt(prompt)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read AMRAP reps for %s: %w", exercise.LiftName, err)
+					return nil, fmt.Errorf("failed to read AMRAP reps for %s: %w", display.FormatLiftName(exercise.WeightKey()), err)
 				}
 				
-				amrapReps[exercise.LiftName] = value
+				amrapReps[exercise.WeightKey()] = value
 				break // Only one AMRAP set per exercise
 			}
 		}
@@ -136,11 +629,10 @@ func collectAMRAPReps(cmd *cobra.Command, nextWorkout *models.Workout) (map[mode
 	return amrapReps, nil
 }
 
-// collectWithFailure prompts user for actual reps on every set
-func collectWithFailure(cmd *cobra.Command, nextWorkout *models.Workout) (*models.Workout, error) {
-	// Create input reader for user interaction
-	inputReader := NewCLIInputReader(cmd.InOrStdin(), cmd.OutOrStdout())
-
+// collectWithFailure prompts user for actual reps on every set, resting between
+// prompts when rest is non-nil. Sets are prompted in the order they're performed,
+// so the lifts of a superset alternate.
+func collectWithFailure(cmd *cobra.Command, inputReader InputReader, nextWorkout *models.Workout, weights display.WeightFormat, rest func(models.SetType)) (*models.Workout, error) {
 	// Create completed workout structure
 	completed := &models.Workout{
 		ID:            uuid.Must(uuid.NewV7()),
@@ -148,56 +640,85 @@ func collectWithFailure(cmd *cobra.Command, nextWorkout *models.Workout) (*model
 		Day:           nextWorkout.Day,
 		Exercises:     make([]models.Lift, len(nextWorkout.Exercises)),
 		EnteredAt:     time.Now(),
+		Quick:         nextWorkout.Quick,
 	}
-
 	for i, exercise := range nextWorkout.Exercises {
-		cmd.Printf("\n%s:\n", display.FormatLiftName(exercise.LiftName))
-		
-		completedExercise := models.Lift{
-			ID:       uuid.Must(uuid.NewV7()),
-			LiftName: exercise.LiftName,
-			Sets:     make([]models.Set, len(exercise.Sets)),
+		completed.Exercises[i] = models.Lift{
+			ID:             uuid.Must(uuid.NewV7()),
+			LiftName:       exercise.LiftName,
+			Variant:        exercise.Variant,
+			Optional:       exercise.Optional,
+			Bodyweight:     exercise.Bodyweight,
+			FixedWeight:    exercise.FixedWeight,
+			Sets:           make([]models.Set, len(exercise.Sets)),
+			Group:          exercise.Group,
+			SubstitutedFor: exercise.SubstitutedFor,
 		}
+	}
 
-		for j, set := range exercise.Sets {
-			// Format set type for display
-			setTypeStr := "Working"
-			if set.Type == models.WarmupSet {
-				setTypeStr = "Warmup"
-			} else if set.Type == models.AMRAPSet {
-				setTypeStr = "AMRAP"
-			}
-
-			prompt := fmt.Sprintf("%s - Set %d (%s):\nTarget: %d reps @ %s lbs\nHow many reps completed? ", 
-				display.FormatLiftName(exercise.LiftName), 
-				set.Order,
-				setTypeStr,
-				set.TargetReps, 
-				display.FormatWeight(set.Weight))
-			
-			value, err := inputReader.ReadInt(prompt)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read reps for %s set %d: %w", exercise.LiftName, set.Order, err)
-			}
+	// Rest after each prompted set; none follows the last one
+	var previousType models.SetType
+	restAfterPrevious := func() {
+		if rest != nil && previousType != "" {
+			rest(previousType)
+		}
+	}
 
-			if value < 0 {
-				return nil, fmt.Errorf("number cannot be negative for %s set %d", exercise.LiftName, set.Order)
-			}
-			
-			// Create completed set
-			completedSet := models.Set{
-				ID:         uuid.Must(uuid.NewV7()),
-				Weight:     set.Weight,
-				TargetReps: set.TargetReps,
-				ActualReps: value, // Use the actual reps entered by user
-				Type:       set.Type,
-				Order:      set.Order,
+	currentLift := -1
+	for _, ref := range nextWorkout.PerformanceOrder() {
+		exercise := nextWorkout.Exercises[ref.Lift]
+		set := exercise.Sets[ref.Set]
+
+		restAfterPrevious()
+		if ref.Lift != currentLift {
+			currentLift = ref.Lift
+			name := display.FormatPerformedLift(&exercise)
+			if label := nextWorkout.GroupLabel(ref.Lift); label != "" {
+				name = label + ". " + name
 			}
+			printf(cmd, "\n%s:\n", name)
+		}
 
-			completedExercise.Sets[j] = completedSet
+		// Format set type for display
+		setTypeStr := "Working"
+		switch set.Type {
+		case models.WarmupSet:
+			setTypeStr = "Warmup"
+		case models.AMRAPSet:
+			setTypeStr = "AMRAP"
+		case models.FeelerSet:
+			setTypeStr = "Feeler"
 		}
 
-		completed.Exercises[i] = completedExercise
+		reps := display.FormatTargetReps(set)
+		target := i18n.Sprintf("%s reps @ %s", reps, weights.Format(set.Weight))
+		if exercise.Optional {
+			target = i18n.Sprintf("%s reps", reps)
+		} else if exercise.Bodyweight {
+			target = i18n.Sprintf("%s reps @ %s", reps, display.FormatAddedWeight(set.Weight, weights))
+		}
+		prompt := i18n.Sprintf("%s - Set %d (%s):\nTarget: %s\nHow many reps completed? ", 
+			display.FormatLiftName(exercise.WeightKey()), 
+			set.Order,
+			setTypeStr,
+			target)
+		
+		value, err := inputReader.ReadNonNegativeInt(prompt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read reps for %s set %d: %w", display.FormatLiftName(exercise.WeightKey()), set.Order, err)
+		}
+		previousType = set.Type
+		
+		// Create completed set
+		completed.Exercises[ref.Lift].Sets[ref.Set] = models.Set{
+			ID:         uuid.Must(uuid.NewV7()),
+			Weight:     set.Weight,
+			TargetReps: set.TargetReps,
+			MaxReps:    set.MaxReps,
+			ActualReps: value, // Use the actual reps entered by user
+			Type:       set.Type,
+			Order:      set.Order,
+		}
 	}
 
 	return completed, nil
@@ -212,13 +733,20 @@ func buildCompletedWorkout(template *models.Workout, amrapReps map[models.LiftNa
 		Day:           template.Day,
 		Exercises:     make([]models.Lift, len(template.Exercises)),
 		EnteredAt:     time.Now(),
+		Quick:         template.Quick,
 	}
 
 	for i, exercise := range template.Exercises {
 		completedExercise := models.Lift{
-			ID:       uuid.Must(uuid.NewV7()),
-			LiftName: exercise.LiftName,
-			Sets:     make([]models.Set, len(exercise.Sets)),
+			ID:             uuid.Must(uuid.NewV7()),
+			LiftName:       exercise.LiftName,
+			Variant:        exercise.Variant,
+			Optional:       exercise.Optional,
+			Bodyweight:     exercise.Bodyweight,
+			FixedWeight:    exercise.FixedWeight,
+			Sets:           make([]models.Set, len(exercise.Sets)),
+			Group:          exercise.Group,
+			SubstitutedFor: exercise.SubstitutedFor,
 		}
 
 		for j, set := range exercise.Sets {
@@ -226,6 +754,7 @@ func buildCompletedWorkout(template *models.Workout, amrapReps map[models.LiftNa
 				ID:         uuid.Must(uuid.NewV7()),
 				Weight:     set.Weight,
 				TargetReps: set.TargetReps,
+				MaxReps:    set.MaxReps,
 				Type:       set.Type,
 				Order:      set.Order,
 			}
@@ -233,7 +762,7 @@ func buildCompletedWorkout(template *models.Workout, amrapReps map[models.LiftNa
 			// Set ActualReps based on set type
 			if set.Type == models.AMRAPSet {
 				// Use AMRAP reps from user input
-				completedSet.ActualReps = amrapReps[exercise.LiftName]
+				completedSet.ActualReps = amrapReps[exercise.WeightKey()]
 			} else {
 				// Auto-complete non-AMRAP sets
 				completedSet.ActualReps = set.TargetReps
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutLog_PostLogHooks(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	saved := filepath.Join(env.tempDir, "workout.json")
+
+	_, err := executePiped(t, "", "config", "set", "hooks.post_log", server.URL, "cat > "+saved, "exit 1")
+	require.NoError(t, err)
+
+	output, err := executePiped(t, "7\n8\n", "workout", "log", "--verbose")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Workout logged successfully!")
+	assert.Contains(t, output, "Ran post_log hook \""+server.URL+"\"\n")
+	assert.Contains(t, output, "Warning: post_log hook \"exit 1\" failed: command failed: exit status 1\n")
+
+	var payload struct {
+		Event    string `json:"event"`
+		Username string `json:"username"`
+		Workout  struct {
+			Day int `json:"day"`
+		} `json:"workout"`
+		Weights map[string]float64 `json:"weights"`
+		NextDay int                `json:"next_day"`
+	}
+	require.NoError(t, json.Unmarshal(posted, &payload))
+	assert.Equal(t, "post_log", payload.Event)
+	assert.Equal(t, "TestUser", payload.Username)
+	assert.Equal(t, 1, payload.Workout.Day)
+	assert.Equal(t, 97.5, payload.Weights["OverheadPress"])
+	assert.Equal(t, 2, payload.NextDay)
+
+	data, err := os.ReadFile(saved)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(posted), string(data))
+}
+
+func TestWorkoutLog_DryRunSkipsHooks(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	saved := filepath.Join(env.tempDir, "workout.json")
+
+	_, err := executePiped(t, "", "config", "set", "hooks.post_log", "cat > "+saved)
+	require.NoError(t, err)
+
+	_, err = executePiped(t, "7\n8\n", "workout", "log", "--dry-run")
+	require.NoError(t, err)
+	assert.NoFileExists(t, saved)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var workoutLogQuickCmd = &cobra.Command{
+	Use:   "quick <shorthand>",
+	Short: "Log a completed workout from a shorthand string",
+	Long: `Log a completed workout without any prompts by describing it in shorthand.
+
+Each lift is written as the lift name, its working weight, and the reps completed
+for every working set, including the AMRAP set. Lifts are separated by semicolons:
+
+  ohp 95x5,5,8; squat 135x5,5,9
+
+Every lift in the next workout must be listed at its prescribed weight. Warmup sets
+and feeler singles are recorded as completed. Optional accessories can't be written
+in shorthand and are recorded as not performed. Variants are written after a colon,
+e.g. "squat:ssb 135x5,5,7".
+
+Use --dry-run to see what the workout would do to your weights without saving it.
+Use --program to log a workout of a program you train alongside your current one.`,
+	Example: `  greyskull workout log quick "ohp 95x5,5,8; squat 135x5,5,9"
+  greyskull workout log quick --dry-run "ohp 95x5,5,8; squat 135x5,5,9"`,
+	Args: cobra.ExactArgs(1),
+	RunE: logWorkoutQuick,
+}
+
+func init() {
+	workoutLogQuickCmd.Flags().Bool("dry-run", false, "Show the resulting weight changes without saving the workout")
+	addProgramFlag(workoutLogQuickCmd)
+}
+
+func logWorkoutQuick(cmd *cobra.Command, args []string) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+
+	// Parse before loading anything so syntax errors are reported immediately
+	entries, err := workout.ParseShorthand(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid shorthand: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user and the selected program in one call
+	user, userProgram, program, err := selectedProgram(cmd, ctx)
+	if err != nil {
+		return err
+	}
+
+	nextWorkout, err := nextWorkoutFor(ctx, user, userProgram, program)
+	if err != nil {
+		return err
+	}
+	if err := workout.ValidateWorkout(nextWorkout, userProgram.Deload != nil); err != nil {
+		return fmt.Errorf("can't log Day %d: %w", nextWorkout.Day, err)
+	}
+
+	completedWorkout, err := workout.BuildFromShorthand(nextWorkout, entries)
+	if err != nil {
+		return fmt.Errorf("shorthand doesn't match the next workout: %w", err)
+	}
+
+	printf(cmd, "Logging Day %d workout.\n\n", completedWorkout.Day)
+
+	return recordWorkout(cmd, ctx, user, userProgram, program, completedWorkout, dryRun)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutLogQuick(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutLogQuickCmd
+	cmd.SetOut(&buf)
+
+	err := cmd.RunE(cmd, []string{"squat 135x5,5,12; ohp 95x5,5,8"})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Logging Day 1 workout.")
+	assert.Contains(t, output, "Workout logged successfully!")
+	assert.Contains(t, output, "Next workout: Day 2")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	logged := user.WorkoutHistory[0]
+	require.Len(t, logged.Exercises, 2)
+
+	ohp := findLiftByName(logged.Exercises, models.OverheadPress)
+	require.NotNil(t, ohp)
+	amrap := ohp.Sets[len(ohp.Sets)-1]
+	assert.Equal(t, models.AMRAPSet, amrap.Type)
+	assert.Equal(t, 8, amrap.ActualReps)
+
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 97.5, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 145.0, userProgram.CurrentWeights[models.Squat])
+}
+
+func TestWorkoutLogQuick_DryRun(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "", "workout", "log", "quick", "--dry-run", "squat 135x5,5,12; ohp 95x5,5,8")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Logging Day 1 workout.")
+	assert.Contains(t, output, "Dry run: workout not saved.")
+	assert.Contains(t, output, "Next workout would be: Day 2")
+
+	saved := loadTestUser(t)
+	assert.Empty(t, saved.WorkoutHistory)
+	assert.Equal(t, 1, saved.Programs[user.CurrentProgram].CurrentDay)
+	assert.Equal(t, 135.0, saved.Programs[user.CurrentProgram].CurrentWeights[models.Squat])
+}
+
+func TestWorkoutLogQuick_Errors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"syntax error", "ohp 95", `invalid shorthand: entry 1 ("ohp 95"): expected sets as <weight>x<reps>`},
+		{"wrong day", "bench 125x5,5,5; deadlift 185x5", "Bench Press is not part of the Day 1 workout (Overhead Press, Squat)"},
+		{"wrong weight", "ohp 95x5,5,8; squat 140x5,5,5", "expected Squat at 135 lbs, got 140"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := workoutLogQuickCmd
+			cmd.SetOut(&bytes.Buffer{})
+
+			err := cmd.RunE(cmd, []string{tt.input})
+			assert.ErrorContains(t, err, tt.expected)
+
+			assert.Empty(t, loadTestUser(t).WorkoutHistory)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -44,10 +47,10 @@ func TestWorkoutLog_NoActiveProgram(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	cmd := workoutLogCmd
@@ -99,10 +102,10 @@ func TestWorkoutLog_SuccessfulLoggingFlow(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// The command should exist and be callable
@@ -163,10 +166,10 @@ func TestWorkoutLog_WorkoutSavedToHistory(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Verify initial state
@@ -185,8 +188,9 @@ func TestWorkoutLog_WorkoutSavedToHistory(t *testing.T) {
 	require.NoError(t, err, "Workout log command should complete successfully")
 
 	// Reload user from repository to check saved state
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	// Verify workout was saved to history
 	assert.Len(t, updatedUser.WorkoutHistory, 1, "WorkoutHistory should have 1 workout after logging")
@@ -267,10 +271,10 @@ func TestWorkoutLog_CurrentDayIncrements(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Verify initial state
@@ -289,8 +293,9 @@ func TestWorkoutLog_CurrentDayIncrements(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check updated state
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	updatedProgram := updatedUser.Programs[userProgram.ID]
 	assert.Equal(t, 4, updatedProgram.CurrentDay, "CurrentDay should increment from 3 to 4")
@@ -334,10 +339,10 @@ func TestWorkoutLog_CurrentDayWrapsAroundAfterDay6(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Verify initial state
@@ -356,8 +361,9 @@ func TestWorkoutLog_CurrentDayWrapsAroundAfterDay6(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check updated state
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	updatedProgram := updatedUser.Programs[userProgram.ID]
 	assert.Equal(t, 1, updatedProgram.CurrentDay, "CurrentDay should wrap from 6 to 1")
@@ -401,10 +407,10 @@ func TestWorkoutLog_CompletionSummary(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input for Day 5 exercises (OverheadPress, Deadlift)
@@ -463,10 +469,10 @@ func TestWorkoutLog_DisplaysWorkoutLikeNextCommand(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input
@@ -603,10 +609,10 @@ func TestWorkoutLog_AMRAPPromptText(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input
@@ -667,10 +673,10 @@ func TestWorkoutLog_AutoCompleteNonAMRAPSets(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input only (should only prompt for AMRAP sets)
@@ -686,8 +692,9 @@ func TestWorkoutLog_AutoCompleteNonAMRAPSets(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check saved workout
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	require.Len(t, updatedUser.WorkoutHistory, 1)
 	workout := updatedUser.WorkoutHistory[0]
@@ -749,10 +756,10 @@ func TestWorkoutLog_WeightProgressionNormalIncrease(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input: 6 reps for OverheadPress, 7 reps for Squat (normal progression)
@@ -768,8 +775,9 @@ func TestWorkoutLog_WeightProgressionNormalIncrease(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check progression
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	updatedProgram := updatedUser.Programs[userProgram.ID]
 
@@ -828,10 +836,10 @@ func TestWorkoutLog_WeightProgressionDoubleIncrease(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input: 12 reps for OverheadPress, 15 reps for Squat (double progression)
@@ -847,8 +855,9 @@ func TestWorkoutLog_WeightProgressionDoubleIncrease(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check progression
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	updatedProgram := updatedUser.Programs[userProgram.ID]
 
@@ -903,10 +912,10 @@ func TestWorkoutLog_WeightProgressionDeload(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input: 3 reps for OverheadPress, 4 reps for Squat (deload)
@@ -922,8 +931,9 @@ func TestWorkoutLog_WeightProgressionDeload(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check progression
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	updatedProgram := updatedUser.Programs[userProgram.ID]
 
@@ -937,6 +947,32 @@ func TestWorkoutLog_WeightProgressionDeload(t *testing.T) {
 	assert.Contains(t, output, "Squat: 135 → 120 lbs (-15.0)", "Should show Squat deload")
 }
 
+func TestWorkoutLog_StallWarning(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].DeloadStreaks = map[models.LiftName]models.DeloadStreak{
+		models.Squat:         {Deloads: 2, Weight: 150},
+		models.OverheadPress: {Deloads: 2, Weight: 100},
+	}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// OverheadPress progresses while still short of 100; Squat deloads a third time
+	output, err := executePiped(t, "7\n3\n", "workout", "log")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Warning: Squat has deloaded 3 times in a row without getting past 150 lbs.\n")
+	assert.NotContains(t, output, "Warning: Overhead Press")
+
+	updatedUser, err := repo.Get(t.Context(), user.Username)
+	require.NoError(t, err)
+	assert.Equal(t, map[models.LiftName]models.DeloadStreak{
+		models.Squat:         {Deloads: 3, Weight: 150},
+		models.OverheadPress: {Deloads: 2, Weight: 100},
+	}, updatedUser.Programs[user.CurrentProgram].DeloadStreaks)
+}
+
 func TestWorkoutLog_SetsHaveUUIDsAndCorrectData(t *testing.T) {
 	_ = setupTestEnv(t)
 
@@ -975,10 +1011,10 @@ func TestWorkoutLog_SetsHaveUUIDsAndCorrectData(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Mock AMRAP input
@@ -994,8 +1030,9 @@ func TestWorkoutLog_SetsHaveUUIDsAndCorrectData(t *testing.T) {
 	require.NoError(t, err)
 
 	// Reload user to check saved workout
-	updatedUser, err := repo.Get("TestUser")
+	updatedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 
 	require.Len(t, updatedUser.WorkoutHistory, 1)
 	savedWorkout := updatedUser.WorkoutHistory[0]
@@ -1068,8 +1105,9 @@ func TestWorkoutLog_DefaultModeStillWorks(t *testing.T) {
 	
 	// Verify workout was logged
 	repo, _ := repository.NewJSONUserRepository()
-	updatedUser, err := repo.Get(user.Username)
+	updatedUser, err := repo.Get(t.Context(), user.Username)
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 	
 	assert.Len(t, updatedUser.WorkoutHistory, 1)
 	loggedWorkout := updatedUser.WorkoutHistory[0]
@@ -1112,8 +1150,9 @@ func TestWorkoutLog_FailFlagChangeBehavior(t *testing.T) {
 	
 	// Verify workout was logged
 	repo, _ := repository.NewJSONUserRepository()
-	updatedUser, err := repo.Get(user.Username)
+	updatedUser, err := repo.Get(t.Context(), user.Username)
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 	
 	assert.Len(t, updatedUser.WorkoutHistory, 1)
 	loggedWorkout := updatedUser.WorkoutHistory[0]
@@ -1170,8 +1209,9 @@ func TestWorkoutLog_FailMode_AcceptZeroReps(t *testing.T) {
 	
 	// Verify workout was logged with failed sets
 	repo, _ := repository.NewJSONUserRepository()
-	updatedUser, err := repo.Get(user.Username)
+	updatedUser, err := repo.Get(t.Context(), user.Username)
 	require.NoError(t, err)
+	require.NoError(t, updatedUser.LoadHistory())
 	
 	assert.Len(t, updatedUser.WorkoutHistory, 1)
 	loggedWorkout := updatedUser.WorkoutHistory[0]
@@ -1199,15 +1239,39 @@ func TestWorkoutLog_FailMode_NegativeRepsError(t *testing.T) {
 	cmd.SetOut(&output)
 	cmd.SetErr(&output)
 	
-	// Input with negative reps (should cause error)
-	cmd.SetIn(strings.NewReader("-1\n"))
+	// Negative reps are re-prompted until the attempts run out
+	cmd.SetIn(strings.NewReader("-1\n-1\n-1\n"))
 	
 	// Set --fail flag
 	cmd.Flags().Set("fail", "true")
 	
 	err := cmd.RunE(cmd, []string{})
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "number cannot be negative")
+	assert.Contains(t, err.Error(), "gave up after 3 invalid answers: number cannot be negative")
+}
+
+func TestWorkoutLog_RepromptsOnInvalidInput(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	// A typo on the squat AMRAP doesn't lose the OHP reps already entered
+	output, err := executePiped(t, "7\n8a\n6\n", "workout", "log")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Invalid input: invalid integer: 8a. Please try again.\n")
+
+	workout := loadTestUser(t).WorkoutHistory[0]
+	assert.Equal(t, 7, findSetsByType(findLiftByName(workout.Exercises, models.OverheadPress).Sets, models.AMRAPSet)[0].ActualReps)
+	assert.Equal(t, 6, findSetsByType(findLiftByName(workout.Exercises, models.Squat).Sets, models.AMRAPSet)[0].ActualReps)
+}
+
+func TestWorkoutLog_MaxAttempts(t *testing.T) {
+	env := setupTestEnv(t)
+	_ = createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "7\n8a\n6\n", "workout", "log", "--max-attempts", "1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gave up after 1 invalid answers: invalid integer: 8a")
+	assert.Empty(t, loadTestUser(t).WorkoutHistory)
 }
 
 func TestWorkoutLog_BothModesSaveCorrectly(t *testing.T) {
@@ -1262,10 +1326,10 @@ func TestWorkoutLog_BothModesSaveCorrectly(t *testing.T) {
 	user2.Programs[userProgram2.ID] = userProgram2
 	user2.CurrentProgram = userProgram2.ID
 	
-	err = repo.Create(user2)
+	err = repo.Create(t.Context(), user2)
 	require.NoError(t, err)
 	
-	err = repo.SetCurrent("TestUser2")
+	err = repo.SetCurrent(t.Context(), "TestUser2")
 	require.NoError(t, err)
 	
 	cmd2 := workoutLogCmd
@@ -1279,13 +1343,13 @@ func TestWorkoutLog_BothModesSaveCorrectly(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Verify both users have logged workouts
-	updatedUser1, err := repo.Get(user1.Username)
+	updatedUser1, err := repo.Get(t.Context(), user1.Username)
 	require.NoError(t, err)
-	assert.Len(t, updatedUser1.WorkoutHistory, 1)
+	assert.Len(t, updatedUser1.History(), 1)
 	
-	updatedUser2, err := repo.Get(user2.Username)
+	updatedUser2, err := repo.Get(t.Context(), user2.Username)
 	require.NoError(t, err)
-	assert.Len(t, updatedUser2.WorkoutHistory, 1)
+	assert.Len(t, updatedUser2.History(), 1)
 	
 	// Verify progression was calculated for both
 	userProgram1 := updatedUser1.Programs[updatedUser1.CurrentProgram]
@@ -1335,10 +1399,10 @@ func createTestUserWithProgram(t *testing.T, env *testEnv) *models.User {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	return user
@@ -1375,3 +1439,438 @@ func findSetsByType(sets []models.Set, setType models.SetType) []models.Set {
 	return result
 }
 
+
+func TestWorkoutLog_DryRun(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	// Day 1: OverheadPress falls short and deloads, Squat earns a double increase
+	cmd.SetIn(strings.NewReader("4\n12\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("dry-run", "false") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Overhead Press: 95 → 85 lbs (-10.0)")
+	assert.Contains(t, output, "Squat: 135 → 145 lbs (+10.0)")
+	assert.Contains(t, output, "Dry run: workout not saved.\nNext workout would be: Day 2\n")
+	assert.NotContains(t, output, "Workout logged successfully!")
+
+	user := loadTestUser(t)
+	assert.Empty(t, user.WorkoutHistory)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 1, userProgram.CurrentDay)
+	assert.Equal(t, 95.0, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+}
+
+func TestWorkoutLog_QuickSession(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("8\n8\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+	require.NoError(t, cmd.Flags().Set("quick", "true"))
+	t.Cleanup(func() { cmd.Flags().Set("quick", "false") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Quick session: warmups trimmed to save time.")
+	assert.Contains(t, output, "  Warmup:\n    5 reps @ 45 lbs\n    2 reps @ 80 lbs\n  Working Sets:")
+
+	user := loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	logged := user.WorkoutHistory[0]
+	assert.True(t, logged.Quick)
+	for _, lift := range logged.Exercises {
+		warmups := 0
+		for _, set := range lift.Sets {
+			if set.Type == models.WarmupSet {
+				warmups++
+			}
+		}
+		assert.Equal(t, 2, warmups, "warmups for %s", lift.LiftName)
+	}
+}
+
+// useProgramWithChinups moves the test user onto a copy of Greyskull LP with
+// optional chin-ups (2x8, 1x8+) added to day 1
+func useProgramWithChinups(t *testing.T, user *models.User) {
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Chin-ups"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = append(slices.Clone(prog.Workouts[0].Lifts), models.LiftTemplate{
+		LiftName: "Chin-ups",
+		Optional: true,
+		WorkingSets: []models.SetTemplate{
+			{Reps: 8, Type: models.WorkingSet},
+			{Reps: 8, Type: models.WorkingSet},
+			{Reps: 8, Type: models.AMRAPSet},
+		},
+	})
+
+	useCustomProgram(t, user, &prog)
+}
+
+// useCustomProgram saves a custom program and moves the test user onto it
+func useCustomProgram(t *testing.T, user *models.User, prog *models.Program) {
+	programRepo, err := repository.NewJSONProgramRepository()
+	require.NoError(t, err)
+	require.NoError(t, programRepo.Save(prog))
+
+	user.Programs[user.CurrentProgram].ProgramID = prog.ID
+	userRepo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, userRepo.Update(t.Context(), user))
+}
+
+func TestWorkoutLog_OptionalLift(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		performed bool
+	}{
+		{"performed", "y\n8\n8\n10\n", true},
+		{"skipped", "\n8\n8\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTestEnv(t)
+			useProgramWithChinups(t, createTestUserWithProgram(t, env))
+
+			var buf bytes.Buffer
+			cmd := workoutLogCmd
+			cmd.SetOut(&buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			require.NoError(t, cmd.Flags().Set("fail", "false"))
+
+			require.NoError(t, cmd.RunE(cmd, []string{}))
+
+			output := buf.String()
+			assert.Contains(t, output, "Chin-ups (optional):\n  Sets:\n    Set 1: 8 reps\n    Set 2: 8 reps\n    Set 3: 8+ reps (AMRAP)\n")
+			assert.Contains(t, output, "Did you do Chin-ups? [y/N] ")
+			assert.Contains(t, output, "Workout logged successfully!")
+
+			user := loadTestUser(t)
+			require.Len(t, user.WorkoutHistory, 1)
+			exercises := user.WorkoutHistory[0].Exercises
+			if !tt.performed {
+				assert.Len(t, exercises, 2)
+				return
+			}
+
+			require.Len(t, exercises, 3)
+			chinups := exercises[2]
+			assert.True(t, chinups.Optional)
+			assert.Equal(t, []int{8, 8, 10}, []int{chinups.Sets[0].ActualReps, chinups.Sets[1].ActualReps, chinups.Sets[2].ActualReps})
+
+			// Accessories never gain a tracked weight
+			_, tracked := user.Programs[user.CurrentProgram].CurrentWeights["Chin-ups"]
+			assert.False(t, tracked)
+		})
+	}
+}
+
+func TestWorkoutLog_BodyweightLift(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].CurrentWeights["Chinup"] = -20
+
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Assisted Chin-ups"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = append(slices.Clone(prog.Workouts[0].Lifts), models.LiftTemplate{
+		LiftName:   "Chinup",
+		Bodyweight: true,
+		WorkingSets: []models.SetTemplate{
+			{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+			{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+		},
+	})
+	prog.ProgressionRules.RepIncreases = map[models.LiftName]int{"Chinup": 1}
+	useCustomProgram(t, user, &prog)
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("8\n8\n7\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+
+	output := buf.String()
+	assert.Contains(t, output, "Chinup:\n  Working Sets:\n    Set 1: 5 reps @ bodyweight - 20 lbs\n    Set 2: 5+ reps @ bodyweight - 20 lbs (AMRAP)\n")
+	assert.Contains(t, output, "\nRep Target Updates:\nChinup: 5 → 6 reps\n")
+
+	userProgram := loadTestUser(t).Programs[user.CurrentProgram]
+	assert.Equal(t, -20.0, userProgram.CurrentWeights["Chinup"])
+	assert.Equal(t, 6, userProgram.RepTargets["Chinup"])
+}
+
+func TestWorkoutDate(t *testing.T) {
+	now := time.Date(2024, 3, 8, 9, 30, 0, 0, time.Local)
+	lastAt := time.Date(2024, 3, 6, 18, 0, 0, 0, time.Local)
+	user := &models.User{WorkoutHistory: []models.Workout{{Day: 1, EnteredAt: lastAt}}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		err      string
+	}{
+		{"yesterday keeps the time of day", "2024-03-07", time.Date(2024, 3, 7, 9, 30, 0, 0, time.Local), ""},
+		{"today", "2024-03-08", now, ""},
+		{"same day as last workout goes after it", "2024-03-06", lastAt.Add(time.Second), ""},
+		{"before last workout", "2024-03-05", time.Time{}, "--date 2024-03-05 is before your last logged workout on 2024-03-06"},
+		{"future", "2024-03-09", time.Time{}, "--date 2024-03-09 is in the future"},
+		{"invalid", "03/07/2024", time.Time{}, `invalid --date "03/07/2024": expected YYYY-MM-DD`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enteredAt, err := workoutDate(tt.input, now, user)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(enteredAt), "expected %s, got %s", tt.expected, enteredAt)
+		})
+	}
+}
+
+func TestWorkoutLog_Date(t *testing.T) {
+	setDate := func(t *testing.T, date string, yes bool) {
+		require.NoError(t, workoutLogCmd.Flags().Set("fail", "false"))
+		require.NoError(t, workoutLogCmd.Flags().Set("date", date))
+		require.NoError(t, workoutLogCmd.Flags().Set("yes", strconv.FormatBool(yes)))
+		t.Cleanup(func() {
+			workoutLogCmd.Flags().Set("date", "")
+			workoutLogCmd.Flags().Set("yes", "false")
+		})
+	}
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	t.Run("confirmed", func(t *testing.T) {
+		env := setupTestEnv(t)
+		createTestUserWithProgram(t, env)
+		setDate(t, yesterday.Format("2006-01-02"), false)
+
+		var buf bytes.Buffer
+		cmd := workoutLogCmd
+		cmd.SetOut(&buf)
+		cmd.SetIn(strings.NewReader("y\n8\n8\n"))
+
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		assert.Contains(t, buf.String(), fmt.Sprintf("Log this workout for %s? [y/N] ", yesterday.Format("Monday, 2006-01-02")))
+		assert.Contains(t, buf.String(), "Workout logged successfully!")
+
+		user := loadTestUser(t)
+		require.Len(t, user.WorkoutHistory, 1)
+		assert.Equal(t, yesterday.Format("2006-01-02"), user.WorkoutHistory[0].EnteredAt.Local().Format("2006-01-02"))
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		env := setupTestEnv(t)
+		createTestUserWithProgram(t, env)
+		setDate(t, yesterday.Format("2006-01-02"), false)
+
+		var buf bytes.Buffer
+		cmd := workoutLogCmd
+		cmd.SetOut(&buf)
+		cmd.SetIn(strings.NewReader("n\n"))
+
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		assert.Contains(t, buf.String(), "Workout not logged.")
+		assert.NotContains(t, buf.String(), "Day 1 Workout")
+		assert.Empty(t, loadTestUser(t).WorkoutHistory)
+	})
+
+	t.Run("kept in date order with --yes", func(t *testing.T) {
+		env := setupTestEnv(t)
+		user := createUserWithHistory(t, env)
+		// Logged the morning after the last session in the history
+		lastDate := user.WorkoutHistory[0].EnteredAt
+		setDate(t, lastDate.Format("2006-01-02"), true)
+
+		var buf bytes.Buffer
+		cmd := workoutLogCmd
+		cmd.SetOut(&buf)
+		cmd.SetIn(strings.NewReader("8\n8\n"))
+
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		assert.NotContains(t, buf.String(), "[y/N]")
+
+		user = loadTestUser(t)
+		require.Len(t, user.WorkoutHistory, 3)
+		assert.True(t, user.WorkoutHistory[2].EnteredAt.After(lastDate))
+		assert.Equal(t, lastDate.Format("2006-01-02"), user.WorkoutHistory[2].EnteredAt.Format("2006-01-02"))
+	})
+}
+
+func TestWorkoutLog_FixedWeightLift(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].CurrentWeights["PauseSquat"] = 115
+
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Pause Squats"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = append(slices.Clone(prog.Workouts[0].Lifts), models.LiftTemplate{
+		LiftName:    "PauseSquat",
+		FixedWeight: true,
+		WorkingSets: []models.SetTemplate{
+			{Reps: 3, WeightPercentage: 1.0, Type: models.WorkingSet},
+			{Reps: 3, WeightPercentage: 1.0, Type: models.WorkingSet},
+		},
+	})
+	useCustomProgram(t, user, &prog)
+
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader("7\n8\n"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "Workout logged successfully!")
+	assert.NotContains(t, buf.String(), "PauseSquat AMRAP")
+
+	saved := loadTestUser(t)
+	weights := saved.Programs[saved.CurrentProgram].CurrentWeights
+	assert.Equal(t, 115.0, weights["PauseSquat"], "fixed-weight lifts keep their weight")
+	assert.Equal(t, 140.0, weights[models.Squat])
+	require.Len(t, saved.WorkoutHistory, 1)
+	assert.True(t, saved.WorkoutHistory[0].Exercises[2].FixedWeight)
+}
+
+func TestWorkoutLog_Superset(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].CurrentWeights["Chinup"] = 0
+
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Chin-up Supersets"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = slices.Clone(prog.Workouts[0].Lifts)
+	prog.Workouts[0].Lifts[1].Group = "A"
+	prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, models.LiftTemplate{
+		LiftName:   "Chinup",
+		Bodyweight: true,
+		Group:      "A",
+		WorkingSets: []models.SetTemplate{
+			{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+			{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+		},
+	})
+	prog.ProgressionRules.RepIncreases = map[models.LiftName]int{"Chinup": 1}
+	require.NoError(t, prog.Validate())
+	useCustomProgram(t, user, &prog)
+
+	// OHP on its own, then the squat warmups, then squats and chin-ups alternating
+	output, err := executePiped(t, "5\n4\n3\n2\n5\n5\n7\n5\n4\n3\n2\n5\n6\n5\n7\n6\n", "workout", "log", "--fail")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "Superset A:\n  A1. Squat\n  A2. Chinup\n  Warmup:\n    A1: 5 reps @ 45 lbs\n")
+	assert.Contains(t, output, "  Working Sets:\n"+
+		"    A1 Set 1: 5 reps @ 135 lbs\n"+
+		"    A2 Set 1: 5 reps @ bodyweight\n"+
+		"    A1 Set 2: 5 reps @ 135 lbs\n"+
+		"    A2 Set 2: 5+ reps @ bodyweight (AMRAP)\n"+
+		"    A1 Set 3: 5+ reps @ 135 lbs (AMRAP)\n")
+	assert.Contains(t, output, "\nA2. Chinup:\nChinup - Set 1 (Working)")
+	assert.Contains(t, output, "\nA1. Squat:\nSquat - Set 6 (Working)")
+
+	workout := loadTestUser(t).WorkoutHistory[0]
+	squat := findLiftByName(workout.Exercises, models.Squat)
+	chinup := findLiftByName(workout.Exercises, "Chinup")
+	require.NotNil(t, squat)
+	require.NotNil(t, chinup)
+	assert.Equal(t, "A", squat.Group)
+	assert.Equal(t, []int{5, 5, 6}, []int{squat.Sets[4].ActualReps, squat.Sets[5].ActualReps, squat.Sets[6].ActualReps})
+	assert.Equal(t, []int{6, 7}, []int{chinup.Sets[0].ActualReps, chinup.Sets[1].ActualReps})
+}
+
+func TestWorkoutLog_Substitute(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	_, err := executePiped(t, "", "lift", "define", "FrontSquat", "--display", "Front Squat", "--alias", "fsq", "--bar", "35")
+	require.NoError(t, err)
+	user := loadTestUser(t)
+	before := user.Programs[user.CurrentProgram].CurrentWeights[models.Squat]
+
+	output, err := executePiped(t, "8\n8\n", "workout", "log", "--substitute", "squat=fsq@95")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Front Squat (for Squat):")
+	assert.Contains(t, output, "5 reps @ 95 lbs")
+
+	user = loadTestUser(t)
+	require.Len(t, user.WorkoutHistory, 1)
+	var substituted *models.Lift
+	for i, lift := range user.WorkoutHistory[0].Exercises {
+		if lift.SubstitutedFor != "" {
+			substituted = &user.WorkoutHistory[0].Exercises[i]
+		}
+	}
+	require.NotNil(t, substituted)
+	assert.Equal(t, models.LiftName("FrontSquat"), substituted.LiftName)
+	assert.Equal(t, models.Squat, substituted.SubstitutedFor)
+
+	// The original lift keeps its weight, and the substitute isn't tracked
+	weights := user.Programs[user.CurrentProgram].CurrentWeights
+	assert.Equal(t, before, weights[models.Squat])
+	assert.NotContains(t, weights, models.LiftName("FrontSquat"))
+
+	output, err = executePiped(t, "", "workout", "history")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Front Squat (for Squat)")
+}
+
+func TestWorkoutLog_SubstituteInteractive(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	output, err := executePiped(t, "deadlift\n95\n8\n8\n", "workout", "log", "--substitute", "squat")
+	require.NoError(t, err)
+	assert.Contains(t, output, "What are you doing in place of Squat? ")
+	assert.Contains(t, output, "Working weight for Deadlift (lbs): ")
+	assert.Contains(t, output, "Deadlift (for Squat):")
+}
+
+func TestWorkoutLog_SubstituteErrors(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	tests := []struct {
+		substitute string
+		err        string
+	}{
+		{"squat=squat@95", "can't substitute Squat for itself"},
+		{"squat=curl@95", `unknown lift "curl"`},
+		{"bench=deadlift@95", "has no BenchPress to substitute"},
+		{"squat=deadlift@heavy", "weight must be a positive number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.substitute, func(t *testing.T) {
+			_, err := executePiped(t, "", "workout", "log", "--substitute", tt.substitute)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+	assert.Empty(t, loadTestUser(t).WorkoutHistory)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRestSleep replaces the rest timer's sleep, returning the total time "slept"
+func fakeRestSleep(t *testing.T) *time.Duration {
+	var slept time.Duration
+	original := restSleep
+	restSleep = func(d time.Duration) { slept += d }
+	t.Cleanup(func() { restSleep = original })
+	return &slept
+}
+
+func runWorkoutLogWithTimer(t *testing.T, input string, flags map[string]string) string {
+	var buf bytes.Buffer
+	cmd := workoutLogCmd
+	cmd.SetOut(&buf)
+	cmd.SetIn(strings.NewReader(input))
+	require.NoError(t, cmd.Flags().Set("timer", "true"))
+	require.NoError(t, cmd.Flags().Set("beep", "false"))
+	require.NoError(t, cmd.Flags().Set("fail", "false"))
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"timer", "beep", "fail"} {
+			cmd.Flags().Set(name, "false")
+		}
+	})
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	return buf.String()
+}
+
+func TestWorkoutLog_Timer(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+	slept := fakeRestSleep(t)
+
+	// Day 1 has two AMRAP prompts, so there is one rest between them
+	output := runWorkoutLogWithTimer(t, "8\n8\n", nil)
+
+	assert.Equal(t, 3*time.Minute, *slept)
+	assert.Contains(t, output, "Overhead Press AMRAP set (5+)? \rRest: 3:00 ")
+	assert.Contains(t, output, "\rRest: 0:01 \rRest over!  \nHow many reps did you complete for Squat AMRAP set (5+)? ")
+	assert.Equal(t, 1, strings.Count(output, "Rest over!"))
+	assert.NotContains(t, output, "\a")
+}
+
+func TestWorkoutLog_TimerFailMode(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	slept := fakeRestSleep(t)
+
+	// Rest times from the user override the defaults
+	user.RestTimes = &models.RestTimes{WarmupSeconds: 30, WorkingSeconds: 60}
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	next, err := calculateNextWorkout(user, getGreyskullLP())
+	require.NoError(t, err)
+	var answers []string
+	var expected time.Duration
+	var sets []models.Set
+	for _, lift := range next.Exercises {
+		sets = append(sets, lift.Sets...)
+	}
+	for i, set := range sets {
+		answers = append(answers, "5")
+		if i == len(sets)-1 {
+			break
+		}
+		if set.Type == models.WarmupSet {
+			expected += 30 * time.Second
+		} else {
+			expected += time.Minute
+		}
+	}
+
+	output := runWorkoutLogWithTimer(t, strings.Join(answers, "\n")+"\n", map[string]string{"fail": "true", "beep": "true"})
+
+	assert.Equal(t, expected, *slept)
+	assert.Equal(t, len(sets)-1, strings.Count(output, "Rest over!  \a\n"))
+}
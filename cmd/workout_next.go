@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/mikowitz/greyskull/display"
+	"github.com/mikowitz/greyskull/models"
 	"github.com/mikowitz/greyskull/services"
 	"github.com/mikowitz/greyskull/workout"
 )
@@ -12,33 +13,140 @@ import (
 var workoutNextCmd = &cobra.Command{
 	Use:   "next",
 	Short: "Display the next workout",
-	Long:  "Display the next workout based on your current program and progress.",
-	RunE:  showNextWorkout,
+	Long: `Display the next workout based on your current program and progress.
+
+Use --day to preview the next time a given program day comes around, or
+--ahead to preview the workout that many sessions after the next one. Previews
+assume every session before them is completed successfully, so each lift
+progresses by its usual increment.
+
+Use --program to show the next workout of a program you train alongside your
+current one, by its index or ID from 'greyskull program list --mine' or its name.
+
+Use --cues to show the coaching cues and demonstration link of each lift that
+has them, from the program's templates or the lift's definition.`,
+	Example: `  greyskull workout next
+  greyskull workout next --cues
+  greyskull workout next --day 4
+  greyskull workout next --ahead 3
+  greyskull workout next --program 2`,
+	RunE: showNextWorkout,
+}
+
+func init() {
+	workoutNextCmd.Flags().Int("day", 0, "Preview the next workout on this program day")
+	workoutNextCmd.Flags().Int("ahead", 0, "Preview the workout this many sessions after the next one")
+	workoutNextCmd.MarkFlagsMutuallyExclusive("day", "ahead")
+	workoutNextCmd.Flags().Bool("cues", false, "Show each lift's coaching cues and demonstration link")
+	addProgramFlag(workoutNextCmd)
 }
 
 func showNextWorkout(cmd *cobra.Command, args []string) error {
+	showCues, err := cmd.Flags().GetBool("cues")
+	if err != nil {
+		return fmt.Errorf("failed to get cues flag: %w", err)
+	}
+
 	// Initialize command context with dependency injection
-	ctx, err := services.NewCommandContextWithDefaults()
+	ctx, err := newCommandContext(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to initialize context: %w", err)
 	}
 
-	// Load current user, program, and user program in one call
-	user, _, program, err := ctx.UserService.GetCurrentUserWithProgram()
+	// Load current user and the selected program in one call
+	user, userProgram, program, err := selectedProgram(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	user = asCurrentProgram(user, userProgram)
+
+	// Previews of later sessions progress a copy of the program up to them
+	sessions, err := sessionsAhead(cmd, userProgram, program)
 	if err != nil {
 		return err
 	}
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	if sessions > 0 {
+		user, err = workout.SimulateSessions(user, program, config, sessions)
+		if err != nil {
+			return fmt.Errorf("failed to simulate upcoming sessions: %w", err)
+		}
+		userProgram = user.Programs[user.CurrentProgram]
+	}
 
 	// Calculate next workout
-	nextWorkout, err := workout.CalculateNextWorkout(user, program)
+	nextWorkout, err := nextWorkoutFor(ctx, user, userProgram, program)
 	if err != nil {
-		return fmt.Errorf("failed to calculate next workout: %w", err)
+		return err
 	}
 
 	// Display workout
 	formatter := display.NewWorkoutFormatter(cmd.OutOrStdout())
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.SetPlateHints(config, userProgram.Unit)
+	if showCues {
+		formatter.SetCoaching(program)
+	}
+	formatter.DisplayPreviewNotice(sessions)
 	formatter.DisplayWorkout(nextWorkout)
+	formatter.DisplayLoadWarnings(workout.LoadWarnings(nextWorkout, config, userProgram.Unit), userProgram.Unit)
+	formatter.DisplayHolds(userProgram.Holds)
+	formatter.DisplayDeload(userProgram.Deload)
+	outputFor(cmd).Result(nextWorkout)
 
 	return nil
 }
 
+// sessionsAhead returns how many sessions before the one to preview, from the
+// --day and --ahead flags: 0 for the next workout
+func sessionsAhead(cmd *cobra.Command, userProgram *models.UserProgram, program *models.Program) (int, error) {
+	day, err := cmd.Flags().GetInt("day")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get day flag: %w", err)
+	}
+	ahead, err := cmd.Flags().GetInt("ahead")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ahead flag: %w", err)
+	}
+
+	switch {
+	case ahead < 0:
+		return 0, fmt.Errorf("invalid --ahead %d: must not be negative", ahead)
+	case cmd.Flags().Changed("day"):
+		if day < 1 || day > len(program.Workouts) {
+			return 0, fmt.Errorf("invalid --day %d: %s has days 1-%d", day, program.Name, len(program.Workouts))
+		}
+		return workout.SessionsUntil(userProgram.CurrentDay, day, len(program.Workouts)), nil
+	}
+	return ahead, nil
+}
+
+// nextWorkoutFor calculates the next workout of one of the user's active
+// programs with the settings from their config, such as their bar weight
+func nextWorkoutFor(ctx *services.CommandContext, user *models.User, userProgram *models.UserProgram, program *models.Program) (*models.Workout, error) {
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	nextWorkout, err := workout.CalculateNextWorkoutWithConfig(asCurrentProgram(user, userProgram), program, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next workout: %w", err)
+	}
+	return nextWorkout, nil
+}
+
+// asCurrentProgram returns a copy of the user with userProgram as their current
+// program, so a program trained alongside it can be calculated like the
+// current one. The copy is only for calculating; the user is what's saved.
+func asCurrentProgram(user *models.User, userProgram *models.UserProgram) *models.User {
+	if user.CurrentProgram == userProgram.ID {
+		return user
+	}
+	selected := *user
+	selected.CurrentProgram = userProgram.ID
+	return &selected
+}
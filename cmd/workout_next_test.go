@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
 	"github.com/mikowitz/greyskull/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,10 +45,10 @@ func TestWorkoutNext_NoActiveProgram(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	cmd := workoutNextCmd
@@ -98,10 +100,10 @@ func TestWorkoutNext_ValidProgramAndWeights(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Capture output
@@ -240,10 +242,10 @@ func TestWorkoutNext_DisplayForDifferentDays(t *testing.T) {
 			user.Programs[userProgram.ID] = userProgram
 			user.CurrentProgram = userProgram.ID
 
-			err = repo.Create(user)
+			err = repo.Create(t.Context(), user)
 			require.NoError(t, err)
 
-			err = repo.SetCurrent("TestUser")
+			err = repo.SetCurrent(t.Context(), "TestUser")
 			require.NoError(t, err)
 
 			// Capture output
@@ -350,10 +352,10 @@ func TestWorkoutNext_WarmupDisplayVsNoWarmup(t *testing.T) {
 			user.Programs[userProgram.ID] = userProgram
 			user.CurrentProgram = userProgram.ID
 
-			err = repo.Create(user)
+			err = repo.Create(t.Context(), user)
 			require.NoError(t, err)
 
-			err = repo.SetCurrent("TestUser")
+			err = repo.SetCurrent(t.Context(), "TestUser")
 			require.NoError(t, err)
 
 			// Capture output
@@ -437,10 +439,10 @@ func TestWorkoutNext_VerifyAMRAPSetsMarked(t *testing.T) {
 	user.Programs[userProgram.ID] = userProgram
 	user.CurrentProgram = userProgram.ID
 
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
-	err = repo.SetCurrent("TestUser")
+	err = repo.SetCurrent(t.Context(), "TestUser")
 	require.NoError(t, err)
 
 	// Capture output
@@ -469,3 +471,77 @@ func TestWorkoutNext_VerifyAMRAPSetsMarked(t *testing.T) {
 	assert.Equal(t, 2, amrapCount, "Should have exactly 2 AMRAP sets marked")
 }
 
+
+func TestWorkoutNext_Preview(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	// Day 3 comes after two successful sessions: days 1 and 2
+	output, err := executePiped(t, "", "workout", "next", "--day", "3")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Preview assuming 2 successful sessions before this one.\n\nDay 3 Workout:")
+	assert.Contains(t, output, "Overhead Press:")
+	assert.Contains(t, output, "97.5 lbs")
+	assert.Contains(t, output, "140 lbs")
+
+	output, err = executePiped(t, "", "workout", "next", "--ahead", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Preview assuming 1 successful session before this one.\n\nDay 2 Workout:")
+
+	output, err = executePiped(t, "", "workout", "next", "--day", "1")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Preview")
+	assert.Contains(t, output, "Day 1 Workout:")
+
+	// Previews leave the program where it is
+	user := loadTestUser(t)
+	assert.Equal(t, 1, user.Programs[user.CurrentProgram].CurrentDay)
+	assert.Equal(t, 95.0, user.Programs[user.CurrentProgram].CurrentWeights[models.OverheadPress])
+}
+
+func TestWorkoutNext_PreviewInvalid(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "workout", "next", "--day", "7")
+	assert.EqualError(t, err, "invalid --day 7: OG Greyskull LP has days 1-6")
+	_, err = executePiped(t, "", "workout", "next", "--ahead", "-1")
+	assert.EqualError(t, err, "invalid --ahead -1: must not be negative")
+	_, err = executePiped(t, "", "workout", "next", "--day", "2", "--ahead", "1")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
+
+func TestWorkoutNext_Cues(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+
+	_, err := executePiped(t, "", "lift", "define", "FrontSquat", "--display", "Front Squat", "--increment", "5",
+		"--cue", "Elbows high, always", "--url", "https://example.com/front-squat")
+	require.NoError(t, err)
+
+	prog := *program.GreyskullLP
+	prog.ID = uuid.New()
+	prog.Name = "Greyskull LP with Cues"
+	prog.Workouts = slices.Clone(prog.Workouts)
+	prog.Workouts[0].Lifts = slices.Clone(prog.Workouts[0].Lifts)
+	for i, lift := range prog.Workouts[0].Lifts {
+		if lift.LiftName == models.Squat {
+			prog.Workouts[0].Lifts[i].LiftName = "FrontSquat"
+			prog.Workouts[0].Lifts[i].Cues = []string{"Stay upright"}
+		}
+		if lift.LiftName == models.OverheadPress {
+			prog.Workouts[0].Lifts[i].Cues = []string{"Squeeze glutes", "Head through"}
+		}
+	}
+	user.Programs[user.CurrentProgram].CurrentWeights["FrontSquat"] = 115
+	useCustomProgram(t, user, &prog)
+
+	output, err := executePiped(t, "", "workout", "next")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Cues:", "cues are only shown with --cues")
+
+	output, err = executePiped(t, "", "workout", "next", "--cues")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Overhead Press:\n  Cues:\n    - Squeeze glutes\n    - Head through\n  Warmup:\n")
+	assert.Contains(t, output, "Front Squat:\n  Cues:\n    - Stay upright\n  Link: https://example.com/front-squat\n  Warmup:\n")
+}
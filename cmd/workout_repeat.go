@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/display"
+	"github.com/spf13/cobra"
+)
+
+var workoutRepeatCmd = &cobra.Command{
+	Use:   "repeat",
+	Short: "Record an abandoned workout and repeat the day",
+	Long: `Record a workout you bailed out of partway and queue the same day again.
+
+You're asked for the reps completed on every set; enter 0 for sets you didn't get
+to. The attempt is saved in your history flagged as incomplete, but the day isn't
+advanced and no progression is applied, so your next workout is the same day at
+the same weights.`,
+	Args: cobra.NoArgs,
+	RunE: repeatWorkout,
+}
+
+func init() {
+	workoutRepeatCmd.Flags().Int("max-attempts", DefaultMaxAttempts, "Attempts allowed per prompt before an invalid answer aborts (0 for no limit)")
+}
+
+func repeatWorkout(cmd *cobra.Command, args []string) error {
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	nextWorkout, err := nextWorkoutFor(ctx, user, userProgram, program)
+	if err != nil {
+		return err
+	}
+
+	config, err := ctx.Config.Load(user.Username)
+	if err != nil {
+		return err
+	}
+	formatter := display.NewWorkoutFormatter(textAt(cmd, display.Normal))
+	formatter.SetWeightFormat(weightFormat(config, userProgram.Unit))
+	formatter.SetPlateHints(config, userProgram.Unit)
+	formatter.DisplayWorkout(nextWorkout)
+	printf(cmd, "Enter the reps you completed for each set, or 0 for sets you didn't get to.\n")
+
+	inputReader, err := promptReader(cmd)
+	if err != nil {
+		return err
+	}
+	attempt, err := collectWithFailure(cmd, inputReader, nextWorkout, weightFormat(config, userProgram.Unit), nil)
+	if err != nil {
+		return fmt.Errorf("failed to collect workout data: %w", err)
+	}
+	attempt.Incomplete = true
+
+	// Weights, holds, deloads, and the current day are left as they are
+	user.AddWorkout(*attempt)
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save workout: %w", err)
+	}
+
+	printf(cmd, "\nIncomplete Day %d workout recorded. Weights are unchanged.\n", attempt.Day)
+	printf(cmd, "Next workout: Day %d (repeated)\n", userProgram.CurrentDay)
+
+	return nil
+}
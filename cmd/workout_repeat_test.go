@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutRepeat(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutRepeatCmd
+	cmd.SetOut(&buf)
+	// Overhead Press: warmups and two working sets before bailing; Squat: nothing
+	input := "5\n4\n3\n2\n5\n5\n0\n" + strings.Repeat("0\n", 7)
+	cmd.SetIn(strings.NewReader(input))
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Day 1 Workout:")
+	assert.Contains(t, output, "Enter the reps you completed for each set, or 0 for sets you didn't get to.")
+	assert.Contains(t, output, "\nIncomplete Day 1 workout recorded. Weights are unchanged.\nNext workout: Day 1 (repeated)\n")
+
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 1, userProgram.CurrentDay)
+	assert.Equal(t, 95.0, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+
+	require.Len(t, user.WorkoutHistory, 1)
+	attempt := user.WorkoutHistory[0]
+	assert.True(t, attempt.Incomplete)
+	assert.Equal(t, 1, attempt.Day)
+	require.Len(t, attempt.Exercises, 2)
+	ohp := attempt.Exercises[0].Sets
+	assert.Equal(t, 5, ohp[5].ActualReps)
+	assert.Equal(t, 0, ohp[6].ActualReps)
+}
+
+func TestWorkoutRepeat_NoInput(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	cmd := workoutRepeatCmd
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("5\n"))
+
+	err := cmd.RunE(cmd, []string{})
+	assert.ErrorIs(t, err, ErrNoInput)
+	assert.Empty(t, loadTestUser(t).WorkoutHistory)
+}
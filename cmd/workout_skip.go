@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+	"github.com/spf13/cobra"
+)
+
+var workoutSkipCmd = &cobra.Command{
+	Use:   "skip",
+	Short: "Skip the next workout day",
+	Long: `Skip the next workout day and move on to the one after it.
+
+Weights, holds, and any deload in progress are unchanged. The skipped day is
+recorded with an optional reason and shown by 'greyskull status', so a missed
+day doesn't silently shift the schedule.`,
+	Example: `  greyskull workout skip --reason "travelling"`,
+	Args:    cobra.NoArgs,
+	RunE:    skipWorkout,
+}
+
+func init() {
+	workoutSkipCmd.Flags().String("reason", "", "Why the day was skipped")
+}
+
+func skipWorkout(cmd *cobra.Command, args []string) error {
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return fmt.Errorf("failed to get reason flag: %w", err)
+	}
+
+	// Initialize command context with dependency injection
+	ctx, err := newCommandContext(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize context: %w", err)
+	}
+
+	// Load current user, program, and user program in one call
+	user, userProgram, program, err := ctx.UserService.GetCurrentUserWithProgram(contextFor(cmd))
+	if err != nil {
+		return err
+	}
+
+	skipped := models.SkippedDay{
+		ID:            uuid.Must(uuid.NewV7()),
+		UserProgramID: userProgram.ID,
+		Day:           userProgram.CurrentDay,
+		Reason:        strings.TrimSpace(reason),
+		SkippedAt:     time.Now(),
+	}
+	user.SkippedDays = append(user.SkippedDays, skipped)
+	userProgram.CurrentDay = workout.NextDay(userProgram.CurrentDay, len(program.Workouts))
+
+	if err := ctx.UserRepo.Update(contextFor(cmd), user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if skipped.Reason != "" {
+		printf(cmd, "Skipped Day %d (%s). Weights are unchanged.\n", skipped.Day, skipped.Reason)
+	} else {
+		printf(cmd, "Skipped Day %d. Weights are unchanged.\n", skipped.Day)
+	}
+	printf(cmd, "Next workout: Day %d\n", userProgram.CurrentDay)
+
+	return nil
+}
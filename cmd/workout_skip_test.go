@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkoutSkip(t *testing.T) {
+	env := setupTestEnv(t)
+	createTestUserWithProgram(t, env)
+
+	var buf bytes.Buffer
+	cmd := workoutSkipCmd
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.Flags().Set("reason", " travelling "))
+	t.Cleanup(func() { cmd.Flags().Set("reason", "") })
+
+	err := cmd.RunE(cmd, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, "Skipped Day 1 (travelling). Weights are unchanged.\nNext workout: Day 2\n", buf.String())
+
+	user := loadTestUser(t)
+	userProgram := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 2, userProgram.CurrentDay)
+	assert.Equal(t, 95.0, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Empty(t, user.WorkoutHistory)
+
+	require.Len(t, user.SkippedDays, 1)
+	skipped := user.SkippedDays[0]
+	assert.Equal(t, userProgram.ID, skipped.UserProgramID)
+	assert.Equal(t, 1, skipped.Day)
+	assert.Equal(t, "travelling", skipped.Reason)
+
+	// Skipping without a reason
+	buf.Reset()
+	require.NoError(t, cmd.Flags().Set("reason", ""))
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Equal(t, "Skipped Day 2. Weights are unchanged.\nNext workout: Day 3\n", buf.String())
+	assert.Len(t, loadTestUser(t).SkippedDays, 2)
+}
+
+func TestWorkoutSkip_WrapsAround(t *testing.T) {
+	env := setupTestEnv(t)
+	user := createTestUserWithProgram(t, env)
+	user.Programs[user.CurrentProgram].CurrentDay = 6
+	repo, err := repository.NewJSONUserRepository()
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	var buf bytes.Buffer
+	cmd := workoutSkipCmd
+	cmd.SetOut(&buf)
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "Next workout: Day 1")
+}
+
+func TestWorkoutSkip_NoCurrentUser(t *testing.T) {
+	setupTestEnv(t)
+
+	err := workoutSkipCmd.RunE(workoutSkipCmd, []string{})
+	assert.Error(t, err)
+}
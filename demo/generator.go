@@ -0,0 +1,174 @@
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/workout"
+)
+
+// SessionsPerWeek is the number of training sessions generated for each week
+const SessionsPerWeek = 3
+
+// sessionWeekdays are the day offsets (from the start of each week) on which sessions are generated
+var sessionWeekdays = [SessionsPerWeek]int{0, 2, 4}
+
+// DefaultStartingWeights are the starting weights used when none are provided
+var DefaultStartingWeights = map[models.LiftName]float64{
+	models.Squat:         115.0,
+	models.Deadlift:      135.0,
+	models.BenchPress:    95.0,
+	models.OverheadPress: 65.0,
+}
+
+// Options configures demo data generation
+type Options struct {
+	// Seed drives all random choices; the same seed always produces the same history
+	Seed int64
+
+	// Weeks is the number of weeks of history to generate
+	Weeks int
+
+	// Username is the name given to the generated user
+	Username string
+
+	// Start is the date of the first generated session
+	Start time.Time
+
+	// Program is the program template to run; defaults to GreyskullLP
+	Program *models.Program
+
+	// StartingWeights overrides DefaultStartingWeights when set
+	StartingWeights map[models.LiftName]float64
+}
+
+// Generate builds a user with a started program and a realistic workout history.
+// Progression is applied through the workout package, so generated weights follow
+// the same rules (normal progress, double progress, deloads) as logged workouts.
+func Generate(opts Options) (*models.User, error) {
+	if opts.Weeks <= 0 {
+		return nil, fmt.Errorf("weeks must be positive, got: %d", opts.Weeks)
+	}
+	if opts.Username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	prog := opts.Program
+	if prog == nil {
+		prog = program.GreyskullLP
+	}
+
+	startingWeights := opts.StartingWeights
+	if startingWeights == nil {
+		startingWeights = DefaultStartingWeights
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	user := &models.User{
+		ID:             newID(rng),
+		Username:       opts.Username,
+		Programs:       make(map[uuid.UUID]*models.UserProgram),
+		WorkoutHistory: []models.Workout{},
+		CreatedAt:      opts.Start,
+	}
+
+	userProgram := &models.UserProgram{
+		ID:              newID(rng),
+		UserID:          user.ID,
+		ProgramID:       prog.ID,
+		StartingWeights: make(map[models.LiftName]float64),
+		CurrentWeights:  make(map[models.LiftName]float64),
+		CurrentDay:      1,
+		StartedAt:       opts.Start,
+		Program:         prog,
+	}
+	for lift, weight := range startingWeights {
+		userProgram.StartingWeights[lift] = weight
+		userProgram.CurrentWeights[lift] = weight
+	}
+
+	user.Programs[userProgram.ID] = userProgram
+	user.CurrentProgram = userProgram.ID
+
+	for week := range opts.Weeks {
+		for _, offset := range sessionWeekdays {
+			enteredAt := opts.Start.AddDate(0, 0, week*7+offset)
+
+			next, err := workout.CalculateNextWorkout(user, prog)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate workout: %w", err)
+			}
+
+			completed := completeWorkout(rng, next, enteredAt)
+
+			if err := workout.ApplyWorkout(userProgram, completed, prog); err != nil {
+				return nil, err
+			}
+			user.WorkoutHistory = append(user.WorkoutHistory, *completed)
+		}
+	}
+
+	return user, nil
+}
+
+// completeWorkout fills in actual reps for every set of a calculated workout.
+// Non-AMRAP sets are completed as prescribed; AMRAP reps are drawn from amrapReps.
+func completeWorkout(rng *rand.Rand, next *models.Workout, enteredAt time.Time) *models.Workout {
+	completed := &models.Workout{
+		ID:            newID(rng),
+		UserProgramID: next.UserProgramID,
+		Day:           next.Day,
+		Exercises:     make([]models.Lift, len(next.Exercises)),
+		EnteredAt:     enteredAt,
+	}
+
+	for i, exercise := range next.Exercises {
+		lift := models.Lift{
+			ID:          newID(rng),
+			LiftName:    exercise.LiftName,
+			Variant:     exercise.Variant,
+			Optional:    exercise.Optional,
+			Bodyweight:  exercise.Bodyweight,
+			FixedWeight: exercise.FixedWeight,
+			Sets:        make([]models.Set, len(exercise.Sets)),
+		}
+
+		for j, set := range exercise.Sets {
+			set.ID = newID(rng)
+			if set.Type == models.AMRAPSet {
+				set.ActualReps = amrapReps(rng)
+			} else {
+				set.ActualReps = set.TargetReps
+			}
+			lift.Sets[j] = set
+		}
+
+		completed.Exercises[i] = lift
+	}
+
+	return completed
+}
+
+// amrapReps picks a plausible AMRAP result: usually 5-9 reps, sometimes
+// 10+ (double progression), and occasionally a failed set that triggers a deload.
+func amrapReps(rng *rand.Rand) int {
+	roll := rng.Intn(100)
+	switch {
+	case roll < 8:
+		return 3 + rng.Intn(2) // 3-4 reps: deload
+	case roll < 20:
+		return 10 + rng.Intn(3) // 10-12 reps: double progression
+	default:
+		return 5 + rng.Intn(5) // 5-9 reps: normal progression
+	}
+}
+
+// newID generates a UUID from the seeded source so generated data is reproducible
+func newID(rng *rand.Rand) uuid.UUID {
+	return uuid.Must(uuid.NewRandomFromReader(rng))
+}
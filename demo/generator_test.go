@@ -0,0 +1,103 @@
+package demo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions(seed int64, weeks int) Options {
+	return Options{
+		Seed:     seed,
+		Weeks:    weeks,
+		Username: "demo",
+		Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first, err := Generate(testOptions(42, 12))
+	require.NoError(t, err)
+
+	second, err := Generate(testOptions(42, 12))
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second, "same seed should produce identical data")
+}
+
+func TestGenerate_DifferentSeedsDiffer(t *testing.T) {
+	first, err := Generate(testOptions(1, 12))
+	require.NoError(t, err)
+
+	second, err := Generate(testOptions(2, 12))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.WorkoutHistory, second.WorkoutHistory)
+}
+
+func TestGenerate_HistoryShape(t *testing.T) {
+	opts := testOptions(42, 4)
+	user, err := Generate(opts)
+	require.NoError(t, err)
+
+	require.Len(t, user.WorkoutHistory, 4*SessionsPerWeek)
+	require.NoError(t, user.Validate())
+
+	userProgram, exists := user.Programs[user.CurrentProgram]
+	require.True(t, exists)
+	assert.Equal(t, program.GreyskullLP.ID, userProgram.ProgramID)
+
+	// 12 sessions through a 6-day program wraps back to day 1
+	assert.Equal(t, 1, userProgram.CurrentDay)
+
+	// Sessions follow the program cycle and are spread through each week
+	for i, w := range user.WorkoutHistory {
+		assert.Equal(t, i%6+1, w.Day)
+		assert.Equal(t, userProgram.ID, w.UserProgramID)
+		assert.False(t, w.EnteredAt.Before(opts.Start))
+		if i > 0 {
+			assert.True(t, w.EnteredAt.After(user.WorkoutHistory[i-1].EnteredAt))
+		}
+	}
+	assert.Equal(t, opts.Start, user.WorkoutHistory[0].EnteredAt)
+	assert.Equal(t, opts.Start.AddDate(0, 0, 2), user.WorkoutHistory[1].EnteredAt)
+}
+
+func TestGenerate_IncludesProgressAndDeloads(t *testing.T) {
+	user, err := Generate(testOptions(42, 12))
+	require.NoError(t, err)
+
+	var failures int
+	for _, w := range user.WorkoutHistory {
+		for _, lift := range w.Exercises {
+			for _, set := range lift.Sets {
+				assert.True(t, set.IsComplete())
+				if set.Type == models.AMRAPSet && set.ActualReps < 5 {
+					failures++
+				}
+			}
+		}
+	}
+	assert.Positive(t, failures, "12 weeks of history should include at least one failed AMRAP set")
+
+	userProgram := user.Programs[user.CurrentProgram]
+	for lift, start := range DefaultStartingWeights {
+		assert.Greater(t, userProgram.CurrentWeights[lift], start, "%s should have progressed", lift)
+	}
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	_, err := Generate(testOptions(42, 0))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "weeks must be positive")
+
+	opts := testOptions(42, 4)
+	opts.Username = ""
+	_, err = Generate(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "username cannot be empty")
+}
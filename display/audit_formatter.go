@@ -0,0 +1,84 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+type AuditFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+}
+
+func NewAuditFormatter(out io.Writer) *AuditFormatter {
+	return &AuditFormatter{out: out}
+}
+
+// SetDateFormat sets how the dates of changes are shown
+func (f *AuditFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+func (f *AuditFormatter) Printf(format string, a ...any) {
+	fmt.Fprintf(f.out, i18n.T(format), a...)
+}
+
+// DisplayAudit lists audit entries oldest first, each with when it was made and
+// the command that made it. total is how many entries the log holds, of which
+// entries are the latest.
+func (f *AuditFormatter) DisplayAudit(username string, entries []models.AuditEntry, total int) {
+	if len(entries) == 0 {
+		f.Printf("No changes recorded for %s yet.\n", username)
+		return
+	}
+
+	if len(entries) < total {
+		f.Printf("Audit log for %s (latest %d of %d changes):\n", username, len(entries), total)
+	} else {
+		f.Printf("Audit log for %s (%s):\n", username, pluralize(total, "change", "changes"))
+	}
+
+	width := 0
+	for _, entry := range entries {
+		width = max(width, len(entry.Command))
+	}
+	for _, entry := range entries {
+		at := entry.At.Local()
+		f.Printf("  %s %s  %-*s  %s\n", f.dateFormat.Format(at), at.Format("15:04"), width, entry.Command, FormatAuditEntry(entry))
+	}
+}
+
+// FormatAuditEntry describes a change, e.g.
+// "weight changed Squat (OG Greyskull LP): 135 lbs → 140 lbs"
+func FormatAuditEntry(entry models.AuditEntry) string {
+	var b strings.Builder
+	b.WriteString(string(entry.Action))
+	if entry.Lift != "" {
+		b.WriteString(" " + FormatLiftName(entry.Lift))
+	}
+	if entry.Program != "" {
+		b.WriteString(" (" + entry.Program + ")")
+	}
+
+	switch {
+	case entry.Action == models.WeightChanged:
+		// A weight that was added or removed changes from or to nothing
+		b.WriteString(": " + orNone(entry.Before) + " → " + orNone(entry.After))
+	case entry.Before != "" && entry.After != "":
+		b.WriteString(": " + entry.Before + " → " + entry.After)
+	case entry.Before != "" || entry.After != "":
+		b.WriteString(": " + entry.Before + entry.After)
+	}
+	return b.String()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
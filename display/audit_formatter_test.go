@@ -0,0 +1,48 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAuditEntry(t *testing.T) {
+	assert.Equal(t, "weight changed Squat (OG Greyskull LP): 135 lbs → 140 lbs", FormatAuditEntry(models.AuditEntry{
+		Action: models.WeightChanged, Program: "OG Greyskull LP", Lift: models.Squat, Before: "135 lbs", After: "140 lbs",
+	}))
+	assert.Equal(t, "weight changed Squat (OG Greyskull LP): none → 135 lbs", FormatAuditEntry(models.AuditEntry{
+		Action: models.WeightChanged, Program: "OG Greyskull LP", Lift: models.Squat, After: "135 lbs",
+	}))
+	assert.Equal(t, "workout removed (OG Greyskull LP): Day 1 on 2024-03-04", FormatAuditEntry(models.AuditEntry{
+		Action: models.WorkoutRemoved, Program: "OG Greyskull LP", Before: "Day 1 on 2024-03-04",
+	}))
+	assert.Equal(t, "program switched: OG Greyskull LP → Conditioning", FormatAuditEntry(models.AuditEntry{
+		Action: models.ProgramSwitched, Before: "OG Greyskull LP", After: "Conditioning",
+	}))
+}
+
+func TestDisplayAudit(t *testing.T) {
+	at := time.Date(2024, 3, 4, 18, 5, 0, 0, time.Local)
+	entries := []models.AuditEntry{
+		{At: at, Command: "user create", Action: models.UserCreated, After: "Alice"},
+		{At: at.Add(time.Hour), Command: "goal set", Action: models.ProgramUpdated, Program: "OG Greyskull LP", After: "goals"},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewAuditFormatter(&buf)
+	formatter.DisplayAudit("Alice", entries, 2)
+	assert.Equal(t, "Audit log for Alice (2 changes):\n"+
+		"  2024-03-04 18:05  user create  user created: Alice\n"+
+		"  2024-03-04 19:05  goal set     program settings changed (OG Greyskull LP): goals\n", buf.String())
+
+	buf.Reset()
+	formatter.DisplayAudit("Alice", entries[1:], 2)
+	assert.Contains(t, buf.String(), "Audit log for Alice (latest 1 of 2 changes):\n")
+
+	buf.Reset()
+	formatter.DisplayAudit("Alice", nil, 0)
+	assert.Equal(t, "No changes recorded for Alice yet.\n", buf.String())
+}
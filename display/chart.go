@@ -0,0 +1,125 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+const (
+	// DefaultChartWidth is the most sessions plotted before a chart is downsampled
+	DefaultChartWidth = 60
+	// DefaultChartHeight is the number of rows in a chart's plot area
+	DefaultChartHeight = 10
+
+	weightMark = '●'
+	e1RMMark   = '·'
+)
+
+type ChartFormatter struct {
+	out     io.Writer
+	weights WeightFormat
+}
+
+func NewChartFormatter(out io.Writer) *ChartFormatter {
+	return &ChartFormatter{out: out}
+}
+
+// SetWeightFormat sets how the first and last weights are shown
+func (f *ChartFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *ChartFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayProgress prints a terminal line chart of a lift's working weight and
+// estimated one-rep max, one column per session. Histories longer than width
+// are downsampled to evenly spaced sessions, always keeping the first and last.
+func (f *ChartFormatter) DisplayProgress(lift models.LiftName, points []analytics.ProgressPoint, width, height int) {
+	if len(points) == 0 {
+		f.Printf("No %s workouts logged yet.\n", FormatLiftName(lift))
+		return
+	}
+
+	first, last := points[0], points[len(points)-1]
+	f.Printf("%s progression: %s to %s (%s)\n", FormatLiftName(lift),
+		first.Date.Format("2006-01-02"), last.Date.Format("2006-01-02"), pluralize(len(points), "session", "sessions"))
+	f.Printf("  %c Working weight: %s\n", weightMark, f.weights.FormatChange(first.Weight, last.Weight))
+	f.Printf("  %c Estimated 1RM:  %s\n\n", e1RMMark, f.weights.FormatChange(first.E1RM, last.E1RM))
+
+	for _, line := range progressChart(samplePoints(points, width), height) {
+		f.Printf("%s\n", line)
+	}
+}
+
+// samplePoints returns at most width points evenly spaced through points
+func samplePoints(points []analytics.ProgressPoint, width int) []analytics.ProgressPoint {
+	if width < 2 || len(points) <= width {
+		return points
+	}
+	sampled := make([]analytics.ProgressPoint, width)
+	for i := range sampled {
+		sampled[i] = points[i*(len(points)-1)/(width-1)]
+	}
+	return sampled
+}
+
+// progressChart renders points as rows of text: the plot area with the highest
+// and lowest values labelled on the y-axis, then the x-axis and its end dates.
+// Where both series fall in the same cell the working weight is drawn.
+func progressChart(points []analytics.ProgressPoint, height int) []string {
+	height = max(height, 2)
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		low = min(low, point.Weight, point.E1RM)
+		high = max(high, point.Weight, point.E1RM)
+	}
+
+	row := func(value float64) int {
+		if high == low {
+			return 0
+		}
+		return int(math.Round((value - low) / (high - low) * float64(height-1)))
+	}
+
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", len(points)))
+	}
+	for col, point := range points {
+		grid[row(point.E1RM)][col] = e1RMMark
+		grid[row(point.Weight)][col] = weightMark
+	}
+
+	highLabel, lowLabel := FormatWeight(high), FormatWeight(low)
+	labelWidth := max(len(highLabel), len(lowLabel))
+
+	lines := make([]string, 0, height+2)
+	for r := height - 1; r >= 0; r-- {
+		label := ""
+		switch {
+		case r == height-1 && high != low:
+			label = highLabel
+		case r == 0:
+			label = lowLabel
+		}
+		lines = append(lines, fmt.Sprintf("%*s ┤%s", labelWidth, label, strings.TrimRight(string(grid[r]), " ")))
+	}
+	lines = append(lines, fmt.Sprintf("%*s └%s", labelWidth, "", strings.Repeat("─", len(points))))
+
+	start := points[0].Date.Format("2006-01-02")
+	end := points[len(points)-1].Date.Format("2006-01-02")
+	dates := start
+	if len(points) > 1 && start != end {
+		dates += strings.Repeat(" ", max(len(points)-len(start)-len(end), 1)) + end
+	}
+	lines = append(lines, fmt.Sprintf("%*s  %s", labelWidth, "", dates))
+	return lines
+}
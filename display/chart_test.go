@@ -0,0 +1,55 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func chartPoints(weights ...float64) []analytics.ProgressPoint {
+	start := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	points := make([]analytics.ProgressPoint, len(weights))
+	for i, weight := range weights {
+		points[i] = analytics.ProgressPoint{Date: start.AddDate(0, 0, 2*i), Weight: weight, Reps: 5, E1RM: weight + 20}
+	}
+	return points
+}
+
+func TestChartFormatter_DisplayProgress(t *testing.T) {
+	var buf bytes.Buffer
+	NewChartFormatter(&buf).DisplayProgress(models.Squat, chartPoints(100, 110, 120, 130, 140, 150, 160, 170, 180, 190, 200), 60, 3)
+
+	expected := "Squat progression: 2024-03-04 to 2024-03-24 (11 sessions)\n" +
+		"  ● Working weight: 100 → 200 lbs\n" +
+		"  · Estimated 1RM:  120 → 220 lbs\n" +
+		"\n" +
+		"220 ┤       ··●●\n" +
+		"    ┤ ··●●●●●●\n" +
+		"100 ┤●●●\n" +
+		"    └───────────\n" +
+		"     2024-03-04 2024-03-24\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestChartFormatter_NoWorkouts(t *testing.T) {
+	var buf bytes.Buffer
+	NewChartFormatter(&buf).DisplayProgress(models.BenchPress, nil, 60, 10)
+	assert.Equal(t, "No Bench Press workouts logged yet.\n", buf.String())
+}
+
+func TestSamplePoints(t *testing.T) {
+	points := chartPoints(100, 105, 110, 115, 120, 125, 130)
+
+	assert.Equal(t, points, samplePoints(points, 10))
+	assert.Equal(t, []analytics.ProgressPoint{points[0], points[3], points[6]}, samplePoints(points, 3))
+}
+
+func TestProgressChart_EndDates(t *testing.T) {
+	lines := progressChart(chartPoints(100, 105, 110, 115, 120, 125, 130, 135, 140, 145, 150, 155, 160, 165, 165, 165, 170, 175, 180, 185, 190, 195), 2)
+
+	assert.Equal(t, "     2024-03-04  2024-04-15", lines[len(lines)-1])
+}
@@ -0,0 +1,63 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikowitz/greyskull/doctor"
+	"github.com/mikowitz/greyskull/i18n"
+)
+
+type DoctorFormatter struct {
+	out io.Writer
+}
+
+func NewDoctorFormatter(out io.Writer) *DoctorFormatter {
+	return &DoctorFormatter{out: out}
+}
+
+func (f *DoctorFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayReports lists the problems in each report with how each can be fixed,
+// or how it was fixed when fixed is true, followed by a count of problems
+func (f *DoctorFormatter) DisplayReports(reports []doctor.Report, fixed bool) {
+	problems, fixable := 0, 0
+	for _, report := range reports {
+		if len(report.Issues) == 0 {
+			continue
+		}
+
+		f.Printf("%s:\n", report.Subject)
+		for _, issue := range report.Issues {
+			problems++
+			f.Printf("  - %s\n", issue.Problem)
+			if !issue.Fixable() {
+				continue
+			}
+			fixable++
+			if fixed {
+				f.Printf("    Fixed: %s\n", issue.Repair)
+			} else {
+				f.Printf("    Fix: %s\n", issue.Repair)
+			}
+		}
+		f.Printf("\n")
+	}
+
+	switch {
+	case problems == 0:
+		f.Printf("No problems found.\n")
+	case fixed:
+		f.Printf("Found %s and fixed %d.\n", pluralize(problems, "problem", "problems"), fixable)
+	case fixable == 0:
+		f.Printf("Found %s. None can be fixed automatically.\n", pluralize(problems, "problem", "problems"))
+	case problems == 1:
+		f.Printf("Found 1 problem. Run 'greyskull doctor --fix' to fix it.\n")
+	case fixable == problems:
+		f.Printf("Found %d problems. Run 'greyskull doctor --fix' to fix them.\n", problems)
+	default:
+		f.Printf("Found %s. Run 'greyskull doctor --fix' to fix %d of them.\n", pluralize(problems, "problem", "problems"), fixable)
+	}
+}
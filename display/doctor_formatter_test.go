@@ -0,0 +1,51 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mikowitz/greyskull/doctor"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorFormatter_DisplayReports(t *testing.T) {
+	fixable := doctor.CheckFiles(nil, "Ghost")
+	unfixable := []doctor.Issue{{Problem: "program started 2024-03-01 follows unknown program 1234"}}
+	reports := []doctor.Report{
+		{Subject: "Data directory", Issues: fixable},
+		{Subject: "Alice"},
+		{Subject: "Bob", Issues: unfixable},
+	}
+
+	var buf bytes.Buffer
+	NewDoctorFormatter(&buf).DisplayReports(reports, false)
+	assert.Equal(t, `Data directory:
+  - the current user Ghost doesn't exist
+    Fix: clear the current user; switch to another with 'greyskull user switch'
+
+Bob:
+  - program started 2024-03-01 follows unknown program 1234
+
+Found 2 problems. Run 'greyskull doctor --fix' to fix 1 of them.
+`, buf.String())
+
+	buf.Reset()
+	NewDoctorFormatter(&buf).DisplayReports(reports, true)
+	assert.Contains(t, buf.String(), "    Fixed: clear the current user; switch to another with 'greyskull user switch'\n")
+	assert.Contains(t, buf.String(), "Found 2 problems and fixed 1.\n")
+
+	buf.Reset()
+	NewDoctorFormatter(&buf).DisplayReports(reports[:1], false)
+	assert.Contains(t, buf.String(), "Found 1 problem. Run 'greyskull doctor --fix' to fix it.\n")
+
+	unreadable := doctor.CheckFiles([]repository.UserFile{{Path: "carol.json", Err: errors.New("bad JSON")}}, "")
+	buf.Reset()
+	NewDoctorFormatter(&buf).DisplayReports([]doctor.Report{{Subject: "Data directory", Issues: unreadable}}, false)
+	assert.Contains(t, buf.String(), "Found 1 problem. None can be fixed automatically.\n")
+
+	buf.Reset()
+	NewDoctorFormatter(&buf).DisplayReports([]doctor.Report{{Subject: "Alice"}}, false)
+	assert.Equal(t, "No problems found.\n", buf.String())
+}
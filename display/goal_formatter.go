@@ -0,0 +1,89 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+)
+
+// progressBarWidth is the number of cells in a goal progress bar
+const progressBarWidth = 20
+
+type GoalFormatter struct {
+	out     io.Writer
+	weights WeightFormat
+}
+
+func NewGoalFormatter(out io.Writer) *GoalFormatter {
+	return &GoalFormatter{out: out}
+}
+
+// SetWeightFormat sets how goal weights are shown
+func (f *GoalFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *GoalFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayGoals prints a progress bar and ETA for each goal
+func (f *GoalFormatter) DisplayGoals(goals []analytics.GoalProgress) {
+	if len(goals) == 0 {
+		f.Printf("No goals set. Set one with 'greyskull goal set <lift> <weight>'.\n")
+		return
+	}
+
+	f.Printf("Goals:\n")
+	for _, goal := range goals {
+		f.Printf("  %s\n", FormatGoalProgress(goal, f.weights))
+	}
+}
+
+// FormatGoalProgress formats a goal on one line, e.g.
+// "Squat: 225 / 315 lbs [##########----------] 50%, ETA 2024-06-01 (+7.5 lbs/week)".
+// A goal with a date adds the rate it needs, e.g. ", needs +10 lbs/week to reach by 2024-05-01".
+// With dual units, the weights follow in the other unit, e.g. "225 / 315 lbs (102.1 / 142.9 kg)".
+func FormatGoalProgress(goal analytics.GoalProgress, weights WeightFormat) string {
+	unit := weights.Unit.OrDefault()
+	progress := fmt.Sprintf("%s / %s %s", FormatWeight(goal.Current), FormatWeight(goal.Goal), unit)
+	if weights.Dual {
+		progress += fmt.Sprintf(" (%s / %s %s)", FormatWeight(weights.converted(goal.Current)),
+			FormatWeight(weights.converted(goal.Goal)), otherUnit(unit))
+	}
+	line := fmt.Sprintf("%s: %s %s %d%%", FormatLiftName(goal.Lift), progress,
+		FormatProgressBar(goal.Fraction), int(math.Floor(goal.Fraction*100)))
+
+	switch {
+	case goal.Reached:
+		return line + i18n.T(", reached!")
+	case goal.ETA.IsZero():
+		line += i18n.T(", ETA unknown until the lift progresses")
+	default:
+		line += i18n.Sprintf(", ETA %s (%s)", goal.ETA.Format("2006-01-02"), formatWeeklyRate(goal.WeeklyRate, weights))
+	}
+
+	switch {
+	case goal.By.IsZero():
+		return line
+	case goal.RequiredRate == 0:
+		return line + i18n.Sprintf(", target date %s passed", goal.By.Format("2006-01-02"))
+	default:
+		return line + i18n.Sprintf(", needs %s to reach by %s", formatWeeklyRate(goal.RequiredRate, weights), goal.By.Format("2006-01-02"))
+	}
+}
+
+// formatWeeklyRate formats a weight gained per week to a tenth, e.g. "+7.5 lbs/week"
+func formatWeeklyRate(rate float64, weights WeightFormat) string {
+	return i18n.Sprintf("+%s/week", weights.Format(math.Round(rate*10)/10))
+}
+
+// FormatProgressBar draws a fraction from 0 to 1 as a bar, e.g. "[#####---------------]"
+func FormatProgressBar(fraction float64) string {
+	filled := int(math.Floor(min(max(fraction, 0), 1) * progressBarWidth))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
@@ -0,0 +1,62 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatProgressBar(t *testing.T) {
+	assert.Equal(t, "[--------------------]", FormatProgressBar(0))
+	assert.Equal(t, "[##########----------]", FormatProgressBar(0.5))
+	assert.Equal(t, "[####################]", FormatProgressBar(1))
+	assert.Equal(t, "[####################]", FormatProgressBar(1.5))
+	assert.Equal(t, "[--------------------]", FormatProgressBar(-0.2))
+}
+
+func TestFormatGoalProgress(t *testing.T) {
+	goal := analytics.GoalProgress{Lift: models.Squat, Goal: 315, Start: 135, Current: 225, Fraction: 0.5}
+	assert.Equal(t, "Squat: 225 / 315 lbs [##########----------] 50%, ETA unknown until the lift progresses",
+		FormatGoalProgress(goal, WeightFormat{Unit: models.Pounds}))
+
+	goal.WeeklyRate = 7.5
+	goal.ETA = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Squat: 225 / 315 lbs [##########----------] 50%, ETA 2024-06-01 (+7.5 lbs/week)",
+		FormatGoalProgress(goal, WeightFormat{Unit: models.Pounds}))
+
+	goal.By = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	goal.RequiredRate = 10.04
+	assert.Equal(t, "Squat: 225 / 315 lbs [##########----------] 50%, ETA 2024-06-01 (+7.5 lbs/week), needs +10 lbs/week to reach by 2024-05-01",
+		FormatGoalProgress(goal, WeightFormat{Unit: models.Pounds}))
+
+	goal.RequiredRate = 0
+	assert.Equal(t, "Squat: 225 / 315 lbs [##########----------] 50%, ETA 2024-06-01 (+7.5 lbs/week), target date 2024-05-01 passed",
+		FormatGoalProgress(goal, WeightFormat{Unit: models.Pounds}))
+
+	goal = analytics.GoalProgress{Lift: models.BenchPress, Goal: 100, Start: 60, Current: 100, Fraction: 1, Reached: true}
+	assert.Equal(t, "Bench Press: 100 / 100 kg [####################] 100%, reached!",
+		FormatGoalProgress(goal, WeightFormat{Unit: models.Kilograms}))
+}
+
+func TestFormatGoalProgress_DualUnits(t *testing.T) {
+	goal := analytics.GoalProgress{Lift: models.Squat, Goal: 315, Start: 135, Current: 225, Fraction: 0.5,
+		WeeklyRate: 7.5, ETA: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "Squat: 225 / 315 lbs (102.1 / 142.9 kg) [##########----------] 50%, ETA 2024-06-01 (+7.5 lbs / 3.4 kg/week)",
+		FormatGoalProgress(goal, WeightFormat{Dual: true}))
+}
+
+func TestDisplayGoals(t *testing.T) {
+	var buf bytes.Buffer
+	NewGoalFormatter(&buf).DisplayGoals([]analytics.GoalProgress{
+		{Lift: models.Deadlift, Goal: 405, Start: 185, Current: 405, Fraction: 1, Reached: true},
+	})
+	assert.Equal(t, "Goals:\n  Deadlift: 405 / 405 lbs [####################] 100%, reached!\n", buf.String())
+
+	buf.Reset()
+	NewGoalFormatter(&buf).DisplayGoals(nil)
+	assert.Equal(t, "No goals set. Set one with 'greyskull goal set <lift> <weight>'.\n", buf.String())
+}
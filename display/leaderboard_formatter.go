@@ -0,0 +1,42 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+type LeaderboardFormatter struct {
+	out io.Writer
+}
+
+func NewLeaderboardFormatter(out io.Writer) *LeaderboardFormatter {
+	return &LeaderboardFormatter{out: out}
+}
+
+func (f *LeaderboardFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayLeaderboard prints ranked standings for a lift, each in its own unit
+func (f *LeaderboardFormatter) DisplayLeaderboard(lift models.LiftName, metric analytics.LeaderboardMetric, standings []analytics.Standing) {
+	if len(standings) == 0 {
+		f.Printf("No one to rank for %s yet. Users join the leaderboard with 'greyskull user leaderboard on'.\n", FormatLiftName(lift))
+		return
+	}
+
+	f.Printf("%s Leaderboard (%s):\n", FormatLiftName(lift), formatLeaderboardMetric(metric))
+	for i, standing := range standings {
+		f.Printf("  %d. %s: %s %s\n", i+1, standing.Username, FormatWeight(standing.Weight), standing.Unit.OrDefault())
+	}
+}
+
+func formatLeaderboardMetric(metric analytics.LeaderboardMetric) string {
+	if metric == analytics.ByE1RM {
+		return "best e1RM"
+	}
+	return "working weight"
+}
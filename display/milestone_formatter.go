@@ -0,0 +1,73 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/milestones"
+	"github.com/mikowitz/greyskull/models"
+)
+
+type MilestoneFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+}
+
+func NewMilestoneFormatter(out io.Writer) *MilestoneFormatter {
+	return &MilestoneFormatter{out: out}
+}
+
+// SetDateFormat sets how unlock dates are shown
+func (f *MilestoneFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+func (f *MilestoneFormatter) Printf(format string, a ...any) {
+	fmt.Fprintf(f.out, i18n.T(format), a...)
+}
+
+// DisplayUnlocked announces milestones unlocked by a workout
+func (f *MilestoneFormatter) DisplayUnlocked(unlocked []milestones.Milestone) {
+	if len(unlocked) == 0 {
+		return
+	}
+
+	f.Printf("\n")
+	for _, milestone := range unlocked {
+		name, description := milestone.Localized()
+		f.Printf("Milestone unlocked! %s: %s\n", name, description)
+	}
+}
+
+// DisplayMilestones lists the user's unlocked milestones with when each was
+// reached, then the milestones still to unlock
+func (f *MilestoneFormatter) DisplayMilestones(unlocked []models.UnlockedMilestone) {
+	f.Printf("Achievements (%d of %d unlocked):\n", len(unlocked), len(milestones.All))
+	if len(unlocked) == 0 {
+		f.Printf("  None yet. Log a workout to unlock your first.\n")
+	}
+	for _, u := range unlocked {
+		// Milestones retired from the catalog keep their ID
+		name := u.ID
+		if milestone, ok := milestones.Lookup(u.ID); ok {
+			name, _ = milestone.Localized()
+		}
+		f.Printf("  %s  %s\n", f.dateFormat.Format(u.UnlockedAt), name)
+	}
+
+	var locked []milestones.Milestone
+	for _, milestone := range milestones.All {
+		if !milestones.IsUnlocked(unlocked, milestone.ID) {
+			locked = append(locked, milestone)
+		}
+	}
+	if len(locked) == 0 {
+		return
+	}
+	f.Printf("\nStill to unlock:\n")
+	for _, milestone := range locked {
+		name, description := milestone.Localized()
+		f.Printf("  %s: %s\n", name, description)
+	}
+}
@@ -0,0 +1,38 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/milestones"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayMilestones(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewMilestoneFormatter(&buf)
+	formatter.SetDateFormat(models.DateUS)
+	formatter.DisplayMilestones([]models.UnlockedMilestone{
+		{ID: "workouts-1", UnlockedAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)},
+		{ID: "retired", UnlockedAt: time.Date(2024, 3, 6, 18, 0, 0, 0, time.UTC)},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, fmt.Sprintf("Achievements (2 of %d unlocked):\n", len(milestones.All)))
+	assert.Contains(t, output, "  03/04/2024  First Workout\n  03/06/2024  retired\n\nStill to unlock:\n")
+	assert.Contains(t, output, "  10th Workout: Log 10 workouts\n")
+	assert.NotContains(t, output, "First Workout:")
+}
+
+func TestDisplayUnlocked(t *testing.T) {
+	var buf bytes.Buffer
+	NewMilestoneFormatter(&buf).DisplayUnlocked(nil)
+	assert.Empty(t, buf.String())
+
+	milestone, _ := milestones.Lookup("club-1000")
+	NewMilestoneFormatter(&buf).DisplayUnlocked([]milestones.Milestone{milestone})
+	assert.Equal(t, "\nMilestone unlocked! 1000 lb Club: Reach a combined 1000 lbs across your heaviest squat, bench press, and deadlift\n", buf.String())
+}
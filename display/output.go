@@ -0,0 +1,119 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Verbosity is how much of a command's text output is shown
+type Verbosity int
+
+const (
+	Quiet   Verbosity = iota - 1 // Only prompts, warnings, and summaries
+	Normal                       // Everything a command usually prints
+	Verbose                      // Debug detail as well, such as files written
+)
+
+// LeveledWriter passes on the text written at its verbosity or below, and
+// discards the rest
+type LeveledWriter struct {
+	out       io.Writer
+	verbosity Verbosity
+}
+
+func NewLeveledWriter(out io.Writer, verbosity Verbosity) *LeveledWriter {
+	return &LeveledWriter{out: out, verbosity: verbosity}
+}
+
+// At returns the writer for text shown at level: the underlying writer if the
+// verbosity includes level, io.Discard if not
+func (w *LeveledWriter) At(level Verbosity) io.Writer {
+	if w.verbosity < level {
+		return io.Discard
+	}
+	return w.out
+}
+
+// Verbosity returns the writer's verbosity
+func (w *LeveledWriter) Verbosity() Verbosity {
+	return w.verbosity
+}
+
+// OutputFormatter receives a command's output: text for people as the command
+// writes it, and a structured result for other programs once it's known
+type OutputFormatter interface {
+	// Text returns the writer for the command's human-readable output
+	Text() io.Writer
+	// Result sets the command's structured result, replacing any set before
+	Result(v any)
+	// Flush writes whatever was held back until the command finished
+	Flush() error
+}
+
+// TextOutput writes human-readable text as it's written and ignores results
+type TextOutput struct {
+	out io.Writer
+}
+
+func NewTextOutput(out io.Writer) *TextOutput {
+	return &TextOutput{out: out}
+}
+
+// Text implements OutputFormatter
+func (o *TextOutput) Text() io.Writer {
+	return o.out
+}
+
+// Result implements OutputFormatter
+func (o *TextOutput) Result(any) {}
+
+// Flush implements OutputFormatter
+func (o *TextOutput) Flush() error {
+	return nil
+}
+
+// JSONOutput writes a command's result to out as indented JSON, keeping out
+// free of anything else: text, including prompts, goes to the text writer
+// (normally stderr) instead. A command that sets no result is reported as
+// {"output": "<its text>"}.
+type JSONOutput struct {
+	out       io.Writer
+	text      io.Writer
+	captured  bytes.Buffer
+	result    any
+	hasResult bool
+}
+
+func NewJSONOutput(out, text io.Writer) *JSONOutput {
+	o := &JSONOutput{out: out}
+	o.text = io.MultiWriter(text, &o.captured)
+	return o
+}
+
+// Text implements OutputFormatter
+func (o *JSONOutput) Text() io.Writer {
+	return o.text
+}
+
+// Result implements OutputFormatter
+func (o *JSONOutput) Result(v any) {
+	o.result = v
+	o.hasResult = true
+}
+
+// Flush implements OutputFormatter
+func (o *JSONOutput) Flush() error {
+	result := o.result
+	if !o.hasResult {
+		result = map[string]string{"output": o.captured.String()}
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	_, err = o.out.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,63 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextOutput(t *testing.T) {
+	var out bytes.Buffer
+	output := NewTextOutput(&out)
+
+	fmt.Fprint(output.Text(), "Squat: 135 lbs\n")
+	output.Result(map[string]int{"squat": 135})
+	require.NoError(t, output.Flush())
+
+	assert.Equal(t, "Squat: 135 lbs\n", out.String())
+}
+
+func TestJSONOutput(t *testing.T) {
+	var out, text bytes.Buffer
+	output := NewJSONOutput(&out, &text)
+
+	fmt.Fprint(output.Text(), "Squat: 135 lbs\n")
+	output.Result(map[string]int{"squat": 130})
+	output.Result(map[string]int{"squat": 135})
+	assert.Empty(t, out.String(), "nothing is written until the command finishes")
+	require.NoError(t, output.Flush())
+
+	assert.Equal(t, "{\n  \"squat\": 135\n}\n", out.String())
+	assert.Equal(t, "Squat: 135 lbs\n", text.String())
+}
+
+func TestJSONOutput_NoResult(t *testing.T) {
+	var out, text bytes.Buffer
+	output := NewJSONOutput(&out, &text)
+
+	fmt.Fprint(output.Text(), "Settings saved.\n")
+	require.NoError(t, output.Flush())
+
+	assert.Equal(t, "{\n  \"output\": \"Settings saved.\\n\"\n}\n", out.String())
+}
+
+func TestLeveledWriter(t *testing.T) {
+	var out bytes.Buffer
+
+	quiet := NewLeveledWriter(&out, Quiet)
+	fmt.Fprint(quiet.At(Quiet), "summary\n")
+	fmt.Fprint(quiet.At(Normal), "workout\n")
+	fmt.Fprint(quiet.At(Verbose), "detail\n")
+	assert.Equal(t, "summary\n", out.String())
+
+	out.Reset()
+	verbose := NewLeveledWriter(&out, Verbose)
+	fmt.Fprint(verbose.At(Quiet), "summary\n")
+	fmt.Fprint(verbose.At(Normal), "workout\n")
+	fmt.Fprint(verbose.At(Verbose), "detail\n")
+	assert.Equal(t, "summary\nworkout\ndetail\n", out.String())
+	assert.Equal(t, Verbose, verbose.Verbosity())
+}
@@ -0,0 +1,545 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/workout"
+)
+
+type ProgramFormatter struct {
+	out     io.Writer
+	weights WeightFormat
+}
+
+func NewProgramFormatter(out io.Writer) *ProgramFormatter {
+	return &ProgramFormatter{out: out}
+}
+
+// SetWeightFormat sets how a program's weights are shown, in the unit it's
+// trained in
+func (f *ProgramFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *ProgramFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayProgramList prints a numbered summary line for each program
+func (f *ProgramFormatter) DisplayProgramList(programs []*models.Program) {
+	if len(programs) == 0 {
+		f.Printf("No programs available.\n")
+		return
+	}
+
+	f.Printf("Available programs:\n")
+	for i, prog := range programs {
+		f.Printf("%d. %s (v%s, %d days)\n", i+1, prog.Name, prog.Version, len(prog.Workouts))
+		f.Printf("   ID: %s\n", prog.ID)
+	}
+}
+
+// DisplayUserPrograms prints a numbered list of a user's programs with their start
+// date, current day, and current weights. The current program is marked with an asterisk,
+// and programs trained alongside it with a plus. Programs without a snapshot of their
+// template use the one in programs, and are listed by their program ID if it can't be found.
+func (f *ProgramFormatter) DisplayUserPrograms(userPrograms []*models.UserProgram, currentID uuid.UUID, alongside []uuid.UUID, programs map[uuid.UUID]*models.Program) {
+	if len(userPrograms) == 0 {
+		f.Printf("You haven't started any programs. Use 'greyskull program start' to begin one.\n")
+		return
+	}
+
+	f.Printf("Your programs:\n")
+	for i, up := range userPrograms {
+		marker := " "
+		if up.ID == currentID {
+			marker = "*"
+		} else if slices.Contains(alongside, up.ID) {
+			marker = "+"
+		}
+
+		name := "Unknown program " + up.ProgramID.String()
+		dayInfo := i18n.Sprintf("Day %d", up.CurrentDay)
+		prog, exists := programs[up.ProgramID]
+		if up.Program != nil {
+			prog, exists = up.Program, true
+		}
+		if exists {
+			name = prog.Name
+			dayInfo = i18n.Sprintf("Day %d of %d", up.CurrentDay, len(prog.Workouts))
+		}
+
+		f.Printf("%s %d. %s\n", marker, i+1, name)
+		f.Printf("     ID: %s\n", up.ID)
+		f.Printf("     Started: %s, %s\n", up.StartedAt.Format("2006-01-02"), dayInfo)
+		if len(up.CurrentWeights) > 0 {
+			f.Printf("     Weights: %s\n", FormatWeights(up.CurrentWeights))
+		}
+	}
+}
+
+// DisplaySwitchPreview describes the program being switched to: when it was started
+// and last trained, its current weights, and its next session. Programs untouched
+// for more than services.StaleProgramDays come with a warning.
+func (f *ProgramFormatter) DisplaySwitchPreview(preview *services.SwitchPreview) {
+	toName := FormatProgramName(preview.To, preview.ToProgram)
+	if preview.From != nil {
+		f.Printf("Switching from %s to %s\n", FormatProgramName(preview.From, preview.FromProgram), toName)
+	} else {
+		f.Printf("Switching to %s\n", toName)
+	}
+
+	f.Printf("  Started: %s\n", preview.To.StartedAt.Format("2006-01-02"))
+	if preview.Trained {
+		f.Printf("  Last trained: %s (%s ago)\n", preview.LastTrained.Format("2006-01-02"), pluralize(preview.DaysSince, "day", "days"))
+	} else {
+		f.Printf("  Last trained: never\n")
+	}
+	if len(preview.To.CurrentWeights) > 0 {
+		f.Printf("  Weights: %s\n", FormatWeights(preview.To.CurrentWeights))
+	}
+	if preview.NextWorkout != nil {
+		f.Printf("  Next session: Day %d (%s)\n", preview.NextWorkout.Day, formatSessionWeights(preview.NextWorkout))
+	} else {
+		f.Printf("  Next session: Day %d\n", preview.To.CurrentDay)
+	}
+
+	if preview.Stale {
+		f.Printf("\nWarning: %s hasn't been touched in %s. Consider lowering its weights before resuming.\n",
+			toName, pluralize(preview.DaysSince, "day", "days"))
+	}
+}
+
+// DisplayUpgradePreview describes moving a program onto the current version of
+// its template: the versions, any change in its number of days, the lifts that
+// need starting weights, and the day it continues on
+func (f *ProgramFormatter) DisplayUpgradePreview(preview *services.UpgradePreview) {
+	to := preview.To
+	if preview.UpToDate {
+		f.Printf("%s is already on the latest version (v%s).\n", to.Name, to.Version)
+		return
+	}
+
+	if preview.From == nil {
+		f.Printf("Pinning %s to v%s\n", to.Name, to.Version)
+		f.Printf("  Later changes to the template won't change this program until you upgrade it.\n")
+	} else {
+		f.Printf("Upgrading %s from v%s to v%s\n", to.Name, preview.From.Version, to.Version)
+		if len(preview.From.Workouts) != len(to.Workouts) {
+			f.Printf("  Days: %d → %d\n", len(preview.From.Workouts), len(to.Workouts))
+		}
+	}
+	if len(preview.NewLifts) > 0 {
+		names := make([]string, len(preview.NewLifts))
+		for i, lift := range preview.NewLifts {
+			names[i] = FormatLiftName(lift)
+		}
+		f.Printf("  New lifts: %s\n", strings.Join(names, ", "))
+	}
+	if preview.Day != preview.Program.CurrentDay {
+		f.Printf("  Next session: Day %d (Day %d no longer exists)\n", preview.Day, preview.Program.CurrentDay)
+	} else {
+		f.Printf("  Next session: Day %d\n", preview.Day)
+	}
+}
+
+// DisplayResume describes a program resumed after days away. When a reduction
+// is suggested, each lift's reduced weight is listed: as applied if applied is
+// set, and otherwise with a way to ease back in without changing them for good.
+func (f *ProgramFormatter) DisplayResume(days int, percentage float64, current, resumed map[models.LiftName]float64, applied bool) {
+	f.Printf("Resumed after %s away.\n", pluralize(days, "day", "days"))
+	if percentage >= 1 {
+		return
+	}
+
+	if applied {
+		f.Printf("Weights reduced to %s to ease back in:\n", formatPercentage(percentage))
+	} else {
+		f.Printf("Consider easing back in at %s of your weights:\n", formatPercentage(percentage))
+	}
+	for _, liftName := range orderedLiftKeys(resumed) {
+		if resumed[liftName] == current[liftName] {
+			continue
+		}
+		label := "now"
+		if applied {
+			label = "was"
+		}
+		f.Printf("  %s: %s (%s %s)\n", FormatLiftName(liftName), f.weights.Format(resumed[liftName]), label, f.weights.Format(current[liftName]))
+	}
+	if !applied {
+		f.Printf("Run 'greyskull deload week --percent %s' for a lighter week.\n", FormatWeight(math.Round(percentage*1000)/10))
+	}
+}
+
+// DisplayLayoff describes the time since a program was last trained and the
+// percentage of its weights suggested for resetting them
+func (f *ProgramFormatter) DisplayLayoff(lastTrained time.Time, daysOff int, percentage float64) {
+	f.Printf("Last trained %s (%s ago).\n", lastTrained.Format("2006-01-02"), pluralize(daysOff, "day", "days"))
+	if percentage < 1 {
+		f.Printf("Suggesting %s of your current weights, 10%% less for each month off.\n", formatPercentage(percentage))
+	} else {
+		f.Printf("That's less than a month off, so your current weights are suggested.\n")
+	}
+}
+
+// DisplayWeightReset lists each lift's weight after a reset, with the weight it
+// replaced
+func (f *ProgramFormatter) DisplayWeightReset(reset models.WeightReset) {
+	f.Printf("Weights reset after %s off:\n", pluralize(reset.DaysOff, "day", "days"))
+	for _, liftName := range orderedLiftKeys(reset.Weights) {
+		weight, previous := reset.Weights[liftName], reset.Previous[liftName]
+		if weight == previous {
+			f.Printf("  %s: %s (unchanged)\n", FormatLiftName(liftName), f.weights.Format(weight))
+		} else {
+			f.Printf("  %s: %s (was %s)\n", FormatLiftName(liftName), f.weights.Format(weight), f.weights.Format(previous))
+		}
+	}
+}
+
+// DisplayRecompute summarizes what was replayed to recompute a program's state
+// and lists the weights and the day that differ from the stored ones
+func (f *ProgramFormatter) DisplayRecompute(result *workout.Recomputation, divergences []workout.WeightDivergence, storedDay int) {
+	f.Printf("Replayed %s, %s, and %s.\n", pluralize(result.Workouts, "workout", "workouts"),
+		pluralize(result.SkippedDays, "skipped day", "skipped days"), pluralize(result.WeightResets, "weight reset", "weight resets"))
+
+	recomputedDay := result.UserProgram.CurrentDay
+	if len(divergences) == 0 && storedDay == recomputedDay {
+		f.Printf("Your stored weights and next day match your history.\n")
+		return
+	}
+
+	f.Printf("\nDifferences from your stored values:\n")
+	for _, d := range divergences {
+		f.Printf("  %s: stored %s, recomputed %s\n", FormatLiftName(d.Lift), f.weights.Format(d.Stored), f.weights.Format(d.Recomputed))
+	}
+	if storedDay != recomputedDay {
+		f.Printf("  Next day: stored Day %d, recomputed Day %d\n", storedDay, recomputedDay)
+	}
+}
+
+// FormatProgramName returns the name of the program a UserProgram follows, or its
+// program ID if the template is missing
+func FormatProgramName(up *models.UserProgram, prog *models.Program) string {
+	if prog == nil {
+		return "Unknown program " + up.ProgramID.String()
+	}
+	return prog.Name
+}
+
+// formatSessionWeights lists each lift in a workout with its working weight,
+// e.g. "Overhead Press 95, Squat 135"
+func formatSessionWeights(workout *models.Workout) string {
+	var parts []string
+	for _, lift := range workout.Exercises {
+		if lift.Optional {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", FormatLiftName(lift.WeightKey()), FormatWeight(topWorkingWeight(&lift))))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// topWorkingWeight returns the heaviest working set of a lift, or 0 if it has none
+func topWorkingWeight(lift *models.Lift) float64 {
+	top := 0.0
+	for _, set := range lift.Sets {
+		if set.Type == models.WorkingSet || set.Type == models.AMRAPSet {
+			top = max(top, set.Weight)
+		}
+	}
+	return top
+}
+
+// DisplayCyclePreview prints the working weight of each lift in a run of
+// upcoming workouts, with the plates to load on each side of the bar from the
+// config's inventory. Accessories are left out.
+func (f *ProgramFormatter) DisplayCyclePreview(prog *models.Program, workouts []*models.Workout, config *models.Config) {
+	unit := f.weights.Unit.OrDefault()
+	f.Printf("Upcoming cycle of %s, assuming each session succeeds:\n", prog.Name)
+	for _, next := range workouts {
+		f.Printf("\nDay %d:\n", next.Day)
+		for _, lift := range next.Exercises {
+			if lift.Optional {
+				continue
+			}
+			weight := topWorkingWeight(&lift)
+			if lift.Bodyweight {
+				f.Printf("  %s: %s\n", FormatLiftName(lift.WeightKey()), FormatAddedWeight(weight, f.weights))
+				continue
+			}
+			barWeight := config.BarWeightFor(lift.LiftName, unit)
+			plates, remainder := workout.PlatesPerSide(weight, barWeight, config, unit)
+			loading := formatPlates(plates, remainder, unit)
+			if remainder > 0 {
+				below, above := workout.NearestLoadable(weight, barWeight, config, unit)
+				warning := workout.LoadWarning{Weight: weight, Below: below, Above: above}
+				loading += "; nearest loadable: " + formatNearestLoadable(warning, unit)
+			}
+			f.Printf("  %s: %s (%s)\n", FormatLiftName(lift.WeightKey()), f.weights.Format(weight), loading)
+		}
+	}
+}
+
+// DisplayProjection prints a projection's weights at the end of each week from
+// start, followed by how far each lift moves overall
+func (f *ProgramFormatter) DisplayProjection(projection *workout.Projection, start time.Time) {
+	weeks := 0
+	for i, point := range projection.Points {
+		week := int(point.Date.Sub(start).Hours()/24/7) + 1
+		if i+1 < len(projection.Points) && int(projection.Points[i+1].Date.Sub(start).Hours()/24/7)+1 == week {
+			continue
+		}
+		f.Printf("Week %d: %s\n", week, f.formatWeights(point.Weights))
+		weeks = week
+	}
+
+	final := projection.Final()
+	f.Printf("\nAfter %s (%s):\n", pluralize(weeks, "week", "weeks"), pluralize(len(projection.Points), "session", "sessions"))
+	for _, lift := range orderedLiftKeys(final) {
+		before, after := projection.Start[lift], final[lift]
+		f.Printf("  %s: %s (%s)\n", FormatLiftName(lift), f.weights.FormatChange(before, after), formatDifference(after-before))
+	}
+}
+
+// formatWeights formats a set of weights on one line as FormatWeights does,
+// or with their units when the weight format shows dual units
+func (f *ProgramFormatter) formatWeights(weights map[models.LiftName]float64) string {
+	if !f.weights.Dual {
+		return FormatWeights(weights)
+	}
+	parts := make([]string, 0, len(weights))
+	for _, liftName := range orderedLiftKeys(weights) {
+		parts = append(parts, fmt.Sprintf("%s %s", FormatLiftName(liftName), f.weights.Format(weights[liftName])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPlates describes the plates loaded on each side of a bar, e.g. "45, 10
+// per side", and any weight per side they can't make up
+func formatPlates(plates []float64, remainder float64, unit models.WeightUnit) string {
+	if len(plates) == 0 && remainder == 0 {
+		return i18n.T("empty bar")
+	}
+
+	loaded := make([]string, len(plates))
+	for i, plate := range plates {
+		loaded[i] = FormatWeight(plate)
+	}
+	description := i18n.Sprintf("%s per side", strings.Join(loaded, ", "))
+	if len(plates) == 0 {
+		description = i18n.T("no plates")
+	}
+	if remainder > 0 {
+		description += i18n.Sprintf(", %s %s per side short", FormatWeight(remainder), unit)
+	}
+	return description
+}
+
+// formatNearestLoadable formats the loadable weights nearest to one the plates
+// can't make up, e.g. "145 or 155 lbs"
+func formatNearestLoadable(warning workout.LoadWarning, unit models.WeightUnit) string {
+	nearest := warning.Nearest()
+	weights := make([]string, len(nearest))
+	for i, weight := range nearest {
+		weights[i] = FormatWeight(weight)
+	}
+	return strings.Join(weights, " or ") + " " + string(unit)
+}
+
+// FormatWeights formats a set of weights on one line, e.g. "Overhead Press 95, Bench Press 125"
+func FormatWeights(weights map[models.LiftName]float64) string {
+	parts := make([]string, 0, len(weights))
+	for _, liftName := range orderedLiftKeys(weights) {
+		parts = append(parts, fmt.Sprintf("%s %s", FormatLiftName(liftName), FormatWeight(weights[liftName])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DisplayProgram prints the full structure of a program: every day's lifts
+// and set schemes, followed by its progression rules
+func (f *ProgramFormatter) DisplayProgram(prog *models.Program) {
+	f.Printf("%s (v%s)\n", prog.Name, prog.Version)
+	f.Printf("ID: %s\n", prog.ID)
+	if prog.Description != "" {
+		f.Printf("\n%s\n\n", indentLines(prog.Description, "  "))
+	}
+	f.Printf("%d-day cycle\n\n", len(prog.Workouts))
+
+	for _, w := range prog.Workouts {
+		f.Printf("Day %d:\n", w.Day)
+		for _, lift := range w.Lifts {
+			if lift.Optional {
+				f.Printf("  %s (optional):\n", FormatLiftName(lift.WeightKey()))
+			} else if lift.Bodyweight {
+				f.Printf("  %s (bodyweight):\n", FormatLiftName(lift.WeightKey()))
+			} else if lift.FixedWeight {
+				f.Printf("  %s (fixed weight):\n", FormatLiftName(lift.WeightKey()))
+			} else {
+				f.Printf("  %s:\n", FormatLiftName(lift.WeightKey()))
+			}
+			if len(lift.WarmupSets) > 0 {
+				f.Printf("    Warmup: %s\n", formatLiftWarmup(prog.WarmupScheme, lift.WarmupSets))
+			}
+			f.Printf("    Working: %s\n", FormatWorkingScheme(lift.WorkingSets))
+			if lift.Group != "" {
+				f.Printf("    Superset: %s\n", lift.Group)
+			}
+			if lift.Feeler != nil {
+				f.Printf("    Feeler: 1 rep @ %s before the AMRAP set (from %s lbs)\n",
+					formatPercentage(lift.Feeler.WeightPercentage), FormatWeight(lift.Feeler.MinWeight))
+			}
+		}
+		f.Printf("\n")
+	}
+
+	f.DisplayProgressionRules(&prog.ProgressionRules)
+}
+
+// DisplayProgressionRules prints per-lift increments and how the program's
+// progression strategy applies them
+func (f *ProgramFormatter) DisplayProgressionRules(rules *models.ProgressionRules) {
+	f.Printf("Progression:\n")
+	for _, liftName := range sortedLiftNames(rules.IncreaseRules) {
+		f.Printf("  %s: +%s %s per session\n", FormatLiftName(liftName), FormatWeight(rules.IncreaseRules[liftName]), rules.Unit.OrDefault())
+	}
+	for _, liftName := range sortedLiftNames(rules.RepIncreases) {
+		f.Printf("  %s: +%s per session\n", FormatLiftName(liftName), pluralize(rules.RepIncreases[liftName], "rep", "reps"))
+	}
+
+	switch rules.StrategyName() {
+	case models.DoubleProgression:
+		f.Printf("  Increase once the AMRAP set reaches %d reps; weights never deload\n", int(rules.Parameter("rep_max")))
+		return
+	case models.PercentageProgression:
+		f.Printf("  Increase by %s of the current weight, or the increment if more\n", formatPercentage(rules.Parameter("increase")))
+	case models.RepLadderProgression:
+		f.Printf("  One increase at %d+ AMRAP reps, plus one per %s beyond, up to %d\n", int(rules.Parameter("deload_below")),
+			pluralize(int(rules.Parameter("rung")), "rep", "reps"), int(rules.Parameter("max_increments")))
+	default:
+		f.Printf("  Double increase at %d+ AMRAP reps\n", rules.DoubleThreshold)
+	}
+	f.Printf("  Deload to %s when the AMRAP set falls short of %d reps\n",
+		formatPercentage(rules.DeloadPercentage), int(rules.Parameter("deload_below")))
+}
+
+// FormatWarmupScheme formats warmup sets as a comma-separated list, e.g. "5 @ bar, 4 @ 55%"
+func FormatWarmupScheme(sets []models.SetTemplate) string {
+	parts := make([]string, len(sets))
+	for i, set := range sets {
+		load := "bar"
+		if set.WeightPercentage > 0 {
+			load = formatPercentage(set.WeightPercentage)
+		}
+		parts[i] = fmt.Sprintf("%d @ %s", set.Reps, load)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatLiftWarmup formats a lift's warmup under the program's warmup scheme,
+// which only uses the lift's own warmup sets when it ramps by percentage
+func formatLiftWarmup(scheme *models.WarmupScheme, sets []models.SetTemplate) string {
+	if scheme == nil {
+		return FormatWarmupScheme(sets)
+	}
+	switch scheme.Strategy {
+	case models.WarmupPlateJump:
+		return "bar, then +1 plate per side each set"
+	case models.WarmupCustom:
+		parts := make([]string, len(scheme.Steps))
+		for i, step := range scheme.Steps {
+			load := "bar"
+			if step.Percentage > 0 {
+				load = formatPercentage(step.Percentage)
+			} else if step.Plates > 0 {
+				load = pluralize(step.Plates, "plate", "plates") + " per side"
+			}
+			parts[i] = fmt.Sprintf("%d @ %s", step.Reps, load)
+		}
+		return strings.Join(parts, ", ")
+	}
+	return FormatWarmupScheme(sets)
+}
+
+// FormatWorkingScheme formats working sets with consecutive identical sets
+// grouped, e.g. "2x5, 1x5+ (AMRAP)", and rep ranges as e.g. "3x6–8". Percentages are shown only when not 100%,
+// and never for the rep-based sets of optional accessories. Percentages of the
+// training max are always shown, e.g. "1x5+ @ 85% TM (AMRAP)".
+func FormatWorkingScheme(sets []models.SetTemplate) string {
+	var parts []string
+	for i := 0; i < len(sets); {
+		count := 1
+		for i+count < len(sets) && sets[i+count] == sets[i] {
+			count++
+		}
+
+		set := sets[i]
+		part := fmt.Sprintf("%dx%d", count, set.Reps)
+		if set.HasRepRange() {
+			part = fmt.Sprintf("%dx%d–%d", count, set.MinReps, set.MaxReps)
+		}
+		if set.Type == models.AMRAPSet {
+			part += "+"
+		}
+		switch {
+		case set.OfTrainingMax:
+			part += " @ " + formatPercentage(set.WeightPercentage) + " TM"
+		case set.WeightPercentage != 1.0 && set.WeightPercentage != 0:
+			part += " @ " + formatPercentage(set.WeightPercentage)
+		}
+		if set.Type == models.AMRAPSet {
+			part += " (AMRAP)"
+		}
+		parts = append(parts, part)
+
+		i += count
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DisplayWarmupPercentages lists a user's per-lift warmup overrides
+func (f *ProgramFormatter) DisplayWarmupPercentages(overrides map[models.LiftName]models.WarmupPercentages) {
+	if len(overrides) == 0 {
+		f.Printf("No custom warmup percentages; every lift uses its program's warmups.\n")
+		return
+	}
+	f.Printf("Custom warmup percentages:\n")
+	for _, lift := range orderedLiftKeys(overrides) {
+		f.Printf("  %s: %s\n", FormatLiftName(lift), FormatPercentages(overrides[lift]))
+	}
+}
+
+// FormatPercentages formats fractions as a comma-separated list of percentages, e.g. "60%, 75%, 90%"
+func FormatPercentages(fractions []float64) string {
+	parts := make([]string, len(fractions))
+	for i, fraction := range fractions {
+		parts[i] = formatPercentage(fraction)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPercentage formats a fraction as a whole or one-decimal percentage, e.g. 0.9 → "90%"
+func formatPercentage(fraction float64) string {
+	return FormatWeight(math.Round(fraction*1000)/10) + "%"
+}
+
+// sortedLiftNames returns the lifts in a map ordered by their display names
+func sortedLiftNames[V any](m map[models.LiftName]V) []models.LiftName {
+	names := make([]models.LiftName, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b models.LiftName) int {
+		return strings.Compare(FormatLiftName(a), FormatLiftName(b))
+	})
+	return names
+}
@@ -0,0 +1,262 @@
+package display
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatWarmupScheme(t *testing.T) {
+	sets := []models.SetTemplate{
+		{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+		{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+		{Reps: 2, WeightPercentage: 0.875, Type: models.WarmupSet},
+	}
+
+	assert.Equal(t, "5 @ bar, 4 @ 55%, 2 @ 87.5%", FormatWarmupScheme(sets))
+	assert.Equal(t, "", FormatWarmupScheme(nil))
+}
+
+func TestFormatLiftWarmup(t *testing.T) {
+	sets := []models.SetTemplate{{Reps: 5, Type: models.WarmupSet}, {Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet}}
+
+	assert.Equal(t, "5 @ bar, 4 @ 55%", formatLiftWarmup(nil, sets))
+	assert.Equal(t, "bar, then +1 plate per side each set",
+		formatLiftWarmup(&models.WarmupScheme{Strategy: models.WarmupPlateJump}, sets))
+	custom := &models.WarmupScheme{Strategy: models.WarmupCustom, Steps: []models.WarmupStep{
+		{Reps: 10}, {Reps: 5, Plates: 1}, {Reps: 3, Plates: 2}, {Reps: 1, Percentage: 0.9},
+	}}
+	assert.Equal(t, "10 @ bar, 5 @ 1 plate per side, 3 @ 2 plates per side, 1 @ 90%", formatLiftWarmup(custom, sets))
+}
+
+func TestFormatWorkingScheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		sets     []models.SetTemplate
+		expected string
+	}{
+		{
+			name: "standard greyskull sets",
+			sets: []models.SetTemplate{
+				{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+				{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+				{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+			},
+			expected: "2x5, 1x5+ (AMRAP)",
+		},
+		{
+			name: "single AMRAP set",
+			sets: []models.SetTemplate{
+				{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+			},
+			expected: "1x5+ (AMRAP)",
+		},
+		{
+			name: "percentage sets",
+			sets: []models.SetTemplate{
+				{Reps: 5, WeightPercentage: 0.9, Type: models.WorkingSet},
+				{Reps: 3, WeightPercentage: 1.0, Type: models.WorkingSet},
+				{Reps: 1, WeightPercentage: 1.05, Type: models.AMRAPSet},
+			},
+			expected: "1x5 @ 90%, 1x3, 1x1+ @ 105% (AMRAP)",
+		},
+		{
+			name: "training max sets",
+			sets: []models.SetTemplate{
+				{Reps: 5, WeightPercentage: 0.65, Type: models.WorkingSet, OfTrainingMax: true},
+				{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet, OfTrainingMax: true},
+				{Reps: 5, WeightPercentage: 0.85, Type: models.AMRAPSet, OfTrainingMax: true},
+			},
+			expected: "1x5 @ 65% TM, 1x5 @ 100% TM, 1x5+ @ 85% TM (AMRAP)",
+		},
+		{
+			name: "rep ranges",
+			sets: []models.SetTemplate{
+				{MinReps: 6, MaxReps: 8, WeightPercentage: 1.0, Type: models.WorkingSet},
+				{MinReps: 6, MaxReps: 8, WeightPercentage: 1.0, Type: models.WorkingSet},
+				{MinReps: 6, MaxReps: 8, WeightPercentage: 0.9, Type: models.AMRAPSet},
+			},
+			expected: "2x6–8, 1x6–8+ @ 90% (AMRAP)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatWorkingScheme(tt.sets))
+		})
+	}
+}
+
+func TestProgramFormatter_DisplayProgramList(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewProgramFormatter(&buf).DisplayProgramList(nil)
+		assert.Equal(t, "No programs available.\n", buf.String())
+	})
+
+	t.Run("programs", func(t *testing.T) {
+		id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+		programs := []*models.Program{
+			{ID: id, Name: "Test LP", Version: "1.2.0", Workouts: make([]models.WorkoutTemplate, 6)},
+		}
+
+		var buf bytes.Buffer
+		NewProgramFormatter(&buf).DisplayProgramList(programs)
+
+		assert.Equal(t, "Available programs:\n"+
+			"1. Test LP (v1.2.0, 6 days)\n"+
+			"   ID: 550e8400-e29b-41d4-a716-446655440000\n", buf.String())
+	})
+}
+
+func TestProgramFormatter_DisplayProgram(t *testing.T) {
+	prog := &models.Program{
+		ID:      uuid.New(),
+		Name:    "Test LP",
+		Version: "1.0.0",
+		Workouts: []models.WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []models.LiftTemplate{
+					{
+						LiftName: models.BenchPress,
+						WarmupSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+						},
+						WorkingSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+						},
+						Feeler: &models.FeelerTemplate{WeightPercentage: 0.95, MinWeight: 200},
+					},
+				},
+			},
+		},
+		ProgressionRules: models.ProgressionRules{
+			IncreaseRules: map[models.LiftName]float64{
+				models.Squat:      5.0,
+				models.BenchPress: 2.5,
+			},
+			DeloadPercentage: 0.9,
+			DoubleThreshold:  10,
+		},
+	}
+
+	var buf bytes.Buffer
+	NewProgramFormatter(&buf).DisplayProgram(prog)
+	output := buf.String()
+
+	assert.Contains(t, output, "Test LP (v1.0.0)\n")
+	assert.Contains(t, output, "1-day cycle\n")
+	assert.Contains(t, output, "Day 1:\n  Bench Press:\n    Warmup: 5 @ bar\n    Working: 1x5+ (AMRAP)\n")
+	assert.Contains(t, output, "    Feeler: 1 rep @ 95% before the AMRAP set (from 200 lbs)\n")
+	assert.Contains(t, output, "Progression:\n  Bench Press: +2.5 lbs per session\n  Squat: +5 lbs per session\n")
+	assert.Contains(t, output, "  Double increase at 10+ AMRAP reps\n")
+	assert.Contains(t, output, "  Deload to 90% when the AMRAP set falls short of 5 reps\n")
+}
+
+func TestProgramFormatter_DisplayProgressionRules_Strategies(t *testing.T) {
+	tests := map[models.ProgressionStrategyName]string{
+		models.DoubleProgression: "  Increase once the AMRAP set reaches 8 reps; weights never deload\n",
+		models.PercentageProgression: "  Increase by 2.5% of the current weight, or the increment if more\n" +
+			"  Deload to 90% when the AMRAP set falls short of 5 reps\n",
+		models.RepLadderProgression: "  One increase at 5+ AMRAP reps, plus one per 2 reps beyond, up to 3\n" +
+			"  Deload to 90% when the AMRAP set falls short of 5 reps\n",
+	}
+	for strategy, expected := range tests {
+		var buf bytes.Buffer
+		NewProgramFormatter(&buf).DisplayProgressionRules(&models.ProgressionRules{DeloadPercentage: 0.9, Strategy: strategy})
+		assert.Equal(t, "Progression:\n"+expected, buf.String(), strategy)
+	}
+}
+
+func TestProgramFormatter_DisplayProgram_Description(t *testing.T) {
+	prog := &models.Program{ID: uuid.New(), Name: "Test LP", Version: "1.0.0", Description: "Three days a week.\nRun for 12 weeks."}
+
+	var buf bytes.Buffer
+	NewProgramFormatter(&buf).DisplayProgram(prog)
+	assert.Contains(t, buf.String(), "ID: "+prog.ID.String()+"\n\n  Three days a week.\n  Run for 12 weeks.\n\n0-day cycle\n")
+}
+
+func TestDisplayUserPrograms(t *testing.T) {
+	prog := &models.Program{ID: uuid.New(), Name: "Test Program", Workouts: make([]models.WorkoutTemplate, 3)}
+	current := &models.UserProgram{
+		ID:             uuid.New(),
+		ProgramID:      prog.ID,
+		CurrentDay:     2,
+		StartedAt:      time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		CurrentWeights: map[models.LiftName]float64{models.BenchPress: 102.5, "Squat:SSB": 120, models.Squat: 140},
+	}
+	orphan := &models.UserProgram{
+		ID:         uuid.New(),
+		ProgramID:  uuid.New(),
+		CurrentDay: 4,
+		StartedAt:  time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	formatter := NewProgramFormatter(&buf)
+	formatter.DisplayUserPrograms([]*models.UserProgram{current, orphan}, current.ID, []uuid.UUID{orphan.ID}, map[uuid.UUID]*models.Program{prog.ID: prog})
+
+	output := buf.String()
+	assert.Contains(t, output, "* 1. Test Program\n     ID: "+current.ID.String())
+	assert.Contains(t, output, "Started: 2024-03-04, Day 2 of 3")
+	assert.Contains(t, output, "Weights: Bench Press 102.5, Squat 140, Squat (SSB) 120")
+	assert.Contains(t, output, "+ 2. Unknown program "+orphan.ProgramID.String())
+	assert.True(t, strings.HasSuffix(output, "Started: 2024-06-01, Day 4\n"))
+}
+
+func TestDisplayUserPrograms_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	NewProgramFormatter(&buf).DisplayUserPrograms(nil, uuid.Nil, nil, nil)
+
+	assert.Contains(t, buf.String(), "haven't started any programs")
+}
+
+func TestProgramFormatter_DisplaySwitchPreview(t *testing.T) {
+	target := &models.UserProgram{
+		ProgramID:      uuid.MustParse("550e8400-e29b-41d4-a716-446655440009"),
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 135},
+		CurrentDay:     3,
+		StartedAt:      time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+	}
+	preview := &services.SwitchPreview{
+		To:          target,
+		LastTrained: time.Date(2024, 2, 1, 18, 0, 0, 0, time.UTC),
+		Trained:     true,
+		DaysSince:   45,
+		Stale:       true,
+	}
+
+	var buf bytes.Buffer
+	NewProgramFormatter(&buf).DisplaySwitchPreview(preview)
+
+	expected := "Switching to Unknown program 550e8400-e29b-41d4-a716-446655440009\n" +
+		"  Started: 2024-01-15\n" +
+		"  Last trained: 2024-02-01 (45 days ago)\n" +
+		"  Weights: Squat 135\n" +
+		"  Next session: Day 3\n" +
+		"\n" +
+		"Warning: Unknown program 550e8400-e29b-41d4-a716-446655440009 hasn't been touched in 45 days. Consider lowering its weights before resuming.\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDisplayWarmupPercentages(t *testing.T) {
+	var buf bytes.Buffer
+	NewProgramFormatter(&buf).DisplayWarmupPercentages(map[models.LiftName]models.WarmupPercentages{
+		models.Deadlift: {0.6, 0.75, 0.9},
+		models.Squat:    {0.5, 0.725},
+	})
+	assert.Equal(t, "Custom warmup percentages:\n"+
+		"  Squat: 50%, 72.5%\n"+
+		"  Deadlift: 60%, 75%, 90%\n", buf.String())
+
+	buf.Reset()
+	NewProgramFormatter(&buf).DisplayWarmupPercentages(nil)
+	assert.Equal(t, "No custom warmup percentages; every lift uses its program's warmups.\n", buf.String())
+}
@@ -0,0 +1,84 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+)
+
+type RecordsFormatter struct {
+	out     io.Writer
+	weights WeightFormat
+}
+
+func NewRecordsFormatter(out io.Writer) *RecordsFormatter {
+	return &RecordsFormatter{out: out}
+}
+
+// SetWeightFormat sets how record weights are shown
+func (f *RecordsFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *RecordsFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayRecords prints every lift's personal records
+func (f *RecordsFormatter) DisplayRecords(all map[models.LiftName]*records.LiftRecords) {
+	if len(all) == 0 {
+		f.Printf("No personal records yet. Log a workout to start setting them.\n")
+		return
+	}
+
+	f.Printf("Personal Records:\n")
+	for _, liftName := range orderedLiftKeys(all) {
+		lift := all[liftName]
+		f.Printf("\n%s:\n", FormatLiftName(liftName))
+		f.Printf("  Heaviest AMRAP: %s (%s)\n", formatRecordSet(lift.HeaviestAMRAP, f.weights), formatRecordDate(lift.HeaviestAMRAP))
+		f.Printf("  Best e1RM: %s from %s (%s)\n",
+			f.weights.Format(lift.BestE1RM.E1RM), formatRecordSet(lift.BestE1RM, f.weights), formatRecordDate(lift.BestE1RM))
+		f.Printf("  Most reps:\n")
+		for _, weight := range lift.Weights() {
+			record := lift.MostReps[weight]
+			f.Printf("    %s: %s (%s)\n", f.weights.Format(weight), pluralize(record.Reps, "rep", "reps"), formatRecordDate(record))
+		}
+	}
+}
+
+// DisplayAchievements prints a "New PR!" banner for each record broken by a workout
+func (f *RecordsFormatter) DisplayAchievements(achievements []records.Achievement) {
+	if len(achievements) == 0 {
+		return
+	}
+
+	f.Printf("\n")
+	for _, a := range achievements {
+		f.Printf("New PR! %s: %s\n", FormatLiftName(a.Lift), FormatAchievement(a, f.weights))
+	}
+}
+
+// FormatAchievement describes a broken record and the record it replaced
+func FormatAchievement(a records.Achievement, weights WeightFormat) string {
+	switch a.Kind {
+	case records.HeaviestAMRAP:
+		return i18n.Sprintf("heaviest AMRAP %s (previous %s)", formatRecordSet(a.New, weights), formatRecordSet(a.Previous, weights))
+	case records.BestE1RM:
+		return i18n.Sprintf("estimated 1RM %s (previous %s)", weights.Format(a.New.E1RM), weights.Format(a.Previous.E1RM))
+	default:
+		return i18n.Sprintf("%s at %s (previous %d)",
+			pluralize(a.New.Reps, "rep", "reps"), weights.Format(a.New.Weight), a.Previous.Reps)
+	}
+}
+
+// formatRecordSet formats a record's set, e.g. "145 lbs x 6"
+func formatRecordSet(record records.Record, weights WeightFormat) string {
+	return i18n.Sprintf("%s x %d", weights.Format(record.Weight), record.Reps)
+}
+
+func formatRecordDate(record records.Record) string {
+	return record.Date.Format("2006-01-02")
+}
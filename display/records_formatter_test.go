@@ -0,0 +1,83 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayRecords(t *testing.T) {
+	date := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	heavy := records.Record{Weight: 145, Reps: 5, E1RM: 169.2, Date: date.AddDate(0, 0, 2)}
+	best := records.Record{Weight: 135, Reps: 12, E1RM: 189, Date: date}
+
+	var buf bytes.Buffer
+	NewRecordsFormatter(&buf).DisplayRecords(map[models.LiftName]*records.LiftRecords{
+		models.Squat: {
+			Lift:          models.Squat,
+			HeaviestAMRAP: heavy,
+			BestE1RM:      best,
+			MostReps:      map[float64]records.Record{145: heavy, 135: best},
+		},
+	})
+
+	assert.Equal(t, "Personal Records:\n\n"+
+		"Squat:\n"+
+		"  Heaviest AMRAP: 145 lbs x 5 (2024-03-06)\n"+
+		"  Best e1RM: 189 lbs from 135 lbs x 12 (2024-03-04)\n"+
+		"  Most reps:\n"+
+		"    145 lbs: 5 reps (2024-03-06)\n"+
+		"    135 lbs: 12 reps (2024-03-04)\n", buf.String())
+}
+
+func TestDisplayRecords_DualUnits(t *testing.T) {
+	record := records.Record{Weight: 100, Reps: 5, E1RM: 116.7, Date: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)}
+
+	var buf bytes.Buffer
+	formatter := NewRecordsFormatter(&buf)
+	formatter.SetWeightFormat(WeightFormat{Unit: models.Kilograms, Dual: true})
+	formatter.DisplayRecords(map[models.LiftName]*records.LiftRecords{
+		models.Squat: {Lift: models.Squat, HeaviestAMRAP: record, BestE1RM: record, MostReps: map[float64]records.Record{100: record}},
+	})
+
+	assert.Equal(t, "Personal Records:\n\n"+
+		"Squat:\n"+
+		"  Heaviest AMRAP: 100 kg / 220.5 lbs x 5 (2024-03-04)\n"+
+		"  Best e1RM: 116.7 kg / 257.3 lbs from 100 kg / 220.5 lbs x 5 (2024-03-04)\n"+
+		"  Most reps:\n"+
+		"    100 kg / 220.5 lbs: 5 reps (2024-03-04)\n", buf.String())
+}
+
+func TestDisplayRecords_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	NewRecordsFormatter(&buf).DisplayRecords(nil)
+
+	assert.Contains(t, buf.String(), "No personal records yet")
+}
+
+func TestFormatAchievement(t *testing.T) {
+	tests := []struct {
+		kind     records.Kind
+		expected string
+	}{
+		{records.HeaviestAMRAP, "heaviest AMRAP 140 lbs x 9 (previous 135 lbs x 10)"},
+		{records.BestE1RM, "estimated 1RM 182 lbs (previous 180 lbs)"},
+		{records.MostReps, "9 reps at 140 lbs (previous 10)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			achievement := records.Achievement{
+				Lift:     models.Squat,
+				Kind:     tt.kind,
+				New:      records.Record{Weight: 140, Reps: 9, E1RM: 182},
+				Previous: records.Record{Weight: 135, Reps: 10, E1RM: 180},
+			}
+			assert.Equal(t, tt.expected, FormatAchievement(achievement, WeightFormat{}))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package display
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+)
+
+//go:embed templates/report.md.tmpl templates/report.html.tmpl
+var reportTemplates embed.FS
+
+// ReportFormat is the markup a training log report is written in
+type ReportFormat string
+
+const (
+	ReportMarkdown ReportFormat = "md"
+	ReportHTML     ReportFormat = "html"
+)
+
+// ParseReportFormat converts user input such as "md" or "HTML" into a ReportFormat
+func ParseReportFormat(input string) (ReportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "md", "markdown":
+		return ReportMarkdown, nil
+	case "html":
+		return ReportHTML, nil
+	}
+	return "", fmt.Errorf("unknown report format %q (expected md or html)", input)
+}
+
+// ReportWorkout is a logged workout with the personal records it broke
+type ReportWorkout struct {
+	Workout      models.Workout
+	Achievements []records.Achievement
+}
+
+// Report is a training log of a lifter's workouts, oldest first
+type Report struct {
+	Username string
+	Workouts []ReportWorkout
+}
+
+type ReportFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+	weights    WeightFormat
+}
+
+func NewReportFormatter(out io.Writer) *ReportFormatter {
+	return &ReportFormatter{out: out}
+}
+
+// SetDateFormat sets how workout dates are shown
+func (f *ReportFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+// SetWeightFormat sets how weights and tonnage are shown
+func (f *ReportFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+// reportView is the data the report templates render
+type reportView struct {
+	Title  string
+	Months []reportMonth
+}
+
+// reportMonth is a month's summary and workouts
+type reportMonth struct {
+	Name     string
+	Workouts int
+	Tonnage  string
+	PRs      int
+	Lifts    []reportLiftSummary
+	Sessions []reportSession
+}
+
+type reportLiftSummary struct {
+	Name     string
+	Sessions int
+	Weight   string
+	Tonnage  string
+}
+
+type reportSession struct {
+	Date  string
+	Day   string
+	Tags  string
+	Lifts []reportLift
+	PRs   []string
+	Notes string
+}
+
+type reportLift struct {
+	Name   string
+	Weight string
+	Reps   string
+}
+
+// DisplayReport writes the report as a training log in format: each month
+// with a summary of its training, then a table of each workout's lifts, the
+// personal records it broke, and its notes
+func (f *ReportFormatter) DisplayReport(report Report, format ReportFormat) error {
+	view := f.reportView(report)
+
+	var err error
+	switch format {
+	case ReportHTML:
+		var tmpl *htmltemplate.Template
+		tmpl, err = htmltemplate.ParseFS(reportTemplates, "templates/report.html.tmpl")
+		if err == nil {
+			err = tmpl.Execute(f.out, view)
+		}
+	default:
+		var tmpl *texttemplate.Template
+		tmpl, err = texttemplate.New("report.md.tmpl").Funcs(texttemplate.FuncMap{
+			"cell": markdownCell,
+		}).ParseFS(reportTemplates, "templates/report.md.tmpl")
+		if err == nil {
+			err = tmpl.Execute(f.out, view)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func (f *ReportFormatter) reportView(report Report) reportView {
+	view := reportView{Title: i18n.Sprintf("Training log: %s", report.Username)}
+
+	var monthWorkouts []ReportWorkout
+	flush := func() {
+		if len(monthWorkouts) > 0 {
+			view.Months = append(view.Months, f.reportMonth(monthWorkouts))
+		}
+		monthWorkouts = nil
+	}
+	for _, w := range report.Workouts {
+		if len(monthWorkouts) > 0 && !sameMonth(monthWorkouts[0].Workout.EnteredAt, w.Workout.EnteredAt) {
+			flush()
+		}
+		monthWorkouts = append(monthWorkouts, w)
+	}
+	flush()
+	return view
+}
+
+func (f *ReportFormatter) reportMonth(workouts []ReportWorkout) reportMonth {
+	history := make([]models.Workout, len(workouts))
+	for i, w := range workouts {
+		history[i] = w.Workout
+	}
+	summary := analytics.Summarize(history)
+
+	month := reportMonth{
+		Name:     workouts[0].Workout.EnteredAt.Format("January 2006"),
+		Workouts: summary.Workouts,
+		Tonnage:  f.weights.FormatTonnage(summary.Tonnage),
+	}
+	for _, liftName := range orderedLiftKeys(summary.Lifts) {
+		lift := summary.Lifts[liftName]
+		weight := f.weights.Format(lift.LatestWeight)
+		if lift.StartingWeight != lift.LatestWeight {
+			weight = f.weights.FormatChange(lift.StartingWeight, lift.LatestWeight)
+		}
+		month.Lifts = append(month.Lifts, reportLiftSummary{
+			Name:     FormatLiftName(liftName),
+			Sessions: lift.Sessions,
+			Weight:   weight,
+			Tonnage:  f.weights.FormatTonnage(lift.Tonnage),
+		})
+	}
+
+	for _, w := range workouts {
+		month.PRs += len(w.Achievements)
+		month.Sessions = append(month.Sessions, f.reportSession(w))
+	}
+	return month
+}
+
+func (f *ReportFormatter) reportSession(w ReportWorkout) reportSession {
+	session := reportSession{
+		Date:  f.dateFormat.Format(w.Workout.EnteredAt),
+		Day:   FormatWorkoutDay(&w.Workout),
+		Notes: w.Workout.Notes,
+	}
+	var tags []string
+	if w.Workout.Quick {
+		tags = append(tags, "quick")
+	}
+	if w.Workout.Incomplete {
+		tags = append(tags, "incomplete")
+	}
+	session.Tags = strings.Join(tags, ", ")
+
+	for _, lift := range w.Workout.Exercises {
+		session.Lifts = append(session.Lifts, reportLiftRow(&lift, f.weights))
+	}
+	for _, a := range w.Achievements {
+		session.PRs = append(session.PRs, fmt.Sprintf("%s: %s", FormatLiftName(a.Lift), FormatAchievement(a, f.weights)))
+	}
+	return session
+}
+
+// reportLiftRow describes a lift's working sets, as formatHistoryLift does,
+// split into the weight and the reps of each set
+func reportLiftRow(lift *models.Lift, weights WeightFormat) reportLift {
+	var reps []string
+	top := math.Inf(-1)
+	for _, set := range lift.Sets {
+		if set.Type == models.WorkingSet || set.Type == models.AMRAPSet {
+			reps = append(reps, strconv.Itoa(set.ActualReps))
+			top = max(top, set.Weight)
+		}
+	}
+
+	row := reportLift{Name: FormatPerformedLift(lift), Reps: strings.Join(reps, ", ")}
+	switch {
+	case lift.Optional || len(reps) == 0:
+	case lift.Bodyweight:
+		row.Weight = FormatAddedWeight(top, weights)
+	default:
+		row.Weight = weights.Format(top)
+	}
+	return row
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
@@ -0,0 +1,135 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleReport() Report {
+	amrap := func(name models.LiftName, weight float64, reps ...int) models.Lift {
+		lift := models.Lift{LiftName: name}
+		for i, r := range reps {
+			setType := models.WorkingSet
+			if i == len(reps)-1 {
+				setType = models.AMRAPSet
+			}
+			lift.Sets = append(lift.Sets, models.Set{Weight: weight, TargetReps: 5, ActualReps: r, Type: setType})
+		}
+		return lift
+	}
+
+	return Report{
+		Username: "Alice",
+		Workouts: []ReportWorkout{
+			{Workout: models.Workout{
+				Day:       1,
+				EnteredAt: time.Date(2024, 4, 29, 18, 0, 0, 0, time.UTC),
+				Exercises: []models.Lift{amrap(models.Squat, 135, 5, 5, 7)},
+			}},
+			{
+				Workout: models.Workout{
+					Day:       2,
+					EnteredAt: time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC),
+					Quick:     true,
+					Notes:     "Grip <slipping> | chalk next time",
+					Exercises: []models.Lift{
+						amrap(models.Squat, 140, 5, 5, 9),
+						{LiftName: "ChinUp", Bodyweight: true, Sets: []models.Set{{Weight: 10, ActualReps: 6, Type: models.AMRAPSet}}},
+					},
+				},
+				Achievements: []records.Achievement{{
+					Lift:     models.Squat,
+					Kind:     records.HeaviestAMRAP,
+					New:      records.Record{Weight: 140, Reps: 9},
+					Previous: records.Record{Weight: 135, Reps: 7},
+				}},
+			},
+		},
+	}
+}
+
+func TestParseReportFormat(t *testing.T) {
+	format, err := ParseReportFormat("Markdown")
+	require.NoError(t, err)
+	assert.Equal(t, ReportMarkdown, format)
+
+	format, err = ParseReportFormat(" HTML ")
+	require.NoError(t, err)
+	assert.Equal(t, ReportHTML, format)
+
+	_, err = ParseReportFormat("pdf")
+	assert.EqualError(t, err, `unknown report format "pdf" (expected md or html)`)
+}
+
+func TestDisplayReport_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewReportFormatter(&buf).DisplayReport(sampleReport(), ReportMarkdown))
+
+	assert.Equal(t, `# Training log: Alice
+
+## April 2024
+
+| Workouts | Tonnage | PRs |
+| --- | --- | --- |
+| 1 | 2,295 lbs | 0 |
+
+| Lift | Sessions | Weight | Tonnage |
+| --- | --- | --- | --- |
+| Squat | 1 | 135 lbs | 2,295 lbs |
+
+### 2024-04-29: Day 1
+
+| Lift | Weight | Reps |
+| --- | --- | --- |
+| Squat | 135 lbs | 5, 5, 7 |
+
+## May 2024
+
+| Workouts | Tonnage | PRs |
+| --- | --- | --- |
+| 1 | 2,720 lbs | 1 |
+
+| Lift | Sessions | Weight | Tonnage |
+| --- | --- | --- | --- |
+| Squat | 1 | 140 lbs | 2,660 lbs |
+| ChinUp | 1 | 10 lbs | 60 lbs |
+
+### 2024-05-01: Day 2 (quick)
+
+| Lift | Weight | Reps |
+| --- | --- | --- |
+| Squat | 140 lbs | 5, 5, 9 |
+| ChinUp | bodyweight + 10 lbs | 6 |
+
+> **New PR!** Squat: heaviest AMRAP 140 lbs x 9 (previous 135 lbs x 7)
+
+**Notes:** Grip <slipping> | chalk next time
+`, buf.String())
+}
+
+func TestDisplayReport_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewReportFormatter(&buf)
+	formatter.SetDateFormat(models.DateLong)
+	require.NoError(t, formatter.DisplayReport(sampleReport(), ReportHTML))
+
+	html := buf.String()
+	assert.Contains(t, html, "<title>Training log: Alice</title>")
+	assert.Contains(t, html, "<h2>May 2024</h2>")
+	assert.Contains(t, html, "<h3>May 1, 2024: Day 2 (quick)</h3>")
+	assert.Contains(t, html, "<tr><td>Squat</td><td>140 lbs</td><td>5, 5, 9</td></tr>")
+	assert.Contains(t, html, `<p class="pr"><strong>New PR!</strong> Squat: heaviest AMRAP 140 lbs x 9 (previous 135 lbs x 7)</p>`)
+	assert.Contains(t, html, "Grip &lt;slipping&gt; | chalk next time")
+}
+
+func TestDisplayReport_NoWorkouts(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewReportFormatter(&buf).DisplayReport(Report{Username: "Alice"}, ReportMarkdown))
+	assert.Equal(t, "# Training log: Alice\n\nNo workouts logged.\n", buf.String())
+}
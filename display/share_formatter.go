@@ -0,0 +1,228 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// ShareFormat is the markup recent workouts are shared in
+type ShareFormat string
+
+const (
+	ShareText     ShareFormat = "text"
+	ShareMarkdown ShareFormat = "md"
+)
+
+// ParseShareFormat converts user input such as "text" or "MD" into a ShareFormat
+func ParseShareFormat(input string) (ShareFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "text", "txt":
+		return ShareText, nil
+	case "md", "markdown":
+		return ShareMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown share format %q (expected text or md)", input)
+}
+
+type ShareFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+	weights    WeightFormat
+}
+
+func NewShareFormatter(out io.Writer) *ShareFormatter {
+	return &ShareFormatter{out: out}
+}
+
+// SetDateFormat sets how workout dates are shown
+func (f *ShareFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+// SetWeightFormat sets how weights are shown
+func (f *ShareFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *ShareFormatter) Printf(format string, a ...any) {
+	fmt.Fprintf(f.out, i18n.T(format), a...)
+}
+
+// DisplayShare prints workouts, oldest first, as a compact block to post for a
+// coach or forum: each lift's working weight and reps, with AMRAP sets marked
+// "+", then the trend of any recorded body weights. Nothing identifying the
+// lifter, such as their username or notes, is included.
+func (f *ShareFormatter) DisplayShare(workouts []models.Workout, format ShareFormat) {
+	heading, session, lift, label := "%s\n\n", "%s\n", "  %s\n", "%s:"
+	if format == ShareMarkdown {
+		heading, session, lift, label = "### %s\n\n", "**%s**\n\n", "- %s\n", "**%s:**"
+	}
+
+	f.Printf(heading, "Recent training: "+pluralize(len(workouts), "workout", "workouts"))
+	if len(workouts) == 0 {
+		f.Printf("No workouts logged yet.\n")
+		return
+	}
+
+	for i := range workouts {
+		workout := &workouts[i]
+		title := fmt.Sprintf("%s · %s", f.dateFormat.Format(workout.EnteredAt), FormatWorkoutDay(workout))
+		if workout.Quick {
+			title += " (quick)"
+		}
+		if workout.Incomplete {
+			title += " (incomplete)"
+		}
+		f.Printf(session, title)
+		for _, l := range workout.Exercises {
+			f.Printf(lift, formatShareLift(&l, f.weights))
+		}
+		f.Printf("\n")
+	}
+
+	if trend := formatBodyWeightTrend(workouts, f.weights); trend != "" {
+		f.Printf(label+" %s\n", "Body weight", trend)
+	}
+}
+
+// SharedTraining is the structured form of a shared block of workouts. Like the
+// text, it leaves out anything identifying the lifter: usernames, notes, IDs,
+// and times of day.
+type SharedTraining struct {
+	Unit     models.WeightUnit `json:"unit"`
+	Workouts []SharedWorkout   `json:"workouts"`
+	// BodyWeights are the body weights recorded with the workouts, oldest first
+	BodyWeights []float64 `json:"body_weights"`
+}
+
+// SharedWorkout is one workout of a SharedTraining
+type SharedWorkout struct {
+	Date       string       `json:"date"` // YYYY-MM-DD
+	Day        string       `json:"day"`  // e.g. "Day 2" or "Extra workout"
+	Quick      bool         `json:"quick,omitempty"`
+	Incomplete bool         `json:"incomplete,omitempty"`
+	Lifts      []SharedLift `json:"lifts"`
+}
+
+// SharedLift is a lift's working sets in a SharedWorkout. Weight is the top
+// working weight, or the weight added to the body for bodyweight lifts, and is
+// left out for accessories.
+type SharedLift struct {
+	Name       string      `json:"name"`
+	Weight     float64     `json:"weight,omitempty"`
+	Bodyweight bool        `json:"bodyweight,omitempty"`
+	Optional   bool        `json:"optional,omitempty"`
+	Sets       []SharedSet `json:"sets"`
+}
+
+// SharedSet is the reps of one working set of a SharedLift
+type SharedSet struct {
+	Reps  int  `json:"reps"`
+	AMRAP bool `json:"amrap,omitempty"`
+}
+
+// ShareTraining returns workouts, oldest first, in the structured form of
+// DisplayShare's block, with weights in unit
+func ShareTraining(workouts []models.Workout, unit models.WeightUnit) SharedTraining {
+	shared := SharedTraining{
+		Unit:        unit.OrDefault(),
+		Workouts:    make([]SharedWorkout, 0, len(workouts)),
+		BodyWeights: []float64{},
+	}
+	for i := range workouts {
+		workout := &workouts[i]
+		sharedWorkout := SharedWorkout{
+			Date:       workout.EnteredAt.Format("2006-01-02"),
+			Day:        FormatWorkoutDay(workout),
+			Quick:      workout.Quick,
+			Incomplete: workout.Incomplete,
+			Lifts:      make([]SharedLift, 0, len(workout.Exercises)),
+		}
+		for _, lift := range workout.Exercises {
+			sharedWorkout.Lifts = append(sharedWorkout.Lifts, shareLift(&lift))
+		}
+		shared.Workouts = append(shared.Workouts, sharedWorkout)
+
+		if workout.BodyWeight > 0 {
+			shared.BodyWeights = append(shared.BodyWeights, workout.BodyWeight)
+		}
+	}
+	return shared
+}
+
+// shareLift returns a logged lift's working sets as formatShareLift shows them
+func shareLift(lift *models.Lift) SharedLift {
+	shared := SharedLift{
+		Name:       FormatPerformedLift(lift),
+		Bodyweight: lift.Bodyweight,
+		Optional:   lift.Optional,
+		Sets:       []SharedSet{},
+	}
+	for _, set := range lift.Sets {
+		if set.Type != models.WorkingSet && set.Type != models.AMRAPSet {
+			continue
+		}
+		if !lift.Optional && (len(shared.Sets) == 0 || set.Weight > shared.Weight) {
+			shared.Weight = set.Weight
+		}
+		shared.Sets = append(shared.Sets, SharedSet{Reps: set.ActualReps, AMRAP: set.Type == models.AMRAPSet})
+	}
+	return shared
+}
+
+// formatShareLift summarizes a logged lift's working sets as formatHistoryLift
+// does, with AMRAP reps marked, e.g. "Squat 135 lbs: 5, 5, 8+"
+func formatShareLift(lift *models.Lift, weights WeightFormat) string {
+	var reps []string
+	top := 0.0
+	for _, set := range lift.Sets {
+		switch set.Type {
+		case models.WorkingSet:
+			reps = append(reps, strconv.Itoa(set.ActualReps))
+		case models.AMRAPSet:
+			reps = append(reps, strconv.Itoa(set.ActualReps)+"+")
+		default:
+			continue
+		}
+		if len(reps) == 1 || set.Weight > top {
+			top = set.Weight
+		}
+	}
+
+	name := FormatPerformedLift(lift)
+	if !lift.Optional && len(reps) > 0 {
+		if lift.Bodyweight {
+			name += " @ " + FormatAddedWeight(top, weights)
+		} else {
+			name += " " + weights.Format(top)
+		}
+	}
+	return fmt.Sprintf("%s: %s", name, strings.Join(reps, ", "))
+}
+
+// formatBodyWeightTrend describes how the body weights recorded with workouts
+// changed, e.g. "182 → 180.5 lbs (-1.5 over 4 weigh-ins)", or ""
+// if none were recorded
+func formatBodyWeightTrend(workouts []models.Workout, weights WeightFormat) string {
+	var recorded []float64
+	for _, workout := range workouts {
+		if workout.BodyWeight > 0 {
+			recorded = append(recorded, workout.BodyWeight)
+		}
+	}
+	if len(recorded) == 0 {
+		return ""
+	}
+
+	first, last := recorded[0], recorded[len(recorded)-1]
+	if len(recorded) == 1 {
+		return weights.Format(first)
+	}
+	return i18n.Sprintf("%s (%s over %s)", weights.FormatChange(first, last),
+		formatDifference(last-first), pluralize(len(recorded), "weigh-in", "weigh-ins"))
+}
@@ -0,0 +1,30 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatShareLift(t *testing.T) {
+	lift := &models.Lift{LiftName: models.Squat, Sets: []models.Set{
+		{Weight: 45, ActualReps: 5, Type: models.WarmupSet},
+		{Weight: 100, ActualReps: 5, Type: models.WorkingSet},
+		{Weight: 100, ActualReps: 5, Type: models.WorkingSet},
+		{Weight: 100, ActualReps: 9, Type: models.AMRAPSet},
+	}}
+	assert.Equal(t, "Squat 100 kg: 5, 5, 9+", formatShareLift(lift, WeightFormat{Unit: models.Kilograms}))
+	assert.Equal(t, "Squat 100 kg / 220.5 lbs: 5, 5, 9+", formatShareLift(lift, WeightFormat{Unit: models.Kilograms, Dual: true}))
+}
+
+func TestFormatBodyWeightTrend(t *testing.T) {
+	assert.Empty(t, formatBodyWeightTrend([]models.Workout{{}}, WeightFormat{}))
+	assert.Equal(t, "80 kg", formatBodyWeightTrend([]models.Workout{{BodyWeight: 80}, {}}, WeightFormat{Unit: models.Kilograms}))
+	assert.Equal(t, "180 → 182.5 lbs (+2.5 over 3 weigh-ins)", formatBodyWeightTrend([]models.Workout{
+		{BodyWeight: 180}, {}, {BodyWeight: 181}, {BodyWeight: 182.5},
+	}, WeightFormat{}))
+	assert.Equal(t, "180 lbs / 81.6 kg → 182.5 lbs / 82.8 kg (+2.5 over 2 weigh-ins)", formatBodyWeightTrend([]models.Workout{
+		{BodyWeight: 180}, {BodyWeight: 182.5},
+	}, WeightFormat{Dual: true}))
+}
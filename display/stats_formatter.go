@@ -0,0 +1,232 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+type StatsFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+	weights    WeightFormat
+}
+
+func NewStatsFormatter(out io.Writer) *StatsFormatter {
+	return &StatsFormatter{out: out}
+}
+
+// SetDateFormat sets how dates are shown in summaries
+func (f *StatsFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+// SetWeightFormat sets how weights and tonnage are shown in summaries
+func (f *StatsFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+func (f *StatsFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplaySummary prints overall training statistics followed by each lift's summary
+func (f *StatsFormatter) DisplaySummary(summary *analytics.Summary) {
+	if summary.Workouts == 0 {
+		f.Printf("No workouts logged yet.\n")
+		return
+	}
+
+	f.Printf("Training Summary:\n")
+	f.Printf("  Workouts: %d from %s to %s (%.1f per week)\n", summary.Workouts,
+		f.dateFormat.Format(summary.First), f.dateFormat.Format(summary.Last), summary.WorkoutsPerWeek)
+	f.Printf("  Total tonnage: %s\n", f.weights.FormatTonnage(summary.Tonnage))
+
+	for _, liftName := range orderedLiftKeys(summary.Lifts) {
+		lift := summary.Lifts[liftName]
+		f.Printf("\n%s:\n", FormatLiftName(liftName))
+		f.Printf("  Weight: %s (%s)\n",
+			f.weights.FormatChange(lift.StartingWeight, lift.LatestWeight), formatDifference(lift.LatestWeight-lift.StartingWeight))
+		f.Printf("  Sessions: %d, average AMRAP reps: %.1f\n", lift.Sessions, lift.AverageAMRAPReps)
+		f.Printf("  Deloads: %d\n", lift.Deloads)
+		f.Printf("  Tonnage: %s\n", f.weights.FormatTonnage(lift.Tonnage))
+	}
+}
+
+// DisplayStalls lists the lifts on a deload streak, marking those past
+// analytics.StallThreshold as stalled
+func (f *StatsFormatter) DisplayStalls(stalls []analytics.Stall) {
+	if len(stalls) == 0 {
+		f.Printf("No stalled lifts. Every lift has got past its last deload.\n")
+		return
+	}
+
+	f.Printf("Deload Streaks:\n")
+	for _, stall := range stalls {
+		format := "  %s: %s, stuck below %s (now %s)\n"
+		if stall.Stalled() {
+			format = "  %s: %s, stuck below %s (now %s) - stalled\n"
+		}
+		f.Printf(format, FormatLiftName(stall.Lift), pluralize(stall.Deloads, "deload", "deloads"),
+			f.weights.Format(stall.Weight), f.weights.Format(stall.Current))
+	}
+	f.Printf("\nA lift is stalled after %d deloads in a row. Consider restarting the program with\n", analytics.StallThreshold)
+	f.Printf("'greyskull program start', or switching the lift to a different rep range.\n")
+}
+
+// FormatStallWarning describes a stalled lift and what to do about it
+func FormatStallWarning(stall analytics.Stall, weights WeightFormat) string {
+	return i18n.Sprintf("Warning: %s has deloaded %d times in a row without getting past %s.\n"+
+		"Consider restarting the program with 'greyskull program start', or switching the lift to a different rep range.",
+		FormatLiftName(stall.Lift), stall.Deloads, weights.Format(stall.Weight))
+}
+
+// FormatTonnage formats a total weight rounded to whole pounds with thousands separators, e.g. "12,345"
+func FormatTonnage(total float64) string {
+	digits := strconv.FormatInt(int64(math.Round(total)), 10)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+// formatDifference formats a signed weight change, e.g. "+30" or "-12.5"
+func formatDifference(difference float64) string {
+	if difference > 0 {
+		return "+" + FormatWeight(difference)
+	}
+	if difference < 0 {
+		return "-" + FormatWeight(-difference)
+	}
+	return "±0"
+}
+
+// DisplayVolume prints a table of each week's tonnage and working sets per
+// lift, in unit, with the week's total. With trend, a sparkline of each lift's
+// weekly tonnage follows.
+func (f *StatsFormatter) DisplayVolume(weeks []analytics.WeekVolume, unit models.WeightUnit, trend bool) {
+	lifts := make(map[models.LiftName]bool)
+	for _, week := range weeks {
+		for key := range week.Lifts {
+			lifts[key] = true
+		}
+	}
+	if len(lifts) == 0 {
+		f.Printf("No workouts logged in the last %s.\n", pluralize(len(weeks), "week", "weeks"))
+		return
+	}
+
+	unit = unit.OrDefault()
+	keys := orderedLiftKeys(lifts)
+	header := []string{i18n.T("Week of")}
+	for _, key := range keys {
+		header = append(header, FormatLiftName(key))
+	}
+	header = append(header, i18n.T("Total"))
+
+	rows := [][]string{header}
+	for _, week := range weeks {
+		row := []string{f.dateFormat.Format(week.Start)}
+		for _, key := range keys {
+			row = append(row, formatLiftVolume(week.Lifts[key]))
+		}
+		rows = append(rows, append(row, formatLiftVolume(&week.Total)))
+	}
+
+	f.Printf("Weekly Volume (%s tonnage / working sets):\n", unit)
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	for _, row := range rows {
+		line := ""
+		for i, cell := range row {
+			line += fmt.Sprintf("  %-*s", widths[i], cell)
+		}
+		f.Printf("%s\n", strings.TrimRight(line, " "))
+	}
+
+	if !trend {
+		return
+	}
+	f.Printf("\nTrend:\n")
+	names := header[1:]
+	width := 0
+	for _, name := range names {
+		width = max(width, utf8.RuneCountInString(name))
+	}
+	for i, name := range names {
+		tonnage := make([]float64, len(weeks))
+		for j, week := range weeks {
+			if i == len(keys) {
+				tonnage[j] = week.Total.Tonnage
+			} else if lv, exists := week.Lifts[keys[i]]; exists {
+				tonnage[j] = lv.Tonnage
+			}
+		}
+		f.Printf("  %-*s %s\n", width, name, Sparkline(tonnage))
+	}
+}
+
+// formatLiftVolume formats a week's volume of a lift, e.g. "4,250 / 6", or "-"
+// if it wasn't trained
+func formatLiftVolume(lv *analytics.LiftVolume) string {
+	if lv == nil || (lv.Tonnage == 0 && lv.Sets == 0) {
+		return "-"
+	}
+	return fmt.Sprintf("%s / %d", FormatTonnage(lv.Tonnage), lv.Sets)
+}
+
+// sparkBlocks are the bars of a sparkline, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a row of bars scaled to the largest, e.g. "▁▄█▆".
+// Zero values are drawn as spaces, so gaps in training stand out.
+func Sparkline(values []float64) string {
+	highest := 0.0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+
+	var line strings.Builder
+	for _, value := range values {
+		if value <= 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		level := int(math.Ceil(value/highest*float64(len(sparkBlocks)))) - 1
+		line.WriteRune(sparkBlocks[max(0, level)])
+	}
+	return line.String()
+}
+
+// DisplayConsistency prints training streaks against the weekly target and
+// the number of recent sessions
+func (f *StatsFormatter) DisplayConsistency(c analytics.Consistency) {
+	f.Printf("Consistency (target: %s a week):\n", pluralize(c.Target, "session", "sessions"))
+	f.Printf("  Current streak: %s\n", pluralize(c.CurrentStreak, "week", "weeks"))
+	f.Printf("  Longest streak: %s\n", pluralize(c.LongestStreak, "week", "weeks"))
+	f.Printf("  This week: %d of %s\n", c.ThisWeek, pluralize(c.Target, "session", "sessions"))
+	f.Printf("  Last %d days: %s\n", analytics.ConsistencyWindow, pluralize(c.RecentSessions, "session", "sessions"))
+}
+
+// FormatConsistency summarizes training streaks on one line, e.g. "Streak: 4
+// weeks at 3 sessions a week (longest 6), 11 sessions in the last 30 days"
+func FormatConsistency(c analytics.Consistency) string {
+	return i18n.Sprintf("Streak: %s at %s a week (longest %d), %s in the last %d days",
+		pluralize(c.CurrentStreak, "week", "weeks"), pluralize(c.Target, "session", "sessions"), c.LongestStreak,
+		pluralize(c.RecentSessions, "session", "sessions"), analytics.ConsistencyWindow)
+}
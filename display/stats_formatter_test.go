@@ -0,0 +1,128 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplaySummary(t *testing.T) {
+	summary := &analytics.Summary{
+		Workouts:        36,
+		First:           time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+		Last:            time.Date(2024, 3, 22, 18, 0, 0, 0, time.UTC),
+		WorkoutsPerWeek: 3.07,
+		Tonnage:         123456.5,
+		Lifts: map[models.LiftName]*analytics.LiftSummary{
+			models.Squat: {
+				Lift: models.Squat, Sessions: 18, StartingWeight: 115, LatestWeight: 207.5,
+				Tonnage: 98765, Deloads: 1, AverageAMRAPReps: 7.25,
+			},
+			models.OverheadPress: {
+				Lift: models.OverheadPress, Sessions: 9, StartingWeight: 95, LatestWeight: 85,
+				Tonnage: 1234, Deloads: 2, AverageAMRAPReps: 5,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	NewStatsFormatter(&buf).DisplaySummary(summary)
+
+	assert.Equal(t, "Training Summary:\n"+
+		"  Workouts: 36 from 2024-01-01 to 2024-03-22 (3.1 per week)\n"+
+		"  Total tonnage: 123,457 lbs\n"+
+		"\nOverhead Press:\n"+
+		"  Weight: 95 → 85 lbs (-10)\n"+
+		"  Sessions: 9, average AMRAP reps: 5.0\n"+
+		"  Deloads: 2\n"+
+		"  Tonnage: 1,234 lbs\n"+
+		"\nSquat:\n"+
+		"  Weight: 115 → 207.5 lbs (+92.5)\n"+
+		"  Sessions: 18, average AMRAP reps: 7.2\n"+
+		"  Deloads: 1\n"+
+		"  Tonnage: 98,765 lbs\n", buf.String())
+}
+
+func TestDisplaySummary_DualUnits(t *testing.T) {
+	summary := &analytics.Summary{
+		Workouts: 3,
+		First:    time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC),
+		Last:     time.Date(2024, 1, 5, 18, 0, 0, 0, time.UTC),
+		Tonnage:  5000,
+		Lifts: map[models.LiftName]*analytics.LiftSummary{
+			models.Squat: {Lift: models.Squat, Sessions: 3, StartingWeight: 60, LatestWeight: 65, Tonnage: 5000},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewStatsFormatter(&buf)
+	formatter.SetWeightFormat(WeightFormat{Unit: models.Kilograms, Dual: true})
+	formatter.DisplaySummary(summary)
+
+	assert.Contains(t, buf.String(), "  Total tonnage: 5,000 kg / 11,023 lbs\n")
+	assert.Contains(t, buf.String(), "  Weight: 60 kg / 132.3 lbs → 65 kg / 143.3 lbs (+5)\n")
+	assert.Contains(t, buf.String(), "  Tonnage: 5,000 kg / 11,023 lbs\n")
+}
+
+func TestDisplaySummary_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	NewStatsFormatter(&buf).DisplaySummary(analytics.Summarize(nil))
+
+	assert.Equal(t, "No workouts logged yet.\n", buf.String())
+}
+
+func TestFormatTonnage(t *testing.T) {
+	assert.Equal(t, "0", FormatTonnage(0))
+	assert.Equal(t, "999", FormatTonnage(999.4))
+	assert.Equal(t, "1,000", FormatTonnage(999.5))
+	assert.Equal(t, "1,234,567", FormatTonnage(1234567))
+}
+
+func TestDisplayVolume(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	weeks := []analytics.WeekVolume{
+		{Start: monday, Lifts: map[models.LiftName]*analytics.LiftVolume{}},
+		{Start: monday.AddDate(0, 0, 7), Workouts: 2, Total: analytics.LiftVolume{Tonnage: 4825, Sets: 6},
+			Lifts: map[models.LiftName]*analytics.LiftVolume{
+				models.Squat:         {Tonnage: 3745, Sets: 4},
+				models.OverheadPress: {Tonnage: 1080, Sets: 2},
+			}},
+		{Start: monday.AddDate(0, 0, 14), Workouts: 1, Total: analytics.LiftVolume{Tonnage: 1675, Sets: 2},
+			Lifts: map[models.LiftName]*analytics.LiftVolume{models.Squat: {Tonnage: 1675, Sets: 2}}},
+	}
+
+	var buf bytes.Buffer
+	NewStatsFormatter(&buf).DisplayVolume(weeks, models.Kilograms, true)
+
+	assert.Equal(t, "Weekly Volume (kg tonnage / working sets):\n"+
+		"  Week of     Overhead Press  Squat      Total\n"+
+		"  2024-03-04  -               -          -\n"+
+		"  2024-03-11  1,080 / 2       3,745 / 4  4,825 / 6\n"+
+		"  2024-03-18  -               1,675 / 2  1,675 / 2\n"+
+		"\nTrend:\n"+
+		"  Overhead Press  █ \n"+
+		"  Squat           █▄\n"+
+		"  Total           █▃\n", buf.String())
+
+	buf.Reset()
+	NewStatsFormatter(&buf).DisplayVolume(weeks[:1], models.Pounds, false)
+	assert.Equal(t, "No workouts logged in the last 1 week.\n", buf.String())
+
+	// Column headers are translated with the rest of the table
+	i18n.SetLocale("es")
+	t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+	buf.Reset()
+	NewStatsFormatter(&buf).DisplayVolume(weeks, models.Kilograms, false)
+	assert.Contains(t, buf.String(), "Volumen semanal (tonelaje en kg / series de trabajo):\n"+
+		"  Semana del  Press militar  Sentadilla  Total\n")
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄ █", Sparkline([]float64{100, 400, 0, 800}))
+	assert.Equal(t, "", Sparkline(nil))
+}
@@ -0,0 +1,194 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// PorcelainVersion identifies the porcelain status format. It only changes if
+// the format changes incompatibly; new fields are never added to version 1.
+const PorcelainVersion = "v1"
+
+// Status is a snapshot of where the current user stands in their program.
+// An empty Username means no user is set; a zero TotalDays means no program is active.
+type Status struct {
+	Username    string                      `json:"username"`
+	ProgramName string                      `json:"program_name"`
+	Day         int                         `json:"day"`
+	TotalDays   int                         `json:"total_days"`
+	NextLifts   []StatusLift                `json:"next_lifts"`
+	Weights     map[models.LiftName]float64 `json:"weights,omitempty"` // Every lift's current working weight
+	Unit        models.WeightUnit           `json:"unit,omitempty"`
+	LastTrained time.Time                   `json:"last_trained,omitzero"`
+	DaysSince   int                         `json:"days_since"`
+	Overdue     bool                        `json:"overdue"`
+
+	// MissedDays counts the scheduled training days since the program was last
+	// trained or skipped, other than those it was paused on
+	MissedDays int `json:"missed_days"`
+
+	// Consistency measures the user's training streaks against their weekly target
+	Consistency *analytics.Consistency `json:"consistency,omitempty"`
+
+	// Goals are the progress toward the program's goals
+	Goals []analytics.GoalProgress `json:"goals,omitempty"`
+
+	// Stalls are the lifts that have deloaded analytics.StallThreshold times in a row
+	Stalls []analytics.Stall `json:"stalls,omitempty"`
+
+	// LastSkipped is the program's most recently skipped day, if any
+	LastSkipped *models.SkippedDay `json:"last_skipped,omitempty"`
+
+	// Paused is the program's open pause, if it is paused. A paused program
+	// is never overdue.
+	Paused *models.Pause `json:"paused,omitempty"`
+
+	// Alongside are the programs trained alongside the current one
+	Alongside []StatusProgram `json:"alongside,omitempty"`
+}
+
+// StatusProgram is a program trained alongside the current one and its next day
+type StatusProgram struct {
+	Name      string `json:"name"`
+	Day       int    `json:"day"`
+	TotalDays int    `json:"total_days"`
+}
+
+// StatusLift is a lift in the next workout and its working weight
+type StatusLift struct {
+	Key    models.LiftName `json:"lift"`
+	Weight float64         `json:"weight"`
+}
+
+type StatusFormatter struct {
+	out     io.Writer
+	weights WeightFormat
+}
+
+func NewStatusFormatter(out io.Writer) *StatusFormatter {
+	return &StatusFormatter{out: out}
+}
+
+// SetWeightFormat sets how weights are shown. Weights are shown as bare
+// numbers unless it shows dual units, when both units are shown.
+func (f *StatusFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+// formatWeight formats a weight as a bare number, or in both units
+func (f *StatusFormatter) formatWeight(weight float64) string {
+	if f.weights.Dual {
+		return f.weights.Format(weight)
+	}
+	return FormatWeight(weight)
+}
+
+func (f *StatusFormatter) Printf(format string, a ...any) {
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayStatus prints a short human-readable summary of the status
+func (f *StatusFormatter) DisplayStatus(status *Status) {
+	if status.Username == "" {
+		f.Printf("No current user set. Use 'greyskull user create' or 'greyskull user switch' first.\n")
+		return
+	}
+	if status.TotalDays == 0 {
+		f.Printf("%s has no active program. Use 'greyskull program start' to begin one.\n", status.Username)
+		return
+	}
+
+	f.Printf("%s: %s, Day %d of %d\n", status.Username, status.ProgramName, status.Day, status.TotalDays)
+
+	parts := make([]string, len(status.NextLifts))
+	for i, lift := range status.NextLifts {
+		parts[i] = fmt.Sprintf("%s %s", FormatLiftName(lift.Key), f.formatWeight(lift.Weight))
+	}
+	f.Printf("Next: %s\n", strings.Join(parts, ", "))
+	if len(status.Weights) > 0 {
+		weights := make([]string, 0, len(status.Weights))
+		for _, key := range orderedLiftKeys(status.Weights) {
+			weights = append(weights, fmt.Sprintf("%s %s", FormatLiftName(key), f.formatWeight(status.Weights[key])))
+		}
+		f.Printf("Weights: %s\n", strings.Join(weights, ", "))
+	}
+
+	ago := i18n.T("today")
+	if status.DaysSince > 0 {
+		ago = i18n.Sprintf("%s ago", pluralize(status.DaysSince, "day", "days"))
+	}
+	f.Printf("Last trained: %s (%s)\n", status.LastTrained.Format("2006-01-02"), ago)
+	if skip := status.LastSkipped; skip != nil {
+		f.Printf("Last skipped: Day %d on %s", skip.Day, skip.SkippedAt.Format("2006-01-02"))
+		if skip.Reason != "" {
+			f.Printf(" (%s)", skip.Reason)
+		}
+		f.Printf("\n")
+	}
+	if pause := status.Paused; pause != nil {
+		f.Printf("Paused since %s", pause.StartedAt.Format("2006-01-02"))
+		if pause.Reason != "" {
+			f.Printf(" (%s)", pause.Reason)
+		}
+		f.Printf(". Run 'greyskull program resume' when you're back.\n")
+	}
+	if status.MissedDays > 0 {
+		f.Printf("Missed: %s since your last workout\n", pluralize(status.MissedDays, "scheduled session", "scheduled sessions"))
+	}
+	if status.Overdue {
+		f.Printf("Overdue: time to train!\n")
+	}
+	for _, stall := range status.Stalls {
+		f.Printf("%s\n", FormatStallWarning(stall, WeightFormat{Unit: status.Unit, Dual: f.weights.Dual}))
+	}
+	for _, goal := range status.Goals {
+		f.Printf("Goal: %s\n", FormatGoalProgress(goal, WeightFormat{Unit: status.Unit, Dual: f.weights.Dual}))
+	}
+	if status.Consistency != nil {
+		f.Printf("%s\n", FormatConsistency(*status.Consistency))
+	}
+	for _, program := range status.Alongside {
+		if program.TotalDays > 0 {
+			f.Printf("Also active: %s, Day %d of %d\n", program.Name, program.Day, program.TotalDays)
+		} else {
+			f.Printf("Also active: %s, Day %d\n", program.Name, program.Day)
+		}
+	}
+}
+
+// FormatPorcelain formats the status as a single stable, machine-readable line
+// for shell prompts and status bars:
+//
+//	v1 user=<name> day=<day>/<total> next=<lift>@<weight>[,...] overdue=<0|1>
+//
+// Fields are always present and in this order. Missing values are "-". Lift
+// names have spaces removed, and variants keep their "Lift:Variant" key form,
+// e.g. "OverheadPress@95,Squat:SSB@135".
+func FormatPorcelain(status *Status) string {
+	user, day, next := "-", "-", "-"
+	if status.Username != "" {
+		user = status.Username
+	}
+	if status.TotalDays > 0 {
+		day = fmt.Sprintf("%d/%d", status.Day, status.TotalDays)
+	}
+	if len(status.NextLifts) > 0 {
+		parts := make([]string, len(status.NextLifts))
+		for i, lift := range status.NextLifts {
+			parts[i] = strings.ReplaceAll(string(lift.Key), " ", "") + "@" + FormatWeight(lift.Weight)
+		}
+		next = strings.Join(parts, ",")
+	}
+	overdue := "0"
+	if status.Overdue {
+		overdue = "1"
+	}
+
+	return fmt.Sprintf("%s user=%s day=%s next=%s overdue=%s", PorcelainVersion, user, day, next, overdue)
+}
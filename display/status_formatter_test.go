@@ -0,0 +1,130 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleStatus() *Status {
+	return &Status{
+		Username:    "adam",
+		ProgramName: "OG Greyskull LP",
+		Day:         3,
+		TotalDays:   6,
+		NextLifts: []StatusLift{
+			{Key: models.OverheadPress, Weight: 97.5},
+			{Key: "Squat:SSB", Weight: 135},
+		},
+		LastTrained: time.Date(2024, 5, 3, 18, 0, 0, 0, time.UTC),
+		DaysSince:   4,
+		Overdue:     true,
+	}
+}
+
+func TestFormatPorcelain(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *Status
+		expected string
+	}{
+		{
+			name:     "active program",
+			status:   sampleStatus(),
+			expected: "v1 user=adam day=3/6 next=OverheadPress@97.5,Squat:SSB@135 overdue=1",
+		},
+		{
+			name:     "no user",
+			status:   &Status{},
+			expected: "v1 user=- day=- next=- overdue=0",
+		},
+		{
+			name:     "no program",
+			status:   &Status{Username: "adam"},
+			expected: "v1 user=adam day=- next=- overdue=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatPorcelain(tt.status))
+		})
+	}
+}
+
+func TestDisplayStatus(t *testing.T) {
+	var buf bytes.Buffer
+	NewStatusFormatter(&buf).DisplayStatus(sampleStatus())
+
+	assert.Equal(t, "adam: OG Greyskull LP, Day 3 of 6\n"+
+		"Next: Overhead Press 97.5, Squat (SSB) 135\n"+
+		"Last trained: 2024-05-03 (4 days ago)\n"+
+		"Overdue: time to train!\n", buf.String())
+
+	status := sampleStatus()
+	status.DaysSince = 0
+	status.Overdue = false
+	buf.Reset()
+	NewStatusFormatter(&buf).DisplayStatus(status)
+	assert.Contains(t, buf.String(), "Last trained: 2024-05-03 (today)\n")
+	assert.NotContains(t, buf.String(), "Overdue")
+}
+
+func TestDisplayStatus_LastSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	status := sampleStatus()
+	status.Overdue = false
+	status.LastSkipped = &models.SkippedDay{Day: 2, SkippedAt: time.Date(2024, 5, 5, 9, 0, 0, 0, time.UTC)}
+	NewStatusFormatter(&buf).DisplayStatus(status)
+	assert.Contains(t, buf.String(), "Last trained: 2024-05-03 (4 days ago)\nLast skipped: Day 2 on 2024-05-05\n")
+
+	buf.Reset()
+	status.LastSkipped.Reason = "travelling"
+	NewStatusFormatter(&buf).DisplayStatus(status)
+	assert.Contains(t, buf.String(), "Last skipped: Day 2 on 2024-05-05 (travelling)\n")
+}
+
+func TestDisplayStatus_Missing(t *testing.T) {
+	var buf bytes.Buffer
+	NewStatusFormatter(&buf).DisplayStatus(&Status{})
+	assert.Contains(t, buf.String(), "No current user set")
+
+	buf.Reset()
+	NewStatusFormatter(&buf).DisplayStatus(&Status{Username: "adam"})
+	assert.Contains(t, buf.String(), "adam has no active program")
+}
+
+func TestDisplayStatus_Warnings(t *testing.T) {
+	var buf bytes.Buffer
+	status := sampleStatus()
+	status.Weights = map[models.LiftName]float64{models.OverheadPress: 97.5, models.Squat: 140}
+	status.Unit = models.Kilograms
+	status.MissedDays = 1
+	status.Stalls = []analytics.Stall{{Lift: models.Squat, Deloads: 3, Weight: 150, Current: 140}}
+	NewStatusFormatter(&buf).DisplayStatus(status)
+
+	assert.Equal(t, "adam: OG Greyskull LP, Day 3 of 6\n"+
+		"Next: Overhead Press 97.5, Squat (SSB) 135\n"+
+		"Weights: Overhead Press 97.5, Squat 140\n"+
+		"Last trained: 2024-05-03 (4 days ago)\n"+
+		"Missed: 1 scheduled session since your last workout\n"+
+		"Overdue: time to train!\n"+
+		"Warning: Squat has deloaded 3 times in a row without getting past 150 kg.\n"+
+		"Consider restarting the program with 'greyskull program start', or switching the lift to a different rep range.\n", buf.String())
+}
+
+func TestDisplayStatus_DualUnits(t *testing.T) {
+	var buf bytes.Buffer
+	status := sampleStatus()
+	status.Weights = map[models.LiftName]float64{models.OverheadPress: 97.5, models.Squat: 140}
+	formatter := NewStatusFormatter(&buf)
+	formatter.SetWeightFormat(WeightFormat{Unit: models.Pounds, Dual: true})
+	formatter.DisplayStatus(status)
+
+	assert.Contains(t, buf.String(), "Next: Overhead Press 97.5 lbs / 44.2 kg, Squat (SSB) 135 lbs / 61.2 kg\n"+
+		"Weights: Overhead Press 97.5 lbs / 44.2 kg, Squat 140 lbs / 63.5 kg\n")
+}
@@ -0,0 +1,246 @@
+package display
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+)
+
+//go:embed templates/summary.txt.tmpl templates/summary.html.tmpl
+var summaryTemplates embed.FS
+
+// SummaryFormat is the markup a weekly summary is written in
+type SummaryFormat string
+
+const (
+	SummaryText SummaryFormat = "text"
+	SummaryHTML SummaryFormat = "html"
+)
+
+// ParseSummaryFormat converts user input such as "text" or "HTML" into a SummaryFormat
+func ParseSummaryFormat(input string) (SummaryFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "text", "txt":
+		return SummaryText, nil
+	case "html":
+		return SummaryHTML, nil
+	}
+	return "", fmt.Errorf("unknown summary format %q (expected text or html)", input)
+}
+
+// WeeklySummary is a lifter's training in a week, Monday to Sunday
+type WeeklySummary struct {
+	Username string
+	Start    time.Time // Midnight on the week's Monday
+
+	// Workouts are the week's workouts, oldest first, with the personal
+	// records they broke
+	Workouts []ReportWorkout
+
+	// Previous is the weight of each lift trained before the week
+	Previous map[models.LiftName]float64
+
+	Target int // Sessions a week that keep a consistency streak going
+	Unit   models.WeightUnit
+}
+
+type SummaryFormatter struct {
+	out        io.Writer
+	dateFormat models.DateFormat
+	weights    WeightFormat
+}
+
+func NewSummaryFormatter(out io.Writer) *SummaryFormatter {
+	return &SummaryFormatter{out: out}
+}
+
+// SetDateFormat sets how dates are shown
+func (f *SummaryFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+// SetWeightFormat sets whether weights and tonnage are also shown in the other
+// unit. They're always in the summary's own unit.
+func (f *SummaryFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+// summaryView is the data the summary templates render
+type summaryView struct {
+	Title      string
+	Sessions   string
+	Days       []string
+	Tonnage    string
+	Lifts      []summaryLift
+	PRs        []string
+	BodyWeight string
+}
+
+type summaryLift struct {
+	Name   string
+	Weight string
+}
+
+// SummaryTitle returns the summary's title, also used as its email subject,
+// e.g. "Weekly summary for lifter: 2024-03-04 to 2024-03-10"
+func (f *SummaryFormatter) SummaryTitle(summary WeeklySummary) string {
+	return i18n.Sprintf("Weekly summary for %s: %s to %s", summary.Username,
+		f.dateFormat.Format(summary.Start), f.dateFormat.Format(summary.Start.AddDate(0, 0, 6)))
+}
+
+// DisplayWeeklySummary writes a weekly summary in format: the sessions done
+// against the weekly target, how each lift's weight moved since the week
+// before, the personal records broken, and the trend of recorded body weights
+func (f *SummaryFormatter) DisplayWeeklySummary(summary WeeklySummary, format SummaryFormat) error {
+	view := f.summaryView(summary)
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case SummaryHTML:
+		var tmpl *htmltemplate.Template
+		tmpl, err = htmltemplate.ParseFS(summaryTemplates, "templates/summary.html.tmpl")
+		if err == nil {
+			err = tmpl.Execute(&buf, view)
+		}
+	default:
+		var tmpl *texttemplate.Template
+		tmpl, err = texttemplate.ParseFS(summaryTemplates, "templates/summary.txt.tmpl")
+		if err == nil {
+			err = tmpl.Execute(&buf, view)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	_, err = f.out.Write(buf.Bytes())
+	return err
+}
+
+func (f *SummaryFormatter) summaryView(summary WeeklySummary) summaryView {
+	workouts := make([]models.Workout, len(summary.Workouts))
+	for i, w := range summary.Workouts {
+		workouts[i] = w.Workout
+	}
+	totals := analytics.Summarize(workouts)
+	weights := WeightFormat{Unit: summary.Unit, Dual: f.weights.Dual}
+
+	view := summaryView{
+		Title:      f.SummaryTitle(summary),
+		Sessions:   pluralize(len(workouts), "session", "sessions"),
+		Tonnage:    weights.FormatTonnage(totals.Tonnage),
+		BodyWeight: formatBodyWeightTrend(workouts, weights),
+	}
+	if summary.Target > 0 {
+		view.Sessions = i18n.Sprintf("%d of %d planned", len(workouts), summary.Target)
+	}
+
+	for _, w := range summary.Workouts {
+		day := fmt.Sprintf("%s  %s", f.dateFormat.Format(w.Workout.EnteredAt), FormatWorkoutDay(&w.Workout))
+		if w.Workout.Incomplete {
+			day += " (incomplete)"
+		}
+		view.Days = append(view.Days, day)
+		for _, a := range w.Achievements {
+			view.PRs = append(view.PRs, fmt.Sprintf("%s: %s", FormatLiftName(a.Lift), FormatAchievement(a, weights)))
+		}
+	}
+
+	for _, change := range weightChanges(summary.Previous, totals) {
+		weight := i18n.Sprintf("%s (no change)", weights.Format(change.Weight))
+		switch {
+		case change.New && change.Previous == change.Weight:
+			weight = i18n.Sprintf("%s (new)", weights.Format(change.Weight))
+		case change.Previous != change.Weight:
+			weight = fmt.Sprintf("%s (%s)", weights.FormatChange(change.Previous, change.Weight),
+				formatDifference(change.Weight-change.Previous))
+		}
+		view.Lifts = append(view.Lifts, summaryLift{Name: FormatLiftName(change.Lift), Weight: weight})
+	}
+	return view
+}
+
+// WeeklySummaryResult is the structured form of a weekly summary, with
+// weights in Unit
+type WeeklySummaryResult struct {
+	User        string                `json:"user"`
+	Start       string                `json:"start"` // The week's Monday, YYYY-MM-DD
+	End         string                `json:"end"`   // The week's Sunday, YYYY-MM-DD
+	Unit        models.WeightUnit     `json:"unit"`
+	Sessions    int                   `json:"sessions"`
+	Target      int                   `json:"target,omitempty"`
+	Tonnage     float64               `json:"tonnage"`
+	Weights     []WeightChange        `json:"weight_changes"`
+	PRs         []records.Achievement `json:"prs"`
+	BodyWeights []float64             `json:"body_weights"` // Recorded with the week's workouts, oldest first
+}
+
+// WeightChange is how a lift's weight moved over a week. Previous is its
+// weight before the week, or the week's first weight for a New lift, one not
+// trained before the week.
+type WeightChange struct {
+	Lift     models.LiftName `json:"lift"`
+	Previous float64         `json:"previous"`
+	Weight   float64         `json:"weight"`
+	New      bool            `json:"new,omitempty"`
+}
+
+// Result returns the summary in structured form, as DisplayWeeklySummary
+// shows it
+func (s WeeklySummary) Result() WeeklySummaryResult {
+	workouts := make([]models.Workout, len(s.Workouts))
+	for i, w := range s.Workouts {
+		workouts[i] = w.Workout
+	}
+	totals := analytics.Summarize(workouts)
+
+	result := WeeklySummaryResult{
+		User:        s.Username,
+		Start:       s.Start.Format("2006-01-02"),
+		End:         s.Start.AddDate(0, 0, 6).Format("2006-01-02"),
+		Unit:        s.Unit.OrDefault(),
+		Sessions:    len(workouts),
+		Target:      s.Target,
+		Tonnage:     totals.Tonnage,
+		Weights:     weightChanges(s.Previous, totals),
+		PRs:         []records.Achievement{},
+		BodyWeights: []float64{},
+	}
+	for _, w := range s.Workouts {
+		result.PRs = append(result.PRs, w.Achievements...)
+		if w.Workout.BodyWeight > 0 {
+			result.BodyWeights = append(result.BodyWeights, w.Workout.BodyWeight)
+		}
+	}
+	return result
+}
+
+// weightChanges returns how the weight of each lift in totals moved since
+// previous, the weights before the week
+func weightChanges(previous map[models.LiftName]float64, totals *analytics.Summary) []WeightChange {
+	changes := make([]WeightChange, 0, len(totals.Lifts))
+	for _, liftName := range orderedLiftKeys(totals.Lifts) {
+		lift := totals.Lifts[liftName]
+		before, trained := previous[liftName]
+		if !trained {
+			before = lift.StartingWeight
+		}
+		changes = append(changes, WeightChange{
+			Lift:     liftName,
+			Previous: before,
+			Weight:   lift.LatestWeight,
+			New:      !trained,
+		})
+	}
+	return changes
+}
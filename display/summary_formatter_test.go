@@ -0,0 +1,49 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSummaryFormat(t *testing.T) {
+	format, err := ParseSummaryFormat("HTML")
+	require.NoError(t, err)
+	assert.Equal(t, SummaryHTML, format)
+
+	_, err = ParseSummaryFormat("md")
+	assert.EqualError(t, err, `unknown summary format "md" (expected text or html)`)
+}
+
+func TestSummaryFormatter_DisplayWeeklySummary(t *testing.T) {
+	summary := WeeklySummary{
+		Username: "<lifter>",
+		Start:    time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		Workouts: []ReportWorkout{{Workout: models.Workout{
+			Day:       1,
+			EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC),
+			Exercises: []models.Lift{
+				{LiftName: models.Squat, Sets: []models.Set{{Weight: 140, ActualReps: 5, Type: models.AMRAPSet}}},
+				{LiftName: models.Deadlift, Sets: []models.Set{{Weight: 185, ActualReps: 5, Type: models.AMRAPSet}}},
+			},
+		}}},
+		Previous: map[models.LiftName]float64{models.Squat: 135},
+		Unit:     models.Kilograms,
+	}
+
+	var buf bytes.Buffer
+	formatter := NewSummaryFormatter(&buf)
+	formatter.SetDateFormat(models.DateLong)
+	require.NoError(t, formatter.DisplayWeeklySummary(summary, SummaryText))
+	assert.Contains(t, buf.String(), "Weekly summary for <lifter>: Mar 4, 2024 to Mar 10, 2024\n\nSessions: 1 session\n")
+	assert.Contains(t, buf.String(), "  Squat: 135 → 140 kg (+5)\n  Deadlift: 185 kg (new)\n")
+
+	buf.Reset()
+	require.NoError(t, formatter.DisplayWeeklySummary(summary, SummaryHTML))
+	assert.Contains(t, buf.String(), "<h1>Weekly summary for &lt;lifter&gt;: Mar 4, 2024 to Mar 10, 2024</h1>")
+	assert.Contains(t, buf.String(), "<tr><td>Squat</td><td>135 → 140 kg (&#43;5)</td></tr>")
+}
@@ -0,0 +1,58 @@
+package display
+
+import (
+	"math"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// WeightFormat is how weights are shown: in the unit they're recorded in and,
+// with Dual, followed by the same weight in the other unit for lifters who
+// train in one unit but think in the other. The zero value shows pounds.
+type WeightFormat struct {
+	Unit models.WeightUnit
+	Dual bool
+}
+
+// Format formats a weight with its unit, e.g. "100 kg", or with Dual, "100 kg / 220.5 lbs"
+func (w WeightFormat) Format(weight float64) string {
+	return w.format(weight, FormatWeight)
+}
+
+// FormatTonnage formats a total weight lifted like FormatTonnage, with its
+// unit, e.g. "12,345 lbs", or with Dual, "12,345 lbs / 5,600 kg"
+func (w WeightFormat) FormatTonnage(total float64) string {
+	return w.format(total, FormatTonnage)
+}
+
+// FormatChange formats a change from one weight to another, e.g. "135 → 140 lbs",
+// or with Dual, "135 lbs / 61.2 kg → 140 lbs / 63.5 kg"
+func (w WeightFormat) FormatChange(from, to float64) string {
+	if !w.Dual {
+		return FormatWeight(from) + " → " + w.Format(to)
+	}
+	return w.Format(from) + " → " + w.Format(to)
+}
+
+func (w WeightFormat) format(weight float64, format func(float64) string) string {
+	unit := w.Unit.OrDefault()
+	formatted := format(weight) + " " + string(unit)
+	if !w.Dual {
+		return formatted
+	}
+	return formatted + " / " + format(w.converted(weight)) + " " + string(otherUnit(unit))
+}
+
+// converted returns a weight in the other unit, to a tenth
+func (w WeightFormat) converted(weight float64) float64 {
+	unit := w.Unit.OrDefault()
+	return math.Round(models.ConvertWeight(weight, unit, otherUnit(unit))*10) / 10
+}
+
+// otherUnit returns kilograms for pounds, and pounds for kilograms
+func otherUnit(unit models.WeightUnit) models.WeightUnit {
+	if unit.OrDefault() == models.Kilograms {
+		return models.Pounds
+	}
+	return models.Kilograms
+}
@@ -0,0 +1,22 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightFormat(t *testing.T) {
+	assert.Equal(t, "135 lbs", WeightFormat{}.Format(135))
+	assert.Equal(t, "100 kg", WeightFormat{Unit: models.Kilograms}.Format(100))
+	assert.Equal(t, "135 lbs / 61.2 kg", WeightFormat{Dual: true}.Format(135))
+	assert.Equal(t, "100 kg / 220.5 lbs", WeightFormat{Unit: models.Kilograms, Dual: true}.Format(100))
+	assert.Equal(t, "62.5 kg / 137.8 lbs", WeightFormat{Unit: models.Kilograms, Dual: true}.Format(62.5))
+
+	assert.Equal(t, "12,345 lbs", WeightFormat{}.FormatTonnage(12345))
+	assert.Equal(t, "12,345 lbs / 5,600 kg", WeightFormat{Dual: true}.FormatTonnage(12345))
+
+	assert.Equal(t, "135 → 140 lbs", WeightFormat{}.FormatChange(135, 140))
+	assert.Equal(t, "60 kg / 132.3 lbs → 62.5 kg / 137.8 lbs", WeightFormat{Unit: models.Kilograms, Dual: true}.FormatChange(60, 62.5))
+}
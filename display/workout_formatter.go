@@ -3,29 +3,202 @@ package display
 import (
 	"fmt"
 	"io"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/mikowitz/greyskull/i18n"
 	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
 )
 
 type WorkoutFormatter struct {
-	out io.Writer
+	out        io.Writer
+	dateFormat models.DateFormat
+	weights    WeightFormat
+
+	// plates, when set, shows the plate change between sets with its plates
+	plates    *models.Config
+	plateUnit models.WeightUnit
+
+	// coaching, when set, is the program whose coaching cues are shown
+	coaching *models.Program
 }
 
 func NewWorkoutFormatter(out io.Writer) *WorkoutFormatter {
 	return &WorkoutFormatter{out: out}
 }
 
+// SetDateFormat sets how dates are shown in workout history
+func (f *WorkoutFormatter) SetDateFormat(format models.DateFormat) {
+	f.dateFormat = format
+}
+
+// SetWeightFormat sets how weights are shown in workouts and history
+func (f *WorkoutFormatter) SetWeightFormat(weights WeightFormat) {
+	f.weights = weights
+}
+
+// SetPlateHints shows the plates to add or take off between sets of a workout,
+// from the config's plates, when the config turns plate hints on
+func (f *WorkoutFormatter) SetPlateHints(config *models.Config, unit models.WeightUnit) {
+	if config == nil || !config.PlateHints {
+		f.plates = nil
+		return
+	}
+	f.plates, f.plateUnit = config, unit.OrDefault()
+}
+
+// plateHint describes the plate change from the weight loaded for a lift's
+// previous set to a set's weight, e.g. " (add 10/side)". There is none without
+// plate hints, for lifts not loaded on a bar, or when the weight stays the same.
+func (f *WorkoutFormatter) plateHint(lift *models.Lift, loaded, weight float64) string {
+	if f.plates == nil || lift.Bodyweight || lift.Optional || weight == loaded {
+		return ""
+	}
+	barWeight := f.plates.BarWeightFor(lift.LiftName, f.plateUnit)
+	if weight < barWeight || loaded < barWeight {
+		return ""
+	}
+
+	perSide, plates := workout.PlateChange(loaded, weight, f.plates, f.plateUnit)
+	hint := "add"
+	if perSide < 0 {
+		hint = "remove"
+	}
+	hint += " " + FormatWeight(math.Abs(perSide)) + "/side"
+	if len(plates) > 1 {
+		formatted := make([]string, len(plates))
+		for i, plate := range plates {
+			formatted[i] = FormatWeight(plate)
+		}
+		hint += ": " + strings.Join(formatted, ", ")
+	}
+	return " (" + hint + ")"
+}
+
+// SetCoaching shows each lift's coaching cues and demonstration link from a
+// program's templates and the lift definitions under the lift
+func (f *WorkoutFormatter) SetCoaching(prog *models.Program) {
+	f.coaching = prog
+}
+
+// displayCoaching prints a lift's coaching cues and link, if it has any, at an indent
+func (f *WorkoutFormatter) displayCoaching(day int, lift *models.Lift, indent string) {
+	if f.coaching == nil {
+		return
+	}
+	coaching := f.coaching.CoachingFor(day, lift.LiftName)
+	if len(coaching.Cues) > 0 {
+		f.Printf("%sCues:\n", indent)
+		for _, cue := range coaching.Cues {
+			f.Printf("%s  - %s\n", indent, cue)
+		}
+	}
+	if coaching.URL != "" {
+		f.Printf("%sLink: %s\n", indent, coaching.URL)
+	}
+}
+
+// emptyBar returns the weight loaded for a lift before its first set: its
+// empty bar with plate hints, or nothing to compare against without
+func (f *WorkoutFormatter) emptyBar(lift *models.Lift) float64 {
+	if f.plates == nil {
+		return 0
+	}
+	return f.plates.BarWeightFor(lift.LiftName, f.plateUnit)
+}
+
 func (f *WorkoutFormatter) Printf(format string, a ...any) {
-	f.out.Write(fmt.Appendf([]byte{}, format, a...))
+	f.out.Write(fmt.Appendf([]byte{}, i18n.T(format), a...))
+}
+
+// DisplayCalendar prints a training schedule, marking missed dates and today's workout
+func (f *WorkoutFormatter) DisplayCalendar(entries []workout.CalendarEntry, trainingDays []time.Weekday, now time.Time) {
+	names := make([]string, len(trainingDays))
+	for i, day := range trainingDays {
+		names[i] = formatWeekday(day)
+	}
+	f.Printf("Training days: %s\n\n", strings.Join(names, ", "))
+
+	missed := 0
+	for _, entry := range entries {
+		line := fmt.Sprintf("  %s %s  ", formatWeekday(entry.Date.Weekday()), f.dateFormat.Format(entry.Date))
+		switch {
+		case entry.Missed:
+			missed++
+			line += "missed"
+		case sameDay(entry.Date, now):
+			line += i18n.Sprintf("Day %d (today)", entry.Day)
+		default:
+			line += i18n.Sprintf("Day %d", entry.Day)
+		}
+		f.Printf("%s\n", line)
+	}
+
+	if missed > 0 {
+		f.Printf("\n%s since your last workout. Your next workout picks up where you left off.\n",
+			pluralize(missed, "missed session", "missed sessions"))
+	}
+}
+
+// DisplayProgressionSteps explains how each lift's next working weight was
+// worked out
+func (f *WorkoutFormatter) DisplayProgressionSteps(steps []workout.ProgressionStep) {
+	if len(steps) == 0 {
+		return
+	}
+
+	f.Printf("\nProgression:\n")
+	for _, step := range steps {
+		line := i18n.Sprintf("  %s: %d reps at %s (%s, increment %s): ", FormatLiftName(step.Lift), step.Reps,
+			f.weights.Format(step.Weight), step.Strategy, FormatWeight(step.Increment))
+		if step.Unrounded != step.Next {
+			line += i18n.Sprintf("%s rounded down to ", strconv.FormatFloat(step.Unrounded, 'f', -1, 64))
+		}
+		f.Printf("%s%s\n", line, f.weights.Format(step.Next))
+	}
+}
+
+// sameDay reports whether a and b fall on the same calendar day in b's time zone
+func sameDay(a, b time.Time) bool {
+	a = a.In(b.Location())
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// DisplayPreviewNotice explains that a workout is a preview of a later session,
+// assuming the given number of successful sessions before it. Nothing is shown
+// for the next workout.
+func (f *WorkoutFormatter) DisplayPreviewNotice(sessions int) {
+	if sessions == 0 {
+		return
+	}
+	f.Printf("Preview assuming %s before this one.\n\n", pluralize(sessions, "successful session", "successful sessions"))
 }
 
 func (f *WorkoutFormatter) DisplayWorkout(workout *models.Workout) {
 	f.Printf("Day %d Workout:\n", workout.Day)
 	f.Printf("================\n\n")
+	if workout.Quick {
+		f.Printf("Quick session: warmups trimmed to save time.\n\n")
+	}
 
-	for _, lift := range workout.Exercises {
-		f.Printf("%s:\n", FormatLiftName(lift.LiftName))
+	for i := 0; i < len(workout.Exercises); i++ {
+		if end := workout.GroupRun(i); end-i > 1 {
+			f.displaySuperset(&models.Workout{Day: workout.Day, Exercises: workout.Exercises[i:end]})
+			i = end - 1
+			continue
+		}
+
+		lift := workout.Exercises[i]
+		if lift.Optional {
+			f.displayAccessory(workout.Day, &lift)
+			continue
+		}
+		f.Printf("%s:\n", FormatPerformedLift(&lift))
+		f.displayCoaching(workout.Day, &lift, "  ")
 
 		// Group sets by type
 		warmupSets := []models.Set{}
@@ -40,20 +213,27 @@ func (f *WorkoutFormatter) DisplayWorkout(workout *models.Workout) {
 		}
 
 		// Display warmup sets if any
+		loaded := f.emptyBar(&lift)
 		if len(warmupSets) > 0 {
 			f.Printf("  Warmup:\n")
 			for _, set := range warmupSets {
-				f.Printf("    %d reps @ %s lbs\n", set.TargetReps, FormatWeight(set.Weight))
+				f.Printf("    %d reps @ %s%s\n", set.TargetReps, f.weights.Format(set.Weight), f.plateHint(&lift, loaded, set.Weight))
+				loaded = set.Weight
 			}
 		}
 
-		// Display working sets
+		// Display working sets; feeler singles are not counted as numbered sets
 		f.Printf("  Working Sets:\n")
-		for i, set := range workingSets {
-			if set.Type == models.AMRAPSet {
-				f.Printf("    Set %d: %d+ reps @ %s lbs (AMRAP)\n", i+1, set.TargetReps, FormatWeight(set.Weight))
+		setNumber := 0
+		for _, set := range workingSets {
+			if set.Type != models.FeelerSet {
+				setNumber++
+			}
+			if lift.Bodyweight {
+				f.Printf("    %s\n", FormatBodyweightSetDisplay(set, setNumber, f.weights))
 			} else {
-				f.Printf("    Set %d: %d reps @ %s lbs\n", i+1, set.TargetReps, FormatWeight(set.Weight))
+				f.Printf("    %s%s\n", FormatSetDisplay(set, setNumber, f.weights), f.plateHint(&lift, loaded, set.Weight))
+				loaded = set.Weight
 			}
 		}
 
@@ -61,6 +241,81 @@ func (f *WorkoutFormatter) DisplayWorkout(workout *models.Workout) {
 	}
 }
 
+// displayAccessory prints an optional accessory's rep-based sets
+func (f *WorkoutFormatter) displayAccessory(day int, lift *models.Lift) {
+	f.Printf("%s (optional):\n", FormatLiftName(lift.WeightKey()))
+	f.displayCoaching(day, lift, "  ")
+	f.Printf("  Sets:\n")
+	for i, set := range lift.Sets {
+		f.Printf("    %s\n", formatAccessorySet(set, i+1))
+	}
+	f.Printf("\n")
+}
+
+// formatAccessorySet formats a rep-based set of an optional accessory
+func formatAccessorySet(set models.Set, index int) string {
+	if set.Type == models.AMRAPSet {
+		return i18n.Sprintf("Set %d: %s+ reps (AMRAP)", index, FormatTargetReps(set))
+	}
+	return i18n.Sprintf("Set %d: %s reps", index, FormatTargetReps(set))
+}
+
+// displaySuperset prints the lifts of a superset, labelled e.g. A1 and A2, with
+// their warmups in turn and then their working sets in the order they alternate
+func (f *WorkoutFormatter) displaySuperset(superset *models.Workout) {
+	f.Printf("Superset %s:\n", superset.Exercises[0].Group)
+	for i, lift := range superset.Exercises {
+		name := FormatPerformedLift(&lift)
+		if lift.Optional {
+			name += " (optional)"
+		}
+		f.Printf("  %s. %s\n", superset.GroupLabel(i), name)
+		f.displayCoaching(superset.Day, &lift, "      ")
+	}
+
+	// Number each lift's sets on its own; feeler singles aren't numbered
+	setNumbers := make([]int, len(superset.Exercises))
+	loaded := make([]float64, len(superset.Exercises))
+	for i := range superset.Exercises {
+		loaded[i] = f.emptyBar(&superset.Exercises[i])
+	}
+	heading := ""
+	for _, ref := range superset.PerformanceOrder() {
+		lift := superset.Exercises[ref.Lift]
+		set := lift.Sets[ref.Set]
+
+		if set.Type == models.WarmupSet {
+			if heading != "Warmup" {
+				heading = "Warmup"
+				f.Printf("  Warmup:\n")
+			}
+			f.Printf("    %s: %s reps @ %s%s\n", superset.GroupLabel(ref.Lift), FormatTargetReps(set), f.weights.Format(set.Weight), f.plateHint(&lift, loaded[ref.Lift], set.Weight))
+			loaded[ref.Lift] = set.Weight
+			continue
+		}
+		if heading != "Working Sets" {
+			heading = "Working Sets"
+			f.Printf("  Working Sets:\n")
+		}
+
+		if set.Type != models.FeelerSet {
+			setNumbers[ref.Lift]++
+		}
+		var line string
+		switch {
+		case lift.Optional:
+			line = formatAccessorySet(set, setNumbers[ref.Lift])
+		case lift.Bodyweight:
+			line = FormatBodyweightSetDisplay(set, setNumbers[ref.Lift], f.weights)
+		default:
+			line = FormatSetDisplay(set, setNumbers[ref.Lift], f.weights) + f.plateHint(&lift, loaded[ref.Lift], set.Weight)
+			loaded[ref.Lift] = set.Weight
+		}
+		f.Printf("    %s %s\n", superset.GroupLabel(ref.Lift), line)
+	}
+	f.Printf("\n")
+}
+
 func (f *WorkoutFormatter) DisplayWeightChanges(old, new map[models.LiftName]float64) {
 	hasChanges := false
 
@@ -79,8 +334,7 @@ func (f *WorkoutFormatter) DisplayWeightChanges(old, new map[models.LiftName]flo
 	f.Printf("\nWeight Updates:\n")
 
 	// Display changes for each lift that was worked
-	lifts := []models.LiftName{models.OverheadPress, models.BenchPress, models.Squat, models.Deadlift}
-	for _, liftName := range lifts {
+	for _, liftName := range orderedLiftKeys(new) {
 		oldWeight, oldExists := old[liftName]
 		newWeight, newExists := new[liftName]
 
@@ -91,16 +345,90 @@ func (f *WorkoutFormatter) DisplayWeightChanges(old, new map[models.LiftName]flo
 				sign = "+"
 			}
 
-			f.Printf("%s: %s → %s lbs (%s%.1f)\n",
+			f.Printf("%s: %s → %s (%s%.1f)\n",
 				FormatLiftName(liftName),
 				FormatWeight(oldWeight),
-				FormatWeight(newWeight),
+				f.weights.Format(newWeight),
 				sign,
 				difference)
 		}
 	}
 }
 
+// DisplayRepTargetChanges shows the new rep targets of bodyweight lifts that
+// progress by reps, for lifts whose target changed
+func (f *WorkoutFormatter) DisplayRepTargetChanges(old, new map[models.LiftName]int) {
+	var changed []models.LiftName
+	for _, liftName := range orderedLiftKeys(new) {
+		if old[liftName] != new[liftName] {
+			changed = append(changed, liftName)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	f.Printf("\nRep Target Updates:\n")
+	for _, liftName := range changed {
+		f.Printf("%s: %d → %d reps\n", FormatLiftName(liftName), old[liftName], new[liftName])
+	}
+}
+
+// DisplayHolds lists lifts whose weight is being held constant
+func (f *WorkoutFormatter) DisplayHolds(holds map[models.LiftName]int) {
+	if len(holds) == 0 {
+		return
+	}
+
+	f.Printf("Held Lifts:\n")
+	for _, liftName := range orderedLiftKeys(holds) {
+		f.Printf("  %s: weight held for %s\n", FormatLiftName(liftName), pluralize(holds[liftName], "more session", "more sessions"))
+	}
+	f.Printf("\n")
+}
+
+// DisplayLoadWarnings lists working weights the plate inventory can't load
+// exactly, with the nearest weights it can
+func (f *WorkoutFormatter) DisplayLoadWarnings(warnings []workout.LoadWarning, unit models.WeightUnit) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	unit = unit.OrDefault()
+	f.Printf("Plate Warnings:\n")
+	for _, warning := range warnings {
+		f.Printf("  %s %s %s can't be loaded with your plates; nearest loadable: %s\n",
+			FormatLiftName(warning.Lift), FormatWeight(warning.Weight), unit, formatNearestLoadable(warning, unit))
+	}
+	f.Printf("\n")
+}
+
+// DisplayDeload notes a deload in progress and how many sessions it has left
+func (f *WorkoutFormatter) DisplayDeload(plan *models.DeloadPlan) {
+	if plan == nil {
+		return
+	}
+
+	f.Printf("Deload: %s, %s left before normal programming resumes\n\n",
+		FormatDeloadSets(plan), pluralize(plan.SessionsRemaining, "session", "sessions"))
+}
+
+// DisplayDeloadPlan lists each lift's deload weight alongside its current weight
+func (f *WorkoutFormatter) DisplayDeloadPlan(plan *models.DeloadPlan, current, deload map[models.LiftName]float64) {
+	f.Printf("Deload planned for the next %s: %s, no AMRAP sets\n",
+		pluralize(plan.SessionsRemaining, "session", "sessions"), FormatDeloadSets(plan))
+	for _, liftName := range orderedLiftKeys(deload) {
+		f.Printf("  %s: %s (normally %s)\n",
+			FormatLiftName(liftName), f.weights.Format(deload[liftName]), f.weights.Format(current[liftName]))
+	}
+	f.Printf("Weights will not progress until the deload is over.\n")
+}
+
+// FormatDeloadSets describes a deload's sets, e.g. "2x5 @ 80%"
+func FormatDeloadSets(plan *models.DeloadPlan) string {
+	return fmt.Sprintf("%dx%d @ %s", plan.Sets, plan.Reps, formatPercentage(plan.Percentage))
+}
+
 func (f *WorkoutFormatter) DisplayWorkoutSummary(workout *models.Workout, nextDay int) {
 	f.DisplayWorkout(workout)
 
@@ -108,36 +436,185 @@ func (f *WorkoutFormatter) DisplayWorkoutSummary(workout *models.Workout, nextDa
 	f.Printf("Next workout: Day %d\n", nextDay)
 }
 
+// DisplayHistory prints past workouts, most recent first, with the working
+// weight and completed reps of each lift and any notes
+func (f *WorkoutFormatter) DisplayHistory(workouts []models.Workout) {
+	if len(workouts) == 0 {
+		f.Printf("No workouts logged yet.\n")
+		return
+	}
+
+	for i := len(workouts) - 1; i >= 0; i-- {
+		workout := &workouts[i]
+		f.Printf("%s  %s", f.dateFormat.Format(workout.EnteredAt), FormatWorkoutDay(workout))
+		if workout.Quick {
+			f.Printf(" (quick)")
+		}
+		if workout.Incomplete {
+			f.Printf(" (incomplete)")
+		}
+		f.Printf("\n")
+
+		for _, lift := range workout.Exercises {
+			f.Printf("  %s\n", formatHistoryLift(&lift, f.weights))
+		}
+		if workout.Notes != "" {
+			f.Printf("  Notes:\n%s\n", indentLines(workout.Notes, "    "))
+		}
+		if i > 0 {
+			f.Printf("\n")
+		}
+	}
+}
+
+// FormatWorkoutDay names the program day a logged workout trained, e.g.
+// "Day 2", or "Extra workout" for one logged outside any program
+func FormatWorkoutDay(workout *models.Workout) string {
+	if workout.AdHoc {
+		return "Extra workout"
+	}
+	return i18n.Sprintf("Day %d", workout.Day)
+}
+
+// formatHistoryLift summarizes a logged lift's working sets, e.g.
+// "Squat 135 lbs: 5, 5, 8"; accessories have no weight
+func formatHistoryLift(lift *models.Lift, weights WeightFormat) string {
+	var reps []string
+	top := math.Inf(-1)
+	for _, set := range lift.Sets {
+		if set.Type == models.WorkingSet || set.Type == models.AMRAPSet {
+			reps = append(reps, strconv.Itoa(set.ActualReps))
+			top = max(top, set.Weight)
+		}
+	}
+
+	name := FormatPerformedLift(lift)
+	if !lift.Optional && len(reps) > 0 {
+		if lift.Bodyweight {
+			name += " @ " + FormatAddedWeight(top, weights)
+		} else {
+			name += " " + weights.Format(top)
+		}
+	}
+	return fmt.Sprintf("%s: %s", name, strings.Join(reps, ", "))
+}
+
+// indentLines prefixes every line of multi-line text
+func indentLines(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// pluralize formats a count with the singular or plural noun, translated
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, i18n.T(singular))
+	}
+	return fmt.Sprintf("%d %s", count, i18n.T(plural))
+}
+
 func FormatWeight(weight float64) string {
 	// Remove decimal if it's a whole number
 	if weight == float64(int(weight)) {
 		return strconv.Itoa(int(weight))
 	}
-	return fmt.Sprintf("%.1f", weight)
+	// Microplate weights such as 103.75 need a second decimal
+	return strings.TrimSuffix(fmt.Sprintf("%.2f", weight), "0")
+}
+
+// displayOrder is the order in which the core lifts are listed in summaries
+var displayOrder = []models.LiftName{models.OverheadPress, models.BenchPress, models.Squat, models.Deadlift}
+
+// orderedLiftKeys returns the keys of a weight-keyed map with the core lifts
+// first in display order, followed by variants and other lifts sorted by name
+func orderedLiftKeys[V any](m map[models.LiftName]V) []models.LiftName {
+	keys := make([]models.LiftName, 0, len(m))
+	for _, liftName := range displayOrder {
+		if _, exists := m[liftName]; exists {
+			keys = append(keys, liftName)
+		}
+	}
+	var others []models.LiftName
+	for key := range m {
+		if !slices.Contains(displayOrder, key) {
+			others = append(others, key)
+		}
+	}
+	slices.Sort(others)
+	return append(keys, others...)
 }
 
+// FormatPerformedLift formats the name of a lift in a session, noting the
+// program lift it was substituted for, e.g. "Front Squat (for Squat)"
+func FormatPerformedLift(lift *models.Lift) string {
+	name := FormatLiftName(lift.WeightKey())
+	if lift.SubstitutedFor != "" {
+		name += i18n.Sprintf(" (for %s)", FormatLiftName(lift.SubstitutedFor))
+	}
+	return name
+}
+
+// FormatLiftName formats a lift name or weight key for display. Variant keys
+// include the variant in parentheses, e.g. "Squat:SSB" → "Squat (SSB)".
 func FormatLiftName(lift models.LiftName) string {
-	switch lift {
-	case models.Squat:
-		return "Squat"
-	case models.Deadlift:
-		return "Deadlift"
-	case models.BenchPress:
-		return "Bench Press"
-	case models.OverheadPress:
-		return "Overhead Press"
-	default:
-		return string(lift)
+	if base, variant := lift.SplitVariant(); variant != "" {
+		return fmt.Sprintf("%s (%s)", FormatLiftName(base), variant)
+	}
+
+	if def, ok := models.LookupLift(lift); ok {
+		return i18n.T(def.DisplayName)
+	}
+	return string(lift)
+}
+
+// formatWeekday abbreviates a day of the week in the current locale, e.g. "Mon"
+func formatWeekday(day time.Weekday) string {
+	return i18n.T(day.String()[:3])
+}
+
+// FormatTargetReps formats a set's target reps, e.g. "5", or its rep range, e.g. "6–8"
+func FormatTargetReps(set models.Set) string {
+	if set.HasRepRange() {
+		return fmt.Sprintf("%d–%d", set.TargetReps, set.MaxReps)
 	}
+	return strconv.Itoa(set.TargetReps)
 }
 
-func FormatSetDisplay(set models.Set, index int) string {
+// FormatSetDisplay formats a set with its weight shown as weights does, e.g.
+// "Set 1: 5 reps @ 135 lbs"
+func FormatSetDisplay(set models.Set, index int, weights WeightFormat) string {
 	switch set.Type {
 	case models.WarmupSet:
-		return fmt.Sprintf("%d reps @ %s lbs", set.TargetReps, FormatWeight(set.Weight))
+		return i18n.Sprintf("%d reps @ %s", set.TargetReps, weights.Format(set.Weight))
 	case models.AMRAPSet:
-		return fmt.Sprintf("Set %d: %d+ reps @ %s lbs (AMRAP)", index, set.TargetReps, FormatWeight(set.Weight))
+		return i18n.Sprintf("Set %d: %s+ reps @ %s (AMRAP)", index, FormatTargetReps(set), weights.Format(set.Weight))
+	case models.FeelerSet:
+		return i18n.Sprintf("Single: %d rep @ %s (feeler)", set.TargetReps, weights.Format(set.Weight))
 	default:
-		return fmt.Sprintf("Set %d: %d reps @ %s lbs", index, set.TargetReps, FormatWeight(set.Weight))
+		return i18n.Sprintf("Set %d: %s reps @ %s", index, FormatTargetReps(set), weights.Format(set.Weight))
+	}
+}
+
+// FormatAddedWeight describes the load of a bodyweight lift, e.g. "bodyweight + 25 lbs"
+// or "bodyweight - 40 lbs" when assisted
+func FormatAddedWeight(weight float64, weights WeightFormat) string {
+	switch {
+	case weight > 0:
+		return i18n.Sprintf("bodyweight + %s", weights.Format(weight))
+	case weight < 0:
+		return i18n.Sprintf("bodyweight - %s", weights.Format(-weight))
+	default:
+		return i18n.T("bodyweight")
+	}
+}
+
+// FormatBodyweightSetDisplay formats a working set of a bodyweight lift
+func FormatBodyweightSetDisplay(set models.Set, index int, weights WeightFormat) string {
+	if set.Type == models.AMRAPSet {
+		return i18n.Sprintf("Set %d: %s+ reps @ %s (AMRAP)", index, FormatTargetReps(set), FormatAddedWeight(set.Weight, weights))
 	}
+	return i18n.Sprintf("Set %d: %s reps @ %s", index, FormatTargetReps(set), FormatAddedWeight(set.Weight, weights))
 }
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/i18n"
 	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,7 +38,7 @@ func TestFormatWeight(t *testing.T) {
 		{
 			name:     "small decimal",
 			weight:   45.25,
-			expected: "45.2", // Should truncate to 1 decimal place
+			expected: "45.25", // Microplate weights keep their second decimal
 		},
 		{
 			name:     "large weight",
@@ -457,6 +459,17 @@ func TestWorkoutFormatter_FormatSetDisplay(t *testing.T) {
 			setIndex: 3,
 			expected: "Set 3: 5+ reps @ 135 lbs (AMRAP)",
 		},
+		{
+			name: "rep range set",
+			set: models.Set{
+				Weight:     135.0,
+				TargetReps: 6,
+				MaxReps:    8,
+				Type:       models.WorkingSet,
+			},
+			setIndex: 1,
+			expected: "Set 1: 6–8 reps @ 135 lbs",
+		},
 		{
 			name: "warmup set",
 			set: models.Set{
@@ -467,16 +480,109 @@ func TestWorkoutFormatter_FormatSetDisplay(t *testing.T) {
 			setIndex: 0, // Warmup sets don't use set index
 			expected: "5 reps @ 45 lbs",
 		},
+		{
+			name: "feeler set",
+			set: models.Set{
+				Weight:     237.5,
+				TargetReps: 1,
+				Type:       models.FeelerSet,
+			},
+			setIndex: 2,
+			expected: "Single: 1 rep @ 237.5 lbs (feeler)",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FormatSetDisplay(tt.set, tt.setIndex)
+			result := FormatSetDisplay(tt.set, tt.setIndex, WeightFormat{})
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestWorkoutFormatter_DisplayWorkout_FeelerSingle(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+
+	workout := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{
+				LiftName: models.Squat,
+				Sets: []models.Set{
+					{Weight: 250, TargetReps: 5, Type: models.WorkingSet, Order: 1},
+					{Weight: 250, TargetReps: 5, Type: models.WorkingSet, Order: 2},
+					{Weight: 237.5, TargetReps: 1, Type: models.FeelerSet, Order: 3},
+					{Weight: 250, TargetReps: 5, Type: models.AMRAPSet, Order: 4},
+				},
+			},
+		},
+	}
+
+	formatter.DisplayWorkout(workout)
+	output := buf.String()
+
+	assert.Contains(t, output, "    Set 2: 5 reps @ 250 lbs\n    Single: 1 rep @ 237.5 lbs (feeler)\n    Set 3: 5+ reps @ 250 lbs (AMRAP)\n")
+}
+
+func TestWorkoutFormatter_DisplayWorkout_PlateHints(t *testing.T) {
+	workout := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{
+				LiftName: models.Squat,
+				Sets: []models.Set{
+					{Weight: 45, TargetReps: 5, Type: models.WarmupSet},
+					{Weight: 95, TargetReps: 5, Type: models.WarmupSet},
+					{Weight: 130, TargetReps: 3, Type: models.WarmupSet},
+					{Weight: 150, TargetReps: 5, Type: models.WorkingSet},
+					{Weight: 150, TargetReps: 5, Type: models.WorkingSet},
+					{Weight: 130, TargetReps: 5, Type: models.WorkingSet},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+	formatter.SetPlateHints(&models.Config{PlateHints: true}, models.Pounds)
+	formatter.DisplayWorkout(workout)
+	assert.Contains(t, buf.String(), "  Warmup:\n"+
+		"    5 reps @ 45 lbs\n"+
+		"    5 reps @ 95 lbs (add 25/side)\n"+
+		"    3 reps @ 130 lbs (add 17.5/side: 10, 5, 2.5)\n"+
+		"  Working Sets:\n"+
+		"    Set 1: 5 reps @ 150 lbs (add 10/side)\n"+
+		"    Set 2: 5 reps @ 150 lbs\n"+
+		"    Set 3: 5 reps @ 130 lbs (remove 10/side)\n")
+
+	buf.Reset()
+	formatter.SetPlateHints(&models.Config{}, models.Pounds)
+	formatter.DisplayWorkout(workout)
+	assert.NotContains(t, buf.String(), "/side", "plate hints are off by default")
+}
+
+func TestWorkoutFormatter_DisplayWorkout_Coaching(t *testing.T) {
+	prog := &models.Program{Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{
+		{LiftName: models.Squat, Cues: []string{"Brace"}, URL: "https://example.com/squat"},
+		{LiftName: "Curl", Optional: true, Cues: []string{"No swinging"}},
+	}}}}
+	workout := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{LiftName: models.Squat, Sets: []models.Set{{Weight: 135, TargetReps: 5, Type: models.WorkingSet}}},
+			{LiftName: "Curl", Optional: true, Sets: []models.Set{{TargetReps: 10, Type: models.WorkingSet}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+	formatter.SetCoaching(prog)
+	formatter.DisplayWorkout(workout)
+	assert.Contains(t, buf.String(), "Squat:\n  Cues:\n    - Brace\n  Link: https://example.com/squat\n  Working Sets:\n")
+	assert.Contains(t, buf.String(), "Curl (optional):\n  Cues:\n    - No swinging\n  Sets:\n")
+}
+
 func TestWorkoutFormatter_IO_Integration(t *testing.T) {
 	t.Run("output is written to provided writer", func(t *testing.T) {
 		var buf bytes.Buffer
@@ -580,3 +686,221 @@ func TestWorkoutFormatter_EdgeCases(t *testing.T) {
 	})
 }
 
+
+func TestWorkoutFormatter_DisplayHolds(t *testing.T) {
+	t.Run("no holds prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewWorkoutFormatter(&buf).DisplayHolds(nil)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("lists held lifts", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewWorkoutFormatter(&buf).DisplayHolds(map[models.LiftName]int{
+			models.Squat:         3,
+			models.OverheadPress: 1,
+		})
+
+		assert.Equal(t, "Held Lifts:\n"+
+			"  Overhead Press: weight held for 1 more session\n"+
+			"  Squat: weight held for 3 more sessions\n\n", buf.String())
+	})
+}
+
+func TestWorkoutFormatter_DisplayDeload(t *testing.T) {
+	t.Run("no deload prints nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewWorkoutFormatter(&buf).DisplayDeload(nil)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("shows remaining sessions", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewWorkoutFormatter(&buf).DisplayDeload(&models.DeloadPlan{Percentage: 0.7, Sets: 3, Reps: 5, SessionsRemaining: 1})
+		assert.Equal(t, "Deload: 3x5 @ 70%, 1 session left before normal programming resumes\n\n", buf.String())
+	})
+}
+
+func TestWorkoutFormatter_DisplayLoadWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	NewWorkoutFormatter(&buf).DisplayLoadWarnings(nil, models.Pounds)
+	assert.Empty(t, buf.String())
+
+	NewWorkoutFormatter(&buf).DisplayLoadWarnings([]workout.LoadWarning{
+		{Lift: models.Squat, Weight: 150, Below: 145, Above: 155},
+		{Lift: models.OverheadPress, Weight: 97.5, Below: 75, Above: 135},
+	}, models.Pounds)
+	assert.Equal(t, "Plate Warnings:\n"+
+		"  Squat 150 lbs can't be loaded with your plates; nearest loadable: 145 or 155 lbs\n"+
+		"  Overhead Press 97.5 lbs can't be loaded with your plates; nearest loadable: 75 lbs\n\n", buf.String())
+}
+
+func TestFormatDeloadSets(t *testing.T) {
+	assert.Equal(t, "2x5 @ 80%", FormatDeloadSets(&models.DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5}))
+	assert.Equal(t, "1x3 @ 72.5%", FormatDeloadSets(&models.DeloadPlan{Percentage: 0.725, Sets: 1, Reps: 3}))
+}
+
+func TestFormatLiftName_Variants(t *testing.T) {
+	assert.Equal(t, "Squat (SSB)", FormatLiftName(models.VariantKey(models.Squat, "SSB")))
+	assert.Equal(t, "Bench Press (Football Bar)", FormatLiftName(models.VariantKey(models.BenchPress, "Football Bar")))
+}
+
+func TestWorkoutFormatter_DisplayWeightChanges_Variants(t *testing.T) {
+	ssb := models.VariantKey(models.Squat, "SSB")
+	old := map[models.LiftName]float64{models.Squat: 225.0, ssb: 185.0, models.BenchPress: 135.0}
+	new := map[models.LiftName]float64{models.Squat: 230.0, ssb: 190.0, models.BenchPress: 137.5}
+
+	var buf bytes.Buffer
+	NewWorkoutFormatter(&buf).DisplayWeightChanges(old, new)
+
+	assert.Equal(t, "\nWeight Updates:\n"+
+		"Bench Press: 135 → 137.5 lbs (+2.5)\n"+
+		"Squat: 225 → 230 lbs (+5.0)\n"+
+		"Squat (SSB): 185 → 190 lbs (+5.0)\n", buf.String())
+}
+
+func TestFormatBodyweightSetDisplay(t *testing.T) {
+	assert.Equal(t, "Set 1: 5 reps @ bodyweight", FormatBodyweightSetDisplay(models.Set{TargetReps: 5, Type: models.WorkingSet}, 1, WeightFormat{}))
+	assert.Equal(t, "Set 2: 8+ reps @ bodyweight + 25 lbs (AMRAP)", FormatBodyweightSetDisplay(models.Set{Weight: 25, TargetReps: 8, Type: models.AMRAPSet}, 2, WeightFormat{}))
+	assert.Equal(t, "Set 1: 5 reps @ bodyweight - 40 lbs", FormatBodyweightSetDisplay(models.Set{Weight: -40, TargetReps: 5, Type: models.WorkingSet}, 1, WeightFormat{}))
+}
+
+func TestDisplayRepTargetChanges(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+
+	formatter.DisplayRepTargetChanges(map[models.LiftName]int{"Chinup": 8}, map[models.LiftName]int{"Chinup": 8})
+	assert.Empty(t, buf.String())
+
+	formatter.DisplayRepTargetChanges(map[models.LiftName]int{"Chinup": 8}, map[models.LiftName]int{"Chinup": 9})
+	assert.Equal(t, "\nRep Target Updates:\nChinup: 8 → 9 reps\n", buf.String())
+}
+
+func TestWorkoutFormatter_DisplayHistory(t *testing.T) {
+	workouts := []models.Workout{
+		{
+			Day:       1,
+			EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC),
+			Exercises: []models.Lift{
+				{LiftName: models.Squat, Sets: []models.Set{
+					{Weight: 45, ActualReps: 5, Type: models.WarmupSet},
+					{Weight: 135, ActualReps: 5, Type: models.WorkingSet},
+					{Weight: 135, ActualReps: 8, Type: models.AMRAPSet},
+				}},
+				{LiftName: "Chinup", Bodyweight: true, Sets: []models.Set{{Weight: 10, ActualReps: 6, Type: models.AMRAPSet}}},
+				{LiftName: "Curl", Optional: true, Sets: []models.Set{{ActualReps: 12, Type: models.WorkingSet}}},
+			},
+			Notes: "Knee felt sore\n  fine after warmups",
+		},
+		{
+			Day:        2,
+			EnteredAt:  time.Date(2024, 3, 6, 18, 0, 0, 0, time.UTC),
+			Quick:      true,
+			Incomplete: true,
+			Exercises: []models.Lift{
+				{LiftName: models.BenchPress, Sets: []models.Set{{Weight: 125, ActualReps: 3, Type: models.AMRAPSet}}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	NewWorkoutFormatter(&buf).DisplayHistory(workouts)
+	assert.Equal(t, "2024-03-06  Day 2 (quick) (incomplete)\n"+
+		"  Bench Press 125 lbs: 3\n"+
+		"\n"+
+		"2024-03-04  Day 1\n"+
+		"  Squat 135 lbs: 5, 8\n"+
+		"  Chinup @ bodyweight + 10 lbs: 6\n"+
+		"  Curl: 12\n"+
+		"  Notes:\n"+
+		"    Knee felt sore\n"+
+		"      fine after warmups\n", buf.String())
+
+	buf.Reset()
+	NewWorkoutFormatter(&buf).DisplayHistory(nil)
+	assert.Equal(t, "No workouts logged yet.\n", buf.String())
+}
+
+func TestFormatWorkoutDay(t *testing.T) {
+	assert.Equal(t, "Day 2", FormatWorkoutDay(&models.Workout{Day: 2}))
+	assert.Equal(t, "Extra workout", FormatWorkoutDay(&models.Workout{AdHoc: true}))
+}
+
+func TestWorkoutFormatter_DisplayCalendar(t *testing.T) {
+	now := time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)
+	entries := []workout.CalendarEntry{
+		{Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Missed: true},
+		{Date: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), Day: 3},
+		{Date: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), Day: 4},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+	formatter.SetDateFormat(models.DateUS)
+	formatter.DisplayCalendar(entries, models.DefaultTrainingDays, now)
+	assert.Equal(t, "Training days: Mon, Wed, Fri\n"+
+		"\n"+
+		"  Mon 03/04/2024  missed\n"+
+		"  Wed 03/06/2024  Day 3 (today)\n"+
+		"  Fri 03/08/2024  Day 4\n"+
+		"\n"+
+		"1 missed session since your last workout. Your next workout picks up where you left off.\n", buf.String())
+
+	// Weekday names are translated
+	i18n.SetLocale("es")
+	t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+	buf.Reset()
+	formatter.DisplayCalendar(entries, models.DefaultTrainingDays, now)
+	assert.Contains(t, buf.String(), "Días de entrenamiento: lun, mié, vie\n")
+	assert.Contains(t, buf.String(), "  mié 03/06/2024  ")
+}
+
+func TestWorkoutFormatter_DisplayProgressionSteps(t *testing.T) {
+	steps := []workout.ProgressionStep{
+		{Lift: models.Squat, Weight: 135, Reps: 4, Strategy: models.LinearProgression, Increment: 5, Unrounded: 121.5, Next: 120},
+		{Lift: models.BenchPress, Weight: 125, Reps: 7, Strategy: models.LinearProgression, Increment: 2.5, Unrounded: 127.5, Next: 127.5},
+	}
+
+	var buf bytes.Buffer
+	NewWorkoutFormatter(&buf).DisplayProgressionSteps(steps)
+	assert.Equal(t, "\nProgression:\n"+
+		"  Squat: 4 reps at 135 lbs (linear, increment 5): 121.5 rounded down to 120 lbs\n"+
+		"  Bench Press: 7 reps at 125 lbs (linear, increment 2.5): 127.5 lbs\n", buf.String())
+
+	buf.Reset()
+	NewWorkoutFormatter(&buf).DisplayProgressionSteps(nil)
+	assert.Empty(t, buf.String())
+
+	buf.Reset()
+	formatter := NewWorkoutFormatter(&buf)
+	formatter.SetWeightFormat(WeightFormat{Dual: true})
+	formatter.DisplayProgressionSteps(steps[1:])
+	assert.Equal(t, "\nProgression:\n"+
+		"  Bench Press: 7 reps at 125 lbs / 56.7 kg (linear, increment 2.5): 127.5 lbs / 57.8 kg\n", buf.String())
+}
+
+func TestWorkoutFormatter_DisplayWorkout_DualUnits(t *testing.T) {
+	workout := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{
+				LiftName: models.Squat,
+				Sets: []models.Set{
+					{Weight: 20, TargetReps: 5, Type: models.WarmupSet},
+					{Weight: 100, TargetReps: 5, Type: models.WorkingSet},
+					{Weight: 100, TargetReps: 5, Type: models.AMRAPSet},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewWorkoutFormatter(&buf)
+	formatter.SetWeightFormat(WeightFormat{Unit: models.Kilograms, Dual: true})
+	formatter.DisplayWorkout(workout)
+	assert.Contains(t, buf.String(), "  Warmup:\n"+
+		"    5 reps @ 20 kg / 44.1 lbs\n"+
+		"  Working Sets:\n"+
+		"    Set 1: 5 reps @ 100 kg / 220.5 lbs\n"+
+		"    Set 2: 5+ reps @ 100 kg / 220.5 lbs (AMRAP)\n")
+}
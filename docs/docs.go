@@ -0,0 +1,108 @@
+// Package docs generates command reference documentation, as Markdown or man
+// pages, from the cobra command tree.
+package docs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Format is an output format for generated documentation
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	Man      Format = "man"
+)
+
+// ParseFormat converts user input such as "markdown" or "md" into a Format
+func ParseFormat(input string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "markdown", "md":
+		return Markdown, nil
+	case "man":
+		return Man, nil
+	}
+	return "", fmt.Errorf("unknown docs format %q (expected markdown or man)", input)
+}
+
+// Commands returns root and every documented command beneath it, depth first
+// in name order. Hidden and deprecated commands and help topics are left out.
+func Commands(root *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{root}
+	for _, child := range documentedChildren(root) {
+		commands = append(commands, Commands(child)...)
+	}
+	return commands
+}
+
+// initDefaultFlags adds the help and version flags cobra otherwise only adds
+// to commands as they run, so every page documents them
+func initDefaultFlags(cmd *cobra.Command) {
+	cmd.InitDefaultHelpFlag()
+	cmd.InitDefaultVersionFlag()
+}
+
+// documentedChildren returns the subcommands of cmd that are documented, which
+// cobra already sorts by name
+func documentedChildren(cmd *cobra.Command) []*cobra.Command {
+	var children []*cobra.Command
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() && !child.IsAdditionalHelpTopicCommand() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Filename returns the file a command's page is written to, e.g.
+// "greyskull_workout_log.md" or "greyskull-workout-log.1"
+func Filename(cmd *cobra.Command, format Format) string {
+	if format == Man {
+		return strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".1"
+	}
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
+}
+
+// WriteFiles writes one page per documented command into dir, creating it if
+// needed, and returns the paths written. Man pages are dated date.
+func WriteFiles(root *cobra.Command, format Format, dir string, date time.Time) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create docs directory: %w", err)
+	}
+
+	var paths []string
+	for _, cmd := range Commands(root) {
+		path := filepath.Join(dir, Filename(cmd, format))
+		file, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+
+		if format == Man {
+			err = WriteManPage(file, cmd, date)
+		} else {
+			err = WriteMarkdownPage(file, cmd, fileLink(Markdown))
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// fileLink links to a command's page in the same directory
+func fileLink(format Format) func(cmd *cobra.Command) string {
+	return func(cmd *cobra.Command) string {
+		return Filename(cmd, format)
+	}
+}
@@ -0,0 +1,110 @@
+package docs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTree builds a small command tree: tool, tool lift (not runnable), and
+// tool lift hold with flags and an example, plus a hidden command
+func testTree() *cobra.Command {
+	root := &cobra.Command{Use: "tool", Short: "A test tool", Version: "1.2.3", Run: func(*cobra.Command, []string) {}}
+	root.PersistentFlags().Bool("verbose", false, "Show more output")
+
+	lift := &cobra.Command{Use: "lift", Short: "Manage lifts"}
+	hold := &cobra.Command{
+		Use:     "hold <lift>",
+		Short:   "Hold a lift's weight",
+		Long:    "Hold a lift's weight.\n\n.Leading dots are escaped:\n  tool lift hold squat --sessions 2",
+		Example: "  tool lift hold squat",
+		Run:     func(*cobra.Command, []string) {},
+	}
+	hold.Flags().IntP("sessions", "s", 1, "Number of `sessions` to hold")
+	hidden := &cobra.Command{Use: "secret", Hidden: true, Run: func(*cobra.Command, []string) {}}
+
+	root.AddCommand(lift, hidden)
+	lift.AddCommand(hold)
+	return root
+}
+
+func TestCommands(t *testing.T) {
+	var paths []string
+	for _, cmd := range Commands(testTree()) {
+		paths = append(paths, cmd.CommandPath())
+	}
+	assert.Equal(t, []string{"tool", "tool lift", "tool lift hold"}, paths)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("MD")
+	require.NoError(t, err)
+	assert.Equal(t, Markdown, format)
+
+	format, err = ParseFormat("man")
+	require.NoError(t, err)
+	assert.Equal(t, Man, format)
+
+	_, err = ParseFormat("pdf")
+	assert.ErrorContains(t, err, `unknown docs format "pdf"`)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buf, testTree()))
+	output := buf.String()
+
+	assert.Contains(t, output, "# tool command reference\n\n- [tool](#tool)\n- [tool lift](#tool-lift)\n- [tool lift hold](#tool-lift-hold)\n")
+	assert.Contains(t, output, "## tool lift hold\n\nHold a lift's weight\n\n### Synopsis\n\n")
+	assert.Contains(t, output, "```\ntool lift hold <lift> [flags]\n```\n")
+	assert.Contains(t, output, "### Examples\n\n```\n  tool lift hold squat\n```\n")
+	assert.Contains(t, output, "  -s, --sessions sessions   Number of sessions to hold (default 1)\n")
+	assert.Contains(t, output, "### Options inherited from parent commands\n\n```\n      --verbose   Show more output\n```\n")
+	assert.Contains(t, output, "### See also\n\n- [tool lift](#tool-lift) - Manage lifts\n")
+	assert.NotContains(t, output, "secret")
+}
+
+func TestWriteManPage(t *testing.T) {
+	root := testTree()
+	hold, _, err := root.Find([]string{"lift", "hold"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteManPage(&buf, hold, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)))
+	output := buf.String()
+
+	assert.Contains(t, output, ".TH \"TOOL\\-LIFT\\-HOLD\" \"1\" \"Mar 2024\" \"tool 1.2.3\" \"Tool Manual\"\n")
+	assert.Contains(t, output, ".SH NAME\ntool\\-lift\\-hold \\- Hold a lift's weight\n")
+	assert.Contains(t, output, ".SH SYNOPSIS\n\\fBtool lift hold\\fP <lift> [flags]\n")
+	// Lines roff would read as requests are guarded, and indented paragraphs keep their layout
+	assert.Contains(t, output, ".PP\n.nf\n\\&.Leading dots are escaped:\n  tool lift hold squat \\-\\-sessions 2\n.fi\n")
+	assert.Contains(t, output, ".TP\n\\fB\\-s\\fP, \\fB\\-\\-sessions\\fP \\fIsessions\\fP\nNumber of sessions to hold (default 1)\n")
+	assert.Contains(t, output, ".SH OPTIONS INHERITED FROM PARENT COMMANDS\n.TP\n\\fB\\-\\-verbose\\fP\nShow more output\n")
+	assert.Contains(t, output, ".SH SEE ALSO\n\\fBtool\\-lift\\fP(1)\n")
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man1")
+
+	paths, err := WriteFiles(testTree(), Man, dir, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "tool.1"),
+		filepath.Join(dir, "tool-lift.1"),
+		filepath.Join(dir, "tool-lift-hold.1"),
+	}, paths)
+
+	paths, err = WriteFiles(testTree(), Markdown, dir, time.Now())
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+	data, err := os.ReadFile(filepath.Join(dir, "tool_lift.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# tool lift\n")
+	assert.Contains(t, string(data), "- [tool lift hold](tool_lift_hold.md) - Hold a lift's weight\n")
+}
@@ -0,0 +1,155 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// WriteManPage writes a command's reference as a section 1 man page in roff,
+// dated date
+func WriteManPage(w io.Writer, cmd *cobra.Command, date time.Time) error {
+	initDefaultFlags(cmd)
+
+	var b strings.Builder
+	name := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+	root := cmd.Root()
+
+	fmt.Fprintf(&b, ".TH \"%s\" \"1\" \"%s\" \"%s\" \"%s Manual\"\n",
+		roffEscape(strings.ToUpper(name)), date.Format("Jan 2006"),
+		roffEscape(strings.TrimSpace(root.Name()+" "+root.Version)), roffEscape(titleCase(root.Name())))
+	fmt.Fprintf(&b, ".SH NAME\n%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, "\\fB%s\\fP", roffEscape(cmd.CommandPath()))
+	if cmd.Runnable() {
+		if rest := strings.TrimPrefix(cmd.UseLine(), cmd.CommandPath()); rest != "" {
+			b.WriteString(roffEscape(rest))
+		}
+	} else {
+		b.WriteString(" [command]")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(".SH DESCRIPTION\n")
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	writeRoffText(&b, description)
+
+	writeManOptions(&b, "OPTIONS", cmd.NonInheritedFlags())
+	writeManOptions(&b, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	if cmd.Example != "" {
+		b.WriteString(".SH EXAMPLES\n.PP\n.RS 4\n.nf\n")
+		for _, line := range strings.Split(strings.TrimRight(cmd.Example, "\n"), "\n") {
+			b.WriteString(roffLine(line) + "\n")
+		}
+		b.WriteString(".fi\n.RE\n")
+	}
+
+	if related := relatedCommands(cmd); len(related) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		pages := make([]string, len(related))
+		for i, other := range related {
+			pages[i] = fmt.Sprintf("\\fB%s\\fP(1)", roffEscape(strings.ReplaceAll(other.CommandPath(), " ", "-")))
+		}
+		b.WriteString(strings.Join(pages, ", ") + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeManOptions writes a section listing each visible flag with its usage
+func writeManOptions(b *strings.Builder, title string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+
+	fmt.Fprintf(b, ".SH %s\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		varname, usage := pflag.UnquoteUsage(flag)
+
+		b.WriteString(".TP\n")
+		if flag.Shorthand != "" {
+			fmt.Fprintf(b, "\\fB\\-%s\\fP, ", roffEscape(flag.Shorthand))
+		}
+		fmt.Fprintf(b, "\\fB\\-\\-%s\\fP", roffEscape(flag.Name))
+		if varname != "" {
+			fmt.Fprintf(b, " \\fI%s\\fP", roffEscape(varname))
+		}
+		b.WriteString("\n")
+
+		if !isZeroDefault(flag.DefValue) {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		b.WriteString(roffLine(usage) + "\n")
+	})
+}
+
+// isZeroDefault reports whether a flag's default is its type's zero value,
+// which isn't worth documenting
+func isZeroDefault(value string) bool {
+	switch value {
+	case "", "false", "0", "0s", "[]":
+		return true
+	}
+	return false
+}
+
+// writeRoffText writes text paragraph by paragraph. Paragraphs with indented
+// lines, such as lists and examples, keep their layout; others are filled.
+func writeRoffText(b *strings.Builder, text string) {
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		lines := strings.Split(paragraph, "\n")
+		preformatted := false
+		for _, line := range lines {
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+				preformatted = true
+			}
+		}
+
+		b.WriteString(".PP\n")
+		if preformatted {
+			b.WriteString(".nf\n")
+		}
+		for _, line := range lines {
+			b.WriteString(roffLine(strings.TrimRight(line, " ")) + "\n")
+		}
+		if preformatted {
+			b.WriteString(".fi\n")
+		}
+	}
+}
+
+// roffLine escapes a line of text, guarding lines that roff would read as requests
+func roffLine(line string) string {
+	escaped := roffEscape(line)
+	if strings.HasPrefix(escaped, ".") || strings.HasPrefix(escaped, "'") {
+		return `\&` + escaped
+	}
+	return escaped
+}
+
+// roffEscape escapes backslashes and hyphens, which roff would otherwise
+// interpret or typeset as hyphens rather than minus signs
+func roffEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
+
+// titleCase capitalizes the first letter of a word
+func titleCase(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
@@ -0,0 +1,86 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// WriteMarkdown writes the reference for root and every documented command
+// beneath it as a single Markdown document, linking between sections
+func WriteMarkdown(w io.Writer, root *cobra.Command) error {
+	fmt.Fprintf(w, "# %s command reference\n\n", root.Name())
+	for _, cmd := range Commands(root) {
+		fmt.Fprintf(w, "- [%s](%s)\n", cmd.CommandPath(), anchorLink(cmd))
+	}
+
+	for _, cmd := range Commands(root) {
+		fmt.Fprintf(w, "\n")
+		if err := writeMarkdownSection(w, cmd, "##", anchorLink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMarkdownPage writes a single command's reference as a standalone
+// Markdown page, linking to related commands with link
+func WriteMarkdownPage(w io.Writer, cmd *cobra.Command, link func(*cobra.Command) string) error {
+	return writeMarkdownSection(w, cmd, "#", link)
+}
+
+// anchorLink links to a command's section of the single-document reference
+// using GitHub's heading anchors, e.g. "#greyskull-workout-log"
+func anchorLink(cmd *cobra.Command) string {
+	return "#" + strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+func writeMarkdownSection(w io.Writer, cmd *cobra.Command, heading string, link func(*cobra.Command) string) error {
+	initDefaultFlags(cmd)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n\n", heading, cmd.CommandPath())
+	fmt.Fprintf(&b, "%s\n\n", cmd.Short)
+
+	if cmd.Long != "" {
+		fmt.Fprintf(&b, "%s# Synopsis\n\n%s\n\n", heading, cmd.Long)
+	}
+	if cmd.Runnable() {
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", cmd.UseLine())
+	}
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: %s\n\n", strings.Join(cmd.Aliases, ", "))
+	}
+	if cmd.Example != "" {
+		fmt.Fprintf(&b, "%s# Examples\n\n```\n%s\n```\n\n", heading, strings.TrimRight(cmd.Example, "\n"))
+	}
+
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(&b, "%s# Options\n\n```\n%s```\n\n", heading, flags.FlagUsages())
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(&b, "%s# Options inherited from parent commands\n\n```\n%s```\n\n", heading, flags.FlagUsages())
+	}
+
+	related := relatedCommands(cmd)
+	if len(related) > 0 {
+		fmt.Fprintf(&b, "%s# See also\n\n", heading)
+		for _, other := range related {
+			fmt.Fprintf(&b, "- [%s](%s) - %s\n", other.CommandPath(), link(other), other.Short)
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// relatedCommands returns a command's parent followed by its documented subcommands
+func relatedCommands(cmd *cobra.Command) []*cobra.Command {
+	var related []*cobra.Command
+	if cmd.HasParent() {
+		related = append(related, cmd.Parent())
+	}
+	return append(related, documentedChildren(cmd)...)
+}
@@ -0,0 +1,255 @@
+// Package doctor finds inconsistencies in stored user data, such as references
+// to programs that no longer exist, and repairs those that can be fixed safely
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// dateFormat identifies workouts and programs in problem descriptions
+const dateFormat = "2006-01-02"
+
+// Programs looks up program templates by ID, as program.Catalog does
+type Programs interface {
+	GetByID(ctx context.Context, id string) (*models.Program, error)
+}
+
+// Issue is a problem found in stored data
+type Issue struct {
+	Problem string `json:"problem"`
+
+	// Repair describes what Fix does about the problem, or is empty when it
+	// has to be fixed by hand
+	Repair string `json:"repair,omitempty"`
+
+	fix func() error
+}
+
+// Fixable reports whether Fix can repair the issue
+func (i Issue) Fixable() bool {
+	return i.fix != nil
+}
+
+// Fix repairs the issue. Issues in a user's data are repaired in memory, and
+// the caller saves the user; issues with files are repaired on disk. Issues
+// that aren't fixable are left alone.
+func (i Issue) Fix() error {
+	if i.fix == nil {
+		return nil
+	}
+	return i.fix()
+}
+
+// Report is the issues found in one part of the data directory, such as a
+// single user's data
+type Report struct {
+	Subject string  `json:"subject"`
+	Issues  []Issue `json:"issues"`
+}
+
+// CheckUser returns the problems in a user's data, in a stable order. The
+// user's workout history must already be loaded. Fixing an issue changes user.
+func CheckUser(ctx context.Context, user *models.User, programs Programs) []Issue {
+	var issues []Issue
+	issues = append(issues, checkCurrentProgram(user)...)
+	for _, id := range sortedProgramIDs(user) {
+		issues = append(issues, checkUserProgram(ctx, user.Programs[id], programs)...)
+	}
+	issues = append(issues, checkHistory(user)...)
+	issues = append(issues, checkSkippedDays(user)...)
+	return issues
+}
+
+func checkCurrentProgram(user *models.User) []Issue {
+	if user.CurrentProgram == uuid.Nil {
+		return nil
+	}
+	if _, exists := user.Programs[user.CurrentProgram]; exists {
+		return nil
+	}
+	return []Issue{{
+		Problem: fmt.Sprintf("the active program %s doesn't exist", user.CurrentProgram),
+		Repair:  "clear the active program; start or switch to one again",
+		fix:     inMemory(func() { user.CurrentProgram = uuid.Nil }),
+	}}
+}
+
+func checkUserProgram(ctx context.Context, userProgram *models.UserProgram, programs Programs) []Issue {
+	name := "program started " + userProgram.StartedAt.Format(dateFormat)
+
+	// A program snapshotted when it was started doesn't need its template
+	prog := userProgram.Program
+	if prog == nil {
+		var err error
+		if prog, err = programs.GetByID(ctx, userProgram.ProgramID.String()); err != nil {
+			// Without the template there's no telling which lifts are bodyweight
+			// lifts, whose weights may be negative
+			return []Issue{{
+				Problem: fmt.Sprintf("%s follows unknown program %s; re-import the program to use it", name, userProgram.ProgramID),
+			}}
+		}
+	}
+
+	var issues []Issue
+	for _, lift := range slices.Sorted(maps.Keys(userProgram.CurrentWeights)) {
+		weight := userProgram.CurrentWeights[lift]
+		if weight >= 0 || prog.IsBodyweight(lift) {
+			continue
+		}
+		issue := Issue{Problem: fmt.Sprintf("%s has a negative %s weight (%s)", name, lift, formatWeight(weight))}
+		if starting := userProgram.StartingWeights[lift]; starting >= 0 {
+			issue.Repair = fmt.Sprintf("reset it to the starting weight (%s)", formatWeight(starting))
+			issue.fix = inMemory(func() { userProgram.CurrentWeights[lift] = starting })
+		}
+		issues = append(issues, issue)
+	}
+	for _, lift := range slices.Sorted(maps.Keys(userProgram.StartingWeights)) {
+		weight := userProgram.StartingWeights[lift]
+		if weight < 0 && !prog.IsBodyweight(lift) {
+			issues = append(issues, Issue{
+				Problem: fmt.Sprintf("%s has a negative %s starting weight (%s)", name, lift, formatWeight(weight)),
+			})
+		}
+	}
+	for _, lift := range slices.Sorted(maps.Keys(userProgram.Goals)) {
+		goal := userProgram.Goals[lift]
+		if goal < 0 && !prog.IsBodyweight(lift) {
+			issues = append(issues, Issue{
+				Problem: fmt.Sprintf("%s has a negative %s goal (%s)", name, lift, formatWeight(goal)),
+				Repair:  "remove the goal",
+				fix:     inMemory(func() { delete(userProgram.Goals, lift) }),
+			})
+		}
+	}
+	return issues
+}
+
+func checkHistory(user *models.User) []Issue {
+	var issues []Issue
+
+	missing := map[uuid.UUID]int{}
+	for _, w := range user.WorkoutHistory {
+		if _, exists := user.Programs[w.UserProgramID]; !exists && !w.AdHoc {
+			missing[w.UserProgramID]++
+		}
+	}
+	for _, id := range slices.SortedFunc(maps.Keys(missing), compareUUIDs) {
+		issues = append(issues, Issue{
+			Problem: fmt.Sprintf("program %s doesn't exist but is referenced by %s", id, pluralize(missing[id], "workout", "workouts")),
+		})
+	}
+
+	for _, w := range user.WorkoutHistory {
+		for _, lift := range w.Exercises {
+			if lift.Bodyweight {
+				continue
+			}
+			for _, set := range lift.Sets {
+				if set.Weight < 0 {
+					issues = append(issues, Issue{
+						Problem: fmt.Sprintf("workout on %s has a %s set with a negative weight (%s)",
+							w.EnteredAt.Format(dateFormat), lift.LiftName, formatWeight(set.Weight)),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	seen := map[uuid.UUID]int{}
+	for i := 0; i < len(user.WorkoutHistory); i++ {
+		w := user.WorkoutHistory[i]
+		first, duplicate := seen[w.ID]
+		if !duplicate {
+			seen[w.ID] = i
+			continue
+		}
+		issue := Issue{Problem: fmt.Sprintf("workouts on %s and %s share the ID %s",
+			user.WorkoutHistory[first].EnteredAt.Format(dateFormat), w.EnteredAt.Format(dateFormat), w.ID)}
+		if reflect.DeepEqual(user.WorkoutHistory[first], w) {
+			issue.Problem = fmt.Sprintf("workout on %s is stored twice", w.EnteredAt.Format(dateFormat))
+			issue.Repair = "remove the copy"
+			issue.fix = inMemory(func() {
+				user.WorkoutHistory = slices.DeleteFunc(user.WorkoutHistory, func(other models.Workout) bool {
+					return other.ID == w.ID && reflect.DeepEqual(other, w)
+				})
+				user.WorkoutHistory = slices.Insert(user.WorkoutHistory, min(first, len(user.WorkoutHistory)), w)
+			})
+		} else {
+			issue.Repair = "give the later workout a new ID"
+			issue.fix = inMemory(func() {
+				for j := range user.WorkoutHistory {
+					if user.WorkoutHistory[j].ID == w.ID && reflect.DeepEqual(user.WorkoutHistory[j], w) {
+						user.WorkoutHistory[j].ID = uuid.New()
+						return
+					}
+				}
+			})
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func checkSkippedDays(user *models.User) []Issue {
+	var issues []Issue
+	seen := map[uuid.UUID]bool{}
+	for _, skipped := range user.SkippedDays {
+		if seen[skipped.ID] {
+			issues = append(issues, Issue{
+				Problem: fmt.Sprintf("day %d skipped on %s shares its ID with another skipped day", skipped.Day, skipped.SkippedAt.Format(dateFormat)),
+				Repair:  "give it a new ID",
+				fix: inMemory(func() {
+					for i := len(user.SkippedDays) - 1; i >= 0; i-- {
+						if user.SkippedDays[i] == skipped {
+							user.SkippedDays[i].ID = uuid.New()
+							return
+						}
+					}
+				}),
+			})
+		}
+		seen[skipped.ID] = true
+	}
+	return issues
+}
+
+// inMemory adapts a repair that can't fail
+func inMemory(fix func()) func() error {
+	return func() error {
+		fix()
+		return nil
+	}
+}
+
+func sortedProgramIDs(user *models.User) []uuid.UUID {
+	return slices.SortedFunc(maps.Keys(user.Programs), func(a, b uuid.UUID) int {
+		pa, pb := user.Programs[a], user.Programs[b]
+		if c := pa.StartedAt.Compare(pb.StartedAt); c != 0 {
+			return c
+		}
+		return compareUUIDs(a, b)
+	})
+}
+
+func compareUUIDs(a, b uuid.UUID) int {
+	return slices.Compare(a[:], b[:])
+}
+
+func formatWeight(weight float64) string {
+	return fmt.Sprintf("%g", weight)
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
@@ -0,0 +1,166 @@
+package doctor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withChinups is Greyskull LP with weighted chin-ups, a bodyweight lift whose
+// weight may be negative
+var withChinups = &models.Program{
+	ID: uuid.New(),
+	Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{
+		{LiftName: models.Squat},
+		{LiftName: "Chinup", Bodyweight: true},
+	}}},
+}
+
+func testUser() (*models.User, *models.UserProgram) {
+	userProgram := &models.UserProgram{
+		ID:              uuid.New(),
+		ProgramID:       withChinups.ID,
+		StartingWeights: map[models.LiftName]float64{models.Squat: 135, "Chinup": -20},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 145, "Chinup": -10},
+		StartedAt:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	user := &models.User{
+		ID:             uuid.New(),
+		Username:       "Alice",
+		CurrentProgram: userProgram.ID,
+		Programs:       map[uuid.UUID]*models.UserProgram{userProgram.ID: userProgram},
+		WorkoutHistory: []models.Workout{},
+	}
+	return user, userProgram
+}
+
+func workoutOn(userProgramID uuid.UUID, day int) models.Workout {
+	return models.Workout{
+		ID:            uuid.New(),
+		UserProgramID: userProgramID,
+		Day:           1,
+		EnteredAt:     time.Date(2024, 3, day, 18, 0, 0, 0, time.UTC),
+		Exercises: []models.Lift{
+			{LiftName: models.Squat, Sets: []models.Set{{Weight: 135, TargetReps: 5, ActualReps: 5}}},
+			{LiftName: "Chinup", Bodyweight: true, Sets: []models.Set{{Weight: -20, TargetReps: 5, ActualReps: 5}}},
+		},
+	}
+}
+
+func problems(issues []Issue) []string {
+	var problems []string
+	for _, issue := range issues {
+		problems = append(problems, issue.Problem)
+	}
+	return problems
+}
+
+func TestCheckUser_Healthy(t *testing.T) {
+	user, userProgram := testUser()
+	user.WorkoutHistory = []models.Workout{workoutOn(userProgram.ID, 4), workoutOn(userProgram.ID, 6)}
+
+	assert.Empty(t, CheckUser(t.Context(), user, program.NewCatalog([]*models.Program{withChinups})))
+}
+
+func TestCheckUser_UnknownProgramsAndMissingUserPrograms(t *testing.T) {
+	user, userProgram := testUser()
+	user.CurrentProgram = uuid.New()
+	missing := uuid.New()
+	user.WorkoutHistory = []models.Workout{workoutOn(userProgram.ID, 4), workoutOn(missing, 6), workoutOn(missing, 8)}
+	userProgram.CurrentWeights[models.Squat] = -5 // Not checked without the program
+
+	issues := CheckUser(t.Context(), user, program.NewCatalog(nil))
+	assert.Equal(t, []string{
+		"the active program " + user.CurrentProgram.String() + " doesn't exist",
+		"program started 2024-03-01 follows unknown program " + withChinups.ID.String() + "; re-import the program to use it",
+		"program " + missing.String() + " doesn't exist but is referenced by 2 workouts",
+	}, problems(issues))
+	assert.True(t, issues[0].Fixable())
+	assert.False(t, issues[1].Fixable())
+	assert.False(t, issues[2].Fixable())
+
+	require.NoError(t, issues[0].Fix())
+	assert.Equal(t, uuid.Nil, user.CurrentProgram)
+	require.NoError(t, issues[1].Fix()) // Unfixable issues are left alone
+	assert.Len(t, user.WorkoutHistory, 3)
+}
+
+func TestCheckUser_AdHocWorkouts(t *testing.T) {
+	user, userProgram := testUser()
+	adHoc := workoutOn(uuid.Nil, 5)
+	adHoc.AdHoc = true
+	user.WorkoutHistory = []models.Workout{workoutOn(userProgram.ID, 4), adHoc}
+
+	assert.Empty(t, CheckUser(t.Context(), user, program.NewCatalog([]*models.Program{withChinups})),
+		"workouts logged outside any program don't reference a missing program")
+}
+
+func TestCheckUser_NegativeWeights(t *testing.T) {
+	user, userProgram := testUser()
+	userProgram.CurrentWeights[models.Squat] = -145
+	userProgram.StartingWeights[models.Deadlift] = -185
+	userProgram.CurrentWeights[models.Deadlift] = -185
+	userProgram.Goals = map[models.LiftName]float64{models.Squat: -200, "Chinup": -5}
+	workout := workoutOn(userProgram.ID, 4)
+	workout.Exercises[0].Sets = append(workout.Exercises[0].Sets, models.Set{Weight: -135}, models.Set{Weight: -135})
+	user.WorkoutHistory = []models.Workout{workout}
+
+	issues := CheckUser(t.Context(), user, program.NewCatalog([]*models.Program{withChinups}))
+	assert.Equal(t, []string{
+		"program started 2024-03-01 has a negative Deadlift weight (-185)",
+		"program started 2024-03-01 has a negative Squat weight (-145)",
+		"program started 2024-03-01 has a negative Deadlift starting weight (-185)",
+		"program started 2024-03-01 has a negative Squat goal (-200)",
+		"workout on 2024-03-04 has a Squat set with a negative weight (-135)",
+	}, problems(issues))
+	assert.Equal(t, []bool{false, true, false, true, false}, []bool{
+		issues[0].Fixable(), issues[1].Fixable(), issues[2].Fixable(), issues[3].Fixable(), issues[4].Fixable(),
+	})
+	assert.Equal(t, "reset it to the starting weight (135)", issues[1].Repair)
+
+	for _, issue := range issues {
+		require.NoError(t, issue.Fix())
+	}
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, -185.0, userProgram.CurrentWeights[models.Deadlift])
+	assert.Equal(t, map[models.LiftName]float64{"Chinup": -5}, userProgram.Goals)
+}
+
+func TestCheckUser_DuplicateIDs(t *testing.T) {
+	user, userProgram := testUser()
+	first := workoutOn(userProgram.ID, 4)
+	second := workoutOn(userProgram.ID, 6)
+	clash := workoutOn(userProgram.ID, 8)
+	clash.ID = second.ID
+	skipped := models.SkippedDay{ID: uuid.New(), UserProgramID: userProgram.ID, Day: 2, SkippedAt: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)}
+	repeat := skipped
+	repeat.SkippedAt = skipped.SkippedAt.AddDate(0, 0, 2)
+	user.WorkoutHistory = []models.Workout{first, second, first, clash}
+	user.SkippedDays = []models.SkippedDay{skipped, repeat}
+
+	issues := CheckUser(t.Context(), user, program.NewCatalog([]*models.Program{withChinups}))
+	assert.Equal(t, []string{
+		"workout on 2024-03-04 is stored twice",
+		"workouts on 2024-03-06 and 2024-03-08 share the ID " + second.ID.String(),
+		"day 2 skipped on 2024-03-13 shares its ID with another skipped day",
+	}, problems(issues))
+
+	for _, issue := range issues {
+		require.True(t, issue.Fixable())
+		require.NoError(t, issue.Fix())
+	}
+	require.Len(t, user.WorkoutHistory, 3)
+	assert.Equal(t, first, user.WorkoutHistory[0])
+	assert.Equal(t, second, user.WorkoutHistory[1])
+	assert.NotEqual(t, second.ID, user.WorkoutHistory[2].ID)
+	assert.Equal(t, clash.EnteredAt, user.WorkoutHistory[2].EnteredAt)
+	assert.Equal(t, skipped, user.SkippedDays[0])
+	assert.NotEqual(t, skipped.ID, user.SkippedDays[1].ID)
+
+	assert.Empty(t, CheckUser(t.Context(), user, program.NewCatalog([]*models.Program{withChinups})))
+}
@@ -0,0 +1,63 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/repository"
+)
+
+// CheckFiles returns the problems with the data directory's files: user files
+// that can't be read, user files that share an ID, and a current user without
+// a user file. Fixing an issue changes the files.
+func CheckFiles(files []repository.UserFile, currentUser string) []Issue {
+	var issues []Issue
+
+	for _, file := range files {
+		if file.Err == nil {
+			continue
+		}
+		issue := Issue{Problem: fmt.Sprintf("%s can't be read: %v", filepath.Base(file.Path), file.Err)}
+		if file.BackupValid {
+			issue.Repair = "restore it from its backup"
+			issue.fix = func() error { return repository.RestoreUserBackup(file.Path) }
+		}
+		issues = append(issues, issue)
+	}
+
+	owners := map[uuid.UUID]string{}
+	for _, file := range files {
+		if file.Username == "" {
+			continue
+		}
+		if owner, exists := owners[file.ID]; exists {
+			issues = append(issues, Issue{
+				Problem: fmt.Sprintf("%s and %s share the user ID %s, so they share workout history", owner, file.Username, file.ID),
+			})
+			continue
+		}
+		owners[file.ID] = file.Username
+	}
+
+	if currentUser != "" && !hasUserFile(files, currentUser) {
+		issues = append(issues, Issue{
+			Problem: fmt.Sprintf("the current user %s doesn't exist", currentUser),
+			Repair:  "clear the current user; switch to another with 'greyskull user switch'",
+			fix:     repository.ClearCurrentUser,
+		})
+	}
+	return issues
+}
+
+// hasUserFile reports whether files include the file for username, whether or
+// not it can be read
+func hasUserFile(files []repository.UserFile, username string) bool {
+	for _, file := range files {
+		if filepath.Base(file.Path) == strings.ToLower(username)+".json" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dataDir, err := repository.DataDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "current_user.txt"), []byte("Dave"), 0644))
+
+	shared := uuid.New()
+	files := []repository.UserFile{
+		{Path: "/data/users/alice.json", Username: "Alice", ID: shared},
+		{Path: "/data/users/bob.json", Username: "Bob", ID: shared},
+		{Path: "/data/users/carol.json", Err: errors.New("bad JSON")},
+	}
+
+	issues := CheckFiles(files, "Dave")
+	assert.Equal(t, []string{
+		"carol.json can't be read: bad JSON",
+		"Alice and Bob share the user ID " + shared.String() + ", so they share workout history",
+		"the current user Dave doesn't exist",
+	}, problems(issues))
+	assert.False(t, issues[0].Fixable())
+	assert.False(t, issues[1].Fixable())
+	assert.True(t, issues[2].Fixable())
+
+	require.NoError(t, issues[2].Fix())
+	current, err := repository.StoredCurrentUser()
+	require.NoError(t, err)
+	assert.Empty(t, current)
+
+	// A current user whose file can't be read still exists
+	assert.Empty(t, CheckFiles(files[:1], "alice"))
+	assert.Len(t, CheckFiles(files[2:], "carol"), 1)
+
+	files[2].BackupValid = true
+	issues = CheckFiles(files[2:], "")
+	require.Len(t, issues, 1)
+	assert.Equal(t, "restore it from its backup", issues[0].Repair)
+}
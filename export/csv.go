@@ -0,0 +1,72 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// DateFormat is the format of the date column
+const DateFormat = "2006-01-02"
+
+// CSVHeader is the header row written before any set rows
+var CSVHeader = []string{"date", "day", "lift", "variant", "set_type", "weight", "target_reps", "actual_reps", "notes"}
+
+// Filter restricts which sets are exported
+type Filter struct {
+	// Lift limits the export to one lift, including its variants; empty exports every lift
+	Lift models.LiftName
+}
+
+// Matches reports whether a lift passes the filter
+func (f Filter) Matches(lift *models.Lift) bool {
+	return f.Lift == "" || lift.LiftName == f.Lift
+}
+
+// WriteCSV writes one row per set in workouts, in the order given, and returns
+// the number of set rows written. A header row is always written, even when
+// no sets match the filter. A workout's notes are written on its first row
+// only, quoted when they span several lines.
+func WriteCSV(w io.Writer, workouts []models.Workout, filter Filter) (int, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return 0, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	rows := 0
+	for _, workout := range workouts {
+		notes := workout.Notes
+		for _, lift := range workout.Exercises {
+			if !filter.Matches(&lift) {
+				continue
+			}
+			for _, set := range lift.Sets {
+				record := []string{
+					workout.EnteredAt.Format(DateFormat),
+					strconv.Itoa(workout.Day),
+					string(lift.LiftName),
+					lift.Variant,
+					string(set.Type),
+					strconv.FormatFloat(set.Weight, 'f', -1, 64),
+					strconv.Itoa(set.TargetReps),
+					strconv.Itoa(set.ActualReps),
+					notes,
+				}
+				notes = ""
+				if err := writer.Write(record); err != nil {
+					return rows, fmt.Errorf("failed to write CSV row: %w", err)
+				}
+				rows++
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return rows, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleWorkouts() []models.Workout {
+	return []models.Workout{
+		{
+			Day:       1,
+			EnteredAt: time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC),
+			Exercises: []models.Lift{
+				{
+					LiftName: models.OverheadPress,
+					Sets: []models.Set{
+						{Weight: 45, TargetReps: 5, ActualReps: 5, Type: models.WarmupSet},
+						{Weight: 97.5, TargetReps: 5, ActualReps: 5, Type: models.WorkingSet},
+						{Weight: 97.5, TargetReps: 5, ActualReps: 8, Type: models.AMRAPSet},
+					},
+				},
+				{
+					LiftName: models.Squat,
+					Variant:  "SSB",
+					Sets: []models.Set{
+						{Weight: 135, TargetReps: 5, ActualReps: 7, Type: models.AMRAPSet},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows, err := WriteCSV(&buf, sampleWorkouts(), Filter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, rows)
+	assert.Equal(t, "date,day,lift,variant,set_type,weight,target_reps,actual_reps,notes\n"+
+		"2024-05-01,1,OverheadPress,,WarmupSet,45,5,5,\n"+
+		"2024-05-01,1,OverheadPress,,WorkingSet,97.5,5,5,\n"+
+		"2024-05-01,1,OverheadPress,,AMRAPSet,97.5,5,8,\n"+
+		"2024-05-01,1,Squat,SSB,AMRAPSet,135,5,7,\n", buf.String())
+}
+
+func TestWriteCSV_Notes(t *testing.T) {
+	workouts := sampleWorkouts()
+	workouts[0].Notes = "Shoulder felt tight\nUse a wider grip"
+
+	var buf bytes.Buffer
+	_, err := WriteCSV(&buf, workouts, Filter{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "2024-05-01,1,OverheadPress,,WarmupSet,45,5,5,\"Shoulder felt tight\nUse a wider grip\"\n"+
+		"2024-05-01,1,OverheadPress,,WorkingSet,97.5,5,5,\n")
+
+	// Notes move to the first row that passes the filter
+	buf.Reset()
+	_, err = WriteCSV(&buf, workouts, Filter{Lift: models.Squat})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "2024-05-01,1,Squat,SSB,AMRAPSet,135,5,7,\"Shoulder felt tight\nUse a wider grip\"\n")
+}
+
+func TestWriteCSV_LiftFilter(t *testing.T) {
+	var buf bytes.Buffer
+	rows, err := WriteCSV(&buf, sampleWorkouts(), Filter{Lift: models.Squat})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, rows)
+	assert.Equal(t, "date,day,lift,variant,set_type,weight,target_reps,actual_reps,notes\n"+
+		"2024-05-01,1,Squat,SSB,AMRAPSet,135,5,7,\n", buf.String())
+}
+
+func TestWriteCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	rows, err := WriteCSV(&buf, nil, Filter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, rows)
+	assert.Equal(t, "date,day,lift,variant,set_type,weight,target_reps,actual_reps,notes\n", buf.String())
+}
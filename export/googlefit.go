@@ -0,0 +1,180 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// googleFitStrengthTraining is Google Fit's activity type for strength training
+const googleFitStrengthTraining = 80
+
+// googleFitExerciseType is the data type of a single set
+const googleFitExerciseType = "com.google.activity.exercise"
+
+// Google Fit's resistance types
+const (
+	googleFitBarbell = 1
+	googleFitBody    = 6
+)
+
+// googleFitExercises maps the built-in lifts to Google Fit's exercise names.
+// Other lifts use their name in snake case.
+var googleFitExercises = map[models.LiftName]string{
+	models.Squat:         "back_squat",
+	models.Deadlift:      "deadlift",
+	models.BenchPress:    "bench_press",
+	models.OverheadPress: "shoulder_press",
+}
+
+type googleFitExport struct {
+	Sessions   []googleFitSession  `json:"session"`
+	DataSource googleFitDataSource `json:"dataSource"`
+	Points     []googleFitPoint    `json:"point"`
+}
+
+type googleFitApplication struct {
+	Name string `json:"name"`
+}
+
+type googleFitSession struct {
+	ID              string               `json:"id"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description,omitempty"`
+	StartTimeMillis int64                `json:"startTimeMillis,string"`
+	EndTimeMillis   int64                `json:"endTimeMillis,string"`
+	ActivityType    int                  `json:"activityType"`
+	Application     googleFitApplication `json:"application"`
+}
+
+type googleFitDataSource struct {
+	Type           string               `json:"type"`
+	DataStreamName string               `json:"dataStreamName"`
+	DataType       googleFitDataType    `json:"dataType"`
+	Application    googleFitApplication `json:"application"`
+}
+
+type googleFitDataType struct {
+	Name string `json:"name"`
+}
+
+type googleFitPoint struct {
+	DataTypeName   string           `json:"dataTypeName"`
+	StartTimeNanos int64            `json:"startTimeNanos,string"`
+	EndTimeNanos   int64            `json:"endTimeNanos,string"`
+	Value          []googleFitValue `json:"value"`
+}
+
+// googleFitValue is one field of a data point; unset optional fields are empty
+type googleFitValue struct {
+	IntVal    *int     `json:"intVal,omitempty"`
+	FpVal     *float64 `json:"fpVal,omitempty"`
+	StringVal *string  `json:"stringVal,omitempty"`
+}
+
+// WriteGoogleFit writes workouts as Google Fit strength training sessions,
+// with each performed set as a com.google.activity.exercise data point, in
+// the JSON of the Fitness REST API's resources, and returns the number of
+// workouts written. Sets are spread evenly over their session, and weights
+// are converted to kilograms.
+func WriteGoogleFit(w io.Writer, workouts []models.Workout, opts HealthOptions) (int, error) {
+	app := googleFitApplication{Name: SourceName}
+	data := googleFitExport{
+		Sessions: []googleFitSession{},
+		DataSource: googleFitDataSource{
+			Type:           "raw",
+			DataStreamName: SourceName,
+			DataType:       googleFitDataType{Name: googleFitExerciseType},
+			Application:    app,
+		},
+		Points: []googleFitPoint{},
+	}
+
+	for _, s := range opts.sessions(workouts) {
+		data.Sessions = append(data.Sessions, googleFitSession{
+			ID:              SourceName + "-" + s.workout.ID.String(),
+			Name:            s.name,
+			Description:     s.workout.Notes,
+			StartTimeMillis: s.start.UnixMilli(),
+			EndTimeMillis:   s.end.UnixMilli(),
+			ActivityType:    googleFitStrengthTraining,
+			Application:     app,
+		})
+		data.Points = append(data.Points, googleFitPoints(s)...)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return 0, fmt.Errorf("failed to write Google Fit export: %w", err)
+	}
+	return len(data.Sessions), nil
+}
+
+// googleFitPoints returns a data point for each set performed in a session
+func googleFitPoints(s session) []googleFitPoint {
+	type performed struct {
+		lift models.Lift
+		set  models.Set
+	}
+	var sets []performed
+	for _, lift := range s.workout.Exercises {
+		for _, set := range performedSets(lift) {
+			sets = append(sets, performed{lift, set})
+		}
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	slot := s.end.Sub(s.start) / time.Duration(len(sets))
+	points := make([]googleFitPoint, 0, len(sets))
+	for i, p := range sets {
+		start := s.start.Add(time.Duration(i) * slot)
+		exercise := googleFitExercise(p.lift.LiftName)
+		reps := p.set.ActualReps
+		resistanceType := googleFitBarbell
+		if p.lift.Bodyweight {
+			resistanceType = googleFitBody
+		}
+		duration := int(slot.Milliseconds())
+
+		// Resistance is the load in kilograms; bodyweight sets without added
+		// weight have none
+		resistance := googleFitValue{}
+		if !p.lift.Bodyweight || p.set.Weight > 0 {
+			kilograms := math.Round(models.ConvertWeight(p.set.Weight, s.unit, models.Kilograms)*100) / 100
+			resistance.FpVal = &kilograms
+		}
+
+		points = append(points, googleFitPoint{
+			DataTypeName:   googleFitExerciseType,
+			StartTimeNanos: start.UnixNano(),
+			EndTimeNanos:   start.Add(slot).UnixNano(),
+			Value: []googleFitValue{
+				{StringVal: &exercise},
+				{IntVal: &reps},
+				{IntVal: &resistanceType},
+				resistance,
+				{IntVal: &duration},
+			},
+		})
+	}
+	return points
+}
+
+var wordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// googleFitExercise returns Google Fit's name for a lift
+func googleFitExercise(lift models.LiftName) string {
+	if exercise, ok := googleFitExercises[lift]; ok {
+		return exercise
+	}
+	return strings.ToLower(wordBoundary.ReplaceAllString(string(lift), "${1}_${2}"))
+}
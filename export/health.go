@@ -0,0 +1,76 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// SourceName identifies greyskull as the app that recorded exported sessions
+const SourceName = "greyskull"
+
+// ProgramInfo is what health exports need to know about the user program a
+// workout belongs to
+type ProgramInfo struct {
+	Name string
+	Unit models.WeightUnit
+}
+
+// HealthOptions controls how workouts are converted into health app sessions
+type HealthOptions struct {
+	// Duration is how long each workout is taken to have lasted. Workouts
+	// record when they were logged, not when they began, so each session
+	// ends when its workout was logged.
+	Duration time.Duration
+
+	// Programs describes the user programs workouts belong to, by ID. A
+	// workout whose program is missing, such as one logged outside any
+	// program, is named by its day alone and has its weights in pounds.
+	Programs map[uuid.UUID]ProgramInfo
+}
+
+// session is a workout placed in time, with the details every format shares
+type session struct {
+	workout models.Workout
+	name    string
+	unit    models.WeightUnit
+	start   time.Time
+	end     time.Time
+}
+
+// sessions converts workouts into sessions, in the order given
+func (o HealthOptions) sessions(workouts []models.Workout) []session {
+	sessions := make([]session, 0, len(workouts))
+	for _, w := range workouts {
+		name := fmt.Sprintf("Day %d", w.Day)
+		info, exists := o.Programs[w.UserProgramID]
+		switch {
+		case w.AdHoc:
+			name = "Extra workout"
+		case exists && info.Name != "":
+			name = info.Name + ": " + name
+		}
+		sessions = append(sessions, session{
+			workout: w,
+			name:    name,
+			unit:    info.Unit.OrDefault(),
+			start:   w.EnteredAt.Add(-o.Duration),
+			end:     w.EnteredAt,
+		})
+	}
+	return sessions
+}
+
+// performedSets returns a lift's sets that were actually performed, warmups
+// included
+func performedSets(lift models.Lift) []models.Set {
+	var sets []models.Set
+	for _, set := range lift.Sets {
+		if set.ActualReps > 0 {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	healthProgramID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	healthWorkoutID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+func healthWorkouts() []models.Workout {
+	workouts := sampleWorkouts()
+	workouts[0].ID = healthWorkoutID
+	workouts[0].UserProgramID = healthProgramID
+	workouts[0].Notes = "Felt strong"
+	workouts[0].Exercises = append(workouts[0].Exercises, models.Lift{
+		LiftName:   "ChinUp",
+		Bodyweight: true,
+		Sets:       []models.Set{{Weight: 0, TargetReps: 8, ActualReps: 8, Type: models.AMRAPSet}},
+	}, models.Lift{
+		LiftName: models.Deadlift,
+		Sets:     []models.Set{{Weight: 185, TargetReps: 5, Type: models.AMRAPSet}},
+	})
+	return workouts
+}
+
+func healthOptions(unit models.WeightUnit) HealthOptions {
+	return HealthOptions{
+		Duration: time.Hour,
+		Programs: map[uuid.UUID]ProgramInfo{healthProgramID: {Name: "OG Greyskull LP", Unit: unit}},
+	}
+}
+
+func TestWriteHealthKit(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := WriteHealthKit(&buf, healthWorkouts(), healthOptions(models.Pounds))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<HealthData locale="en_US">
+ <Workout workoutActivityType="HKWorkoutActivityTypeTraditionalStrengthTraining" duration="60" durationUnit="min" sourceName="greyskull" creationDate="2024-05-01 18:00:00 +0000" startDate="2024-05-01 17:00:00 +0000" endDate="2024-05-01 18:00:00 +0000">
+  <MetadataEntry key="HKExternalUUID" value="22222222-2222-2222-2222-222222222222"></MetadataEntry>
+  <MetadataEntry key="HKIndoorWorkout" value="1"></MetadataEntry>
+  <MetadataEntry key="GreyskullWorkout" value="OG Greyskull LP: Day 1"></MetadataEntry>
+  <MetadataEntry key="GreyskullLifts" value="Overhead Press, Squat (SSB), ChinUp"></MetadataEntry>
+  <MetadataEntry key="GreyskullNotes" value="Felt strong"></MetadataEntry>
+ </Workout>
+</HealthData>
+`, buf.String())
+}
+
+func TestWriteHealthKit_NoWorkouts(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := WriteHealthKit(&buf, nil, healthOptions(models.Pounds))
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, count)
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<HealthData locale=\"en_US\"></HealthData>\n", buf.String())
+}
+
+func TestWriteGoogleFit(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := WriteGoogleFit(&buf, healthWorkouts(), healthOptions(models.Pounds))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var data struct {
+		Session []struct {
+			ID              string `json:"id"`
+			Name            string `json:"name"`
+			Description     string `json:"description"`
+			StartTimeMillis string `json:"startTimeMillis"`
+			EndTimeMillis   string `json:"endTimeMillis"`
+			ActivityType    int    `json:"activityType"`
+		} `json:"session"`
+		DataSource struct {
+			DataType struct {
+				Name string `json:"name"`
+			} `json:"dataType"`
+		} `json:"dataSource"`
+		Point []struct {
+			StartTimeNanos string           `json:"startTimeNanos"`
+			EndTimeNanos   string           `json:"endTimeNanos"`
+			Value          []map[string]any `json:"value"`
+		} `json:"point"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+
+	require.Len(t, data.Session, 1)
+	session := data.Session[0]
+	assert.Equal(t, "greyskull-22222222-2222-2222-2222-222222222222", session.ID)
+	assert.Equal(t, "OG Greyskull LP: Day 1", session.Name)
+	assert.Equal(t, "Felt strong", session.Description)
+	assert.Equal(t, "1714582800000", session.StartTimeMillis)
+	assert.Equal(t, "1714586400000", session.EndTimeMillis)
+	assert.Equal(t, 80, session.ActivityType)
+	assert.Equal(t, "com.google.activity.exercise", data.DataSource.DataType.Name)
+
+	// Three overhead press sets, one squat set and one chin-up set; the
+	// deadlift wasn't performed
+	require.Len(t, data.Point, 5)
+	assert.Equal(t, "1714582800000000000", data.Point[0].StartTimeNanos)
+	assert.Equal(t, "1714583520000000000", data.Point[0].EndTimeNanos)
+	assert.Equal(t, []map[string]any{
+		{"stringVal": "shoulder_press"},
+		{"intVal": 5.0},
+		{"intVal": 1.0},
+		{"fpVal": 20.41},
+		{"intVal": 720000.0},
+	}, data.Point[0].Value)
+	assert.Equal(t, "back_squat", data.Point[3].Value[0]["stringVal"])
+	assert.Equal(t, []map[string]any{
+		{"stringVal": "chin_up"},
+		{"intVal": 8.0},
+		{"intVal": 6.0},
+		{},
+		{"intVal": 720000.0},
+	}, data.Point[4].Value)
+}
+
+func TestWriteGoogleFit_Kilograms(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := WriteGoogleFit(&buf, healthWorkouts(), healthOptions(models.Kilograms))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"fpVal": 97.5`)
+
+	// Without its program, a workout is named by its day and in pounds
+	buf.Reset()
+	_, err = WriteGoogleFit(&buf, healthWorkouts(), HealthOptions{Duration: time.Hour})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"name": "Day 1"`)
+	assert.Contains(t, buf.String(), `"fpVal": 44.23`)
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// healthKitDateFormat is the date format of Apple Health's export.xml
+const healthKitDateFormat = "2006-01-02 15:04:05 -0700"
+
+// healthKitStrengthTraining is HealthKit's activity type for weight training
+const healthKitStrengthTraining = "HKWorkoutActivityTypeTraditionalStrengthTraining"
+
+type healthData struct {
+	XMLName  xml.Name           `xml:"HealthData"`
+	Locale   string             `xml:"locale,attr"`
+	Workouts []healthKitWorkout `xml:"Workout"`
+}
+
+type healthKitWorkout struct {
+	ActivityType string              `xml:"workoutActivityType,attr"`
+	Duration     string              `xml:"duration,attr"`
+	DurationUnit string              `xml:"durationUnit,attr"`
+	SourceName   string              `xml:"sourceName,attr"`
+	CreationDate string              `xml:"creationDate,attr"`
+	StartDate    string              `xml:"startDate,attr"`
+	EndDate      string              `xml:"endDate,attr"`
+	Metadata     []healthKitMetadata `xml:"MetadataEntry"`
+}
+
+type healthKitMetadata struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteHealthKit writes workouts as traditional strength training workouts in
+// the format of Apple Health's export.xml, which health data import apps read,
+// and returns the number of workouts written. HealthKit records no sets, so
+// each workout's lifts, program and notes are kept as metadata.
+func WriteHealthKit(w io.Writer, workouts []models.Workout, opts HealthOptions) (int, error) {
+	data := healthData{Locale: "en_US"}
+	for _, s := range opts.sessions(workouts) {
+		metadata := []healthKitMetadata{
+			{Key: "HKExternalUUID", Value: s.workout.ID.String()},
+			{Key: "HKIndoorWorkout", Value: "1"},
+			{Key: "GreyskullWorkout", Value: s.name},
+			{Key: "GreyskullLifts", Value: strings.Join(liftNames(s.workout), ", ")},
+		}
+		if s.workout.Notes != "" {
+			metadata = append(metadata, healthKitMetadata{Key: "GreyskullNotes", Value: s.workout.Notes})
+		}
+
+		data.Workouts = append(data.Workouts, healthKitWorkout{
+			ActivityType: healthKitStrengthTraining,
+			Duration:     strconv.FormatFloat(s.end.Sub(s.start).Minutes(), 'f', -1, 64),
+			DurationUnit: "min",
+			SourceName:   SourceName,
+			CreationDate: s.end.Format(healthKitDateFormat),
+			StartDate:    s.start.Format(healthKitDateFormat),
+			EndDate:      s.end.Format(healthKitDateFormat),
+			Metadata:     metadata,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, fmt.Errorf("failed to write HealthKit export: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", " ")
+	if err := encoder.Encode(data); err != nil {
+		return 0, fmt.Errorf("failed to write HealthKit export: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return 0, fmt.Errorf("failed to write HealthKit export: %w", err)
+	}
+	return len(data.Workouts), nil
+}
+
+// liftNames lists the display names of a workout's lifts that had sets
+// performed, with their variants
+func liftNames(workout models.Workout) []string {
+	var names []string
+	for _, lift := range workout.Exercises {
+		if len(performedSets(lift)) == 0 {
+			continue
+		}
+		name := string(lift.LiftName)
+		if def, ok := models.LookupLift(lift.LiftName); ok {
+			name = def.DisplayName
+		}
+		if lift.Variant != "" {
+			name += " (" + lift.Variant + ")"
+		}
+		names = append(names, name)
+	}
+	return names
+}
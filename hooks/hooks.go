@@ -0,0 +1,79 @@
+// Package hooks runs the shell commands and web requests configured to follow
+// greyskull events, such as logging a workout
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Timeout is how long a single hook may run before it is abandoned
+const Timeout = 30 * time.Second
+
+// PostLog is the event sent to hooks after a workout is logged
+const PostLog = "post_log"
+
+// Client sends the requests of URL hooks
+var Client = &http.Client{Timeout: Timeout}
+
+// Run runs a hook for an event. A URL hook is sent payload as JSON in a POST
+// request, which must succeed with a 2xx status. Any other hook is run as a
+// shell command with payload on its standard input and the event name in
+// GREYSKULL_EVENT, and must exit successfully.
+func Run(hook, event string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	if models.IsURLHook(hook) {
+		return post(ctx, hook, event, payload)
+	}
+	return runCommand(ctx, hook, event, payload)
+}
+
+func post(ctx context.Context, url, event string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Greyskull-Event", event)
+
+	resp, err := Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("request failed: %s", resp.Status)
+	}
+	return nil
+}
+
+func runCommand(ctx context.Context, command, event string, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "GREYSKULL_EVENT="+event)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("command failed: %w: %s", err, bytes.TrimSpace(output))
+		}
+		return fmt.Errorf("command failed: %w", err)
+	}
+	return nil
+}
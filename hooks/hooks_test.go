@@ -0,0 +1,48 @@
+package hooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_URL(t *testing.T) {
+	var body []byte
+	var contentType, event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+		event = r.Header.Get("X-Greyskull-Event")
+		if r.URL.Path == "/broken" {
+			http.Error(w, "nope", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, Run(server.URL+"/hook", PostLog, []byte(`{"day":1}`)))
+	assert.Equal(t, `{"day":1}`, string(body))
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "post_log", event)
+
+	err := Run(server.URL+"/broken", PostLog, []byte(`{}`))
+	assert.EqualError(t, err, "request failed: 500 Internal Server Error")
+}
+
+func TestRun_Command(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+
+	require.NoError(t, Run(`cat > `+out+` && echo " $GREYSKULL_EVENT" >> `+out, PostLog, []byte(`{"day":1}`)))
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"day\":1} post_log\n", string(data))
+
+	err = Run("echo oops >&2; exit 3", PostLog, nil)
+	assert.EqualError(t, err, "command failed: exit status 3: oops")
+}
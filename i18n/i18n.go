@@ -0,0 +1,125 @@
+// Package i18n translates the messages greyskull shows into the user's
+// language. Messages are looked up by their English text, so code keeps its
+// English format strings, and a message with no translation in a locale's
+// catalog is shown in English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// English is the locale messages are written in, and the default
+const English = "en"
+
+// localeFiles holds a catalog for each locale other than English, named after
+// the locale, mapping English messages to their translations
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs are the translations of each locale other than English
+var catalogs = loadCatalogs()
+
+// current is the locale messages are translated into
+var current = English
+
+func loadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read message catalogs: %v", err))
+	}
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read message catalog %s: %v", entry.Name(), err))
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("failed to parse message catalog %s: %v", entry.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(entry.Name(), ".json")] = catalog
+	}
+	return catalogs
+}
+
+// Locales lists the supported locales, English first
+func Locales() []string {
+	locales := []string{English}
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales[1:])
+	return locales
+}
+
+// ParseLocale converts a locale such as "es", "es-MX", or "es_ES.UTF-8" into
+// a supported locale. The POSIX locales "C" and "POSIX" are English.
+func ParseLocale(input string) (string, error) {
+	locale := strings.TrimSpace(input)
+	if locale == "C" || locale == "POSIX" || strings.HasPrefix(locale, "C.") {
+		return English, nil
+	}
+	language, _, _ := strings.Cut(locale, ".")
+	language, _, _ = strings.Cut(language, "@")
+	language, _, _ = strings.Cut(strings.ReplaceAll(language, "-", "_"), "_")
+	language = strings.ToLower(language)
+	if language == English {
+		return English, nil
+	}
+	if _, ok := catalogs[language]; ok && language != "" {
+		return language, nil
+	}
+	return "", fmt.Errorf("unsupported locale %q (expected one of %s)", input, strings.Join(Locales(), ", "))
+}
+
+// FromEnv returns the locale named by the environment: the first of LC_ALL,
+// LC_MESSAGES, and LANG that is set. A locale greyskull doesn't support falls
+// back to English.
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		locale, err := ParseLocale(value)
+		if err != nil {
+			return English
+		}
+		return locale
+	}
+	return English
+}
+
+// SetLocale translates messages into a supported locale from now on; any
+// other locale shows them in English
+func SetLocale(locale string) {
+	if _, ok := catalogs[locale]; !ok {
+		locale = English
+	}
+	current = locale
+}
+
+// Locale returns the locale messages are translated into
+func Locale() string {
+	return current
+}
+
+// T translates a message into the current locale
+func T(message string) string {
+	if translated, ok := catalogs[current][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf formats according to the translation of format into the current locale
+func Sprintf(format string, a ...any) string {
+	return fmt.Sprintf(T(format), a...)
+}
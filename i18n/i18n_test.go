@@ -0,0 +1,231 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"en", English},
+		{"es", "es"},
+		{" ES ", "es"},
+		{"es-MX", "es"},
+		{"es_ES.UTF-8", "es"},
+		{"es_ES@euro", "es"},
+		{"en_US.UTF-8", English},
+		{"C", English},
+		{"C.UTF-8", English},
+		{"POSIX", English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			locale, err := ParseLocale(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locale)
+		})
+	}
+
+	for _, input := range []string{"", "xx", "klingon_KL"} {
+		_, err := ParseLocale(input)
+		assert.ErrorContains(t, err, "unsupported locale", input)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+	assert.Equal(t, English, FromEnv())
+
+	t.Setenv("LANG", "es_ES.UTF-8")
+	assert.Equal(t, "es", FromEnv())
+
+	// LC_ALL and LC_MESSAGES take precedence over LANG
+	t.Setenv("LC_MESSAGES", "en_GB.UTF-8")
+	assert.Equal(t, English, FromEnv())
+	t.Setenv("LC_ALL", "es_MX.UTF-8")
+	assert.Equal(t, "es", FromEnv())
+
+	// Unsupported locales fall back to English
+	t.Setenv("LC_ALL", "ja_JP.UTF-8")
+	assert.Equal(t, English, FromEnv())
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLocale(English) })
+
+	assert.Equal(t, "Day %d Workout:\n", T("Day %d Workout:\n"))
+
+	SetLocale("es")
+	assert.Equal(t, "es", Locale())
+	assert.Equal(t, "Entrenamiento del día %d:\n", T("Day %d Workout:\n"))
+	assert.Equal(t, "Entrenamiento del día 3:\n", Sprintf("Day %d Workout:\n", 3))
+
+	// Messages without a translation are shown in English
+	assert.Equal(t, "Not in any catalog %d", T("Not in any catalog %d"))
+
+	SetLocale("xx")
+	assert.Equal(t, English, Locale())
+}
+
+func TestLocales(t *testing.T) {
+	locales := Locales()
+	assert.Equal(t, English, locales[0])
+	assert.Contains(t, locales, "es")
+}
+
+// formatVerbs matches the verbs of a format string, without their flags
+var formatVerbs = regexp.MustCompile(`%[-+# 0]*[0-9*]*(?:\.[0-9]+)?([a-zA-Z%])`)
+
+// TestCatalogs checks that every translation takes the same arguments as its
+// English message, so translating never misformats a message
+func TestCatalogs(t *testing.T) {
+	verbs := func(format string) []string {
+		var found []string
+		for _, match := range formatVerbs.FindAllStringSubmatch(format, -1) {
+			if match[1] != "%" {
+				found = append(found, match[0])
+			}
+		}
+		return found
+	}
+
+	for locale, catalog := range catalogs {
+		assert.NotEmpty(t, catalog, locale)
+		for message, translated := range catalog {
+			assert.NotEmpty(t, translated, "%s: %q", locale, message)
+			assert.True(t, slices.Equal(verbs(message), verbs(translated)),
+				"%s: %q takes different arguments than %q", locale, translated, message)
+		}
+	}
+}
+
+// routedCalls are the functions that translate their arguments at the
+// indexes given: the i18n helpers themselves, the cmd package's printf and
+// fprintf and input prompts, and the display formatters' Printf methods and
+// pluralize
+var routedCalls = map[string][]int{
+	"T":                  {0},
+	"Sprintf":            {0},
+	"printf":             {1},
+	"fprintf":            {1},
+	"Printf":             {0},
+	"pluralize":          {1, 2},
+	"ReadLine":           {0},
+	"ReadFloat":          {0},
+	"ReadInt":            {0},
+	"ReadPositiveFloat":  {0},
+	"ReadPositiveInt":    {0},
+	"ReadNonNegativeInt": {0},
+	"ReadConfirm":        {0},
+	"ReadMultiLine":      {0},
+}
+
+// routedMessages collects the literal messages passed to routedCalls in the
+// non-test Go files under dir
+func routedMessages(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	messages := make(map[string]string)
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fn := call.Fun.(type) {
+			case *ast.Ident:
+				name = fn.Name
+			case *ast.SelectorExpr:
+				// fmt.Sprintf and fmt.Printf don't translate
+				if pkg, ok := fn.X.(*ast.Ident); ok && pkg.Name == "fmt" {
+					return true
+				}
+				name = fn.Sel.Name
+			}
+			for _, index := range routedCalls[name] {
+				if index >= len(call.Args) {
+					continue
+				}
+				message, ok := stringConstant(call.Args[index])
+				if ok && hasWords(message) {
+					messages[message] = fset.Position(call.Pos()).String()
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	return messages
+}
+
+// hasWords reports whether a message has anything to translate besides its
+// verbs, unlike "  %s: %s\n"
+func hasWords(message string) bool {
+	return strings.ContainsFunc(formatVerbs.ReplaceAllString(message, ""), unicode.IsLetter)
+}
+
+// stringConstant returns the value of a string literal, or of literals joined
+// with +
+func stringConstant(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, ok := stringConstant(e.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := stringConstant(e.Y)
+		return x + y, ok
+	case *ast.ParenExpr:
+		return stringConstant(e.X)
+	}
+	return "", false
+}
+
+// TestCatalogsCoverRoutedMessages checks that every message passed through
+// the translation helpers has a translation in every catalog
+func TestCatalogsCoverRoutedMessages(t *testing.T) {
+	messages := routedMessages(t, "..")
+	require.NotEmpty(t, messages)
+
+	for locale, catalog := range catalogs {
+		for message, position := range messages {
+			_, ok := catalog[message]
+			assert.True(t, ok, "%s: %q (%s) has no translation", locale, message, position)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package importer reads workout history exported from greyskull or other
+// trackers. Both supported formats share one schema, with one record per set:
+//
+//	date         YYYY-MM-DD or an RFC 3339 timestamp
+//	day          program day the workout was for, starting at 1
+//	lift         squat, deadlift, bench, or ohp (any name accepted by models.ParseLiftName)
+//	variant      optional bar or implement variant, e.g. "SSB"
+//	set_type     warmup, working, amrap, or feeler (the set type names written by export are also accepted)
+//	weight       weight in lbs
+//	target_reps  prescribed reps
+//	actual_reps  completed reps
+//	notes        optional workout notes; the first non-empty value in a workout is used
+//
+// CSV files have a header row naming these columns in any order; the variant
+// and notes columns may be omitted. JSON files hold an array of objects with these keys.
+// The CSV written by "greyskull export csv" can be imported as-is.
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Row is a single set record in the import schema
+type Row struct {
+	// Line identifies the record in error messages: the line number in a CSV
+	// file, or the 1-based array index in a JSON file
+	Line int `json:"-"`
+
+	Date       string  `json:"date"`
+	Day        int     `json:"day"`
+	Lift       string  `json:"lift"`
+	Variant    string  `json:"variant"`
+	SetType    string  `json:"set_type"`
+	Weight     float64 `json:"weight"`
+	TargetReps int     `json:"target_reps"`
+	ActualReps int     `json:"actual_reps"`
+	Notes      string  `json:"notes"`
+}
+
+// RowError is a problem with a single record
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Line, e.Err)
+}
+
+// ValidationError collects every invalid record found in an import
+type ValidationError struct {
+	Errors []RowError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("%d invalid rows", len(e.Errors))
+}
+
+// add records an error for a row
+func (e *ValidationError) add(line int, format string, a ...any) {
+	e.Errors = append(e.Errors, RowError{Line: line, Err: fmt.Errorf(format, a...)})
+}
+
+// errOrNil returns e if any errors were recorded, so callers get a true nil error otherwise
+func (e *ValidationError) errOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var requiredColumns = []string{"date", "day", "lift", "set_type", "weight", "target_reps", "actual_reps"}
+
+// ReadFile reads rows from a file, choosing the format by extension:
+// .json files are read as JSON and anything else as CSV
+func ReadFile(filename string) ([]Row, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		return ReadJSON(file)
+	}
+	return ReadCSV(file)
+}
+
+// ReadJSON reads rows from a JSON array of records
+func ReadJSON(r io.Reader) ([]Row, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var rows []Row
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	for i := range rows {
+		rows[i].Line = i + 1
+	}
+	return rows, nil
+}
+
+// ReadCSV reads rows from CSV with a header row. Numeric fields that can't be
+// parsed are reported together as a *ValidationError.
+func ReadCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	var missing []string
+	for _, name := range requiredColumns {
+		if _, exists := columns[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("CSV header is missing columns: %s", strings.Join(missing, ", "))
+	}
+
+	var rows []Row
+	invalid := &ValidationError{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, exists := columns[name]; exists && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := Row{
+			Line:    line,
+			Date:    field("date"),
+			Lift:    field("lift"),
+			Variant: field("variant"),
+			SetType: field("set_type"),
+			Notes:   field("notes"),
+		}
+
+		valid := true
+		parseInt := func(name string) int {
+			value, err := strconv.Atoi(field(name))
+			if err != nil {
+				invalid.add(line, "invalid %s %q: must be a whole number", name, field(name))
+				valid = false
+			}
+			return value
+		}
+		row.Day = parseInt("day")
+		row.TargetReps = parseInt("target_reps")
+		row.ActualReps = parseInt("actual_reps")
+		if row.Weight, err = strconv.ParseFloat(field("weight"), 64); err != nil {
+			invalid.add(line, "invalid weight %q: must be a number", field("weight"))
+			valid = false
+		}
+
+		if valid {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, invalid.errOrNil()
+}
@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := "lift,date,day,set_type,weight,target_reps,actual_reps\n" +
+		"squat,2024-03-04,1,amrap,135,5,8\n" +
+		"Bench Press, 2024-03-06 ,2,WorkingSet,97.5,5,5\n"
+
+	rows, err := ReadCSV(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Row{
+		{Line: 2, Date: "2024-03-04", Day: 1, Lift: "squat", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 8},
+		{Line: 3, Date: "2024-03-06", Day: 2, Lift: "Bench Press", SetType: "WorkingSet", Weight: 97.5, TargetReps: 5, ActualReps: 5},
+	}, rows)
+}
+
+func TestReadCSV_Notes(t *testing.T) {
+	input := "date,day,lift,set_type,weight,target_reps,actual_reps,notes\n" +
+		"2024-03-04,1,squat,working,135,5,5,\"Knee sore\n  better after warmups\"\n" +
+		"2024-03-04,1,squat,amrap,135,5,8,\n"
+
+	rows, err := ReadCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Knee sore\n  better after warmups", rows[0].Notes)
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Empty(t, rows[1].Notes)
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader(""))
+	assert.EqualError(t, err, "CSV file is empty")
+
+	_, err = ReadCSV(strings.NewReader("date,lift,weight\n"))
+	assert.EqualError(t, err, "CSV header is missing columns: day, set_type, target_reps, actual_reps")
+
+	input := "date,day,lift,set_type,weight,target_reps,actual_reps\n" +
+		"2024-03-04,one,squat,amrap,135,5,8\n" +
+		"2024-03-04,1,squat,amrap,135,5,8\n" +
+		"2024-03-04,1,squat,amrap,heavy,5,\n"
+	rows, err := ReadCSV(strings.NewReader(input))
+	require.Len(t, rows, 1)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{
+		`row 2: invalid day "one": must be a whole number`,
+		`row 4: invalid actual_reps "": must be a whole number`,
+		`row 4: invalid weight "heavy": must be a number`,
+	}, errorStrings(validationErr))
+	assert.EqualError(t, err, "3 invalid rows")
+}
+
+func TestReadJSON(t *testing.T) {
+	input := `[
+		{"date": "2024-03-04", "day": 1, "lift": "ohp", "set_type": "amrap", "weight": 95, "target_reps": 5, "actual_reps": 7},
+		{"date": "2024-03-04", "day": 1, "lift": "squat", "variant": "SSB", "set_type": "amrap", "weight": 135, "target_reps": 5, "actual_reps": 9}
+	]`
+
+	rows, err := ReadJSON(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, Row{Line: 2, Date: "2024-03-04", Day: 1, Lift: "squat", Variant: "SSB", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 9}, rows[1])
+
+	_, err = ReadJSON(strings.NewReader(`[{"date": "2024-03-04", "reps": 5}]`))
+	assert.ErrorContains(t, err, `failed to parse JSON: json: unknown field "reps"`)
+}
+
+func errorStrings(err *ValidationError) []string {
+	messages := make([]string, len(err.Errors))
+	for i, rowErr := range err.Errors {
+		messages[i] = rowErr.Error()
+	}
+	return messages
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// setTypeAliases maps accepted set_type values (lowercased) to set types
+var setTypeAliases = map[string]models.SetType{
+	"warmup":     models.WarmupSet,
+	"warmupset":  models.WarmupSet,
+	"working":    models.WorkingSet,
+	"workingset": models.WorkingSet,
+	"amrap":      models.AMRAPSet,
+	"amrapset":   models.AMRAPSet,
+	"feeler":     models.FeelerSet,
+	"feelerset":  models.FeelerSet,
+}
+
+// BuildWorkouts validates rows and groups them into completed workouts for a
+// UserProgram whose program has totalDays days. Rows with the same date and day
+// form one workout, and lifts and sets keep the order they appear in; the
+// first notes given for a workout are kept. Workouts
+// are returned sorted by date. Every invalid row is reported in a *ValidationError.
+func BuildWorkouts(rows []Row, userProgramID uuid.UUID, totalDays int) ([]models.Workout, error) {
+	type workoutKey struct {
+		date string
+		day  int
+	}
+
+	var workouts []*models.Workout
+	byKey := make(map[workoutKey]*models.Workout)
+	invalid := &ValidationError{}
+
+	for _, row := range rows {
+		set, enteredAt, ok := validateRow(row, totalDays, invalid)
+		if !ok {
+			continue
+		}
+		lift, _ := models.ParseLiftName(row.Lift)
+
+		key := workoutKey{date: enteredAt.Format(time.RFC3339), day: row.Day}
+		workout, exists := byKey[key]
+		if !exists {
+			workout = &models.Workout{
+				ID:            uuid.Must(uuid.NewV7()),
+				UserProgramID: userProgramID,
+				Day:           row.Day,
+				Exercises:     []models.Lift{},
+				EnteredAt:     enteredAt,
+			}
+			byKey[key] = workout
+			workouts = append(workouts, workout)
+		}
+
+		index := slices.IndexFunc(workout.Exercises, func(l models.Lift) bool {
+			return l.LiftName == lift && l.Variant == row.Variant
+		})
+		if index < 0 {
+			workout.Exercises = append(workout.Exercises, models.Lift{
+				ID:       uuid.Must(uuid.NewV7()),
+				LiftName: lift,
+				Variant:  row.Variant,
+				Sets:     []models.Set{},
+			})
+			index = len(workout.Exercises) - 1
+		}
+
+		if workout.Notes == "" {
+			workout.Notes = row.Notes
+		}
+
+		exercise := &workout.Exercises[index]
+		set.Order = len(exercise.Sets) + 1
+		exercise.Sets = append(exercise.Sets, set)
+	}
+
+	if err := invalid.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Workout, len(workouts))
+	for i, workout := range workouts {
+		result[i] = *workout
+	}
+	slices.SortStableFunc(result, func(a, b models.Workout) int {
+		return a.EnteredAt.Compare(b.EnteredAt)
+	})
+	return result, nil
+}
+
+// validateRow checks a row's values, recording every problem found, and returns
+// the set and date it describes
+func validateRow(row Row, totalDays int, invalid *ValidationError) (models.Set, time.Time, bool) {
+	before := len(invalid.Errors)
+
+	enteredAt, err := parseDate(row.Date)
+	if err != nil {
+		invalid.add(row.Line, "invalid date %q: expected YYYY-MM-DD", row.Date)
+	}
+	if row.Day < 1 || row.Day > totalDays {
+		invalid.add(row.Line, "invalid day %d: the program has days 1-%d", row.Day, totalDays)
+	}
+	if _, err := models.ParseLiftName(row.Lift); err != nil {
+		invalid.add(row.Line, "%v", err)
+	}
+	if strings.Contains(row.Variant, ":") {
+		invalid.add(row.Line, "invalid variant %q: must not contain ':'", row.Variant)
+	}
+	setType, exists := setTypeAliases[strings.ToLower(row.SetType)]
+	if !exists {
+		invalid.add(row.Line, "unknown set_type %q (expected warmup, working, amrap, or feeler)", row.SetType)
+	}
+	if row.Weight <= 0 {
+		invalid.add(row.Line, "invalid weight %v: must be positive", row.Weight)
+	}
+	if row.TargetReps <= 0 {
+		invalid.add(row.Line, "invalid target_reps %d: must be positive", row.TargetReps)
+	}
+	if row.ActualReps < 0 {
+		invalid.add(row.Line, "invalid actual_reps %d: cannot be negative", row.ActualReps)
+	}
+
+	set := models.Set{
+		ID:         uuid.Must(uuid.NewV7()),
+		Weight:     row.Weight,
+		TargetReps: row.TargetReps,
+		ActualReps: row.ActualReps,
+		Type:       setType,
+	}
+	return set, enteredAt, len(invalid.Errors) == before
+}
+
+// parseDate accepts a date (interpreted in local time) or an RFC 3339 timestamp
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildWorkouts(t *testing.T) {
+	userProgramID := uuid.New()
+	rows := []Row{
+		{Line: 2, Date: "2024-03-06", Day: 2, Lift: "bench", SetType: "working", Weight: 100, TargetReps: 5, ActualReps: 5},
+		{Line: 3, Date: "2024-03-06", Day: 2, Lift: "bench", SetType: "amrap", Weight: 100, TargetReps: 5, ActualReps: 7, Notes: "Paused reps"},
+		{Line: 4, Date: "2024-03-04", Day: 1, Lift: "ohp", SetType: "WarmupSet", Weight: 45, TargetReps: 5, ActualReps: 5},
+		{Line: 5, Date: "2024-03-04", Day: 1, Lift: "squat", Variant: "SSB", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 9},
+		{Line: 6, Date: "2024-03-04", Day: 1, Lift: "ohp", SetType: "amrap", Weight: 95, TargetReps: 5, ActualReps: 6},
+	}
+
+	workouts, err := BuildWorkouts(rows, userProgramID, 6)
+	require.NoError(t, err)
+	require.Len(t, workouts, 2)
+
+	first := workouts[0]
+	assert.Equal(t, userProgramID, first.UserProgramID)
+	assert.Equal(t, 1, first.Day)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local), first.EnteredAt)
+	require.Len(t, first.Exercises, 2)
+
+	ohp := first.Exercises[0]
+	assert.Equal(t, models.OverheadPress, ohp.LiftName)
+	require.Len(t, ohp.Sets, 2)
+	assert.Equal(t, models.WarmupSet, ohp.Sets[0].Type)
+	assert.Equal(t, models.AMRAPSet, ohp.Sets[1].Type)
+	assert.Equal(t, 2, ohp.Sets[1].Order)
+
+	assert.Equal(t, models.LiftName("Squat:SSB"), first.Exercises[1].WeightKey())
+
+	assert.Empty(t, first.Notes)
+
+	second := workouts[1]
+	assert.Equal(t, 2, second.Day)
+	assert.Equal(t, "Paused reps", second.Notes)
+	require.Len(t, second.Exercises, 1)
+	assert.Len(t, second.Exercises[0].Sets, 2)
+}
+
+func TestBuildWorkouts_ValidationErrors(t *testing.T) {
+	rows := []Row{
+		{Line: 2, Date: "2024-03-04", Day: 1, Lift: "squat", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 8},
+		{Line: 3, Date: "03/04/2024", Day: 9, Lift: "curl", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 8},
+		{Line: 4, Date: "2024-03-04", Day: 1, Lift: "squat", Variant: "a:b", SetType: "drop", Weight: 0, TargetReps: 0, ActualReps: -1},
+	}
+
+	_, err := BuildWorkouts(rows, uuid.New(), 6)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{
+		`row 3: invalid date "03/04/2024": expected YYYY-MM-DD`,
+		"row 3: invalid day 9: the program has days 1-6",
+		`row 3: unknown lift "curl" (expected squat, deadlift, bench, ohp, or a lift added with 'greyskull lift define')`,
+		`row 4: invalid variant "a:b": must not contain ':'`,
+		`row 4: unknown set_type "drop" (expected warmup, working, amrap, or feeler)`,
+		"row 4: invalid weight 0: must be positive",
+		"row 4: invalid target_reps 0: must be positive",
+		"row 4: invalid actual_reps -1: cannot be negative",
+	}, errorStrings(validationErr))
+}
+
+func TestBuildWorkouts_RFC3339Dates(t *testing.T) {
+	rows := []Row{
+		{Line: 1, Date: "2024-03-04T18:30:00Z", Day: 1, Lift: "squat", SetType: "amrap", Weight: 135, TargetReps: 5, ActualReps: 8},
+	}
+
+	workouts, err := BuildWorkouts(rows, uuid.New(), 6)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 18, 30, 0, 0, time.UTC), workouts[0].EnteredAt)
+}
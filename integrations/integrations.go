@@ -0,0 +1,120 @@
+// Package integrations sends logged workouts to fitness services such as
+// Strava, handling each service's OAuth tokens and API requests
+package integrations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Activity is a workout as fitness services record it
+type Activity struct {
+	Name        string
+	Description string
+	Start       time.Time
+	Elapsed     time.Duration
+}
+
+// Exporter uploads activities to a fitness service
+type Exporter interface {
+	// Name is the service's name, e.g. "Strava"
+	Name() string
+
+	// Push uploads an activity and returns a link to it on the service
+	Push(activity Activity) (string, error)
+}
+
+// NewActivity converts a logged workout into an activity named after its
+// program and day, or "Extra workout" for one logged outside any program.
+// Workouts record when they were logged, not how long they took, so the
+// activity is taken to have lasted elapsed and ended when the workout was
+// logged. The description lists each lift's working sets, with weights in
+// unit, then the workout's notes.
+func NewActivity(workout models.Workout, programName string, unit models.WeightUnit, elapsed time.Duration) Activity {
+	var lines []string
+	for _, lift := range workout.Exercises {
+		if sets := describeSets(lift, unit); sets != "" {
+			lines = append(lines, liftName(lift)+": "+sets)
+		}
+	}
+	if workout.Notes != "" {
+		lines = append(lines, "", workout.Notes)
+	}
+
+	name := fmt.Sprintf("%s: Day %d", programName, workout.Day)
+	if workout.AdHoc {
+		name = "Extra workout"
+	}
+	return Activity{
+		Name:        name,
+		Description: strings.Join(lines, "\n"),
+		Start:       workout.EnteredAt.Add(-elapsed),
+		Elapsed:     elapsed,
+	}
+}
+
+// describeSets lists a lift's performed sets other than warmups, grouping runs
+// of sets at the same weight, e.g. "135 lbs × 5, 5, 8". Bodyweight lifts show
+// the weight added, and optional lifts just their reps.
+func describeSets(lift models.Lift, unit models.WeightUnit) string {
+	var groups []string
+	var reps []string
+	weight := 0.0
+	flush := func() {
+		if len(reps) == 0 {
+			return
+		}
+		switch {
+		case lift.Optional:
+			groups = append(groups, strings.Join(reps, ", ")+" reps")
+		case lift.Bodyweight:
+			groups = append(groups, fmt.Sprintf("bodyweight %s%s %s × %s", sign(weight), formatWeight(weight), unit.OrDefault(), strings.Join(reps, ", ")))
+		default:
+			groups = append(groups, fmt.Sprintf("%s %s × %s", formatWeight(weight), unit.OrDefault(), strings.Join(reps, ", ")))
+		}
+		reps = nil
+	}
+
+	for _, set := range lift.Sets {
+		if set.Type == models.WarmupSet || set.ActualReps == 0 {
+			continue
+		}
+		if len(reps) > 0 && set.Weight != weight {
+			flush()
+		}
+		weight = set.Weight
+		reps = append(reps, strconv.Itoa(set.ActualReps))
+	}
+	flush()
+	return strings.Join(groups, "; ")
+}
+
+// liftName is a lift's display name, with its variant if it has one
+func liftName(lift models.Lift) string {
+	name := string(lift.LiftName)
+	if def, ok := models.LookupLift(lift.LiftName); ok {
+		name = def.DisplayName
+	}
+	if lift.Variant != "" {
+		name += " (" + lift.Variant + ")"
+	}
+	return name
+}
+
+func sign(weight float64) string {
+	if weight < 0 {
+		return "-"
+	}
+	return "+"
+}
+
+func formatWeight(weight float64) string {
+	if weight < 0 {
+		weight = -weight
+	}
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
@@ -0,0 +1,94 @@
+package integrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewActivity(t *testing.T) {
+	enteredAt := time.Date(2024, 3, 4, 18, 30, 0, 0, time.UTC)
+	workout := models.Workout{
+		Day:       2,
+		EnteredAt: enteredAt,
+		Notes:     "Felt strong",
+		Exercises: []models.Lift{
+			{LiftName: models.BenchPress, Sets: []models.Set{
+				{Weight: 45, ActualReps: 5, Type: models.WarmupSet},
+				{Weight: 125, ActualReps: 5, Type: models.WorkingSet},
+				{Weight: 125, ActualReps: 5, Type: models.WorkingSet},
+				{Weight: 125, ActualReps: 8, Type: models.AMRAPSet},
+			}},
+			{LiftName: models.Deadlift, Variant: "Sumo", Sets: []models.Set{
+				{Weight: 185, ActualReps: 5, Type: models.WorkingSet},
+				{Weight: 205, ActualReps: 3, Type: models.AMRAPSet},
+			}},
+			{LiftName: "ChinUp", Bodyweight: true, Sets: []models.Set{
+				{Weight: 10, ActualReps: 6, Type: models.WorkingSet},
+				{Weight: 10, ActualReps: 5, Type: models.AMRAPSet},
+			}},
+			{LiftName: "Curl", Optional: true, Sets: []models.Set{
+				{Weight: 0, ActualReps: 12, Type: models.WorkingSet},
+				{Weight: 0, ActualReps: 10, Type: models.WorkingSet},
+			}},
+			{LiftName: models.Squat, Sets: []models.Set{
+				{Weight: 135, ActualReps: 0, Type: models.WorkingSet},
+			}},
+		},
+	}
+
+	activity := NewActivity(workout, "Greyskull LP", models.Pounds, 45*time.Minute)
+
+	assert.Equal(t, "Greyskull LP: Day 2", activity.Name)
+	assert.Equal(t, enteredAt.Add(-45*time.Minute), activity.Start)
+	assert.Equal(t, 45*time.Minute, activity.Elapsed)
+	assert.Equal(t, "Bench Press: 125 lbs × 5, 5, 8\n"+
+		"Deadlift (Sumo): 185 lbs × 5; 205 lbs × 3\n"+
+		"ChinUp: bodyweight +10 lbs × 6, 5\n"+
+		"Curl: 12, 10 reps\n"+
+		"\n"+
+		"Felt strong", activity.Description)
+}
+
+func TestNewActivity_AssistedBodyweightInKilograms(t *testing.T) {
+	workout := models.Workout{Day: 1, Exercises: []models.Lift{
+		{LiftName: "Dip", Bodyweight: true, Sets: []models.Set{
+			{Weight: -12.5, ActualReps: 8, Type: models.WorkingSet},
+		}},
+	}}
+
+	activity := NewActivity(workout, "Program", models.Kilograms, time.Hour)
+
+	assert.Equal(t, "Dip: bodyweight -12.5 kg × 8", activity.Description)
+}
+
+func TestToken_Expired(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, Token{}.Expired(now))
+	assert.True(t, Token{AccessToken: "a", ExpiresAt: now.Add(-time.Hour)}.Expired(now))
+	assert.True(t, Token{AccessToken: "a", ExpiresAt: now.Add(30 * time.Second)}.Expired(now))
+	assert.False(t, Token{AccessToken: "a", ExpiresAt: now.Add(time.Hour)}.Expired(now))
+}
+
+func TestParseAuthorizationCode(t *testing.T) {
+	code, err := ParseAuthorizationCode("  abc123\n")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", code)
+
+	code, err = ParseAuthorizationCode("http://localhost/exchange_token?state=&code=abc123&scope=read,activity:write")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", code)
+
+	_, err = ParseAuthorizationCode("http://localhost/exchange_token?state=&error=access_denied")
+	assert.EqualError(t, err, "authorization failed: access_denied")
+
+	_, err = ParseAuthorizationCode("http://localhost/exchange_token?state=")
+	assert.EqualError(t, err, "no authorization code found")
+
+	_, err = ParseAuthorizationCode("")
+	assert.EqualError(t, err, "no authorization code found")
+}
@@ -0,0 +1,95 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// expiryMargin renews access tokens shortly before they expire, so a token
+// doesn't lapse partway through a request
+const expiryMargin = time.Minute
+
+// Token is an OAuth access token and the refresh token that renews it
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Expired reports whether the access token must be renewed before use
+func (t Token) Expired(now time.Time) bool {
+	return t.AccessToken == "" || !now.Add(expiryMargin).Before(t.ExpiresAt)
+}
+
+// tokenResponse is an OAuth token endpoint's response. Strava gives the
+// expiry as a Unix time.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    int64  `json:"expires_at"`
+	Message      string `json:"message"`
+}
+
+// requestToken posts form to an OAuth token endpoint and returns the token it grants
+func requestToken(client *http.Client, tokenURL string, form url.Values) (Token, error) {
+	resp, err := client.PostForm(tokenURL, form)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to read token response: %w", err)
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil && resp.StatusCode == http.StatusOK {
+		return Token{}, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("token request failed: %s", describeFailure(resp.Status, token.Message))
+	}
+	if token.AccessToken == "" {
+		return Token{}, fmt.Errorf("token response has no access token")
+	}
+
+	return Token{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    time.Unix(token.ExpiresAt, 0),
+	}, nil
+}
+
+// ParseAuthorizationCode returns the code from the page a browser was sent to
+// after authorizing, given either the code itself or the page's whole address
+func ParseAuthorizationCode(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "?") {
+		u, err := url.Parse(input)
+		if err != nil {
+			return "", fmt.Errorf("invalid address %q", input)
+		}
+		query := u.Query()
+		if reason := query.Get("error"); reason != "" {
+			return "", fmt.Errorf("authorization failed: %s", reason)
+		}
+		input = query.Get("code")
+	}
+	if input == "" {
+		return "", fmt.Errorf("no authorization code found")
+	}
+	return input, nil
+}
+
+// describeFailure combines a response's status with the service's explanation
+func describeFailure(status, message string) string {
+	if message == "" {
+		return status
+	}
+	return status + ": " + message
+}
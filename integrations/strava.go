@@ -0,0 +1,179 @@
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Strava's endpoints
+const (
+	StravaAuthorizeURL = "https://www.strava.com/oauth/authorize"
+	StravaTokenURL     = "https://www.strava.com/oauth/token"
+	StravaAPIURL       = "https://www.strava.com/api/v3"
+	stravaActivityURL  = "https://www.strava.com/activities/"
+)
+
+// StravaRedirectURL is where Strava sends the browser after authorization.
+// Nothing listens there; the authorization code is read from the address the
+// browser ends up at. Strava allows localhost as a redirect for any application.
+const StravaRedirectURL = "http://localhost/exchange_token"
+
+// stravaSportType is the sport type of uploaded activities
+const stravaSportType = "WeightTraining"
+
+// ErrNotAuthorized means a service has no tokens yet and must be authorized
+var ErrNotAuthorized = errors.New("not authorized")
+
+// Strava uploads activities to Strava with a user's API application. Its
+// endpoints and HTTP client can be replaced, e.g. in tests.
+type Strava struct {
+	ClientID     string
+	ClientSecret string
+	Token        Token
+
+	Client       *http.Client
+	AuthorizeURL string
+	TokenURL     string
+	APIURL       string
+}
+
+// NewStrava creates a Strava exporter from stored credentials
+func NewStrava(config models.StravaConfig) *Strava {
+	return &Strava{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Token: Token{
+			AccessToken:  config.AccessToken,
+			RefreshToken: config.RefreshToken,
+			ExpiresAt:    config.ExpiresAt,
+		},
+		Client:       &http.Client{Timeout: 30 * time.Second},
+		AuthorizeURL: StravaAuthorizeURL,
+		TokenURL:     StravaTokenURL,
+		APIURL:       StravaAPIURL,
+	}
+}
+
+// Config returns the credentials with the current tokens, to be stored after
+// authorizing or pushing renews them
+func (s *Strava) Config() models.StravaConfig {
+	return models.StravaConfig{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		AccessToken:  s.Token.AccessToken,
+		RefreshToken: s.Token.RefreshToken,
+		ExpiresAt:    s.Token.ExpiresAt,
+	}
+}
+
+// Name returns "Strava"
+func (s *Strava) Name() string {
+	return "Strava"
+}
+
+// AuthorizationURL returns the page where the user grants greyskull permission
+// to upload activities
+func (s *Strava) AuthorizationURL() string {
+	query := url.Values{
+		"client_id":       {s.ClientID},
+		"redirect_uri":    {StravaRedirectURL},
+		"response_type":   {"code"},
+		"approval_prompt": {"force"},
+		"scope":           {"activity:write"},
+	}
+	return s.AuthorizeURL + "?" + query.Encode()
+}
+
+// Authorize exchanges the code from an authorization for tokens
+func (s *Strava) Authorize(code string) error {
+	token, err := requestToken(s.Client, s.TokenURL, url.Values{
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"code":          {code},
+		"grant_type":    {"authorization_code"},
+	})
+	if err != nil {
+		return err
+	}
+	s.Token = token
+	return nil
+}
+
+// Push uploads an activity as a manual weight training activity, renewing the
+// access token first if it has expired
+func (s *Strava) Push(activity Activity) (string, error) {
+	if err := s.refresh(); err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"name":             {activity.Name},
+		"sport_type":       {stravaSportType},
+		"start_date_local": {activity.Start.Format("2006-01-02T15:04:05")},
+		"elapsed_time":     {strconv.Itoa(int(activity.Elapsed.Seconds()))},
+		"description":      {activity.Description},
+	}
+	req, err := http.NewRequest(http.MethodPost, s.APIURL+"/activities", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+s.Token.AccessToken)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload activity: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read upload response: %w", err)
+	}
+	var created struct {
+		ID      int64  `json:"id"`
+		Message string `json:"message"`
+	}
+	parseErr := json.Unmarshal(body, &created)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("upload failed: %s", describeFailure(resp.Status, created.Message))
+	}
+	if parseErr != nil {
+		return "", fmt.Errorf("failed to parse upload response: %w", parseErr)
+	}
+	return stravaActivityURL + strconv.FormatInt(created.ID, 10), nil
+}
+
+// refresh renews an expired access token with the refresh token
+func (s *Strava) refresh() error {
+	if !s.Token.Expired(time.Now()) {
+		return nil
+	}
+	if s.Token.RefreshToken == "" {
+		return ErrNotAuthorized
+	}
+
+	token, err := requestToken(s.Client, s.TokenURL, url.Values{
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"refresh_token": {s.Token.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	})
+	if err != nil {
+		return err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.Token.RefreshToken
+	}
+	s.Token = token
+	return nil
+}
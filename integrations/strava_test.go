@@ -0,0 +1,167 @@
+package integrations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStrava serves Strava's token and activity endpoints, recording the
+// requests made to them
+type fakeStrava struct {
+	*httptest.Server
+	tokenForms    []url.Values
+	activityForms []url.Values
+	authorization string
+	uploadStatus  int
+}
+
+func newFakeStrava(t *testing.T) *fakeStrava {
+	fake := &fakeStrava{uploadStatus: http.StatusCreated}
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.URL.Path {
+		case "/oauth/token":
+			fake.tokenForms = append(fake.tokenForms, r.PostForm)
+			if r.PostForm.Get("code") == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"message":"Bad Request"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","expires_at":4102444800}`))
+		case "/api/v3/activities":
+			fake.activityForms = append(fake.activityForms, r.PostForm)
+			fake.authorization = r.Header.Get("Authorization")
+			w.WriteHeader(fake.uploadStatus)
+			if fake.uploadStatus == http.StatusCreated {
+				w.Write([]byte(`{"id":12345}`))
+			} else {
+				w.Write([]byte(`{"message":"Authorization Error"}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func (f *fakeStrava) exporter(config models.StravaConfig) *Strava {
+	strava := NewStrava(config)
+	strava.TokenURL = f.URL + "/oauth/token"
+	strava.APIURL = f.URL + "/api/v3"
+	return strava
+}
+
+var testActivity = Activity{
+	Name:        "Greyskull LP: Day 1",
+	Description: "Squat: 135 lbs × 5, 5, 8",
+	Start:       time.Date(2024, 3, 4, 17, 30, 0, 0, time.UTC),
+	Elapsed:     time.Hour,
+}
+
+func TestStrava_AuthorizationURL(t *testing.T) {
+	strava := NewStrava(models.StravaConfig{ClientID: "42"})
+
+	u, err := url.Parse(strava.AuthorizationURL())
+	require.NoError(t, err)
+	assert.Equal(t, "www.strava.com", u.Host)
+	assert.Equal(t, "/oauth/authorize", u.Path)
+	assert.Equal(t, "42", u.Query().Get("client_id"))
+	assert.Equal(t, StravaRedirectURL, u.Query().Get("redirect_uri"))
+	assert.Equal(t, "activity:write", u.Query().Get("scope"))
+}
+
+func TestStrava_Authorize(t *testing.T) {
+	fake := newFakeStrava(t)
+	strava := fake.exporter(models.StravaConfig{ClientID: "42", ClientSecret: "secret"})
+
+	require.NoError(t, strava.Authorize("abc123"))
+
+	require.Len(t, fake.tokenForms, 1)
+	assert.Equal(t, "abc123", fake.tokenForms[0].Get("code"))
+	assert.Equal(t, "authorization_code", fake.tokenForms[0].Get("grant_type"))
+	assert.Equal(t, "secret", fake.tokenForms[0].Get("client_secret"))
+	assert.Equal(t, models.StravaConfig{
+		ClientID:     "42",
+		ClientSecret: "secret",
+		AccessToken:  "new-access",
+		RefreshToken: "new-refresh",
+		ExpiresAt:    time.Unix(4102444800, 0),
+	}, strava.Config())
+
+	err := strava.Authorize("bad")
+	assert.EqualError(t, err, "token request failed: 400 Bad Request: Bad Request")
+}
+
+func TestStrava_Push(t *testing.T) {
+	fake := newFakeStrava(t)
+	strava := fake.exporter(models.StravaConfig{
+		ClientID:     "42",
+		ClientSecret: "secret",
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+
+	url, err := strava.Push(testActivity)
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.strava.com/activities/12345", url)
+
+	assert.Empty(t, fake.tokenForms)
+	assert.Equal(t, "Bearer access", fake.authorization)
+	require.Len(t, fake.activityForms, 1)
+	form := fake.activityForms[0]
+	assert.Equal(t, "Greyskull LP: Day 1", form.Get("name"))
+	assert.Equal(t, "WeightTraining", form.Get("sport_type"))
+	assert.Equal(t, "2024-03-04T17:30:00", form.Get("start_date_local"))
+	assert.Equal(t, "3600", form.Get("elapsed_time"))
+	assert.Equal(t, "Squat: 135 lbs × 5, 5, 8", form.Get("description"))
+}
+
+func TestStrava_Push_RefreshesExpiredToken(t *testing.T) {
+	fake := newFakeStrava(t)
+	strava := fake.exporter(models.StravaConfig{
+		ClientID:     "42",
+		ClientSecret: "secret",
+		AccessToken:  "old-access",
+		RefreshToken: "old-refresh",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	})
+
+	_, err := strava.Push(testActivity)
+	require.NoError(t, err)
+
+	require.Len(t, fake.tokenForms, 1)
+	assert.Equal(t, "refresh_token", fake.tokenForms[0].Get("grant_type"))
+	assert.Equal(t, "old-refresh", fake.tokenForms[0].Get("refresh_token"))
+	assert.Equal(t, "Bearer new-access", fake.authorization)
+	assert.Equal(t, "new-refresh", strava.Config().RefreshToken)
+}
+
+func TestStrava_Push_NotAuthorized(t *testing.T) {
+	fake := newFakeStrava(t)
+	strava := fake.exporter(models.StravaConfig{ClientID: "42", ClientSecret: "secret"})
+
+	_, err := strava.Push(testActivity)
+	assert.ErrorIs(t, err, ErrNotAuthorized)
+	assert.Empty(t, fake.activityForms)
+}
+
+func TestStrava_Push_UploadFails(t *testing.T) {
+	fake := newFakeStrava(t)
+	fake.uploadStatus = http.StatusUnauthorized
+	strava := fake.exporter(models.StravaConfig{
+		AccessToken: "access",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+
+	_, err := strava.Push(testActivity)
+	assert.EqualError(t, err, "upload failed: 401 Unauthorized: Authorization Error")
+}
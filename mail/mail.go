@@ -0,0 +1,113 @@
+// Package mail sends messages, such as weekly summaries, through the SMTP
+// server in a user's config
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// ErrNotConfigured is returned when the config is missing what's needed to send
+var ErrNotConfigured = errors.New("no mail server configured; set smtp.server, smtp.from, and smtp.to with 'greyskull config set'")
+
+// Message is an email with a plaintext or HTML body
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Date    time.Time
+	Body    []byte
+	HTML    bool
+}
+
+// Bytes returns the message as it is sent: its headers, then its body in
+// UTF-8, quoted-printable encoded, with CRLF line endings
+func (m Message) Bytes() []byte {
+	var buf bytes.Buffer
+	contentType := "text/plain"
+	if m.HTML {
+		contentType = "text/html"
+	}
+	headers := [][2]string{
+		{"From", m.From},
+		{"To", strings.Join(m.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", m.Date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType + "; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+
+	body := bytes.ReplaceAll(m.Body, []byte("\r\n"), []byte("\n"))
+	body = bytes.ReplaceAll(body, []byte("\n"), []byte("\r\n"))
+	writer := quotedprintable.NewWriter(&buf)
+	writer.Write(body)
+	writer.Close()
+	return buf.Bytes()
+}
+
+// sendMail delivers a message; tests replace it to capture what's sent
+var sendMail = smtp.SendMail
+
+// Send sends a message through the config's mail server, signing in when it
+// has a username. The server's STARTTLS is used whenever it offers it. A
+// message without a sender or recipients uses the config's.
+func Send(config models.SMTPConfig, message Message) error {
+	if message.From == "" {
+		message.From = config.From
+	}
+	if len(message.To) == 0 {
+		message.To = config.To
+	}
+	if config.Server == "" || message.From == "" || len(message.To) == 0 {
+		return ErrNotConfigured
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		host, _, err := net.SplitHostPort(config.Server)
+		if err != nil {
+			return fmt.Errorf("invalid mail server %q: %w", config.Server, err)
+		}
+		auth = smtp.PlainAuth("", config.Username, config.Password, host)
+	}
+
+	from, err := envelopeAddress(message.From)
+	if err != nil {
+		return err
+	}
+	to := make([]string, len(message.To))
+	for i, address := range message.To {
+		if to[i], err = envelopeAddress(address); err != nil {
+			return err
+		}
+	}
+
+	if err := sendMail(config.Server, auth, from, to, message.Bytes()); err != nil {
+		return fmt.Errorf("failed to send mail through %s: %w", config.Server, err)
+	}
+	return nil
+}
+
+// envelopeAddress returns the bare address of one that may have a name
+func envelopeAddress(address string) (string, error) {
+	parsed, err := netmail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid email address %q", address)
+	}
+	return parsed.Address, nil
+}
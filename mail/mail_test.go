@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	message := Message{
+		From:    "lifter@example.com",
+		To:      []string{"coach@example.com", "me@example.com"},
+		Subject: "Squat 135 → 145",
+		Date:    time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC),
+		Body:    []byte("Squat: 135 → 145 lbs\n"),
+	}
+
+	assert.Equal(t, "From: lifter@example.com\r\n"+
+		"To: coach@example.com, me@example.com\r\n"+
+		"Subject: =?utf-8?q?Squat_135_=E2=86=92_145?=\r\n"+
+		"Date: Sun, 10 Mar 2024 18:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"Content-Transfer-Encoding: quoted-printable\r\n"+
+		"\r\n"+
+		"Squat: 135 =E2=86=92 145 lbs\r\n", string(message.Bytes()))
+
+	message.HTML = true
+	assert.Contains(t, string(message.Bytes()), "Content-Type: text/html; charset=utf-8\r\n")
+}
+
+func TestSend(t *testing.T) {
+	var sentTo []string
+	var sentFrom, sentServer, sent string
+	var sentAuth smtp.Auth
+	sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		sentServer, sentAuth, sentFrom, sentTo, sent = addr, auth, from, to, string(msg)
+		return nil
+	}
+	t.Cleanup(func() { sendMail = smtp.SendMail })
+
+	config := models.SMTPConfig{
+		Server:   "smtp.example.com:587",
+		Username: "lifter",
+		Password: "secret",
+		From:     `"Lifter" <lifter@example.com>`,
+		To:       []string{"coach@example.com"},
+	}
+	require.NoError(t, Send(config, Message{Subject: "Weekly summary", Body: []byte("3 sessions")}))
+
+	assert.Equal(t, "smtp.example.com:587", sentServer)
+	assert.NotNil(t, sentAuth)
+	assert.Equal(t, "lifter@example.com", sentFrom, "the envelope has the bare address")
+	assert.Equal(t, []string{"coach@example.com"}, sentTo)
+	assert.True(t, strings.HasPrefix(sent, "From: \"Lifter\" <lifter@example.com>\r\nTo: coach@example.com\r\n"))
+
+	config.Username = ""
+	require.NoError(t, Send(config, Message{Subject: "Weekly summary"}))
+	assert.Nil(t, sentAuth, "no sign-in without a username")
+
+	assert.ErrorIs(t, Send(models.SMTPConfig{}, Message{}), ErrNotConfigured)
+}
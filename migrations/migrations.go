@@ -0,0 +1,117 @@
+// Package migrations upgrades persisted user data written by older versions of
+// greyskull. Each migration works on the raw JSON, so it can read fields the
+// current models no longer have.
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Migration upgrades a user document from the previous schema version to Version
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(user map[string]any) error
+}
+
+// versionKey is the JSON field holding a user document's schema version. Files
+// written before versioning have none and are version 0.
+const versionKey = "schema_version"
+
+// migrations lists every migration in version order. Add new migrations to the
+// end; CurrentVersion follows automatically.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "fill in missing program and history collections and record each program's unit",
+		Apply:       fillCollectionsAndUnits,
+	},
+	{
+		// History stays in the file until the user is next saved, when the
+		// repository moves it to monthly files. Older versions of greyskull
+		// would find no history in files written since.
+		Version:     2,
+		Description: "store workout history apart from the user",
+		Apply:       func(map[string]any) error { return nil },
+	},
+}
+
+// CurrentVersion is the schema version written by this version of greyskull
+var CurrentVersion = migrations[len(migrations)-1].Version
+
+// Upgrade applies every migration newer than the document's schema version and
+// returns the upgraded JSON, reporting whether anything changed. Documents from
+// a newer version of greyskull are rejected rather than loaded with data missing.
+func Upgrade(data []byte) ([]byte, bool, error) {
+	var user map[string]any
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal user data: %w", err)
+	}
+
+	version, err := documentVersion(user)
+	if err != nil {
+		return nil, false, err
+	}
+	if version > CurrentVersion {
+		return nil, false, fmt.Errorf("user data uses schema version %d, but this version of greyskull only understands up to %d; upgrade greyskull", version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return data, false, nil
+	}
+
+	for _, migration := range migrations {
+		if migration.Version <= version {
+			continue
+		}
+		if err := migration.Apply(user); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate user data to version %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		user[versionKey] = migration.Version
+	}
+
+	upgraded, err := json.Marshal(user)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal migrated user data: %w", err)
+	}
+	return upgraded, true, nil
+}
+
+func documentVersion(user map[string]any) (int, error) {
+	raw, ok := user[versionKey]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	version, ok := raw.(float64)
+	if !ok || version < 0 || version != float64(int(version)) {
+		return 0, fmt.Errorf("invalid schema version %v", raw)
+	}
+	return int(version), nil
+}
+
+// fillCollectionsAndUnits replaces a null programs map or workout history with
+// empty ones, which later code can add to, and stores the unit of programs
+// started before units existed, which were always pounds
+func fillCollectionsAndUnits(user map[string]any) error {
+	if user["programs"] == nil {
+		user["programs"] = map[string]any{}
+	}
+	if user["workout_history"] == nil {
+		user["workout_history"] = []any{}
+	}
+
+	programs, ok := user["programs"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("programs is not an object")
+	}
+	for id, raw := range programs {
+		program, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("program %s is not an object", id)
+		}
+		if unit, _ := program["unit"].(string); unit == "" {
+			program["unit"] = "lbs"
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrade_UnversionedFile(t *testing.T) {
+	data := []byte(`{
+		"username": "Alice",
+		"programs": {
+			"0191f2d0-0000-7000-8000-000000000001": {"current_day": 2},
+			"0191f2d0-0000-7000-8000-000000000002": {"current_day": 1, "unit": "kg"}
+		},
+		"workout_history": null
+	}`)
+
+	upgraded, changed, err := Upgrade(data)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	var user map[string]any
+	require.NoError(t, json.Unmarshal(upgraded, &user))
+	assert.Equal(t, float64(CurrentVersion), user["schema_version"])
+	assert.Equal(t, "Alice", user["username"])
+	assert.Equal(t, []any{}, user["workout_history"])
+
+	programs := user["programs"].(map[string]any)
+	assert.Equal(t, "lbs", programs["0191f2d0-0000-7000-8000-000000000001"].(map[string]any)["unit"])
+	assert.Equal(t, "kg", programs["0191f2d0-0000-7000-8000-000000000002"].(map[string]any)["unit"])
+}
+
+func TestUpgrade_NullPrograms(t *testing.T) {
+	upgraded, _, err := Upgrade([]byte(`{"username": "Alice", "programs": null}`))
+	require.NoError(t, err)
+
+	var user map[string]any
+	require.NoError(t, json.Unmarshal(upgraded, &user))
+	assert.Equal(t, map[string]any{}, user["programs"])
+}
+
+func TestUpgrade_CurrentVersionUnchanged(t *testing.T) {
+	data := []byte(`{"username": "Alice", "schema_version": 2, "programs": {}}`)
+
+	upgraded, changed, err := Upgrade(data)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, data, upgraded)
+}
+
+func TestUpgrade_Errors(t *testing.T) {
+	_, _, err := Upgrade([]byte(`{"schema_version": 99}`))
+	assert.ErrorContains(t, err, "schema version 99")
+	assert.ErrorContains(t, err, "upgrade greyskull")
+
+	_, _, err = Upgrade([]byte(`{"schema_version": "one"}`))
+	assert.ErrorContains(t, err, "invalid schema version")
+
+	_, _, err = Upgrade([]byte(`{"programs": []}`))
+	assert.ErrorContains(t, err, "failed to migrate user data to version 1")
+
+	_, _, err = Upgrade([]byte(`{`))
+	assert.ErrorContains(t, err, "failed to unmarshal user data")
+}
@@ -0,0 +1,218 @@
+// Package milestones detects training milestones, such as a first 225 lb squat
+// or a 100th workout, in a user's history and records them on the user as they
+// are unlocked. Weights are compared in pounds, so kilogram programs reach a
+// milestone once their weight converts to at least its pounds.
+package milestones
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/analytics"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Milestone is a landmark a training history can reach
+type Milestone struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	reached func(p *progress) bool
+
+	// text formats the name and description in the current locale
+	text func() (name, description string)
+}
+
+// newMilestone returns a milestone named and described by text. The catalog
+// is built before any locale is set, so Name and Description are in English.
+func newMilestone(id string, reached func(p *progress) bool, text func() (name, description string)) Milestone {
+	name, description := text()
+	return Milestone{ID: id, Name: name, Description: description, reached: reached, text: text}
+}
+
+// Localized returns the milestone's name and description in the current locale
+func (m Milestone) Localized() (name, description string) {
+	if m.text == nil {
+		return m.Name, m.Description
+	}
+	return m.text()
+}
+
+// progress is what a history has achieved so far
+type progress struct {
+	// heaviest is the heaviest completed non-warmup set of each weight key, in pounds
+	heaviest map[models.LiftName]float64
+
+	workouts int
+
+	// weeksInARow counts the weeks in a row, up to the latest workout's, with
+	// a workout; lastWeek is the Monday of the latest workout's week
+	weeksInARow int
+	lastWeek    time.Time
+}
+
+// All lists every milestone, grouped by kind and in increasing difficulty
+var All = slices.Concat(
+	liftMilestones(models.Squat, "Squat", 135, 225, 315, 405),
+	liftMilestones(models.BenchPress, "Bench Press", 135, 225, 315),
+	liftMilestones(models.Deadlift, "Deadlift", 225, 315, 405, 495),
+	liftMilestones(models.OverheadPress, "Overhead Press", 95, 135, 185),
+	[]Milestone{clubMilestone(1000)},
+	workoutMilestones(1, 10, 50, 100, 250, 500),
+	weekMilestones(4, 10, 26, 52),
+)
+
+// liftMilestones returns a milestone for completing a set of the lift at each weight
+func liftMilestones(lift models.LiftName, name string, weights ...float64) []Milestone {
+	milestones := make([]Milestone, len(weights))
+	for i, weight := range weights {
+		milestones[i] = newMilestone(fmt.Sprintf("%s-%g", strings.ToLower(string(lift)), weight),
+			func(p *progress) bool { return p.heaviest[lift] >= weight },
+			func() (string, string) {
+				name := i18n.T(name)
+				return i18n.Sprintf("%g lb %s", weight, name),
+					i18n.Sprintf("Complete a working set of %s at %g lbs or more", name, weight)
+			})
+	}
+	return milestones
+}
+
+// clubMilestone returns the milestone for a squat, bench press, and deadlift
+// that add up to total
+func clubMilestone(total float64) Milestone {
+	return newMilestone(fmt.Sprintf("club-%g", total),
+		func(p *progress) bool {
+			return p.heaviest[models.Squat]+p.heaviest[models.BenchPress]+p.heaviest[models.Deadlift] >= total
+		},
+		func() (string, string) {
+			return i18n.Sprintf("%g lb Club", total),
+				i18n.Sprintf("Reach a combined %g lbs across your heaviest squat, bench press, and deadlift", total)
+		})
+}
+
+// workoutMilestones returns a milestone for logging each number of workouts
+func workoutMilestones(counts ...int) []Milestone {
+	milestones := make([]Milestone, len(counts))
+	for i, count := range counts {
+		milestones[i] = newMilestone(fmt.Sprintf("workouts-%d", count),
+			func(p *progress) bool { return p.workouts >= count },
+			func() (string, string) {
+				if count == 1 {
+					return i18n.T("First Workout"), i18n.T("Log your first workout")
+				}
+				return i18n.Sprintf("%s Workout", ordinal(count)), i18n.Sprintf("Log %d workouts", count)
+			})
+	}
+	return milestones
+}
+
+// weekMilestones returns a milestone for training in each number of weeks in a row
+func weekMilestones(counts ...int) []Milestone {
+	milestones := make([]Milestone, len(counts))
+	for i, count := range counts {
+		milestones[i] = newMilestone(fmt.Sprintf("weeks-%d", count),
+			func(p *progress) bool { return p.weeksInARow >= count },
+			func() (string, string) {
+				return i18n.Sprintf("%d Weeks Strong", count),
+					i18n.Sprintf("Train at least once a week for %d weeks in a row", count)
+			})
+	}
+	return milestones
+}
+
+// Lookup returns the milestone with an ID
+func Lookup(id string) (Milestone, bool) {
+	i := slices.IndexFunc(All, func(m Milestone) bool { return m.ID == id })
+	if i < 0 {
+		return Milestone{}, false
+	}
+	return All[i], true
+}
+
+// ReachedAt returns when the user's history first reached each milestone it
+// has reached: the date of the workout that reached it
+func ReachedAt(user *models.User) map[string]time.Time {
+	reached := make(map[string]time.Time)
+	p := &progress{heaviest: make(map[models.LiftName]float64)}
+	for _, workout := range user.History() {
+		p.add(user, &workout)
+		for _, milestone := range All {
+			if _, done := reached[milestone.ID]; !done && milestone.reached(p) {
+				reached[milestone.ID] = workout.EnteredAt
+			}
+		}
+	}
+	return reached
+}
+
+// Unlock records the milestones the user has reached but not yet unlocked,
+// dated when they were reached, and returns them in catalog order
+func Unlock(user *models.User) []Milestone {
+	reached := ReachedAt(user)
+	var unlocked []Milestone
+	for _, milestone := range All {
+		at, done := reached[milestone.ID]
+		if !done || IsUnlocked(user.Milestones, milestone.ID) {
+			continue
+		}
+		user.Milestones = append(user.Milestones, models.UnlockedMilestone{ID: milestone.ID, UnlockedAt: at})
+		unlocked = append(unlocked, milestone)
+	}
+	return unlocked
+}
+
+// IsUnlocked reports whether the milestone with an ID is among those unlocked
+func IsUnlocked(unlocked []models.UnlockedMilestone, id string) bool {
+	return slices.ContainsFunc(unlocked, func(m models.UnlockedMilestone) bool { return m.ID == id })
+}
+
+// add folds a workout into the progress. Weights are in the unit of the
+// workout's program.
+func (p *progress) add(user *models.User, workout *models.Workout) {
+	p.workouts++
+
+	week := analytics.WeekStart(workout.EnteredAt)
+	switch {
+	case p.weeksInARow > 0 && week.Equal(p.lastWeek):
+	case p.weeksInARow > 0 && week.Equal(p.lastWeek.AddDate(0, 0, 7)):
+		p.weeksInARow++
+	default:
+		p.weeksInARow = 1
+	}
+	p.lastWeek = week
+
+	unit := user.Unit
+	if userProgram, exists := user.Programs[workout.UserProgramID]; exists {
+		unit = userProgram.Unit
+	}
+	for _, lift := range workout.Exercises {
+		if lift.Optional || lift.Bodyweight {
+			continue
+		}
+		for _, set := range lift.Sets {
+			if set.Type == models.WarmupSet || set.ActualReps <= 0 {
+				continue
+			}
+			key := lift.WeightKey()
+			p.heaviest[key] = max(p.heaviest[key], models.ConvertWeight(set.Weight, unit, models.Pounds))
+		}
+	}
+}
+
+// ordinal formats a count as an ordinal number in the current locale, e.g. "100th"
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		return i18n.Sprintf("%dst", n)
+	case n%10 == 2:
+		return i18n.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return i18n.Sprintf("%drd", n)
+	}
+	return i18n.Sprintf("%dth", n)
+}
@@ -0,0 +1,116 @@
+package milestones
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var base = time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC) // A Monday
+
+func lift(name models.LiftName, weight float64, reps int) models.Lift {
+	return models.Lift{LiftName: name, Sets: []models.Set{
+		{Weight: 45, TargetReps: 5, ActualReps: 5, Type: models.WarmupSet},
+		{Weight: weight, TargetReps: 5, ActualReps: reps, Type: models.AMRAPSet},
+	}}
+}
+
+func unlockedIDs(milestones []Milestone) []string {
+	ids := make([]string, len(milestones))
+	for i, milestone := range milestones {
+		ids[i] = milestone.ID
+	}
+	return ids
+}
+
+func TestUnlock(t *testing.T) {
+	programID := uuid.New()
+	user := &models.User{Programs: map[uuid.UUID]*models.UserProgram{programID: {ID: programID}}}
+	user.WorkoutHistory = []models.Workout{
+		{UserProgramID: programID, EnteredAt: base, Exercises: []models.Lift{lift(models.Squat, 135, 5), lift(models.BenchPress, 135, 0)}},
+	}
+
+	assert.Equal(t, []string{"squat-135", "workouts-1"}, unlockedIDs(Unlock(user)))
+	assert.Equal(t, []models.UnlockedMilestone{{ID: "squat-135", UnlockedAt: base}, {ID: "workouts-1", UnlockedAt: base}}, user.Milestones)
+	assert.Empty(t, Unlock(user), "milestones are only unlocked once")
+
+	// A warmup at the weight doesn't count, and a set that came after the
+	// heavier one still reaches the club total
+	user.WorkoutHistory = append(user.WorkoutHistory,
+		models.Workout{UserProgramID: programID, EnteredAt: base.AddDate(0, 0, 7), Exercises: []models.Lift{
+			lift(models.Squat, 315, 5), lift(models.BenchPress, 225, 3), lift(models.Deadlift, 225, 5),
+		}},
+		models.Workout{UserProgramID: programID, EnteredAt: base.AddDate(0, 0, 16), Exercises: []models.Lift{
+			{LiftName: models.Deadlift, Sets: []models.Set{{Weight: 495, ActualReps: 5, Type: models.WarmupSet}, {Weight: 465, ActualReps: 5, Type: models.AMRAPSet}}},
+		}},
+	)
+	assert.Equal(t, []string{
+		"squat-225", "squat-315", "benchpress-135", "benchpress-225",
+		"deadlift-225", "deadlift-315", "deadlift-405", "club-1000",
+	}, unlockedIDs(Unlock(user)))
+	reached := ReachedAt(user)
+	assert.Equal(t, base.AddDate(0, 0, 7), reached["squat-315"])
+	assert.Equal(t, base.AddDate(0, 0, 16), reached["club-1000"])
+}
+
+func TestUnlock_Kilograms(t *testing.T) {
+	programID := uuid.New()
+	user := &models.User{Programs: map[uuid.UUID]*models.UserProgram{programID: {ID: programID, Unit: models.Kilograms}}}
+	user.WorkoutHistory = []models.Workout{
+		{UserProgramID: programID, EnteredAt: base, Exercises: []models.Lift{lift(models.Squat, 102.5, 5)}},
+	}
+
+	assert.Contains(t, unlockedIDs(Unlock(user)), "squat-225")
+}
+
+func TestUnlock_WeeksInARow(t *testing.T) {
+	user := &models.User{}
+	for _, week := range []int{0, 1, 2, 4} { // Skipping a week
+		for _, day := range []int{0, 2} {
+			user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{EnteredAt: base.AddDate(0, 0, 7*week+day)})
+		}
+	}
+	assert.NotContains(t, unlockedIDs(Unlock(user)), "weeks-4")
+
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{EnteredAt: base.AddDate(0, 0, 7*3+4)})
+	unlocked := Unlock(user)
+	require.Len(t, unlocked, 1)
+	assert.Equal(t, "weeks-4", unlocked[0].ID)
+	assert.Equal(t, "Train at least once a week for 4 weeks in a row", unlocked[0].Description)
+	assert.Equal(t, base.AddDate(0, 0, 7*3+4), ReachedAt(user)["weeks-4"])
+}
+
+func TestLookup(t *testing.T) {
+	milestone, ok := Lookup("workouts-100")
+	require.True(t, ok)
+	assert.Equal(t, "100th Workout", milestone.Name)
+	milestone, ok = Lookup("club-1000")
+	require.True(t, ok)
+	assert.Equal(t, "1000 lb Club", milestone.Name)
+
+	_, ok = Lookup("squat-1000")
+	assert.False(t, ok)
+}
+
+func TestLocalized(t *testing.T) {
+	milestone, _ := Lookup("squat-225")
+	name, description := milestone.Localized()
+	assert.Equal(t, "225 lb Squat", name)
+	assert.Equal(t, "Complete a working set of Squat at 225 lbs or more", description)
+
+	i18n.SetLocale("es")
+	t.Cleanup(func() { i18n.SetLocale(i18n.English) })
+	name, description = milestone.Localized()
+	assert.Equal(t, "225 lb en Sentadilla", name)
+	assert.Equal(t, "Completa una serie de trabajo de Sentadilla con 225 lbs o más", description)
+	assert.Equal(t, "225 lb Squat", milestone.Name, "Name stays in English")
+
+	milestone, _ = Lookup("workouts-100")
+	name, _ = milestone.Localized()
+	assert.Equal(t, "Entrenamiento 100.º", name)
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// IsActive reports whether a program is the user's current program or one
+// trained alongside it
+func (u *User) IsActive(userProgramID uuid.UUID) bool {
+	return userProgramID != uuid.Nil && (userProgramID == u.CurrentProgram || slices.Contains(u.ActivePrograms, userProgramID))
+}
+
+// ActiveProgramList returns the user's current program followed by the
+// programs trained alongside it, skipping any that can't be found
+func (u *User) ActiveProgramList() []*UserProgram {
+	var programs []*UserProgram
+	for _, id := range append([]uuid.UUID{u.CurrentProgram}, u.ActivePrograms...) {
+		if up, exists := u.Programs[id]; exists && !slices.Contains(programs, up) {
+			programs = append(programs, up)
+		}
+	}
+	return programs
+}
+
+// ActivateProgram trains a program alongside the current one. The current
+// program and programs already active are left as they are.
+func (u *User) ActivateProgram(userProgramID uuid.UUID) {
+	if userProgramID != uuid.Nil && !u.IsActive(userProgramID) {
+		u.ActivePrograms = append(u.ActivePrograms, userProgramID)
+	}
+}
+
+// DeactivateProgram stops training a program alongside the current one
+func (u *User) DeactivateProgram(userProgramID uuid.UUID) {
+	u.ActivePrograms = slices.DeleteFunc(slices.Clone(u.ActivePrograms), func(id uuid.UUID) bool {
+		return id == userProgramID
+	})
+	if len(u.ActivePrograms) == 0 {
+		u.ActivePrograms = nil
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserActivePrograms(t *testing.T) {
+	current := &UserProgram{ID: uuid.New()}
+	conditioning := &UserProgram{ID: uuid.New()}
+	old := &UserProgram{ID: uuid.New()}
+	user := &User{
+		CurrentProgram: current.ID,
+		Programs:       map[uuid.UUID]*UserProgram{current.ID: current, conditioning.ID: conditioning, old.ID: old},
+	}
+
+	assert.True(t, user.IsActive(current.ID))
+	assert.False(t, user.IsActive(conditioning.ID))
+	assert.False(t, user.IsActive(uuid.Nil))
+	assert.Equal(t, []*UserProgram{current}, user.ActiveProgramList())
+
+	user.ActivateProgram(conditioning.ID)
+	user.ActivateProgram(conditioning.ID)
+	user.ActivateProgram(current.ID)
+	user.ActivateProgram(uuid.Nil)
+	assert.Equal(t, []uuid.UUID{conditioning.ID}, user.ActivePrograms)
+	assert.True(t, user.IsActive(conditioning.ID))
+	assert.Equal(t, []*UserProgram{current, conditioning}, user.ActiveProgramList())
+
+	user.ActivePrograms = append(user.ActivePrograms, uuid.New())
+	assert.Equal(t, []*UserProgram{current, conditioning}, user.ActiveProgramList(), "missing programs are skipped")
+
+	user.DeactivateProgram(conditioning.ID)
+	assert.False(t, user.IsActive(conditioning.ID))
+	user.ActivePrograms = nil
+	user.DeactivateProgram(conditioning.ID)
+	assert.Nil(t, user.ActivePrograms)
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// AuditAction names a kind of change recorded in a user's audit log
+type AuditAction string
+
+const (
+	UserCreated     AuditAction = "user created"
+	UserUpdated     AuditAction = "settings changed"
+	ProgramStarted  AuditAction = "program started"
+	ProgramRemoved  AuditAction = "program removed"
+	ProgramSwitched AuditAction = "program switched"
+	ProgramUpdated  AuditAction = "program settings changed"
+	DayChanged      AuditAction = "next day changed"
+	WeightChanged   AuditAction = "weight changed"
+	WorkoutLogged   AuditAction = "workout logged"
+	WorkoutEdited   AuditAction = "workout edited"
+	WorkoutRemoved  AuditAction = "workout removed"
+)
+
+// AuditEntry records one change to a user's stored data. Before and After
+// describe the changed value for display, and are empty for values that were
+// added or removed.
+type AuditEntry struct {
+	At time.Time `json:"at"`
+
+	// Command is the command that made the change, e.g. "workout log"
+	Command string `json:"command,omitempty"`
+
+	Action  AuditAction `json:"action"`
+	Program string      `json:"program,omitempty"`
+	Lift    LiftName    `json:"lift,omitempty"`
+	Before  string      `json:"before,omitempty"`
+	After   string      `json:"after,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// IsBodyweight reports whether a weight key belongs to a bodyweight lift in the
+// program, whose tracked weight is added weight and may be zero or negative
+func (p *Program) IsBodyweight(key LiftName) bool {
+	for _, w := range p.Workouts {
+		for _, lift := range w.Lifts {
+			if lift.Bodyweight && !lift.Optional && lift.WeightKey() == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RepIncrementFor returns the reps added per successful session for a bodyweight
+// lift that progresses by reps, falling back to the base lift's rule for variants.
+// It reports false for lifts that progress by weight.
+func (r *ProgressionRules) RepIncrementFor(key LiftName) (int, bool) {
+	if increment, exists := r.RepIncreases[key]; exists {
+		return increment, true
+	}
+	base, _ := key.SplitVariant()
+	increment, exists := r.RepIncreases[base]
+	return increment, exists
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Coaching is the technique reminders and demonstration link for a lift
+type Coaching struct {
+	Cues []string
+	URL  string
+}
+
+// CoachingFor returns the coaching for a lift in a program's day: the cues and
+// URL of its template, each falling back to the lift definition's. A lift the
+// day doesn't have, such as a substitute, gets its definition's.
+func (p *Program) CoachingFor(day int, name LiftName) Coaching {
+	var coaching Coaching
+	if day >= 1 && day <= len(p.Workouts) {
+		for _, lift := range p.Workouts[day-1].Lifts {
+			if lift.LiftName == name {
+				coaching = Coaching{Cues: lift.Cues, URL: lift.URL}
+				break
+			}
+		}
+	}
+
+	if def, ok := LookupLift(name); ok {
+		if len(coaching.Cues) == 0 {
+			coaching.Cues = def.Cues
+		}
+		if coaching.URL == "" {
+			coaching.URL = def.URL
+		}
+	}
+	return coaching
+}
+
+// validateCoachingURL checks that a demonstration link, if given, is an http
+// or https URL with a host
+func validateCoachingURL(link string) error {
+	if link == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" || (!strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https")) {
+		return fmt.Errorf("must be an http or https URL, got %q", link)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgram_CoachingFor(t *testing.T) {
+	registerTestLifts(t, LiftDefinition{
+		Name:        "FrontSquat",
+		DisplayName: "Front Squat",
+		Cues:        []string{"Elbows high"},
+		URL:         "https://example.com/front-squat",
+	})
+	prog := &Program{Workouts: []WorkoutTemplate{
+		{Day: 1, Lifts: []LiftTemplate{
+			{LiftName: Squat, Cues: []string{"Brace", "Knees out"}, URL: "https://example.com/squat"},
+			{LiftName: "FrontSquat", Cues: []string{"Stay upright"}},
+		}},
+	}}
+
+	assert.Equal(t, Coaching{Cues: []string{"Brace", "Knees out"}, URL: "https://example.com/squat"}, prog.CoachingFor(1, Squat))
+	assert.Equal(t, Coaching{Cues: []string{"Stay upright"}, URL: "https://example.com/front-squat"}, prog.CoachingFor(1, "FrontSquat"),
+		"the definition fills in what the template leaves out")
+	assert.Equal(t, Coaching{Cues: []string{"Elbows high"}, URL: "https://example.com/front-squat"}, prog.CoachingFor(2, "FrontSquat"))
+	assert.Equal(t, Coaching{}, prog.CoachingFor(1, Deadlift))
+}
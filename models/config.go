@@ -0,0 +1,271 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds a user's settings that are kept in their config file rather
+// than with their training data. Zero values mean the built-in defaults.
+type Config struct {
+	BarWeight  float64      `json:"bar_weight,omitempty"` // Empty bar weight for warmups of the built-in lifts
+	BarUnit    WeightUnit   `json:"bar_unit,omitempty"`   // Unit of BarWeight
+	Plates     []PlateCount `json:"plates,omitempty"`     // Plate inventory, heaviest first
+	PlateUnit  WeightUnit   `json:"plate_unit,omitempty"` // Unit of Plates
+	DateFormat DateFormat   `json:"date_format,omitempty"`
+
+	// Locale is the language messages are shown in, such as "es"; empty uses
+	// the locale named by LC_ALL, LC_MESSAGES, or LANG
+	Locale string `json:"locale,omitempty"`
+
+	// PlateHints shows the plates to add or take off each side of the bar
+	// between sets when a workout is displayed
+	PlateHints bool `json:"plate_hints,omitempty"`
+
+	// DualUnits shows weights in workouts and stats in both pounds and
+	// kilograms, whichever unit they're recorded in
+	DualUnits bool `json:"dual_units,omitempty"`
+
+	// WarmupStrategy replaces the warmup strategy of every program
+	WarmupStrategy WarmupStrategyName `json:"warmup_strategy,omitempty"`
+
+	// WeeklyTarget is the sessions a week that keep a consistency streak going
+	WeeklyTarget int `json:"weekly_target,omitempty"`
+
+	Hooks Hooks `json:"hooks,omitzero"`
+
+	// Strava holds the credentials 'greyskull push strava' uploads with
+	Strava StravaConfig `json:"strava,omitzero"`
+
+	// SMTP is the mail server 'greyskull summary --send' sends through
+	SMTP SMTPConfig `json:"smtp,omitzero"`
+}
+
+// SMTPConfig is a mail server and the addresses to send summaries from and to
+type SMTPConfig struct {
+	Server   string   `json:"server,omitempty"` // host:port
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+}
+
+// StravaConfig is a Strava API application's credentials and the OAuth tokens
+// it was granted. The access token is refreshed with the refresh token as it
+// expires, and both are saved again each time.
+type StravaConfig struct {
+	ClientID     string    `json:"client_id,omitempty"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	AccessToken  string    `json:"access_token,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+}
+
+// Hooks are shell commands and URLs run after greyskull events. A hook that is
+// an http or https URL is sent the event's JSON in a POST request; any other
+// hook is run as a shell command with the JSON on its standard input.
+type Hooks struct {
+	PostLog []string `json:"post_log,omitempty"` // Run after a workout is logged
+}
+
+// ParseHook validates a hook, which must be a shell command or an http or
+// https URL with a host
+func ParseHook(input string) (string, error) {
+	hook := strings.TrimSpace(input)
+	if hook == "" {
+		return "", fmt.Errorf("hook cannot be empty")
+	}
+	if IsURLHook(hook) {
+		u, err := url.Parse(hook)
+		if err != nil || u.Host == "" {
+			return "", fmt.Errorf("invalid hook URL %q", hook)
+		}
+	}
+	return hook, nil
+}
+
+// ParseSMTPServer validates a mail server given as host:port, or as a host
+// alone to use the mail submission port, 587
+func ParseSMTPServer(input string) (string, error) {
+	server := strings.TrimSpace(input)
+	if server == "" {
+		return "", fmt.Errorf("server cannot be empty")
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		host, port = server, "587"
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+		return "", fmt.Errorf("invalid port %q in server %q", port, input)
+	}
+	if host == "" || strings.ContainsAny(host, " /") {
+		return "", fmt.Errorf("invalid server %q, e.g. smtp.example.com:587", input)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// ParseEmailAddress validates an email address, with or without a name, e.g.
+// "Lifter <lifter@example.com>"
+func ParseEmailAddress(input string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(input))
+	if err != nil {
+		return "", fmt.Errorf("invalid email address %q", input)
+	}
+	return address.String(), nil
+}
+
+// IsURLHook reports whether a hook is a URL to POST to rather than a command
+func IsURLHook(hook string) bool {
+	lower := strings.ToLower(hook)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// BarWeightFor returns the empty bar weight used in a lift's warmups, in unit:
+// a custom lift's own bar, then the configured bar, then a standard barbell.
+// A nil config uses the defaults.
+func (c *Config) BarWeightFor(name LiftName, unit WeightUnit) float64 {
+	if c == nil || c.BarWeight == 0 {
+		return BarWeightFor(name, unit)
+	}
+	if def, ok := LookupLift(name); ok && def.BarWeight != 0 {
+		return BarWeightFor(name, unit)
+	}
+	return roundToStep(ConvertWeight(c.BarWeight, c.BarUnit, unit), unit)
+}
+
+// WeeklyTargetFor returns the sessions a week that keep a consistency streak
+// going: the configured target, or else one per training day of the program.
+// A nil config or program uses the defaults.
+func (c *Config) WeeklyTargetFor(up *UserProgram) int {
+	if c != nil && c.WeeklyTarget > 0 {
+		return c.WeeklyTarget
+	}
+	if up == nil {
+		return len(DefaultTrainingDays)
+	}
+	return len(up.TrainingDaysOrDefault())
+}
+
+// HeaviestPlate returns the heaviest plate in unit that can be loaded as a
+// pair, falling back to a standard plate when no inventory in unit is set
+func (c *Config) HeaviestPlate(unit WeightUnit) float64 {
+	if c != nil && c.PlateUnit.OrDefault() == unit.OrDefault() {
+		for _, plate := range c.Plates {
+			if plate.Count >= 2 {
+				return plate.Weight
+			}
+		}
+	}
+	return unit.StandardPlate()
+}
+
+// PlateCount is the number of plates of one weight available to load a bar
+type PlateCount struct {
+	Weight float64 `json:"weight"`
+	Count  int     `json:"count"`
+}
+
+// ParsePlates converts user input such as "45x4,25x2,10x2" into a plate
+// inventory, heaviest first. Entries may be separated by commas or spaces.
+func ParsePlates(input string) ([]PlateCount, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one plate is required, e.g. 45x4,25x2")
+	}
+
+	plates := make([]PlateCount, 0, len(fields))
+	for _, field := range fields {
+		weightInput, countInput, found := strings.Cut(strings.ToLower(field), "x")
+		if !found {
+			return nil, fmt.Errorf("invalid plates %q: expected <weight>x<count>, e.g. 45x4", field)
+		}
+		weight, err := strconv.ParseFloat(weightInput, 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid plate weight %q: must be a positive number", weightInput)
+		}
+		count, err := strconv.Atoi(countInput)
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid plate count %q: must be a positive whole number", countInput)
+		}
+		if slices.ContainsFunc(plates, func(p PlateCount) bool { return p.Weight == weight }) {
+			return nil, fmt.Errorf("plate weight %g is listed more than once", weight)
+		}
+		plates = append(plates, PlateCount{Weight: weight, Count: count})
+	}
+
+	slices.SortFunc(plates, func(a, b PlateCount) int { return cmp.Compare(b.Weight, a.Weight) })
+	return plates, nil
+}
+
+// ParseWeight converts user input such as "35", "35lbs", or "15 kg" into a
+// weight and its unit; a bare number is in defaultUnit
+func ParseWeight(input string, defaultUnit WeightUnit) (float64, WeightUnit, error) {
+	input = strings.TrimSpace(input)
+	number := strings.TrimRightFunc(input, func(r rune) bool { return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' })
+	weight, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || weight < 0 {
+		return 0, "", fmt.Errorf("invalid weight %q: must be a non-negative number", input)
+	}
+
+	unit := defaultUnit.OrDefault()
+	if suffix := input[len(number):]; suffix != "" {
+		if unit, err = ParseWeightUnit(suffix); err != nil {
+			return 0, "", err
+		}
+	}
+	return weight, unit, nil
+}
+
+// roundToStep rounds a weight to the nearest weight loadable in unit
+func roundToStep(weight float64, unit WeightUnit) float64 {
+	step := unit.RoundingStep()
+	return math.Round(weight/step) * step
+}
+
+// DateFormat is how dates are shown in workout history and stats
+type DateFormat string
+
+const (
+	DateISO  DateFormat = "iso"  // 2024-03-04
+	DateUS   DateFormat = "us"   // 03/04/2024
+	DateEU   DateFormat = "eu"   // 04/03/2024
+	DateLong DateFormat = "long" // Mar 4, 2024
+)
+
+// dateLayouts maps each date format to its time layout
+var dateLayouts = map[DateFormat]string{
+	DateISO:  "2006-01-02",
+	DateUS:   "01/02/2006",
+	DateEU:   "02/01/2006",
+	DateLong: "Jan 2, 2006",
+}
+
+// ParseDateFormat converts user input such as "iso" or "US" into a DateFormat
+func ParseDateFormat(input string) (DateFormat, error) {
+	format := DateFormat(strings.ToLower(strings.TrimSpace(input)))
+	if _, ok := dateLayouts[format]; !ok {
+		return "", fmt.Errorf("unknown date format %q (expected iso, us, eu, or long)", input)
+	}
+	return format, nil
+}
+
+// OrDefault returns the format, or DateISO for the zero value
+func (f DateFormat) OrDefault() DateFormat {
+	if f == "" {
+		return DateISO
+	}
+	return f
+}
+
+// Format formats a date in this format
+func (f DateFormat) Format(t time.Time) string {
+	return t.Format(dateLayouts[f.OrDefault()])
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlates(t *testing.T) {
+	plates, err := ParsePlates("10x2, 45x4 2.5X2")
+	require.NoError(t, err)
+	assert.Equal(t, []PlateCount{{Weight: 45, Count: 4}, {Weight: 10, Count: 2}, {Weight: 2.5, Count: 2}}, plates)
+
+	for input, message := range map[string]string{
+		"":          "at least one plate is required",
+		"45":        "expected <weight>x<count>",
+		"fortyx2":   `invalid plate weight "forty"`,
+		"45x0":      `invalid plate count "0"`,
+		"45x2,45x4": "plate weight 45 is listed more than once",
+	} {
+		_, err := ParsePlates(input)
+		assert.ErrorContains(t, err, message, input)
+	}
+}
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		input  string
+		weight float64
+		unit   WeightUnit
+	}{
+		{"35", 35, Pounds},
+		{"35lbs", 35, Pounds},
+		{"15 kg", 15, Kilograms},
+		{"7.5KG", 7.5, Kilograms},
+	}
+	for _, tt := range tests {
+		weight, unit, err := ParseWeight(tt.input, "")
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.weight, weight, tt.input)
+		assert.Equal(t, tt.unit, unit, tt.input)
+	}
+
+	_, unit, err := ParseWeight("20", Kilograms)
+	require.NoError(t, err)
+	assert.Equal(t, Kilograms, unit)
+
+	_, _, err = ParseWeight("20 stone", Pounds)
+	assert.ErrorContains(t, err, "unknown weight unit")
+	_, _, err = ParseWeight("heavy", Pounds)
+	assert.ErrorContains(t, err, `invalid weight "heavy"`)
+}
+
+func TestConfig_BarWeightFor(t *testing.T) {
+	t.Cleanup(func() { RegisterLifts(nil) })
+	RegisterLifts([]LiftDefinition{{Name: "SafetySquat", DisplayName: "Safety Squat", BarWeight: 65}})
+
+	var defaults *Config
+	assert.Equal(t, 45.0, defaults.BarWeightFor(Squat, Pounds))
+
+	config := &Config{BarWeight: 15, BarUnit: Kilograms}
+	assert.Equal(t, 15.0, config.BarWeightFor(Squat, Kilograms))
+	assert.Equal(t, 32.5, config.BarWeightFor(Squat, Pounds))
+	// Custom lifts with their own bar keep it
+	assert.Equal(t, 65.0, config.BarWeightFor("SafetySquat", Pounds))
+}
+
+func TestConfig_HeaviestPlate(t *testing.T) {
+	var defaults *Config
+	assert.Equal(t, 45.0, defaults.HeaviestPlate(Pounds))
+	assert.Equal(t, 20.0, defaults.HeaviestPlate(Kilograms))
+
+	// A single plate can't be loaded on both sides
+	config := &Config{Plates: []PlateCount{{Weight: 45, Count: 1}, {Weight: 35, Count: 2}}}
+	assert.Equal(t, 35.0, config.HeaviestPlate(Pounds))
+	// Plates in another unit aren't used
+	assert.Equal(t, 20.0, config.HeaviestPlate(Kilograms))
+}
+
+func TestDateFormat(t *testing.T) {
+	date := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+
+	var unset DateFormat
+	assert.Equal(t, "2024-03-04", unset.Format(date))
+	assert.Equal(t, "03/04/2024", DateUS.Format(date))
+	assert.Equal(t, "04/03/2024", DateEU.Format(date))
+	assert.Equal(t, "Mar 4, 2024", DateLong.Format(date))
+
+	format, err := ParseDateFormat(" US ")
+	require.NoError(t, err)
+	assert.Equal(t, DateUS, format)
+	_, err = ParseDateFormat("julian")
+	assert.ErrorContains(t, err, `unknown date format "julian"`)
+}
+
+func TestParseHook(t *testing.T) {
+	hook, err := ParseHook("  https://example.com/hook ")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", hook)
+	assert.True(t, IsURLHook(hook))
+
+	hook, err = ParseHook("notify-send 'Workout logged'")
+	require.NoError(t, err)
+	assert.False(t, IsURLHook(hook))
+
+	_, err = ParseHook(" ")
+	assert.ErrorContains(t, err, "hook cannot be empty")
+	_, err = ParseHook("http:///path")
+	assert.ErrorContains(t, err, "invalid hook URL")
+}
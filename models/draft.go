@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// LogDraft is an unfinished 'workout log' session, saved after each answer so an
+// interrupted session can be resumed where it stopped
+type LogDraft struct {
+	Workout   Workout   `json:"workout"`              // The session being logged, after modifiers and skipped accessories
+	FailMode  bool      `json:"fail_mode,omitempty"`  // Reps are entered for every set, not just AMRAP sets
+	EnteredAt time.Time `json:"entered_at,omitempty"` // Date the workout was performed, zero for today
+	Answers   []int     `json:"answers"`              // Reps entered so far, in the order they were asked for
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Matches reports whether the draft is for the user program's current day, so
+// it can still be resumed
+func (d *LogDraft) Matches(userProgram *UserProgram) bool {
+	return d.Workout.UserProgramID == userProgram.ID && d.Workout.Day == userProgram.CurrentDay
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetHistoryLoader defers reading the user's workout history until it's first
+// used. Storage that keeps history apart from the user sets it when loading, so
+// commands that never look at history don't pay to read it.
+func (u *User) SetHistoryLoader(load func() ([]Workout, error)) {
+	u.historyLoader = load
+	u.historyErr = nil
+}
+
+// LoadHistory reads workout history deferred by SetHistoryLoader if it hasn't
+// been read yet. The history accessors below load it implicitly; call
+// LoadHistory first to handle a failure, or before using WorkoutHistory directly.
+func (u *User) LoadHistory() error {
+	if u.historyLoader == nil {
+		return u.historyErr
+	}
+
+	workouts, err := u.historyLoader()
+	u.historyLoader = nil
+	if err != nil {
+		u.historyErr = err
+		return err
+	}
+	u.WorkoutHistory = workouts
+	return nil
+}
+
+// HistoryLoaded reports whether the user's history has been read, or was never
+// deferred. A user whose history is still deferred can be saved without it.
+func (u *User) HistoryLoaded() bool {
+	return u.historyLoader == nil
+}
+
+// History returns a copy of the user's workout history sorted by EnteredAt.
+// Workouts can be recorded out of order (backdated logs, merged data), so
+// anything that depends on chronology should read history through this
+// accessor rather than relying on append order. Workouts with identical
+// timestamps keep their relative append order.
+func (u *User) History() []Workout {
+	u.LoadHistory()
+	return sortedWorkouts(u.WorkoutHistory)
+}
+
+// HistoryFor returns the sorted workout history for a single UserProgram
+func (u *User) HistoryFor(userProgramID uuid.UUID) []Workout {
+	u.LoadHistory()
+	var workouts []Workout
+	for _, w := range u.WorkoutHistory {
+		if w.UserProgramID == userProgramID {
+			workouts = append(workouts, w)
+		}
+	}
+	return sortedWorkouts(workouts)
+}
+
+// HistoryBetween returns the sorted workouts entered in the half-open range [from, to).
+// A zero from or to leaves that end of the range unbounded.
+func (u *User) HistoryBetween(from, to time.Time) []Workout {
+	u.LoadHistory()
+	var workouts []Workout
+	for _, w := range u.WorkoutHistory {
+		if !from.IsZero() && w.EnteredAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !w.EnteredAt.Before(to) {
+			continue
+		}
+		workouts = append(workouts, w)
+	}
+	return sortedWorkouts(workouts)
+}
+
+// AddWorkout inserts a workout into the history after every workout entered
+// at or before it, keeping the stored history in EnteredAt order
+func (u *User) AddWorkout(w Workout) {
+	u.LoadHistory()
+	i := len(u.WorkoutHistory)
+	for i > 0 && u.WorkoutHistory[i-1].EnteredAt.After(w.EnteredAt) {
+		i--
+	}
+	u.WorkoutHistory = slices.Insert(u.WorkoutHistory, i, w)
+}
+
+// LastWorkout returns the most recent workout by EnteredAt, or false if there is no history
+func (u *User) LastWorkout() (*Workout, bool) {
+	history := u.History()
+	if len(history) == 0 {
+		return nil, false
+	}
+	return &history[len(history)-1], true
+}
+
+// SkipsFor returns the days skipped in a single UserProgram, ordered by SkippedAt
+func (u *User) SkipsFor(userProgramID uuid.UUID) []SkippedDay {
+	var skips []SkippedDay
+	for _, s := range u.SkippedDays {
+		if s.UserProgramID == userProgramID {
+			skips = append(skips, s)
+		}
+	}
+	slices.SortStableFunc(skips, func(a, b SkippedDay) int {
+		return a.SkippedAt.Compare(b.SkippedAt)
+	})
+	return skips
+}
+
+// sortedWorkouts returns a stably sorted copy of workouts ordered by EnteredAt
+func sortedWorkouts(workouts []Workout) []Workout {
+	sorted := slices.Clone(workouts)
+	if sorted == nil {
+		sorted = []Workout{}
+	}
+	slices.SortStableFunc(sorted, func(a, b Workout) int {
+		return a.EnteredAt.Compare(b.EnteredAt)
+	})
+	return sorted
+}
+
+// ProgramList returns the user's programs ordered by start date, oldest first
+func (u *User) ProgramList() []*UserProgram {
+	programs := make([]*UserProgram, 0, len(u.Programs))
+	for _, up := range u.Programs {
+		programs = append(programs, up)
+	}
+	slices.SortFunc(programs, func(a, b *UserProgram) int {
+		if c := a.StartedAt.Compare(b.StartedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return programs
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyTestUser() (*User, uuid.UUID, uuid.UUID) {
+	programA := uuid.New()
+	programB := uuid.New()
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	user := &User{
+		ID:       uuid.New(),
+		Username: "TestUser",
+		WorkoutHistory: []Workout{
+			{ID: uuid.New(), UserProgramID: programA, Day: 3, EnteredAt: base.AddDate(0, 0, 4)},
+			{ID: uuid.New(), UserProgramID: programA, Day: 1, EnteredAt: base},
+			// Backdated log entered with a different zone offset
+			{ID: uuid.New(), UserProgramID: programB, Day: 2, EnteredAt: base.AddDate(0, 0, 2).In(time.FixedZone("EST", -5*3600))},
+			{ID: uuid.New(), UserProgramID: programA, Day: 4, EnteredAt: base.AddDate(0, 0, 6)},
+		},
+	}
+	return user, programA, programB
+}
+
+func TestUserHistory_SortsByEnteredAt(t *testing.T) {
+	user, _, _ := historyTestUser()
+	original := append([]Workout(nil), user.WorkoutHistory...)
+
+	history := user.History()
+	require.Len(t, history, 4)
+	assert.Equal(t, []int{1, 2, 3, 4}, workoutDays(history))
+
+	// The underlying history is left untouched
+	assert.Equal(t, original, user.WorkoutHistory)
+}
+
+func TestUserHistory_StableForEqualTimestamps(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	user := &User{WorkoutHistory: []Workout{
+		{Day: 2, EnteredAt: at},
+		{Day: 1, EnteredAt: at},
+	}}
+
+	assert.Equal(t, []int{2, 1}, workoutDays(user.History()))
+}
+
+func TestUserHistory_Empty(t *testing.T) {
+	user := &User{}
+
+	assert.Empty(t, user.History())
+	assert.NotNil(t, user.History())
+
+	last, ok := user.LastWorkout()
+	assert.False(t, ok)
+	assert.Nil(t, last)
+}
+
+func TestUserHistoryFor(t *testing.T) {
+	user, programA, programB := historyTestUser()
+
+	assert.Equal(t, []int{1, 3, 4}, workoutDays(user.HistoryFor(programA)))
+	assert.Equal(t, []int{2}, workoutDays(user.HistoryFor(programB)))
+	assert.Empty(t, user.HistoryFor(uuid.New()))
+}
+
+func TestUserHistoryBetween(t *testing.T) {
+	user, _, _ := historyTestUser()
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []int{2, 3}, workoutDays(user.HistoryBetween(base.AddDate(0, 0, 1), base.AddDate(0, 0, 6))))
+	assert.Equal(t, []int{3, 4}, workoutDays(user.HistoryBetween(base.AddDate(0, 0, 3), time.Time{})))
+	assert.Equal(t, []int{1, 2}, workoutDays(user.HistoryBetween(time.Time{}, base.AddDate(0, 0, 3))))
+}
+
+func TestUserLastWorkout(t *testing.T) {
+	user, _, _ := historyTestUser()
+
+	last, ok := user.LastWorkout()
+	require.True(t, ok)
+	assert.Equal(t, 4, last.Day)
+}
+
+func TestUserAddWorkout(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	user := &User{}
+
+	user.AddWorkout(Workout{Day: 2, EnteredAt: at.AddDate(0, 0, 2)})
+	user.AddWorkout(Workout{Day: 1, EnteredAt: at})
+	user.AddWorkout(Workout{Day: 3, EnteredAt: at.AddDate(0, 0, 2)})
+	user.AddWorkout(Workout{Day: 4, EnteredAt: at.AddDate(0, 0, 3)})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, workoutDays(user.WorkoutHistory))
+}
+
+func TestUserSkipsFor(t *testing.T) {
+	programA, programB := uuid.New(), uuid.New()
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	user := &User{SkippedDays: []SkippedDay{
+		{UserProgramID: programA, Day: 3, SkippedAt: at.AddDate(0, 0, 2)},
+		{UserProgramID: programB, Day: 1, SkippedAt: at.AddDate(0, 0, 1)},
+		{UserProgramID: programA, Day: 2, SkippedAt: at},
+	}}
+
+	skips := user.SkipsFor(programA)
+	require.Len(t, skips, 2)
+	assert.Equal(t, 2, skips[0].Day)
+	assert.Equal(t, 3, skips[1].Day)
+	assert.Empty(t, user.SkipsFor(uuid.New()))
+}
+
+func workoutDays(workouts []Workout) []int {
+	days := make([]int, len(workouts))
+	for i, w := range workouts {
+		days[i] = w.Day
+	}
+	return days
+}
+
+func TestUserProgramList(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	first := &UserProgram{ID: uuid.New(), StartedAt: base}
+	second := &UserProgram{ID: uuid.New(), StartedAt: base.AddDate(0, 1, 0)}
+	third := &UserProgram{ID: uuid.New(), StartedAt: base.AddDate(0, 2, 0)}
+
+	user := &User{Programs: map[uuid.UUID]*UserProgram{
+		third.ID:  third,
+		first.ID:  first,
+		second.ID: second,
+	}}
+
+	assert.Equal(t, []*UserProgram{first, second, third}, user.ProgramList())
+	assert.Empty(t, (&User{}).ProgramList())
+}
+
+func TestUser_HistoryLoader(t *testing.T) {
+	loaded, _, _ := historyTestUser()
+	calls := 0
+
+	user := &User{ID: loaded.ID, Username: "TestUser"}
+	user.SetHistoryLoader(func() ([]Workout, error) {
+		calls++
+		return loaded.WorkoutHistory, nil
+	})
+	assert.False(t, user.HistoryLoaded())
+	assert.Equal(t, 0, calls)
+
+	// Accessors load the history once, on first use
+	assert.Len(t, user.History(), 4)
+	assert.Len(t, user.History(), 4)
+	assert.Equal(t, 1, calls)
+	assert.True(t, user.HistoryLoaded())
+	assert.NoError(t, user.LoadHistory())
+}
+
+func TestUser_HistoryLoaderError(t *testing.T) {
+	user := &User{Username: "TestUser"}
+	user.SetHistoryLoader(func() ([]Workout, error) {
+		return nil, assert.AnError
+	})
+
+	assert.Empty(t, user.History())
+	// The failure is kept, so the user can't be saved as if it had no history
+	assert.ErrorIs(t, user.LoadHistory(), assert.AnError)
+	assert.True(t, user.HistoryLoaded())
+}
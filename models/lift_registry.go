@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LiftDefinition describes a lift beyond the four built-in barbell lifts, such
+// as FrontSquat or RomanianDeadlift, so custom programs can use it without
+// spelling out every detail. Increment and BarWeight are in Unit.
+type LiftDefinition struct {
+	Name        LiftName   `json:"name"`
+	DisplayName string     `json:"display_name"`
+	Aliases     []string   `json:"aliases,omitempty"`
+	Increment   float64    `json:"increment,omitempty"`  // Default progression increment when a program has no rule for the lift
+	BarWeight   float64    `json:"bar_weight,omitempty"` // Empty bar weight for warmups; zero means a standard barbell
+	Unit        WeightUnit `json:"unit,omitempty"`
+	Cues        []string   `json:"cues,omitempty"` // Technique reminders for programs that give none
+	URL         string     `json:"url,omitempty"`  // Demonstration link for programs that give none
+}
+
+// liftNamePattern matches lift names: a letter followed by letters and digits
+var liftNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// Validate checks that a lift definition is well formed
+func (d *LiftDefinition) Validate() error {
+	if !liftNamePattern.MatchString(string(d.Name)) {
+		return fmt.Errorf("invalid lift name %q: use letters and digits only, e.g. FrontSquat", d.Name)
+	}
+	if strings.TrimSpace(d.DisplayName) == "" {
+		return fmt.Errorf("lift %s needs a display name", d.Name)
+	}
+	if d.Increment < 0 {
+		return fmt.Errorf("lift %s increment cannot be negative, got %g", d.Name, d.Increment)
+	}
+	if d.BarWeight < 0 {
+		return fmt.Errorf("lift %s bar weight cannot be negative, got %g", d.Name, d.BarWeight)
+	}
+	if d.Unit != "" && d.Unit != Pounds && d.Unit != Kilograms {
+		return fmt.Errorf("lift %s unit must be %s or %s, got %q", d.Name, Pounds, Kilograms, d.Unit)
+	}
+	if slices.ContainsFunc(d.Cues, func(cue string) bool { return strings.TrimSpace(cue) == "" }) {
+		return fmt.Errorf("lift %s cues cannot be empty", d.Name)
+	}
+	if err := validateCoachingURL(d.URL); err != nil {
+		return fmt.Errorf("lift %s url %w", d.Name, err)
+	}
+	return nil
+}
+
+// builtinLifts defines the four lifts every program is built from. Their
+// increments always come from the program's progression rules.
+var builtinLifts = []LiftDefinition{
+	{Name: Squat, DisplayName: "Squat"},
+	{Name: Deadlift, DisplayName: "Deadlift"},
+	{Name: BenchPress, DisplayName: "Bench Press"},
+	{Name: OverheadPress, DisplayName: "Overhead Press"},
+}
+
+// liftRegistry holds the custom lift definitions loaded from storage
+var liftRegistry = struct {
+	sync.RWMutex
+	lifts []LiftDefinition
+}{}
+
+// RegisterLifts replaces the registered custom lifts. Definitions that reuse a
+// built-in lift's name are ignored.
+func RegisterLifts(definitions []LiftDefinition) {
+	liftRegistry.Lock()
+	defer liftRegistry.Unlock()
+
+	liftRegistry.lifts = nil
+	for _, def := range definitions {
+		if !IsBuiltinLift(def.Name) {
+			liftRegistry.lifts = append(liftRegistry.lifts, def)
+		}
+	}
+}
+
+// IsBuiltinLift reports whether name is one of the four built-in lifts
+func IsBuiltinLift(name LiftName) bool {
+	return slices.ContainsFunc(builtinLifts, func(def LiftDefinition) bool { return def.Name == name })
+}
+
+// Lifts returns the built-in lifts followed by the registered custom lifts
+func Lifts() []LiftDefinition {
+	liftRegistry.RLock()
+	defer liftRegistry.RUnlock()
+	return append(slices.Clone(builtinLifts), liftRegistry.lifts...)
+}
+
+// LookupLift returns the definition of a built-in or registered lift
+func LookupLift(name LiftName) (LiftDefinition, bool) {
+	for _, def := range Lifts() {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return LiftDefinition{}, false
+}
+
+// DisplayName returns the name a lift is shown by, e.g. "Bench Press", with
+// any variant in parentheses, e.g. "Squat (SSB)". Unregistered lifts are shown
+// by their lift name.
+func (n LiftName) DisplayName() string {
+	if base, variant := n.SplitVariant(); variant != "" {
+		return fmt.Sprintf("%s (%s)", base.DisplayName(), variant)
+	}
+	if def, ok := LookupLift(n); ok {
+		return def.DisplayName
+	}
+	return string(n)
+}
+
+// lookupLiftInput finds a registered custom lift by name or alias, ignoring
+// case; the name may be written with dashes, e.g. "front-squat" for FrontSquat
+func lookupLiftInput(key string) (LiftName, bool) {
+	liftRegistry.RLock()
+	defer liftRegistry.RUnlock()
+
+	for _, def := range liftRegistry.lifts {
+		if strings.EqualFold(string(def.Name), strings.ReplaceAll(key, "-", "")) || slices.ContainsFunc(def.Aliases, func(alias string) bool {
+			return strings.EqualFold(alias, key)
+		}) {
+			return def.Name, true
+		}
+	}
+	return "", false
+}
+
+// DefaultIncrement returns a lift's default progression increment in unit, for
+// lifts without an increase rule in their program. Only custom lifts have one.
+func DefaultIncrement(name LiftName, unit WeightUnit) (float64, bool) {
+	if IsBuiltinLift(name) {
+		return 0, false
+	}
+	def, ok := LookupLift(name)
+	if !ok || def.Increment == 0 {
+		return 0, false
+	}
+	return ConvertIncrement(def.Increment, def.Unit, unit), true
+}
+
+// BarWeightFor returns the empty bar weight used in a lift's warmups, in unit
+func BarWeightFor(name LiftName, unit WeightUnit) float64 {
+	def, ok := LookupLift(name)
+	if !ok || def.BarWeight == 0 {
+		return unit.BarWeight()
+	}
+	return roundToStep(ConvertWeight(def.BarWeight, def.Unit, unit), unit)
+}
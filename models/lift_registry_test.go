@@ -0,0 +1,117 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerTestLifts(t *testing.T, definitions ...LiftDefinition) {
+	RegisterLifts(definitions)
+	t.Cleanup(func() { RegisterLifts(nil) })
+}
+
+func TestLiftRegistry_Lookup(t *testing.T) {
+	registerTestLifts(t,
+		LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Aliases: []string{"fsq"}, Increment: 5},
+		LiftDefinition{Name: Squat, DisplayName: "Back Squat"},
+	)
+
+	def, ok := LookupLift("FrontSquat")
+	require.True(t, ok)
+	assert.Equal(t, "Front Squat", def.DisplayName)
+
+	// Built-in lifts can't be redefined
+	def, ok = LookupLift(Squat)
+	require.True(t, ok)
+	assert.Equal(t, "Squat", def.DisplayName)
+	assert.Len(t, Lifts(), 5)
+
+	_, ok = LookupLift("Curl")
+	assert.False(t, ok)
+}
+
+func TestLiftRegistry_ParseLiftName(t *testing.T) {
+	registerTestLifts(t, LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Aliases: []string{"fsq"}})
+
+	for _, input := range []string{"FrontSquat", "frontsquat", "front-squat", "Front Squat", "FSQ"} {
+		lift, err := ParseLiftName(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, LiftName("FrontSquat"), lift, input)
+	}
+
+	lift, err := ParseLiftName("squat")
+	require.NoError(t, err)
+	assert.Equal(t, Squat, lift)
+
+	_, err = ParseLiftName("curl")
+	assert.ErrorContains(t, err, "greyskull lift define")
+}
+
+func TestLiftRegistry_Defaults(t *testing.T) {
+	registerTestLifts(t,
+		LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Increment: 5, BarWeight: 35},
+		LiftDefinition{Name: "Landmine", DisplayName: "Landmine Press", BarWeight: 15, Unit: Kilograms},
+	)
+
+	increment, ok := DefaultIncrement("FrontSquat", Pounds)
+	require.True(t, ok)
+	assert.Equal(t, 5.0, increment)
+	increment, ok = DefaultIncrement("FrontSquat", Kilograms)
+	require.True(t, ok)
+	assert.Equal(t, 2.5, increment)
+
+	_, ok = DefaultIncrement("Landmine", Pounds)
+	assert.False(t, ok)
+	_, ok = DefaultIncrement(Squat, Pounds)
+	assert.False(t, ok)
+
+	assert.Equal(t, 35.0, BarWeightFor("FrontSquat", Pounds))
+	assert.Equal(t, 32.5, BarWeightFor("Landmine", Pounds))
+	assert.Equal(t, 15.0, BarWeightFor("Landmine", Kilograms))
+	assert.Equal(t, 45.0, BarWeightFor(Squat, Pounds))
+	assert.Equal(t, 20.0, BarWeightFor("Curl", Kilograms))
+
+	// Programs fall back to the default increment for lifts without a rule
+	rules := &ProgressionRules{IncreaseRules: map[LiftName]float64{Squat: 5}}
+	increment, ok = rules.IncrementFor("FrontSquat:SSB")
+	require.True(t, ok)
+	assert.Equal(t, 5.0, increment)
+}
+
+func TestLiftDefinition_Validate(t *testing.T) {
+	valid := LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Increment: 5}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*LiftDefinition)
+		errMsg string
+	}{
+		{"bad name", func(d *LiftDefinition) { d.Name = "Front Squat" }, "invalid lift name"},
+		{"variant separator", func(d *LiftDefinition) { d.Name = "Squat:SSB" }, "invalid lift name"},
+		{"no display name", func(d *LiftDefinition) { d.DisplayName = " " }, "needs a display name"},
+		{"negative increment", func(d *LiftDefinition) { d.Increment = -5 }, "increment cannot be negative"},
+		{"negative bar", func(d *LiftDefinition) { d.BarWeight = -1 }, "bar weight cannot be negative"},
+		{"bad unit", func(d *LiftDefinition) { d.Unit = "stone" }, "unit must be"},
+		{"empty cue", func(d *LiftDefinition) { d.Cues = []string{""} }, "cues cannot be empty"},
+		{"bad url", func(d *LiftDefinition) { d.URL = "ftp://example.com/squat" }, "url must be an http or https URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := valid
+			tt.modify(&def)
+			assert.ErrorContains(t, def.Validate(), tt.errMsg)
+		})
+	}
+}
+
+func TestLiftName_DisplayName(t *testing.T) {
+	registerTestLifts(t, LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat"})
+
+	assert.Equal(t, "Bench Press", BenchPress.DisplayName())
+	assert.Equal(t, "Front Squat", LiftName("FrontSquat").DisplayName())
+	assert.Equal(t, "Squat (SSB)", LiftName("Squat:SSB").DisplayName())
+	assert.Equal(t, "Curl", LiftName("Curl").DisplayName())
+}
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,11 +25,40 @@ const (
 	OverheadPress LiftName = "OverheadPress"
 )
 
+// liftAliases maps lowercase user input to lift names
+var liftAliases = map[string]LiftName{
+	"squat":          Squat,
+	"deadlift":       Deadlift,
+	"dl":             Deadlift,
+	"benchpress":     BenchPress,
+	"bench":          BenchPress,
+	"bench-press":    BenchPress,
+	"overheadpress":  OverheadPress,
+	"overhead-press": OverheadPress,
+	"press":          OverheadPress,
+	"ohp":            OverheadPress,
+}
+
+// ParseLiftName converts user input such as "squat", "bench", or "ohp", or the
+// name or alias of a registered custom lift, into a LiftName
+func ParseLiftName(input string) (LiftName, error) {
+	key := strings.ToLower(strings.TrimSpace(input))
+	key = strings.ReplaceAll(key, " ", "-")
+	if lift, ok := liftAliases[key]; ok {
+		return lift, nil
+	}
+	if lift, ok := lookupLiftInput(key); ok {
+		return lift, nil
+	}
+	return "", fmt.Errorf("unknown lift %q (expected squat, deadlift, bench, ohp, or a lift added with 'greyskull lift define')", input)
+}
+
 // SetType constants
 const (
 	WarmupSet  SetType = "WarmupSet"
 	WorkingSet SetType = "WorkingSet"
 	AMRAPSet   SetType = "AMRAPSet"
+	FeelerSet  SetType = "FeelerSet"
 )
 
 // User domain structs
@@ -35,8 +67,40 @@ type User struct {
 	Username       string                     `json:"username"`
 	CurrentProgram uuid.UUID                  `json:"current_program"` // UUID ref
 	Programs       map[uuid.UUID]*UserProgram `json:"programs"`
-	WorkoutHistory []Workout                  `json:"workout_history"`
+	WorkoutHistory []Workout                  `json:"workout_history,omitempty"`
+	SkippedDays    []SkippedDay               `json:"skipped_days,omitempty"`
+	WeightResets   []WeightReset              `json:"weight_resets,omitempty"`
 	CreatedAt      time.Time                  `json:"created_at"`
+	RestTimes      *RestTimes                 `json:"rest_times,omitempty"`  // Overrides the program's rest times
+	Unit           WeightUnit                 `json:"unit,omitempty"`        // Unit for newly started programs
+	Leaderboard    bool                       `json:"leaderboard,omitempty"` // Opted in to the shared leaderboard
+
+	// ActivePrograms are programs trained alongside CurrentProgram, such as a
+	// conditioning template, in the order they were activated
+	ActivePrograms []uuid.UUID `json:"active_programs,omitempty"`
+
+	// WarmupPercentages overrides the program's warmup ramp for individual lifts
+	WarmupPercentages map[LiftName]WarmupPercentages `json:"warmup_percentages,omitempty"`
+
+	// Milestones are the training milestones the user has unlocked, in the
+	// order they were unlocked
+	Milestones []UnlockedMilestone `json:"milestones,omitempty"`
+
+	// SchemaVersion is the version of this file's layout, used to upgrade files
+	// written by older versions of greyskull when they are loaded
+	SchemaVersion int `json:"schema_version"`
+
+	// historyLoader reads WorkoutHistory the first time it's needed, for users
+	// whose history is stored apart from the user; historyErr is its failure
+	historyLoader func() ([]Workout, error)
+	historyErr    error
+}
+
+// UnlockedMilestone records when a user reached a training milestone, such as
+// their first 225 lb squat, identified by the milestone's ID
+type UnlockedMilestone struct {
+	ID         string    `json:"id"`
+	UnlockedAt time.Time `json:"unlocked_at"`
 }
 
 type UserProgram struct {
@@ -47,6 +111,89 @@ type UserProgram struct {
 	CurrentWeights  map[LiftName]float64 `json:"current_weights"`
 	CurrentDay      int                  `json:"current_day"`
 	StartedAt       time.Time            `json:"started_at"`
+	Holds           map[LiftName]int     `json:"holds,omitempty"`  // Remaining sessions each lift's weight is held constant
+	Deload          *DeloadPlan          `json:"deload,omitempty"` // Temporary reduced sessions in place of normal programming
+	Unit            WeightUnit           `json:"unit,omitempty"`   // Unit of all weights in this program
+
+	// RepTargets are the working set reps of bodyweight lifts that progress by
+	// reps. Lifts without a target use the program's reps.
+	RepTargets map[LiftName]int `json:"rep_targets,omitempty"`
+
+	// Goals are target working weights set with 'greyskull goal set'
+	Goals map[LiftName]float64 `json:"goals,omitempty"`
+
+	// GoalDeadlines are the dates goals are to be reached by, for goals set
+	// with 'greyskull goal set --by'
+	GoalDeadlines map[LiftName]time.Time `json:"goal_deadlines,omitempty"`
+
+	// DeloadStreaks track lifts that keep deloading without getting past the
+	// weight they deloaded from
+	DeloadStreaks map[LiftName]DeloadStreak `json:"deload_streaks,omitempty"`
+
+	// TrainingDays are the weekdays the program is trained on, in week order
+	TrainingDays []time.Weekday `json:"training_days,omitempty"`
+
+	// TrainingMaxes are the weights that sets of a percentage-based program
+	// are calculated from. A lift without one uses its current weight.
+	TrainingMaxes map[LiftName]float64 `json:"training_maxes,omitempty"`
+
+	// Pauses are the times the program was set aside, oldest first. Only the
+	// last may still be open.
+	Pauses []Pause `json:"pauses,omitempty"`
+
+	// RoundingSteps are the steps, in Unit, that lifts' weights are rounded
+	// down to, e.g. 1.25 lbs with microplates. Other lifts use Unit's step.
+	RoundingSteps map[LiftName]float64 `json:"rounding_steps,omitempty"`
+
+	// ProgressionRules are the program's progression rules as customized when
+	// it was started, in Unit. Without them the template's rules are used.
+	ProgressionRules *ProgressionRules `json:"progression_rules,omitempty"`
+
+	// Program is the template as it was when the program was started or last
+	// upgraded with 'greyskull program upgrade', so later changes to the
+	// template don't change a program in progress. Programs started before
+	// snapshots were kept have none and follow the template.
+	Program *Program `json:"program,omitempty"`
+}
+
+// Clone returns a copy of the UserProgram that shares no mutable state with it
+func (up *UserProgram) Clone() *UserProgram {
+	clone := *up
+	clone.StartingWeights = maps.Clone(up.StartingWeights)
+	clone.CurrentWeights = maps.Clone(up.CurrentWeights)
+	clone.Holds = maps.Clone(up.Holds)
+	clone.RepTargets = maps.Clone(up.RepTargets)
+	clone.Goals = maps.Clone(up.Goals)
+	clone.GoalDeadlines = maps.Clone(up.GoalDeadlines)
+	clone.TrainingMaxes = maps.Clone(up.TrainingMaxes)
+	clone.RoundingSteps = maps.Clone(up.RoundingSteps)
+	clone.DeloadStreaks = maps.Clone(up.DeloadStreaks)
+	clone.TrainingDays = slices.Clone(up.TrainingDays)
+	clone.Pauses = slices.Clone(up.Pauses)
+	if up.Deload != nil {
+		deload := *up.Deload
+		clone.Deload = &deload
+	}
+	if up.ProgressionRules != nil {
+		clone.ProgressionRules = up.ProgressionRules.Clone()
+	}
+	return &clone
+}
+
+// DeloadPlan replaces a program's sets with lighter straight sets for a number of
+// sessions. Weights do not progress while a deload is in effect.
+type DeloadPlan struct {
+	Percentage        float64 `json:"percentage"` // Fraction of each lift's current weight, e.g. 0.8
+	Sets              int     `json:"sets"`
+	Reps              int     `json:"reps"`
+	SessionsRemaining int     `json:"sessions_remaining"`
+}
+
+// DeloadStreak counts a lift's deloads since it last got past the heaviest
+// weight it deloaded from
+type DeloadStreak struct {
+	Deloads int     `json:"deloads"`
+	Weight  float64 `json:"weight"` // The heaviest weight deloaded from during the streak
 }
 
 type Workout struct {
@@ -55,18 +202,62 @@ type Workout struct {
 	Day           int       `json:"day"`
 	Exercises     []Lift    `json:"exercises"`
 	EnteredAt     time.Time `json:"entered_at"`
+	Quick         bool      `json:"quick,omitempty"`       // Warmups were trimmed to save time
+	Incomplete    bool      `json:"incomplete,omitempty"`  // Abandoned partway; the day is repeated
+	Notes         string    `json:"notes,omitempty"`       // Free-form notes, possibly several lines
+	AdHoc         bool      `json:"ad_hoc,omitempty"`      // Logged outside any program, so it has no program or day
+	BodyWeight    float64   `json:"body_weight,omitempty"` // The lifter's body weight that day, in the program's unit
+
+	// Held are the lifts whose weights were held rather than progressed by the
+	// workout, so that replaying it holds them again
+	Held []LiftName `json:"held,omitempty"`
+}
+
+// SkippedDay records a program day that was skipped instead of trained
+type SkippedDay struct {
+	ID            uuid.UUID `json:"id"`
+	UserProgramID uuid.UUID `json:"user_program_id"`
+	Day           int       `json:"day"`
+	Reason        string    `json:"reason,omitempty"`
+	SkippedAt     time.Time `json:"skipped_at"`
+}
+
+// WeightReset records a program's weights being reset, e.g. to ease back in
+// after a layoff
+type WeightReset struct {
+	ID            uuid.UUID            `json:"id"`
+	UserProgramID uuid.UUID            `json:"user_program_id"`
+	ResetAt       time.Time            `json:"reset_at"`
+	DaysOff       int                  `json:"days_off"` // Days since the program was last trained
+	Previous      map[LiftName]float64 `json:"previous"`
+	Weights       map[LiftName]float64 `json:"weights"`
 }
 
 type Lift struct {
-	ID       uuid.UUID `json:"id"`
-	LiftName LiftName  `json:"lift_name"`
-	Sets     []Set     `json:"sets"`
+	ID         uuid.UUID `json:"id"`
+	LiftName   LiftName  `json:"lift_name"`
+	Variant    string    `json:"variant,omitempty"`    // Alternate bar or implement, e.g. "SSB" or "TrapBar"
+	Optional   bool      `json:"optional,omitempty"`   // Rep-based accessory; never weight-tracked or progressed
+	Bodyweight bool      `json:"bodyweight,omitempty"` // Set weights are added weight, negative for assistance
+	Sets       []Set     `json:"sets"`
+
+	// FixedWeight marks a weight-tracked lift that never progresses automatically
+	FixedWeight bool `json:"fixed_weight,omitempty"`
+
+	// Group names the superset the lift was performed in, if any
+	Group string `json:"group,omitempty"`
+
+	// SubstitutedFor is the weight key of the program lift this one was
+	// performed in place of, e.g. Squat for a front squat done with a sore back.
+	// The program lift's weight is held, and this lift isn't progressed.
+	SubstitutedFor LiftName `json:"substituted_for,omitempty"`
 }
 
 type Set struct {
 	ID         uuid.UUID `json:"id"`
 	Weight     float64   `json:"weight"`
 	TargetReps int       `json:"target_reps"`
+	MaxReps    int       `json:"max_reps,omitempty"` // Top of a rep range starting at TargetReps
 	ActualReps int       `json:"actual_reps"`
 	Type       SetType   `json:"type"`
 	Order      int       `json:"order"`
@@ -74,11 +265,21 @@ type Set struct {
 
 // Program template structs
 type Program struct {
-	ID               uuid.UUID         `json:"id"`
+	ID               uuid.UUID         `json:"id,omitzero"` // Generated on import if empty
 	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
 	Version          string            `json:"version"`
 	Workouts         []WorkoutTemplate `json:"workouts"`
 	ProgressionRules ProgressionRules  `json:"progression_rules"`
+	RestTimes        *RestTimes        `json:"rest_times,omitempty"`
+	WarmupScheme     *WarmupScheme     `json:"warmup_scheme,omitempty"` // Percentage ramps if empty
+}
+
+// RestTimes are the rest periods between sets used by the workout log timer.
+// A zero value leaves that rest period to the program or the default.
+type RestTimes struct {
+	WarmupSeconds  int `json:"warmup_seconds,omitempty"`
+	WorkingSeconds int `json:"working_seconds,omitempty"`
 }
 
 type WorkoutTemplate struct {
@@ -87,21 +288,80 @@ type WorkoutTemplate struct {
 }
 
 type LiftTemplate struct {
-	LiftName    LiftName      `json:"lift_name"`
-	WarmupSets  []SetTemplate `json:"warmup_sets"`
-	WorkingSets []SetTemplate `json:"working_sets"`
+	LiftName    LiftName        `json:"lift_name"`
+	Variant     string          `json:"variant,omitempty"` // Alternate bar with its own weight track
+	WarmupSets  []SetTemplate   `json:"warmup_sets"`
+	WorkingSets []SetTemplate   `json:"working_sets"`
+	Feeler      *FeelerTemplate `json:"feeler,omitempty"`
+
+	// Bodyweight marks a movement such as chin-ups or dips whose tracked weight
+	// is the weight added to the lifter's body: 0 for bodyweight alone, negative
+	// for assistance. Bodyweight lifts have no warmups.
+	Bodyweight bool `json:"bodyweight,omitempty"`
+
+	// Optional marks an accessory slot, such as chin-ups or curls, that the lifter
+	// may skip. Optional lifts are rep-based: they have no warmups, tracked weight,
+	// or progression, and workout log asks whether they were performed.
+	Optional bool `json:"optional,omitempty"`
+
+	// FixedWeight marks a weight-tracked lift, such as a back-off or technique
+	// lift, whose weight never progresses automatically and is only changed by
+	// hand. Fixed-weight lifts need no AMRAP set or progression rule.
+	FixedWeight bool `json:"fixed_weight,omitempty"`
+
+	// Group names a superset or circuit, e.g. "A". Consecutive lifts in the same
+	// group are warmed up in turn, then alternate their working sets.
+	Group string `json:"group,omitempty"`
+
+	// Cues are technique reminders, and URL a link to a demonstration, shown
+	// under the lift by 'workout next --cues'. They replace the lift
+	// definition's own.
+	Cues []string `json:"cues,omitempty"`
+	URL  string   `json:"url,omitempty"`
+}
+
+// FeelerTemplate describes an optional heavy single performed before the AMRAP set.
+// The single is only prescribed once the working weight reaches MinWeight.
+type FeelerTemplate struct {
+	WeightPercentage float64 `json:"weight_percentage"`
+	MinWeight        float64 `json:"min_weight"`
 }
 
 type SetTemplate struct {
 	Reps             int     `json:"reps"`
 	WeightPercentage float64 `json:"weight_percentage"`
 	Type             SetType `json:"type"`
+
+	// OfTrainingMax makes WeightPercentage a fraction of the lift's training
+	// max instead of its working weight, for percentage-based programs such as
+	// 5/3/1. Only working sets of barbell lifts can use it.
+	OfTrainingMax bool `json:"of_training_max,omitempty"`
+
+	// MinReps and MaxReps prescribe a rep range, e.g. 6-8, in place of Reps.
+	// The lifter aims for the bottom of the range, and lifts progress once the
+	// AMRAP set reaches the top.
+	MinReps int `json:"min_reps,omitempty"`
+	MaxReps int `json:"max_reps,omitempty"`
 }
 
 type ProgressionRules struct {
 	IncreaseRules    map[LiftName]float64 `json:"increase_rules"`
 	DeloadPercentage float64              `json:"deload_percentage"`
 	DoubleThreshold  int                  `json:"double_threshold"`
+	Unit             WeightUnit           `json:"unit,omitempty"` // Unit of IncreaseRules; pounds if empty
+
+	// RepIncreases lists bodyweight lifts that progress by adding reps to their
+	// working sets instead of weight, with the reps added per successful session
+	RepIncreases map[LiftName]int `json:"rep_increases,omitempty"`
+
+	// Strategy decides how barbell lifts progress, tuned by Parameters; linear
+	// if empty. Parameters not set take the strategy's defaults.
+	Strategy   ProgressionStrategyName `json:"strategy,omitempty"`
+	Parameters map[string]float64      `json:"parameters,omitempty"`
+
+	// RoundingSteps are a UserProgram's per-lift rounding steps, set by
+	// ForUserProgram; templates don't declare them
+	RoundingSteps map[LiftName]float64 `json:"-"`
 }
 
 // Validation methods
@@ -140,4 +400,3 @@ const (
 	ErrUsernameEmpty   ValidationError = "username cannot be empty"
 	ErrUsernameInvalid ValidationError = "username must start with a letter and contain only letters, numbers, and dashes"
 )
-
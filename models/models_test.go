@@ -369,4 +369,68 @@ func TestValidationError(t *testing.T) {
 		assert.Error(t, err2)
 		assert.Equal(t, "username must start with a letter and contain only letters, numbers, and dashes", err2.Error())
 	})
-}
\ No newline at end of file
+}
+func TestParseLiftName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LiftName
+	}{
+		{"squat", Squat},
+		{"Squat", Squat},
+		{"deadlift", Deadlift},
+		{"DL", Deadlift},
+		{"bench", BenchPress},
+		{"BenchPress", BenchPress},
+		{"bench press", BenchPress},
+		{"ohp", OverheadPress},
+		{"press", OverheadPress},
+		{"overhead-press", OverheadPress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lift, err := ParseLiftName(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, lift)
+		})
+	}
+
+	_, err := ParseLiftName("curl")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown lift "curl"`)
+}
+
+func TestUserProgramClone(t *testing.T) {
+	original := &UserProgram{
+		ID:               uuid.New(),
+		StartingWeights:  map[LiftName]float64{Squat: 135},
+		CurrentWeights:   map[LiftName]float64{Squat: 145},
+		CurrentDay:       3,
+		Holds:            map[LiftName]int{Squat: 2},
+		Deload:           &DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5, SessionsRemaining: 3},
+		RepTargets:       map[LiftName]int{"Chinup": 8},
+		Goals:            map[LiftName]float64{Squat: 315},
+		ProgressionRules: &ProgressionRules{IncreaseRules: map[LiftName]float64{Squat: 5}},
+	}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.CurrentWeights[Squat] = 150
+	clone.StartingWeights[Squat] = 100
+	clone.Holds[Squat] = 1
+	clone.RepTargets["Chinup"] = 9
+	clone.Goals[Squat] = 405
+	clone.Deload.SessionsRemaining = 1
+	clone.ProgressionRules.IncreaseRules[Squat] = 10
+	clone.CurrentDay = 4
+
+	assert.Equal(t, 145.0, original.CurrentWeights[Squat])
+	assert.Equal(t, 135.0, original.StartingWeights[Squat])
+	assert.Equal(t, 2, original.Holds[Squat])
+	assert.Equal(t, 8, original.RepTargets["Chinup"])
+	assert.Equal(t, 315.0, original.Goals[Squat])
+	assert.Equal(t, 3, original.Deload.SessionsRemaining)
+	assert.Equal(t, 5.0, original.ProgressionRules.IncreaseRules[Squat])
+	assert.Equal(t, 3, original.CurrentDay)
+}
@@ -0,0 +1,294 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FieldError reports a validation failure for a specific field of a program
+// template. Field is the JSON path of the offending field, e.g.
+// "workouts[1].lifts[0].working_sets[2].reps".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func fieldErrorf(field, format string, a ...any) *FieldError {
+	return &FieldError{Field: field, Message: fmt.Sprintf(format, a...)}
+}
+
+// Validate checks that a program template is complete and internally consistent.
+// It returns a *FieldError pointing at the first offending field.
+func (p *Program) Validate() error {
+	if p.ID == uuid.Nil {
+		return fieldErrorf("id", "must be a non-nil UUID")
+	}
+	if p.Name == "" {
+		return fieldErrorf("name", "cannot be empty")
+	}
+	if len(p.Workouts) == 0 {
+		return fieldErrorf("workouts", "must contain at least one workout")
+	}
+
+	// Track where each lift is first used, in template order, so progression
+	// rule errors can point back to the lift that needs them
+	var usedLifts []liftUsage
+	seen := make(map[LiftName]bool)
+	for i, w := range p.Workouts {
+		path := fmt.Sprintf("workouts[%d]", i)
+		if w.Day != i+1 {
+			return fieldErrorf(path+".day", "expected %d, got %d (days must be numbered sequentially from 1)", i+1, w.Day)
+		}
+		if len(w.Lifts) == 0 {
+			return fieldErrorf(path+".lifts", "must contain at least one lift")
+		}
+		if err := w.validateGroups(path); err != nil {
+			return err
+		}
+		for j, lift := range w.Lifts {
+			liftPath := fmt.Sprintf("%s.lifts[%d]", path, j)
+			if err := lift.validate(liftPath); err != nil {
+				return err
+			}
+			if lift.Optional || lift.FixedWeight {
+				// Accessories and fixed-weight lifts don't progress, so need no progression rule
+				continue
+			}
+			key := lift.WeightKey()
+			if !seen[key] {
+				seen[key] = true
+				usedLifts = append(usedLifts, liftUsage{lift: key, path: liftPath, bodyweight: lift.Bodyweight})
+			}
+		}
+	}
+
+	if err := p.ProgressionRules.validate("progression_rules", usedLifts); err != nil {
+		return err
+	}
+
+	if p.WarmupScheme != nil {
+		if err := p.WarmupScheme.validate("warmup_scheme"); err != nil {
+			return err
+		}
+	}
+
+	if p.RestTimes != nil {
+		return p.RestTimes.validate("rest_times")
+	}
+	return nil
+}
+
+func (r *RestTimes) validate(path string) error {
+	if r.WarmupSeconds < 0 {
+		return fieldErrorf(path+".warmup_seconds", "cannot be negative, got %d", r.WarmupSeconds)
+	}
+	if r.WorkingSeconds < 0 {
+		return fieldErrorf(path+".working_seconds", "cannot be negative, got %d", r.WorkingSeconds)
+	}
+	return nil
+}
+
+func (l *LiftTemplate) validate(path string) error {
+	if l.LiftName == "" {
+		return fieldErrorf(path+".lift_name", "cannot be empty")
+	}
+	if strings.Contains(string(l.LiftName), variantSeparator) {
+		return fieldErrorf(path+".lift_name", "cannot contain %q; use the variant field for alternate bars", variantSeparator)
+	}
+	if strings.Contains(l.Variant, variantSeparator) {
+		return fieldErrorf(path+".variant", "cannot contain %q", variantSeparator)
+	}
+
+	for i, cue := range l.Cues {
+		if strings.TrimSpace(cue) == "" {
+			return fieldErrorf(fmt.Sprintf("%s.cues[%d]", path, i), "cannot be empty")
+		}
+	}
+	if err := validateCoachingURL(l.URL); err != nil {
+		return fieldErrorf(path+".url", "%v", err)
+	}
+
+	if l.Optional {
+		if l.Bodyweight {
+			return fieldErrorf(path+".bodyweight", "cannot be combined with optional")
+		}
+		if l.FixedWeight {
+			return fieldErrorf(path+".fixed_weight", "cannot be combined with optional")
+		}
+		return l.validateOptional(path)
+	}
+	if l.Bodyweight {
+		if len(l.WarmupSets) > 0 {
+			return fieldErrorf(path+".warmup_sets", "must be empty for bodyweight lifts")
+		}
+		if l.Feeler != nil {
+			return fieldErrorf(path+".feeler", "must be empty for bodyweight lifts")
+		}
+	}
+
+	for i, set := range l.WarmupSets {
+		setPath := fmt.Sprintf("%s.warmup_sets[%d]", path, i)
+		if err := set.validate(setPath); err != nil {
+			return err
+		}
+		if set.Type != WarmupSet {
+			return fieldErrorf(setPath+".type", "must be %s, got %q", WarmupSet, set.Type)
+		}
+		if set.OfTrainingMax {
+			return fieldErrorf(setPath+".of_training_max", "can only be set for working sets")
+		}
+		if set.HasRepRange() {
+			return fieldErrorf(setPath+".max_reps", "rep ranges can only be set for working sets")
+		}
+	}
+
+	if len(l.WorkingSets) == 0 {
+		return fieldErrorf(path+".working_sets", "must contain at least one set")
+	}
+	amrapSets := 0
+	for i, set := range l.WorkingSets {
+		setPath := fmt.Sprintf("%s.working_sets[%d]", path, i)
+		if err := set.validate(setPath); err != nil {
+			return err
+		}
+		if l.Bodyweight && set.WeightPercentage != 1 {
+			return fieldErrorf(setPath+".weight_percentage", "must be 1 for bodyweight lifts, got %g", set.WeightPercentage)
+		}
+		if set.OfTrainingMax {
+			if l.Bodyweight {
+				return fieldErrorf(setPath+".of_training_max", "cannot be set for bodyweight lifts")
+			}
+			if set.WeightPercentage == 0 {
+				return fieldErrorf(setPath+".weight_percentage", "must be positive for sets of the training max")
+			}
+		}
+		switch set.Type {
+		case WorkingSet:
+		case AMRAPSet:
+			amrapSets++
+		default:
+			return fieldErrorf(setPath+".type", "must be %s or %s, got %q", WorkingSet, AMRAPSet, set.Type)
+		}
+	}
+	switch {
+	case l.FixedWeight && amrapSets > 1:
+		return fieldErrorf(path+".working_sets", "must contain at most one %s, got %d", AMRAPSet, amrapSets)
+	case !l.FixedWeight && amrapSets != 1:
+		return fieldErrorf(path+".working_sets", "must contain exactly one %s, got %d (or set fixed_weight for a lift that doesn't progress)", AMRAPSet, amrapSets)
+	}
+
+	if l.Feeler != nil {
+		if l.Feeler.WeightPercentage <= 0 || l.Feeler.WeightPercentage > 1 {
+			return fieldErrorf(path+".feeler.weight_percentage", "must be between 0 and 1, got %g", l.Feeler.WeightPercentage)
+		}
+		if l.Feeler.MinWeight < 0 {
+			return fieldErrorf(path+".feeler.min_weight", "cannot be negative, got %g", l.Feeler.MinWeight)
+		}
+	}
+
+	return nil
+}
+
+// validateOptional checks an accessory slot's rep-based scheme: working sets
+// without weight percentages, at most one of them an AMRAP set
+func (l *LiftTemplate) validateOptional(path string) error {
+	if len(l.WarmupSets) > 0 {
+		return fieldErrorf(path+".warmup_sets", "must be empty for optional lifts")
+	}
+	if l.Feeler != nil {
+		return fieldErrorf(path+".feeler", "must be empty for optional lifts")
+	}
+
+	if len(l.WorkingSets) == 0 {
+		return fieldErrorf(path+".working_sets", "must contain at least one set")
+	}
+	amrapSets := 0
+	for i, set := range l.WorkingSets {
+		setPath := fmt.Sprintf("%s.working_sets[%d]", path, i)
+		if err := set.validate(setPath); err != nil {
+			return err
+		}
+		if set.WeightPercentage != 0 {
+			return fieldErrorf(setPath+".weight_percentage", "must be 0 for optional lifts, which are rep-based, got %g", set.WeightPercentage)
+		}
+		if set.OfTrainingMax {
+			return fieldErrorf(setPath+".of_training_max", "cannot be set for optional lifts, which are rep-based")
+		}
+		switch set.Type {
+		case WorkingSet:
+		case AMRAPSet:
+			amrapSets++
+		default:
+			return fieldErrorf(setPath+".type", "must be %s or %s, got %q", WorkingSet, AMRAPSet, set.Type)
+		}
+	}
+	if amrapSets > 1 {
+		return fieldErrorf(path+".working_sets", "must contain at most one %s, got %d", AMRAPSet, amrapSets)
+	}
+
+	return nil
+}
+
+func (s *SetTemplate) validate(path string) error {
+	if s.HasRepRange() {
+		if s.Reps != 0 {
+			return fieldErrorf(path+".reps", "must be empty when min_reps and max_reps are set, got %d", s.Reps)
+		}
+		if s.MinReps <= 0 {
+			return fieldErrorf(path+".min_reps", "must be positive, got %d", s.MinReps)
+		}
+		if s.MaxReps <= s.MinReps {
+			return fieldErrorf(path+".max_reps", "must be greater than min_reps (%d), got %d", s.MinReps, s.MaxReps)
+		}
+	} else if s.Reps <= 0 {
+		return fieldErrorf(path+".reps", "must be positive, got %d", s.Reps)
+	}
+	if s.WeightPercentage < 0 {
+		return fieldErrorf(path+".weight_percentage", "cannot be negative, got %g", s.WeightPercentage)
+	}
+	return nil
+}
+
+type liftUsage struct {
+	lift       LiftName
+	path       string
+	bodyweight bool
+}
+
+func (r *ProgressionRules) validate(path string, usedLifts []liftUsage) error {
+	for _, usage := range usedLifts {
+		if reps, exists := r.RepIncrementFor(usage.lift); exists {
+			if !usage.bodyweight {
+				return fieldErrorf(fmt.Sprintf("%s.rep_increases.%s", path, usage.lift), "only bodyweight lifts can progress by reps (used at %s)", usage.path)
+			}
+			if reps <= 0 {
+				return fieldErrorf(fmt.Sprintf("%s.rep_increases.%s", path, usage.lift), "must be positive, got %d", reps)
+			}
+			continue
+		}
+
+		increment, exists := r.IncrementFor(usage.lift)
+		if !exists {
+			return fieldErrorf(path+".increase_rules", "missing increment for %s (used at %s)", usage.lift, usage.path)
+		}
+		if increment <= 0 {
+			return fieldErrorf(fmt.Sprintf("%s.increase_rules.%s", path, usage.lift), "must be positive, got %g", increment)
+		}
+	}
+	if r.DeloadPercentage <= 0 || r.DeloadPercentage > 1 {
+		return fieldErrorf(path+".deload_percentage", "must be between 0 and 1, got %g", r.DeloadPercentage)
+	}
+	if r.DoubleThreshold <= 0 {
+		return fieldErrorf(path+".double_threshold", "must be positive, got %d", r.DoubleThreshold)
+	}
+	if r.Unit != "" && r.Unit != Pounds && r.Unit != Kilograms {
+		return fieldErrorf(path+".unit", "must be %s or %s, got %q", Pounds, Kilograms, r.Unit)
+	}
+	return r.validateStrategy(path)
+}
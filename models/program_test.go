@@ -0,0 +1,490 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validTestProgram() *Program {
+	return &Program{
+		ID:      uuid.New(),
+		Name:    "Test Program",
+		Version: "1.0.0",
+		Workouts: []WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []LiftTemplate{
+					{
+						LiftName: Squat,
+						WarmupSets: []SetTemplate{
+							{Reps: 5, WeightPercentage: 0.0, Type: WarmupSet},
+						},
+						WorkingSets: []SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: WorkingSet},
+							{Reps: 5, WeightPercentage: 1.0, Type: AMRAPSet},
+						},
+					},
+				},
+			},
+		},
+		ProgressionRules: ProgressionRules{
+			IncreaseRules:    map[LiftName]float64{Squat: 5.0},
+			DeloadPercentage: 0.9,
+			DoubleThreshold:  10,
+		},
+	}
+}
+
+// testAccessory returns an optional chin-up slot: two sets of 5, then an AMRAP set
+func testAccessory() LiftTemplate {
+	return LiftTemplate{
+		LiftName: "Chin-ups",
+		Optional: true,
+		WorkingSets: []SetTemplate{
+			{Reps: 5, Type: WorkingSet},
+			{Reps: 5, Type: WorkingSet},
+			{Reps: 5, Type: AMRAPSet},
+		},
+	}
+}
+
+// testBodyweightLift returns a chin-up lift that progresses by reps: 2x5, 1x5+
+func testBodyweightLift() LiftTemplate {
+	return LiftTemplate{
+		LiftName:   "Chinup",
+		Bodyweight: true,
+		WorkingSets: []SetTemplate{
+			{Reps: 5, WeightPercentage: 1.0, Type: WorkingSet},
+			{Reps: 5, WeightPercentage: 1.0, Type: WorkingSet},
+			{Reps: 5, WeightPercentage: 1.0, Type: AMRAPSet},
+		},
+	}
+}
+
+func TestProgramValidate_Valid(t *testing.T) {
+	assert.NoError(t, validTestProgram().Validate())
+}
+
+func TestProgramValidate_OptionalLift(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, testAccessory())
+
+	// Accessories need no progression rule and aren't weight-tracked
+	assert.NoError(t, prog.Validate())
+	assert.Equal(t, []LiftName{Squat}, prog.WeightKeys())
+}
+
+func TestProgramValidate_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(p *Program)
+		expectedField string
+	}{
+		{
+			name:          "missing ID",
+			modify:        func(p *Program) { p.ID = uuid.Nil },
+			expectedField: "id",
+		},
+		{
+			name:          "empty name",
+			modify:        func(p *Program) { p.Name = "" },
+			expectedField: "name",
+		},
+		{
+			name:          "no workouts",
+			modify:        func(p *Program) { p.Workouts = nil },
+			expectedField: "workouts",
+		},
+		{
+			name:          "day out of sequence",
+			modify:        func(p *Program) { p.Workouts[0].Day = 2 },
+			expectedField: "workouts[0].day",
+		},
+		{
+			name:          "no lifts",
+			modify:        func(p *Program) { p.Workouts[0].Lifts = nil },
+			expectedField: "workouts[0].lifts",
+		},
+		{
+			name:          "empty lift name",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].LiftName = "" },
+			expectedField: "workouts[0].lifts[0].lift_name",
+		},
+		{
+			name:          "empty cue",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].Cues = []string{"Brace", " "} },
+			expectedField: "workouts[0].lifts[0].cues[1]",
+		},
+		{
+			name:          "cue link not a URL",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].URL = "youtube squat video" },
+			expectedField: "workouts[0].lifts[0].url",
+		},
+		{
+			name:          "zero reps",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WorkingSets[1].Reps = 0 },
+			expectedField: "workouts[0].lifts[0].working_sets[1].reps",
+		},
+		{
+			name:          "negative percentage",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WarmupSets[0].WeightPercentage = -0.5 },
+			expectedField: "workouts[0].lifts[0].warmup_sets[0].weight_percentage",
+		},
+		{
+			name:          "wrong warmup set type",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WarmupSets[0].Type = WorkingSet },
+			expectedField: "workouts[0].lifts[0].warmup_sets[0].type",
+		},
+		{
+			name:          "unknown working set type",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WorkingSets[0].Type = "Bogus" },
+			expectedField: "workouts[0].lifts[0].working_sets[0].type",
+		},
+		{
+			name:          "no AMRAP set",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WorkingSets[1].Type = WorkingSet },
+			expectedField: "workouts[0].lifts[0].working_sets",
+		},
+		{
+			name: "invalid feeler percentage",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].Feeler = &FeelerTemplate{WeightPercentage: 1.5}
+			},
+			expectedField: "workouts[0].lifts[0].feeler.weight_percentage",
+		},
+		{
+			name:          "missing increase rule",
+			modify:        func(p *Program) { delete(p.ProgressionRules.IncreaseRules, Squat) },
+			expectedField: "progression_rules.increase_rules",
+		},
+		{
+			name:          "zero increase rule",
+			modify:        func(p *Program) { p.ProgressionRules.IncreaseRules[Squat] = 0 },
+			expectedField: "progression_rules.increase_rules.Squat",
+		},
+		{
+			name:          "deload percentage out of range",
+			modify:        func(p *Program) { p.ProgressionRules.DeloadPercentage = 0 },
+			expectedField: "progression_rules.deload_percentage",
+		},
+		{
+			name:          "zero double threshold",
+			modify:        func(p *Program) { p.ProgressionRules.DoubleThreshold = 0 },
+			expectedField: "progression_rules.double_threshold",
+		},
+		{
+			name:          "unknown unit",
+			modify:        func(p *Program) { p.ProgressionRules.Unit = "stone" },
+			expectedField: "progression_rules.unit",
+		},
+		{
+			name: "optional lift with warmups",
+			modify: func(p *Program) {
+				chinups := testAccessory()
+				chinups.WarmupSets = []SetTemplate{{Reps: 5, Type: WarmupSet}}
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].warmup_sets",
+		},
+		{
+			name: "optional lift with a weight percentage",
+			modify: func(p *Program) {
+				chinups := testAccessory()
+				chinups.WorkingSets[0].WeightPercentage = 1.0
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].working_sets[0].weight_percentage",
+		},
+		{
+			name: "optional lift with two AMRAP sets",
+			modify: func(p *Program) {
+				chinups := testAccessory()
+				chinups.WorkingSets[0].Type = AMRAPSet
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].working_sets",
+		},
+		{
+			name: "bodyweight lift with warmups",
+			modify: func(p *Program) {
+				chinups := testBodyweightLift()
+				chinups.WarmupSets = []SetTemplate{{Reps: 5, Type: WarmupSet}}
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].warmup_sets",
+		},
+		{
+			name: "bodyweight lift below full weight",
+			modify: func(p *Program) {
+				chinups := testBodyweightLift()
+				chinups.WorkingSets[0].WeightPercentage = 0.9
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].working_sets[0].weight_percentage",
+		},
+		{
+			name: "optional bodyweight lift",
+			modify: func(p *Program) {
+				chinups := testAccessory()
+				chinups.Bodyweight = true
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].bodyweight",
+		},
+		{
+			name: "optional fixed-weight lift",
+			modify: func(p *Program) {
+				chinups := testAccessory()
+				chinups.FixedWeight = true
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, chinups)
+			},
+			expectedField: "workouts[0].lifts[1].fixed_weight",
+		},
+		{
+			name: "fixed-weight lift with two AMRAP sets",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].FixedWeight = true
+				p.Workouts[0].Lifts[0].WorkingSets[0].Type = AMRAPSet
+			},
+			expectedField: "workouts[0].lifts[0].working_sets",
+		},
+		{
+			name:          "rep increase for a barbell lift",
+			modify:        func(p *Program) { p.ProgressionRules.RepIncreases = map[LiftName]int{Squat: 1} },
+			expectedField: "progression_rules.rep_increases.Squat",
+		},
+		{
+			name: "zero rep increase",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts = append(p.Workouts[0].Lifts, testBodyweightLift())
+				p.ProgressionRules.RepIncreases = map[LiftName]int{"Chinup": 0}
+			},
+			expectedField: "progression_rules.rep_increases.Chinup",
+		},
+		{
+			name:          "unknown warmup strategy",
+			modify:        func(p *Program) { p.WarmupScheme = &WarmupScheme{Strategy: "pyramid"} },
+			expectedField: "warmup_scheme.strategy",
+		},
+		{
+			name:          "custom warmup scheme without steps",
+			modify:        func(p *Program) { p.WarmupScheme = &WarmupScheme{Strategy: WarmupCustom} },
+			expectedField: "warmup_scheme.steps",
+		},
+		{
+			name: "warmup step with percentage and plates",
+			modify: func(p *Program) {
+				p.WarmupScheme = &WarmupScheme{Strategy: WarmupCustom, Steps: []WarmupStep{{Reps: 5}, {Reps: 3, Percentage: 0.7, Plates: 1}}}
+			},
+			expectedField: "warmup_scheme.steps[1]",
+		},
+		{
+			name: "plate jump scheme with steps",
+			modify: func(p *Program) {
+				p.WarmupScheme = &WarmupScheme{Strategy: WarmupPlateJump, Steps: []WarmupStep{{Reps: 5}}}
+			},
+			expectedField: "warmup_scheme.steps",
+		},
+		{
+			name:          "unknown progression strategy",
+			modify:        func(p *Program) { p.ProgressionRules.Strategy = "wave" },
+			expectedField: "progression_rules.strategy",
+		},
+		{
+			name: "parameter of another strategy",
+			modify: func(p *Program) {
+				p.ProgressionRules.Strategy = DoubleProgression
+				p.ProgressionRules.Parameters = map[string]float64{"deload_below": 5}
+			},
+			expectedField: "progression_rules.parameters.deload_below",
+		},
+		{
+			name: "percentage increase of 100%",
+			modify: func(p *Program) {
+				p.ProgressionRules.Strategy = PercentageProgression
+				p.ProgressionRules.Parameters = map[string]float64{"increase": 1}
+			},
+			expectedField: "progression_rules.parameters.increase",
+		},
+		{
+			name: "fractional rep parameter",
+			modify: func(p *Program) {
+				p.ProgressionRules.Strategy = RepLadderProgression
+				p.ProgressionRules.Parameters = map[string]float64{"rung": 1.5}
+			},
+			expectedField: "progression_rules.parameters.rung",
+		},
+		{
+			name:          "training max warmup",
+			modify:        func(p *Program) { p.Workouts[0].Lifts[0].WarmupSets[0].OfTrainingMax = true },
+			expectedField: "workouts[0].lifts[0].warmup_sets[0].of_training_max",
+		},
+		{
+			name: "training max set without a percentage",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].WorkingSets[0] = SetTemplate{Reps: 5, Type: WorkingSet, OfTrainingMax: true}
+			},
+			expectedField: "workouts[0].lifts[0].working_sets[0].weight_percentage",
+		},
+		{
+			name: "rep range with reps",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].WorkingSets[0] = SetTemplate{Reps: 5, MinReps: 6, MaxReps: 8, WeightPercentage: 1, Type: WorkingSet}
+			},
+			expectedField: "workouts[0].lifts[0].working_sets[0].reps",
+		},
+		{
+			name: "rep range without a minimum",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].WorkingSets[0] = SetTemplate{MaxReps: 8, WeightPercentage: 1, Type: WorkingSet}
+			},
+			expectedField: "workouts[0].lifts[0].working_sets[0].min_reps",
+		},
+		{
+			name: "inverted rep range",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].WorkingSets[0] = SetTemplate{MinReps: 8, MaxReps: 6, WeightPercentage: 1, Type: WorkingSet}
+			},
+			expectedField: "workouts[0].lifts[0].working_sets[0].max_reps",
+		},
+		{
+			name: "rep range warmup",
+			modify: func(p *Program) {
+				p.Workouts[0].Lifts[0].WarmupSets[0] = SetTemplate{MinReps: 3, MaxReps: 5, Type: WarmupSet}
+			},
+			expectedField: "workouts[0].lifts[0].warmup_sets[0].max_reps",
+		},
+		{
+			name:          "negative rest time",
+			modify:        func(p *Program) { p.RestTimes = &RestTimes{WorkingSeconds: -60} },
+			expectedField: "rest_times.working_seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prog := validTestProgram()
+			tt.modify(prog)
+
+			err := prog.Validate()
+			require.Error(t, err)
+
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr))
+			assert.Equal(t, tt.expectedField, fieldErr.Field)
+			assert.Contains(t, err.Error(), tt.expectedField+": ")
+		})
+	}
+}
+
+func TestProgramValidate_BodyweightLift(t *testing.T) {
+	t.Run("by weight", func(t *testing.T) {
+		prog := validTestProgram()
+		prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, testBodyweightLift())
+
+		require.ErrorContains(t, prog.Validate(), "missing increment for Chinup")
+		prog.ProgressionRules.IncreaseRules["Chinup"] = 2.5
+		assert.NoError(t, prog.Validate())
+	})
+
+	t.Run("by reps", func(t *testing.T) {
+		prog := validTestProgram()
+		prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, testBodyweightLift())
+		prog.ProgressionRules.RepIncreases = map[LiftName]int{"Chinup": 1}
+
+		assert.NoError(t, prog.Validate())
+		assert.True(t, prog.IsBodyweight("Chinup"))
+		assert.False(t, prog.IsBodyweight(Squat))
+	})
+}
+
+func TestProgramValidate_TrainingMax(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts[0].Lifts[0].WorkingSets = []SetTemplate{
+		{Reps: 5, WeightPercentage: 0.65, Type: WorkingSet, OfTrainingMax: true},
+		{Reps: 5, WeightPercentage: 0.75, Type: WorkingSet, OfTrainingMax: true},
+		{Reps: 5, WeightPercentage: 0.85, Type: AMRAPSet, OfTrainingMax: true},
+	}
+	require.NoError(t, prog.Validate())
+	assert.True(t, prog.UsesTrainingMax(Squat))
+	assert.False(t, prog.UsesTrainingMax(Deadlift))
+
+	bodyweight := testBodyweightLift()
+	bodyweight.WorkingSets[0].OfTrainingMax = true
+	prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, bodyweight)
+	prog.ProgressionRules.IncreaseRules["Chinup"] = 2.5
+	assert.ErrorContains(t, prog.Validate(), "of_training_max: cannot be set for bodyweight lifts")
+}
+
+func TestProgramValidate_RepRange(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts[0].Lifts[0].WorkingSets = []SetTemplate{
+		{MinReps: 6, MaxReps: 8, WeightPercentage: 1, Type: WorkingSet},
+		{MinReps: 6, MaxReps: 8, WeightPercentage: 1, Type: AMRAPSet},
+	}
+	require.NoError(t, prog.Validate())
+
+	set := prog.Workouts[0].Lifts[0].WorkingSets[0]
+	assert.True(t, set.HasRepRange())
+	assert.Equal(t, 6, set.TargetReps())
+	assert.Equal(t, 5, SetTemplate{Reps: 5}.TargetReps())
+}
+
+func TestUserProgram_TrainingMaxFor(t *testing.T) {
+	userProgram := &UserProgram{
+		CurrentWeights: map[LiftName]float64{Squat: 225, BenchPress: 155},
+		TrainingMaxes:  map[LiftName]float64{Squat: 250},
+	}
+	assert.Equal(t, 250.0, userProgram.TrainingMaxFor(Squat))
+	assert.Equal(t, 155.0, userProgram.TrainingMaxFor(BenchPress), "the current weight stands in for a missing training max")
+}
+
+func TestProgramValidate_FixedWeightLift(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, LiftTemplate{
+		LiftName: "PauseSquat",
+		WorkingSets: []SetTemplate{
+			{Reps: 3, WeightPercentage: 1.0, Type: WorkingSet},
+			{Reps: 3, WeightPercentage: 1.0, Type: WorkingSet},
+		},
+	})
+	require.ErrorContains(t, prog.Validate(), "must contain exactly one AMRAP")
+
+	// Fixed-weight lifts need neither an AMRAP set nor an increase rule
+	prog.Workouts[0].Lifts[1].FixedWeight = true
+	assert.NoError(t, prog.Validate())
+}
+
+func TestProgressionRules_Parameter(t *testing.T) {
+	rules := &ProgressionRules{}
+	assert.Equal(t, LinearProgression, rules.StrategyName())
+	assert.Equal(t, 5.0, rules.Parameter("deload_below"))
+
+	rules = &ProgressionRules{Strategy: RepLadderProgression, Parameters: map[string]float64{"rung": 3}}
+	assert.Equal(t, 3.0, rules.Parameter("rung"))
+	assert.Equal(t, 3.0, rules.Parameter("max_increments"))
+	assert.Zero(t, rules.Parameter("rep_max"))
+
+	prog := validTestProgram()
+	prog.ProgressionRules.Strategy = PercentageProgression
+	prog.ProgressionRules.Parameters = map[string]float64{"increase": 0.05, "deload_below": 4}
+	assert.NoError(t, prog.Validate())
+}
+
+func TestProgressionRules_RepIncrementFor(t *testing.T) {
+	rules := &ProgressionRules{RepIncreases: map[LiftName]int{"Chinup": 1, "Dip": 2, "Dip:Rings": 1}}
+
+	reps, exists := rules.RepIncrementFor("Chinup:Neutral")
+	assert.True(t, exists)
+	assert.Equal(t, 1, reps, "variants fall back to the base lift")
+
+	reps, _ = rules.RepIncrementFor("Dip:Rings")
+	assert.Equal(t, 1, reps)
+
+	_, exists = rules.RepIncrementFor(Squat)
+	assert.False(t, exists)
+}
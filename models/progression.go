@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ProgressionStrategyName selects how a program changes working weights after
+// each session
+type ProgressionStrategyName string
+
+const (
+	// LinearProgression adds the lift's increment after a successful AMRAP set,
+	// twice the increment at double_threshold reps or more, and deloads below
+	// deload_below reps. It's the default.
+	LinearProgression ProgressionStrategyName = "linear"
+	// DoubleProgression keeps the weight until the AMRAP set reaches rep_max
+	// reps, then adds the increment. It never deloads.
+	DoubleProgression ProgressionStrategyName = "double_progression"
+	// PercentageProgression adds a fraction of the current weight, at least the
+	// lift's increment, and deloads below deload_below reps
+	PercentageProgression ProgressionStrategyName = "percentage"
+	// RepLadderProgression adds one increment at deload_below reps and another
+	// for every rung reps beyond, up to max_increments, and deloads below
+	// deload_below reps
+	RepLadderProgression ProgressionStrategyName = "rep_ladder"
+)
+
+// progressionParameters lists the parameters of each strategy with their defaults
+var progressionParameters = map[ProgressionStrategyName]map[string]float64{
+	LinearProgression:     {"deload_below": 5},
+	DoubleProgression:     {"rep_max": 8},
+	PercentageProgression: {"deload_below": 5, "increase": 0.025},
+	RepLadderProgression:  {"deload_below": 5, "rung": 2, "max_increments": 3},
+}
+
+// StrategyName returns the rules' progression strategy, linear if none is set
+func (r *ProgressionRules) StrategyName() ProgressionStrategyName {
+	if r.Strategy == "" {
+		return LinearProgression
+	}
+	return r.Strategy
+}
+
+// Parameter returns a strategy parameter, or its default when the rules don't set it
+func (r *ProgressionRules) Parameter(name string) float64 {
+	if value, ok := r.Parameters[name]; ok {
+		return value
+	}
+	return progressionParameters[r.StrategyName()][name]
+}
+
+// Clone returns a copy of the rules that shares no mutable state with them
+func (r *ProgressionRules) Clone() *ProgressionRules {
+	clone := *r
+	clone.IncreaseRules = maps.Clone(r.IncreaseRules)
+	clone.RepIncreases = maps.Clone(r.RepIncreases)
+	clone.Parameters = maps.Clone(r.Parameters)
+	clone.RoundingSteps = maps.Clone(r.RoundingSteps)
+	return &clone
+}
+
+func (r *ProgressionRules) validateStrategy(path string) error {
+	defaults, known := progressionParameters[r.StrategyName()]
+	if !known {
+		names := make([]string, 0, len(progressionParameters))
+		for name := range progressionParameters {
+			names = append(names, string(name))
+		}
+		slices.Sort(names)
+		return fieldErrorf(path+".strategy", "must be one of %s, got %q", strings.Join(names, ", "), r.Strategy)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(r.Parameters)) {
+		value := r.Parameters[name]
+		paramPath := fmt.Sprintf("%s.parameters.%s", path, name)
+		if _, ok := defaults[name]; !ok {
+			return fieldErrorf(paramPath, "is not a parameter of the %s strategy (expected %s)",
+				r.StrategyName(), strings.Join(slices.Sorted(maps.Keys(defaults)), ", "))
+		}
+		if value <= 0 {
+			return fieldErrorf(paramPath, "must be positive, got %g", value)
+		}
+		if name == "increase" && value >= 1 {
+			return fieldErrorf(paramPath, "must be a fraction of the current weight below 1, got %g", value)
+		}
+		if name != "increase" && value != float64(int(value)) {
+			return fieldErrorf(paramPath, "must be a whole number, got %g", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package models
+
+// HasRepRange reports whether the set prescribes a rep range rather than a
+// fixed number of reps
+func (s SetTemplate) HasRepRange() bool {
+	return s.MinReps != 0 || s.MaxReps != 0
+}
+
+// TargetReps returns the reps the set aims for: its reps, or the bottom of its
+// rep range
+func (s SetTemplate) TargetReps() int {
+	if s.HasRepRange() {
+		return s.MinReps
+	}
+	return s.Reps
+}
+
+// HasRepRange reports whether the set was prescribed as a rep range
+func (s Set) HasRepRange() bool {
+	return s.MaxReps > s.TargetReps
+}
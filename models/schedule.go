@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultTrainingDays are the weekdays a program is trained on when the user
+// hasn't chosen any
+var DefaultTrainingDays = []time.Weekday{time.Monday, time.Wednesday, time.Friday}
+
+// ParseTrainingDays converts a comma-separated list of weekdays such as
+// "mon,wed,fri" into weekdays in week order. Days may be full names or their
+// first three letters.
+func ParseTrainingDays(input string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, field := range strings.Split(input, ",") {
+		day, err := parseWeekday(field)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+func parseWeekday(input string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(input))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) == 3 && name == full[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown training day %q (expected a weekday such as mon or monday)", input)
+}
+
+// TrainingDaysOrDefault returns the UserProgram's training days, or
+// DefaultTrainingDays if none are set
+func (up *UserProgram) TrainingDaysOrDefault() []time.Weekday {
+	if len(up.TrainingDays) == 0 {
+		return DefaultTrainingDays
+	}
+	return up.TrainingDays
+}
+
+// Pause is a stretch of time a program was set aside, e.g. for a vacation or
+// an injury. An open pause has no end yet.
+type Pause struct {
+	Reason    string    `json:"reason,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at,omitzero"`
+}
+
+// Days returns the number of calendar days the pause lasted, or has lasted as
+// of now if it is still open, in now's time zone
+func (p Pause) Days(now time.Time) int {
+	end := now
+	if !p.EndedAt.IsZero() {
+		end = p.EndedAt.In(now.Location())
+	}
+	return int(calendarDate(end).Sub(calendarDate(p.StartedAt.In(now.Location()))).Hours() / 24)
+}
+
+// covers reports whether the pause spans the calendar date of t, counting the
+// dates it started and ended on
+func (p Pause) covers(t time.Time) bool {
+	date := calendarDate(t)
+	if date.Before(calendarDate(p.StartedAt.In(t.Location()))) {
+		return false
+	}
+	return p.EndedAt.IsZero() || !date.After(calendarDate(p.EndedAt.In(t.Location())))
+}
+
+// ActivePause returns the UserProgram's open pause, or nil if it isn't paused
+func (up *UserProgram) ActivePause() *Pause {
+	if len(up.Pauses) == 0 || !up.Pauses[len(up.Pauses)-1].EndedAt.IsZero() {
+		return nil
+	}
+	return &up.Pauses[len(up.Pauses)-1]
+}
+
+// PausedOn reports whether the UserProgram was paused on the calendar date of
+// t, in t's time zone
+func (up *UserProgram) PausedOn(t time.Time) bool {
+	return slices.ContainsFunc(up.Pauses, func(p Pause) bool { return p.covers(t) })
+}
+
+// LastResumedAt returns when the UserProgram's most recent pause ended, or the
+// zero time if it has never been resumed
+func (up *UserProgram) LastResumedAt() time.Time {
+	for i := len(up.Pauses) - 1; i >= 0; i-- {
+		if !up.Pauses[i].EndedAt.IsZero() {
+			return up.Pauses[i].EndedAt
+		}
+	}
+	return time.Time{}
+}
+
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
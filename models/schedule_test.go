@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrainingDays(t *testing.T) {
+	days, err := ParseTrainingDays("Fri, monday,wed,mon")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday, time.Friday}, days, "sorted in week order without duplicates")
+
+	_, err = ParseTrainingDays("mon,funday")
+	assert.EqualError(t, err, `unknown training day "funday" (expected a weekday such as mon or monday)`)
+	_, err = ParseTrainingDays("")
+	assert.Error(t, err)
+}
+
+func TestTrainingDaysOrDefault(t *testing.T) {
+	assert.Equal(t, DefaultTrainingDays, (&UserProgram{}).TrainingDaysOrDefault())
+
+	weekend := []time.Weekday{time.Saturday, time.Sunday}
+	assert.Equal(t, weekend, (&UserProgram{TrainingDays: weekend}).TrainingDaysOrDefault())
+}
+
+func TestUserProgram_Pauses(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC) }
+	userProgram := &UserProgram{}
+	assert.Nil(t, userProgram.ActivePause())
+	assert.True(t, userProgram.LastResumedAt().IsZero())
+
+	userProgram.Pauses = []Pause{
+		{StartedAt: at(1, 18), EndedAt: at(4, 9)},
+		{StartedAt: at(10, 18), Reason: "vacation"},
+	}
+	require.NotNil(t, userProgram.ActivePause())
+	assert.Equal(t, "vacation", userProgram.ActivePause().Reason)
+	assert.Equal(t, at(4, 9), userProgram.LastResumedAt())
+
+	assert.False(t, userProgram.PausedOn(at(30, 12).AddDate(0, -1, 0)))
+	assert.True(t, userProgram.PausedOn(at(1, 6)), "the day a pause started is paused")
+	assert.True(t, userProgram.PausedOn(at(4, 20)), "the day a pause ended is paused")
+	assert.False(t, userProgram.PausedOn(at(7, 12)))
+	assert.True(t, userProgram.PausedOn(at(25, 12)), "an open pause continues")
+
+	assert.Equal(t, 3, userProgram.Pauses[0].Days(at(25, 12)))
+	assert.Equal(t, 15, userProgram.Pauses[1].Days(at(25, 12)))
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// validateGroups checks that each superset group is a run of at least two
+// consecutive lifts
+func (w *WorkoutTemplate) validateGroups(path string) error {
+	closed := make(map[string]bool)
+	for i, lift := range w.Lifts {
+		if lift.Group == "" {
+			continue
+		}
+		groupPath := fmt.Sprintf("%s.lifts[%d].group", path, i)
+		if closed[lift.Group] {
+			return fieldErrorf(groupPath, "lifts in group %q must be consecutive", lift.Group)
+		}
+		first := i == 0 || w.Lifts[i-1].Group != lift.Group
+		last := i == len(w.Lifts)-1 || w.Lifts[i+1].Group != lift.Group
+		if first && last {
+			return fieldErrorf(groupPath, "group %q must contain at least two lifts", lift.Group)
+		}
+		if last {
+			closed[lift.Group] = true
+		}
+	}
+	return nil
+}
+
+// SetRef identifies a set by the index of its lift in a workout and its index
+// within the lift
+type SetRef struct {
+	Lift int
+	Set  int
+}
+
+// GroupRun returns the index just past the run of lifts in the same superset
+// as the lift at start, or start+1 if it isn't in one
+func (w *Workout) GroupRun(start int) int {
+	group := w.Exercises[start].Group
+	end := start + 1
+	for group != "" && end < len(w.Exercises) && w.Exercises[end].Group == group {
+		end++
+	}
+	return end
+}
+
+// GroupLabel labels a lift by its superset and position in it, e.g. "A2", or
+// returns "" for a lift performed on its own
+func (w *Workout) GroupLabel(i int) string {
+	group := w.Exercises[i].Group
+	if group == "" {
+		return ""
+	}
+	start := i
+	for start > 0 && w.Exercises[start-1].Group == group {
+		start--
+	}
+	if w.GroupRun(start)-start < 2 {
+		return ""
+	}
+	return group + strconv.Itoa(i-start+1)
+}
+
+// PerformanceOrder returns the workout's sets in the order they're performed:
+// lift by lift, except that the lifts of a superset do their warmups in turn
+// and then alternate working sets, one set of each lift per round
+func (w *Workout) PerformanceOrder() []SetRef {
+	var order []SetRef
+	for start := 0; start < len(w.Exercises); {
+		end := w.GroupRun(start)
+		if end-start == 1 {
+			for j := range w.Exercises[start].Sets {
+				order = append(order, SetRef{Lift: start, Set: j})
+			}
+			start = end
+			continue
+		}
+
+		rounds := 0
+		for i := start; i < end; i++ {
+			working := 0
+			for j, set := range w.Exercises[i].Sets {
+				if set.Type == WarmupSet {
+					order = append(order, SetRef{Lift: i, Set: j})
+				} else {
+					working++
+				}
+			}
+			rounds = max(rounds, working)
+		}
+
+		for round := 0; round < rounds; round++ {
+			for i := start; i < end; i++ {
+				working := 0
+				for j, set := range w.Exercises[i].Sets {
+					if set.Type == WarmupSet {
+						continue
+					}
+					if working == round {
+						order = append(order, SetRef{Lift: i, Set: j})
+					}
+					working++
+				}
+			}
+		}
+
+		start = end
+	}
+	return order
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramValidate_Groups(t *testing.T) {
+	tests := []struct {
+		name          string
+		groups        []string
+		expectedField string
+	}{
+		{"superset", []string{"A", "A", ""}, ""},
+		{"two supersets", []string{"A", "A", "B", "B"}, ""},
+		{"single lift", []string{"A", "", ""}, "workouts[0].lifts[0].group"},
+		{"split group", []string{"A", "A", "", "A"}, "workouts[0].lifts[3].group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prog := validTestProgram()
+			lift := prog.Workouts[0].Lifts[0]
+			prog.Workouts[0].Lifts = nil
+			for _, group := range tt.groups {
+				lift.Group = group
+				prog.Workouts[0].Lifts = append(prog.Workouts[0].Lifts, lift)
+			}
+
+			err := prog.Validate()
+			if tt.expectedField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr))
+			assert.Equal(t, tt.expectedField, fieldErr.Field)
+		})
+	}
+}
+
+func TestWorkout_PerformanceOrder(t *testing.T) {
+	workout := &Workout{Exercises: []Lift{
+		{LiftName: OverheadPress, Sets: []Set{{Type: WarmupSet}, {Type: AMRAPSet}}},
+		{LiftName: Squat, Group: "A", Sets: []Set{{Type: WarmupSet}, {Type: WorkingSet}, {Type: AMRAPSet}}},
+		{LiftName: "Chinup", Group: "A", Sets: []Set{{Type: AMRAPSet}}},
+	}}
+
+	assert.Equal(t, []SetRef{
+		{Lift: 0, Set: 0}, {Lift: 0, Set: 1},
+		{Lift: 1, Set: 0},
+		{Lift: 1, Set: 1}, {Lift: 2, Set: 0},
+		{Lift: 1, Set: 2},
+	}, workout.PerformanceOrder())
+
+	assert.Equal(t, "", workout.GroupLabel(0))
+	assert.Equal(t, "A1", workout.GroupLabel(1))
+	assert.Equal(t, "A2", workout.GroupLabel(2))
+	assert.Equal(t, 3, workout.GroupRun(1))
+	assert.Equal(t, 1, workout.GroupRun(0))
+}
@@ -0,0 +1,34 @@
+package models
+
+// TrainingMaxFor returns the training max of a lift: the one set for it, or
+// its current weight otherwise
+func (up *UserProgram) TrainingMaxFor(lift LiftName) float64 {
+	if trainingMax, exists := up.TrainingMaxes[lift]; exists {
+		return trainingMax
+	}
+	return up.CurrentWeights[lift]
+}
+
+// UsesTrainingMax reports whether any of the lift's working sets are a
+// percentage of its training max
+func (t *LiftTemplate) UsesTrainingMax() bool {
+	for _, set := range t.WorkingSets {
+		if set.OfTrainingMax {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesTrainingMax reports whether any lift tracked under a weight key has sets
+// that are a percentage of its training max
+func (p *Program) UsesTrainingMax(key LiftName) bool {
+	for _, w := range p.Workouts {
+		for _, lift := range w.Lifts {
+			if lift.WeightKey() == key && lift.UsesTrainingMax() {
+				return true
+			}
+		}
+	}
+	return false
+}
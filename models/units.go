@@ -0,0 +1,164 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WeightUnit is the unit weights are recorded in. The zero value means pounds,
+// which every weight used before units were introduced is in.
+type WeightUnit string
+
+const (
+	Pounds    WeightUnit = "lbs"
+	Kilograms WeightUnit = "kg"
+)
+
+// unitAliases maps lowercase user input to weight units
+var unitAliases = map[string]WeightUnit{
+	"lb":        Pounds,
+	"lbs":       Pounds,
+	"pounds":    Pounds,
+	"kg":        Kilograms,
+	"kgs":       Kilograms,
+	"kilograms": Kilograms,
+}
+
+// ParseWeightUnit converts user input such as "lbs" or "kg" into a WeightUnit
+func ParseWeightUnit(input string) (WeightUnit, error) {
+	if unit, ok := unitAliases[strings.ToLower(strings.TrimSpace(input))]; ok {
+		return unit, nil
+	}
+	return "", fmt.Errorf("unknown weight unit %q (expected lbs or kg)", input)
+}
+
+// OrDefault returns the unit, or Pounds for the zero value
+func (u WeightUnit) OrDefault() WeightUnit {
+	if u == "" {
+		return Pounds
+	}
+	return u
+}
+
+// RoundingStep is the smallest weight change loadable with standard plates:
+// a pair of 1.25 lb or 0.625 kg plates is uncommon, so 2.5 lbs and 1.25 kg
+func (u WeightUnit) RoundingStep() float64 {
+	if u.OrDefault() == Kilograms {
+		return 1.25
+	}
+	return 2.5
+}
+
+// RoundingStepFor returns the step a lift's weights are rounded down to: its own
+// rounding step, then its base lift's for variants, then the unit's
+func (up *UserProgram) RoundingStepFor(key LiftName) float64 {
+	return roundingStepFor(up.RoundingSteps, key, up.Unit)
+}
+
+// RoundingStepFor returns the step a lift's progressed weight is rounded down
+// to, like UserProgram.RoundingStepFor
+func (r *ProgressionRules) RoundingStepFor(key LiftName) float64 {
+	return roundingStepFor(r.RoundingSteps, key, r.Unit)
+}
+
+func roundingStepFor(steps map[LiftName]float64, key LiftName, unit WeightUnit) float64 {
+	if step, exists := steps[key]; exists {
+		return step
+	}
+	base, _ := key.SplitVariant()
+	if step, exists := steps[base]; exists {
+		return step
+	}
+	return unit.RoundingStep()
+}
+
+// BarWeight is the weight of an empty standard barbell
+func (u WeightUnit) BarWeight() float64 {
+	if u.OrDefault() == Kilograms {
+		return 20
+	}
+	return 45
+}
+
+// StandardPlate is the heaviest common plate: 45 lbs or 20 kg
+func (u WeightUnit) StandardPlate() float64 {
+	if u.OrDefault() == Kilograms {
+		return 20
+	}
+	return 45
+}
+
+// StandardPlates are the plates commonly found in a gym, heaviest first
+func (u WeightUnit) StandardPlates() []float64 {
+	if u.OrDefault() == Kilograms {
+		return []float64{20, 15, 10, 5, 2.5, 1.25}
+	}
+	return []float64{45, 35, 25, 10, 5, 2.5, 1.25}
+}
+
+// MinWarmupWeight is the working weight at or below which warmup sets are skipped
+func (u WeightUnit) MinWarmupWeight() float64 {
+	if u.OrDefault() == Kilograms {
+		return 40
+	}
+	return 85
+}
+
+// ConvertIncrement converts a progression increment between units using plate-
+// friendly equivalents rather than exact conversion: 5 lbs becomes 2.5 kg and
+// 2.5 lbs becomes 1.25 kg, the increments lifters use in each system.
+func ConvertIncrement(increment float64, from, to WeightUnit) float64 {
+	from, to = from.OrDefault(), to.OrDefault()
+	switch {
+	case from == to:
+		return increment
+	case to == Kilograms:
+		return increment / 2
+	default:
+		return increment * 2
+	}
+}
+
+// poundsPerKilogram is the exact conversion factor between the two units
+const poundsPerKilogram = 2.20462262
+
+// ConvertWeight converts a weight between units exactly, for comparing weights
+// recorded in different units. Use ConvertIncrement for progression increments.
+func ConvertWeight(weight float64, from, to WeightUnit) float64 {
+	from, to = from.OrDefault(), to.OrDefault()
+	switch {
+	case from == to:
+		return weight
+	case to == Kilograms:
+		return weight / poundsPerKilogram
+	default:
+		return weight * poundsPerKilogram
+	}
+}
+
+// ForUnit returns the progression rules with increments expressed in unit.
+// Templates declare the unit of their increments, defaulting to pounds.
+func (r *ProgressionRules) ForUnit(unit WeightUnit) *ProgressionRules {
+	converted := *r
+	converted.Unit = unit.OrDefault()
+	if r.Unit.OrDefault() == converted.Unit {
+		return &converted
+	}
+
+	converted.IncreaseRules = make(map[LiftName]float64, len(r.IncreaseRules))
+	for lift, increment := range r.IncreaseRules {
+		converted.IncreaseRules[lift] = ConvertIncrement(increment, r.Unit, unit)
+	}
+	return &converted
+}
+
+// ForUserProgram returns the progression rules in the UserProgram's unit, with
+// its rounding steps. A UserProgram's customized rules replace the template's.
+func (r *ProgressionRules) ForUserProgram(up *UserProgram) *ProgressionRules {
+	if up.ProgressionRules != nil {
+		r = up.ProgressionRules
+	}
+	rules := r.ForUnit(up.Unit)
+	rules.RoundingSteps = up.RoundingSteps
+	return rules
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWeightUnit(t *testing.T) {
+	for input, expected := range map[string]WeightUnit{"lbs": Pounds, "LB": Pounds, " kg ": Kilograms, "kilograms": Kilograms} {
+		unit, err := ParseWeightUnit(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, unit, input)
+	}
+
+	_, err := ParseWeightUnit("stone")
+	assert.EqualError(t, err, `unknown weight unit "stone" (expected lbs or kg)`)
+}
+
+func TestWeightUnit_Defaults(t *testing.T) {
+	var unset WeightUnit
+	assert.Equal(t, Pounds, unset.OrDefault())
+	assert.Equal(t, 2.5, unset.RoundingStep())
+	assert.Equal(t, 45.0, Pounds.BarWeight())
+	assert.Equal(t, 1.25, Kilograms.RoundingStep())
+	assert.Equal(t, 20.0, Kilograms.BarWeight())
+	assert.Equal(t, 40.0, Kilograms.MinWarmupWeight())
+}
+
+func TestConvertIncrement(t *testing.T) {
+	assert.Equal(t, 2.5, ConvertIncrement(5, Pounds, Kilograms))
+	assert.Equal(t, 1.25, ConvertIncrement(2.5, "", Kilograms))
+	assert.Equal(t, 5.0, ConvertIncrement(2.5, Kilograms, Pounds))
+	assert.Equal(t, 5.0, ConvertIncrement(5, Pounds, ""))
+}
+
+func TestConvertWeight(t *testing.T) {
+	assert.Equal(t, 100.0, ConvertWeight(100, Kilograms, Kilograms))
+	assert.InDelta(t, 220.462, ConvertWeight(100, Kilograms, ""), 0.001)
+	assert.InDelta(t, 102.058, ConvertWeight(225, Pounds, Kilograms), 0.001)
+}
+
+func TestProgressionRules_ForUnit(t *testing.T) {
+	rules := &ProgressionRules{
+		IncreaseRules:    map[LiftName]float64{OverheadPress: 2.5, Squat: 5},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	kg := rules.ForUnit(Kilograms)
+	assert.Equal(t, Kilograms, kg.Unit)
+	assert.Equal(t, map[LiftName]float64{OverheadPress: 1.25, Squat: 2.5}, kg.IncreaseRules)
+	assert.Equal(t, 0.9, kg.DeloadPercentage)
+	assert.Equal(t, 2.5, rules.IncreaseRules[OverheadPress], "original rules are unchanged")
+
+	lbs := rules.ForUnit("")
+	assert.Equal(t, Pounds, lbs.Unit)
+	assert.Equal(t, rules.IncreaseRules, lbs.IncreaseRules)
+
+	// Templates written in kilograms convert back to pounds
+	assert.Equal(t, map[LiftName]float64{OverheadPress: 2.5, Squat: 5}, kg.ForUnit(Pounds).IncreaseRules)
+}
+
+func TestUserProgram_RoundingStepFor(t *testing.T) {
+	up := &UserProgram{RoundingSteps: map[LiftName]float64{OverheadPress: 1.25, "OverheadPress:Push": 2.5}}
+	assert.Equal(t, 1.25, up.RoundingStepFor(OverheadPress))
+	assert.Equal(t, 1.25, up.RoundingStepFor("OverheadPress:Seated"), "variants use their base lift's step")
+	assert.Equal(t, 2.5, up.RoundingStepFor("OverheadPress:Push"))
+	assert.Equal(t, 2.5, up.RoundingStepFor(Squat))
+
+	kg := &UserProgram{Unit: Kilograms}
+	assert.Equal(t, 1.25, kg.RoundingStepFor(Squat))
+}
+
+func TestProgressionRules_ForUserProgram(t *testing.T) {
+	rules := &ProgressionRules{IncreaseRules: map[LiftName]float64{OverheadPress: 2.5}}
+	up := &UserProgram{Unit: Pounds, RoundingSteps: map[LiftName]float64{OverheadPress: 1.25}}
+
+	forProgram := rules.ForUserProgram(up)
+	assert.Equal(t, Pounds, forProgram.Unit)
+	assert.Equal(t, 1.25, forProgram.RoundingStepFor(OverheadPress))
+	assert.Equal(t, 2.5, forProgram.RoundingStepFor(Squat))
+	assert.Nil(t, rules.RoundingSteps, "original rules are unchanged")
+
+	// Rules customized when the program was started replace the template's
+	up.ProgressionRules = &ProgressionRules{IncreaseRules: map[LiftName]float64{OverheadPress: 1.25}, DeloadPercentage: 0.85, Unit: Pounds}
+	forProgram = rules.ForUserProgram(up)
+	assert.Equal(t, 1.25, forProgram.IncreaseRules[OverheadPress])
+	assert.Equal(t, 0.85, forProgram.DeloadPercentage)
+	assert.Equal(t, 1.25, forProgram.RoundingStepFor(OverheadPress))
+}
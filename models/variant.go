@@ -0,0 +1,71 @@
+package models
+
+import "strings"
+
+// variantSeparator joins a lift name and its variant in a weight key, e.g. "Squat:SSB"
+const variantSeparator = ":"
+
+// VariantKey returns the key under which a lift variant's weight is tracked in
+// UserProgram.CurrentWeights. A lift without a variant uses its plain LiftName,
+// so alternate bars (e.g. a safety squat bar) progress independently of the
+// straight-bar lift.
+func VariantKey(lift LiftName, variant string) LiftName {
+	if variant == "" {
+		return lift
+	}
+	return LiftName(string(lift) + variantSeparator + variant)
+}
+
+// SplitVariant splits a weight key into its base lift name and variant.
+// The variant is empty for plain lift names.
+func (n LiftName) SplitVariant() (LiftName, string) {
+	base, variant, found := strings.Cut(string(n), variantSeparator)
+	if !found {
+		return n, ""
+	}
+	return LiftName(base), variant
+}
+
+// WeightKey returns the key under which this lift's weight is tracked
+func (t *LiftTemplate) WeightKey() LiftName {
+	return VariantKey(t.LiftName, t.Variant)
+}
+
+// WeightKey returns the key under which this lift's weight is tracked
+func (l *Lift) WeightKey() LiftName {
+	return VariantKey(l.LiftName, l.Variant)
+}
+
+// IncrementFor returns the progression increment for a weight key, falling back
+// to the base lift's increment for variants without their own rule, then to a
+// registered custom lift's default increment
+func (r *ProgressionRules) IncrementFor(key LiftName) (float64, bool) {
+	if increment, exists := r.IncreaseRules[key]; exists {
+		return increment, true
+	}
+	base, _ := key.SplitVariant()
+	if increment, exists := r.IncreaseRules[base]; exists {
+		return increment, true
+	}
+	return DefaultIncrement(base, r.Unit)
+}
+
+// WeightKeys returns every weight key used by the program, in template order.
+// Optional accessories aren't weight-tracked and are left out.
+func (p *Program) WeightKeys() []LiftName {
+	var keys []LiftName
+	seen := make(map[LiftName]bool)
+	for _, w := range p.Workouts {
+		for _, lift := range w.Lifts {
+			if lift.Optional {
+				continue
+			}
+			key := lift.WeightKey()
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantKey(t *testing.T) {
+	assert.Equal(t, Squat, VariantKey(Squat, ""))
+	assert.Equal(t, LiftName("Squat:SSB"), VariantKey(Squat, "SSB"))
+}
+
+func TestLiftName_SplitVariant(t *testing.T) {
+	base, variant := LiftName("Deadlift:TrapBar").SplitVariant()
+	assert.Equal(t, Deadlift, base)
+	assert.Equal(t, "TrapBar", variant)
+
+	base, variant = Squat.SplitVariant()
+	assert.Equal(t, Squat, base)
+	assert.Empty(t, variant)
+}
+
+func TestWeightKey(t *testing.T) {
+	tpl := LiftTemplate{LiftName: Squat, Variant: "SSB"}
+	assert.Equal(t, LiftName("Squat:SSB"), tpl.WeightKey())
+
+	lift := Lift{LiftName: Squat}
+	assert.Equal(t, Squat, lift.WeightKey())
+}
+
+func TestProgressionRules_IncrementFor(t *testing.T) {
+	rules := ProgressionRules{IncreaseRules: map[LiftName]float64{
+		Squat:                    5.0,
+		VariantKey(Squat, "SSB"): 2.5,
+	}}
+
+	increment, ok := rules.IncrementFor(VariantKey(Squat, "SSB"))
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, increment)
+
+	// Variants without their own rule use the base lift's increment
+	increment, ok = rules.IncrementFor(VariantKey(Squat, "Box"))
+	assert.True(t, ok)
+	assert.Equal(t, 5.0, increment)
+
+	_, ok = rules.IncrementFor(VariantKey(Deadlift, "TrapBar"))
+	assert.False(t, ok)
+}
+
+func TestProgram_WeightKeys(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts = append(prog.Workouts, WorkoutTemplate{
+		Day: 2,
+		Lifts: []LiftTemplate{
+			{LiftName: Squat, Variant: "SSB"},
+			{LiftName: Squat},
+			{LiftName: Deadlift, Variant: "TrapBar"},
+		},
+	})
+
+	assert.Equal(t, []LiftName{Squat, "Squat:SSB", "Deadlift:TrapBar"}, prog.WeightKeys())
+}
+
+func TestProgramValidate_Variants(t *testing.T) {
+	prog := validTestProgram()
+	prog.Workouts[0].Lifts[0].Variant = "SSB"
+	assert.NoError(t, prog.Validate(), "variant falls back to the base lift's increment")
+
+	prog.Workouts[0].Lifts[0].Variant = "SSB:High"
+	err := prog.Validate()
+	assert.ErrorContains(t, err, "workouts[0].lifts[0].variant")
+
+	prog = validTestProgram()
+	prog.Workouts[0].Lifts[0].LiftName = "Squat:SSB"
+	err = prog.Validate()
+	assert.ErrorContains(t, err, "workouts[0].lifts[0].lift_name")
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WarmupPercentages overrides the ramp of a lift's warmup sets: the fraction of
+// the working weight for each warmup set after the empty bar, lightest first
+type WarmupPercentages []float64
+
+// ParseWarmupPercentages converts user input such as "60,75,90" into validated
+// warmup percentages. A trailing "%" on each value is allowed.
+func ParseWarmupPercentages(input string) (WarmupPercentages, error) {
+	var percentages WarmupPercentages
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSuffix(strings.TrimSpace(field), "%")
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warmup percentage %q", field)
+		}
+		percentages = append(percentages, value/100)
+	}
+
+	if err := percentages.Validate(); err != nil {
+		return nil, err
+	}
+	return percentages, nil
+}
+
+// Validate checks that there is at least one percentage and that percentages
+// are strictly ascending and between 0 and 100%
+func (p WarmupPercentages) Validate() error {
+	if len(p) == 0 {
+		return fmt.Errorf("at least one warmup percentage is required")
+	}
+	for i, fraction := range p {
+		if fraction <= 0 || fraction >= 1 {
+			return fmt.Errorf("warmup percentages must be between 0 and 100, got: %g", fraction*100)
+		}
+		if i > 0 && fraction <= p[i-1] {
+			return fmt.Errorf("warmup percentages must be ascending, got %g after %g", fraction*100, p[i-1]*100)
+		}
+	}
+	return nil
+}
+
+// WarmupStrategyName selects how a program's warmup sets are built
+type WarmupStrategyName string
+
+const (
+	// WarmupPercent ramps through each lift's warmup set percentages. It's the
+	// default, and skips warmups for light working weights.
+	WarmupPercent WarmupStrategyName = "percent"
+	// WarmupPlateJump starts with the empty bar, then adds a pair of the
+	// heaviest plates per set while staying well short of the working weight
+	WarmupPlateJump WarmupStrategyName = "plate_jump"
+	// WarmupCustom follows the steps listed in the program's warmup scheme
+	WarmupCustom WarmupStrategyName = "custom"
+)
+
+// ParseWarmupStrategyName converts user input such as "plate-jump" into a
+// strategy a user can choose; custom schemes only come from program files
+func ParseWarmupStrategyName(input string) (WarmupStrategyName, error) {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), "-", "_") {
+	case "percent", "percentage":
+		return WarmupPercent, nil
+	case "plate_jump", "plates":
+		return WarmupPlateJump, nil
+	}
+	return "", fmt.Errorf("unknown warmup strategy %q (expected percent or plate_jump)", input)
+}
+
+// WarmupScheme is a program's choice of warmup strategy. Lifts the program
+// gives no warmup sets are never warmed up, whatever the strategy.
+type WarmupScheme struct {
+	Strategy WarmupStrategyName `json:"strategy"`
+	Steps    []WarmupStep       `json:"steps,omitempty"` // The warmup sets of a custom scheme, lightest first
+}
+
+// WarmupStep is one set of a custom warmup scheme: a fraction of the working
+// weight, a number of the heaviest plates per side of the bar, or neither for
+// the empty bar
+type WarmupStep struct {
+	Reps       int     `json:"reps"`
+	Percentage float64 `json:"percentage,omitempty"`
+	Plates     int     `json:"plates,omitempty"`
+}
+
+func (s *WarmupScheme) validate(path string) error {
+	switch s.Strategy {
+	case WarmupPercent, WarmupPlateJump:
+		if len(s.Steps) > 0 {
+			return fieldErrorf(path+".steps", "only custom schemes have steps")
+		}
+		return nil
+	case WarmupCustom:
+	default:
+		return fieldErrorf(path+".strategy", "must be %s, %s, or %s, got %q", WarmupPercent, WarmupPlateJump, WarmupCustom, s.Strategy)
+	}
+
+	if len(s.Steps) == 0 {
+		return fieldErrorf(path+".steps", "must contain at least one step for custom schemes")
+	}
+	for i, step := range s.Steps {
+		stepPath := fmt.Sprintf("%s.steps[%d]", path, i)
+		if step.Reps <= 0 {
+			return fieldErrorf(stepPath+".reps", "must be positive, got %d", step.Reps)
+		}
+		if step.Percentage < 0 || step.Percentage >= 1 {
+			return fieldErrorf(stepPath+".percentage", "must be at least 0 and less than 1, got %g", step.Percentage)
+		}
+		if step.Plates < 0 {
+			return fieldErrorf(stepPath+".plates", "cannot be negative, got %d", step.Plates)
+		}
+		if step.Percentage > 0 && step.Plates > 0 {
+			return fieldErrorf(stepPath, "can set percentage or plates, not both")
+		}
+	}
+	return nil
+}
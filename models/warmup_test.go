@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWarmupPercentages(t *testing.T) {
+	percentages, err := ParseWarmupPercentages("60,75,90")
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0.6, 0.75, 0.9}, percentages, 1e-9)
+
+	percentages, err = ParseWarmupPercentages(" 50%, 72.5% ")
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0.5, 0.725}, percentages, 1e-9)
+
+	tests := map[string]string{
+		"":         `invalid warmup percentage ""`,
+		"60,abc":   `invalid warmup percentage "abc"`,
+		"0,50":     "warmup percentages must be between 0 and 100, got: 0",
+		"60,100":   "warmup percentages must be between 0 and 100, got: 100",
+		"75,60,90": "warmup percentages must be ascending, got 60 after 75",
+		"60,60":    "warmup percentages must be ascending, got 60 after 60",
+	}
+	for input, expected := range tests {
+		_, err := ParseWarmupPercentages(input)
+		assert.EqualError(t, err, expected, input)
+	}
+}
+
+func TestWarmupPercentages_ValidateEmpty(t *testing.T) {
+	assert.EqualError(t, WarmupPercentages{}.Validate(), "at least one warmup percentage is required")
+}
+
+func TestParseWarmupStrategyName(t *testing.T) {
+	for input, expected := range map[string]WarmupStrategyName{
+		"percent":    WarmupPercent,
+		"Plate-Jump": WarmupPlateJump,
+		" plates ":   WarmupPlateJump,
+	} {
+		strategy, err := ParseWarmupStrategyName(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, strategy, input)
+	}
+
+	// Custom schemes need steps, which only a program file can give
+	_, err := ParseWarmupStrategyName("custom")
+	assert.EqualError(t, err, `unknown warmup strategy "custom" (expected percent or plate_jump)`)
+}
@@ -0,0 +1,56 @@
+package program
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Catalog combines the built-in programs with user-defined custom programs
+type Catalog struct {
+	custom []*models.Program
+}
+
+// NewCatalog creates a Catalog from a set of custom programs. Custom programs
+// whose IDs collide with a built-in program are ignored.
+func NewCatalog(custom []*models.Program) *Catalog {
+	catalog := &Catalog{}
+	for _, prog := range custom {
+		if _, err := GetByID(prog.ID.String()); err == nil {
+			continue
+		}
+		catalog.custom = append(catalog.custom, prog)
+	}
+	return catalog
+}
+
+// List returns the built-in programs followed by custom programs
+func (c *Catalog) List() []*models.Program {
+	return append(List(), c.custom...)
+}
+
+// GetByID retrieves a built-in or custom program by its ID. The catalog is held
+// in memory, so it only takes a context to satisfy services.ProgramService.
+func (c *Catalog) GetByID(ctx context.Context, id string) (*models.Program, error) {
+	for _, prog := range c.List() {
+		if id == prog.ID.String() {
+			return prog, nil
+		}
+	}
+	return nil, ErrProgramNotFound
+}
+
+// Find looks up a program by ID or by name (case-insensitive)
+func (c *Catalog) Find(ctx context.Context, query string) (*models.Program, error) {
+	query = strings.TrimSpace(query)
+	if prog, err := c.GetByID(ctx, query); err == nil {
+		return prog, nil
+	}
+	for _, prog := range c.List() {
+		if strings.EqualFold(prog.Name, query) {
+			return prog, nil
+		}
+	}
+	return nil, ErrProgramNotFound
+}
@@ -0,0 +1,80 @@
+package program
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinPrograms_Validate(t *testing.T) {
+	for _, prog := range List() {
+		assert.NoError(t, prog.Validate(), prog.Name)
+	}
+}
+
+func TestCatalog_ListAndGetByID(t *testing.T) {
+	custom := &models.Program{ID: uuid.New(), Name: "Custom"}
+	catalog := NewCatalog([]*models.Program{custom})
+
+	programs := catalog.List()
+	require.Len(t, programs, len(List())+1)
+	assert.Same(t, GreyskullLP, programs[0])
+	assert.Same(t, custom, programs[len(programs)-1])
+
+	found, err := catalog.GetByID(t.Context(), custom.ID.String())
+	require.NoError(t, err)
+	assert.Same(t, custom, found)
+
+	found, err = catalog.GetByID(t.Context(), GreyskullLP.ID.String())
+	require.NoError(t, err)
+	assert.Same(t, GreyskullLP, found)
+
+	_, err = catalog.GetByID(t.Context(), uuid.New().String())
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+}
+
+func TestCatalog_IgnoresBuiltinIDCollisions(t *testing.T) {
+	impostor := &models.Program{ID: GreyskullLP.ID, Name: "Impostor"}
+	catalog := NewCatalog([]*models.Program{impostor})
+
+	assert.Len(t, catalog.List(), len(List()))
+
+	found, err := catalog.GetByID(t.Context(), GreyskullLP.ID.String())
+	require.NoError(t, err)
+	assert.Same(t, GreyskullLP, found)
+}
+
+func TestCatalog_Empty(t *testing.T) {
+	catalog := NewCatalog(nil)
+	assert.Equal(t, List(), catalog.List())
+}
+
+func TestCatalog_Find(t *testing.T) {
+	custom := &models.Program{ID: uuid.New(), Name: "My Variant"}
+	catalog := NewCatalog([]*models.Program{custom})
+
+	tests := []struct {
+		query    string
+		expected *models.Program
+	}{
+		{GreyskullLP.ID.String(), GreyskullLP},
+		{"OG Greyskull LP", GreyskullLP},
+		{"og greyskull lp", GreyskullLP},
+		{" my variant ", custom},
+		{custom.ID.String(), custom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			found, err := catalog.Find(t.Context(), tt.query)
+			require.NoError(t, err)
+			assert.Same(t, tt.expected, found)
+		})
+	}
+
+	_, err := catalog.Find(t.Context(), "5/3/1")
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+}
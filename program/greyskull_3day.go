@@ -0,0 +1,110 @@
+package program
+
+import (
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// GreyskullLP3Day is the 3-day/week Greyskull LP template, alternating
+// workouts A and B on each training day (A/B/A one week, B/A/B the next)
+var GreyskullLP3Day = &models.Program{
+	ID:      uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"), // Fixed UUID for consistency
+	Name:    "Greyskull LP (3-Day A/B)",
+	Version: "1.0.0",
+	Workouts: []models.WorkoutTemplate{
+		// Workout A: Overhead Press, Squat, optional chin-ups
+		{
+			Day: 1,
+			Lifts: []models.LiftTemplate{
+				{
+					LiftName: models.OverheadPress,
+					WarmupSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},  // Empty bar
+						{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet}, // 55%
+						{Reps: 3, WeightPercentage: 0.70, Type: models.WarmupSet}, // 70%
+						{Reps: 2, WeightPercentage: 0.85, Type: models.WarmupSet}, // 85%
+					},
+					WorkingSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+					},
+				},
+				{
+					LiftName: models.Squat,
+					WarmupSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+						{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+						{Reps: 3, WeightPercentage: 0.70, Type: models.WarmupSet},
+						{Reps: 2, WeightPercentage: 0.85, Type: models.WarmupSet},
+					},
+					WorkingSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+					},
+				},
+				{
+					LiftName: "Chinup",
+					Optional: true,
+					WorkingSets: []models.SetTemplate{
+						{Reps: 8, Type: models.WorkingSet},
+						{Reps: 8, Type: models.WorkingSet},
+						{Reps: 8, Type: models.AMRAPSet},
+					},
+				},
+			},
+		},
+		// Workout B: Bench Press, Deadlift (single AMRAP set), optional curls
+		{
+			Day: 2,
+			Lifts: []models.LiftTemplate{
+				{
+					LiftName: models.BenchPress,
+					WarmupSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+						{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+						{Reps: 3, WeightPercentage: 0.70, Type: models.WarmupSet},
+						{Reps: 2, WeightPercentage: 0.85, Type: models.WarmupSet},
+					},
+					WorkingSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+						{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+					},
+				},
+				{
+					LiftName: models.Deadlift,
+					WarmupSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+						{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+						{Reps: 3, WeightPercentage: 0.70, Type: models.WarmupSet},
+						{Reps: 2, WeightPercentage: 0.85, Type: models.WarmupSet},
+					},
+					WorkingSets: []models.SetTemplate{
+						{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+					},
+				},
+				{
+					LiftName: "Curl",
+					Optional: true,
+					WorkingSets: []models.SetTemplate{
+						{Reps: 10, Type: models.WorkingSet},
+						{Reps: 10, Type: models.WorkingSet},
+						{Reps: 10, Type: models.AMRAPSet},
+					},
+				},
+			},
+		},
+	},
+	ProgressionRules: models.ProgressionRules{
+		IncreaseRules: map[models.LiftName]float64{
+			models.OverheadPress: 2.5, // Upper body: +2.5 lbs
+			models.BenchPress:    2.5, // Upper body: +2.5 lbs
+			models.Squat:         5.0, // Lower body: +5.0 lbs
+			models.Deadlift:      5.0, // Lower body: +5.0 lbs
+		},
+		DeloadPercentage: 0.9, // Deload to 90% on failure
+		DoubleThreshold:  10,  // Double progression at 10+ reps
+	},
+}
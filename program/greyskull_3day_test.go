@@ -0,0 +1,88 @@
+package program
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGreyskullLP3Day_ProgramStructure(t *testing.T) {
+	program := GreyskullLP3Day
+
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", program.ID.String())
+	assert.Equal(t, "Greyskull LP (3-Day A/B)", program.Name)
+	assert.Equal(t, "1.0.0", program.Version)
+	assert.NotEqual(t, GreyskullLP.ID, program.ID)
+
+	// A/B alternation: two workout templates
+	require.Len(t, program.Workouts, 2)
+	for i, workout := range program.Workouts {
+		assert.Equal(t, i+1, workout.Day)
+	}
+}
+
+func TestGreyskullLP3Day_Workouts(t *testing.T) {
+	program := GreyskullLP3Day
+
+	expected := [][]models.LiftName{
+		{models.OverheadPress, models.Squat},
+		{models.BenchPress, models.Deadlift},
+	}
+	accessories := []models.LiftName{"Chinup", "Curl"}
+
+	for i, workout := range program.Workouts {
+		require.Len(t, workout.Lifts, len(expected[i])+1)
+		for j, lift := range workout.Lifts[:len(expected[i])] {
+			assert.Equal(t, expected[i][j], lift.LiftName)
+			assert.False(t, lift.Optional)
+			assert.Len(t, lift.WarmupSets, 4)
+
+			// Every lift ends with exactly one AMRAP set
+			require.NotEmpty(t, lift.WorkingSets)
+			last := lift.WorkingSets[len(lift.WorkingSets)-1]
+			assert.Equal(t, models.AMRAPSet, last.Type)
+		}
+
+		// Each workout ends with an optional accessory
+		accessory := workout.Lifts[len(workout.Lifts)-1]
+		assert.Equal(t, accessories[i], accessory.LiftName)
+		assert.True(t, accessory.Optional)
+	}
+
+	// Deadlift is a single AMRAP set
+	assert.Len(t, program.Workouts[1].Lifts[1].WorkingSets, 1)
+
+	require.NoError(t, program.Validate())
+}
+
+func TestGreyskullLP3Day_WeightKeys(t *testing.T) {
+	// Accessories are never weight-tracked, so no starting weight is asked for them
+	assert.Equal(t, []models.LiftName{
+		models.OverheadPress, models.Squat, models.BenchPress, models.Deadlift,
+	}, GreyskullLP3Day.WeightKeys())
+}
+
+func TestGreyskullLP3Day_ProgressionRules(t *testing.T) {
+	rules := GreyskullLP3Day.ProgressionRules
+
+	for _, lift := range []models.LiftName{models.Squat, models.Deadlift, models.BenchPress, models.OverheadPress} {
+		assert.Contains(t, rules.IncreaseRules, lift)
+	}
+	assert.Equal(t, 0.9, rules.DeloadPercentage)
+	assert.Equal(t, 10, rules.DoubleThreshold)
+}
+
+func TestGetByID_GreyskullLP3Day(t *testing.T) {
+	program, err := GetByID(GreyskullLP3Day.ID.String())
+	require.NoError(t, err)
+	assert.Same(t, GreyskullLP3Day, program)
+}
+
+func TestList_ReturnsCopy(t *testing.T) {
+	programs := List()
+	programs[0] = nil
+
+	assert.Same(t, GreyskullLP, List()[0])
+}
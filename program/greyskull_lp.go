@@ -235,15 +235,22 @@ var GreyskullLP = &models.Program{
 	},
 }
 
+// builtinPrograms lists every built-in program in display order
+var builtinPrograms = []*models.Program{GreyskullLP, GreyskullLP3Day}
+
 // GetByID retrieves a program by its ID
 func GetByID(id string) (*models.Program, error) {
-	if id == GreyskullLP.ID.String() {
-		return GreyskullLP, nil
+	for _, prog := range builtinPrograms {
+		if id == prog.ID.String() {
+			return prog, nil
+		}
 	}
 	return nil, ErrProgramNotFound
 }
 
 // List returns all available programs
 func List() []*models.Program {
-	return []*models.Program{GreyskullLP}
+	programs := make([]*models.Program, len(builtinPrograms))
+	copy(programs, builtinPrograms)
+	return programs
 }
\ No newline at end of file
@@ -191,8 +191,8 @@ func TestGetByID(t *testing.T) {
 func TestList(t *testing.T) {
 	programs := List()
 
-	// Should return exactly one program (Greyskull LP)
-	require.Len(t, programs, 1)
+	// Should return both built-in programs, OG Greyskull LP first
+	require.Len(t, programs, 2)
 
 	program := programs[0]
 	assert.Equal(t, GreyskullLP.ID.String(), program.ID.String())
@@ -201,6 +201,7 @@ func TestList(t *testing.T) {
 
 	// Verify it's the same instance
 	assert.Same(t, GreyskullLP, program)
+	assert.Same(t, GreyskullLP3Day, programs[1])
 }
 
 func TestGreyskullLP_AllLiftsPresent(t *testing.T) {
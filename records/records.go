@@ -0,0 +1,154 @@
+// Package records computes personal records from workout history. Records are
+// tracked per weight key, so bar variants keep records separate from the base lift.
+package records
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Kind identifies a type of personal record
+type Kind string
+
+const (
+	// HeaviestAMRAP is the heaviest weight used for an AMRAP set
+	HeaviestAMRAP Kind = "HeaviestAMRAP"
+	// BestE1RM is the best estimated one-rep max from an AMRAP set
+	BestE1RM Kind = "BestE1RM"
+	// MostReps is the most AMRAP reps completed at a particular weight
+	MostReps Kind = "MostReps"
+)
+
+// Record is a single set that set a personal record
+type Record struct {
+	Weight float64   `json:"weight"`
+	Reps   int       `json:"reps"`
+	E1RM   float64   `json:"e1rm"`
+	Date   time.Time `json:"date"`
+}
+
+// LiftRecords are the personal records for one weight key
+type LiftRecords struct {
+	Lift          models.LiftName `json:"lift"`
+	HeaviestAMRAP Record          `json:"heaviest_amrap"`
+	BestE1RM      Record          `json:"best_e1rm"`
+	// MostReps holds the best AMRAP set at each weight used
+	MostReps map[float64]Record `json:"most_reps"`
+}
+
+// Weights returns the weights with a MostReps record, heaviest first
+func (r *LiftRecords) Weights() []float64 {
+	weights := make([]float64, 0, len(r.MostReps))
+	for weight := range r.MostReps {
+		weights = append(weights, weight)
+	}
+	slices.Sort(weights)
+	slices.Reverse(weights)
+	return weights
+}
+
+// MarshalJSON writes MostReps as a list of records, heaviest first, since JSON
+// object keys can't be numbers
+func (r LiftRecords) MarshalJSON() ([]byte, error) {
+	type plain LiftRecords
+	mostReps := make([]Record, 0, len(r.MostReps))
+	for _, weight := range r.Weights() {
+		mostReps = append(mostReps, r.MostReps[weight])
+	}
+	return json.Marshal(struct {
+		plain
+		MostReps []Record `json:"most_reps"`
+	}{plain(r), mostReps})
+}
+
+// Achievement is a record broken by a workout
+type Achievement struct {
+	Lift     models.LiftName `json:"lift"`
+	Kind     Kind            `json:"kind"`
+	New      Record          `json:"new"`
+	Previous Record          `json:"previous"`
+}
+
+// EstimateOneRepMax estimates a one-rep max with the Epley formula,
+// weight × (1 + reps/30). A single is its own one-rep max.
+func EstimateOneRepMax(weight float64, reps int) float64 {
+	if reps <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+	return math.Round(weight*(1+float64(reps)/30)*10) / 10
+}
+
+// Compute scans a chronologically sorted history and returns the records for each
+// weight key. Only AMRAP sets with at least one rep count toward records, and when
+// a record is tied the earliest set keeps it. Optional accessories and bodyweight
+// lifts, whose weights are only the weight added to the lifter, are ignored.
+func Compute(history []models.Workout) map[models.LiftName]*LiftRecords {
+	all := make(map[models.LiftName]*LiftRecords)
+	for i := range history {
+		update(all, &history[i])
+	}
+	return all
+}
+
+// Broken returns the records that a workout breaks relative to existing records,
+// and updates existing to include the workout. Setting a first record for a lift
+// or weight isn't an achievement: there is no previous record to break.
+func Broken(existing map[models.LiftName]*LiftRecords, workout *models.Workout) []Achievement {
+	var achievements []Achievement
+	for _, change := range update(existing, workout) {
+		if change.Previous.Reps > 0 {
+			achievements = append(achievements, change)
+		}
+	}
+	return achievements
+}
+
+// update folds a workout's AMRAP sets into the records and returns every record that changed
+func update(all map[models.LiftName]*LiftRecords, workout *models.Workout) []Achievement {
+	var changes []Achievement
+	for _, lift := range workout.Exercises {
+		if lift.Optional || lift.Bodyweight {
+			continue
+		}
+		key := lift.WeightKey()
+		for _, set := range lift.Sets {
+			if set.Type != models.AMRAPSet || set.ActualReps <= 0 {
+				continue
+			}
+
+			record := Record{
+				Weight: set.Weight,
+				Reps:   set.ActualReps,
+				E1RM:   EstimateOneRepMax(set.Weight, set.ActualReps),
+				Date:   workout.EnteredAt,
+			}
+
+			current, exists := all[key]
+			if !exists {
+				current = &LiftRecords{Lift: key, MostReps: make(map[float64]Record)}
+				all[key] = current
+			}
+
+			if record.Weight > current.HeaviestAMRAP.Weight {
+				changes = append(changes, Achievement{Lift: key, Kind: HeaviestAMRAP, New: record, Previous: current.HeaviestAMRAP})
+				current.HeaviestAMRAP = record
+			}
+			if record.E1RM > current.BestE1RM.E1RM {
+				changes = append(changes, Achievement{Lift: key, Kind: BestE1RM, New: record, Previous: current.BestE1RM})
+				current.BestE1RM = record
+			}
+			if previous := current.MostReps[record.Weight]; record.Reps > previous.Reps {
+				changes = append(changes, Achievement{Lift: key, Kind: MostReps, New: record, Previous: previous})
+				current.MostReps[record.Weight] = record
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,120 @@
+package records
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var recordsBase = time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+
+func amrapWorkout(daysIn int, lifts ...models.Lift) models.Workout {
+	return models.Workout{EnteredAt: recordsBase.AddDate(0, 0, daysIn), Exercises: lifts}
+}
+
+func amrapLift(name models.LiftName, variant string, weight float64, reps int) models.Lift {
+	return models.Lift{LiftName: name, Variant: variant, Sets: []models.Set{
+		{Weight: weight, TargetReps: 5, ActualReps: 5, Type: models.WorkingSet},
+		{Weight: weight, TargetReps: 5, ActualReps: reps, Type: models.AMRAPSet},
+	}}
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	assert.Equal(t, 0.0, EstimateOneRepMax(135, 0))
+	assert.Equal(t, 200.0, EstimateOneRepMax(200, 1))
+	assert.Equal(t, 157.5, EstimateOneRepMax(135, 5))
+	assert.Equal(t, 185.0, EstimateOneRepMax(150, 7))
+}
+
+func TestCompute(t *testing.T) {
+	history := []models.Workout{
+		amrapWorkout(0, amrapLift(models.Squat, "", 135, 10), amrapLift(models.OverheadPress, "", 95, 6)),
+		amrapWorkout(2, amrapLift(models.Squat, "", 145, 5), amrapLift(models.Squat, "SSB", 150, 6)),
+		amrapWorkout(4, amrapLift(models.Squat, "", 135, 12)),
+		// A workout with a zero-rep AMRAP set doesn't count
+		amrapWorkout(6, amrapLift(models.Squat, "", 225, 0)),
+	}
+
+	all := Compute(history)
+	require.Len(t, all, 3)
+
+	squat := all[models.Squat]
+	assert.Equal(t, Record{Weight: 145, Reps: 5, E1RM: 169.2, Date: recordsBase.AddDate(0, 0, 2)}, squat.HeaviestAMRAP)
+	assert.Equal(t, Record{Weight: 135, Reps: 12, E1RM: 189, Date: recordsBase.AddDate(0, 0, 4)}, squat.BestE1RM)
+	assert.Equal(t, []float64{145, 135}, squat.Weights())
+	assert.Equal(t, 12, squat.MostReps[135].Reps)
+
+	ssb := all["Squat:SSB"]
+	assert.Equal(t, 150.0, ssb.HeaviestAMRAP.Weight)
+	assert.Equal(t, models.LiftName("Squat:SSB"), ssb.Lift)
+}
+
+func TestCompute_TiesKeepEarliest(t *testing.T) {
+	history := []models.Workout{
+		amrapWorkout(0, amrapLift(models.BenchPress, "", 100, 8)),
+		amrapWorkout(2, amrapLift(models.BenchPress, "", 100, 8)),
+	}
+
+	bench := Compute(history)[models.BenchPress]
+	assert.Equal(t, recordsBase, bench.HeaviestAMRAP.Date)
+	assert.Equal(t, recordsBase, bench.MostReps[100].Date)
+}
+
+func TestBroken(t *testing.T) {
+	existing := Compute([]models.Workout{
+		amrapWorkout(-2, amrapLift(models.Squat, "", 115, 8)),
+		amrapWorkout(0, amrapLift(models.Squat, "", 135, 10)),
+	})
+
+	workout := amrapWorkout(2, amrapLift(models.Squat, "", 140, 9), amrapLift(models.Deadlift, "", 185, 5))
+	achievements := Broken(existing, &workout)
+
+	// The first deadlift and the first set at 140 lbs don't break anything
+	require.Len(t, achievements, 2)
+	assert.Equal(t, HeaviestAMRAP, achievements[0].Kind)
+	assert.Equal(t, 140.0, achievements[0].New.Weight)
+	assert.Equal(t, 135.0, achievements[0].Previous.Weight)
+	assert.Equal(t, BestE1RM, achievements[1].Kind)
+	assert.Equal(t, 182.0, achievements[1].New.E1RM)
+	assert.Equal(t, 180.0, achievements[1].Previous.E1RM)
+
+	// Existing records now include the workout
+	assert.Equal(t, 140.0, existing[models.Squat].HeaviestAMRAP.Weight)
+	assert.Contains(t, existing, models.Deadlift)
+
+	repPR := amrapWorkout(4, amrapLift(models.Squat, "", 115, 9))
+	achievements = Broken(existing, &repPR)
+	require.Len(t, achievements, 1)
+	assert.Equal(t, MostReps, achievements[0].Kind)
+	assert.Equal(t, 8, achievements[0].Previous.Reps)
+}
+
+func TestLiftRecords_MarshalJSON(t *testing.T) {
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	lift := &LiftRecords{
+		Lift:          models.Squat,
+		HeaviestAMRAP: Record{Weight: 140, Reps: 5, E1RM: 163.3, Date: date},
+		MostReps: map[float64]Record{
+			135: {Weight: 135, Reps: 8, Date: date},
+			140: {Weight: 140, Reps: 5, Date: date},
+		},
+	}
+
+	data, err := json.Marshal(map[models.LiftName]*LiftRecords{models.Squat: lift})
+	require.NoError(t, err)
+
+	var decoded map[string]struct {
+		Lift          string   `json:"lift"`
+		HeaviestAMRAP Record   `json:"heaviest_amrap"`
+		MostReps      []Record `json:"most_reps"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Squat", decoded["Squat"].Lift)
+	assert.Equal(t, 140.0, decoded["Squat"].HeaviestAMRAP.Weight)
+	require.Len(t, decoded["Squat"].MostReps, 2)
+	assert.Equal(t, []float64{140, 135}, []float64{decoded["Squat"].MostReps[0].Weight, decoded["Squat"].MostReps[1].Weight}, "heaviest first")
+}
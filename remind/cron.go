@@ -0,0 +1,70 @@
+package remind
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cronMarker ends the crontab line greyskull manages, so setting reminders up
+// again replaces it instead of adding another
+const cronMarker = "# greyskull reminder"
+
+// CronLine returns the crontab line running the job on the schedule
+func CronLine(s Schedule, job Job) string {
+	days := make([]string, len(s.Days))
+	for i, day := range s.Days {
+		days[i] = strconv.Itoa(int(day))
+	}
+
+	var command []string
+	for _, name := range slices.Sorted(maps.Keys(job.Env)) {
+		command = append(command, name+"="+shellQuote(job.Env[name]))
+	}
+	for _, arg := range job.Args {
+		command = append(command, shellQuote(arg))
+	}
+
+	// cron turns unescaped percent signs into newlines
+	escaped := strings.ReplaceAll(strings.Join(command, " "), "%", `\%`)
+	return fmt.Sprintf("%d %d * * %s %s %s", s.Minute, s.Hour, strings.Join(days, ","), escaped, cronMarker)
+}
+
+// ReplaceCronLine returns the crontab with greyskull's reminder line replaced
+// by line, or line appended if there was none. Every other line is kept.
+func ReplaceCronLine(crontab, line string) string {
+	var lines []string
+	for _, existing := range strings.Split(strings.TrimRight(crontab, "\n"), "\n") {
+		if existing != "" && !strings.HasSuffix(existing, cronMarker) {
+			lines = append(lines, existing)
+		}
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// InstallCron adds the job to the user's crontab, replacing any reminder set
+// up before
+func InstallCron(run Runner, s Schedule, job Job) error {
+	// crontab -l fails when the user has no crontab yet
+	current, err := run(nil, "crontab", "-l")
+	if err != nil {
+		current = nil
+	}
+
+	updated := ReplaceCronLine(string(current), CronLine(s, job))
+	if output, err := run([]byte(updated), "crontab", "-"); err != nil {
+		return fmt.Errorf("failed to install crontab: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// shellQuote quotes an argument for sh if it contains anything but safe characters
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
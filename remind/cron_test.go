@@ -0,0 +1,58 @@
+package remind
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchedule = Schedule{Days: []time.Weekday{time.Monday, time.Wednesday, time.Friday}, Hour: 18, Minute: 5}
+
+func TestCronLine(t *testing.T) {
+	job := Job{
+		Args: []string{"/Applications/My Tools/greyskull", "remind", "notify"},
+		Env:  map[string]string{"XDG_CONFIG_HOME": "/home/me/.config", "A": "50%"},
+	}
+
+	assert.Equal(t, `5 18 * * 1,3,5 A='50\%' XDG_CONFIG_HOME=/home/me/.config '/Applications/My Tools/greyskull' remind notify # greyskull reminder`,
+		CronLine(testSchedule, job))
+}
+
+func TestReplaceCronLine(t *testing.T) {
+	assert.Equal(t, "new # greyskull reminder\n", ReplaceCronLine("", "new # greyskull reminder"))
+
+	crontab := "MAILTO=me\n0 1 * * * backup\n0 18 * * 1 old # greyskull reminder\n"
+	assert.Equal(t, "MAILTO=me\n0 1 * * * backup\nnew # greyskull reminder\n",
+		ReplaceCronLine(crontab, "new # greyskull reminder"))
+}
+
+func TestInstallCron(t *testing.T) {
+	job := Job{Args: []string{"/usr/bin/greyskull", "remind", "notify"}}
+
+	t.Run("adds to the existing crontab", func(t *testing.T) {
+		var calls []call
+		run := fakeRunner(&calls, map[string]string{"crontab -l": "0 1 * * * backup\n"})
+
+		require.NoError(t, InstallCron(run, testSchedule, job))
+		require.Len(t, calls, 2)
+		assert.Equal(t, []string{"crontab", "-"}, calls[1].Args)
+		assert.Equal(t, "0 1 * * * backup\n5 18 * * 1,3,5 /usr/bin/greyskull remind notify # greyskull reminder\n", calls[1].Stdin)
+	})
+
+	t.Run("creates a crontab", func(t *testing.T) {
+		var calls []call
+		run := fakeRunner(&calls, nil, "crontab -l")
+
+		require.NoError(t, InstallCron(run, testSchedule, job))
+		assert.Equal(t, "5 18 * * 1,3,5 /usr/bin/greyskull remind notify # greyskull reminder\n", calls[1].Stdin)
+	})
+
+	t.Run("reports install failures", func(t *testing.T) {
+		var calls []call
+		run := fakeRunner(&calls, nil, "crontab -")
+
+		assert.EqualError(t, InstallCron(run, testSchedule, job), "failed to install crontab: exit status 1: boom")
+	})
+}
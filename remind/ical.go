@@ -0,0 +1,63 @@
+package remind
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// icalDays are the iCalendar abbreviations of each weekday, Sunday first
+var icalDays = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// eventDuration is how long each training event lasts in a calendar
+const eventDuration = "PT1H"
+
+// ICalendar returns an iCalendar file with one event repeating weekly on the
+// schedule's days, each with an alert at its start. The first event is on the
+// first training day from now on. Times are in the calendar's local time zone.
+// Importing a file with the same uid again updates the event rather than
+// adding a second one.
+func ICalendar(s Schedule, uid, summary string, now time.Time) []byte {
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.Hour, s.Minute, 0, 0, now.Location())
+	for i := 0; i < 7 && !slices.Contains(s.Days, start.Weekday()); i++ {
+		start = start.AddDate(0, 0, 1)
+	}
+
+	days := make([]string, len(s.Days))
+	for i, day := range s.Days {
+		days[i] = icalDays[day]
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//greyskull//reminders//EN",
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:" + now.UTC().Format("20060102T150405Z"),
+		"DTSTART:" + start.Format("20060102T150405"),
+		"DURATION:" + eventDuration,
+		"RRULE:FREQ=WEEKLY;BYDAY=" + strings.Join(days, ","),
+		"SUMMARY:" + escapeText(summary),
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:PT0M",
+		"DESCRIPTION:" + escapeText(summary),
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// escapeText escapes the characters iCalendar gives meaning to in text values
+func escapeText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// EventUID returns the iCalendar UID of the reminders for a program
+func EventUID(programID uuid.UUID) string {
+	return "reminder-" + programID.String() + "@greyskull"
+}
@@ -0,0 +1,40 @@
+package remind
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICalendar(t *testing.T) {
+	schedule := Schedule{Days: []time.Weekday{time.Monday, time.Wednesday, time.Friday}, Hour: 18, Minute: 30}
+	// A Saturday, so the first event is on the following Monday
+	now := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+
+	calendar := string(ICalendar(schedule, "reminder-1@greyskull", "Greyskull LP; day, two", now))
+
+	assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+	lines := strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n")
+	assert.Equal(t, "BEGIN:VCALENDAR", lines[0])
+	assert.Contains(t, lines, "UID:reminder-1@greyskull")
+	assert.Contains(t, lines, "DTSTAMP:20240309T100000Z")
+	assert.Contains(t, lines, "DTSTART:20240311T183000")
+	assert.Contains(t, lines, "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	assert.Contains(t, lines, `SUMMARY:Greyskull LP\; day\, two`)
+	assert.Contains(t, lines, "BEGIN:VALARM")
+}
+
+func TestICalendar_StartsToday(t *testing.T) {
+	schedule := Schedule{Days: []time.Weekday{time.Saturday}, Hour: 9}
+	now := time.Date(2024, 3, 9, 10, 0, 0, 0, time.UTC)
+
+	assert.Contains(t, string(ICalendar(schedule, "uid", "Workout", now)), "DTSTART:20240309T090000\r\n")
+}
+
+func TestEventUID(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	assert.Equal(t, "reminder-550e8400-e29b-41d4-a716-446655440000@greyskull", EventUID(id))
+}
@@ -0,0 +1,73 @@
+package remind
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// LaunchdLabel identifies greyskull's reminder job to launchd
+const LaunchdLabel = "com.github.mikowitz.greyskull.reminder"
+
+// LaunchdPlist returns a launchd property list running the job on the schedule
+func LaunchdPlist(s Schedule, job Job) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	buf.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	fmt.Fprintf(&buf, "\t<key>Label</key>\n\t<string>%s</string>\n", escapeXML(LaunchdLabel))
+
+	buf.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range job.Args {
+		fmt.Fprintf(&buf, "\t\t<string>%s</string>\n", escapeXML(arg))
+	}
+	buf.WriteString("\t</array>\n")
+
+	if len(job.Env) > 0 {
+		buf.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		for _, name := range slices.Sorted(maps.Keys(job.Env)) {
+			fmt.Fprintf(&buf, "\t\t<key>%s</key>\n\t\t<string>%s</string>\n", escapeXML(name), escapeXML(job.Env[name]))
+		}
+		buf.WriteString("\t</dict>\n")
+	}
+
+	// launchd numbers weekdays from Sunday as 0, like time.Weekday
+	buf.WriteString("\t<key>StartCalendarInterval</key>\n\t<array>\n")
+	for _, day := range s.Days {
+		fmt.Fprintf(&buf, "\t\t<dict>\n\t\t\t<key>Weekday</key>\n\t\t\t<integer>%d</integer>\n"+
+			"\t\t\t<key>Hour</key>\n\t\t\t<integer>%d</integer>\n"+
+			"\t\t\t<key>Minute</key>\n\t\t\t<integer>%d</integer>\n\t\t</dict>\n", int(day), s.Hour, s.Minute)
+	}
+	buf.WriteString("\t</array>\n</dict>\n</plist>\n")
+	return buf.Bytes()
+}
+
+// InstallLaunchd writes the job's property list to agentsDir (normally
+// ~/Library/LaunchAgents) and loads it, replacing any reminder set up before.
+// It returns the path of the property list.
+func InstallLaunchd(run Runner, agentsDir string, s Schedule, job Job) (string, error) {
+	if err := os.MkdirAll(agentsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create launch agents directory: %w", err)
+	}
+	path := filepath.Join(agentsDir, LaunchdLabel+".plist")
+	if err := os.WriteFile(path, LaunchdPlist(s, job), 0644); err != nil {
+		return "", fmt.Errorf("failed to write launchd job: %w", err)
+	}
+
+	// Unloading fails when no reminder was loaded before
+	run(nil, "launchctl", "unload", path)
+	if output, err := run(nil, "launchctl", "load", path); err != nil {
+		return "", fmt.Errorf("failed to load launchd job: %w: %s", err, bytes.TrimSpace(output))
+	}
+	return path, nil
+}
+
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
@@ -0,0 +1,42 @@
+package remind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaunchdPlist(t *testing.T) {
+	job := Job{Args: []string{"/usr/local/bin/greyskull", "remind", "notify"}, Env: map[string]string{"XDG_CONFIG_HOME": "/tmp/a&b"}}
+
+	plist := string(LaunchdPlist(testSchedule, job))
+
+	assert.Contains(t, plist, "<string>com.github.mikowitz.greyskull.reminder</string>")
+	assert.Contains(t, plist, "\t<array>\n\t\t<string>/usr/local/bin/greyskull</string>\n\t\t<string>remind</string>\n\t\t<string>notify</string>\n\t</array>\n")
+	assert.Contains(t, plist, "<key>XDG_CONFIG_HOME</key>\n\t\t<string>/tmp/a&amp;b</string>")
+	assert.Contains(t, plist, "<key>Weekday</key>\n\t\t\t<integer>3</integer>\n\t\t\t<key>Hour</key>\n\t\t\t<integer>18</integer>\n\t\t\t<key>Minute</key>\n\t\t\t<integer>5</integer>")
+}
+
+func TestInstallLaunchd(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "LaunchAgents")
+	job := Job{Args: []string{"/usr/local/bin/greyskull", "remind", "notify"}}
+
+	var calls []call
+	path, err := InstallLaunchd(fakeRunner(&calls, nil, "launchctl unload"), dir, testSchedule, job)
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "com.github.mikowitz.greyskull.reminder.plist"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, LaunchdPlist(testSchedule, job), data)
+	assert.Equal(t, []call{
+		{Args: []string{"launchctl", "unload", path}},
+		{Args: []string{"launchctl", "load", path}},
+	}, calls)
+
+	_, err = InstallLaunchd(fakeRunner(&calls, nil, "launchctl load"), dir, testSchedule, job)
+	assert.EqualError(t, err, "failed to load launchd job: exit status 1: boom")
+}
@@ -0,0 +1,22 @@
+package remind
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Notify shows a desktop notification: with osascript on macOS (goos "darwin")
+// and notify-send elsewhere
+func Notify(run Runner, goos, title, message string) error {
+	var err error
+	if goos == "darwin" {
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		_, err = run(nil, "osascript", "-e", script)
+	} else {
+		_, err = run(nil, "notify-send", title, message)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to show notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package remind sets up reminders on a program's training days: an iCalendar
+// file for calendar apps, or a cron or launchd job that runs a command, such as
+// 'greyskull remind notify', at the training time.
+package remind
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/i18n"
+)
+
+// Schedule is when reminders fire: a time of day on each training day
+type Schedule struct {
+	Days   []time.Weekday
+	Hour   int
+	Minute int
+}
+
+// Job is the command a scheduled reminder runs
+type Job struct {
+	Args []string          // The executable followed by its arguments
+	Env  map[string]string // Environment variables the command needs set
+}
+
+// Runner runs an external command, feeding it stdin if not nil, and returns its
+// combined output
+type Runner func(stdin []byte, name string, args ...string) ([]byte, error)
+
+// ExecRunner runs commands with os/exec
+func ExecRunner(stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	return cmd.CombinedOutput()
+}
+
+// ParseTimeOfDay parses a 24-hour time such as "18:00" or "6:30"
+func ParseTimeOfDay(input string) (hour, minute int, err error) {
+	hourText, minuteText, found := strings.Cut(strings.TrimSpace(input), ":")
+	if found {
+		hour, err = strconv.Atoi(hourText)
+	}
+	if found && err == nil {
+		minute, err = strconv.Atoi(minuteText)
+	}
+	if !found || err != nil || len(minuteText) != 2 || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time %q: expected HH:MM, e.g. 18:00", input)
+	}
+	return hour, minute, nil
+}
+
+// String formats the schedule for people, e.g. "Mon, Wed, Fri at 18:00"
+func (s Schedule) String() string {
+	names := make([]string, len(s.Days))
+	for i, day := range s.Days {
+		names[i] = i18n.T(day.String()[:3])
+	}
+	return i18n.Sprintf("%s at %02d:%02d", strings.Join(names, ", "), s.Hour, s.Minute)
+}
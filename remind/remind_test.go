@@ -0,0 +1,69 @@
+package remind
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// call is a command run through a fake Runner
+type call struct {
+	Stdin string
+	Args  []string
+}
+
+// fakeRunner records the commands it runs, failing those named in failing
+func fakeRunner(calls *[]call, output map[string]string, failing ...string) Runner {
+	return func(stdin []byte, name string, args ...string) ([]byte, error) {
+		*calls = append(*calls, call{Stdin: string(stdin), Args: append([]string{name}, args...)})
+		key := name
+		if len(args) > 0 {
+			key += " " + args[0]
+		}
+		for _, failure := range failing {
+			if failure == key {
+				return []byte("boom"), errors.New("exit status 1")
+			}
+		}
+		return []byte(output[key]), nil
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	hour, minute, err := ParseTimeOfDay("6:05")
+	require.NoError(t, err)
+	assert.Equal(t, 6, hour)
+	assert.Equal(t, 5, minute)
+
+	hour, minute, err = ParseTimeOfDay(" 18:30 ")
+	require.NoError(t, err)
+	assert.Equal(t, 18, hour)
+	assert.Equal(t, 30, minute)
+
+	for _, input := range []string{"18", "24:00", "18:60", "18:5", "six:00", ""} {
+		_, _, err := ParseTimeOfDay(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestSchedule_String(t *testing.T) {
+	schedule := Schedule{Days: []time.Weekday{time.Monday, time.Thursday}, Hour: 7, Minute: 5}
+	assert.Equal(t, "Mon, Thu at 07:05", schedule.String())
+}
+
+func TestNotify(t *testing.T) {
+	var calls []call
+
+	require.NoError(t, Notify(fakeRunner(&calls, nil), "linux", "Time to train", "Day 2 is up next."))
+	require.NoError(t, Notify(fakeRunner(&calls, nil), "darwin", "Time to train", `Day 2 of "LP" is up next.`))
+	assert.Equal(t, []call{
+		{Args: []string{"notify-send", "Time to train", "Day 2 is up next."}},
+		{Args: []string{"osascript", "-e", `display notification "Day 2 of \"LP\" is up next." with title "Time to train"`}},
+	}, calls)
+
+	err := Notify(fakeRunner(&calls, nil, "notify-send Time to train"), "linux", "Time to train", "")
+	assert.ErrorContains(t, err, "failed to show notification")
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// archiveExt is the extension of archive files: gzip-compressed JSON arrays of workouts
+const archiveExt = ".json.gz"
+
+// JSONArchiveRepository implements ArchiveRepository with gzip-compressed JSON
+// files, one per archive, in a directory per user
+type JSONArchiveRepository struct {
+	archiveDir string
+	files      FileStore
+	mutex      sync.Mutex
+}
+
+// NewJSONArchiveRepository creates a new JSONArchiveRepository instance
+func NewJSONArchiveRepository() (ArchiveRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONArchiveRepository{archiveDir: filepath.Join(greyskullDir, "archive"), files: files}, nil
+}
+
+// Save writes workouts to a new archive file named by the time it was created
+func (r *JSONArchiveRepository) Save(userID uuid.UUID, workouts []models.Workout) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	userDir := r.userDir(userID)
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	name := time.Now().UTC().Format("20060102T150405.000000000")
+	filename := filepath.Join(userDir, name+archiveExt)
+	if _, err := os.Stat(filename); err == nil {
+		return "", fmt.Errorf("failed to create archive file: %s already exists", filename)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	err := json.NewEncoder(gz).Encode(workouts)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = r.files.WriteFile(filename, buf.Bytes(), 0644)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write archive file: %w", err)
+	}
+
+	return name, nil
+}
+
+// Load reads every archive file for the user
+func (r *JSONArchiveRepository) Load(userID uuid.UUID) ([]models.Workout, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := os.ReadDir(r.userDir(userID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.Workout{}, nil
+		}
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+
+	workouts := []models.Workout{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), archiveExt) {
+			continue
+		}
+		archived, err := r.readArchiveFile(filepath.Join(r.userDir(userID), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, archived...)
+	}
+
+	return workouts, nil
+}
+
+// Delete removes an archive file
+func (r *JSONArchiveRepository) Delete(userID uuid.UUID, name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := os.Remove(filepath.Join(r.userDir(userID), name+archiveExt)); err != nil {
+		return fmt.Errorf("failed to delete archive: %w", err)
+	}
+	return nil
+}
+
+func (r *JSONArchiveRepository) userDir(userID uuid.UUID) string {
+	return filepath.Join(r.archiveDir, userID.String())
+}
+
+func (r *JSONArchiveRepository) readArchiveFile(filename string) ([]models.Workout, error) {
+	data, err := r.files.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive file: %w", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive file %s: %w", filepath.Base(filename), err)
+	}
+	defer gz.Close()
+
+	var workouts []models.Workout
+	if err := json.NewDecoder(gz).Decode(&workouts); err != nil {
+		return nil, fmt.Errorf("failed to parse archive file %s: %w", filepath.Base(filename), err)
+	}
+	return workouts, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupArchiveRepo(t *testing.T) *JSONArchiveRepository {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONArchiveRepository()
+	require.NoError(t, err)
+	return repo.(*JSONArchiveRepository)
+}
+
+func archiveWorkouts(days ...int) []models.Workout {
+	workouts := make([]models.Workout, len(days))
+	for i, day := range days {
+		workouts[i] = models.Workout{
+			ID:        uuid.New(),
+			Day:       day,
+			Exercises: []models.Lift{{ID: uuid.New(), LiftName: models.Squat}},
+			EnteredAt: time.Date(2022, 1, day, 18, 0, 0, 0, time.UTC),
+		}
+	}
+	return workouts
+}
+
+func TestArchiveRepository_SaveAndLoad(t *testing.T) {
+	repo := setupArchiveRepo(t)
+	userID := uuid.New()
+
+	first, err := repo.Save(userID, archiveWorkouts(1, 2))
+	require.NoError(t, err)
+	second, err := repo.Save(userID, archiveWorkouts(3))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	assert.FileExists(t, filepath.Join(repo.archiveDir, userID.String(), first+".json.gz"))
+
+	loaded, err := repo.Load(userID)
+	require.NoError(t, err)
+	days := make([]int, len(loaded))
+	for i, w := range loaded {
+		days[i] = w.Day
+	}
+	slices.Sort(days)
+	assert.Equal(t, []int{1, 2, 3}, days)
+	assert.Equal(t, models.Squat, loaded[0].Exercises[0].LiftName)
+
+	// Other users' archives are separate
+	other, err := repo.Load(uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, other)
+	assert.NotNil(t, other)
+}
+
+func TestArchiveRepository_Delete(t *testing.T) {
+	repo := setupArchiveRepo(t)
+	userID := uuid.New()
+
+	name, err := repo.Save(userID, archiveWorkouts(1))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(userID, name))
+	loaded, err := repo.Load(userID)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	assert.Error(t, repo.Delete(userID, name))
+}
+
+func TestArchiveRepository_CorruptFile(t *testing.T) {
+	repo := setupArchiveRepo(t)
+	userID := uuid.New()
+
+	userDir := filepath.Join(repo.archiveDir, userID.String())
+	require.NoError(t, os.MkdirAll(userDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(userDir, "bad.json.gz"), []byte("not gzip"), 0644))
+
+	_, err := repo.Load(userID)
+	assert.ErrorContains(t, err, "failed to read archive file bad.json.gz")
+}
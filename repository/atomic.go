@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// backupExt is appended to a user file's name for the copy of its previous version
+const backupExt = ".bak"
+
+// writeLog, when set, is told the name of every file written
+var writeLog io.Writer
+
+// SetWriteLog reports the name of every file the repositories write to w, or
+// stops reporting if w is nil
+func SetWriteLog(w io.Writer) {
+	writeLog = w
+}
+
+// writeFileAtomic writes data to a temporary file beside filename and renames it
+// into place, so a crash mid-write leaves either the old file or the new one,
+// never a partial write
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(filename)
+	file, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempName := file.Name()
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tempName, perm)
+	}
+	if err == nil {
+		err = os.Rename(tempName, filename)
+	}
+	if err != nil {
+		os.Remove(tempName)
+		return err
+	}
+
+	// Persist the rename itself; not every platform can sync a directory
+	if dirFile, err := os.Open(dir); err == nil {
+		dirFile.Sync()
+		dirFile.Close()
+	}
+	if writeLog != nil {
+		fmt.Fprintf(writeLog, "Wrote %s\n", filename)
+	}
+	return nil
+}
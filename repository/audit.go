@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// JSONAuditRepository implements AuditRepository with a JSON Lines file per
+// user in the audit directory, one entry per line
+type JSONAuditRepository struct {
+	auditDir string
+	files    FileStore
+	mutex    sync.Mutex
+}
+
+// NewJSONAuditRepository creates a new JSONAuditRepository instance
+func NewJSONAuditRepository() (AuditRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONAuditRepository{auditDir: filepath.Join(greyskullDir, "audit"), files: files}, nil
+}
+
+// Append adds entries to the end of the user's log file. The file is rewritten
+// whole so it can be stored encrypted; earlier entries are never changed.
+func (r *JSONAuditRepository) Append(userID uuid.UUID, entries []models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := r.files.ReadFile(r.logFile(userID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit entry: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	if err := os.MkdirAll(r.auditDir, 0755); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	if err := r.files.WriteFile(r.logFile(userID), data, 0644); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Load reads the user's log file, oldest entry first
+func (r *JSONAuditRepository) Load(userID uuid.UUID) ([]models.AuditEntry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := r.files.ReadFile(r.logFile(userID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []models.AuditEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	entries := []models.AuditEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry models.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func (r *JSONAuditRepository) logFile(userID uuid.UUID) string {
+	return filepath.Join(r.auditDir, userID.String()+".jsonl")
+}
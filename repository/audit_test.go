@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository_AppendAndLoad(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONAuditRepository()
+	require.NoError(t, err)
+	userID := uuid.New()
+
+	entries, err := repo.Load(userID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	at := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	first := models.AuditEntry{At: at, Command: "user create", Action: models.UserCreated, After: "Alice"}
+	second := models.AuditEntry{At: at.Add(time.Minute), Command: "workout log", Action: models.WeightChanged,
+		Program: "OG Greyskull LP", Lift: models.Squat, Before: "135 lbs", After: "140 lbs"}
+	require.NoError(t, repo.Append(userID, []models.AuditEntry{first}))
+	require.NoError(t, repo.Append(userID, nil))
+	require.NoError(t, repo.Append(userID, []models.AuditEntry{second}))
+
+	entries, err = repo.Load(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.AuditEntry{first, second}, entries)
+
+	// Other users have their own logs
+	entries, err = repo.Load(uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// backupTimeFormat names backup files by the time they were taken, in UTC
+const backupTimeFormat = "20060102T150405.000000000"
+
+// JSONBackupRepository implements BackupRepository by copying user files into a
+// directory per user, named <time>-<reason>.json
+type JSONBackupRepository struct {
+	usersDir  string
+	backupDir string
+	history   WorkoutHistoryRepository
+	files     FileStore
+	mutex     sync.Mutex
+}
+
+// NewJSONBackupRepository creates a new JSONBackupRepository instance
+func NewJSONBackupRepository() (BackupRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := NewJSONWorkoutHistoryRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONBackupRepository{
+		usersDir:  filepath.Join(greyskullDir, "users"),
+		backupDir: filepath.Join(greyskullDir, "backups"),
+		history:   history,
+		files:     files,
+	}, nil
+}
+
+// Snapshot copies the user's file as it is currently stored
+func (r *JSONBackupRepository) Snapshot(username, reason string) (Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	name := strings.ToLower(username)
+	data, err := r.files.ReadFile(filepath.Join(r.usersDir, name+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Backup{}, ErrUserNotFound
+		}
+		return Backup{}, fmt.Errorf("failed to read user file: %w", err)
+	}
+	if data, err = r.withHistory(data); err != nil {
+		return Backup{}, err
+	}
+
+	userDir := filepath.Join(r.backupDir, name)
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		return Backup{}, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	createdAt := time.Now().UTC()
+	backupPath := filepath.Join(userDir, createdAt.Format(backupTimeFormat)+"-"+reason+".json")
+	if _, err := os.Stat(backupPath); err == nil {
+		return Backup{}, fmt.Errorf("failed to create backup file: %s already exists", backupPath)
+	}
+	if err := r.files.WriteFile(backupPath, data, 0644); err != nil {
+		return Backup{}, fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	return Backup{Username: username, Reason: reason, CreatedAt: createdAt, Path: backupPath}, nil
+}
+
+// List reads the backup directory of every user. Backup files store the user's
+// data as-is, so the username is taken from the directory, which is lowercase.
+func (r *JSONBackupRepository) List() ([]Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	userDirs, err := os.ReadDir(r.backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Backup{}, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []Backup{}
+	for _, userDir := range userDirs {
+		if !userDir.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(r.backupDir, userDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup directory: %w", err)
+		}
+		for _, entry := range entries {
+			backup, ok := parseBackupName(entry.Name())
+			if entry.IsDir() || !ok {
+				continue
+			}
+			backup.Username = userDir.Name()
+			backup.Path = filepath.Join(r.backupDir, userDir.Name(), entry.Name())
+			backups = append(backups, backup)
+		}
+	}
+
+	slices.SortFunc(backups, func(a, b Backup) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return backups, nil
+}
+
+// parseBackupName reads the time and reason from a backup file name
+func parseBackupName(name string) (Backup, bool) {
+	base, ok := strings.CutSuffix(name, ".json")
+	if !ok {
+		return Backup{}, false
+	}
+	stamp, reason, _ := strings.Cut(base, "-")
+	createdAt, err := time.Parse(backupTimeFormat, stamp)
+	if err != nil {
+		return Backup{}, false
+	}
+	return Backup{Reason: reason, CreatedAt: createdAt}, true
+}
+
+// withHistory adds the user's stored workout history to their user file, so a
+// backup holds everything needed to roll back by copying it over the user file.
+// User files that already hold their history are returned as they are.
+func (r *JSONBackupRepository) withHistory(data []byte) ([]byte, error) {
+	var user map[string]any
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user data: %w", err)
+	}
+	if user["workout_history"] != nil {
+		return data, nil
+	}
+
+	id, _ := user["id"].(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id %q: %w", id, err)
+	}
+	history, err := r.history.Load(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workout history: %w", err)
+	}
+	user["workout_history"] = history
+
+	data, err = json.MarshalIndent(user, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup data: %w", err)
+	}
+	return data, nil
+}
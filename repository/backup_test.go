@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRepository_SnapshotAndList(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	userRepo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Username: "Alice"}
+	user.AddWorkout(models.Workout{ID: uuid.New(), Day: 1, EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)})
+	require.NoError(t, userRepo.Create(t.Context(), user))
+
+	repo, err := NewJSONBackupRepository()
+	require.NoError(t, err)
+
+	first, err := repo.Snapshot("Alice", "import")
+	require.NoError(t, err)
+	second, err := repo.Snapshot("alice", "restart")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Path, second.Path)
+
+	// Backups are copies of the stored user file with the workout history,
+	// which is stored apart from it, folded back in
+	original, err := os.ReadFile(filepath.Join(userRepo.(*JSONUserRepository).usersDir, "alice.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(original), "workout_history")
+	copied, err := readUserFile(plainFiles{}, first.Path)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, copied.ID)
+	assert.Len(t, copied.WorkoutHistory, 1)
+
+	backups, err := repo.List()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "restart", backups[0].Reason)
+	assert.Equal(t, "import", backups[1].Reason)
+	assert.Equal(t, "alice", backups[1].Username)
+	assert.Equal(t, first.Path, backups[1].Path)
+	assert.True(t, first.CreatedAt.Equal(backups[1].CreatedAt))
+}
+
+func TestBackupRepository_Errors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONBackupRepository()
+	require.NoError(t, err)
+
+	backups, err := repo.List()
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+	assert.NotNil(t, backups)
+
+	_, err = repo.Snapshot("Nobody", "import")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// JSONConfigRepository implements ConfigRepository with one JSON file per user
+// in the config directory, named by lowercase username
+type JSONConfigRepository struct {
+	configsDir string
+	mutex      sync.Mutex
+}
+
+// NewJSONConfigRepository creates a new JSONConfigRepository instance
+func NewJSONConfigRepository() (ConfigRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONConfigRepository{configsDir: filepath.Join(greyskullDir, "config")}, nil
+}
+
+// Get returns the user's stored config, or an empty config
+func (r *JSONConfigRepository) Get(username string) (*models.Config, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	config := &models.Config{}
+	data, err := os.ReadFile(r.configFile(username))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return config, nil
+}
+
+// Save writes the user's config file, readable only by its owner since it may
+// hold API credentials
+func (r *JSONConfigRepository) Save(username string, config *models.Config) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.MkdirAll(r.configsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := writeFileAtomic(r.configFile(username), data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+func (r *JSONConfigRepository) configFile(username string) string {
+	return filepath.Join(r.configsDir, strings.ToLower(username)+".json")
+}
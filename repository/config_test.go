@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRepository_SaveAndGet(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONConfigRepository()
+	require.NoError(t, err)
+
+	config, err := repo.Get("Alice")
+	require.NoError(t, err)
+	assert.Equal(t, &models.Config{}, config)
+
+	config.BarWeight = 35
+	config.BarUnit = models.Pounds
+	config.Plates = []models.PlateCount{{Weight: 45, Count: 4}, {Weight: 2.5, Count: 2}}
+	config.DateFormat = models.DateUS
+	require.NoError(t, repo.Save("Alice", config))
+
+	// Usernames are case-insensitive, and each user has their own config
+	loaded, err := repo.Get("alice")
+	require.NoError(t, err)
+	assert.Equal(t, config, loaded)
+
+	other, err := repo.Get("bob")
+	require.NoError(t, err)
+	assert.Equal(t, &models.Config{}, other)
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// UserFile describes a user file as it is stored, before any fallback to its
+// backup, for diagnosing the data directory
+type UserFile struct {
+	Path string
+
+	// Username and ID are read from the backup when only it can be read, and
+	// are empty when neither can
+	Username string
+	ID       uuid.UUID
+
+	// Err is why the file can't be read, or nil if it's valid
+	Err error
+
+	// BackupValid reports whether the file's backup can be read, and so restored
+	BackupValid bool
+}
+
+// InspectUserFiles reads every user file in the data directory, in name order,
+// reporting files that fail to parse instead of skipping them as List does
+func InspectUserFiles() ([]UserFile, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	userData, err := userFiles(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	usersDir := filepath.Join(dataDir, "users")
+	entries, err := os.ReadDir(usersDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users directory: %w", err)
+	}
+
+	var files []UserFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		file := UserFile{Path: filepath.Join(usersDir, entry.Name())}
+		user, err := readUserFile(userData, file.Path)
+		if err != nil {
+			file.Err = err
+			user, err = readUserFile(userData, file.Path+backupExt)
+			file.BackupValid = err == nil
+		}
+		if user != nil {
+			file.Username = user.Username
+			file.ID = user.ID
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// RestoreUserBackup replaces a user file with its backup. Both are stored the
+// same way, so an encrypted backup is copied as it is.
+func RestoreUserBackup(path string) error {
+	data, err := os.ReadFile(path + backupExt)
+	if err != nil {
+		return fmt.Errorf("failed to read user backup file: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write user file: %w", err)
+	}
+	return nil
+}
+
+// StoredCurrentUser returns the username saved as the current user, whether or
+// not that user exists, or an empty string if none is saved
+func StoredCurrentUser() (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dataDir, "current_user.txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read current user file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// ClearCurrentUser removes the saved current user, so no user is current
+func ClearCurrentUser() error {
+	dataDir, err := DataDir()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(dataDir, "current_user.txt"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove current user file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectUserFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	userRepo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+	usersDir := userRepo.(*JSONUserRepository).usersDir
+
+	alice := &models.User{ID: uuid.New(), Username: "Alice"}
+	require.NoError(t, userRepo.Create(t.Context(), alice))
+	bob := &models.User{ID: uuid.New(), Username: "Bob"}
+	require.NoError(t, userRepo.Create(t.Context(), bob))
+	require.NoError(t, userRepo.Update(t.Context(), bob)) // Leaves a backup
+	require.NoError(t, os.WriteFile(filepath.Join(usersDir, "bob.json"), []byte("{"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(usersDir, "carol.json"), []byte("not json"), 0644))
+
+	files, err := InspectUserFiles()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	assert.Equal(t, UserFile{Path: filepath.Join(usersDir, "alice.json"), Username: "Alice", ID: alice.ID}, files[0])
+
+	assert.Error(t, files[1].Err)
+	assert.True(t, files[1].BackupValid)
+	assert.Equal(t, "Bob", files[1].Username)
+	assert.Equal(t, bob.ID, files[1].ID)
+
+	assert.Error(t, files[2].Err)
+	assert.False(t, files[2].BackupValid)
+	assert.Empty(t, files[2].Username)
+
+	require.NoError(t, RestoreUserBackup(files[1].Path))
+	restored, err := readUserFile(plainFiles{}, files[1].Path)
+	require.NoError(t, err)
+	assert.Equal(t, bob.ID, restored.ID)
+
+	assert.Error(t, RestoreUserBackup(files[2].Path))
+}
+
+func TestStoredCurrentUser(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	userRepo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+
+	current, err := StoredCurrentUser()
+	require.NoError(t, err)
+	assert.Empty(t, current)
+
+	require.NoError(t, userRepo.Create(t.Context(), &models.User{ID: uuid.New(), Username: "Alice"}))
+	require.NoError(t, userRepo.SetCurrent(t.Context(), "alice"))
+	require.NoError(t, os.Remove(filepath.Join(userRepo.(*JSONUserRepository).usersDir, "alice.json")))
+
+	// The stored name is returned even though the user is gone
+	current, err = StoredCurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", current)
+	_, err = userRepo.GetCurrent(t.Context())
+	assert.ErrorIs(t, err, ErrNoCurrentUser)
+
+	require.NoError(t, ClearCurrentUser())
+	current, err = StoredCurrentUser()
+	require.NoError(t, err)
+	assert.Empty(t, current)
+	require.NoError(t, ClearCurrentUser())
+}
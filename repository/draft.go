@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// JSONDraftRepository implements DraftRepository with one JSON file per user in
+// the drafts directory, named by lowercase username
+type JSONDraftRepository struct {
+	draftsDir string
+	files     FileStore
+	mutex     sync.Mutex
+}
+
+// NewJSONDraftRepository creates a new JSONDraftRepository instance
+func NewJSONDraftRepository() (DraftRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONDraftRepository{draftsDir: filepath.Join(greyskullDir, "drafts"), files: files}, nil
+}
+
+// Get returns the user's stored draft, or nil
+func (r *JSONDraftRepository) Get(username string) (*models.LogDraft, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := r.files.ReadFile(r.draftFile(username))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read draft file: %w", err)
+	}
+
+	draft := &models.LogDraft{}
+	if err := json.Unmarshal(data, draft); err != nil {
+		return nil, fmt.Errorf("failed to parse draft file: %w", err)
+	}
+	return draft, nil
+}
+
+// Save writes the user's draft file
+func (r *JSONDraftRepository) Save(username string, draft *models.LogDraft) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal draft: %w", err)
+	}
+	if err := os.MkdirAll(r.draftsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create drafts directory: %w", err)
+	}
+	if err := r.files.WriteFile(r.draftFile(username), data, 0644); err != nil {
+		return fmt.Errorf("failed to write draft file: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user's draft file, if there is one
+func (r *JSONDraftRepository) Delete(username string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := os.Remove(r.draftFile(username)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete draft file: %w", err)
+	}
+	return nil
+}
+
+func (r *JSONDraftRepository) draftFile(username string) string {
+	return filepath.Join(r.draftsDir, strings.ToLower(username)+".json")
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftRepository_SaveGetDelete(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONDraftRepository()
+	require.NoError(t, err)
+
+	draft, err := repo.Get("Alice")
+	require.NoError(t, err)
+	assert.Nil(t, draft)
+
+	// Deleting a missing draft is fine
+	require.NoError(t, repo.Delete("Alice"))
+
+	saved := &models.LogDraft{
+		Workout:   models.Workout{ID: uuid.New(), UserProgramID: uuid.New(), Day: 2},
+		FailMode:  true,
+		Answers:   []int{5, 5, 4},
+		UpdatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.Save("Alice", saved))
+
+	// Usernames are case-insensitive, and each user has their own draft
+	loaded, err := repo.Get("alice")
+	require.NoError(t, err)
+	assert.Equal(t, saved.Workout.UserProgramID, loaded.Workout.UserProgramID)
+	assert.Equal(t, saved.Answers, loaded.Answers)
+	assert.True(t, loaded.FailMode)
+
+	other, err := repo.Get("bob")
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	require.NoError(t, repo.Delete("ALICE"))
+	draft, err = repo.Get("alice")
+	require.NoError(t, err)
+	assert.Nil(t, draft)
+}
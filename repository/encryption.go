@@ -0,0 +1,343 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PassphraseEnv names the environment variable holding the passphrase for
+// encrypted data
+const PassphraseEnv = "GREYSKULL_PASSPHRASE"
+
+// Sentinel errors for encrypted data
+var (
+	ErrPassphraseRequired = errors.New("user data is encrypted; set " + PassphraseEnv + " to its passphrase")
+	ErrWrongPassphrase    = errors.New("wrong passphrase for encrypted user data; check " + PassphraseEnv)
+	ErrNotEncrypted       = errors.New("user data is not encrypted")
+	ErrAlreadyEncrypted   = errors.New("user data is already encrypted")
+)
+
+// encryptionFile, in the data directory, holds what's needed to derive the key
+// from the passphrase. Its presence turns encryption on.
+const encryptionFile = "encryption.json"
+
+// encryptedMagic starts every encrypted file, so files written before
+// encryption was turned on can still be told apart and read
+var encryptedMagic = []byte("greyskull-aes-gcm-v1\n")
+
+// keyIterations is the PBKDF2-SHA256 work factor for new encryption files
+var keyIterations = 600_000
+
+// encryptionCheck is sealed into the encryption file to recognize a wrong passphrase
+var encryptionCheck = []byte("greyskull")
+
+// encryptionParams is the stored form of encryptionFile
+type encryptionParams struct {
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Check      []byte `json:"check"`
+}
+
+// FileStore reads and writes the files holding user data: user files, workout
+// history, backups, archives, drafts, and audit logs
+type FileStore interface {
+	// ReadFile returns a file's contents
+	ReadFile(name string) ([]byte, error)
+
+	// WriteFile replaces a file's contents atomically
+	WriteFile(name string, data []byte, perm os.FileMode) error
+}
+
+// plainFiles stores data as it is given
+type plainFiles struct{}
+
+func (plainFiles) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (plainFiles) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return writeFileAtomic(name, data, perm)
+}
+
+// EncryptedFiles wraps a FileStore, encrypting data with AES-256-GCM before it
+// is written and decrypting it after it's read. Files written before
+// encryption was turned on are read as they are, and encrypted when next saved.
+type EncryptedFiles struct {
+	inner FileStore
+	aead  cipher.AEAD
+}
+
+// NewEncryptedFiles wraps inner to encrypt with a 32-byte key
+func NewEncryptedFiles(inner FileStore, key []byte) (*EncryptedFiles, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &EncryptedFiles{inner: inner, aead: aead}, nil
+}
+
+// ReadFile reads and decrypts a file
+func (f *EncryptedFiles) ReadFile(name string) ([]byte, error) {
+	data, err := f.inner.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.open(data)
+}
+
+// WriteFile encrypts and writes a file
+func (f *EncryptedFiles) WriteFile(name string, data []byte, perm os.FileMode) error {
+	sealed, err := f.seal(data)
+	if err != nil {
+		return err
+	}
+	return f.inner.WriteFile(name, sealed, perm)
+}
+
+// seal encrypts data under a fresh nonce, stored after the magic prefix
+func (f *EncryptedFiles) seal(data []byte) ([]byte, error) {
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := append(bytes.Clone(encryptedMagic), nonce...)
+	return f.aead.Seal(sealed, nonce, data, encryptedMagic), nil
+}
+
+// open decrypts sealed data, returning unencrypted data as it is
+func (f *EncryptedFiles) open(data []byte) ([]byte, error) {
+	sealed, ok := bytes.CutPrefix(data, encryptedMagic)
+	if !ok {
+		return data, nil
+	}
+	if len(sealed) < f.aead.NonceSize() {
+		return nil, errors.New("failed to decrypt: file is truncated")
+	}
+	nonce, ciphertext := sealed[:f.aead.NonceSize()], sealed[f.aead.NonceSize():]
+	plain, err := f.aead.Open(nil, nonce, ciphertext, encryptedMagic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plain, nil
+}
+
+// derivedKeys caches keys by encryption file and passphrase, so the
+// repositories a command opens share one key derivation
+var derivedKeys sync.Map
+
+// userFiles returns the FileStore for the user data in a data directory:
+// encrypted with the passphrase in PassphraseEnv once encryption is turned
+// on, or plain otherwise
+func userFiles(dataDir string) (FileStore, error) {
+	params, err := readEncryptionParams(dataDir)
+	if errors.Is(err, ErrNotEncrypted) {
+		return plainFiles{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	return params.files(passphrase)
+}
+
+// files derives the key for a passphrase and checks it against the stored check
+func (p *encryptionParams) files(passphrase string) (*EncryptedFiles, error) {
+	cacheKey := string(p.Salt) + "\x00" + passphrase
+	if cached, ok := derivedKeys.Load(cacheKey); ok {
+		return cached.(*EncryptedFiles), nil
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, p.Salt, p.Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	files, err := NewEncryptedFiles(plainFiles{}, key)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := files.open(p.Check); err != nil || !bytes.Equal(check, encryptionCheck) {
+		return nil, ErrWrongPassphrase
+	}
+
+	derivedKeys.Store(cacheKey, files)
+	return files, nil
+}
+
+// readEncryptionParams reads the data directory's encryption file, returning
+// ErrNotEncrypted if there is none
+func readEncryptionParams(dataDir string) (*encryptionParams, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, encryptionFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotEncrypted
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption file: %w", err)
+	}
+
+	var params encryptionParams
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse encryption file: %w", err)
+	}
+	if params.Iterations <= 0 || len(params.Salt) == 0 {
+		return nil, errors.New("invalid encryption file: missing key parameters")
+	}
+	return &params, nil
+}
+
+// EncryptionEnabled reports whether user data in the data directory is encrypted
+func EncryptionEnabled() (bool, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return false, err
+	}
+	_, err = readEncryptionParams(dataDir)
+	if errors.Is(err, ErrNotEncrypted) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// EnableEncryption turns on encryption with a passphrase and encrypts the user
+// data already stored, returning how many files were encrypted
+func EnableEncryption(passphrase string) (int, error) {
+	if passphrase == "" {
+		return 0, errors.New("the passphrase can't be empty")
+	}
+	dataDir, err := DataDir()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	unlock, err := acquireLock(filepath.Join(dataDir, "users.lock"), lockTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if _, err := readEncryptionParams(dataDir); !errors.Is(err, ErrNotEncrypted) {
+		if err == nil {
+			return 0, ErrAlreadyEncrypted
+		}
+		return 0, err
+	}
+
+	params := &encryptionParams{Iterations: keyIterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(params.Salt); err != nil {
+		return 0, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, params.Salt, params.Iterations, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to derive key: %w", err)
+	}
+	files, err := NewEncryptedFiles(plainFiles{}, key)
+	if err != nil {
+		return 0, err
+	}
+	if params.Check, err = files.seal(encryptionCheck); err != nil {
+		return 0, err
+	}
+
+	// Write the encryption file first, so an interrupted run leaves data the
+	// encrypted store can read: it reads files not yet encrypted as they are
+	data, err := json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal encryption file: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dataDir, encryptionFile), data, 0600); err != nil {
+		return 0, fmt.Errorf("failed to write encryption file: %w", err)
+	}
+	return rewriteUserData(dataDir, plainFiles{}, files)
+}
+
+// DisableEncryption decrypts the stored user data with a passphrase and turns
+// encryption off, returning how many files were decrypted
+func DisableEncryption(passphrase string) (int, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return 0, err
+	}
+	unlock, err := acquireLock(filepath.Join(dataDir, "users.lock"), lockTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	params, err := readEncryptionParams(dataDir)
+	if err != nil {
+		return 0, err
+	}
+	files, err := params.files(passphrase)
+	if err != nil {
+		return 0, err
+	}
+
+	// Decrypt the files first, for the same reason
+	count, err := rewriteUserData(dataDir, files, plainFiles{})
+	if err != nil {
+		return count, err
+	}
+
+	if err := os.Remove(filepath.Join(dataDir, encryptionFile)); err != nil {
+		return count, fmt.Errorf("failed to remove encryption file: %w", err)
+	}
+	return count, nil
+}
+
+// userDataDirs are the data directory's subdirectories whose files hold user data
+var userDataDirs = []string{"users", "history", "backups", "archive", "drafts", "audit"}
+
+// rewriteUserData reads every user data file with from and writes it back
+// with to, skipping the temporary files of interrupted writes
+func rewriteUserData(dataDir string, from, to FileStore) (int, error) {
+	count := 0
+	for _, dir := range userDataDirs {
+		err := filepath.WalkDir(filepath.Join(dataDir, dir), func(path string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil || entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				return err
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			data, err := from.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			if err := to.WriteFile(path, data, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			count++
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
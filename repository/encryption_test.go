@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedFiles_RoundTrip(t *testing.T) {
+	files, err := NewEncryptedFiles(plainFiles{}, bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "alice.json")
+
+	require.NoError(t, files.WriteFile(path, []byte(`{"username": "Alice"}`), 0644))
+	stored, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(stored, encryptedMagic))
+	assert.NotContains(t, string(stored), "Alice")
+
+	data, err := files.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"username": "Alice"}`, string(data))
+
+	// Files written before encryption are read as they are
+	require.NoError(t, os.WriteFile(path, []byte(`{"username": "Bob"}`), 0644))
+	data, err = files.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"username": "Bob"}`, string(data))
+
+	// Another key can't read the file
+	require.NoError(t, files.WriteFile(path, []byte(`{"username": "Alice"}`), 0644))
+	other, err := NewEncryptedFiles(plainFiles{}, bytes.Repeat([]byte{8}, 32))
+	require.NoError(t, err)
+	_, err = other.ReadFile(path)
+	assert.ErrorContains(t, err, "failed to decrypt")
+}
+
+func TestEnableEncryption(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	keyIterations = 1000
+	t.Cleanup(func() { keyIterations = 600_000 })
+
+	userRepo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Username: "Alice"}
+	user.AddWorkout(models.Workout{ID: uuid.New(), Day: 1, EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)})
+	require.NoError(t, userRepo.Create(t.Context(), user))
+
+	count, err := EnableEncryption("hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count) // The user file and a month of history
+
+	enabled, err := EncryptionEnabled()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	_, err = EnableEncryption("hunter2")
+	assert.ErrorIs(t, err, ErrAlreadyEncrypted)
+
+	dataDir, err := DataDir()
+	require.NoError(t, err)
+	stored, err := os.ReadFile(filepath.Join(dataDir, "users", "alice.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "Alice")
+
+	// Repositories need the passphrase
+	t.Setenv(PassphraseEnv, "")
+	_, err = NewJSONUserRepository()
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+	t.Setenv(PassphraseEnv, "hunter3")
+	_, err = NewJSONUserRepository()
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	t.Setenv(PassphraseEnv, "hunter2")
+	userRepo, err = NewJSONUserRepository()
+	require.NoError(t, err)
+	loaded, err := userRepo.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	assert.Len(t, loaded.History(), 1)
+
+	_, err = DisableEncryption("hunter3")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	count, err = DisableEncryption("hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	stored, err = os.ReadFile(filepath.Join(dataDir, "users", "alice.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(stored), "Alice")
+	_, err = DisableEncryption("hunter2")
+	assert.ErrorIs(t, err, ErrNotEncrypted)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// historyMonthFormat names history files by the UTC month of their workouts,
+// e.g. "2024-03.json"
+const historyMonthFormat = "2006-01"
+
+// JSONWorkoutHistoryRepository implements WorkoutHistoryRepository with a JSON
+// file per calendar month of workouts, in a directory per user. Saving only
+// rewrites the months that changed.
+type JSONWorkoutHistoryRepository struct {
+	historyDir string
+	files      FileStore
+	mutex      sync.Mutex
+}
+
+// NewJSONWorkoutHistoryRepository creates a new JSONWorkoutHistoryRepository instance
+func NewJSONWorkoutHistoryRepository() (WorkoutHistoryRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWorkoutHistoryRepository{historyDir: filepath.Join(greyskullDir, "history"), files: files}, nil
+}
+
+// Load reads every month file for the user, oldest month first
+func (r *JSONWorkoutHistoryRepository) Load(userID uuid.UUID) ([]models.Workout, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	months, err := r.months(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workouts := []models.Workout{}
+	for _, month := range months {
+		data, err := r.files.ReadFile(r.monthFile(userID, month))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history file: %w", err)
+		}
+		var monthWorkouts []models.Workout
+		if err := json.Unmarshal(data, &monthWorkouts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal history for %s: %w", month, err)
+		}
+		workouts = append(workouts, monthWorkouts...)
+	}
+	return workouts, nil
+}
+
+// Save writes each month of workouts whose file has changed and removes the files
+// of months that no longer have any workouts, e.g. after archiving
+func (r *JSONWorkoutHistoryRepository) Save(userID uuid.UUID, workouts []models.Workout) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	byMonth := make(map[string][]models.Workout)
+	for _, w := range workouts {
+		month := w.EnteredAt.UTC().Format(historyMonthFormat)
+		byMonth[month] = append(byMonth[month], w)
+	}
+
+	existing, err := r.months(userID)
+	if err != nil {
+		return err
+	}
+	if len(byMonth) > 0 {
+		if err := os.MkdirAll(r.userDir(userID), 0755); err != nil {
+			return fmt.Errorf("failed to create history directory: %w", err)
+		}
+	}
+
+	for month, monthWorkouts := range byMonth {
+		data, err := json.MarshalIndent(monthWorkouts, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal history data: %w", err)
+		}
+		filename := r.monthFile(userID, month)
+		if current, err := r.files.ReadFile(filename); err == nil && bytes.Equal(current, data) {
+			continue
+		}
+		if err := r.files.WriteFile(filename, data, 0644); err != nil {
+			return fmt.Errorf("failed to write history file: %w", err)
+		}
+	}
+
+	for _, month := range existing {
+		if _, ok := byMonth[month]; !ok {
+			if err := os.Remove(r.monthFile(userID, month)); err != nil {
+				return fmt.Errorf("failed to remove history file: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// months returns the months the user has history files for, oldest first
+func (r *JSONWorkoutHistoryRepository) months(userID uuid.UUID) ([]string, error) {
+	entries, err := os.ReadDir(r.userDir(userID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var months []string
+	for _, entry := range entries {
+		month, ok := strings.CutSuffix(entry.Name(), ".json")
+		if ok && !entry.IsDir() && !strings.HasPrefix(month, ".") {
+			months = append(months, month)
+		}
+	}
+	slices.Sort(months)
+	return months, nil
+}
+
+func (r *JSONWorkoutHistoryRepository) userDir(userID uuid.UUID) string {
+	return filepath.Join(r.historyDir, userID.String())
+}
+
+func (r *JSONWorkoutHistoryRepository) monthFile(userID uuid.UUID, month string) string {
+	return filepath.Join(r.userDir(userID), month+".json")
+}
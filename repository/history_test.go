@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyWorkout(enteredAt time.Time) models.Workout {
+	return models.Workout{ID: uuid.New(), Day: 1, EnteredAt: enteredAt}
+}
+
+func TestWorkoutHistoryRepository_SaveAndLoad(t *testing.T) {
+	repo := &JSONWorkoutHistoryRepository{historyDir: t.TempDir(), files: plainFiles{}}
+	userID := uuid.New()
+
+	workouts, err := repo.Load(userID)
+	require.NoError(t, err)
+	assert.Empty(t, workouts)
+
+	march := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	april := time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC)
+	history := []models.Workout{historyWorkout(march), historyWorkout(march.AddDate(0, 0, 2)), historyWorkout(april)}
+	require.NoError(t, repo.Save(userID, history))
+
+	// One file per month
+	entries, err := os.ReadDir(repo.userDir(userID))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "2024-03.json", entries[0].Name())
+	assert.Equal(t, "2024-04.json", entries[1].Name())
+
+	workouts, err = repo.Load(userID)
+	require.NoError(t, err)
+	require.Len(t, workouts, 3)
+	for i, w := range workouts {
+		assert.Equal(t, history[i].ID, w.ID)
+	}
+}
+
+func TestWorkoutHistoryRepository_SaveOnlyRewritesChangedMonths(t *testing.T) {
+	repo := &JSONWorkoutHistoryRepository{historyDir: t.TempDir(), files: plainFiles{}}
+	userID := uuid.New()
+
+	march := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	april := time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC)
+	history := []models.Workout{historyWorkout(march), historyWorkout(april)}
+	require.NoError(t, repo.Save(userID, history))
+
+	// Mark March's file so a rewrite would be noticed
+	marchFile := repo.monthFile(userID, "2024-03")
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(marchFile, old, old))
+
+	history = append(history, historyWorkout(april.AddDate(0, 0, 2)))
+	require.NoError(t, repo.Save(userID, history))
+
+	info, err := os.Stat(marchFile)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old), "unchanged month should not be rewritten")
+
+	// Months left without workouts are removed
+	require.NoError(t, repo.Save(userID, history[1:]))
+	assert.NoFileExists(t, marchFile)
+	workouts, err := repo.Load(userID)
+	require.NoError(t, err)
+	assert.Len(t, workouts, 2)
+}
+
+func TestWorkoutHistoryRepository_CorruptedMonth(t *testing.T) {
+	repo := &JSONWorkoutHistoryRepository{historyDir: t.TempDir(), files: plainFiles{}}
+	userID := uuid.New()
+
+	require.NoError(t, os.MkdirAll(repo.userDir(userID), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo.userDir(userID), "2024-03.json"), []byte(`[{`), 0644))
+
+	_, err := repo.Load(userID)
+	assert.ErrorContains(t, err, "failed to unmarshal history for 2024-03")
+}
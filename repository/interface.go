@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
 )
 
@@ -11,25 +14,121 @@ var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrNoCurrentUser     = errors.New("no current user set")
+	ErrProgramExists     = errors.New("program already exists")
+	ErrLocked            = errors.New("data is locked by another greyskull command")
+	ErrConflict          = errors.New("user data was changed by another greyskull command")
 )
 
-// UserRepository defines the interface for user persistence operations
+// UserRepository defines the interface for user persistence operations. Every
+// method takes a context so backends that do I/O can honor its cancellation and
+// deadline; methods return the context's error once it's done.
 type UserRepository interface {
 	// Create creates a new user. Returns ErrUserAlreadyExists if username already exists.
-	Create(user *models.User) error
+	Create(ctx context.Context, user *models.User) error
 
 	// Get retrieves a user by username (case-insensitive). Returns ErrUserNotFound if user doesn't exist.
-	Get(username string) (*models.User, error)
+	Get(ctx context.Context, username string) (*models.User, error)
 
-	// Update updates an existing user. Returns ErrUserNotFound if user doesn't exist.
-	Update(user *models.User) error
+	// Update updates an existing user. Returns ErrUserNotFound if user doesn't exist,
+	// and ErrConflict if another process saved the user after it was read.
+	Update(ctx context.Context, user *models.User) error
 
 	// List returns all usernames in their original casing.
-	List() ([]string, error)
+	List(ctx context.Context) ([]string, error)
 
 	// GetCurrent returns the current active username. Returns ErrNoCurrentUser if none is set.
-	GetCurrent() (string, error)
+	GetCurrent(ctx context.Context) (string, error)
 
 	// SetCurrent sets the current active user. Returns ErrUserNotFound if user doesn't exist.
-	SetCurrent(username string) error
-}
\ No newline at end of file
+	SetCurrent(ctx context.Context, username string) error
+}
+
+// ProgramRepository defines the interface for custom program persistence operations
+type ProgramRepository interface {
+	// List returns all valid custom programs. Files that fail to parse or validate are skipped.
+	List() ([]*models.Program, error)
+
+	// Save stores a custom program. Returns ErrProgramExists if a program with the same ID is already stored.
+	Save(program *models.Program) error
+}
+
+// ArchiveRepository defines the interface for storing workouts moved out of a user's active history
+type ArchiveRepository interface {
+	// Save stores workouts in a new archive for the user and returns the archive's name.
+	Save(userID uuid.UUID, workouts []models.Workout) (string, error)
+
+	// Load returns every archived workout for the user, in no particular order.
+	Load(userID uuid.UUID) ([]models.Workout, error)
+
+	// Delete removes a single archive by name.
+	Delete(userID uuid.UUID, name string) error
+}
+
+// WorkoutHistoryRepository defines the interface for workout history stored apart
+// from the user, so logging a workout doesn't rewrite every workout before it
+type WorkoutHistoryRepository interface {
+	// Load returns every stored workout for the user, oldest first.
+	Load(userID uuid.UUID) ([]models.Workout, error)
+
+	// Save replaces the user's stored history with workouts.
+	Save(userID uuid.UUID, workouts []models.Workout) error
+}
+
+// AuditRepository defines the interface for each user's append-only log of
+// changes to their data
+type AuditRepository interface {
+	// Append adds entries to the end of the user's log.
+	Append(userID uuid.UUID, entries []models.AuditEntry) error
+
+	// Load returns every entry in the user's log, oldest first.
+	Load(userID uuid.UUID) ([]models.AuditEntry, error)
+}
+
+// Backup is a copy of a user's stored data taken before a destructive command
+type Backup struct {
+	Username  string
+	Reason    string
+	CreatedAt time.Time
+	Path      string
+}
+
+// BackupRepository defines the interface for snapshots of user data
+type BackupRepository interface {
+	// Snapshot copies the user's stored data to a new backup, noting the command that
+	// prompted it. Returns ErrUserNotFound if the user has nothing stored yet.
+	Snapshot(username, reason string) (Backup, error)
+
+	// List returns every backup for all users, newest first.
+	List() ([]Backup, error)
+}
+
+// LiftRepository defines the interface for custom lift definitions shared by all users
+type LiftRepository interface {
+	// List returns every custom lift definition, in the order they were first saved.
+	List() ([]models.LiftDefinition, error)
+
+	// Save stores a lift definition, replacing any existing definition with the same name.
+	Save(definition models.LiftDefinition) error
+}
+
+// ConfigRepository defines the interface for per-user config files
+type ConfigRepository interface {
+	// Get returns the user's config (case-insensitive username), or an empty config if none is stored.
+	Get(username string) (*models.Config, error)
+
+	// Save stores the user's config, replacing any existing config.
+	Save(username string, config *models.Config) error
+}
+
+// DraftRepository defines the interface for unfinished 'workout log' sessions,
+// at most one per user
+type DraftRepository interface {
+	// Get returns the user's draft (case-insensitive username), or nil if none is stored.
+	Get(username string) (*models.LogDraft, error)
+
+	// Save stores the user's draft, replacing any existing draft.
+	Save(username string, draft *models.LogDraft) error
+
+	// Delete removes the user's draft. Deleting a missing draft is not an error.
+	Delete(username string) error
+}
@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/mikowitz/greyskull/migrations"
 	"github.com/mikowitz/greyskull/models"
 )
 
@@ -16,24 +19,34 @@ type JSONUserRepository struct {
 	configDir   string
 	usersDir    string
 	currentFile string
+	lockTimeout time.Duration
+	history     WorkoutHistoryRepository
+	files       FileStore
+	versions    map[string]fileVersion // Each user file as last read or written, by filename
 	mutex       sync.Mutex
 }
 
-// NewJSONUserRepository creates a new JSONUserRepository instance
-func NewJSONUserRepository() (UserRepository, error) {
+// DataDir returns the greyskull data directory inside the user's config directory
+func DataDir() (string, error) {
 	// Check for XDG_CONFIG_HOME first (for Linux/testing), fallback to OS default
-	var configDir string
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
-		configDir = xdgConfig
-	} else {
-		var err error
-		configDir, err = os.UserConfigDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user config directory: %w", err)
-		}
+		return filepath.Join(xdgConfig, "greyskull"), nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "greyskull"), nil
+}
+
+// NewJSONUserRepository creates a new JSONUserRepository instance
+func NewJSONUserRepository() (UserRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
 	}
 
-	greyskullDir := filepath.Join(configDir, "greyskull")
 	usersDir := filepath.Join(greyskullDir, "users")
 	currentFile := filepath.Join(greyskullDir, "current_user.txt")
 
@@ -42,17 +55,38 @@ func NewJSONUserRepository() (UserRepository, error) {
 		return nil, fmt.Errorf("failed to create users directory: %w", err)
 	}
 
+	history, err := NewJSONWorkoutHistoryRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := userFiles(greyskullDir)
+	if err != nil {
+		return nil, err
+	}
+
 	return &JSONUserRepository{
 		configDir:   greyskullDir,
 		usersDir:    usersDir,
 		currentFile: currentFile,
+		lockTimeout: lockTimeout,
+		history:     history,
+		files:       files,
 	}, nil
 }
 
 // Create creates a new user
-func (r *JSONUserRepository) Create(user *models.User) error {
+func (r *JSONUserRepository) Create(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Check if user already exists (case-insensitive)
 	if r.userExists(user.Username) {
@@ -61,11 +95,18 @@ func (r *JSONUserRepository) Create(user *models.User) error {
 
 	// Save user to file
 	filename := r.getUserFilename(user.Username)
-	return r.saveUserToFile(user, filename)
+	if err := r.saveUserToFile(user, filename); err != nil {
+		return err
+	}
+	r.recordVersion(filename)
+	return nil
 }
 
 // Get retrieves a user by username (case-insensitive)
-func (r *JSONUserRepository) Get(username string) (*models.User, error) {
+func (r *JSONUserRepository) Get(ctx context.Context, username string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
@@ -74,24 +115,51 @@ func (r *JSONUserRepository) Get(username string) (*models.User, error) {
 		return nil, ErrUserNotFound
 	}
 
+	// Taken before reading, so a save in between is seen as a conflict rather
+	// than missed
+	r.recordVersion(filename)
 	return r.loadUserFromFile(filename)
 }
 
-// Update updates an existing user
-func (r *JSONUserRepository) Update(user *models.User) error {
+// Update updates an existing user. The user is read, changed, and saved by
+// separate calls, and a command may spend minutes between them waiting for
+// input, so rather than holding the lock throughout, Update refuses to save over
+// a user file another process saved after this repository read it.
+func (r *JSONUserRepository) Update(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	filename := r.findUserFile(user.Username)
 	if filename == "" {
 		return ErrUserNotFound
 	}
 
-	return r.saveUserToFile(user, filename)
+	if read, seen := r.versions[filename]; seen {
+		if current, err := readFileVersion(filename); err != nil || current != read {
+			return fmt.Errorf("%w since it was loaded; run the command again to apply your changes to the latest data", ErrConflict)
+		}
+	}
+
+	if err := r.saveUserToFile(user, filename); err != nil {
+		return err
+	}
+	r.recordVersion(filename)
+	return nil
 }
 
 // List returns all usernames in their original casing
-func (r *JSONUserRepository) List() ([]string, error) {
+func (r *JSONUserRepository) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
@@ -119,7 +187,10 @@ func (r *JSONUserRepository) List() ([]string, error) {
 }
 
 // GetCurrent returns the current active username
-func (r *JSONUserRepository) GetCurrent() (string, error) {
+func (r *JSONUserRepository) GetCurrent(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
@@ -145,9 +216,17 @@ func (r *JSONUserRepository) GetCurrent() (string, error) {
 }
 
 // SetCurrent sets the current active user
-func (r *JSONUserRepository) SetCurrent(username string) error {
+func (r *JSONUserRepository) SetCurrent(ctx context.Context, username string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Find user file (case-insensitive)
 	filename := r.findUserFile(username)
@@ -162,7 +241,7 @@ func (r *JSONUserRepository) SetCurrent(username string) error {
 	}
 
 	// Save the original username casing
-	err = os.WriteFile(r.currentFile, []byte(user.Username), 0644)
+	err = writeFileAtomic(r.currentFile, []byte(user.Username), 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write current user file: %w", err)
 	}
@@ -172,6 +251,24 @@ func (r *JSONUserRepository) SetCurrent(username string) error {
 
 // Helper methods
 
+// lock takes the lock shared by every greyskull process writing user data, so
+// two commands saving at once can't overwrite each other's changes
+func (r *JSONUserRepository) lock() (func(), error) {
+	return acquireLock(filepath.Join(r.configDir, "users.lock"), r.lockTimeout)
+}
+
+// recordVersion notes a user file's version as this repository last saw it
+func (r *JSONUserRepository) recordVersion(filename string) {
+	if version, err := readFileVersion(filename); err == nil {
+		if r.versions == nil {
+			r.versions = make(map[string]fileVersion)
+		}
+		r.versions[filename] = version
+	} else {
+		delete(r.versions, filename)
+	}
+}
+
 // getUserFilename returns the filename for a user (lowercase)
 func (r *JSONUserRepository) getUserFilename(username string) string {
 	return filepath.Join(r.usersDir, strings.ToLower(username)+".json")
@@ -193,28 +290,80 @@ func (r *JSONUserRepository) findUserFile(username string) string {
 	return ""
 }
 
-// saveUserToFile saves a user to a JSON file
+// saveUserToFile saves a user to a JSON file. The file is replaced atomically,
+// and its previous version is kept beside it as a .bak file for loadUserFromFile
+// to fall back on. Workout history is saved to the history repository, unless
+// it was never loaded and so can't have changed.
 func (r *JSONUserRepository) saveUserToFile(user *models.User, filename string) error {
-	data, err := json.MarshalIndent(user, "", "  ")
+	if user.HistoryLoaded() {
+		if err := user.LoadHistory(); err != nil {
+			return fmt.Errorf("failed to load workout history: %w", err)
+		}
+		if err := r.history.Save(user.ID, user.WorkoutHistory); err != nil {
+			return fmt.Errorf("failed to save workout history: %w", err)
+		}
+	}
+
+	user.SchemaVersion = migrations.CurrentVersion
+	stored := *user
+	stored.WorkoutHistory = nil
+	data, err := json.MarshalIndent(&stored, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal user data: %w", err)
 	}
 
-	err = os.WriteFile(filename, data, 0644)
-	if err != nil {
+	// Only a readable previous version is worth keeping; a corrupted file would
+	// overwrite a good backup
+	if previous, err := r.files.ReadFile(filename); err == nil && json.Valid(previous) {
+		if err := r.files.WriteFile(filename+backupExt, previous, 0644); err != nil {
+			return fmt.Errorf("failed to write user backup file: %w", err)
+		}
+	}
+
+	if err := r.files.WriteFile(filename, data, 0644); err != nil {
 		return fmt.Errorf("failed to write user file: %w", err)
 	}
 
 	return nil
 }
 
-// loadUserFromFile loads a user from a JSON file
+// loadUserFromFile loads a user from a JSON file, falling back to the backup of
+// its previous version when the file can't be read or parsed. Workout history
+// is read from the history repository when first used, unless the file holds
+// its own, as files written before history was stored apart and restored
+// backups do.
 func (r *JSONUserRepository) loadUserFromFile(filename string) (*models.User, error) {
-	data, err := os.ReadFile(filename)
+	user, err := readUserFile(r.files, filename)
+	if err != nil {
+		backup, backupErr := readUserFile(r.files, filename+backupExt)
+		if backupErr != nil {
+			return nil, err
+		}
+		user = backup
+	}
+
+	if user.WorkoutHistory == nil {
+		userID := user.ID
+		user.SetHistoryLoader(func() ([]models.Workout, error) {
+			return r.history.Load(userID)
+		})
+	}
+	return user, nil
+}
+
+// readUserFile reads and parses a single user file, upgrading files written by
+// older versions of greyskull. Upgrades are saved with the user's next change.
+func readUserFile(files FileStore, filename string) (*models.User, error) {
+	data, err := files.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read user file: %w", err)
 	}
 
+	data, _, err = migrations.Upgrade(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user data: %w", err)
+	}
+
 	var user models.User
 	err = json.Unmarshal(data, &user)
 	if err != nil {
@@ -222,4 +371,4 @@ func (r *JSONUserRepository) loadUserFromFile(filename string) (*models.User, er
 	}
 
 	return &user, nil
-}
\ No newline at end of file
+}
@@ -1,14 +1,17 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/migrations"
 	"github.com/mikowitz/greyskull/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,16 +50,16 @@ func TestJSONUserRepository_Create(t *testing.T) {
 	user := createTestUser("TestUser")
 
 	// Test successful creation
-	err := repo.Create(user)
+	err := repo.Create(t.Context(), user)
 	assert.NoError(t, err)
 
 	// Test duplicate creation
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	assert.ErrorIs(t, err, ErrUserAlreadyExists)
 
 	// Test case-insensitive duplicate detection
 	userLower := createTestUser("testuser")
-	err = repo.Create(userLower)
+	err = repo.Create(t.Context(), userLower)
 	assert.ErrorIs(t, err, ErrUserAlreadyExists)
 }
 
@@ -64,7 +67,7 @@ func TestJSONUserRepository_Get(t *testing.T) {
 	repo := setupTestRepository(t)
 
 	originalUser := createTestUser("TestUser")
-	err := repo.Create(originalUser)
+	err := repo.Create(t.Context(), originalUser)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -101,7 +104,7 @@ func TestJSONUserRepository_Get(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			user, err := repo.Get(tt.username)
+			user, err := repo.Get(t.Context(), tt.username)
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
@@ -120,7 +123,7 @@ func TestJSONUserRepository_Update(t *testing.T) {
 	repo := setupTestRepository(t)
 
 	user := createTestUser("TestUser")
-	err := repo.Create(user)
+	err := repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
 	// Update user data
@@ -141,18 +144,18 @@ func TestJSONUserRepository_Update(t *testing.T) {
 	user.Programs[program.ID] = program
 
 	// Test successful update
-	err = repo.Update(user)
+	err = repo.Update(t.Context(), user)
 	assert.NoError(t, err)
 
 	// Verify update was saved
-	retrievedUser, err := repo.Get("TestUser")
+	retrievedUser, err := repo.Get(t.Context(), "TestUser")
 	require.NoError(t, err)
 	assert.Len(t, retrievedUser.Programs, 1)
 	assert.Equal(t, 2, retrievedUser.Programs[program.ID].CurrentDay)
 
 	// Test update non-existent user
 	nonExistentUser := createTestUser("NonExistent")
-	err = repo.Update(nonExistentUser)
+	err = repo.Update(t.Context(), nonExistentUser)
 	assert.ErrorIs(t, err, ErrUserNotFound)
 }
 
@@ -160,7 +163,7 @@ func TestJSONUserRepository_List(t *testing.T) {
 	repo := setupTestRepository(t)
 
 	// Test empty repository
-	usernames, err := repo.List()
+	usernames, err := repo.List(t.Context())
 	assert.NoError(t, err)
 	assert.Empty(t, usernames)
 
@@ -168,12 +171,12 @@ func TestJSONUserRepository_List(t *testing.T) {
 	users := []string{"Alice", "bob", "Charlie", "DAVE"}
 	for _, username := range users {
 		user := createTestUser(username)
-		err := repo.Create(user)
+		err := repo.Create(t.Context(), user)
 		require.NoError(t, err)
 	}
 
 	// Test listing users
-	usernames, err = repo.List()
+	usernames, err = repo.List(t.Context())
 	assert.NoError(t, err)
 	assert.Len(t, usernames, 4)
 
@@ -188,30 +191,30 @@ func TestJSONUserRepository_CurrentUser(t *testing.T) {
 	repo := setupTestRepository(t)
 
 	// Test no current user
-	current, err := repo.GetCurrent()
+	current, err := repo.GetCurrent(t.Context())
 	assert.ErrorIs(t, err, ErrNoCurrentUser)
 	assert.Empty(t, current)
 
 	// Create a user
 	user := createTestUser("TestUser")
-	err = repo.Create(user)
+	err = repo.Create(t.Context(), user)
 	require.NoError(t, err)
 
 	// Test setting current user
-	err = repo.SetCurrent("testuser") // Case-insensitive
+	err = repo.SetCurrent(t.Context(), "testuser") // Case-insensitive
 	assert.NoError(t, err)
 
 	// Test getting current user
-	current, err = repo.GetCurrent()
+	current, err = repo.GetCurrent(t.Context())
 	assert.NoError(t, err)
 	assert.Equal(t, "TestUser", current) // Original casing preserved
 
 	// Test setting non-existent user as current
-	err = repo.SetCurrent("NonExistent")
+	err = repo.SetCurrent(t.Context(), "NonExistent")
 	assert.ErrorIs(t, err, ErrUserNotFound)
 
 	// Verify current user unchanged
-	current, err = repo.GetCurrent()
+	current, err = repo.GetCurrent(t.Context())
 	assert.NoError(t, err)
 	assert.Equal(t, "TestUser", current)
 }
@@ -232,7 +235,7 @@ func TestJSONUserRepository_ConcurrentAccess(t *testing.T) {
 			defer wg.Done()
 			for j := 0; j < numUsers; j++ {
 				user := createTestUser(fmt.Sprintf("User_%d_%d", goroutineID, j))
-				err := repo.Create(user)
+				err := repo.Create(t.Context(), user)
 				if err != nil {
 					errors <- err
 				}
@@ -249,7 +252,7 @@ func TestJSONUserRepository_ConcurrentAccess(t *testing.T) {
 	}
 
 	// Verify all users were created
-	usernames, err := repo.List()
+	usernames, err := repo.List(t.Context())
 	assert.NoError(t, err)
 	assert.Len(t, usernames, numGoroutines*numUsers)
 }
@@ -259,7 +262,7 @@ func TestJSONUserRepository_CaseInsensitiveHandling(t *testing.T) {
 
 	// Create user with mixed case
 	originalUser := createTestUser("MixedCaseUser")
-	err := repo.Create(originalUser)
+	err := repo.Create(t.Context(), originalUser)
 	require.NoError(t, err)
 
 	testCases := []string{
@@ -272,21 +275,95 @@ func TestJSONUserRepository_CaseInsensitiveHandling(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run("access_with_"+testCase, func(t *testing.T) {
 			// Test Get
-			user, err := repo.Get(testCase)
+			user, err := repo.Get(t.Context(), testCase)
 			assert.NoError(t, err)
 			assert.Equal(t, "MixedCaseUser", user.Username)
 
 			// Test SetCurrent
-			err = repo.SetCurrent(testCase)
+			err = repo.SetCurrent(t.Context(), testCase)
 			assert.NoError(t, err)
 
-			current, err := repo.GetCurrent()
+			current, err := repo.GetCurrent(t.Context())
 			assert.NoError(t, err)
 			assert.Equal(t, "MixedCaseUser", current)
 		})
 	}
 }
 
+func TestJSONUserRepository_AtomicSaveKeepsBackup(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	user := createTestUser("TestUser")
+	require.NoError(t, repo.Create(t.Context(), user))
+	filename := jsonRepo.getUserFilename("TestUser")
+	assert.NoFileExists(t, filename+".bak")
+
+	user.Unit = models.Kilograms
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// The backup holds the previous version
+	backup, err := readUserFile(plainFiles{}, filename+".bak")
+	require.NoError(t, err)
+	assert.Equal(t, models.WeightUnit(""), backup.Unit)
+
+	// No temporary files are left behind, and the backup isn't listed as a user
+	entries, err := os.ReadDir(jsonRepo.usersDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	usernames, err := repo.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TestUser"}, usernames)
+}
+
+func TestSetWriteLog(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	var log strings.Builder
+	SetWriteLog(&log)
+	t.Cleanup(func() { SetWriteLog(nil) })
+
+	require.NoError(t, repo.Create(t.Context(), createTestUser("TestUser")))
+	assert.Contains(t, log.String(), "Wrote "+jsonRepo.getUserFilename("TestUser")+"\n")
+
+	SetWriteLog(nil)
+	log.Reset()
+	require.NoError(t, repo.Create(t.Context(), createTestUser("OtherUser")))
+	assert.Empty(t, log.String())
+}
+
+func TestJSONUserRepository_RecoversFromCorruptedFile(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	user := createTestUser("TestUser")
+	require.NoError(t, repo.Create(t.Context(), user))
+	user.Unit = models.Kilograms
+	require.NoError(t, repo.Update(t.Context(), user))
+
+	// Simulate a write cut off part way through
+	filename := jsonRepo.getUserFilename("TestUser")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"id": "`), 0644))
+
+	recovered, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, recovered.ID)
+
+	// Saving over a corrupted file keeps the good backup
+	recovered.Unit = models.Kilograms
+	require.NoError(t, repo.Update(t.Context(), recovered))
+	backup, err := readUserFile(plainFiles{}, filename+".bak")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, backup.ID)
+
+	// Without a usable backup the original error is returned
+	require.NoError(t, os.WriteFile(filename, []byte(`{`), 0644))
+	require.NoError(t, os.Remove(filename+".bak"))
+	_, err = repo.Get(t.Context(), "TestUser")
+	assert.ErrorContains(t, err, "failed to unmarshal user data")
+}
+
 // Helper functions
 
 func setupTestRepository(t *testing.T) UserRepository {
@@ -298,6 +375,8 @@ func setupTestRepository(t *testing.T) UserRepository {
 		configDir:   tempDir,
 		usersDir:    filepath.Join(tempDir, "users"),
 		currentFile: filepath.Join(tempDir, "current_user.txt"),
+		history:     &JSONWorkoutHistoryRepository{historyDir: filepath.Join(tempDir, "history"), files: plainFiles{}},
+		files:       plainFiles{},
 	}
 
 	// Create users directory
@@ -316,4 +395,108 @@ func createTestUser(username string) *models.User {
 		WorkoutHistory: []models.Workout{},
 		CreatedAt:      time.Now(),
 	}
-}
\ No newline at end of file
+}
+func TestJSONUserRepository_UpgradesOldFiles(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	// A file written before schema versioning, with a program that predates units
+	filename := jsonRepo.getUserFilename("OldUser")
+	require.NoError(t, os.WriteFile(filename, []byte(`{
+		"id": "0191f2d0-0000-7000-8000-000000000001",
+		"username": "OldUser",
+		"programs": {"0191f2d0-0000-7000-8000-000000000002": {"current_day": 2}},
+		"workout_history": null
+	}`), 0644))
+
+	user, err := repo.Get(t.Context(), "OldUser")
+	require.NoError(t, err)
+	assert.Equal(t, migrations.CurrentVersion, user.SchemaVersion)
+	assert.NotNil(t, user.WorkoutHistory)
+	for _, program := range user.Programs {
+		assert.Equal(t, models.Pounds, program.Unit)
+		assert.Equal(t, 2, program.CurrentDay)
+	}
+
+	// Saving writes the current version, keeping the original as the backup
+	require.NoError(t, repo.Update(t.Context(), user))
+	saved, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), fmt.Sprintf(`"schema_version": %d`, migrations.CurrentVersion))
+	backup, err := os.ReadFile(filename + ".bak")
+	require.NoError(t, err)
+	assert.NotContains(t, string(backup), "schema_version")
+}
+
+func TestJSONUserRepository_HistoryStoredApart(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	user := createTestUser("TestUser")
+	user.AddWorkout(models.Workout{ID: uuid.New(), Day: 1, EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)})
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	// The user file leaves the history out
+	data, err := os.ReadFile(jsonRepo.getUserFilename("TestUser"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "workout_history")
+
+	// History is only read when it's used
+	loaded, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.False(t, loaded.HistoryLoaded())
+	assert.Len(t, loaded.History(), 1)
+
+	// Saving a user whose history was never read leaves the history alone
+	unread, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	unread.Unit = models.Kilograms
+	require.NoError(t, repo.Update(t.Context(), unread))
+	reloaded, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, models.Kilograms, reloaded.Unit)
+	assert.Len(t, reloaded.History(), 1)
+}
+
+func TestJSONUserRepository_UnreadableHistory(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+
+	user := createTestUser("TestUser")
+	user.AddWorkout(models.Workout{ID: uuid.New(), Day: 1, EnteredAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)})
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	historyDir := jsonRepo.history.(*JSONWorkoutHistoryRepository).userDir(user.ID)
+	require.NoError(t, os.WriteFile(filepath.Join(historyDir, "2024-03.json"), []byte(`[{`), 0644))
+
+	loaded, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Error(t, loaded.LoadHistory())
+
+	// A user whose history failed to load isn't saved, which would lose it
+	err = repo.Update(t.Context(), loaded)
+	assert.ErrorContains(t, err, "failed to load workout history")
+}
+
+func TestJSONUserRepository_CanceledContext(t *testing.T) {
+	repo := setupTestRepository(t)
+	user := &models.User{ID: uuid.New(), Username: "Alice"}
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.ErrorIs(t, repo.Create(ctx, &models.User{ID: uuid.New(), Username: "Bob"}), context.Canceled)
+	_, err := repo.Get(ctx, "Alice")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.Update(ctx, user), context.Canceled)
+	_, err = repo.List(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetCurrent(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.SetCurrent(ctx, "Alice"), context.Canceled)
+
+	usernames, err := repo.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice"}, usernames, "nothing is written once the context is canceled")
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// JSONLiftRepository implements LiftRepository with a single lifts.json file
+// holding an array of lift definitions
+type JSONLiftRepository struct {
+	liftsFile string
+	mutex     sync.Mutex
+}
+
+// NewJSONLiftRepository creates a new JSONLiftRepository instance
+func NewJSONLiftRepository() (LiftRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLiftRepository{liftsFile: filepath.Join(greyskullDir, "lifts.json")}, nil
+}
+
+// List returns every stored lift definition
+func (r *JSONLiftRepository) List() ([]models.LiftDefinition, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.read()
+}
+
+// Save adds or replaces a lift definition
+func (r *JSONLiftRepository) Save(definition models.LiftDefinition) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	definitions, err := r.read()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(definitions, func(def models.LiftDefinition) bool { return def.Name == definition.Name })
+	if i >= 0 {
+		definitions[i] = definition
+	} else {
+		definitions = append(definitions, definition)
+	}
+
+	data, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lift definitions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.liftsFile), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := writeFileAtomic(r.liftsFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write lifts file: %w", err)
+	}
+	return nil
+}
+
+func (r *JSONLiftRepository) read() ([]models.LiftDefinition, error) {
+	data, err := os.ReadFile(r.liftsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.LiftDefinition{}, nil
+		}
+		return nil, fmt.Errorf("failed to read lifts file: %w", err)
+	}
+
+	definitions := []models.LiftDefinition{}
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse lifts file: %w", err)
+	}
+	return definitions, nil
+}
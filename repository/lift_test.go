@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiftRepository_SaveAndList(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONLiftRepository()
+	require.NoError(t, err)
+
+	lifts, err := repo.List()
+	require.NoError(t, err)
+	assert.Empty(t, lifts)
+	assert.NotNil(t, lifts)
+
+	require.NoError(t, repo.Save(models.LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Increment: 5}))
+	require.NoError(t, repo.Save(models.LiftDefinition{Name: "RomanianDeadlift", DisplayName: "RDL"}))
+	// Saving an existing lift replaces it in place
+	require.NoError(t, repo.Save(models.LiftDefinition{Name: "FrontSquat", DisplayName: "Front Squat", Increment: 10}))
+
+	lifts, err = repo.List()
+	require.NoError(t, err)
+	require.Len(t, lifts, 2)
+	assert.Equal(t, models.LiftName("FrontSquat"), lifts[0].Name)
+	assert.Equal(t, 10.0, lifts[0].Increment)
+	assert.Equal(t, "RDL", lifts[1].DisplayName)
+}
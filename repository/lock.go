@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// lockTimeout is how long to wait for another process to release a lock
+	lockTimeout = 5 * time.Second
+	// lockRetryInterval is how often a held lock is checked while waiting
+	lockRetryInterval = 50 * time.Millisecond
+	// staleLockAge is when a lock file is assumed to belong to a process that
+	// crashed before removing it, even if its process ID has been reused. Locks
+	// are only held while a file is written.
+	staleLockAge = time.Minute
+)
+
+// acquireLock takes a cross-process lock by creating path exclusively, waiting
+// up to timeout for another process to release it. Call the returned function
+// to release the lock.
+func acquireLock(path string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if removeStaleLock(path) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w; try again in a moment, or delete %s if no other greyskull command is running", ErrLocked, path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removeStaleLock removes the lock file at path if the process that took it
+// is no longer running or it's older than staleLockAge, and reports whether it
+// did
+func removeStaleLock(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !lockIsStale(path, info) {
+		return false
+	}
+	return removeLock(path, info)
+}
+
+// removeLock removes the lock file at path if it's still the file info
+// describes, and reports whether it did. The file is renamed aside first, so
+// that when several processes find the same stale lock only one removes it,
+// and a lock another process took in the meantime is put back.
+func removeLock(path string, info os.FileInfo) bool {
+	aside := fmt.Sprintf("%s.%d.stale", path, os.Getpid())
+	if err := os.Rename(path, aside); err != nil {
+		return false
+	}
+	defer os.Remove(aside)
+
+	if moved, err := os.Stat(aside); err != nil || !sameLock(info, moved) {
+		// Put back the other process's lock, unless yet another has been
+		// taken since
+		os.Link(aside, path)
+		return false
+	}
+	return true
+}
+
+// sameLock reports whether two lock file infos describe the same lock. Inodes
+// are reused as soon as a file is removed, so the file's modification time is
+// compared too.
+func sameLock(a, b os.FileInfo) bool {
+	return os.SameFile(a, b) && a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
+
+// lockIsStale reports whether a lock file was left by a process that is no
+// longer running, or is older than any lock held while writing
+func lockIsStale(path string, info os.FileInfo) bool {
+	if time.Since(info.ModTime()) > staleLockAge {
+		return true
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		// The holder may not have written its process ID yet
+		return false
+	}
+	return !processRunning(pid)
+}
+
+// processRunning reports whether a process with the given ID is running
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return !errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
+
+// fileVersion identifies the contents of a file as of one read or write, so
+// that a save can tell whether another process wrote the file in between
+type fileVersion struct {
+	modTime int64
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+// readFileVersion returns the current version of the file at path
+func readFileVersion(path string) (fileVersion, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	return fileVersion{modTime: info.ModTime().UnixNano(), size: info.Size(), sum: sha256.Sum256(data)}, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	unlock, err := acquireLock(path, 0)
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+
+	// A second holder waits out the timeout, then gives up
+	start := time.Now()
+	_, err = acquireLock(path, 100*time.Millisecond)
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.ErrorContains(t, err, path)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// Releasing the lock lets a waiting holder in
+	go func(release func()) {
+		time.Sleep(2 * lockRetryInterval)
+		release()
+	}(unlock)
+	unlock, err = acquireLock(path, time.Second)
+	require.NoError(t, err)
+	unlock()
+	assert.NoFileExists(t, path)
+}
+
+func TestAcquireLock_StaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	require.NoError(t, os.WriteFile(path, []byte("12345\n"), 0644))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	unlock, err := acquireLock(path, 0)
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestAcquireLock_ProcessGone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	// A recent lock is kept while its process is running
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644))
+	_, err := acquireLock(path, 0)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	// and taken over once it isn't
+	exited := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, exited.Run())
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(exited.Process.Pid)+"\n"), 0644))
+	unlock, err := acquireLock(path, 0)
+	require.NoError(t, err)
+	unlock()
+	assert.NoFileExists(t, path)
+}
+
+func TestRemoveLock_KeepsReplacedLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	require.NoError(t, os.WriteFile(path, []byte("12345\n"), 0644))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path, old, old))
+	stale, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// Another process removes the stale lock and takes its own before this
+	// one gets to remove it
+	require.NoError(t, os.Remove(path))
+	unlock, err := acquireLock(path, 0)
+	require.NoError(t, err)
+	defer unlock()
+
+	assert.False(t, removeLock(path, stale))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(data))
+}
+
+func TestJSONUserRepository_ConcurrentUpdate(t *testing.T) {
+	repo := setupTestRepository(t)
+	jsonRepo := repo.(*JSONUserRepository)
+	require.NoError(t, repo.Create(t.Context(), createTestUser("TestUser")))
+
+	// Two processes read the same user
+	other := &JSONUserRepository{
+		configDir:   jsonRepo.configDir,
+		usersDir:    jsonRepo.usersDir,
+		currentFile: jsonRepo.currentFile,
+		history:     jsonRepo.history,
+		files:       jsonRepo.files,
+	}
+	first, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	second, err := other.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+
+	// The first to save wins, and the second is refused rather than
+	// overwriting its changes
+	first.Unit = models.Kilograms
+	require.NoError(t, repo.Update(t.Context(), first))
+	second.CurrentProgram = uuid.New()
+	assert.ErrorIs(t, other.Update(t.Context(), second), ErrConflict)
+
+	saved, err := repo.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.Equal(t, models.Kilograms, saved.Unit)
+	assert.Equal(t, uuid.Nil, saved.CurrentProgram)
+
+	// Saving again after reading the latest data works, and so do repeated saves
+	require.NoError(t, repo.Update(t.Context(), saved))
+	require.NoError(t, repo.Update(t.Context(), saved))
+	second, err = other.Get(t.Context(), "TestUser")
+	require.NoError(t, err)
+	assert.NoError(t, other.Update(t.Context(), second))
+}
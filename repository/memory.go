@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// InMemoryUserRepository implements UserRepository with users held in memory,
+// for tests and tools that shouldn't touch the filesystem. It is safe for
+// concurrent use. Users are stored as copies, so changes to a user only take
+// effect once it's passed to Create or Update, as with JSONUserRepository.
+type InMemoryUserRepository struct {
+	mutex   sync.Mutex
+	users   map[string][]byte // Encoded users, keyed by lowercase username
+	current string
+}
+
+// NewInMemoryUserRepository creates an empty in-memory user repository
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{users: make(map[string][]byte)}
+}
+
+// Create creates a new user
+func (r *InMemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := strings.ToLower(user.Username)
+	if _, exists := r.users[key]; exists {
+		return ErrUserAlreadyExists
+	}
+	return r.store(key, user)
+}
+
+// Get retrieves a user by username (case-insensitive)
+func (r *InMemoryUserRepository) Get(ctx context.Context, username string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.load(strings.ToLower(username))
+}
+
+// Update updates an existing user
+func (r *InMemoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := strings.ToLower(user.Username)
+	if _, exists := r.users[key]; !exists {
+		return ErrUserNotFound
+	}
+	return r.store(key, user)
+}
+
+// List returns all usernames in their original casing, ordered as
+// JSONUserRepository orders them
+func (r *InMemoryUserRepository) List(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	usernames := []string{}
+	for _, key := range slices.Sorted(maps.Keys(r.users)) {
+		user, err := r.load(key)
+		if err != nil {
+			return nil, err
+		}
+		usernames = append(usernames, user.Username)
+	}
+	return usernames, nil
+}
+
+// GetCurrent returns the current active username
+func (r *InMemoryUserRepository) GetCurrent(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.current == "" {
+		return "", ErrNoCurrentUser
+	}
+	user, err := r.load(r.current)
+	if err != nil {
+		return "", ErrNoCurrentUser
+	}
+	return user.Username, nil
+}
+
+// SetCurrent sets the current active user
+func (r *InMemoryUserRepository) SetCurrent(ctx context.Context, username string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := strings.ToLower(username)
+	if _, exists := r.users[key]; !exists {
+		return ErrUserNotFound
+	}
+	r.current = key
+	return nil
+}
+
+// store saves an encoded copy of a user, workout history included
+func (r *InMemoryUserRepository) store(key string, user *models.User) error {
+	if err := user.LoadHistory(); err != nil {
+		return fmt.Errorf("failed to load workout history: %w", err)
+	}
+	data, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to marshal user data: %w", err)
+	}
+	r.users[key] = data
+	return nil
+}
+
+// load decodes a fresh copy of a stored user
+func (r *InMemoryUserRepository) load(key string) (*models.User, error) {
+	data, exists := r.users[key]
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+	var user models.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user data: %w", err)
+	}
+	return &user, nil
+}
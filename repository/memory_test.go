@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryUserRepository(t *testing.T) {
+	var repo UserRepository = NewInMemoryUserRepository()
+
+	_, err := repo.GetCurrent(t.Context())
+	assert.ErrorIs(t, err, ErrNoCurrentUser)
+	_, err = repo.Get(t.Context(), "alice")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.ErrorIs(t, repo.Update(t.Context(), &models.User{Username: "Alice"}), ErrUserNotFound)
+	assert.ErrorIs(t, repo.SetCurrent(t.Context(), "alice"), ErrUserNotFound)
+
+	alice := &models.User{ID: uuid.New(), Username: "Alice", Programs: map[uuid.UUID]*models.UserProgram{}}
+	require.NoError(t, repo.Create(t.Context(), alice))
+	require.NoError(t, repo.Create(t.Context(), &models.User{ID: uuid.New(), Username: "bob"}))
+	assert.ErrorIs(t, repo.Create(t.Context(), &models.User{Username: "ALICE"}), ErrUserAlreadyExists)
+
+	usernames, err := repo.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "bob"}, usernames)
+
+	require.NoError(t, repo.SetCurrent(t.Context(), "ALICE"))
+	current, err := repo.GetCurrent(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", current)
+
+	loaded, err := repo.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, loaded.ID)
+}
+
+func TestInMemoryUserRepository_StoresCopies(t *testing.T) {
+	repo := NewInMemoryUserRepository()
+	user := &models.User{ID: uuid.New(), Username: "Alice"}
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	// Changes aren't seen until they're saved
+	user.WorkoutHistory = append(user.WorkoutHistory, models.Workout{ID: uuid.New(), Day: 1, EnteredAt: time.Now()})
+	loaded, err := repo.Get(t.Context(), "Alice")
+	require.NoError(t, err)
+	assert.Empty(t, loaded.WorkoutHistory)
+
+	require.NoError(t, repo.Update(t.Context(), user))
+	loaded, err = repo.Get(t.Context(), "Alice")
+	require.NoError(t, err)
+	assert.Len(t, loaded.WorkoutHistory, 1)
+
+	loaded.WorkoutHistory = nil
+	again, err := repo.Get(t.Context(), "Alice")
+	require.NoError(t, err)
+	assert.Len(t, again.WorkoutHistory, 1)
+}
+
+func TestInMemoryUserRepository_Concurrent(t *testing.T) {
+	repo := NewInMemoryUserRepository()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			username := fmt.Sprintf("user%02d", i)
+			assert.NoError(t, repo.Create(t.Context(), &models.User{ID: uuid.New(), Username: username}))
+			assert.NoError(t, repo.SetCurrent(t.Context(), username))
+			_, err := repo.List(t.Context())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	usernames, err := repo.List(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, usernames, 20)
+}
+
+func TestInMemoryUserRepository_CanceledContext(t *testing.T) {
+	repo := NewInMemoryUserRepository()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.ErrorIs(t, repo.Create(ctx, &models.User{Username: "Alice"}), context.Canceled)
+	_, err := repo.Get(ctx, "Alice")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MigrationSource is existing greyskull data found outside the data directory:
+// an older data directory location, a renamed copy, or a .tar.gz backup of one
+type MigrationSource struct {
+	Path    string
+	Archive bool
+
+	// Users is the number of user files the source contains
+	Users int
+}
+
+// NeedsMigration reports whether the data directory is missing, in which case
+// any existing data elsewhere should be offered for migration before a fresh,
+// empty store is created in its place
+func NeedsMigration() (bool, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(dataDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check data directory: %w", err)
+	}
+	return false, nil
+}
+
+// FindMigrationSources returns the existing data that could be migrated into a
+// missing data directory, in order of preference. Candidates are the legacy
+// ~/.greyskull and ~/.config/greyskull directories, the OS config directory,
+// and renamed directories or .tar.gz backups beside the data directory whose
+// names start with "greyskull" (e.g. greyskull.bak or greyskull-backup.tar.gz).
+// Candidates without any user files are skipped.
+func FindMigrationSources() ([]MigrationSource, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates,
+			filepath.Join(home, ".greyskull"),
+			filepath.Join(home, ".config", "greyskull"))
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		candidates = append(candidates, filepath.Join(configDir, "greyskull"))
+	}
+	siblings, err := renamedDataDirs(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	candidates = append(candidates, siblings...)
+
+	var sources []MigrationSource
+	seen := map[string]bool{filepath.Clean(dataDir): true}
+	for _, candidate := range candidates {
+		candidate = filepath.Clean(candidate)
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+
+		source, ok, err := inspectMigrationSource(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			sources = append(sources, source)
+		}
+	}
+	return sources, nil
+}
+
+// Migrate copies a source's data into the data directory, which must not exist
+// yet. The source itself is left untouched. Data is staged beside the data
+// directory first so a failed migration never leaves a partial store behind.
+func Migrate(source MigrationSource) error {
+	dataDir, err := DataDir()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dataDir); err == nil {
+		return fmt.Errorf("data directory %s already exists", dataDir)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dataDir), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(dataDir), ".greyskull-migrate-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if source.Archive {
+		err = extractDataArchive(source.Path, staging)
+	} else {
+		err = os.CopyFS(staging, os.DirFS(source.Path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy data from %s: %w", source.Path, err)
+	}
+
+	if err := os.Rename(staging, dataDir); err != nil {
+		return fmt.Errorf("failed to move migrated data into place: %w", err)
+	}
+	return nil
+}
+
+// renamedDataDirs lists entries beside the data directory that look like a
+// renamed or backed-up copy of it
+func renamedDataDirs(dataDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(dataDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if strings.HasPrefix(strings.ToLower(entry.Name()), "greyskull") {
+			paths = append(paths, filepath.Join(filepath.Dir(dataDir), entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// inspectMigrationSource reports whether path is a data directory or backup
+// archive containing at least one user file
+func inspectMigrationSource(p string) (MigrationSource, bool, error) {
+	info, err := os.Stat(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return MigrationSource{}, false, nil
+	}
+	if err != nil {
+		return MigrationSource{}, false, fmt.Errorf("failed to check %s: %w", p, err)
+	}
+
+	if info.IsDir() {
+		users, err := filepath.Glob(filepath.Join(p, "users", "*.json"))
+		if err != nil {
+			return MigrationSource{}, false, err
+		}
+		return MigrationSource{Path: p, Users: len(users)}, len(users) > 0, nil
+	}
+
+	if !isDataArchive(p) {
+		return MigrationSource{}, false, nil
+	}
+	_, users, err := scanDataArchive(p)
+	if err != nil {
+		// An unreadable archive isn't worth offering, but shouldn't stop startup
+		return MigrationSource{}, false, nil
+	}
+	return MigrationSource{Path: p, Archive: true, Users: users}, users > 0, nil
+}
+
+// isDataArchive reports whether a file name looks like a gzip-compressed tarball
+func isDataArchive(p string) bool {
+	name := strings.ToLower(filepath.Base(p))
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+// scanDataArchive finds the directory inside an archive that holds the data,
+// either the archive root or a single top-level directory such as "greyskull/",
+// and counts the user files in it
+func scanDataArchive(p string) (string, int, error) {
+	root := ""
+	users := 0
+	err := walkTarGz(p, func(header *tar.Header, _ io.Reader) error {
+		name := path.Clean(header.Name)
+		dir, file := path.Split(name)
+		if header.Typeflag != tar.TypeReg || path.Ext(file) != ".json" || path.Base(dir) != "users" {
+			return nil
+		}
+		userRoot := path.Dir(path.Dir(name))
+		if userRoot == "." {
+			userRoot = ""
+		}
+		if users == 0 {
+			root = userRoot
+		}
+		if userRoot == root {
+			users++
+		}
+		return nil
+	})
+	return root, users, err
+}
+
+// extractDataArchive extracts the data directory inside an archive into dest.
+// Entries outside the data directory, links, and unsafe paths are skipped.
+func extractDataArchive(p, dest string) error {
+	root, _, err := scanDataArchive(p)
+	if err != nil {
+		return err
+	}
+
+	return walkTarGz(p, func(header *tar.Header, r io.Reader) error {
+		name := path.Clean(header.Name)
+		if root != "" {
+			rel, ok := strings.CutPrefix(name, root+"/")
+			if !ok {
+				return nil
+			}
+			name = rel
+		}
+		if !filepath.IsLocal(name) {
+			return nil
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(file, r); err != nil {
+				file.Close()
+				return err
+			}
+			return file.Close()
+		}
+		return nil
+	})
+}
+
+// walkTarGz calls fn for each entry of a gzip-compressed tarball
+func walkTarGz(p string, fn func(header *tar.Header, r io.Reader) error) error {
+	file, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(header, tr); err != nil {
+			return err
+		}
+	}
+}
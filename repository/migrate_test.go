@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMigrationEnv isolates both the config directory and the home directory,
+// returning the config directory
+func setupMigrationEnv(t *testing.T) string {
+	configDir := filepath.Join(t.TempDir(), "config")
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", t.TempDir())
+	return configDir
+}
+
+// writeLegacyData writes a data directory holding a single user and a current user file
+func writeLegacyData(t *testing.T, dir, username string) {
+	data, err := json.Marshal(&models.User{ID: uuid.New(), Username: username})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "users"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users", strings.ToLower(username)+".json"), data, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "current_user.txt"), []byte(username), 0644))
+}
+
+// writeDataArchive writes a .tar.gz with the given files
+func writeDataArchive(t *testing.T, path string, files map[string]string) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+}
+
+func TestNeedsMigration(t *testing.T) {
+	setupMigrationEnv(t)
+
+	needed, err := NeedsMigration()
+	require.NoError(t, err)
+	assert.True(t, needed)
+
+	_, err = NewJSONUserRepository()
+	require.NoError(t, err)
+
+	needed, err = NeedsMigration()
+	require.NoError(t, err)
+	assert.False(t, needed)
+}
+
+func TestFindMigrationSources(t *testing.T) {
+	configDir := setupMigrationEnv(t)
+	home := os.Getenv("HOME")
+
+	writeLegacyData(t, filepath.Join(home, ".greyskull"), "Legacy")
+	writeLegacyData(t, filepath.Join(configDir, "greyskull.bak"), "Renamed")
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "greyskull-empty", "users"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "greyskull-notes.txt"), []byte("hi"), 0644))
+	writeDataArchive(t, filepath.Join(configDir, "greyskull-backup.tar.gz"), map[string]string{
+		"greyskull/users/a.json":         `{"username":"A"}`,
+		"greyskull/users/b.json":         `{"username":"B"}`,
+		"greyskull/current_user.txt":     "A",
+		"greyskull/programs/custom.json": `{}`,
+	})
+
+	sources, err := FindMigrationSources()
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+
+	assert.Equal(t, MigrationSource{Path: filepath.Join(home, ".greyskull"), Users: 1}, sources[0])
+	assert.Equal(t, MigrationSource{Path: filepath.Join(configDir, "greyskull-backup.tar.gz"), Archive: true, Users: 2}, sources[1])
+	assert.Equal(t, MigrationSource{Path: filepath.Join(configDir, "greyskull.bak"), Users: 1}, sources[2])
+}
+
+func TestFindMigrationSources_None(t *testing.T) {
+	setupMigrationEnv(t)
+
+	sources, err := FindMigrationSources()
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+}
+
+func TestMigrate_Directory(t *testing.T) {
+	setupMigrationEnv(t)
+	legacy := filepath.Join(os.Getenv("HOME"), ".greyskull")
+	writeLegacyData(t, legacy, "Legacy")
+
+	require.NoError(t, Migrate(MigrationSource{Path: legacy, Users: 1}))
+
+	repo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+	user, err := repo.Get(t.Context(), "Legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "Legacy", user.Username)
+	current, err := repo.GetCurrent(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Legacy", current)
+
+	// The source is left in place
+	assert.FileExists(t, filepath.Join(legacy, "users", "legacy.json"))
+
+	// Migrating again would overwrite the new data directory
+	assert.ErrorContains(t, Migrate(MigrationSource{Path: legacy, Users: 1}), "already exists")
+}
+
+func TestMigrate_Archive(t *testing.T) {
+	configDir := setupMigrationEnv(t)
+	archive := filepath.Join(configDir, "greyskull.tgz")
+	data, err := json.Marshal(&models.User{ID: uuid.New(), Username: "Backup"})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	writeDataArchive(t, archive, map[string]string{
+		"users/backup.json": string(data),
+		"current_user.txt":  "Backup",
+		"../escape.txt":     "nope",
+	})
+
+	require.NoError(t, Migrate(MigrationSource{Path: archive, Archive: true, Users: 1}))
+
+	repo, err := NewJSONUserRepository()
+	require.NoError(t, err)
+	_, err = repo.Get(t.Context(), "Backup")
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(configDir, "escape.txt"))
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mikowitz/greyskull/models"
+	"gopkg.in/yaml.v3"
+)
+
+// JSONProgramRepository implements ProgramRepository using program files in
+// the programs directory. Programs are read from .json, .yaml, and .yml files
+// and always written back as JSON.
+type JSONProgramRepository struct {
+	programsDir string
+	mutex       sync.Mutex
+}
+
+// NewJSONProgramRepository creates a new JSONProgramRepository instance
+func NewJSONProgramRepository() (ProgramRepository, error) {
+	greyskullDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	programsDir := filepath.Join(greyskullDir, "programs")
+	if err := os.MkdirAll(programsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create programs directory: %w", err)
+	}
+
+	return &JSONProgramRepository{programsDir: programsDir}, nil
+}
+
+// List returns all valid custom programs
+func (r *JSONProgramRepository) List() ([]*models.Program, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := os.ReadDir(r.programsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*models.Program{}, nil
+		}
+		return nil, fmt.Errorf("failed to read programs directory: %w", err)
+	}
+
+	programs := []*models.Program{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isProgramFile(entry.Name()) {
+			continue
+		}
+		prog, err := LoadProgramFile(filepath.Join(r.programsDir, entry.Name()))
+		if err != nil {
+			continue // Skip invalid files
+		}
+		programs = append(programs, prog)
+	}
+
+	return programs, nil
+}
+
+// Save stores a custom program as JSON, named by its ID
+func (r *JSONProgramRepository) Save(program *models.Program) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	filename := filepath.Join(r.programsDir, program.ID.String()+".json")
+	if _, err := os.Stat(filename); err == nil {
+		return ErrProgramExists
+	}
+
+	data, err := json.MarshalIndent(program, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal program data: %w", err)
+	}
+
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write program file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadProgramFile reads, parses, and validates a program file
+func LoadProgramFile(filename string) (*models.Program, error) {
+	prog, err := ParseProgramFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prog.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid program: %w", err)
+	}
+
+	return prog, nil
+}
+
+// ParseProgramFile reads and parses a program file without validating it.
+// The format is chosen by file extension: .yaml/.yml are parsed as YAML,
+// anything else as JSON. YAML files use the same field names as the JSON schema.
+func ParseProgramFile(filename string) (*models.Program, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read program file: %w", err)
+	}
+
+	return ParseProgram(data, IsYAMLFile(filename))
+}
+
+// ParseProgram decodes program data without validating it
+func ParseProgram(data []byte, isYAML bool) (*models.Program, error) {
+	if isYAML {
+		// Decode YAML generically and re-encode as JSON so both formats
+		// share the JSON field names defined on the models
+		var raw any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert YAML: %w", err)
+		}
+		data = converted
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var prog models.Program
+	if err := decoder.Decode(&prog); err != nil {
+		return nil, fmt.Errorf("failed to parse program: %w", err)
+	}
+
+	return &prog, nil
+}
+
+// EncodeProgram encodes a program in the schema read by ParseProgram, as
+// indented JSON or as YAML with the same field names and order
+func EncodeProgram(prog *models.Program, isYAML bool) ([]byte, error) {
+	data, err := json.MarshalIndent(prog, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal program data: %w", err)
+	}
+	if !isYAML {
+		return append(data, '\n'), nil
+	}
+
+	// JSON is valid YAML, so parse it into nodes to keep the field order, then
+	// drop the JSON flow style and quoting before re-encoding
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to convert program to YAML: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to marshal program data: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal program data: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// clearYAMLStyle resets a node tree to the encoder's default block style
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// isProgramFile reports whether a filename has a supported program extension
+func isProgramFile(name string) bool {
+	return strings.HasSuffix(name, ".json") || IsYAMLFile(name)
+}
+
+// IsYAMLFile reports whether a filename has a YAML extension
+func IsYAMLFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProgramYAML = `id: 0190d2b4-0000-7000-8000-000000000001
+name: YAML Program
+version: "1.0.0"
+workouts:
+  - day: 1
+    lifts:
+      - lift_name: Squat
+        warmup_sets:
+          - {reps: 5, weight_percentage: 0, type: WarmupSet}
+        working_sets:
+          - {reps: 5, weight_percentage: 1, type: WorkingSet}
+          - {reps: 5, weight_percentage: 1, type: AMRAPSet}
+progression_rules:
+  increase_rules:
+    Squat: 5
+  deload_percentage: 0.9
+  double_threshold: 10
+`
+
+func setupProgramRepo(t *testing.T) (*JSONProgramRepository, string) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repo, err := NewJSONProgramRepository()
+	require.NoError(t, err)
+
+	jsonRepo := repo.(*JSONProgramRepository)
+	return jsonRepo, jsonRepo.programsDir
+}
+
+func testProgram() *models.Program {
+	return &models.Program{
+		ID:      uuid.New(),
+		Name:    "Test Program",
+		Version: "1.0.0",
+		Workouts: []models.WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []models.LiftTemplate{
+					{
+						LiftName: models.Squat,
+						WorkingSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+						},
+					},
+				},
+			},
+		},
+		ProgressionRules: models.ProgressionRules{
+			IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+			DeloadPercentage: 0.9,
+			DoubleThreshold:  10,
+		},
+	}
+}
+
+func TestNewJSONProgramRepository_CreatesDirectory(t *testing.T) {
+	_, dir := setupProgramRepo(t)
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.Equal(t, "programs", filepath.Base(dir))
+}
+
+func TestJSONProgramRepository_SaveAndList(t *testing.T) {
+	repo, dir := setupProgramRepo(t)
+
+	programs, err := repo.List()
+	require.NoError(t, err)
+	assert.Empty(t, programs)
+
+	prog := testProgram()
+	require.NoError(t, repo.Save(prog))
+	assert.FileExists(t, filepath.Join(dir, prog.ID.String()+".json"))
+
+	programs, err = repo.List()
+	require.NoError(t, err)
+	require.Len(t, programs, 1)
+	assert.Equal(t, prog, programs[0])
+
+	assert.ErrorIs(t, repo.Save(prog), ErrProgramExists)
+}
+
+func TestJSONProgramRepository_ListReadsYAMLAndSkipsInvalid(t *testing.T) {
+	repo, dir := setupProgramRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mine.yaml"), []byte(testProgramYAML), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{not json"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.json"), []byte(`{"name": ""}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	programs, err := repo.List()
+	require.NoError(t, err)
+	require.Len(t, programs, 1)
+	assert.Equal(t, "YAML Program", programs[0].Name)
+	assert.Equal(t, 5.0, programs[0].ProgressionRules.IncreaseRules[models.Squat])
+}
+
+func TestLoadProgramFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadProgramFile(filepath.Join(dir, "missing.json"))
+		assert.ErrorContains(t, err, "failed to read program file")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := filepath.Join(dir, "unknown.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"name": "x", "wokouts": []}`), 0644))
+
+		_, err := LoadProgramFile(path)
+		assert.ErrorContains(t, err, `unknown field "wokouts"`)
+	})
+
+	t.Run("validation error names the field", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.yml")
+		bad := []byte(testProgramYAML[:len(testProgramYAML)-len("  double_threshold: 10\n")] + "  double_threshold: 0\n")
+		require.NoError(t, os.WriteFile(path, bad, 0644))
+
+		_, err := LoadProgramFile(path)
+		assert.ErrorContains(t, err, "invalid program: progression_rules.double_threshold: must be positive")
+	})
+}
+
+func TestEncodeProgram_RoundTrips(t *testing.T) {
+	prog, err := ParseProgram([]byte(testProgramYAML), true)
+	require.NoError(t, err)
+
+	for _, isYAML := range []bool{false, true} {
+		data, err := EncodeProgram(prog, isYAML)
+		require.NoError(t, err)
+
+		decoded, err := ParseProgram(data, isYAML)
+		require.NoError(t, err)
+		assert.Equal(t, prog, decoded)
+	}
+
+	data, err := EncodeProgram(prog, true)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "id: 0190d2b4-0000-7000-8000-000000000001\nname: YAML Program\nversion: 1.0.0\nworkouts:\n  - day: 1\n")
+}
@@ -0,0 +1,429 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	greyskullv1 "github.com/mikowitz/greyskull/api/greyskull/v1"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/records"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toUser converts a user and their programs, leaving out their workout history
+func toUser(user *models.User) *greyskullv1.User {
+	programs := make(map[string]*greyskullv1.UserProgram, len(user.Programs))
+	for id, userProgram := range user.Programs {
+		programs[id.String()] = toUserProgram(userProgram)
+	}
+	skipped := make([]*greyskullv1.SkippedDay, len(user.SkippedDays))
+	for i, day := range user.SkippedDays {
+		skipped[i] = &greyskullv1.SkippedDay{
+			Id:            day.ID.String(),
+			UserProgramId: day.UserProgramID.String(),
+			Day:           int32(day.Day),
+			Reason:        day.Reason,
+			SkippedAt:     toTimestamp(day.SkippedAt),
+		}
+	}
+	resets := make([]*greyskullv1.WeightReset, len(user.WeightResets))
+	for i, reset := range user.WeightResets {
+		resets[i] = &greyskullv1.WeightReset{
+			Id:            reset.ID.String(),
+			UserProgramId: reset.UserProgramID.String(),
+			ResetAt:       toTimestamp(reset.ResetAt),
+			DaysOff:       int32(reset.DaysOff),
+			Previous:      byLift(reset.Previous, identity),
+			Weights:       byLift(reset.Weights, identity),
+		}
+	}
+	unlocked := make([]*greyskullv1.UnlockedMilestone, len(user.Milestones))
+	for i, milestone := range user.Milestones {
+		unlocked[i] = &greyskullv1.UnlockedMilestone{Id: milestone.ID, UnlockedAt: toTimestamp(milestone.UnlockedAt)}
+	}
+	active := make([]string, len(user.ActivePrograms))
+	for i, id := range user.ActivePrograms {
+		active[i] = id.String()
+	}
+
+	return &greyskullv1.User{
+		Id:             user.ID.String(),
+		Username:       user.Username,
+		CurrentProgram: optionalID(user.CurrentProgram),
+		Programs:       programs,
+		SkippedDays:    skipped,
+		CreatedAt:      toTimestamp(user.CreatedAt),
+		RestTimes:      toRestTimes(user.RestTimes),
+		Unit:           toWeightUnit(user.Unit),
+		Leaderboard:    user.Leaderboard,
+		WarmupPercentages: byLift(user.WarmupPercentages, func(percentages models.WarmupPercentages) *greyskullv1.WarmupPercentages {
+			return &greyskullv1.WarmupPercentages{Percentages: percentages}
+		}),
+		ActivePrograms: active,
+		WeightResets:   resets,
+		Milestones:     unlocked,
+	}
+}
+
+func toUserProgram(userProgram *models.UserProgram) *greyskullv1.UserProgram {
+	var deload *greyskullv1.DeloadPlan
+	if plan := userProgram.Deload; plan != nil {
+		deload = &greyskullv1.DeloadPlan{
+			Percentage:        plan.Percentage,
+			Sets:              int32(plan.Sets),
+			Reps:              int32(plan.Reps),
+			SessionsRemaining: int32(plan.SessionsRemaining),
+		}
+	}
+	trainingDays := make([]int32, len(userProgram.TrainingDays))
+	for i, day := range userProgram.TrainingDays {
+		trainingDays[i] = int32(day)
+	}
+	pauses := make([]*greyskullv1.Pause, len(userProgram.Pauses))
+	for i, pause := range userProgram.Pauses {
+		pauses[i] = &greyskullv1.Pause{
+			Reason:    pause.Reason,
+			StartedAt: toTimestamp(pause.StartedAt),
+			EndedAt:   toTimestamp(pause.EndedAt),
+		}
+	}
+	var rules *greyskullv1.ProgressionRules
+	if userProgram.ProgressionRules != nil {
+		rules = toProgressionRules(userProgram.ProgressionRules)
+	}
+	var snapshot *greyskullv1.Program
+	if userProgram.Program != nil {
+		snapshot = toProgram(userProgram.Program)
+	}
+
+	return &greyskullv1.UserProgram{
+		Id:              userProgram.ID.String(),
+		UserId:          userProgram.UserID.String(),
+		ProgramId:       userProgram.ProgramID.String(),
+		StartingWeights: byLift(userProgram.StartingWeights, identity),
+		CurrentWeights:  byLift(userProgram.CurrentWeights, identity),
+		CurrentDay:      int32(userProgram.CurrentDay),
+		StartedAt:       toTimestamp(userProgram.StartedAt),
+		Holds:           byLift(userProgram.Holds, toInt32),
+		Deload:          deload,
+		Unit:            toWeightUnit(userProgram.Unit),
+		RepTargets:      byLift(userProgram.RepTargets, toInt32),
+		Goals:           byLift(userProgram.Goals, identity),
+		DeloadStreaks: byLift(userProgram.DeloadStreaks, func(streak models.DeloadStreak) *greyskullv1.DeloadStreak {
+			return &greyskullv1.DeloadStreak{Deloads: int32(streak.Deloads), Weight: streak.Weight}
+		}),
+		TrainingDays:     trainingDays,
+		GoalDeadlines:    byLift(userProgram.GoalDeadlines, toTimestamp),
+		TrainingMaxes:    byLift(userProgram.TrainingMaxes, identity),
+		Pauses:           pauses,
+		RoundingSteps:    byLift(userProgram.RoundingSteps, identity),
+		ProgressionRules: rules,
+		Program:          snapshot,
+	}
+}
+
+func toWorkout(workout *models.Workout) *greyskullv1.Workout {
+	exercises := make([]*greyskullv1.Lift, len(workout.Exercises))
+	for i, lift := range workout.Exercises {
+		sets := make([]*greyskullv1.Set, len(lift.Sets))
+		for j, set := range lift.Sets {
+			sets[j] = &greyskullv1.Set{
+				Id:         set.ID.String(),
+				Weight:     set.Weight,
+				TargetReps: int32(set.TargetReps),
+				ActualReps: int32(set.ActualReps),
+				Type:       toSetType(set.Type),
+				Order:      int32(set.Order),
+				MaxReps:    int32(set.MaxReps),
+			}
+		}
+		exercises[i] = &greyskullv1.Lift{
+			Id:             lift.ID.String(),
+			LiftName:       string(lift.LiftName),
+			Variant:        lift.Variant,
+			Optional:       lift.Optional,
+			Bodyweight:     lift.Bodyweight,
+			Sets:           sets,
+			FixedWeight:    lift.FixedWeight,
+			Group:          lift.Group,
+			SubstitutedFor: string(lift.SubstitutedFor),
+		}
+	}
+	held := make([]string, len(workout.Held))
+	for i, lift := range workout.Held {
+		held[i] = string(lift)
+	}
+
+	return &greyskullv1.Workout{
+		Id:            workout.ID.String(),
+		UserProgramId: optionalID(workout.UserProgramID),
+		Day:           int32(workout.Day),
+		Exercises:     exercises,
+		EnteredAt:     toTimestamp(workout.EnteredAt),
+		Quick:         workout.Quick,
+		Incomplete:    workout.Incomplete,
+		Notes:         workout.Notes,
+		AdHoc:         workout.AdHoc,
+		BodyWeight:    workout.BodyWeight,
+		Held:          held,
+	}
+}
+
+// fromWorkout converts a workout sent by a client. IDs it leaves empty are
+// generated, and an unset entry time is left zero for the caller to fill in.
+func fromWorkout(workout *greyskullv1.Workout) (*models.Workout, error) {
+	id, err := parseOptionalID(workout.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid workout ID: %w", err)
+	}
+	userProgramID, err := parseOptionalID(workout.GetUserProgramId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid user program ID: %w", err)
+	}
+
+	exercises := make([]models.Lift, len(workout.GetExercises()))
+	for i, lift := range workout.GetExercises() {
+		liftID, err := parseOptionalID(lift.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID for %s: %w", lift.GetLiftName(), err)
+		}
+		sets := make([]models.Set, len(lift.GetSets()))
+		for j, set := range lift.GetSets() {
+			setID, err := parseOptionalID(set.GetId())
+			if err != nil {
+				return nil, fmt.Errorf("invalid set ID for %s: %w", lift.GetLiftName(), err)
+			}
+			setType, err := fromSetType(set.GetType())
+			if err != nil {
+				return nil, fmt.Errorf("invalid set for %s: %w", lift.GetLiftName(), err)
+			}
+			sets[j] = models.Set{
+				ID:         orNew(setID),
+				Weight:     set.GetWeight(),
+				TargetReps: int(set.GetTargetReps()),
+				MaxReps:    int(set.GetMaxReps()),
+				ActualReps: int(set.GetActualReps()),
+				Type:       setType,
+				Order:      int(set.GetOrder()),
+			}
+		}
+		exercises[i] = models.Lift{
+			ID:             orNew(liftID),
+			LiftName:       models.LiftName(lift.GetLiftName()),
+			Variant:        lift.GetVariant(),
+			Optional:       lift.GetOptional(),
+			Bodyweight:     lift.GetBodyweight(),
+			Sets:           sets,
+			FixedWeight:    lift.GetFixedWeight(),
+			Group:          lift.GetGroup(),
+			SubstitutedFor: models.LiftName(lift.GetSubstitutedFor()),
+		}
+	}
+
+	var enteredAt time.Time
+	if workout.GetEnteredAt() != nil {
+		enteredAt = workout.GetEnteredAt().AsTime().Local()
+	}
+	return &models.Workout{
+		ID:            orNew(id),
+		UserProgramID: userProgramID,
+		Day:           int(workout.GetDay()),
+		Exercises:     exercises,
+		EnteredAt:     enteredAt,
+		Quick:         workout.GetQuick(),
+		Incomplete:    workout.GetIncomplete(),
+		Notes:         workout.GetNotes(),
+		BodyWeight:    workout.GetBodyWeight(),
+	}, nil
+}
+
+func toProgram(program *models.Program) *greyskullv1.Program {
+	workouts := make([]*greyskullv1.WorkoutTemplate, len(program.Workouts))
+	for i, template := range program.Workouts {
+		lifts := make([]*greyskullv1.LiftTemplate, len(template.Lifts))
+		for j, lift := range template.Lifts {
+			var feeler *greyskullv1.FeelerTemplate
+			if lift.Feeler != nil {
+				feeler = &greyskullv1.FeelerTemplate{
+					WeightPercentage: lift.Feeler.WeightPercentage,
+					MinWeight:        lift.Feeler.MinWeight,
+				}
+			}
+			lifts[j] = &greyskullv1.LiftTemplate{
+				LiftName:    string(lift.LiftName),
+				Variant:     lift.Variant,
+				WarmupSets:  toSetTemplates(lift.WarmupSets),
+				WorkingSets: toSetTemplates(lift.WorkingSets),
+				Feeler:      feeler,
+				Bodyweight:  lift.Bodyweight,
+				Optional:    lift.Optional,
+				FixedWeight: lift.FixedWeight,
+				Group:       lift.Group,
+				Cues:        lift.Cues,
+				Url:         lift.URL,
+			}
+		}
+		workouts[i] = &greyskullv1.WorkoutTemplate{Day: int32(template.Day), Lifts: lifts}
+	}
+	var warmups *greyskullv1.WarmupScheme
+	if scheme := program.WarmupScheme; scheme != nil {
+		steps := make([]*greyskullv1.WarmupStep, len(scheme.Steps))
+		for i, step := range scheme.Steps {
+			steps[i] = &greyskullv1.WarmupStep{Reps: int32(step.Reps), Percentage: step.Percentage, Plates: int32(step.Plates)}
+		}
+		warmups = &greyskullv1.WarmupScheme{Strategy: string(scheme.Strategy), Steps: steps}
+	}
+
+	return &greyskullv1.Program{
+		Id:               optionalID(program.ID),
+		Name:             program.Name,
+		Description:      program.Description,
+		Version:          program.Version,
+		Workouts:         workouts,
+		ProgressionRules: toProgressionRules(&program.ProgressionRules),
+		RestTimes:        toRestTimes(program.RestTimes),
+		WarmupScheme:     warmups,
+	}
+}
+
+func toSetTemplates(sets []models.SetTemplate) []*greyskullv1.SetTemplate {
+	templates := make([]*greyskullv1.SetTemplate, len(sets))
+	for i, set := range sets {
+		templates[i] = &greyskullv1.SetTemplate{
+			Reps:             int32(set.Reps),
+			WeightPercentage: set.WeightPercentage,
+			Type:             toSetType(set.Type),
+			OfTrainingMax:    set.OfTrainingMax,
+			MinReps:          int32(set.MinReps),
+			MaxReps:          int32(set.MaxReps),
+		}
+	}
+	return templates
+}
+
+func toProgressionRules(rules *models.ProgressionRules) *greyskullv1.ProgressionRules {
+	return &greyskullv1.ProgressionRules{
+		IncreaseRules:    byLift(rules.IncreaseRules, identity),
+		DeloadPercentage: rules.DeloadPercentage,
+		DoubleThreshold:  int32(rules.DoubleThreshold),
+		Unit:             toWeightUnit(rules.Unit),
+		RepIncreases:     byLift(rules.RepIncreases, toInt32),
+		Strategy:         string(rules.Strategy),
+		Parameters:       maps.Clone(rules.Parameters),
+	}
+}
+
+func toRestTimes(rest *models.RestTimes) *greyskullv1.RestTimes {
+	if rest == nil {
+		return nil
+	}
+	return &greyskullv1.RestTimes{WarmupSeconds: int32(rest.WarmupSeconds), WorkingSeconds: int32(rest.WorkingSeconds)}
+}
+
+func toAchievements(achievements []records.Achievement) []*greyskullv1.Achievement {
+	converted := make([]*greyskullv1.Achievement, len(achievements))
+	for i, a := range achievements {
+		converted[i] = &greyskullv1.Achievement{
+			Lift:     string(a.Lift),
+			Kind:     string(a.Kind),
+			New:      toRecord(a.New),
+			Previous: toRecord(a.Previous),
+		}
+	}
+	return converted
+}
+
+func toRecord(record records.Record) *greyskullv1.Record {
+	return &greyskullv1.Record{
+		Weight: record.Weight,
+		Reps:   int32(record.Reps),
+		E1Rm:   record.E1RM,
+		Date:   toTimestamp(record.Date),
+	}
+}
+
+func toWeightUnit(unit models.WeightUnit) greyskullv1.WeightUnit {
+	switch unit {
+	case models.Pounds:
+		return greyskullv1.WeightUnit_WEIGHT_UNIT_POUNDS
+	case models.Kilograms:
+		return greyskullv1.WeightUnit_WEIGHT_UNIT_KILOGRAMS
+	}
+	return greyskullv1.WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+// setTypes maps each set type to its protobuf enum value
+var setTypes = map[models.SetType]greyskullv1.SetType{
+	models.WarmupSet:  greyskullv1.SetType_SET_TYPE_WARMUP,
+	models.WorkingSet: greyskullv1.SetType_SET_TYPE_WORKING,
+	models.AMRAPSet:   greyskullv1.SetType_SET_TYPE_AMRAP,
+	models.FeelerSet:  greyskullv1.SetType_SET_TYPE_FEELER,
+}
+
+func toSetType(setType models.SetType) greyskullv1.SetType {
+	return setTypes[setType]
+}
+
+func fromSetType(setType greyskullv1.SetType) (models.SetType, error) {
+	for converted, value := range setTypes {
+		if value == setType {
+			return converted, nil
+		}
+	}
+	return "", fmt.Errorf("unknown set type %s", setType)
+}
+
+// toTimestamp converts a time, leaving the zero time unset
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// optionalID formats an ID, leaving the nil UUID empty
+func optionalID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// parseOptionalID parses an ID, returning the nil UUID for an empty one
+func parseOptionalID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(id)
+}
+
+// orNew returns id, or a new ID in place of the nil UUID
+func orNew(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+// byLift converts a map keyed by lift name into one keyed by string, or nil
+// for an empty map
+func byLift[V, W any](values map[models.LiftName]V, convert func(V) W) map[string]W {
+	if len(values) == 0 {
+		return nil
+	}
+	converted := make(map[string]W, len(values))
+	for lift, value := range values {
+		converted[string(lift)] = convert(value)
+	}
+	return converted
+}
+
+func identity[T any](value T) T {
+	return value
+}
+
+func toInt32(value int) int32 {
+	return int32(value)
+}
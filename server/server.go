@@ -0,0 +1,252 @@
+// Package server implements GreyskullService, the gRPC API 'greyskull serve'
+// runs, on top of the same services the CLI commands use
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	greyskullv1 "github.com/mikowitz/greyskull/api/greyskull/v1"
+	"github.com/mikowitz/greyskull/milestones"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/records"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/mikowitz/greyskull/workout"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves GreyskullService from a CommandContext. Requests naming no
+// user act as the stored current user.
+type Server struct {
+	greyskullv1.UnimplementedGreyskullServiceServer
+
+	ctx *services.CommandContext
+
+	// logging serializes LogWorkout, so concurrent requests for one user
+	// don't fail each other with repository.ErrConflict
+	logging sync.Mutex
+}
+
+// New creates a Server using the services in ctx
+func New(ctx *services.CommandContext) *Server {
+	return &Server{ctx: ctx}
+}
+
+// ListUsers returns every username and the current user
+func (s *Server) ListUsers(ctx context.Context, req *greyskullv1.ListUsersRequest) (*greyskullv1.ListUsersResponse, error) {
+	usernames, err := s.ctx.UserRepo.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	current, err := s.ctx.UserRepo.GetCurrent(ctx)
+	if err != nil && !errors.Is(err, repository.ErrNoCurrentUser) {
+		return nil, toStatus(err)
+	}
+	return &greyskullv1.ListUsersResponse{Usernames: usernames, Current: current}, nil
+}
+
+// GetUser returns a user with their programs, without their workout history
+func (s *Server) GetUser(ctx context.Context, req *greyskullv1.GetUserRequest) (*greyskullv1.User, error) {
+	user, err := s.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+// ListPrograms returns the built-in programs followed by custom programs
+func (s *Server) ListPrograms(ctx context.Context, req *greyskullv1.ListProgramsRequest) (*greyskullv1.ListProgramsResponse, error) {
+	programs := s.ctx.Programs.List()
+	resp := &greyskullv1.ListProgramsResponse{Programs: make([]*greyskullv1.Program, len(programs))}
+	for i, prog := range programs {
+		resp.Programs[i] = toProgram(prog)
+	}
+	return resp, nil
+}
+
+// GetProgram returns a program template by ID or name
+func (s *Server) GetProgram(ctx context.Context, req *greyskullv1.GetProgramRequest) (*greyskullv1.Program, error) {
+	prog, err := s.ctx.Programs.Find(ctx, req.GetQuery())
+	if err != nil {
+		if errors.Is(err, program.ErrProgramNotFound) {
+			return nil, toStatus(services.ProgramNotFound(req.GetQuery()))
+		}
+		return nil, toStatus(err)
+	}
+	return toProgram(prog), nil
+}
+
+// GetNextWorkout calculates the user's next workout of their current program
+func (s *Server) GetNextWorkout(ctx context.Context, req *greyskullv1.GetNextWorkoutRequest) (*greyskullv1.Workout, error) {
+	user, err := s.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	_, prog, err := s.userProgram(ctx, user, "")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	config, err := s.ctx.Config.Load(user.Username)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	next, err := workout.CalculateNextWorkoutWithConfig(user, prog, config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to calculate next workout: %v", err)
+	}
+	return toWorkout(next), nil
+}
+
+// LogWorkout records a completed workout of one of the user's programs,
+// current unless the workout names another, and applies progression as
+// 'greyskull workout log' does. The user's post_log hooks aren't run.
+func (s *Server) LogWorkout(ctx context.Context, req *greyskullv1.LogWorkoutRequest) (*greyskullv1.LogWorkoutResponse, error) {
+	if req.GetWorkout() == nil {
+		return nil, status.Error(codes.InvalidArgument, "no workout given")
+	}
+	completed, err := fromWorkout(req.GetWorkout())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(completed.Exercises) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "workout has no exercises")
+	}
+
+	s.logging.Lock()
+	defer s.logging.Unlock()
+
+	user, err := s.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	userProgram, prog, err := s.userProgram(ctx, user, req.GetWorkout().GetUserProgramId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	switch completed.Day {
+	case 0:
+		completed.Day = userProgram.CurrentDay
+	case userProgram.CurrentDay:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "workout is for day %d, but %s is on day %d", completed.Day, prog.Name, userProgram.CurrentDay)
+	}
+	completed.UserProgramID = userProgram.ID
+	if completed.EnteredAt.IsZero() {
+		completed.EnteredAt = time.Now()
+	}
+
+	// Check for broken personal records before the workout joins the history
+	achievements := records.Broken(records.Compute(user.History()), completed)
+
+	if req.GetDryRun() {
+		userProgram = userProgram.Clone()
+	}
+	if err := workout.ApplyWorkout(userProgram, completed, prog); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var unlocked []milestones.Milestone
+	if !req.GetDryRun() {
+		user.AddWorkout(*completed)
+		unlocked = milestones.Unlock(user)
+		if err := s.ctx.UserRepo.Update(ctx, user); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	resp := &greyskullv1.LogWorkoutResponse{
+		Workout:      toWorkout(completed),
+		UserProgram:  toUserProgram(userProgram),
+		Achievements: toAchievements(achievements),
+	}
+	for _, milestone := range unlocked {
+		resp.Milestones = append(resp.Milestones, milestone.ID)
+	}
+	return resp, nil
+}
+
+// ListWorkouts returns the user's workout history, oldest first
+func (s *Server) ListWorkouts(ctx context.Context, req *greyskullv1.ListWorkoutsRequest) (*greyskullv1.ListWorkoutsResponse, error) {
+	user, err := s.user(ctx, req.GetUsername())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	history := user.History()
+	resp := &greyskullv1.ListWorkoutsResponse{Workouts: make([]*greyskullv1.Workout, len(history))}
+	for i := range history {
+		resp.Workouts[i] = toWorkout(&history[i])
+	}
+	return resp, nil
+}
+
+// user loads the named user, or the stored current user if username is empty
+func (s *Server) user(ctx context.Context, username string) (*models.User, error) {
+	if username == "" {
+		return s.ctx.UserService.RequireCurrentUser(ctx)
+	}
+	user, err := s.ctx.UserRepo.Get(ctx, username)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, services.NewError(services.ErrUserNotFound.Code, services.ErrUserNotFound.Hint, "user %q not found", username)
+	}
+	return user, err
+}
+
+// userProgram returns one of the user's programs, by ID or else their current
+// program, with the Program it follows
+func (s *Server) userProgram(ctx context.Context, user *models.User, id string) (*models.UserProgram, *models.Program, error) {
+	programID := user.CurrentProgram
+	if id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid user program ID: %v", err)
+		}
+		programID = parsed
+	}
+	userProgram, ok := user.Programs[programID]
+	if !ok {
+		if id != "" {
+			return nil, nil, status.Errorf(codes.NotFound, "%s has no program %s", user.Username, id)
+		}
+		return nil, nil, services.ErrNoActiveProgram
+	}
+	prog, err := s.ctx.UserService.ProgramFor(ctx, userProgram)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userProgram, prog, nil
+}
+
+// toStatus converts an error into a gRPC status, by its services.Error code
+// or repository sentinel
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	var serviceErr *services.Error
+	switch {
+	case errors.As(err, &serviceErr):
+		switch serviceErr.Code {
+		case services.ErrUserNotFound.Code, services.ErrProgramNotFound.Code:
+			code = codes.NotFound
+		case services.ErrNoCurrentUser.Code, services.ErrNoActiveProgram.Code:
+			code = codes.FailedPrecondition
+		}
+	case errors.Is(err, repository.ErrUserNotFound), errors.Is(err, program.ErrProgramNotFound):
+		code = codes.NotFound
+	case errors.Is(err, repository.ErrConflict):
+		code = codes.Aborted
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
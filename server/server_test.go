@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	greyskullv1 "github.com/mikowitz/greyskull/api/greyskull/v1"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves a Server over an in-memory connection, with the JSON
+// repositories in a temporary directory, and returns a client for it
+func startServer(t *testing.T) (greyskullv1.GreyskullServiceClient, *services.CommandContext) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	ctx, err := services.NewCommandContext(services.NewJSONRepositoryFactory())
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	greyskullv1.RegisterGreyskullServiceServer(grpcServer, New(ctx))
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return greyskullv1.NewGreyskullServiceClient(conn), ctx
+}
+
+// createLifter saves a user who has started the Greyskull LP, as the current user
+func createLifter(t *testing.T, ctx *services.CommandContext, username string) *models.UserProgram {
+	t.Helper()
+	weights := map[models.LiftName]float64{
+		models.OverheadPress: 95, models.BenchPress: 125, models.Squat: 135, models.Deadlift: 185,
+	}
+	userProgram := &models.UserProgram{
+		ID:              uuid.New(),
+		ProgramID:       program.GreyskullLP.ID,
+		StartingWeights: weights,
+		CurrentWeights:  weights,
+		CurrentDay:      1,
+		StartedAt:       time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		Unit:            models.Pounds,
+	}
+	user := &models.User{
+		ID:             uuid.New(),
+		Username:       username,
+		CurrentProgram: userProgram.ID,
+		Programs:       map[uuid.UUID]*models.UserProgram{userProgram.ID: userProgram},
+		CreatedAt:      time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	userProgram.UserID = user.ID
+	require.NoError(t, ctx.UserRepo.Create(t.Context(), user))
+	require.NoError(t, ctx.UserRepo.SetCurrent(t.Context(), username))
+	return userProgram
+}
+
+func requireCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	require.Error(t, err)
+	assert.Equal(t, code, status.Code(err), err.Error())
+}
+
+func TestServer_Users(t *testing.T) {
+	client, ctx := startServer(t)
+
+	_, err := client.GetUser(t.Context(), &greyskullv1.GetUserRequest{})
+	requireCode(t, err, codes.FailedPrecondition)
+
+	userProgram := createLifter(t, ctx, "Alice")
+
+	users, err := client.ListUsers(t.Context(), &greyskullv1.ListUsersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice"}, users.GetUsernames())
+	assert.Equal(t, "Alice", users.GetCurrent())
+
+	user, err := client.GetUser(t.Context(), &greyskullv1.GetUserRequest{Username: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.GetUsername())
+	assert.Equal(t, userProgram.ID.String(), user.GetCurrentProgram())
+	require.Contains(t, user.GetPrograms(), userProgram.ID.String())
+	started := user.GetPrograms()[userProgram.ID.String()]
+	assert.Equal(t, 135.0, started.GetCurrentWeights()["Squat"])
+	assert.Equal(t, greyskullv1.WeightUnit_WEIGHT_UNIT_POUNDS, started.GetUnit())
+
+	_, err = client.GetUser(t.Context(), &greyskullv1.GetUserRequest{Username: "bob"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestServer_Programs(t *testing.T) {
+	client, _ := startServer(t)
+
+	programs, err := client.ListPrograms(t.Context(), &greyskullv1.ListProgramsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, programs.GetPrograms())
+	assert.Equal(t, program.GreyskullLP.ID.String(), programs.GetPrograms()[0].GetId())
+
+	found, err := client.GetProgram(t.Context(), &greyskullv1.GetProgramRequest{Query: program.GreyskullLP.Name})
+	require.NoError(t, err)
+	assert.Equal(t, program.GreyskullLP.ID.String(), found.GetId())
+	assert.Len(t, found.GetWorkouts(), len(program.GreyskullLP.Workouts))
+
+	_, err = client.GetProgram(t.Context(), &greyskullv1.GetProgramRequest{Query: "nonexistent"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestServer_LogWorkout(t *testing.T) {
+	client, ctx := startServer(t)
+	userProgram := createLifter(t, ctx, "alice")
+
+	next, err := client.GetNextWorkout(t.Context(), &greyskullv1.GetNextWorkoutRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), next.GetDay())
+	assert.Equal(t, userProgram.ID.String(), next.GetUserProgramId())
+	require.NotEmpty(t, next.GetExercises())
+
+	// Hit every target, so each lift trained progresses
+	trained := make(map[string]bool)
+	for _, lift := range next.GetExercises() {
+		trained[lift.GetLiftName()] = true
+		for _, set := range lift.GetSets() {
+			set.ActualReps = set.GetTargetReps()
+		}
+	}
+
+	dryRun, err := client.LogWorkout(t.Context(), &greyskullv1.LogWorkoutRequest{Workout: next, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), dryRun.GetUserProgram().GetCurrentDay())
+	history, err := client.ListWorkouts(t.Context(), &greyskullv1.ListWorkoutsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, history.GetWorkouts(), "a dry run must not save the workout")
+
+	logged, err := client.LogWorkout(t.Context(), &greyskullv1.LogWorkoutRequest{Username: "alice", Workout: next})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), logged.GetUserProgram().GetCurrentDay())
+	for lift, weight := range userProgram.CurrentWeights {
+		if trained[string(lift)] {
+			assert.Greater(t, logged.GetUserProgram().GetCurrentWeights()[string(lift)], weight, lift)
+		}
+	}
+	assert.Contains(t, logged.GetMilestones(), "workouts-1")
+
+	history, err = client.ListWorkouts(t.Context(), &greyskullv1.ListWorkoutsRequest{Username: "alice"})
+	require.NoError(t, err)
+	require.Len(t, history.GetWorkouts(), 1)
+	assert.Equal(t, next.GetId(), history.GetWorkouts()[0].GetId())
+	assert.NotNil(t, history.GetWorkouts()[0].GetEnteredAt())
+
+	saved, err := ctx.UserRepo.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved.Programs[userProgram.ID].CurrentDay)
+}
+
+func TestServer_LogWorkout_InvalidWorkout(t *testing.T) {
+	client, ctx := startServer(t)
+	createLifter(t, ctx, "alice")
+
+	next, err := client.GetNextWorkout(t.Context(), &greyskullv1.GetNextWorkoutRequest{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		workout *greyskullv1.Workout
+		code    codes.Code
+	}{
+		{name: "no workout", code: codes.InvalidArgument},
+		{name: "no exercises", workout: &greyskullv1.Workout{}, code: codes.InvalidArgument},
+		{name: "wrong day", workout: &greyskullv1.Workout{Day: 2, Exercises: next.GetExercises()}, code: codes.InvalidArgument},
+		{name: "invalid ID", workout: &greyskullv1.Workout{Id: "nope", Exercises: next.GetExercises()}, code: codes.InvalidArgument},
+		{name: "unknown program", workout: &greyskullv1.Workout{UserProgramId: uuid.NewString(), Exercises: next.GetExercises()}, code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.LogWorkout(t.Context(), &greyskullv1.LogWorkoutRequest{Workout: tt.workout})
+			requireCode(t, err, tt.code)
+		})
+	}
+
+	saved, err := ctx.UserRepo.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	assert.Empty(t, saved.WorkoutHistory)
+}
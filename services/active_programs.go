@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// GetCurrentUserWithActiveProgram loads the current user and the active
+// program ref picks, with its Program. ref is an index or ID from 'greyskull
+// program list --mine', or the name of the program's template. An empty ref
+// picks the current program, as GetCurrentUserWithProgram does.
+func (s *UserService) GetCurrentUserWithActiveProgram(ctx context.Context, ref string) (*models.User, *models.UserProgram, *models.Program, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return s.GetCurrentUserWithProgram(ctx)
+	}
+
+	user, err := s.RequireCurrentUser(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if user.CurrentProgram == uuid.Nil {
+		return nil, nil, nil, ErrNoActiveProgram
+	}
+
+	userProgram, programDef, err := s.resolveActiveProgram(ctx, user, ref)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !user.IsActive(userProgram.ID) {
+		return nil, nil, nil, NewError("program_not_active",
+			fmt.Sprintf("run 'greyskull program activate %s' to train it alongside your current program", ref),
+			"program %q is not active", ref)
+	}
+	if programDef == nil {
+		if programDef, err = s.ProgramFor(ctx, userProgram); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load program: %w", err)
+		}
+	}
+	return user, userProgram, programDef, nil
+}
+
+// resolveActiveProgram finds the program ref names: by index or ID among all
+// the user's programs, or else by template name among their active programs.
+// The Program is returned too if it had to be loaded to match its name.
+func (s *UserService) resolveActiveProgram(ctx context.Context, user *models.User, ref string) (*models.UserProgram, *models.Program, error) {
+	if index, err := strconv.Atoi(ref); err == nil {
+		userPrograms := user.ProgramList()
+		if index < 1 || index > len(userPrograms) {
+			return nil, nil, fmt.Errorf("invalid program index %d: expected a number between 1 and %d", index, len(userPrograms))
+		}
+		return userPrograms[index-1], nil, nil
+	}
+	if id, err := uuid.Parse(ref); err == nil {
+		if userProgram, exists := user.Programs[id]; exists {
+			return userProgram, nil, nil
+		}
+		return nil, nil, ProgramNotFound(ref)
+	}
+
+	for _, userProgram := range user.ActiveProgramList() {
+		programDef, err := s.ProgramFor(ctx, userProgram)
+		if err == nil && strings.EqualFold(programDef.Name, ref) {
+			return userProgram, programDef, nil
+		}
+	}
+	return nil, nil, ProgramNotFound(ref)
+}
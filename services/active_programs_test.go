@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_GetCurrentUserWithActiveProgram(t *testing.T) {
+	user, current, target := switchTestUser()
+	conditioning := &models.UserProgram{
+		ID: uuid.New(), ProgramID: program.GreyskullLP3Day.ID, CurrentDay: 3, StartedAt: time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC),
+	}
+	user.Programs[conditioning.ID] = conditioning
+	user.ActivePrograms = []uuid.UUID{conditioning.ID}
+
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("GetCurrent").Return("testuser", nil)
+	mockRepo.On("Get", "testuser").Return(user, nil)
+	userService := NewUserService(mockRepo, nil)
+
+	_, userProgram, prog, err := userService.GetCurrentUserWithActiveProgram(t.Context(), "")
+	require.NoError(t, err)
+	assert.Equal(t, current, userProgram)
+	assert.Equal(t, program.GreyskullLP.ID, prog.ID)
+
+	for _, ref := range []string{conditioning.ID.String(), program.GreyskullLP3Day.Name, "  " + program.GreyskullLP3Day.Name + " "} {
+		_, userProgram, prog, err = userService.GetCurrentUserWithActiveProgram(t.Context(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, conditioning, userProgram, ref)
+		assert.Equal(t, program.GreyskullLP3Day.ID, prog.ID, ref)
+	}
+
+	// Programs are listed oldest first, so the target is first
+	_, _, _, err = userService.GetCurrentUserWithActiveProgram(t.Context(), "1")
+	assert.ErrorIs(t, err, &Error{Code: "program_not_active"})
+	assert.EqualError(t, err, `program "1" is not active`)
+	_, _, _, err = userService.GetCurrentUserWithActiveProgram(t.Context(), target.ID.String())
+	assert.ErrorIs(t, err, &Error{Code: "program_not_active"})
+
+	_, _, _, err = userService.GetCurrentUserWithActiveProgram(t.Context(), "9")
+	assert.EqualError(t, err, "invalid program index 9: expected a number between 1 and 3")
+	_, _, _, err = userService.GetCurrentUserWithActiveProgram(t.Context(), "Starting Strength")
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+}
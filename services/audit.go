@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/audit"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/mikowitz/greyskull/repository"
+)
+
+// auditCommand names the command making changes, recorded in audit entries
+var auditCommand string
+
+// SetAuditCommand names the command whose changes are being recorded, e.g.
+// "workout log"; empty records entries without a command
+func SetAuditCommand(name string) {
+	auditCommand = name
+}
+
+// auditedUserRepository wraps a UserRepository, recording every change made
+// through it in the user's audit log. Changes are found by comparing each
+// saved user with the version stored before it.
+type auditedUserRepository struct {
+	repository.UserRepository
+	audit   repository.AuditRepository
+	catalog *program.Catalog
+	now     func() time.Time
+}
+
+// NewAuditedUserRepository records the changes made through repo in log,
+// naming programs from catalog
+func NewAuditedUserRepository(repo repository.UserRepository, log repository.AuditRepository, catalog *program.Catalog) repository.UserRepository {
+	return &auditedUserRepository{UserRepository: repo, audit: log, catalog: catalog, now: time.Now}
+}
+
+// Create creates the user and records its creation
+func (r *auditedUserRepository) Create(ctx context.Context, user *models.User) error {
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	return r.record(ctx, nil, user)
+}
+
+// Update saves the user and records how it differs from the stored version
+func (r *auditedUserRepository) Update(ctx context.Context, user *models.User) error {
+	// Users are stored by username, so the stored version is found by it
+	before, err := r.UserRepository.Get(ctx, user.Username)
+	if err != nil {
+		return fmt.Errorf("failed to load %s to record changes: %w", user.Username, err)
+	}
+	// Read the stored history before it's replaced, if it's to be compared
+	if user.HistoryLoaded() {
+		if err := before.LoadHistory(); err != nil {
+			return fmt.Errorf("failed to load workout history: %w", err)
+		}
+	}
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	return r.record(ctx, before, user)
+}
+
+func (r *auditedUserRepository) record(ctx context.Context, before, after *models.User) error {
+	entries := audit.Diff(before, after, r.programName(ctx))
+	at := r.now()
+	for i := range entries {
+		entries[i].At = at
+		entries[i].Command = auditCommand
+	}
+	if err := r.audit.Append(after.ID, entries); err != nil {
+		return fmt.Errorf("failed to record changes in the audit log: %w", err)
+	}
+	return nil
+}
+
+func (r *auditedUserRepository) programName(ctx context.Context) audit.ProgramNamer {
+	return func(programID uuid.UUID) string {
+		if prog, err := r.catalog.GetByID(ctx, programID.String()); err == nil {
+			return prog.Name
+		}
+		return ""
+	}
+}
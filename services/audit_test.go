@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditLog is an AuditRepository kept in memory
+type memoryAuditLog map[uuid.UUID][]models.AuditEntry
+
+func (l memoryAuditLog) Append(userID uuid.UUID, entries []models.AuditEntry) error {
+	l[userID] = append(l[userID], entries...)
+	return nil
+}
+
+func (l memoryAuditLog) Load(userID uuid.UUID) ([]models.AuditEntry, error) {
+	return l[userID], nil
+}
+
+func TestAuditedUserRepository_Update(t *testing.T) {
+	log := memoryAuditLog{}
+	repo := NewAuditedUserRepository(repository.NewInMemoryUserRepository(), log, nil)
+	user := &models.User{ID: uuid.New(), Username: "Alice"}
+	require.NoError(t, repo.Create(t.Context(), user))
+	require.Len(t, log[user.ID], 1)
+
+	user.Leaderboard = true
+	require.NoError(t, repo.Update(t.Context(), user))
+	require.Len(t, log[user.ID], 2)
+	assert.Equal(t, models.UserUpdated, log[user.ID][1].Action)
+
+	// A user that can't be loaded isn't saved without its changes being recorded
+	missing := &models.User{ID: uuid.New(), Username: "Bob"}
+	err := repo.Update(t.Context(), missing)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	assert.Empty(t, log[missing.ID])
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"fmt"
 
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
 	"github.com/mikowitz/greyskull/repository"
 )
 
@@ -14,6 +16,30 @@ type CommandContext struct {
 	
 	// UserService provides high-level user operations (built on top of UserRepo)
 	UserService *UserService
+
+	// ProgramRepo provides access to custom program storage; nil if the factory doesn't support it
+	ProgramRepo repository.ProgramRepository
+
+	// Programs lists built-in and custom programs
+	Programs *program.Catalog
+
+	// ArchiveRepo provides access to archived workouts; nil if the factory doesn't support it
+	ArchiveRepo repository.ArchiveRepository
+
+	// LiftRepo provides access to custom lift definitions; nil if the factory doesn't support it
+	LiftRepo repository.LiftRepository
+
+	// BackupRepo takes snapshots of user data before destructive commands; nil if the factory doesn't support it
+	BackupRepo repository.BackupRepository
+
+	// Config reads and writes the current user's settings
+	Config *ConfigService
+
+	// DraftRepo saves unfinished workout logs so they can be resumed; nil if the factory doesn't support it
+	DraftRepo repository.DraftRepository
+
+	// AuditRepo reads the log of changes made through UserRepo; nil if the factory doesn't support it
+	AuditRepo repository.AuditRepository
 }
 
 // NewCommandContext creates a new CommandContext with the specified repository factory
@@ -29,15 +55,108 @@ func NewCommandContext(factory RepositoryFactory) (*CommandContext, error) {
 		return nil, fmt.Errorf("failed to create user repository: %w", err)
 	}
 	
+	// Register custom lifts first, since custom programs may rely on their defaults
+	liftRepo, err := RegisterCustomLifts(factory)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load custom programs when the factory supports program storage
+	var programRepo repository.ProgramRepository
+	var customPrograms []*models.Program
+	if programFactory, ok := factory.(ProgramRepositoryFactory); ok {
+		programRepo, err = programFactory.NewProgramRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create program repository: %w", err)
+		}
+		customPrograms, err = programRepo.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load custom programs: %w", err)
+		}
+	}
+	catalog := program.NewCatalog(customPrograms)
+
+	var archiveRepo repository.ArchiveRepository
+	if archiveFactory, ok := factory.(ArchiveRepositoryFactory); ok {
+		archiveRepo, err = archiveFactory.NewArchiveRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive repository: %w", err)
+		}
+	}
+
+	var backupRepo repository.BackupRepository
+	if backupFactory, ok := factory.(BackupRepositoryFactory); ok {
+		backupRepo, err = backupFactory.NewBackupRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backup repository: %w", err)
+		}
+	}
+
+	var configRepo repository.ConfigRepository
+	if configFactory, ok := factory.(ConfigRepositoryFactory); ok {
+		configRepo, err = configFactory.NewConfigRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config repository: %w", err)
+		}
+	}
+
+	var draftRepo repository.DraftRepository
+	if draftFactory, ok := factory.(DraftRepositoryFactory); ok {
+		draftRepo, err = draftFactory.NewDraftRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create draft repository: %w", err)
+		}
+	}
+
+	// Record every change to users once the catalog can name their programs
+	var auditRepo repository.AuditRepository
+	if auditFactory, ok := factory.(AuditRepositoryFactory); ok {
+		auditRepo, err = auditFactory.NewAuditRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit repository: %w", err)
+		}
+		userRepo = NewAuditedUserRepository(userRepo, auditRepo, catalog)
+	}
+
 	// Create the user service with the repository
-	userService := NewUserService(userRepo, nil)
+	userService := NewUserService(userRepo, catalog)
 	
 	return &CommandContext{
 		UserRepo:    userRepo,
 		UserService: userService,
+		ProgramRepo: programRepo,
+		Programs:    catalog,
+		ArchiveRepo: archiveRepo,
+		LiftRepo:    liftRepo,
+		BackupRepo:  backupRepo,
+		Config:      NewConfigService(userRepo, configRepo),
+		DraftRepo:   draftRepo,
+		AuditRepo:   auditRepo,
 	}, nil
 }
 
+// RegisterCustomLifts loads the custom lift definitions from the factory's lift
+// repository into the lift registry and returns the repository. Factories without
+// lift storage register no custom lifts and return a nil repository.
+func RegisterCustomLifts(factory RepositoryFactory) (repository.LiftRepository, error) {
+	liftFactory, ok := factory.(LiftRepositoryFactory)
+	if !ok {
+		models.RegisterLifts(nil)
+		return nil, nil
+	}
+
+	liftRepo, err := liftFactory.NewLiftRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lift repository: %w", err)
+	}
+	customLifts, err := liftRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom lifts: %w", err)
+	}
+	models.RegisterLifts(customLifts)
+	return liftRepo, nil
+}
+
 // NewCommandContextWithDefaults creates a CommandContext using the default repository factory
 // This is a convenience method for commands that don't need custom dependency injection
 func NewCommandContextWithDefaults() (*CommandContext, error) {
@@ -20,7 +20,7 @@ func TestCommandContext_NewCommandContext(t *testing.T) {
 	assert.NotNil(t, ctx.UserService)
 	
 	// Verify the user service has the correct repository
-	user, err := ctx.UserRepo.GetCurrent()
+	user, err := ctx.UserRepo.GetCurrent(t.Context())
 	// We don't care about the result, just that it doesn't panic
 	_ = user
 	_ = err
@@ -75,7 +75,7 @@ func TestCommandContext_UserServiceIntegration(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Use the user service from the context
-	user, err := ctx.UserService.RequireCurrentUser()
+	user, err := ctx.UserService.RequireCurrentUser(t.Context())
 	
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -124,7 +124,7 @@ func TestCommandContext_CommandTestingExample(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Simulate a command using the context
-	user, err := ctx.UserService.RequireCurrentUser()
+	user, err := ctx.UserService.RequireCurrentUser(t.Context())
 	
 	// Verify the command worked
 	assert.NoError(t, err)
@@ -139,4 +139,35 @@ func TestCommandContext_CommandTestingExample(t *testing.T) {
 	// 1. Create repositories directly
 	// 2. Handle repository creation errors individually
 	// 3. Set up complex test environments
-}
\ No newline at end of file
+}
+func TestCommandContext_LoadsProgramCatalog(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	ctx, err := NewCommandContext(NewJSONRepositoryFactory())
+	require.NoError(t, err)
+
+	assert.NotNil(t, ctx.ProgramRepo)
+	assert.NotNil(t, ctx.ArchiveRepo)
+	assert.NotNil(t, ctx.BackupRepo)
+	assert.NotNil(t, ctx.LiftRepo)
+	assert.NotNil(t, ctx.DraftRepo)
+	require.NotNil(t, ctx.Programs)
+	assert.NotEmpty(t, ctx.Programs.List())
+}
+
+func TestCommandContext_FactoryWithoutProgramSupport(t *testing.T) {
+	mockFactory := new(MockRepositoryFactory)
+	mockFactory.On("NewUserRepository").Return(new(MockUserRepository), nil).Once()
+
+	ctx, err := NewCommandContext(mockFactory)
+	require.NoError(t, err)
+
+	// Built-in programs are still available without program storage
+	assert.Nil(t, ctx.ProgramRepo)
+	assert.Nil(t, ctx.ArchiveRepo)
+	assert.Nil(t, ctx.BackupRepo)
+	assert.Nil(t, ctx.LiftRepo)
+	assert.Nil(t, ctx.DraftRepo)
+	require.NotNil(t, ctx.Programs)
+	assert.NotEmpty(t, ctx.Programs.List())
+}
@@ -0,0 +1,616 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikowitz/greyskull/i18n"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/mikowitz/greyskull/timer"
+)
+
+// Setting is a single config value formatted for display
+type Setting struct {
+	Key     string
+	Value   string
+	Default bool // The value is the built-in default
+	Secret  bool // Value only says whether the setting is set, never what it is
+}
+
+// configSetting describes how a config key is read, written, and reset. Unit and
+// rest timer settings are stored on the user, where programs and the rest timer
+// read them; the rest live in the user's config file. Settings that hold a list
+// set it with setList, taking each value given as one entry; other settings
+// join the values with spaces.
+type configSetting struct {
+	key     string
+	onUser  bool
+	secret  bool
+	get     func(user *models.User, config *models.Config) (value string, isDefault bool)
+	set     func(user *models.User, config *models.Config, value string) error
+	setList func(user *models.User, config *models.Config, values []string) error
+	reset   func(user *models.User, config *models.Config)
+}
+
+// configSettings lists every config key in display order
+var configSettings = []configSetting{
+	{
+		key:    "unit",
+		onUser: true,
+		get: func(user *models.User, _ *models.Config) (string, bool) {
+			return string(user.Unit.OrDefault()), user.Unit == ""
+		},
+		set: func(user *models.User, _ *models.Config, value string) error {
+			unit, err := models.ParseWeightUnit(value)
+			if err != nil {
+				return err
+			}
+			user.Unit = unit
+			return nil
+		},
+		reset: func(user *models.User, _ *models.Config) { user.Unit = "" },
+	},
+	{
+		key: "bar_weight",
+		get: func(user *models.User, config *models.Config) (string, bool) {
+			if config.BarWeight == 0 {
+				return formatConfigWeight(user.Unit.OrDefault().BarWeight(), user.Unit), true
+			}
+			return formatConfigWeight(config.BarWeight, config.BarUnit), false
+		},
+		set: func(user *models.User, config *models.Config, value string) error {
+			weight, unit, err := models.ParseWeight(value, user.Unit)
+			if err != nil {
+				return err
+			}
+			if weight == 0 {
+				return fmt.Errorf("bar weight must be positive, got %q", value)
+			}
+			config.BarWeight, config.BarUnit = weight, unit
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.BarWeight, config.BarUnit = 0, "" },
+	},
+	{
+		key: "plates",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if len(config.Plates) == 0 {
+				return "not set", true
+			}
+			plates := make([]string, len(config.Plates))
+			for i, plate := range config.Plates {
+				plates[i] = fmt.Sprintf("%sx%d", strconv.FormatFloat(plate.Weight, 'f', -1, 64), plate.Count)
+			}
+			return strings.Join(plates, ",") + " " + string(config.PlateUnit.OrDefault()), false
+		},
+		set: func(user *models.User, config *models.Config, value string) error {
+			// A trailing unit applies to every plate, e.g. "20x4,10x2 kg"
+			unit := user.Unit.OrDefault()
+			fields := strings.Fields(value)
+			if len(fields) > 1 {
+				if parsed, err := models.ParseWeightUnit(fields[len(fields)-1]); err == nil {
+					unit = parsed
+					value = strings.Join(fields[:len(fields)-1], " ")
+				}
+			}
+			plates, err := models.ParsePlates(value)
+			if err != nil {
+				return err
+			}
+			config.Plates, config.PlateUnit = plates, unit
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.Plates, config.PlateUnit = nil, "" },
+	},
+	{
+		key: "plate_hints",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.PlateHints {
+				return "on", false
+			}
+			return "off", true
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			on, err := parseSwitch("plate hints", value)
+			if err != nil {
+				return err
+			}
+			config.PlateHints = on
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.PlateHints = false },
+	},
+	{
+		key: "warmup_strategy",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.WarmupStrategy == "" {
+				return "from program", true
+			}
+			return string(config.WarmupStrategy), false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			strategy, err := models.ParseWarmupStrategyName(value)
+			if err != nil {
+				return err
+			}
+			config.WarmupStrategy = strategy
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.WarmupStrategy = "" },
+	},
+	{
+		key:    "timer.warmup",
+		onUser: true,
+		get: func(user *models.User, _ *models.Config) (string, bool) {
+			if user.RestTimes == nil || user.RestTimes.WarmupSeconds == 0 {
+				return "from program", true
+			}
+			return timer.FormatDuration(time.Duration(user.RestTimes.WarmupSeconds) * time.Second), false
+		},
+		set: func(user *models.User, _ *models.Config, value string) error {
+			return setRestTime(user, value, func(times *models.RestTimes, seconds int) { times.WarmupSeconds = seconds })
+		},
+		reset: func(user *models.User, _ *models.Config) {
+			setRestTime(user, "0s", func(times *models.RestTimes, seconds int) { times.WarmupSeconds = seconds })
+		},
+	},
+	{
+		key:    "timer.working",
+		onUser: true,
+		get: func(user *models.User, _ *models.Config) (string, bool) {
+			if user.RestTimes == nil || user.RestTimes.WorkingSeconds == 0 {
+				return "from program", true
+			}
+			return timer.FormatDuration(time.Duration(user.RestTimes.WorkingSeconds) * time.Second), false
+		},
+		set: func(user *models.User, _ *models.Config, value string) error {
+			return setRestTime(user, value, func(times *models.RestTimes, seconds int) { times.WorkingSeconds = seconds })
+		},
+		reset: func(user *models.User, _ *models.Config) {
+			setRestTime(user, "0s", func(times *models.RestTimes, seconds int) { times.WorkingSeconds = seconds })
+		},
+	},
+	{
+		key: "date_format",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			return string(config.DateFormat.OrDefault()), config.DateFormat == ""
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			format, err := models.ParseDateFormat(value)
+			if err != nil {
+				return err
+			}
+			config.DateFormat = format
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.DateFormat = "" },
+	},
+	{
+		key: "dual_units",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.DualUnits {
+				return "on", false
+			}
+			return "off", true
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			on, err := parseSwitch("dual units", value)
+			if err != nil {
+				return err
+			}
+			config.DualUnits = on
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.DualUnits = false },
+	},
+	{
+		key: "locale",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.Locale == "" {
+				return i18n.FromEnv(), true
+			}
+			return config.Locale, false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			locale, err := i18n.ParseLocale(value)
+			if err != nil {
+				return err
+			}
+			config.Locale = locale
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.Locale = "" },
+	},
+	{
+		key: "weekly_target",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.WeeklyTarget == 0 {
+				return "from training days", true
+			}
+			return strconv.Itoa(config.WeeklyTarget), false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			target, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || target < 1 || target > 7 {
+				return fmt.Errorf("weekly target must be a whole number of sessions from 1 to 7, got %q", value)
+			}
+			config.WeeklyTarget = target
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.WeeklyTarget = 0 },
+	},
+	{
+		key: "hooks.post_log",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if len(config.Hooks.PostLog) == 0 {
+				return "none", true
+			}
+			hooks := make([]string, len(config.Hooks.PostLog))
+			for i, hook := range config.Hooks.PostLog {
+				hooks[i] = strconv.Quote(hook)
+			}
+			return strings.Join(hooks, ", "), false
+		},
+		setList: func(_ *models.User, config *models.Config, values []string) error {
+			hooks := make([]string, len(values))
+			for i, value := range values {
+				hook, err := models.ParseHook(value)
+				if err != nil {
+					return err
+				}
+				hooks[i] = hook
+			}
+			config.Hooks.PostLog = hooks
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.Hooks.PostLog = nil },
+	},
+	{
+		key: "strava.client_id",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.Strava.ClientID == "" {
+				return "not set", true
+			}
+			return config.Strava.ClientID, false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			return setStravaCredential(&config.Strava, &config.Strava.ClientID, value)
+		},
+		reset: func(_ *models.User, config *models.Config) { config.Strava = models.StravaConfig{} },
+	},
+	{
+		key:    "strava.client_secret",
+		secret: true,
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			// The secret itself is never shown
+			if config.Strava.ClientSecret == "" {
+				return "not set", true
+			}
+			return "set", false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			return setStravaCredential(&config.Strava, &config.Strava.ClientSecret, value)
+		},
+		reset: func(_ *models.User, config *models.Config) { config.Strava = models.StravaConfig{} },
+	},
+	{
+		key: "smtp.server",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.SMTP.Server == "" {
+				return "not set", true
+			}
+			return config.SMTP.Server, false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			server, err := models.ParseSMTPServer(value)
+			if err != nil {
+				return err
+			}
+			config.SMTP.Server = server
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.SMTP.Server = "" },
+	},
+	{
+		key: "smtp.username",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.SMTP.Username == "" {
+				return "not set", true
+			}
+			return config.SMTP.Username, false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			config.SMTP.Username = strings.TrimSpace(value)
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.SMTP.Username = "" },
+	},
+	{
+		key:    "smtp.password",
+		secret: true,
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			// The password itself is never shown
+			if config.SMTP.Password == "" {
+				return "not set", true
+			}
+			return "set", false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			if value == "" {
+				return fmt.Errorf("value cannot be empty")
+			}
+			config.SMTP.Password = value
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.SMTP.Password = "" },
+	},
+	{
+		key: "smtp.from",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if config.SMTP.From == "" {
+				return "not set", true
+			}
+			return config.SMTP.From, false
+		},
+		set: func(_ *models.User, config *models.Config, value string) error {
+			address, err := models.ParseEmailAddress(value)
+			if err != nil {
+				return err
+			}
+			config.SMTP.From = address
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.SMTP.From = "" },
+	},
+	{
+		key: "smtp.to",
+		get: func(_ *models.User, config *models.Config) (string, bool) {
+			if len(config.SMTP.To) == 0 {
+				return "not set", true
+			}
+			return strings.Join(config.SMTP.To, ", "), false
+		},
+		setList: func(_ *models.User, config *models.Config, values []string) error {
+			addresses := make([]string, len(values))
+			for i, value := range values {
+				address, err := models.ParseEmailAddress(value)
+				if err != nil {
+					return err
+				}
+				addresses[i] = address
+			}
+			config.SMTP.To = addresses
+			return nil
+		},
+		reset: func(_ *models.User, config *models.Config) { config.SMTP.To = nil },
+	},
+}
+
+// ConfigKeys returns every config key in display order
+func ConfigKeys() []string {
+	keys := make([]string, len(configSettings))
+	for i, setting := range configSettings {
+		keys[i] = setting.key
+	}
+	return keys
+}
+
+// ConfigService reads and writes a user's settings by key, whether they are
+// stored in the user's config file or on the user
+type ConfigService struct {
+	userRepo   repository.UserRepository
+	configRepo repository.ConfigRepository
+}
+
+// NewConfigService creates a new ConfigService instance
+// If configRepo is nil, every user has the default config and only settings
+// stored on the user can be changed
+func NewConfigService(userRepo repository.UserRepository, configRepo repository.ConfigRepository) *ConfigService {
+	if userRepo == nil {
+		panic("repository cannot be nil")
+	}
+	return &ConfigService{
+		userRepo:   userRepo,
+		configRepo: configRepo,
+	}
+}
+
+// Load returns the user's config file settings
+func (s *ConfigService) Load(username string) (*models.Config, error) {
+	if s.configRepo == nil {
+		return &models.Config{}, nil
+	}
+	config, err := s.configRepo.Get(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return config, nil
+}
+
+// Save stores the user's config file settings
+func (s *ConfigService) Save(username string, config *models.Config) error {
+	if s.configRepo == nil {
+		return fmt.Errorf("config storage is unavailable")
+	}
+	if err := s.configRepo.Save(username, config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// SelectLocale translates messages into the current user's locale setting, or
+// the locale named by the environment when they haven't chosen one. Without a
+// current user or config storage to read it from, the environment decides;
+// commands that need them report why they're missing.
+func SelectLocale(ctx context.Context, factory RepositoryFactory, userOverride string) {
+	i18n.SetLocale(configuredLocale(ctx, factory, userOverride))
+}
+
+func configuredLocale(ctx context.Context, factory RepositoryFactory, userOverride string) string {
+	configFactory, ok := factory.(ConfigRepositoryFactory)
+	if !ok {
+		return i18n.FromEnv()
+	}
+	userRepo, err := factory.NewUserRepository()
+	if err != nil {
+		return i18n.FromEnv()
+	}
+	userService := NewUserService(userRepo, nil)
+	userService.SetUserOverride(userOverride)
+	username, err := userService.CurrentUsername(ctx)
+	if err != nil {
+		return i18n.FromEnv()
+	}
+	configRepo, err := configFactory.NewConfigRepository()
+	if err != nil {
+		return i18n.FromEnv()
+	}
+	config, err := configRepo.Get(username)
+	if err != nil || config.Locale == "" {
+		return i18n.FromEnv()
+	}
+	return config.Locale
+}
+
+// List returns every setting for the user
+func (s *ConfigService) List(user *models.User) ([]Setting, error) {
+	config, err := s.Load(user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]Setting, len(configSettings))
+	for i, setting := range configSettings {
+		settings[i] = setting.display(user, config)
+	}
+	return settings, nil
+}
+
+// Get returns a single setting for the user
+func (s *ConfigService) Get(user *models.User, key string) (Setting, error) {
+	setting, err := lookupConfigSetting(key)
+	if err != nil {
+		return Setting{}, err
+	}
+	config, err := s.Load(user.Username)
+	if err != nil {
+		return Setting{}, err
+	}
+	return setting.display(user, config), nil
+}
+
+// Set validates and saves a setting for the user, returning its new value. The
+// value "default" goes back to the built-in default.
+func (s *ConfigService) Set(ctx context.Context, user *models.User, key string, values ...string) (Setting, error) {
+	setting, err := lookupConfigSetting(key)
+	if err != nil {
+		return Setting{}, err
+	}
+	if !setting.onUser && s.configRepo == nil {
+		return Setting{}, fmt.Errorf("%s cannot be set: config storage is unavailable", setting.key)
+	}
+
+	config, err := s.Load(user.Username)
+	if err != nil {
+		return Setting{}, err
+	}
+	value := strings.Join(values, " ")
+	switch {
+	case strings.EqualFold(strings.TrimSpace(value), "default"):
+		setting.reset(user, config)
+	case setting.setList != nil:
+		if err := setting.setList(user, config, values); err != nil {
+			return Setting{}, err
+		}
+	default:
+		if err := setting.set(user, config, value); err != nil {
+			return Setting{}, err
+		}
+	}
+
+	if setting.onUser {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return Setting{}, fmt.Errorf("failed to save user: %w", err)
+		}
+	} else if err := s.configRepo.Save(user.Username, config); err != nil {
+		return Setting{}, fmt.Errorf("failed to save config: %w", err)
+	}
+	return setting.display(user, config), nil
+}
+
+func (c configSetting) display(user *models.User, config *models.Config) Setting {
+	value, isDefault := c.get(user, config)
+	return Setting{Key: c.key, Value: value, Default: isDefault, Secret: c.secret}
+}
+
+func lookupConfigSetting(key string) (configSetting, error) {
+	i := slices.IndexFunc(configSettings, func(setting configSetting) bool {
+		return strings.EqualFold(setting.key, strings.TrimSpace(key))
+	})
+	if i < 0 {
+		return configSetting{}, fmt.Errorf("unknown config key %q (expected one of: %s)", key, strings.Join(ConfigKeys(), ", "))
+	}
+	return configSettings[i], nil
+}
+
+// parseSwitch parses the value of a setting that is on or off, also accepting
+// true or false, yes or no, and 1 or 0
+func parseSwitch(name, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "yes", "y":
+		return true, nil
+	case "off", "no", "n":
+		return false, nil
+	}
+	on, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q (expected on or off)", name, value)
+	}
+	return on, nil
+}
+
+// setRestTime parses a rest duration and stores it with store, clearing the
+// user's rest times entirely once neither is set
+func setRestTime(user *models.User, value string, store func(times *models.RestTimes, seconds int)) error {
+	rest, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, e.g. 90s or 2m30s", value)
+	}
+	if rest < 0 || rest%time.Second != 0 {
+		return fmt.Errorf("rest must be a non-negative whole number of seconds, got: %s", rest)
+	}
+
+	if user.RestTimes == nil {
+		user.RestTimes = &models.RestTimes{}
+	}
+	store(user.RestTimes, int(rest.Seconds()))
+	if *user.RestTimes == (models.RestTimes{}) {
+		user.RestTimes = nil
+	}
+	return nil
+}
+
+// setStravaCredential stores one of the Strava application's credentials. Tokens
+// granted to a different application no longer work, so changing a credential
+// clears them.
+func setStravaCredential(strava *models.StravaConfig, credential *string, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("value cannot be empty")
+	}
+	if *credential != value {
+		*credential = value
+		strava.AccessToken, strava.RefreshToken, strava.ExpiresAt = "", "", time.Time{}
+	}
+	return nil
+}
+
+// formatConfigWeight formats a weight with its unit, e.g. "35 lbs"
+func formatConfigWeight(weight float64, unit models.WeightUnit) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64) + " " + string(unit.OrDefault())
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigService_SetAndGet(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configRepo, err := repository.NewJSONConfigRepository()
+	require.NoError(t, err)
+	mockRepo := new(MockUserRepository)
+	service := NewConfigService(mockRepo, configRepo)
+	user := &models.User{Username: "alice"}
+
+	// Settings stored in the config file don't touch the user
+	setting, err := service.Set(t.Context(), user, "bar_weight", "15kg")
+	require.NoError(t, err)
+	assert.Equal(t, "15 kg", setting.Value)
+	assert.False(t, setting.Default)
+
+	setting, err = service.Set(t.Context(), user, "plates", "10x2 25x4 5x2")
+	require.NoError(t, err)
+	assert.Equal(t, "25x4,10x2,5x2 lbs", setting.Value)
+
+	config, err := service.Load("alice")
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, config.BarWeight)
+	assert.Equal(t, models.Kilograms, config.BarUnit)
+	assert.Equal(t, []models.PlateCount{{Weight: 25, Count: 4}, {Weight: 10, Count: 2}, {Weight: 5, Count: 2}}, config.Plates)
+
+	// Settings stored on the user save the user
+	mockRepo.On("Update", user).Return(nil).Twice()
+	setting, err = service.Set(t.Context(), user, "timer.working", "2m30s")
+	require.NoError(t, err)
+	assert.Equal(t, "2:30", setting.Value)
+	assert.Equal(t, &models.RestTimes{WorkingSeconds: 150}, user.RestTimes)
+
+	_, err = service.Set(t.Context(), user, "timer.working", "default")
+	require.NoError(t, err)
+	assert.Nil(t, user.RestTimes)
+	mockRepo.AssertExpectations(t)
+
+	setting, err = service.Get(user, "BAR_WEIGHT")
+	require.NoError(t, err)
+	assert.Equal(t, "15 kg", setting.Value)
+
+	settings, err := service.List(user)
+	require.NoError(t, err)
+	require.Len(t, settings, len(ConfigKeys()))
+	assert.Equal(t, Setting{Key: "unit", Value: "lbs", Default: true}, settings[0])
+	assert.Equal(t, "date_format", settings[7].Key)
+	assert.Equal(t, "iso", settings[7].Value)
+}
+
+func TestConfigService_SetList(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configRepo, err := repository.NewJSONConfigRepository()
+	require.NoError(t, err)
+	service := NewConfigService(new(MockUserRepository), configRepo)
+	user := &models.User{Username: "alice"}
+
+	setting, err := service.Get(user, "hooks.post_log")
+	require.NoError(t, err)
+	assert.Equal(t, Setting{Key: "hooks.post_log", Value: "none", Default: true}, setting)
+
+	// Each value is one entry, even when it has spaces
+	setting, err = service.Set(t.Context(), user, "hooks.post_log", "https://example.com/log", "cat >> log.json")
+	require.NoError(t, err)
+	assert.Equal(t, `"https://example.com/log", "cat >> log.json"`, setting.Value)
+	config, err := service.Load("alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/log", "cat >> log.json"}, config.Hooks.PostLog)
+
+	_, err = service.Set(t.Context(), user, "hooks.post_log", "https://")
+	assert.ErrorContains(t, err, `invalid hook URL "https://"`)
+
+	_, err = service.Set(t.Context(), user, "hooks.post_log", "default")
+	require.NoError(t, err)
+	config, err = service.Load("alice")
+	require.NoError(t, err)
+	assert.Empty(t, config.Hooks.PostLog)
+}
+
+func TestConfigService_Strava(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configRepo, err := repository.NewJSONConfigRepository()
+	require.NoError(t, err)
+	service := NewConfigService(new(MockUserRepository), configRepo)
+	user := &models.User{Username: "alice"}
+
+	setting, err := service.Set(t.Context(), user, "strava.client_id", "42")
+	require.NoError(t, err)
+	assert.Equal(t, "42", setting.Value)
+	setting, err = service.Set(t.Context(), user, "strava.client_secret", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "set", setting.Value)
+
+	config, err := service.Load("alice")
+	require.NoError(t, err)
+	config.Strava.AccessToken = "access"
+	config.Strava.RefreshToken = "refresh"
+	require.NoError(t, service.Save("alice", config))
+
+	// Tokens belong to the application that was authorized
+	_, err = service.Set(t.Context(), user, "strava.client_secret", "new-secret")
+	require.NoError(t, err)
+	config, err = service.Load("alice")
+	require.NoError(t, err)
+	assert.Equal(t, models.StravaConfig{ClientID: "42", ClientSecret: "new-secret"}, config.Strava)
+
+	_, err = service.Set(t.Context(), user, "strava.client_id", " ")
+	assert.EqualError(t, err, "value cannot be empty")
+
+	setting, err = service.Set(t.Context(), user, "strava.client_id", "default")
+	require.NoError(t, err)
+	assert.Equal(t, Setting{Key: "strava.client_id", Value: "not set", Default: true}, setting)
+	config, err = service.Load("alice")
+	require.NoError(t, err)
+	assert.Equal(t, models.StravaConfig{}, config.Strava)
+}
+
+func TestConfigService_Errors(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewConfigService(mockRepo, nil)
+	user := &models.User{Username: "alice"}
+
+	_, err := service.Get(user, "colour")
+	assert.ErrorContains(t, err, `unknown config key "colour"`)
+
+	_, err = service.Set(t.Context(), user, "date_format", "julian")
+	assert.ErrorContains(t, err, "config storage is unavailable")
+	assert.EqualError(t, service.Save("alice", &models.Config{}), "config storage is unavailable")
+
+	_, err = service.Set(t.Context(), user, "timer.warmup", "soon")
+	assert.ErrorContains(t, err, `invalid duration "soon"`)
+
+	_, err = service.Set(t.Context(), user, "unit", "stone")
+	assert.ErrorContains(t, err, `unknown weight unit "stone"`)
+
+	// Without config storage every user has the defaults
+	config, err := service.Load("alice")
+	require.NoError(t, err)
+	assert.Equal(t, &models.Config{}, config)
+	mockRepo.AssertNotCalled(t, "Update", user)
+}
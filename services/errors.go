@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mikowitz/greyskull/repository"
+)
+
+// Error is an error the user can act on. Code identifies it to programs reading
+// --json output, and Hint says what to do about it, e.g. "run 'greyskull
+// program start'". Commands show the hint on a line of its own.
+type Error struct {
+	Code    string
+	Message string
+	Hint    string
+	Err     error // The underlying cause, if any
+}
+
+// NewError creates an Error with a formatted message
+func NewError(code, hint, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an Error with the same code, so errors.Is
+// matches the sentinels below however the message was worded
+func (e *Error) Is(target error) bool {
+	var other *Error
+	return errors.As(target, &other) && other.Code == e.Code
+}
+
+// Errors commands return when there's nothing to act on yet
+var (
+	ErrNoCurrentUser = &Error{
+		Code:    "no_current_user",
+		Message: "no current user set",
+		Hint:    "run 'greyskull user create' or 'greyskull user switch' first",
+		Err:     repository.ErrNoCurrentUser,
+	}
+	ErrNoActiveProgram = &Error{
+		Code:    "no_active_program",
+		Message: "no active program",
+		Hint:    "run 'greyskull program start' to begin a program",
+	}
+	ErrUserNotFound = &Error{
+		Code:    "user_not_found",
+		Message: "user not found",
+		Hint:    "run 'greyskull user list' to see every user",
+		Err:     repository.ErrUserNotFound,
+	}
+	ErrProgramNotFound = &Error{
+		Code:    "program_not_found",
+		Message: "program not found",
+		Hint:    "run 'greyskull program list' to see available programs",
+	}
+)
+
+// userNotFound returns ErrUserNotFound naming the user
+func userNotFound(username string) *Error {
+	err := *ErrUserNotFound
+	err.Message = fmt.Sprintf("user %q not found", username)
+	return &err
+}
+
+// ProgramNotFound returns ErrProgramNotFound naming the program looked up
+func ProgramNotFound(query string) *Error {
+	err := *ErrProgramNotFound
+	err.Message = fmt.Sprintf("program %q not found", query)
+	return &err
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mikowitz/greyskull/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("failed to switch: %w", ProgramNotFound("5x5"))
+	assert.EqualError(t, err, `failed to switch: program "5x5" not found`)
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+	assert.NotErrorIs(t, err, ErrNoActiveProgram)
+
+	assert.ErrorIs(t, ErrNoCurrentUser, repository.ErrNoCurrentUser)
+
+	custom := NewError("deload_in_progress", "run 'greyskull deload cancel'", "%d sessions left", 2)
+	assert.Equal(t, "2 sessions left", custom.Error())
+	assert.Equal(t, "run 'greyskull deload cancel'", custom.Hint)
+}
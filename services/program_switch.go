@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/workout"
+)
+
+// StaleProgramDays is how long a program can go untrained before switching to
+// it comes with a warning that its weights may be too heavy to resume
+const StaleProgramDays = 30
+
+// SwitchPreview describes what changes when the active program is switched
+type SwitchPreview struct {
+	// From is the currently active program, or nil if there is none
+	From        *models.UserProgram
+	FromProgram *models.Program
+
+	To        *models.UserProgram
+	ToProgram *models.Program
+
+	// NextWorkout is the target program's next session, or nil if it cannot be
+	// calculated (e.g. a lift has no current weight)
+	NextWorkout *models.Workout
+
+	// LastTrained is when the target was last trained, or its start date if it never was
+	LastTrained time.Time
+	Trained     bool
+	DaysSince   int
+	Stale       bool
+}
+
+// PreviewSwitch describes switching the user's active program to target as of now.
+// Programs whose templates can't be found are reported without them.
+func (s *UserService) PreviewSwitch(ctx context.Context, user *models.User, target *models.UserProgram, now time.Time) (*SwitchPreview, error) {
+	if _, exists := user.Programs[target.ID]; !exists {
+		return nil, fmt.Errorf("program %s not found", target.ID)
+	}
+
+	preview := &SwitchPreview{
+		To:          target,
+		LastTrained: workout.LastTrainedAt(user, target),
+		Trained:     len(user.HistoryFor(target.ID)) > 0,
+	}
+	preview.DaysSince = workout.DaysSince(preview.LastTrained, now)
+	preview.Stale = preview.DaysSince > StaleProgramDays
+
+	if from, exists := user.Programs[user.CurrentProgram]; exists {
+		preview.From = from
+		preview.FromProgram, _ = s.ProgramFor(ctx, from)
+	}
+
+	toProgram, err := s.ProgramFor(ctx, target)
+	if err != nil {
+		return preview, nil
+	}
+	preview.ToProgram = toProgram
+
+	// Calculate the target's next session as if it were already active
+	switched := *user
+	switched.CurrentProgram = target.ID
+	if next, err := workout.CalculateNextWorkout(&switched, toProgram); err == nil {
+		preview.NextWorkout = next
+	}
+
+	return preview, nil
+}
+
+// SwitchProgram makes target the user's current program and saves the user.
+// A target already trained alongside the current program trades places with
+// it, so both stay active.
+func (s *UserService) SwitchProgram(ctx context.Context, user *models.User, target *models.UserProgram) error {
+	if _, exists := user.Programs[target.ID]; !exists {
+		return fmt.Errorf("program %s not found", target.ID)
+	}
+
+	previous, previousActive := user.CurrentProgram, user.ActivePrograms
+	alongside := user.IsActive(target.ID)
+	user.CurrentProgram = target.ID
+	if alongside {
+		user.DeactivateProgram(target.ID)
+		user.ActivateProgram(previous)
+	}
+	if err := s.repo.Update(ctx, user); err != nil {
+		user.CurrentProgram, user.ActivePrograms = previous, previousActive
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func switchTestUser() (*models.User, *models.UserProgram, *models.UserProgram) {
+	weights := map[models.LiftName]float64{
+		models.OverheadPress: 95, models.BenchPress: 125, models.Squat: 135, models.Deadlift: 185,
+	}
+	current := &models.UserProgram{
+		ID:             uuid.New(),
+		ProgramID:      program.GreyskullLP.ID,
+		CurrentWeights: weights,
+		CurrentDay:     1,
+		StartedAt:      time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	target := &models.UserProgram{
+		ID:             uuid.New(),
+		ProgramID:      program.GreyskullLP.ID,
+		CurrentWeights: weights,
+		CurrentDay:     2,
+		StartedAt:      time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+	user := &models.User{
+		Username:       "testuser",
+		CurrentProgram: current.ID,
+		Programs:       map[uuid.UUID]*models.UserProgram{current.ID: current, target.ID: target},
+		WorkoutHistory: []models.Workout{
+			{UserProgramID: target.ID, Day: 1, EnteredAt: time.Date(2024, 2, 1, 18, 0, 0, 0, time.UTC)},
+		},
+	}
+	return user, current, target
+}
+
+func TestUserService_PreviewSwitch(t *testing.T) {
+	user, current, target := switchTestUser()
+	userService := NewUserService(new(MockUserRepository), nil)
+
+	preview, err := userService.PreviewSwitch(t.Context(), user, target, time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, current, preview.From)
+	assert.Equal(t, program.GreyskullLP.ID, preview.FromProgram.ID)
+	assert.Equal(t, target, preview.To)
+	assert.Equal(t, time.Date(2024, 2, 1, 18, 0, 0, 0, time.UTC), preview.LastTrained)
+	assert.True(t, preview.Trained)
+	assert.Equal(t, 30, preview.DaysSince)
+	assert.False(t, preview.Stale)
+
+	require.NotNil(t, preview.NextWorkout)
+	assert.Equal(t, 2, preview.NextWorkout.Day)
+	assert.Equal(t, target.ID, preview.NextWorkout.UserProgramID)
+	assert.Equal(t, current.ID, user.CurrentProgram, "previewing must not switch programs")
+
+	preview, err = userService.PreviewSwitch(t.Context(), user, target, time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, preview.Stale)
+}
+
+func TestUserService_PreviewSwitch_MissingTemplate(t *testing.T) {
+	user, _, target := switchTestUser()
+	programService := new(MockProgramService)
+	programService.On("GetByID", mock.Anything).Return(nil, errors.New("program not found"))
+	userService := NewUserService(new(MockUserRepository), programService)
+
+	preview, err := userService.PreviewSwitch(t.Context(), user, target, time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, preview.ToProgram)
+	assert.Nil(t, preview.NextWorkout)
+
+	_, err = userService.PreviewSwitch(t.Context(), user, &models.UserProgram{ID: uuid.New()}, time.Now())
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestUserService_SwitchProgram(t *testing.T) {
+	user, current, target := switchTestUser()
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo, nil)
+
+	mockRepo.On("Update", user).Return(errors.New("disk full")).Once()
+	err := userService.SwitchProgram(t.Context(), user, target)
+	assert.EqualError(t, err, "failed to save user: disk full")
+	assert.Equal(t, current.ID, user.CurrentProgram)
+
+	mockRepo.On("Update", user).Return(nil).Once()
+	require.NoError(t, userService.SwitchProgram(t.Context(), user, target))
+	assert.Equal(t, target.ID, user.CurrentProgram)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_SwitchProgram_Alongside(t *testing.T) {
+	user, current, target := switchTestUser()
+	user.ActivePrograms = []uuid.UUID{target.ID}
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo, nil)
+
+	mockRepo.On("Update", user).Return(errors.New("disk full")).Once()
+	require.Error(t, userService.SwitchProgram(t.Context(), user, target))
+	assert.Equal(t, current.ID, user.CurrentProgram)
+	assert.Equal(t, []uuid.UUID{target.ID}, user.ActivePrograms)
+
+	// The programs trade places, so both stay active
+	mockRepo.On("Update", user).Return(nil).Once()
+	require.NoError(t, userService.SwitchProgram(t.Context(), user, target))
+	assert.Equal(t, target.ID, user.CurrentProgram)
+	assert.Equal(t, []uuid.UUID{current.ID}, user.ActivePrograms)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// UpgradePreview describes moving a user program onto the current version of
+// its template
+type UpgradePreview struct {
+	Program *models.UserProgram
+
+	// From is the snapshot the program follows, or nil for programs started
+	// before snapshots were kept, which already follow the template
+	From *models.Program
+	To   *models.Program
+
+	// UpToDate reports that the program already follows the template as it is
+	UpToDate bool
+
+	// NewLifts are the weight keys the new version uses that the program has no
+	// weight for yet, in template order
+	NewLifts []models.LiftName
+
+	// Day is the day the program continues on: its current day, or day 1 if
+	// the new version has fewer days
+	Day int
+}
+
+// PreviewUpgrade describes moving target onto the current version of its template
+func (s *UserService) PreviewUpgrade(ctx context.Context, target *models.UserProgram) (*UpgradePreview, error) {
+	to, err := s.loadTemplate(ctx, target)
+	if err != nil {
+		return nil, ProgramNotFound(target.ProgramID.String())
+	}
+
+	preview := &UpgradePreview{
+		Program:  target,
+		From:     target.Program,
+		To:       to,
+		UpToDate: target.Program != nil && sameProgram(target.Program, to),
+		Day:      target.CurrentDay,
+	}
+	for _, key := range to.WeightKeys() {
+		if _, exists := target.CurrentWeights[key]; !exists {
+			preview.NewLifts = append(preview.NewLifts, key)
+		}
+	}
+	if preview.Day > len(to.Workouts) {
+		preview.Day = 1
+	}
+	return preview, nil
+}
+
+// UpgradeProgram moves the previewed program onto the new version of its
+// template, starting each of its new lifts at the weight given in weights, and
+// saves the user
+func (s *UserService) UpgradeProgram(ctx context.Context, user *models.User, preview *UpgradePreview, weights map[models.LiftName]float64) error {
+	for _, lift := range preview.NewLifts {
+		if _, exists := weights[lift]; !exists {
+			return fmt.Errorf("no starting weight for %s", lift)
+		}
+	}
+
+	target := preview.Program
+	previous := *target.Clone()
+	target.Program = preview.To
+	target.CurrentDay = preview.Day
+	if target.StartingWeights == nil {
+		target.StartingWeights = make(map[models.LiftName]float64)
+	}
+	if target.CurrentWeights == nil {
+		target.CurrentWeights = make(map[models.LiftName]float64)
+	}
+	for lift, weight := range weights {
+		target.StartingWeights[lift] = weight
+		target.CurrentWeights[lift] = weight
+	}
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		*target = previous
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// sameProgram reports whether two programs are stored the same way
+func sameProgram(a, b *models.Program) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeTestPrograms returns an old version of a two-day program and a newer
+// one that drops a day and adds a safety squat bar variant
+func upgradeTestPrograms() (*models.Program, *models.Program) {
+	day := func(n int, lifts ...models.LiftName) models.WorkoutTemplate {
+		template := models.WorkoutTemplate{Day: n}
+		for _, lift := range lifts {
+			template.Lifts = append(template.Lifts, models.LiftTemplate{
+				LiftName:    lift,
+				WorkingSets: []models.SetTemplate{{Reps: 5, WeightPercentage: 1, Type: models.AMRAPSet}},
+			})
+		}
+		return template
+	}
+	id := uuid.New()
+	old := &models.Program{
+		ID: id, Name: "Test Program", Version: "1.0.0",
+		Workouts: []models.WorkoutTemplate{day(1, models.Squat), day(2, models.Deadlift)},
+	}
+	newer := &models.Program{
+		ID: id, Name: "Test Program", Version: "1.1.0",
+		Workouts: []models.WorkoutTemplate{day(1, models.Squat, "Squat:SSB")},
+	}
+	return old, newer
+}
+
+func TestUserService_ProgramFor(t *testing.T) {
+	old, newer := upgradeTestPrograms()
+	programService := new(MockProgramService)
+	programService.On("GetByID", old.ID.String()).Return(newer, nil)
+	userService := NewUserService(new(MockUserRepository), programService)
+
+	// The snapshot taken at start wins over the template
+	prog, err := userService.ProgramFor(t.Context(), &models.UserProgram{ProgramID: old.ID, Program: old})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", prog.Version)
+
+	// Programs without a snapshot follow the template
+	prog, err = userService.ProgramFor(t.Context(), &models.UserProgram{ProgramID: old.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", prog.Version)
+}
+
+func TestUserService_PreviewUpgrade(t *testing.T) {
+	old, newer := upgradeTestPrograms()
+	programService := new(MockProgramService)
+	programService.On("GetByID", old.ID.String()).Return(newer, nil)
+	userService := NewUserService(new(MockUserRepository), programService)
+
+	target := &models.UserProgram{
+		ProgramID:      old.ID,
+		Program:        old,
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 135, models.Deadlift: 185},
+		CurrentDay:     2,
+	}
+	preview, err := userService.PreviewUpgrade(t.Context(), target)
+	require.NoError(t, err)
+	assert.Same(t, old, preview.From)
+	assert.Same(t, newer, preview.To)
+	assert.False(t, preview.UpToDate)
+	assert.Equal(t, []models.LiftName{"Squat:SSB"}, preview.NewLifts)
+	assert.Equal(t, 1, preview.Day, "day 2 no longer exists")
+
+	target.Program = newer
+	preview, err = userService.PreviewUpgrade(t.Context(), target)
+	require.NoError(t, err)
+	assert.True(t, preview.UpToDate)
+
+	// A program without a snapshot can be pinned to the template as it is
+	target.Program = nil
+	preview, err = userService.PreviewUpgrade(t.Context(), target)
+	require.NoError(t, err)
+	assert.Nil(t, preview.From)
+	assert.False(t, preview.UpToDate)
+}
+
+func TestUserService_PreviewUpgrade_MissingTemplate(t *testing.T) {
+	programService := new(MockProgramService)
+	programService.On("GetByID", mock.Anything).Return(nil, errors.New("program not found"))
+	userService := NewUserService(new(MockUserRepository), programService)
+
+	_, err := userService.PreviewUpgrade(t.Context(), &models.UserProgram{ProgramID: program.GreyskullLP.ID, Program: program.GreyskullLP})
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+}
+
+func TestUserService_UpgradeProgram(t *testing.T) {
+	old, newer := upgradeTestPrograms()
+	target := &models.UserProgram{
+		ID:              uuid.New(),
+		ProgramID:       old.ID,
+		Program:         old,
+		StartingWeights: map[models.LiftName]float64{models.Squat: 95, models.Deadlift: 135},
+		CurrentWeights:  map[models.LiftName]float64{models.Squat: 135, models.Deadlift: 185},
+		CurrentDay:      2,
+	}
+	user := &models.User{Username: "testuser", CurrentProgram: target.ID, Programs: map[uuid.UUID]*models.UserProgram{target.ID: target}}
+	preview := &UpgradePreview{Program: target, From: old, To: newer, NewLifts: []models.LiftName{"Squat:SSB"}, Day: 1}
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo, nil)
+
+	err := userService.UpgradeProgram(t.Context(), user, preview, nil)
+	assert.EqualError(t, err, "no starting weight for Squat:SSB")
+
+	mockRepo.On("Update", user).Return(errors.New("disk full")).Once()
+	err = userService.UpgradeProgram(t.Context(), user, preview, map[models.LiftName]float64{"Squat:SSB": 115})
+	assert.EqualError(t, err, "failed to save user: disk full")
+	assert.Same(t, old, target.Program)
+	assert.Equal(t, 2, target.CurrentDay)
+	assert.NotContains(t, target.CurrentWeights, models.LiftName("Squat:SSB"))
+
+	mockRepo.On("Update", user).Return(nil).Once()
+	require.NoError(t, userService.UpgradeProgram(t.Context(), user, preview, map[models.LiftName]float64{"Squat:SSB": 115}))
+	assert.Same(t, newer, target.Program)
+	assert.Equal(t, 1, target.CurrentDay)
+	assert.Equal(t, 115.0, target.StartingWeights["Squat:SSB"])
+	assert.Equal(t, 115.0, target.CurrentWeights["Squat:SSB"])
+	assert.Equal(t, 135.0, target.CurrentWeights[models.Squat], "existing weights are kept")
+	mockRepo.AssertExpectations(t)
+}
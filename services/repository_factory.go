@@ -11,6 +11,55 @@ type RepositoryFactory interface {
 	NewUserRepository() (repository.UserRepository, error)
 }
 
+// ProgramRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create custom program repositories
+type ProgramRepositoryFactory interface {
+	// NewProgramRepository creates a new ProgramRepository instance
+	NewProgramRepository() (repository.ProgramRepository, error)
+}
+
+// ArchiveRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create workout archive repositories
+type ArchiveRepositoryFactory interface {
+	// NewArchiveRepository creates a new ArchiveRepository instance
+	NewArchiveRepository() (repository.ArchiveRepository, error)
+}
+
+// BackupRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create user backup repositories
+type BackupRepositoryFactory interface {
+	// NewBackupRepository creates a new BackupRepository instance
+	NewBackupRepository() (repository.BackupRepository, error)
+}
+
+// LiftRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create custom lift repositories
+type LiftRepositoryFactory interface {
+	// NewLiftRepository creates a new LiftRepository instance
+	NewLiftRepository() (repository.LiftRepository, error)
+}
+
+// ConfigRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create per-user config repositories
+type ConfigRepositoryFactory interface {
+	// NewConfigRepository creates a new ConfigRepository instance
+	NewConfigRepository() (repository.ConfigRepository, error)
+}
+
+// DraftRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create repositories for unfinished workout logs
+type DraftRepositoryFactory interface {
+	// NewDraftRepository creates a new DraftRepository instance
+	NewDraftRepository() (repository.DraftRepository, error)
+}
+
+// AuditRepositoryFactory is an optional extension of RepositoryFactory for
+// factories that can also create audit log repositories
+type AuditRepositoryFactory interface {
+	// NewAuditRepository creates a new AuditRepository instance
+	NewAuditRepository() (repository.AuditRepository, error)
+}
+
 // JSONRepositoryFactory implements RepositoryFactory for JSON-based storage
 type JSONRepositoryFactory struct{}
 
@@ -24,6 +73,41 @@ func (f *JSONRepositoryFactory) NewUserRepository() (repository.UserRepository,
 	return repository.NewJSONUserRepository()
 }
 
+// NewProgramRepository creates a new JSON-based ProgramRepository
+func (f *JSONRepositoryFactory) NewProgramRepository() (repository.ProgramRepository, error) {
+	return repository.NewJSONProgramRepository()
+}
+
+// NewArchiveRepository creates a new JSON-based ArchiveRepository
+func (f *JSONRepositoryFactory) NewArchiveRepository() (repository.ArchiveRepository, error) {
+	return repository.NewJSONArchiveRepository()
+}
+
+// NewBackupRepository creates a new JSON-based BackupRepository
+func (f *JSONRepositoryFactory) NewBackupRepository() (repository.BackupRepository, error) {
+	return repository.NewJSONBackupRepository()
+}
+
+// NewLiftRepository creates a new JSON-based LiftRepository
+func (f *JSONRepositoryFactory) NewLiftRepository() (repository.LiftRepository, error) {
+	return repository.NewJSONLiftRepository()
+}
+
+// NewConfigRepository creates a new JSON-based ConfigRepository
+func (f *JSONRepositoryFactory) NewConfigRepository() (repository.ConfigRepository, error) {
+	return repository.NewJSONConfigRepository()
+}
+
+// NewDraftRepository creates a new JSON-based DraftRepository
+func (f *JSONRepositoryFactory) NewDraftRepository() (repository.DraftRepository, error) {
+	return repository.NewJSONDraftRepository()
+}
+
+// NewAuditRepository creates a new JSON-based AuditRepository
+func (f *JSONRepositoryFactory) NewAuditRepository() (repository.AuditRepository, error) {
+	return repository.NewJSONAuditRepository()
+}
+
 // DefaultRepositoryFactory provides a package-level default factory
 // This can be overridden for testing or different storage backends
 var DefaultRepositoryFactory RepositoryFactory = NewJSONRepositoryFactory()
@@ -182,7 +182,7 @@ func TestCommandService_WithRepositoryFactory(t *testing.T) {
 				
 				// Simulate UserService usage
 				userService := NewUserService(repo, nil)
-				user, err := userService.RequireCurrentUser()
+				user, err := userService.RequireCurrentUser(t.Context())
 				
 				if tt.expectedError != "" {
 					assert.Error(t, err)
@@ -213,7 +213,7 @@ func TestRepositoryDependencyInjection_TestingBenefits(t *testing.T) {
 	mockRepo.On("Get", "testuser").Return(&models.User{Username: "testuser"}, nil).Once()
 	
 	// Execute the service method
-	user, err := userService.RequireCurrentUser()
+	user, err := userService.RequireCurrentUser(t.Context())
 	
 	// Verify results
 	assert.NoError(t, err)
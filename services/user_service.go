@@ -1,7 +1,10 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
@@ -11,13 +14,17 @@ import (
 
 // ProgramService defines the interface for loading programs
 type ProgramService interface {
-	GetByID(id string) (*models.Program, error)
+	GetByID(ctx context.Context, id string) (*models.Program, error)
 }
 
 // UserService encapsulates common user operations used across CLI commands
 type UserService struct {
 	repo           repository.UserRepository
 	programService ProgramService
+
+	// userOverride names the user this service acts as in place of the stored
+	// current user; empty uses the stored current user
+	userOverride string
 }
 
 // NewUserService creates a new UserService instance
@@ -32,20 +39,50 @@ func NewUserService(repo repository.UserRepository, programService ProgramServic
 	}
 }
 
+// SetUserOverride makes the service act as username rather than the stored
+// current user, as the global --user flag does, without changing the stored
+// current user. An empty username goes back to the stored current user.
+func (s *UserService) SetUserOverride(username string) {
+	s.userOverride = strings.TrimSpace(username)
+}
+
+// CurrentUsername resolves the user commands act as: the user named by
+// SetUserOverride, or else the stored current user. It returns
+// repository.ErrNoCurrentUser when there is neither.
+func (s *UserService) CurrentUsername(ctx context.Context) (string, error) {
+	if s.userOverride == "" {
+		username, err := s.repo.GetCurrent(ctx)
+		if err != nil && !errors.Is(err, repository.ErrNoCurrentUser) {
+			return "", fmt.Errorf("failed to get current user: %w", err)
+		}
+		return username, err
+	}
+
+	// Load the user for their username's original casing
+	user, err := s.repo.Get(ctx, s.userOverride)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return "", userNotFound(s.userOverride)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load user %s: %w", s.userOverride, err)
+	}
+	return user.Username, nil
+}
+
 // RequireCurrentUser loads the current user, handling all common error cases
 // This consolidates the repository setup and user loading logic used by all commands
-func (s *UserService) RequireCurrentUser() (*models.User, error) {
+func (s *UserService) RequireCurrentUser(ctx context.Context) (*models.User, error) {
 	// Get current username
-	currentUsername, err := s.repo.GetCurrent()
+	currentUsername, err := s.CurrentUsername(ctx)
 	if err != nil {
-		if err == repository.ErrNoCurrentUser {
-			return nil, fmt.Errorf("no current user set. Use 'greyskull user create' or 'greyskull user switch' first")
+		if errors.Is(err, repository.ErrNoCurrentUser) {
+			return nil, ErrNoCurrentUser
 		}
-		return nil, fmt.Errorf("failed to get current user: %w", err)
+		return nil, err
 	}
 
 	// Load user
-	user, err := s.repo.Get(currentUsername)
+	user, err := s.repo.Get(ctx, currentUsername)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load current user: %w", err)
 	}
@@ -53,18 +90,38 @@ func (s *UserService) RequireCurrentUser() (*models.User, error) {
 	return user, nil
 }
 
+// ListUsers loads every user in the repository, in the repository's username order.
+// It is for commands that compare users, such as the leaderboard; each user
+// decides what they share, so callers must respect their privacy settings.
+func (s *UserService) ListUsers(ctx context.Context) ([]*models.User, error) {
+	usernames, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*models.User, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.repo.Get(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load user %s: %w", username, err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
 // GetCurrentUserWithProgram loads the current user, their active UserProgram, and Program
 // This consolidates the complete user + program loading logic used by workout commands
-func (s *UserService) GetCurrentUserWithProgram() (*models.User, *models.UserProgram, *models.Program, error) {
+func (s *UserService) GetCurrentUserWithProgram(ctx context.Context) (*models.User, *models.UserProgram, *models.Program, error) {
 	// Load current user first
-	user, err := s.RequireCurrentUser()
+	user, err := s.RequireCurrentUser(ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
 	// Check if user has a current program
 	if user.CurrentProgram == uuid.Nil {
-		return nil, nil, nil, fmt.Errorf("no active program. Use 'greyskull program start' to begin a program")
+		return nil, nil, nil, ErrNoActiveProgram
 	}
 
 	// Get UserProgram
@@ -74,16 +131,28 @@ func (s *UserService) GetCurrentUserWithProgram() (*models.User, *models.UserPro
 	}
 
 	// Load Program definition
-	var programDef *models.Program
-	if s.programService != nil {
-		programDef, err = s.programService.GetByID(userProgram.ProgramID.String())
-	} else {
-		// Fallback to direct program.GetByID call if no service is injected
-		programDef, err = program.GetByID(userProgram.ProgramID.String())
-	}
+	programDef, err := s.ProgramFor(ctx, userProgram)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load program: %w", err)
 	}
 
 	return user, userProgram, programDef, nil
-}
\ No newline at end of file
+}
+
+// ProgramFor returns the Program a UserProgram follows: the snapshot taken when
+// it was started or last upgraded, or the template for programs without one
+func (s *UserService) ProgramFor(ctx context.Context, userProgram *models.UserProgram) (*models.Program, error) {
+	if userProgram.Program != nil {
+		return userProgram.Program, nil
+	}
+	return s.loadTemplate(ctx, userProgram)
+}
+
+// loadTemplate loads the current version of the Program template a UserProgram follows
+func (s *UserService) loadTemplate(ctx context.Context, userProgram *models.UserProgram) (*models.Program, error) {
+	if s.programService != nil {
+		return s.programService.GetByID(ctx, userProgram.ProgramID.String())
+	}
+	// Fallback to direct program.GetByID call if no service is injected
+	return program.GetByID(userProgram.ProgramID.String())
+}
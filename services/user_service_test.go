@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -17,12 +18,12 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) Create(user *models.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Get(username string) (*models.User, error) {
+func (m *MockUserRepository) Get(ctx context.Context, username string) (*models.User, error) {
 	args := m.Called(username)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -30,22 +31,22 @@ func (m *MockUserRepository) Get(username string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) Update(user *models.User) error {
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) List() ([]string, error) {
+func (m *MockUserRepository) List(ctx context.Context) ([]string, error) {
 	args := m.Called()
 	return args.Get(0).([]string), args.Error(1)
 }
 
-func (m *MockUserRepository) GetCurrent() (string, error) {
+func (m *MockUserRepository) GetCurrent(ctx context.Context) (string, error) {
 	args := m.Called()
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (m *MockUserRepository) SetCurrent(username string) error {
+func (m *MockUserRepository) SetCurrent(ctx context.Context, username string) error {
 	args := m.Called(username)
 	return args.Error(0)
 }
@@ -55,7 +56,7 @@ type MockProgramService struct {
 	mock.Mock
 }
 
-func (m *MockProgramService) GetByID(id string) (*models.Program, error) {
+func (m *MockProgramService) GetByID(ctx context.Context, id string) (*models.Program, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -96,7 +97,7 @@ func TestUserService_RequireCurrentUser(t *testing.T) {
 				t.Fatal("Get should not be called when GetCurrent fails")
 				return nil, nil
 			},
-			expectedError: "no current user set. Use 'greyskull user create' or 'greyskull user switch' first",
+			expectedError: "no current user set",
 		},
 		{
 			name: "get current user fails with other error",
@@ -148,7 +149,7 @@ func TestUserService_RequireCurrentUser(t *testing.T) {
 			}
 
 			// Execute test
-			user, err := userService.RequireCurrentUser()
+			user, err := userService.RequireCurrentUser(t.Context())
 
 			// Assert results
 			if tt.expectedError != "" {
@@ -166,6 +167,38 @@ func TestUserService_RequireCurrentUser(t *testing.T) {
 	}
 }
 
+func TestUserService_CurrentUsername_Override(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	userService := NewUserService(mockRepo, nil)
+
+	// The override is resolved to the username's original casing, and the
+	// stored current user isn't consulted
+	userService.SetUserOverride(" alice ")
+	mockRepo.On("Get", "alice").Return(&models.User{Username: "Alice"}, nil)
+	mockRepo.On("Get", "Alice").Return(&models.User{Username: "Alice"}, nil)
+	username, err := userService.CurrentUsername(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", username)
+
+	user, err := userService.RequireCurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.Username)
+	mockRepo.AssertNotCalled(t, "GetCurrent")
+
+	userService.SetUserOverride("bob")
+	mockRepo.On("Get", "bob").Return(nil, repository.ErrUserNotFound)
+	_, err = userService.RequireCurrentUser(t.Context())
+	assert.EqualError(t, err, `user "bob" not found`)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+
+	userService.SetUserOverride("")
+	mockRepo.On("GetCurrent").Return("Carol", nil)
+	username, err = userService.CurrentUsername(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Carol", username)
+}
+
 func TestUserService_GetCurrentUserWithProgram(t *testing.T) {
 	testProgramID := uuid.New()
 	testUserProgramID := uuid.New()
@@ -229,7 +262,7 @@ func TestUserService_GetCurrentUserWithProgram(t *testing.T) {
 				t.Fatal("GetByID should not be called when no program is active")
 				return nil, nil
 			},
-			expectedError: "no active program. Use 'greyskull program start' to begin a program",
+			expectedError: "no active program",
 		},
 		{
 			name: "current program not found in user programs",
@@ -312,7 +345,7 @@ func TestUserService_GetCurrentUserWithProgram(t *testing.T) {
 			}
 
 			// Execute test
-			user, userProgram, program, err := userService.GetCurrentUserWithProgram()
+			user, userProgram, program, err := userService.GetCurrentUserWithProgram(t.Context())
 
 			// Assert results
 			if tt.expectedError != "" {
@@ -389,7 +422,7 @@ func TestUserService_GetCurrentUserWithProgram_UsesDefaultProgramService(t *test
 	// This should work even without programService by using program.GetByID directly
 	// But we can't actually test this without mocking the program package, 
 	// so this test just ensures the service doesn't panic
-	_, _, _, err := userService.GetCurrentUserWithProgram()
+	_, _, _, err := userService.GetCurrentUserWithProgram(t.Context())
 	
 	// We expect an error because program.GetByID won't find a test program
 	// but we shouldn't get a panic
@@ -407,11 +440,12 @@ func TestUserService_RequireCurrentUser_ErrorMessages(t *testing.T) {
 	t.Run("no current user error message matches existing commands", func(t *testing.T) {
 		mockRepo.On("GetCurrent").Return("", repository.ErrNoCurrentUser).Once()
 
-		_, err := userService.RequireCurrentUser()
+		_, err := userService.RequireCurrentUser(t.Context())
 
 		assert.Error(t, err)
-		// This should match the exact error message used in workout_log.go and workout_next.go
-		assert.Equal(t, "no current user set. Use 'greyskull user create' or 'greyskull user switch' first", err.Error())
+		assert.ErrorIs(t, err, ErrNoCurrentUser)
+		assert.ErrorIs(t, err, repository.ErrNoCurrentUser)
+		assert.Equal(t, "no current user set", err.Error())
 	})
 
 	mockRepo.AssertExpectations(t)
@@ -433,11 +467,11 @@ func TestUserService_GetCurrentUserWithProgram_ErrorMessages(t *testing.T) {
 		mockRepo.On("GetCurrent").Return("testuser", nil).Once()
 		mockRepo.On("Get", "testuser").Return(user, nil).Once()
 
-		_, _, _, err := userService.GetCurrentUserWithProgram()
+		_, _, _, err := userService.GetCurrentUserWithProgram(t.Context())
 
 		assert.Error(t, err)
-		// This should match the exact error message used in workout_log.go and workout_next.go  
-		assert.Equal(t, "no active program. Use 'greyskull program start' to begin a program", err.Error())
+		assert.ErrorIs(t, err, ErrNoActiveProgram)
+		assert.Equal(t, "no active program", err.Error())
 	})
 
 	t.Run("current program not found error message matches existing commands", func(t *testing.T) {
@@ -452,7 +486,7 @@ func TestUserService_GetCurrentUserWithProgram_ErrorMessages(t *testing.T) {
 		mockRepo.On("GetCurrent").Return("testuser", nil).Once()
 		mockRepo.On("Get", "testuser").Return(user, nil).Once()
 
-		_, _, _, err := userService.GetCurrentUserWithProgram()
+		_, _, _, err := userService.GetCurrentUserWithProgram(t.Context())
 
 		assert.Error(t, err)
 		// This should match the exact error message used in workout_log.go and workout_next.go
@@ -460,4 +494,64 @@ func TestUserService_GetCurrentUserWithProgram_ErrorMessages(t *testing.T) {
 	})
 
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestUserService_ListUsers(t *testing.T) {
+	alice := &models.User{ID: uuid.New(), Username: "Alice"}
+	bob := &models.User{ID: uuid.New(), Username: "Bob"}
+
+	t.Run("loads every user", func(t *testing.T) {
+		mockRepo := &MockUserRepository{}
+		mockRepo.On("List").Return([]string{"Alice", "Bob"}, nil)
+		mockRepo.On("Get", "Alice").Return(alice, nil)
+		mockRepo.On("Get", "Bob").Return(bob, nil)
+
+		users, err := NewUserService(mockRepo, nil).ListUsers(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []*models.User{alice, bob}, users)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("load failure", func(t *testing.T) {
+		mockRepo := &MockUserRepository{}
+		mockRepo.On("List").Return([]string{"Alice", "Bob"}, nil)
+		mockRepo.On("Get", "Alice").Return(alice, nil)
+		mockRepo.On("Get", "Bob").Return(nil, errors.New("corrupt file"))
+
+		_, err := NewUserService(mockRepo, nil).ListUsers(t.Context())
+		assert.EqualError(t, err, "failed to load user Bob: corrupt file")
+	})
+
+	t.Run("list failure", func(t *testing.T) {
+		mockRepo := &MockUserRepository{}
+		mockRepo.On("List").Return([]string{}, errors.New("permission denied"))
+
+		_, err := NewUserService(mockRepo, nil).ListUsers(t.Context())
+		assert.EqualError(t, err, "failed to list users: permission denied")
+	})
+}
+
+func TestUserService_InMemoryRepository(t *testing.T) {
+	repo := repository.NewInMemoryUserRepository()
+	userService := NewUserService(repo, nil)
+
+	_, err := userService.RequireCurrentUser(t.Context())
+	assert.ErrorIs(t, err, ErrNoCurrentUser)
+
+	require.NoError(t, repo.Create(t.Context(), &models.User{ID: uuid.New(), Username: "Alice"}))
+	require.NoError(t, repo.Create(t.Context(), &models.User{ID: uuid.New(), Username: "Bob"}))
+	require.NoError(t, repo.SetCurrent(t.Context(), "alice"))
+
+	user, err := userService.RequireCurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.Username)
+
+	userService.SetUserOverride("bob")
+	user, err = userService.RequireCurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", user.Username)
+
+	users, err := userService.ListUsers(t.Context())
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Alice", users[0].Username)
+}
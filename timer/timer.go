@@ -0,0 +1,93 @@
+// Package timer provides the rest timer shown between sets while logging a workout.
+package timer
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Default rest periods, used when neither the user nor the program sets one
+const (
+	DefaultWarmupRest  = 90 * time.Second
+	DefaultWorkingRest = 3 * time.Minute
+)
+
+// Rests are the rest periods to count down after each kind of set
+type Rests struct {
+	Warmup  time.Duration
+	Working time.Duration
+}
+
+// RestsFor resolves the rest periods for a user following a program. Each period
+// comes from the user's settings if set, then the program's, then the default.
+// Either argument may be nil.
+func RestsFor(user *models.User, program *models.Program) Rests {
+	rests := Rests{Warmup: DefaultWarmupRest, Working: DefaultWorkingRest}
+	if program != nil {
+		rests = rests.override(program.RestTimes)
+	}
+	if user != nil {
+		rests = rests.override(user.RestTimes)
+	}
+	return rests
+}
+
+func (r Rests) override(times *models.RestTimes) Rests {
+	if times == nil {
+		return r
+	}
+	if times.WarmupSeconds > 0 {
+		r.Warmup = time.Duration(times.WarmupSeconds) * time.Second
+	}
+	if times.WorkingSeconds > 0 {
+		r.Working = time.Duration(times.WorkingSeconds) * time.Second
+	}
+	return r
+}
+
+// After returns the rest period following a set of the given type
+func (r Rests) After(setType models.SetType) time.Duration {
+	if setType == models.WarmupSet {
+		return r.Warmup
+	}
+	return r.Working
+}
+
+// Countdown renders a rest countdown on a single terminal line, updating it every second
+type Countdown struct {
+	out   io.Writer
+	beep  bool
+	sleep func(time.Duration)
+}
+
+// NewCountdown creates a Countdown writing to out. When beep is set, the terminal
+// bell is rung once the rest is over. A nil sleep function defaults to time.Sleep.
+func NewCountdown(out io.Writer, beep bool, sleep func(time.Duration)) *Countdown {
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	return &Countdown{out: out, beep: beep, sleep: sleep}
+}
+
+// Run counts down the rest period, returning once it has elapsed
+func (c *Countdown) Run(rest time.Duration) {
+	for remaining := rest.Round(time.Second); remaining > 0; remaining -= time.Second {
+		fmt.Fprintf(c.out, "\rRest: %s ", FormatDuration(remaining))
+		c.sleep(time.Second)
+	}
+
+	bell := ""
+	if c.beep {
+		bell = "\a"
+	}
+	fmt.Fprintf(c.out, "\rRest over!  %s\n", bell)
+}
+
+// FormatDuration formats a duration as minutes and seconds, e.g. "1:30"
+func FormatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
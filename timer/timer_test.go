@@ -0,0 +1,55 @@
+package timer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestsFor(t *testing.T) {
+	program := &models.Program{RestTimes: &models.RestTimes{WarmupSeconds: 60, WorkingSeconds: 240}}
+	user := &models.User{RestTimes: &models.RestTimes{WorkingSeconds: 150}}
+
+	assert.Equal(t, Rests{Warmup: DefaultWarmupRest, Working: DefaultWorkingRest}, RestsFor(nil, nil))
+	assert.Equal(t, Rests{Warmup: DefaultWarmupRest, Working: DefaultWorkingRest}, RestsFor(&models.User{}, &models.Program{}))
+	assert.Equal(t, Rests{Warmup: time.Minute, Working: 4 * time.Minute}, RestsFor(nil, program))
+	assert.Equal(t, Rests{Warmup: time.Minute, Working: 150 * time.Second}, RestsFor(user, program))
+}
+
+func TestRests_After(t *testing.T) {
+	rests := Rests{Warmup: time.Minute, Working: 3 * time.Minute}
+
+	assert.Equal(t, time.Minute, rests.After(models.WarmupSet))
+	assert.Equal(t, 3*time.Minute, rests.After(models.WorkingSet))
+	assert.Equal(t, 3*time.Minute, rests.After(models.AMRAPSet))
+	assert.Equal(t, 3*time.Minute, rests.After(models.FeelerSet))
+}
+
+func TestCountdown_Run(t *testing.T) {
+	var buf bytes.Buffer
+	var slept time.Duration
+	countdown := NewCountdown(&buf, true, func(d time.Duration) { slept += d })
+
+	countdown.Run(3 * time.Second)
+
+	assert.Equal(t, 3*time.Second, slept)
+	assert.Equal(t, "\rRest: 0:03 \rRest: 0:02 \rRest: 0:01 \rRest over!  \a\n", buf.String())
+}
+
+func TestCountdown_NoBeep(t *testing.T) {
+	var buf bytes.Buffer
+	countdown := NewCountdown(&buf, false, func(time.Duration) {})
+
+	countdown.Run(time.Second)
+	assert.NotContains(t, buf.String(), "\a")
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "1:30", FormatDuration(90*time.Second))
+	assert.Equal(t, "3:00", FormatDuration(3*time.Minute))
+	assert.Equal(t, "0:05", FormatDuration(5*time.Second))
+	assert.Equal(t, "10:00", FormatDuration(10*time.Minute))
+}
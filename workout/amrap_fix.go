@@ -0,0 +1,143 @@
+package workout
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// AMRAPFix is a correction to the reps of a lift's AMRAP set in a logged
+// workout, and the change it made to the lift's weight
+type AMRAPFix struct {
+	WorkoutID      uuid.UUID       `json:"workout_id"`
+	Day            int             `json:"day"`
+	EnteredAt      time.Time       `json:"entered_at"`
+	Lift           models.LiftName `json:"lift"`
+	PreviousReps   int             `json:"previous_reps"`
+	Reps           int             `json:"reps"`
+	PreviousWeight float64         `json:"previous_weight"`
+	Weight         float64         `json:"weight"`
+}
+
+// FixAMRAP corrects the reps of lift's AMRAP set in the most recent workout of
+// userProgram, and adjusts the lift's weight, rep target, and deload streak by
+// the difference the correction makes when the history is replayed with
+// Recompute. Adjusting by the difference, rather than taking the replayed
+// values, keeps changes that can't be replayed. A lift held in that workout
+// wasn't progressed by it, so only its reps are corrected.
+func FixAMRAP(user *models.User, userProgram *models.UserProgram, program *models.Program, lift models.LiftName, reps int) (*AMRAPFix, error) {
+	if reps < 0 {
+		return nil, fmt.Errorf("reps must be zero or more, got: %d", reps)
+	}
+
+	history := user.HistoryFor(userProgram.ID)
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no workouts logged for this program yet")
+	}
+	latest := history[len(history)-1]
+	index := -1
+	for i, w := range user.WorkoutHistory {
+		if w.ID == latest.ID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("workout %s not found in history", latest.ID)
+	}
+
+	completed := &user.WorkoutHistory[index]
+	exercise, set := findAMRAPSet(completed, lift)
+	if set == nil {
+		return nil, fmt.Errorf("the Day %d workout on %s has no AMRAP set for %s",
+			completed.Day, completed.EnteredAt.Format("2006-01-02"), lift)
+	}
+	key := exercise.WeightKey()
+
+	fix := &AMRAPFix{
+		WorkoutID:      completed.ID,
+		Day:            completed.Day,
+		EnteredAt:      completed.EnteredAt,
+		Lift:           key,
+		PreviousReps:   set.ActualReps,
+		Reps:           reps,
+		PreviousWeight: userProgram.CurrentWeights[key],
+		Weight:         userProgram.CurrentWeights[key],
+	}
+
+	if slices.Contains(completed.Held, key) {
+		set.ActualReps = reps
+		return fix, nil
+	}
+
+	before, err := Recompute(user, userProgram, program)
+	if err != nil {
+		return nil, err
+	}
+	set.ActualReps = reps
+	after, err := Recompute(user, userProgram, program)
+	if err != nil {
+		set.ActualReps = fix.PreviousReps
+		return nil, err
+	}
+	rebuiltBefore, rebuiltAfter := before.UserProgram, after.UserProgram
+
+	if weight, exists := userProgram.CurrentWeights[key]; exists {
+		fix.Weight = weight + rebuiltAfter.CurrentWeights[key] - rebuiltBefore.CurrentWeights[key]
+		userProgram.CurrentWeights[key] = fix.Weight
+	}
+	if delta := rebuiltAfter.RepTargets[key] - rebuiltBefore.RepTargets[key]; delta != 0 {
+		if userProgram.RepTargets == nil {
+			userProgram.RepTargets = make(map[models.LiftName]int)
+		}
+		if target, exists := userProgram.RepTargets[key]; exists {
+			userProgram.RepTargets[key] = target + delta
+		} else {
+			userProgram.RepTargets[key] = rebuiltAfter.RepTargets[key]
+		}
+		if userProgram.RepTargets[key] <= 0 {
+			delete(userProgram.RepTargets, key)
+		}
+	}
+	// Only a streak that matches the replayed one can be replaced with the
+	// corrected one
+	if userProgram.DeloadStreaks[key] == rebuiltBefore.DeloadStreaks[key] {
+		if streak, exists := rebuiltAfter.DeloadStreaks[key]; exists {
+			if userProgram.DeloadStreaks == nil {
+				userProgram.DeloadStreaks = make(map[models.LiftName]models.DeloadStreak)
+			}
+			userProgram.DeloadStreaks[key] = streak
+		} else {
+			delete(userProgram.DeloadStreaks, key)
+		}
+	}
+	return fix, nil
+}
+
+// findAMRAPSet returns the lift performed in a workout with the weight key or
+// lift name lift, and its AMRAP set, or a nil set if it has none
+func findAMRAPSet(completed *models.Workout, lift models.LiftName) (*models.Lift, *models.Set) {
+	var match *models.Lift
+	for i := range completed.Exercises {
+		exercise := &completed.Exercises[i]
+		if exercise.WeightKey() == lift {
+			match = exercise
+			break
+		}
+		if match == nil && exercise.LiftName == lift {
+			match = exercise
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+	for i := range match.Sets {
+		if match.Sets[i].Type == models.AMRAPSet {
+			return match, &match.Sets[i]
+		}
+	}
+	return match, nil
+}
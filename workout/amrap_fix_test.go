@@ -0,0 +1,88 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixAMRAP(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+		models.BenchPress:    125.0,
+		models.Deadlift:      185.0,
+	})
+	userProgram := user.Programs[user.CurrentProgram]
+	start := time.Date(2025, 3, 3, 18, 0, 0, 0, time.UTC)
+	logTestWorkout(t, user, 8, start)
+
+	// Day 2 was logged with 3 reps on every AMRAP set, deloading both lifts
+	logTestWorkout(t, user, 3, start.AddDate(0, 0, 2))
+	require.Equal(t, 112.5, userProgram.CurrentWeights[models.BenchPress])
+	require.Equal(t, 165.0, userProgram.CurrentWeights[models.Deadlift])
+
+	// A change to the weight that can't be replayed is kept
+	userProgram.CurrentWeights[models.Deadlift] = 170
+
+	fix, err := FixAMRAP(user, userProgram, program.GreyskullLP, models.Deadlift, 6)
+	require.NoError(t, err)
+	assert.Equal(t, models.Deadlift, fix.Lift)
+	assert.Equal(t, 2, fix.Day)
+	assert.Equal(t, 3, fix.PreviousReps)
+	assert.Equal(t, 6, fix.Reps)
+	assert.Equal(t, 170.0, fix.PreviousWeight)
+	assert.Equal(t, 195.0, fix.Weight)
+	assert.Equal(t, 195.0, userProgram.CurrentWeights[models.Deadlift])
+	assert.NotContains(t, userProgram.DeloadStreaks, models.Deadlift)
+
+	// Only the one lift changes
+	assert.Equal(t, 112.5, userProgram.CurrentWeights[models.BenchPress])
+	assert.Contains(t, userProgram.DeloadStreaks, models.BenchPress)
+	latest := user.HistoryFor(userProgram.ID)[1]
+	assert.Equal(t, 6, amrapReps(t, latest, models.Deadlift))
+	assert.Equal(t, 3, amrapReps(t, latest, models.BenchPress))
+
+	_, err = FixAMRAP(user, userProgram, program.GreyskullLP, models.Squat, 6)
+	assert.EqualError(t, err, "the Day 2 workout on 2025-03-05 has no AMRAP set for Squat")
+
+	_, err = FixAMRAP(user, userProgram, program.GreyskullLP, models.Squat, -1)
+	assert.Error(t, err)
+}
+
+func TestFixAMRAP_HeldLift(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+	})
+	userProgram := user.Programs[user.CurrentProgram]
+	userProgram.Holds = map[models.LiftName]int{models.Squat: 1}
+	logTestWorkout(t, user, 8, time.Date(2025, 3, 3, 18, 0, 0, 0, time.UTC))
+	require.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+
+	// The held squat wasn't progressed, so fewer reps don't deload it
+	fix, err := FixAMRAP(user, userProgram, program.GreyskullLP, models.Squat, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 135.0, fix.Weight)
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.NotContains(t, userProgram.DeloadStreaks, models.Squat)
+	assert.Equal(t, 3, amrapReps(t, user.HistoryFor(userProgram.ID)[0], models.Squat))
+}
+
+func TestFixAMRAP_NoWorkouts(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{models.Squat: 135.0})
+
+	_, err := FixAMRAP(user, user.Programs[user.CurrentProgram], program.GreyskullLP, models.Squat, 5)
+	assert.EqualError(t, err, "no workouts logged for this program yet")
+}
+
+func amrapReps(t *testing.T, completed models.Workout, lift models.LiftName) int {
+	t.Helper()
+	_, set := findAMRAPSet(&completed, lift)
+	require.NotNil(t, set)
+	return set.ActualReps
+}
@@ -2,7 +2,9 @@ package workout
 
 import (
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,18 +12,36 @@ import (
 )
 
 func RoundDown2_5(input float64) float64 {
-	return math.Floor(input/2.5) * 2.5
+	return RoundDown(input, models.Pounds)
 }
 
-func CalculateWarmupSets(weight float64, setTemplates []models.SetTemplate) []models.Set {
+// RoundDown rounds a weight down to the nearest weight loadable in unit
+func RoundDown(input float64, unit models.WeightUnit) float64 {
+	return RoundDownTo(input, unit.RoundingStep())
+}
+
+// RoundDownTo rounds a weight down to the nearest multiple of step, such as a
+// lift's rounding step when it's loaded with microplates
+func RoundDownTo(input, step float64) float64 {
+	return math.Floor(input/step) * step
+}
+
+func CalculateWarmupSets(weight float64, setTemplates []models.SetTemplate, unit models.WeightUnit) []models.Set {
+	return CalculateWarmupSetsWithBar(weight, setTemplates, unit, unit.BarWeight(), unit.RoundingStep())
+}
+
+// CalculateWarmupSetsWithBar calculates warmup sets for a lift whose empty bar
+// isn't a standard barbell, such as a registered lift with its own bar weight,
+// rounding them down to the lift's rounding step
+func CalculateWarmupSetsWithBar(weight float64, setTemplates []models.SetTemplate, unit models.WeightUnit, barWeight, step float64) []models.Set {
 	sets := []models.Set{}
-	if weight <= 85.0 {
+	if weight <= unit.MinWarmupWeight() {
 		return sets
 	}
 	for i, tpl := range setTemplates {
-		setWeight := 45.0
+		setWeight := barWeight
 		if tpl.WeightPercentage > 0.0 {
-			setWeight = RoundDown2_5(weight * tpl.WeightPercentage)
+			setWeight = RoundDownTo(weight*tpl.WeightPercentage, step)
 		}
 		set := models.Set{
 			ID:         uuid.Must(uuid.NewV7()),
@@ -36,14 +56,48 @@ func CalculateWarmupSets(weight float64, setTemplates []models.SetTemplate) []mo
 	return sets
 }
 
-func CalculateWorkingSets(weight float64, setTemplates []models.SetTemplate) []models.Set {
+// MergeWarmupTemplates applies a user's warmup percentages over a lift's warmup
+// templates. Empty bar sets are kept, and the remaining sets are replaced by one
+// set per percentage; each takes the reps of the program's set in the same
+// position, or of its heaviest set when there are more percentages. Lifts the
+// program doesn't warm up are left without warmups.
+func MergeWarmupTemplates(templates []models.SetTemplate, percentages models.WarmupPercentages) []models.SetTemplate {
+	if len(percentages) == 0 || len(templates) == 0 {
+		return templates
+	}
+
+	var merged, ramp []models.SetTemplate
+	for _, tpl := range templates {
+		if tpl.WeightPercentage > 0 {
+			ramp = append(ramp, tpl)
+		} else {
+			merged = append(merged, tpl)
+		}
+	}
+
+	for i, percentage := range percentages {
+		reps := templates[len(templates)-1].Reps
+		if i < len(ramp) {
+			reps = ramp[i].Reps
+		} else if len(ramp) > 0 {
+			reps = ramp[len(ramp)-1].Reps
+		}
+		merged = append(merged, models.SetTemplate{Reps: reps, WeightPercentage: percentage, Type: models.WarmupSet})
+	}
+	return merged
+}
+
+// CalculateWorkingSets returns the working sets at a weight, rounded down to the
+// lift's rounding step
+func CalculateWorkingSets(weight float64, setTemplates []models.SetTemplate, step float64) []models.Set {
 	sets := []models.Set{}
-	weight = RoundDown2_5(weight)
+	weight = RoundDownTo(weight, step)
 	for i, tpl := range setTemplates {
 		set := models.Set{
 			ID:         uuid.Must(uuid.NewV7()),
 			Weight:     weight,
-			TargetReps: tpl.Reps,
+			TargetReps: tpl.TargetReps(),
+			MaxReps:    tpl.MaxReps,
 			Type:       tpl.Type,
 			Order:      i + 1,
 		}
@@ -52,6 +106,83 @@ func CalculateWorkingSets(weight float64, setTemplates []models.SetTemplate) []m
 	return sets
 }
 
+// ApplyTrainingMax weighs each working set whose template is a percentage of
+// the training max, leaving the others at the working weight. Sets match
+// their templates by position.
+func ApplyTrainingMax(sets []models.Set, setTemplates []models.SetTemplate, trainingMax, step float64) {
+	for i, tpl := range setTemplates {
+		if tpl.OfTrainingMax && i < len(sets) {
+			sets[i].Weight = RoundDownTo(trainingMax*tpl.WeightPercentage, step)
+		}
+	}
+}
+
+// heaviestSet returns the weight of the heaviest set
+func heaviestSet(sets []models.Set) float64 {
+	heaviest := 0.0
+	for _, set := range sets {
+		heaviest = max(heaviest, set.Weight)
+	}
+	return heaviest
+}
+
+// CalculateBodyweightSets returns the working sets for a bodyweight lift at an
+// added weight, which may be zero or negative for assistance. A positive reps
+// replaces the template's reps for lifts that progress by reps.
+func CalculateBodyweightSets(addedWeight float64, reps int, setTemplates []models.SetTemplate, step float64) []models.Set {
+	sets := CalculateWorkingSets(addedWeight, setTemplates, step)
+	if reps > 0 {
+		for i := range sets {
+			sets[i].TargetReps = reps
+			sets[i].MaxReps = 0
+		}
+	}
+	return sets
+}
+
+// CalculateAccessorySets returns the sets for an optional accessory lift. They are
+// rep-based, so carry no weight.
+func CalculateAccessorySets(setTemplates []models.SetTemplate) []models.Set {
+	sets := make([]models.Set, 0, len(setTemplates))
+	for i, tpl := range setTemplates {
+		sets = append(sets, models.Set{
+			ID:         uuid.Must(uuid.NewV7()),
+			TargetReps: tpl.TargetReps(),
+			MaxReps:    tpl.MaxReps,
+			Type:       tpl.Type,
+			Order:      i + 1,
+		})
+	}
+	return sets
+}
+
+// CalculateFeelerSet returns the feeler single for a lift, or false when the template
+// has no feeler or the working weight is below the template's threshold
+func CalculateFeelerSet(weight float64, tpl *models.FeelerTemplate, step float64) (models.Set, bool) {
+	if tpl == nil || weight < tpl.MinWeight {
+		return models.Set{}, false
+	}
+	return models.Set{
+		ID:         uuid.Must(uuid.NewV7()),
+		Weight:     RoundDownTo(weight*tpl.WeightPercentage, step),
+		TargetReps: 1,
+		Type:       models.FeelerSet,
+	}, true
+}
+
+// insertBeforeAMRAP places a set immediately before the first AMRAP set,
+// or at the end if there is no AMRAP set
+func insertBeforeAMRAP(sets []models.Set, set models.Set) []models.Set {
+	idx := len(sets)
+	for i, s := range sets {
+		if s.Type == models.AMRAPSet {
+			idx = i
+			break
+		}
+	}
+	return append(sets[:idx], append([]models.Set{set}, sets[idx:]...)...)
+}
+
 func GetWorkoutDay(currentDay, totalDays int) int {
 	mod := currentDay % totalDays
 	if mod == 0 {
@@ -61,6 +192,12 @@ func GetWorkoutDay(currentDay, totalDays int) int {
 }
 
 func CalculateNextWorkout(user *models.User, program *models.Program) (*models.Workout, error) {
+	return CalculateNextWorkoutWithConfig(user, program, nil)
+}
+
+// CalculateNextWorkoutWithConfig calculates the user's next workout, taking
+// settings such as the bar weight from their config. A nil config uses the defaults.
+func CalculateNextWorkoutWithConfig(user *models.User, program *models.Program, config *models.Config) (*models.Workout, error) {
 	// Check if user has a current program
 	if user.CurrentProgram == uuid.Nil {
 		return nil, fmt.Errorf("no current program set for user")
@@ -78,6 +215,12 @@ func CalculateNextWorkout(user *models.User, program *models.Program) (*models.W
 	// Get WorkoutTemplate for that day (convert to 0-based index)
 	workoutTemplate := program.Workouts[workoutDay-1]
 
+	var userWarmup models.WarmupStrategyName
+	if config != nil {
+		userWarmup = config.WarmupStrategy
+	}
+	warmupStrategy := NewWarmupStrategy(program.WarmupScheme, userWarmup)
+
 	// Create the workout
 	workout := &models.Workout{
 		ID:            uuid.Must(uuid.NewV7()),
@@ -89,17 +232,73 @@ func CalculateNextWorkout(user *models.User, program *models.Program) (*models.W
 
 	// For each LiftTemplate, calculate sets and create Lift
 	for _, liftTemplate := range workoutTemplate.Lifts {
+		// Accessories are the same reps every session, deload or not
+		if liftTemplate.Optional {
+			workout.Exercises = append(workout.Exercises, models.Lift{
+				ID:       uuid.Must(uuid.NewV7()),
+				LiftName: liftTemplate.LiftName,
+				Variant:  liftTemplate.Variant,
+				Optional: true,
+				Sets:     CalculateAccessorySets(liftTemplate.WorkingSets),
+				Group:    liftTemplate.Group,
+			})
+			continue
+		}
+
 		// Get current weight for this lift
-		currentWeight, exists := userProgram.CurrentWeights[liftTemplate.LiftName]
+		currentWeight, exists := userProgram.CurrentWeights[liftTemplate.WeightKey()]
 		if !exists {
-			return nil, fmt.Errorf("current weight not found for lift %s", liftTemplate.LiftName)
+			return nil, fmt.Errorf("current weight not found for lift %s", liftTemplate.WeightKey())
+		}
+
+		// Bodyweight lifts have no warmups and keep their normal sets during a deload
+		if liftTemplate.Bodyweight {
+			workout.Exercises = append(workout.Exercises, models.Lift{
+				ID:          uuid.Must(uuid.NewV7()),
+				LiftName:    liftTemplate.LiftName,
+				Variant:     liftTemplate.Variant,
+				Bodyweight:  true,
+				FixedWeight: liftTemplate.FixedWeight,
+				Sets: CalculateBodyweightSets(currentWeight, userProgram.RepTargets[liftTemplate.WeightKey()],
+					liftTemplate.WorkingSets, userProgram.RoundingStepFor(liftTemplate.WeightKey())),
+				Group: liftTemplate.Group,
+			})
+			continue
+		}
+
+		step := userProgram.RoundingStepFor(liftTemplate.WeightKey())
+		warmup := WarmupContext{
+			Templates: MergeWarmupTemplates(liftTemplate.WarmupSets, user.WarmupPercentages[liftTemplate.LiftName]),
+			Unit:      userProgram.Unit,
+			Step:      step,
+			BarWeight: config.BarWeightFor(liftTemplate.LiftName, userProgram.Unit),
+			Plate:     config.HeaviestPlate(userProgram.Unit),
 		}
 
-		// Calculate warmup sets (may be empty if weight < 85 lbs)
-		warmupSets := CalculateWarmupSets(currentWeight, liftTemplate.WarmupSets)
+		var warmupSets, workingSets []models.Set
+		if userProgram.Deload != nil {
+			// Deload sessions warm up to the lighter weight and replace the working sets
+			deloadWeight := DeloadWeight(currentWeight, userProgram.Deload, step)
+			warmupSets = warmupStrategy.WarmupSets(deloadWeight, warmup)
+			workingSets = CalculateDeloadSets(currentWeight, userProgram.Deload, step)
+		} else {
+			// Calculate working sets
+			workingSets = CalculateWorkingSets(currentWeight, liftTemplate.WorkingSets, step)
+
+			// Calculate warmup sets (may be empty for light weights). Lifts
+			// with sets of their training max warm up to the heaviest set.
+			warmupWeight := currentWeight
+			if liftTemplate.UsesTrainingMax() {
+				ApplyTrainingMax(workingSets, liftTemplate.WorkingSets, userProgram.TrainingMaxFor(liftTemplate.WeightKey()), step)
+				warmupWeight = heaviestSet(workingSets)
+			}
+			warmupSets = warmupStrategy.WarmupSets(warmupWeight, warmup)
 
-		// Calculate working sets
-		workingSets := CalculateWorkingSets(currentWeight, liftTemplate.WorkingSets)
+			// Add the feeler single before the AMRAP set once the weight is heavy enough
+			if feeler, ok := CalculateFeelerSet(currentWeight, liftTemplate.Feeler, step); ok {
+				workingSets = insertBeforeAMRAP(workingSets, feeler)
+			}
+		}
 
 		// Combine all sets and adjust order for working sets
 		allSets := make([]models.Set, 0, len(warmupSets)+len(workingSets))
@@ -113,9 +312,12 @@ func CalculateNextWorkout(user *models.User, program *models.Program) (*models.W
 
 		// Create Lift with all sets
 		lift := models.Lift{
-			ID:       uuid.Must(uuid.NewV7()),
-			LiftName: liftTemplate.LiftName,
-			Sets:     allSets,
+			ID:          uuid.Must(uuid.NewV7()),
+			LiftName:    liftTemplate.LiftName,
+			Variant:     liftTemplate.Variant,
+			FixedWeight: liftTemplate.FixedWeight,
+			Sets:        allSets,
+			Group:       liftTemplate.Group,
 		}
 
 		workout.Exercises = append(workout.Exercises, lift)
@@ -124,6 +326,31 @@ func CalculateNextWorkout(user *models.User, program *models.Program) (*models.W
 	return workout, nil
 }
 
+// ValidateWorkout checks that a calculated workout can be logged before any reps
+// are collected: every lift that progresses needs exactly one AMRAP set to base
+// its progression on. Deload sessions have no AMRAP sets and don't progress.
+func ValidateWorkout(workout *models.Workout, deloading bool) error {
+	if deloading {
+		return nil
+	}
+	for _, lift := range workout.Exercises {
+		if lift.Optional || lift.FixedWeight {
+			continue
+		}
+		amrapSets := 0
+		for _, set := range lift.Sets {
+			if set.Type == models.AMRAPSet {
+				amrapSets++
+			}
+		}
+		if amrapSets != 1 {
+			return fmt.Errorf("%s has %d AMRAP sets, but lifts that progress need exactly one; fix the program template or mark the lift fixed_weight",
+				lift.WeightKey(), amrapSets)
+		}
+	}
+	return nil
+}
+
 // GetAMRAPReps finds and returns the actual reps completed in the AMRAP set for a given lift
 func GetAMRAPReps(lift *models.Lift) (int, error) {
 	for _, set := range lift.Sets {
@@ -134,27 +361,90 @@ func GetAMRAPReps(lift *models.Lift) (int, error) {
 	return 0, fmt.Errorf("no AMRAP set found for lift %s", lift.LiftName)
 }
 
-// CalculateNewWeight determines the new weight based on AMRAP performance
-func CalculateNewWeight(currentWeight float64, amrapReps int, baseIncrement float64, rules *models.ProgressionRules) float64 {
+// CalculateNewWeight determines a lift's new weight based on AMRAP performance
+// using the rules' progression strategy, rounded down to the lift's rounding step
+func CalculateNewWeight(key models.LiftName, currentWeight float64, amrapReps int, baseIncrement float64, rules *models.ProgressionRules) float64 {
+	return CalculateNewWeightInRange(key, currentWeight, amrapReps, 0, baseIncrement, rules)
+}
+
+// CalculateNewWeightInRange determines the new weight of a lift whose AMRAP set
+// is a rep range topping out at maxReps, which only increases once the range is
+// reached. A maxReps of 0 is a fixed number of reps.
+func CalculateNewWeightInRange(key models.LiftName, currentWeight float64, amrapReps, maxReps int, baseIncrement float64, rules *models.ProgressionRules) float64 {
+	return RoundDownTo(nextWeightInRange(currentWeight, amrapReps, maxReps, baseIncrement, rules), rules.RoundingStepFor(key))
+}
+
+// CalculateNewAddedWeight determines the new added weight of a bodyweight lift.
+// Falling short of the AMRAP set's target drops two increments, since a percentage
+// of added weight means nothing at bodyweight or with assistance; otherwise it
+// progresses like a barbell lift.
+func CalculateNewAddedWeight(key models.LiftName, currentWeight float64, amrapReps, targetReps int, baseIncrement float64, rules *models.ProgressionRules) float64 {
 	var newWeight float64
-	
-	if amrapReps < 5 {
-		// Deload - reduce weight by deload percentage
-		newWeight = currentWeight * rules.DeloadPercentage
-	} else if amrapReps >= rules.DoubleThreshold {
-		// Double progression - add double the base increment
-		newWeight = currentWeight + (baseIncrement * 2)
-	} else {
-		// Normal progression - add base increment
+	switch {
+	case amrapReps < targetReps:
+		newWeight = currentWeight - baseIncrement*2
+	case amrapReps >= rules.DoubleThreshold:
+		newWeight = currentWeight + baseIncrement*2
+	default:
 		newWeight = currentWeight + baseIncrement
 	}
-	
-	// Round down to 2.5 lbs
-	return RoundDown2_5(newWeight)
+	return RoundDownTo(newWeight, rules.RoundingStepFor(key))
 }
 
-// CalculateProgression calculates new weights for all lifts based on workout performance
-func CalculateProgression(workout *models.Workout, currentWeights map[models.LiftName]float64, rules *models.ProgressionRules) (map[models.LiftName]float64, error) {
+// CalculateRepTargets returns the rep targets after a workout for bodyweight lifts
+// that progress by reps. A lift that reaches its target on the AMRAP set gains the
+// rule's reps, and one that falls short loses them, to no fewer than 1. Held lifts
+// keep their target.
+func CalculateRepTargets(workout *models.Workout, repTargets map[models.LiftName]int, rules *models.ProgressionRules, holds map[models.LiftName]int) map[models.LiftName]int {
+	newTargets := maps.Clone(repTargets)
+	for _, lift := range workout.Exercises {
+		key := lift.WeightKey()
+		increment, byReps := rules.RepIncrementFor(key)
+		if !lift.Bodyweight || lift.FixedWeight || !byReps || holds[key] > 0 {
+			continue
+		}
+		amrapReps, err := GetAMRAPReps(&lift)
+		if err != nil {
+			continue
+		}
+
+		if newTargets == nil {
+			newTargets = make(map[models.LiftName]int)
+		}
+		target := amrapTarget(&lift)
+		if amrapReps >= target {
+			newTargets[key] = target + increment
+		} else {
+			newTargets[key] = max(1, target-increment)
+		}
+	}
+	return newTargets
+}
+
+// amrapTarget returns the target reps of a lift's AMRAP set, or 0 if it has none
+func amrapTarget(lift *models.Lift) int {
+	for _, set := range lift.Sets {
+		if set.Type == models.AMRAPSet {
+			return set.TargetReps
+		}
+	}
+	return 0
+}
+
+// amrapMaxReps returns the top of the rep range of a lift's AMRAP set, or 0 if
+// it has none
+func amrapMaxReps(lift *models.Lift) int {
+	for _, set := range lift.Sets {
+		if set.Type == models.AMRAPSet {
+			return set.MaxReps
+		}
+	}
+	return 0
+}
+
+// CalculateProgression calculates new weights for all lifts based on workout performance.
+// Lifts with remaining sessions in holds keep their current weight.
+func CalculateProgression(workout *models.Workout, currentWeights map[models.LiftName]float64, rules *models.ProgressionRules, holds map[models.LiftName]int) (map[models.LiftName]float64, error) {
 	newWeights := make(map[models.LiftName]float64)
 	
 	// Copy current weights first
@@ -164,27 +454,141 @@ func CalculateProgression(workout *models.Workout, currentWeights map[models.Lif
 	
 	// Update weights for lifts that were performed in this workout
 	for _, lift := range workout.Exercises {
+		// Accessories, fixed-weight, and substituted lifts don't progress
+		if lift.Optional || lift.FixedWeight || lift.SubstitutedFor != "" {
+			continue
+		}
+		key := lift.WeightKey()
+
+		// Bodyweight lifts that progress by reps keep their added weight
+		if _, byReps := rules.RepIncrementFor(key); byReps && lift.Bodyweight {
+			continue
+		}
+
 		// Get AMRAP reps for this lift
 		amrapReps, err := GetAMRAPReps(&lift)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get AMRAP reps for %s: %w", lift.LiftName, err)
+			return nil, fmt.Errorf("failed to get AMRAP reps for %s: %w", key, err)
 		}
 		
-		// Get base increment for this lift
-		baseIncrement, exists := rules.IncreaseRules[lift.LiftName]
+		// Get base increment for this lift (variants fall back to the base lift's rule)
+		baseIncrement, exists := rules.IncrementFor(key)
 		if !exists {
-			return nil, fmt.Errorf("no progression rule found for lift %s", lift.LiftName)
+			return nil, fmt.Errorf("no progression rule found for lift %s", key)
 		}
 		
 		// Get current weight
-		currentWeight, exists := currentWeights[lift.LiftName]
+		currentWeight, exists := currentWeights[key]
 		if !exists {
-			return nil, fmt.Errorf("current weight not found for lift %s", lift.LiftName)
+			return nil, fmt.Errorf("current weight not found for lift %s", key)
 		}
-		
+
+		// Held lifts keep their weight until the hold runs out
+		if holds[key] > 0 {
+			continue
+		}
+
 		// Calculate new weight
-		newWeights[lift.LiftName] = CalculateNewWeight(currentWeight, amrapReps, baseIncrement, rules)
+		if lift.Bodyweight {
+			newWeights[key] = CalculateNewAddedWeight(key, currentWeight, amrapReps, amrapTarget(&lift), baseIncrement, rules)
+		} else {
+			newWeights[key] = CalculateNewWeightInRange(key, currentWeight, amrapReps, amrapMaxReps(&lift), baseIncrement, rules)
+		}
 	}
 	
 	return newWeights, nil
 }
+
+// HeldLifts returns the lifts performed in the workout whose weights are held,
+// in the order they were performed
+func HeldLifts(workout *models.Workout, holds map[models.LiftName]int) []models.LiftName {
+	var held []models.LiftName
+	for _, lift := range workout.Exercises {
+		if key := lift.WeightKey(); holds[key] > 0 && !slices.Contains(held, key) {
+			held = append(held, key)
+		}
+	}
+	return held
+}
+
+// AdvanceHolds counts down the held sessions for each lift performed in the workout,
+// removing holds that have run out
+func AdvanceHolds(workout *models.Workout, holds map[models.LiftName]int) {
+	for _, lift := range workout.Exercises {
+		key := lift.WeightKey()
+		remaining, held := holds[key]
+		if !held {
+			continue
+		}
+		if remaining <= 1 {
+			delete(holds, key)
+		} else {
+			holds[key] = remaining - 1
+		}
+	}
+}
+
+// UpdateDeloadStreaks records the weight changes of each lift performed in the
+// workout: a deload extends the lift's streak, and getting past the heaviest
+// weight it deloaded from ends it. It returns the updated streaks.
+func UpdateDeloadStreaks(workout *models.Workout, streaks map[models.LiftName]models.DeloadStreak, oldWeights, newWeights map[models.LiftName]float64) map[models.LiftName]models.DeloadStreak {
+	for _, lift := range workout.Exercises {
+		key := lift.WeightKey()
+		oldWeight, hadOld := oldWeights[key]
+		newWeight, hasNew := newWeights[key]
+		if !hadOld || !hasNew {
+			continue
+		}
+
+		streak, onStreak := streaks[key]
+		switch {
+		case newWeight < oldWeight:
+			if streaks == nil {
+				streaks = make(map[models.LiftName]models.DeloadStreak)
+			}
+			streaks[key] = models.DeloadStreak{Deloads: streak.Deloads + 1, Weight: max(streak.Weight, oldWeight)}
+		case onStreak && newWeight > streak.Weight:
+			delete(streaks, key)
+		}
+	}
+	return streaks
+}
+
+// NextDay returns the program day following currentDay, wrapping back to day 1
+// after the last day of the program
+func NextDay(currentDay, totalDays int) int {
+	nextDay := currentDay + 1
+	if nextDay > totalDays {
+		nextDay = 1
+	}
+	return nextDay
+}
+
+// ApplyWorkout applies a completed workout to a UserProgram: it updates current
+// weights, and the rep targets of bodyweight lifts that progress by reps, based on
+// AMRAP performance, records and counts down lift holds, tracks deload streaks, and
+// advances CurrentDay. During a deload weights and holds are left alone and the deload
+// is counted down instead.
+// The UserProgram is left unchanged if progression cannot be calculated.
+func ApplyWorkout(userProgram *models.UserProgram, completed *models.Workout, program *models.Program) error {
+	if userProgram.Deload != nil {
+		advanceDeload(userProgram)
+		userProgram.CurrentDay = NextDay(userProgram.CurrentDay, len(program.Workouts))
+		return nil
+	}
+
+	rules := program.ProgressionRules.ForUserProgram(userProgram)
+	newWeights, err := CalculateProgression(completed, userProgram.CurrentWeights, rules, userProgram.Holds)
+	if err != nil {
+		return fmt.Errorf("failed to calculate progression: %w", err)
+	}
+
+	userProgram.DeloadStreaks = UpdateDeloadStreaks(completed, userProgram.DeloadStreaks, userProgram.CurrentWeights, newWeights)
+	userProgram.CurrentWeights = newWeights
+	userProgram.RepTargets = CalculateRepTargets(completed, userProgram.RepTargets, rules, userProgram.Holds)
+	completed.Held = HeldLifts(completed, userProgram.Holds)
+	AdvanceHolds(completed, userProgram.Holds)
+	userProgram.CurrentDay = NextDay(userProgram.CurrentDay, len(program.Workouts))
+
+	return nil
+}
@@ -92,17 +92,25 @@ func TestCalculateWarmupSets(t *testing.T) {
 	}
 
 	t.Run("skip warmup for weight less than 85 lbs", func(t *testing.T) {
-		result := CalculateWarmupSets(80.0, warmupTemplates)
+		result := CalculateWarmupSets(80.0, warmupTemplates, models.Pounds)
 		assert.Empty(t, result)
 	})
 
 	t.Run("skip warmup for exactly 85 lbs", func(t *testing.T) {
-		result := CalculateWarmupSets(85.0, warmupTemplates)
+		result := CalculateWarmupSets(85.0, warmupTemplates, models.Pounds)
 		assert.Empty(t, result)
 	})
 
+	t.Run("lighter bar for the empty bar set", func(t *testing.T) {
+		result := CalculateWarmupSetsWithBar(100.0, warmupTemplates, models.Pounds, 35, 2.5)
+
+		require.Len(t, result, 4)
+		assert.Equal(t, 35.0, result[0].Weight)
+		assert.Equal(t, 55.0, result[1].Weight)
+	})
+
 	t.Run("calculate warmup for 100 lbs working weight", func(t *testing.T) {
-		result := CalculateWarmupSets(100.0, warmupTemplates)
+		result := CalculateWarmupSets(100.0, warmupTemplates, models.Pounds)
 
 		require.Len(t, result, 4)
 
@@ -133,7 +141,7 @@ func TestCalculateWarmupSets(t *testing.T) {
 	})
 
 	t.Run("calculate warmup with rounding for 97.5 lbs working weight", func(t *testing.T) {
-		result := CalculateWarmupSets(97.5, warmupTemplates)
+		result := CalculateWarmupSets(97.5, warmupTemplates, models.Pounds)
 
 		require.Len(t, result, 4)
 
@@ -151,7 +159,7 @@ func TestCalculateWarmupSets(t *testing.T) {
 	})
 
 	t.Run("empty templates returns empty slice", func(t *testing.T) {
-		result := CalculateWarmupSets(100.0, []models.SetTemplate{})
+		result := CalculateWarmupSets(100.0, []models.SetTemplate{}, models.Pounds)
 		assert.Empty(t, result)
 	})
 }
@@ -165,7 +173,7 @@ func TestCalculateWorkingSets(t *testing.T) {
 	}
 
 	t.Run("calculate working sets for 135 lbs", func(t *testing.T) {
-		result := CalculateWorkingSets(135.0, workingTemplates)
+		result := CalculateWorkingSets(135.0, workingTemplates, 2.5)
 
 		require.Len(t, result, 3)
 
@@ -190,7 +198,7 @@ func TestCalculateWorkingSets(t *testing.T) {
 	})
 
 	t.Run("calculate working sets with rounding for 42.7 lbs", func(t *testing.T) {
-		result := CalculateWorkingSets(42.7, workingTemplates)
+		result := CalculateWorkingSets(42.7, workingTemplates, 2.5)
 
 		require.Len(t, result, 3)
 
@@ -208,7 +216,7 @@ func TestCalculateWorkingSets(t *testing.T) {
 	})
 
 	t.Run("handle weight less than 45 lbs", func(t *testing.T) {
-		result := CalculateWorkingSets(30.0, workingTemplates)
+		result := CalculateWorkingSets(30.0, workingTemplates, 2.5)
 
 		require.Len(t, result, 3)
 
@@ -219,7 +227,7 @@ func TestCalculateWorkingSets(t *testing.T) {
 	})
 
 	t.Run("empty templates returns empty slice", func(t *testing.T) {
-		result := CalculateWorkingSets(135.0, []models.SetTemplate{})
+		result := CalculateWorkingSets(135.0, []models.SetTemplate{}, 2.5)
 		assert.Empty(t, result)
 	})
 }
@@ -490,7 +498,247 @@ func TestCalculateNextWorkout_OrderValues(t *testing.T) {
 	}
 }
 
+func TestCalculateNextWorkout_ThreeDayProgram(t *testing.T) {
+	weights := map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+		models.BenchPress:    125.0,
+		models.Deadlift:      185.0,
+	}
+
+	// Two-template program alternates A/B regardless of the day counter
+	for currentDay, expectedDay := range map[int]int{1: 1, 2: 2, 3: 1, 4: 2} {
+		user := createTestUser(currentDay, weights)
+
+		result, err := CalculateNextWorkout(user, program.GreyskullLP3Day)
+		require.NoError(t, err)
+		assert.Equal(t, expectedDay, result.Day)
+	}
+
+	user := createTestUser(2, weights)
+	result, err := CalculateNextWorkout(user, program.GreyskullLP3Day)
+	require.NoError(t, err)
+
+	deadlift := result.Exercises[1]
+	assert.Equal(t, models.Deadlift, deadlift.LiftName)
+	require.Len(t, deadlift.Sets, 5) // 4 warmups + 1 AMRAP
+	assert.Equal(t, models.AMRAPSet, deadlift.Sets[4].Type)
+}
+
+func TestMergeWarmupTemplates(t *testing.T) {
+	templates := program.GreyskullLP.Workouts[0].Lifts[0].WarmupSets
+
+	t.Run("no override", func(t *testing.T) {
+		assert.Equal(t, templates, MergeWarmupTemplates(templates, nil))
+	})
+
+	t.Run("same number of sets", func(t *testing.T) {
+		merged := MergeWarmupTemplates(templates, models.WarmupPercentages{0.6, 0.75, 0.9})
+		assert.Equal(t, []models.SetTemplate{
+			{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+			{Reps: 4, WeightPercentage: 0.6, Type: models.WarmupSet},
+			{Reps: 3, WeightPercentage: 0.75, Type: models.WarmupSet},
+			{Reps: 2, WeightPercentage: 0.9, Type: models.WarmupSet},
+		}, merged)
+	})
+
+	t.Run("more sets reuse the heaviest set's reps", func(t *testing.T) {
+		merged := MergeWarmupTemplates(templates, models.WarmupPercentages{0.4, 0.55, 0.7, 0.85})
+		require.Len(t, merged, 5)
+		assert.Equal(t, 2, merged[4].Reps)
+		assert.Equal(t, 0.85, merged[4].WeightPercentage)
+	})
+
+	t.Run("fewer sets", func(t *testing.T) {
+		merged := MergeWarmupTemplates(templates, models.WarmupPercentages{0.7})
+		assert.Equal(t, []models.SetTemplate{
+			{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+			{Reps: 4, WeightPercentage: 0.7, Type: models.WarmupSet},
+		}, merged)
+	})
+
+	t.Run("lift without warmups", func(t *testing.T) {
+		assert.Empty(t, MergeWarmupTemplates(nil, models.WarmupPercentages{0.6}))
+	})
+}
+
+func TestCalculateNextWorkout_CustomWarmups(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+	})
+	user.WarmupPercentages = map[models.LiftName]models.WarmupPercentages{
+		models.Squat: {0.6, 0.75, 0.9},
+	}
+
+	result, err := CalculateNextWorkout(user, program.GreyskullLP)
+	require.NoError(t, err)
+
+	weights := func(lift models.Lift) []float64 {
+		var w []float64
+		for _, set := range lift.Sets {
+			if set.Type == models.WarmupSet {
+				w = append(w, set.Weight)
+			}
+		}
+		return w
+	}
+	assert.Equal(t, []float64{45, 50, 65, 80}, weights(result.Exercises[0]), "overhead press keeps the program's warmups")
+	assert.Equal(t, []float64{45, 80, 100, 120}, weights(result.Exercises[1]))
+}
+
+func TestCalculateFeelerSet(t *testing.T) {
+	tpl := &models.FeelerTemplate{WeightPercentage: 0.95, MinWeight: 200.0}
+
+	t.Run("no feeler template", func(t *testing.T) {
+		_, ok := CalculateFeelerSet(250.0, nil, 2.5)
+		assert.False(t, ok)
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		_, ok := CalculateFeelerSet(195.0, tpl, 2.5)
+		assert.False(t, ok)
+	})
+
+	t.Run("at threshold", func(t *testing.T) {
+		set, ok := CalculateFeelerSet(200.0, tpl, 2.5)
+		require.True(t, ok)
+		assert.Equal(t, 190.0, set.Weight)
+		assert.Equal(t, 1, set.TargetReps)
+		assert.Equal(t, models.FeelerSet, set.Type)
+		assert.NotEqual(t, uuid.Nil, set.ID)
+	})
+
+	t.Run("rounds down", func(t *testing.T) {
+		set, ok := CalculateFeelerSet(255.0, tpl, 2.5)
+		require.True(t, ok)
+		assert.Equal(t, 240.0, set.Weight) // 95% of 255 = 242.25 → 240.0
+	})
+}
+
+func TestCalculateNextWorkout_FeelerSingle(t *testing.T) {
+	prog := &models.Program{
+		ID: uuid.New(),
+		Workouts: []models.WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []models.LiftTemplate{
+					{
+						LiftName: models.Squat,
+						WorkingSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+							{Reps: 5, WeightPercentage: 1.0, Type: models.WorkingSet},
+							{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+						},
+						Feeler: &models.FeelerTemplate{WeightPercentage: 0.95, MinWeight: 200.0},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("heavy enough adds single before AMRAP", func(t *testing.T) {
+		user := createTestUser(1, map[models.LiftName]float64{models.Squat: 250.0})
+
+		result, err := CalculateNextWorkout(user, prog)
+		require.NoError(t, err)
+
+		sets := result.Exercises[0].Sets
+		require.Len(t, sets, 4)
+		assert.Equal(t, models.WorkingSet, sets[1].Type)
+		assert.Equal(t, models.FeelerSet, sets[2].Type)
+		assert.Equal(t, 237.5, sets[2].Weight)
+		assert.Equal(t, models.AMRAPSet, sets[3].Type)
+		for i, set := range sets {
+			assert.Equal(t, i+1, set.Order)
+		}
+	})
+
+	t.Run("too light skips single", func(t *testing.T) {
+		user := createTestUser(1, map[models.LiftName]float64{models.Squat: 150.0})
+
+		result, err := CalculateNextWorkout(user, prog)
+		require.NoError(t, err)
+
+		sets := result.Exercises[0].Sets
+		require.Len(t, sets, 3)
+		for _, set := range sets {
+			assert.NotEqual(t, models.FeelerSet, set.Type)
+		}
+	})
+}
+
 // Helper function to create a test user with a program
+func TestCalculateNextWorkout_TrainingMax(t *testing.T) {
+	prog := &models.Program{
+		ID: uuid.New(),
+		Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{
+			{
+				LiftName: models.Squat,
+				WarmupSets: []models.SetTemplate{
+					{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+					{Reps: 3, WeightPercentage: 0.5, Type: models.WarmupSet},
+				},
+				WorkingSets: []models.SetTemplate{
+					{Reps: 5, WeightPercentage: 0.65, Type: models.WorkingSet, OfTrainingMax: true},
+					{Reps: 5, WeightPercentage: 0.75, Type: models.WorkingSet, OfTrainingMax: true},
+					{Reps: 5, WeightPercentage: 0.85, Type: models.AMRAPSet, OfTrainingMax: true},
+				},
+			},
+		}}},
+	}
+
+	user := createTestUser(1, map[models.LiftName]float64{models.Squat: 225.0})
+	user.Programs[user.CurrentProgram].TrainingMaxes = map[models.LiftName]float64{models.Squat: 300}
+
+	result, err := CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+
+	sets := result.Exercises[0].Sets
+	require.Len(t, sets, 5)
+	assert.Equal(t, []float64{45, 127.5, 195, 225, 255}, []float64{sets[0].Weight, sets[1].Weight, sets[2].Weight, sets[3].Weight, sets[4].Weight},
+		"warmups ramp to the heaviest set, and working sets are percentages of the training max")
+	assert.Equal(t, models.AMRAPSet, sets[4].Type)
+
+	// Without a training max, the current weight is used
+	user.Programs[user.CurrentProgram].TrainingMaxes = nil
+	result, err = CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	assert.Equal(t, 190.0, result.Exercises[0].Sets[4].Weight)
+}
+
+func TestCalculateNextWorkout_RoundingSteps(t *testing.T) {
+	prog := &models.Program{
+		ID: uuid.New(),
+		Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{
+			{
+				LiftName: models.OverheadPress,
+				WarmupSets: []models.SetTemplate{
+					{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+					{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+				},
+				WorkingSets: []models.SetTemplate{
+					{Reps: 5, Type: models.WorkingSet},
+					{Reps: 5, Type: models.AMRAPSet},
+				},
+			},
+		}}},
+	}
+
+	user := createTestUser(1, map[models.LiftName]float64{models.OverheadPress: 103.75})
+	result, err := CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	sets := result.Exercises[0].Sets
+	assert.Equal(t, []float64{45, 55, 102.5, 102.5}, []float64{sets[0].Weight, sets[1].Weight, sets[2].Weight, sets[3].Weight},
+		"without microplates weights round down to 2.5 lbs")
+
+	user.Programs[user.CurrentProgram].RoundingSteps = map[models.LiftName]float64{models.OverheadPress: 1.25}
+	result, err = CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	sets = result.Exercises[0].Sets
+	assert.Equal(t, []float64{45, 56.25, 103.75, 103.75}, []float64{sets[0].Weight, sets[1].Weight, sets[2].Weight, sets[3].Weight})
+}
+
 func createTestUser(currentDay int, weights map[models.LiftName]float64) *models.User {
 	userProgram := &models.UserProgram{
 		ID:              uuid.New(),
@@ -514,3 +762,86 @@ func createTestUser(currentDay int, weights map[models.LiftName]float64) *models
 	return user
 }
 
+func TestCalculateNextWorkout_Variants(t *testing.T) {
+	prog := &models.Program{
+		ID: uuid.New(),
+		Workouts: []models.WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []models.LiftTemplate{
+					{
+						LiftName: models.Squat,
+						Variant:  "SSB",
+						WorkingSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.Squat:                           225.0,
+		models.VariantKey(models.Squat, "SSB"): 185.0,
+	})
+
+	result, err := CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+
+	lift := result.Exercises[0]
+	assert.Equal(t, models.Squat, lift.LiftName)
+	assert.Equal(t, "SSB", lift.Variant)
+	assert.Equal(t, 185.0, lift.Sets[0].Weight, "variant uses its own weight track")
+
+	t.Run("missing variant weight", func(t *testing.T) {
+		user := createTestUser(1, map[models.LiftName]float64{models.Squat: 225.0})
+
+		_, err := CalculateNextWorkout(user, prog)
+		assert.ErrorContains(t, err, "current weight not found for lift Squat:SSB")
+	})
+}
+
+func TestCalculateNextWorkout_OptionalLift(t *testing.T) {
+	prog := &models.Program{
+		ID: uuid.New(),
+		Workouts: []models.WorkoutTemplate{
+			{
+				Day: 1,
+				Lifts: []models.LiftTemplate{
+					{
+						LiftName: models.Squat,
+						WorkingSets: []models.SetTemplate{
+							{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet},
+						},
+					},
+					{
+						LiftName: "Chin-ups",
+						Optional: true,
+						WorkingSets: []models.SetTemplate{
+							{Reps: 8, Type: models.WorkingSet},
+							{Reps: 8, Type: models.AMRAPSet},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// No weight is needed for the accessory
+	user := createTestUser(1, map[models.LiftName]float64{models.Squat: 225.0})
+
+	result, err := CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	require.Len(t, result.Exercises, 2)
+
+	chinups := result.Exercises[1]
+	assert.True(t, chinups.Optional)
+	require.Len(t, chinups.Sets, 2)
+	for i, set := range chinups.Sets {
+		assert.Equal(t, 0.0, set.Weight)
+		assert.Equal(t, 8, set.TargetReps)
+		assert.Equal(t, i+1, set.Order)
+	}
+	assert.Equal(t, models.AMRAPSet, chinups.Sets[1].Type)
+}
@@ -0,0 +1,52 @@
+package workout
+
+import (
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// CalendarEntry is a date on a UserProgram's training schedule
+type CalendarEntry struct {
+	Date time.Time `json:"date"`
+	// Day is the program day that falls on Date. Missed dates have none.
+	Day    int  `json:"day,omitempty"`
+	Missed bool `json:"missed"`
+}
+
+// Calendar lays out a UserProgram's training days: the scheduled dates missed
+// since it was last trained, other than those it was paused on, then the
+// program day falling on each scheduled date from today through the given
+// number of weeks. Today counts as upcoming unless a workout has already been
+// logged today. Dates are in now's time zone.
+func Calendar(userProgram *models.UserProgram, lastTrained time.Time, totalDays int, now time.Time, weeks int) []CalendarEntry {
+	trainingDays := userProgram.TrainingDaysOrDefault()
+	today := startOfDay(now)
+	last := startOfDay(lastTrained.In(now.Location()))
+
+	var entries []CalendarEntry
+	for date := last.AddDate(0, 0, 1); date.Before(today); date = date.AddDate(0, 0, 1) {
+		if slices.Contains(trainingDays, date.Weekday()) && !userProgram.PausedOn(date) {
+			entries = append(entries, CalendarEntry{Date: date, Missed: true})
+		}
+	}
+
+	start := today
+	if !last.Before(today) {
+		start = today.AddDate(0, 0, 1)
+	}
+	end := today.AddDate(0, 0, weeks*7)
+	day := userProgram.CurrentDay
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		if slices.Contains(trainingDays, date.Weekday()) {
+			entries = append(entries, CalendarEntry{Date: date, Day: day})
+			day = NextDay(day, totalDays)
+		}
+	}
+	return entries
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
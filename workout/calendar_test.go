@@ -0,0 +1,52 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2024, 5, day, 0, 0, 0, 0, time.UTC) }
+	userProgram := &models.UserProgram{CurrentDay: 5}
+	// Wednesday 2024-05-08; last trained the Friday before last
+	now := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	lastTrained := time.Date(2024, 4, 26, 18, 0, 0, 0, time.UTC)
+
+	entries := Calendar(userProgram, lastTrained, 6, now, 1)
+
+	assert.Equal(t, []CalendarEntry{
+		{Date: time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), Missed: true},
+		{Date: date(1), Missed: true},
+		{Date: date(3), Missed: true},
+		{Date: date(6), Missed: true},
+		{Date: date(8), Day: 5},
+		{Date: date(10), Day: 6},
+		{Date: date(13), Day: 1},
+	}, entries)
+
+	t.Run("trained today", func(t *testing.T) {
+		userProgram := &models.UserProgram{CurrentDay: 2, TrainingDays: []time.Weekday{time.Tuesday, time.Wednesday}}
+
+		entries := Calendar(userProgram, now.Add(-time.Hour), 6, now, 1)
+
+		assert.Equal(t, []CalendarEntry{{Date: date(14), Day: 2}}, entries)
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		userProgram := &models.UserProgram{CurrentDay: 5, Pauses: []models.Pause{
+			{StartedAt: time.Date(2024, 4, 28, 9, 0, 0, 0, time.UTC), EndedAt: time.Date(2024, 5, 3, 7, 0, 0, 0, time.UTC)},
+		}}
+
+		entries := Calendar(userProgram, lastTrained, 6, now, 1)
+
+		assert.Equal(t, []CalendarEntry{
+			{Date: date(6), Missed: true},
+			{Date: date(8), Day: 5},
+			{Date: date(10), Day: 6},
+			{Date: date(13), Day: 1},
+		}, entries, "dates from the start of the pause through its end aren't missed")
+	})
+}
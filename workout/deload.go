@@ -0,0 +1,76 @@
+package workout
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Defaults for a deload week: three sessions of 2x5 at 80% of current weights
+const (
+	DefaultDeloadPercentage = 0.8
+	DefaultDeloadSets       = 2
+	DefaultDeloadReps       = 5
+	DeloadWeekSessions      = 3
+)
+
+// NewDeloadWeek returns a deload plan covering one week of training
+func NewDeloadWeek(percentage float64, sets, reps int) (*models.DeloadPlan, error) {
+	if percentage <= 0 || percentage >= 1 {
+		return nil, fmt.Errorf("deload percentage must be between 0 and 100, got: %g", percentage*100)
+	}
+	if sets <= 0 {
+		return nil, fmt.Errorf("deload sets must be positive, got: %d", sets)
+	}
+	if reps <= 0 {
+		return nil, fmt.Errorf("deload reps must be positive, got: %d", reps)
+	}
+	return &models.DeloadPlan{
+		Percentage:        percentage,
+		Sets:              sets,
+		Reps:              reps,
+		SessionsRemaining: DeloadWeekSessions,
+	}, nil
+}
+
+// DeloadWeight returns the weight used for a lift during a deload, rounded down
+// to the lift's rounding step
+func DeloadWeight(weight float64, plan *models.DeloadPlan, step float64) float64 {
+	return RoundDownTo(weight*plan.Percentage, step)
+}
+
+// CalculateDeloadSets returns the straight working sets of a deload session.
+// Deload sessions have no AMRAP set, so they never change a lift's weight.
+func CalculateDeloadSets(weight float64, plan *models.DeloadPlan, step float64) []models.Set {
+	sets := make([]models.Set, plan.Sets)
+	for i := range sets {
+		sets[i] = models.Set{
+			ID:         uuid.Must(uuid.NewV7()),
+			Weight:     DeloadWeight(weight, plan, step),
+			TargetReps: plan.Reps,
+			Type:       models.WorkingSet,
+			Order:      i + 1,
+		}
+	}
+	return sets
+}
+
+// advanceDeload counts down a UserProgram's deload, removing it once every
+// session has been completed
+func advanceDeload(userProgram *models.UserProgram) {
+	userProgram.Deload.SessionsRemaining--
+	if userProgram.Deload.SessionsRemaining <= 0 {
+		userProgram.Deload = nil
+	}
+}
+
+// DeloadWeights returns the deload weight of every lift in a UserProgram's
+// current weights
+func DeloadWeights(userProgram *models.UserProgram, plan *models.DeloadPlan) map[models.LiftName]float64 {
+	weights := make(map[models.LiftName]float64, len(userProgram.CurrentWeights))
+	for key, weight := range userProgram.CurrentWeights {
+		weights[key] = DeloadWeight(weight, plan, userProgram.RoundingStepFor(key))
+	}
+	return weights
+}
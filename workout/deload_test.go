@@ -0,0 +1,59 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeloadWeek(t *testing.T) {
+	plan, err := NewDeloadWeek(0.8, 2, 5)
+	require.NoError(t, err)
+	assert.Equal(t, &models.DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5, SessionsRemaining: DeloadWeekSessions}, plan)
+
+	_, err = NewDeloadWeek(0, 2, 5)
+	assert.ErrorContains(t, err, "deload percentage must be between 0 and 100")
+	_, err = NewDeloadWeek(0.8, 2, 0)
+	assert.EqualError(t, err, "deload reps must be positive, got: 0")
+}
+
+func TestCalculateDeloadSets(t *testing.T) {
+	sets := CalculateDeloadSets(135, &models.DeloadPlan{Percentage: 0.8, Sets: 3, Reps: 5}, 2.5)
+
+	require.Len(t, sets, 3)
+	for i, set := range sets {
+		assert.Equal(t, 107.5, set.Weight)
+		assert.Equal(t, 5, set.TargetReps)
+		assert.Equal(t, models.WorkingSet, set.Type)
+		assert.Equal(t, i+1, set.Order)
+	}
+}
+
+func TestApplyWorkout_Deload(t *testing.T) {
+	program := &models.Program{
+		Workouts:         make([]models.WorkoutTemplate, 2),
+		ProgressionRules: models.ProgressionRules{IncreaseRules: map[models.LiftName]float64{models.Squat: 5}},
+	}
+	userProgram := &models.UserProgram{
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 135},
+		CurrentDay:     2,
+		Holds:          map[models.LiftName]int{models.Squat: 1},
+		Deload:         &models.DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5, SessionsRemaining: 2},
+	}
+	completed := &models.Workout{Exercises: []models.Lift{{
+		LiftName: models.Squat,
+		Sets:     CalculateDeloadSets(135, userProgram.Deload, 2.5),
+	}}}
+
+	require.NoError(t, ApplyWorkout(userProgram, completed, program))
+	assert.Equal(t, 1, userProgram.CurrentDay)
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Equal(t, 1, userProgram.Holds[models.Squat])
+	assert.Equal(t, 1, userProgram.Deload.SessionsRemaining)
+
+	require.NoError(t, ApplyWorkout(userProgram, completed, program))
+	assert.Equal(t, 2, userProgram.CurrentDay)
+	assert.Nil(t, userProgram.Deload)
+}
@@ -0,0 +1,48 @@
+package workout
+
+import "github.com/mikowitz/greyskull/models"
+
+// LongPauseDays is the shortest pause after which easing back in at lighter
+// weights is suggested
+const LongPauseDays = 14
+
+// ResumePercentage returns the fraction of their weights a lifter is suggested
+// to resume at after a pause of the given number of days: all of it after a
+// short pause, 90% after two weeks away, and 80% after four
+func ResumePercentage(pausedDays int) float64 {
+	switch {
+	case pausedDays >= 2*LongPauseDays:
+		return 0.8
+	case pausedDays >= LongPauseDays:
+		return 0.9
+	default:
+		return 1
+	}
+}
+
+// LayoffMonthDays is the length of a month of time off when suggesting how far
+// to reset weights after a layoff
+const LayoffMonthDays = 30
+
+// LayoffPercentage returns the fraction of their weights a lifter is suggested
+// to reset to after the given number of days off: 10% less for every full
+// month away, but never below half
+func LayoffPercentage(daysOff int) float64 {
+	months := daysOff / LayoffMonthDays
+	return max(float64(10-months)/10, 0.5)
+}
+
+// ResumeWeights returns userProgram's current weights reduced to percentage
+// and rounded down to each lift's rounding step. Assisted bodyweight lifts,
+// whose weights are negative, are left alone since reducing their assistance
+// would make them harder.
+func ResumeWeights(userProgram *models.UserProgram, percentage float64) map[models.LiftName]float64 {
+	weights := make(map[models.LiftName]float64, len(userProgram.CurrentWeights))
+	for key, weight := range userProgram.CurrentWeights {
+		if weight > 0 {
+			weight = RoundDownTo(weight*percentage, userProgram.RoundingStepFor(key))
+		}
+		weights[key] = weight
+	}
+	return weights
+}
@@ -0,0 +1,45 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResumePercentage(t *testing.T) {
+	assert.Equal(t, 1.0, ResumePercentage(0))
+	assert.Equal(t, 1.0, ResumePercentage(13))
+	assert.Equal(t, 0.9, ResumePercentage(14))
+	assert.Equal(t, 0.9, ResumePercentage(27))
+	assert.Equal(t, 0.8, ResumePercentage(28))
+	assert.Equal(t, 0.8, ResumePercentage(90))
+}
+
+func TestResumeWeights(t *testing.T) {
+	userProgram := &models.UserProgram{
+		Unit:           models.Pounds,
+		CurrentWeights: map[models.LiftName]float64{models.Squat: 225, models.OverheadPress: 97.5, "Chinup": -20},
+	}
+
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat:         202.5,
+		models.OverheadPress: 87.5,
+		"Chinup":             -20,
+	}, ResumeWeights(userProgram, 0.9))
+	assert.Equal(t, 225.0, userProgram.CurrentWeights[models.Squat], "current weights are left alone")
+
+	// Lifts loaded with microplates round to their own step
+	userProgram.RoundingSteps = map[models.LiftName]float64{models.Squat: 1.25}
+	resumed := ResumeWeights(userProgram, 0.85)
+	assert.Equal(t, 191.25, resumed[models.Squat])
+	assert.Equal(t, 82.5, resumed[models.OverheadPress])
+}
+
+func TestLayoffPercentage(t *testing.T) {
+	assert.Equal(t, 1.0, LayoffPercentage(0))
+	assert.Equal(t, 1.0, LayoffPercentage(29))
+	assert.Equal(t, 0.9, LayoffPercentage(30))
+	assert.Equal(t, 0.7, LayoffPercentage(95))
+	assert.Equal(t, 0.5, LayoffPercentage(365), "never below half")
+}
@@ -0,0 +1,273 @@
+package workout
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// PlatesPerSide returns the plates to load on each side of a bar to make up a
+// weight, heaviest first. Plates come from the config's inventory in unit, in
+// pairs, or from the unit's standard plates, as many as needed, without one.
+// Any weight per side the plates can't make up is returned as the remainder.
+func PlatesPerSide(weight, barWeight float64, config *models.Config, unit models.WeightUnit) ([]float64, float64) {
+	side := (weight - barWeight) / 2
+	var plates []float64
+	if side <= 0 {
+		return plates, 0
+	}
+
+	remaining := side
+	for _, plate := range availablePlates(config, unit) {
+		for count := plate.Count; plate.Weight <= remaining+weightTolerance && (plate.Count < 0 || count >= 2); count -= 2 {
+			plates = append(plates, plate.Weight)
+			remaining -= plate.Weight
+		}
+	}
+	if remaining < weightTolerance {
+		return plates, 0
+	}
+
+	// Loading the heaviest plates first can use up the pairs needed to make up
+	// the rest, such as 25s for 30 lbs per side with only 10s left
+	table := newLoadTable(config, unit, side)
+	if exact, ok := table.platesFor(side); ok {
+		return exact, 0
+	}
+	return plates, remaining
+}
+
+// CanLoad reports whether the plates can make up a weight exactly on a bar
+func CanLoad(weight, barWeight float64, config *models.Config, unit models.WeightUnit) bool {
+	_, remainder := PlatesPerSide(weight, barWeight, config, unit)
+	return weight >= barWeight-weightTolerance && remainder == 0
+}
+
+// NearestLoadable returns the heaviest weight at or below a weight, and the
+// lightest at or above it, that the plates can make up exactly on a bar. Either
+// is 0 if there is none: below the bar's weight, or beyond a limited inventory.
+func NearestLoadable(weight, barWeight float64, config *models.Config, unit models.WeightUnit) (below, above float64) {
+	if weight <= barWeight+weightTolerance {
+		if weight >= barWeight-weightTolerance {
+			return barWeight, barWeight
+		}
+		return 0, barWeight
+	}
+
+	// Look as far as one heaviest plate per side beyond the weight
+	side := (weight - barWeight) / 2
+	table := newLoadTable(config, unit, side+config.HeaviestPlate(unit))
+	entry := table.entry(side)
+	for i := min(entry, len(table.last)-1); i >= 0; i-- {
+		if table.last[i] >= 0 {
+			below = barWeight + 2*table.weightOf(i)
+			break
+		}
+	}
+	for i := max(entry, 0); i < len(table.last); i++ {
+		if table.last[i] >= 0 && table.weightOf(i) >= side-weightTolerance {
+			above = barWeight + 2*table.weightOf(i)
+			break
+		}
+	}
+	return below, above
+}
+
+// PlateChange returns the weight per side to add to a bar loaded to one weight
+// to make up another, negative to take off, along with the plates that make
+// it up, heaviest first. There are no plates if the available plates can't
+// make up the change exactly.
+func PlateChange(from, to float64, config *models.Config, unit models.WeightUnit) (float64, []float64) {
+	perSide := (to - from) / 2
+	plates, remainder := PlatesPerSide(math.Abs(perSide)*2, 0, config, unit)
+	if remainder > 0 {
+		return perSide, nil
+	}
+	return perSide, plates
+}
+
+// LoadWarning is a prescribed weight the plate inventory can't make up exactly
+type LoadWarning struct {
+	Lift   models.LiftName `json:"lift"`
+	Weight float64         `json:"weight"`
+	Below  float64         `json:"below,omitempty"` // Nearest loadable weight below, if any
+	Above  float64         `json:"above,omitempty"` // Nearest loadable weight above, if any
+}
+
+// Nearest returns the loadable weights closest to the prescribed weight: one,
+// or both when they are equally close
+func (w LoadWarning) Nearest() []float64 {
+	switch {
+	case w.Below == 0 && w.Above == 0:
+		return nil
+	case w.Below == 0:
+		return []float64{w.Above}
+	case w.Above == 0:
+		return []float64{w.Below}
+	}
+	down, up := w.Weight-w.Below, w.Above-w.Weight
+	switch {
+	case math.Abs(down-up) < weightTolerance:
+		return []float64{w.Below, w.Above}
+	case down < up:
+		return []float64{w.Below}
+	}
+	return []float64{w.Above}
+}
+
+// LoadWarnings returns the working weights in a workout that the config's plate
+// inventory can't load exactly, once per lift and weight. Without an inventory
+// in unit there is nothing to check against, so there are no warnings;
+// bodyweight lifts and accessories are not checked.
+func LoadWarnings(workout *models.Workout, config *models.Config, unit models.WeightUnit) []LoadWarning {
+	if !hasInventory(config, unit) {
+		return nil
+	}
+
+	var warnings []LoadWarning
+	for _, lift := range workout.Exercises {
+		if lift.Optional || lift.Bodyweight {
+			continue
+		}
+		barWeight := config.BarWeightFor(lift.LiftName, unit)
+		for _, set := range lift.Sets {
+			if set.Type == models.WarmupSet || CanLoad(set.Weight, barWeight, config, unit) {
+				continue
+			}
+			key := lift.WeightKey()
+			if slices.ContainsFunc(warnings, func(w LoadWarning) bool { return w.Lift == key && w.Weight == set.Weight }) {
+				continue
+			}
+			below, above := NearestLoadable(set.Weight, barWeight, config, unit)
+			warnings = append(warnings, LoadWarning{Lift: key, Weight: set.Weight, Below: below, Above: above})
+		}
+	}
+	return warnings
+}
+
+// weightTolerance absorbs floating point error when subtracting plates
+const weightTolerance = 1e-9
+
+// hasInventory reports whether the config lists the plates available in unit
+func hasInventory(config *models.Config, unit models.WeightUnit) bool {
+	return config != nil && len(config.Plates) > 0 && config.PlateUnit.OrDefault() == unit.OrDefault()
+}
+
+// availablePlates returns the config's plate inventory in unit, or the unit's
+// standard plates with a count of -1 for as many as needed
+func availablePlates(config *models.Config, unit models.WeightUnit) []models.PlateCount {
+	if hasInventory(config, unit) {
+		return config.Plates
+	}
+	standard := unit.StandardPlates()
+	plates := make([]models.PlateCount, len(standard))
+	for i, weight := range standard {
+		plates[i] = models.PlateCount{Weight: weight, Count: -1}
+	}
+	return plates
+}
+
+// plateScale is how finely plate weights are matched: to a thousandth of a unit
+const plateScale = 1000
+
+// loadTable holds every weight per side, up to a limit, that the available
+// plates can make up, in steps of the largest weight dividing every plate
+type loadTable struct {
+	step   int64   // Weight of one entry, in thousandths of a unit
+	plates []int64 // One plate of each pair, heaviest first, in steps
+
+	// last is, for each multiple of step, the index of the plate that first
+	// made it up, or -1 if the plates can't
+	last []int
+}
+
+// newLoadTable finds the weights per side the plates can make up, up to limit
+func newLoadTable(config *models.Config, unit models.WeightUnit, limit float64) *loadTable {
+	available := availablePlates(config, unit)
+	table := &loadTable{}
+	for _, plate := range available {
+		if scaled := scaleWeight(plate.Weight); scaled > 0 {
+			table.step = gcd(table.step, scaled)
+		}
+	}
+	if table.step == 0 {
+		table.last = []int{0}
+		return table
+	}
+
+	size := int(scaleWeight(limit)/table.step) + 1
+	for _, plate := range available {
+		steps := scaleWeight(plate.Weight) / table.step
+		if steps == 0 {
+			continue
+		}
+		pairs := size / int(steps)
+		if plate.Count >= 0 {
+			pairs = min(pairs, plate.Count/2)
+		}
+		for range pairs {
+			table.plates = append(table.plates, steps)
+		}
+	}
+
+	// Subset sums over the plates, heaviest first, remembering which plate
+	// reached each sum so the plates can be read back
+	table.last = make([]int, size)
+	for i := range table.last {
+		table.last[i] = -1
+	}
+	table.last[0] = 0
+	for i, steps := range table.plates {
+		for sum := size - 1; sum >= int(steps); sum-- {
+			if table.last[sum] < 0 && table.last[sum-int(steps)] >= 0 {
+				table.last[sum] = i
+			}
+		}
+	}
+	return table
+}
+
+// entry returns the table entry for a weight per side, rounded down
+func (t *loadTable) entry(side float64) int {
+	if t.step == 0 {
+		return 0
+	}
+	return int(scaleWeight(side) / t.step)
+}
+
+// weightOf returns the weight per side of a table entry
+func (t *loadTable) weightOf(entry int) float64 {
+	return float64(int64(entry)*t.step) / plateScale
+}
+
+// platesFor returns the plates making up a weight per side exactly, heaviest
+// first, if the plates can
+func (t *loadTable) platesFor(side float64) ([]float64, bool) {
+	entry := t.entry(side)
+	if entry >= len(t.last) || t.last[entry] < 0 || math.Abs(t.weightOf(entry)-side) > 1.0/plateScale {
+		return nil, false
+	}
+
+	var plates []float64
+	for entry > 0 {
+		steps := t.plates[t.last[entry]]
+		plates = append(plates, t.weightOf(int(steps)))
+		entry -= int(steps)
+	}
+	slices.SortFunc(plates, func(a, b float64) int { return cmp.Compare(b, a) })
+	return plates, true
+}
+
+// scaleWeight converts a weight to thousandths of a unit
+func scaleWeight(weight float64) int64 {
+	return int64(math.Round(weight * plateScale))
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,110 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlatesPerSide(t *testing.T) {
+	plates, remainder := PlatesPerSide(140, 45, nil, models.Pounds)
+	assert.Equal(t, []float64{45, 2.5}, plates)
+	assert.Zero(t, remainder)
+
+	plates, remainder = PlatesPerSide(275, 45, nil, models.Pounds)
+	assert.Equal(t, []float64{45, 45, 25}, plates)
+	assert.Zero(t, remainder)
+
+	plates, _ = PlatesPerSide(45, 45, nil, models.Pounds)
+	assert.Empty(t, plates, "the empty bar needs no plates")
+
+	plates, remainder = PlatesPerSide(62.5, 20, nil, models.Kilograms)
+	assert.Equal(t, []float64{20, 1.25}, plates)
+	assert.Zero(t, remainder)
+}
+
+func TestPlatesPerSide_Inventory(t *testing.T) {
+	config := &models.Config{Plates: []models.PlateCount{{Weight: 45, Count: 2}, {Weight: 10, Count: 4}, {Weight: 2.5, Count: 1}}}
+
+	plates, remainder := PlatesPerSide(225, 45, config, models.Pounds)
+	assert.Equal(t, []float64{45, 10, 10}, plates, "only pairs of plates are loaded")
+	assert.Equal(t, 25.0, remainder)
+
+	// An inventory in another unit falls back to standard plates
+	plates, remainder = PlatesPerSide(60, 20, config, models.Kilograms)
+	assert.Equal(t, []float64{20}, plates)
+	assert.Zero(t, remainder)
+}
+
+func TestPlatesPerSide_ExactCombination(t *testing.T) {
+	config := &models.Config{Plates: []models.PlateCount{{Weight: 25, Count: 2}, {Weight: 10, Count: 6}}}
+
+	plates, remainder := PlatesPerSide(105, 45, config, models.Pounds)
+	assert.Equal(t, []float64{10, 10, 10}, plates, "a 25 per side leaves 5 the 10s can't make up")
+	assert.Zero(t, remainder)
+}
+
+func TestNearestLoadable(t *testing.T) {
+	config := &models.Config{Plates: []models.PlateCount{{Weight: 45, Count: 4}, {Weight: 25, Count: 2}, {Weight: 5, Count: 2}}}
+
+	below, above := NearestLoadable(137.5, 45, config, models.Pounds)
+	assert.Equal(t, 135.0, below)
+	assert.Equal(t, 145.0, above)
+
+	below, above = NearestLoadable(195, 45, config, models.Pounds)
+	assert.Equal(t, 195.0, below, "a loadable weight is its own nearest")
+	assert.Equal(t, 195.0, above)
+
+	below, above = NearestLoadable(300, 45, config, models.Pounds)
+	assert.Equal(t, 285.0, below)
+	assert.Zero(t, above, "nothing heavier than the whole inventory")
+
+	below, above = NearestLoadable(30, 45, config, models.Pounds)
+	assert.Zero(t, below)
+	assert.Equal(t, 45.0, above)
+
+	assert.True(t, CanLoad(145, 45, config, models.Pounds))
+	assert.False(t, CanLoad(140, 45, config, models.Pounds))
+	assert.False(t, CanLoad(30, 45, config, models.Pounds))
+}
+
+func TestLoadWarnings(t *testing.T) {
+	config := &models.Config{Plates: []models.PlateCount{{Weight: 45, Count: 4}, {Weight: 10, Count: 2}, {Weight: 5, Count: 2}}}
+	next := &models.Workout{Exercises: []models.Lift{
+		{LiftName: models.Squat, Sets: []models.Set{
+			{Weight: 45, Type: models.WarmupSet},
+			{Weight: 137.5, Type: models.WarmupSet},
+			{Weight: 150, Type: models.WorkingSet},
+			{Weight: 150, Type: models.AMRAPSet},
+		}},
+		{LiftName: models.OverheadPress, Sets: []models.Set{{Weight: 97.5, Type: models.WorkingSet}}},
+		{LiftName: "ChinUp", Bodyweight: true, Sets: []models.Set{{Weight: 2.5, Type: models.WorkingSet}}},
+	}}
+
+	warnings := LoadWarnings(next, config, models.Pounds)
+	assert.Equal(t, []LoadWarning{
+		{Lift: models.Squat, Weight: 150, Below: 145, Above: 155},
+		{Lift: models.OverheadPress, Weight: 97.5, Below: 75, Above: 135},
+	}, warnings)
+	assert.Equal(t, []float64{145, 155}, warnings[0].Nearest())
+	assert.Equal(t, []float64{75}, warnings[1].Nearest())
+
+	assert.Empty(t, LoadWarnings(next, nil, models.Pounds), "no inventory, nothing to check against")
+	assert.Empty(t, LoadWarnings(next, config, models.Kilograms), "an inventory in another unit isn't checked")
+}
+
+func TestPlateChange(t *testing.T) {
+	perSide, plates := PlateChange(95, 130, nil, models.Pounds)
+	assert.Equal(t, 17.5, perSide)
+	assert.Equal(t, []float64{10, 5, 2.5}, plates)
+
+	perSide, plates = PlateChange(135, 115, nil, models.Pounds)
+	assert.Equal(t, -10.0, perSide, "lighter sets take plates off")
+	assert.Equal(t, []float64{10}, plates)
+
+	config := &models.Config{Plates: []models.PlateCount{{Weight: 45, Count: 2}, {Weight: 25, Count: 2}}}
+	perSide, plates = PlateChange(95, 115, config, models.Pounds)
+	assert.Equal(t, 10.0, perSide)
+	assert.Empty(t, plates, "the inventory can't make up 10 per side")
+}
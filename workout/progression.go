@@ -0,0 +1,149 @@
+package workout
+
+import (
+	"maps"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// ProgressionStrategy decides a barbell lift's next working weight from the
+// reps completed on its AMRAP set. Weights are rounded by the caller.
+type ProgressionStrategy interface {
+	NextWeight(currentWeight float64, amrapReps int, increment float64, rules *models.ProgressionRules) float64
+}
+
+// progressionStrategies maps each strategy name to its implementation
+var progressionStrategies = map[models.ProgressionStrategyName]ProgressionStrategy{
+	models.LinearProgression:     LinearStrategy{},
+	models.DoubleProgression:     DoubleProgressionStrategy{},
+	models.PercentageProgression: PercentageStrategy{},
+	models.RepLadderProgression:  RepLadderStrategy{},
+}
+
+// ProgressionStrategyFor returns the strategy the rules name, linear for
+// rules that name none or one this version doesn't know
+func ProgressionStrategyFor(rules *models.ProgressionRules) ProgressionStrategy {
+	if strategy, ok := progressionStrategies[rules.StrategyName()]; ok {
+		return strategy
+	}
+	return LinearStrategy{}
+}
+
+// nextWeightInRange returns the strategy's next weight for a lift whose AMRAP
+// set tops out at maxReps. Short of the top of the range the weight is kept,
+// though strategies may still deload; double progression works up to the top
+// of the range in place of rep_max. A maxReps of 0 leaves the strategy as is.
+func nextWeightInRange(currentWeight float64, amrapReps, maxReps int, increment float64, rules *models.ProgressionRules) float64 {
+	if maxReps == 0 {
+		return ProgressionStrategyFor(rules).NextWeight(currentWeight, amrapReps, increment, rules)
+	}
+
+	ranged := *rules
+	ranged.Parameters = maps.Clone(rules.Parameters)
+	if ranged.Parameters == nil {
+		ranged.Parameters = make(map[string]float64)
+	}
+	ranged.Parameters["rep_max"] = float64(maxReps)
+
+	next := ProgressionStrategyFor(&ranged).NextWeight(currentWeight, amrapReps, increment, &ranged)
+	if amrapReps < maxReps && next > currentWeight {
+		return currentWeight
+	}
+	return next
+}
+
+// LinearStrategy deloads to the rules' deload percentage below deload_below
+// reps, doubles the increment at the double threshold, and otherwise adds it
+type LinearStrategy struct{}
+
+// NextWeight implements ProgressionStrategy
+func (LinearStrategy) NextWeight(currentWeight float64, amrapReps int, increment float64, rules *models.ProgressionRules) float64 {
+	switch {
+	case amrapReps < int(rules.Parameter("deload_below")):
+		return currentWeight * rules.DeloadPercentage
+	case amrapReps >= rules.DoubleThreshold:
+		return currentWeight + increment*2
+	default:
+		return currentWeight + increment
+	}
+}
+
+// DoubleProgressionStrategy keeps the weight while the lifter works up to
+// rep_max reps on the AMRAP set, then adds the increment
+type DoubleProgressionStrategy struct{}
+
+// NextWeight implements ProgressionStrategy
+func (DoubleProgressionStrategy) NextWeight(currentWeight float64, amrapReps int, increment float64, rules *models.ProgressionRules) float64 {
+	if amrapReps >= int(rules.Parameter("rep_max")) {
+		return currentWeight + increment
+	}
+	return currentWeight
+}
+
+// PercentageStrategy adds the increase fraction of the current weight, or the
+// lift's increment if that's more, and deloads below deload_below reps
+type PercentageStrategy struct{}
+
+// NextWeight implements ProgressionStrategy
+func (PercentageStrategy) NextWeight(currentWeight float64, amrapReps int, increment float64, rules *models.ProgressionRules) float64 {
+	if amrapReps < int(rules.Parameter("deload_below")) {
+		return currentWeight * rules.DeloadPercentage
+	}
+	return currentWeight + max(currentWeight*rules.Parameter("increase"), increment)
+}
+
+// RepLadderStrategy adds one increment at deload_below reps and another for
+// each rung of reps beyond, up to max_increments, and deloads below deload_below
+type RepLadderStrategy struct{}
+
+// NextWeight implements ProgressionStrategy
+func (RepLadderStrategy) NextWeight(currentWeight float64, amrapReps int, increment float64, rules *models.ProgressionRules) float64 {
+	base := int(rules.Parameter("deload_below"))
+	if amrapReps < base {
+		return currentWeight * rules.DeloadPercentage
+	}
+	increments := min(1+(amrapReps-base)/int(rules.Parameter("rung")), int(rules.Parameter("max_increments")))
+	return currentWeight + increment*float64(increments)
+}
+
+// ProgressionStep explains how a barbell lift's next working weight was worked out
+type ProgressionStep struct {
+	Lift      models.LiftName
+	Weight    float64 // The working weight lifted
+	Reps      int     // Reps completed on the AMRAP set
+	Strategy  models.ProgressionStrategyName
+	Increment float64
+	Unrounded float64 // The strategy's next weight, before rounding down
+	Next      float64
+}
+
+// ExplainProgression returns the progression steps behind CalculateProgression
+// for the barbell lifts in a workout. Lifts it leaves alone, such as held,
+// substituted, or bodyweight lifts, or that it can't progress, are left out.
+func ExplainProgression(completed *models.Workout, currentWeights map[models.LiftName]float64, rules *models.ProgressionRules, holds map[models.LiftName]int) []ProgressionStep {
+	var steps []ProgressionStep
+	for _, lift := range completed.Exercises {
+		key := lift.WeightKey()
+		if lift.Optional || lift.FixedWeight || lift.Bodyweight || lift.SubstitutedFor != "" || holds[key] > 0 {
+			continue
+		}
+		reps, err := GetAMRAPReps(&lift)
+		increment, hasRule := rules.IncrementFor(key)
+		weight, hasWeight := currentWeights[key]
+		if err != nil || !hasRule || !hasWeight {
+			continue
+		}
+
+		unrounded := nextWeightInRange(weight, reps, amrapMaxReps(&lift), increment, rules)
+		steps = append(steps, ProgressionStep{
+			Lift:      key,
+			Weight:    weight,
+			Reps:      reps,
+			Strategy:  rules.StrategyName(),
+			Increment: increment,
+			Unrounded: unrounded,
+			Next:      RoundDownTo(unrounded, rules.RoundingStepFor(key)),
+		})
+	}
+	return steps
+}
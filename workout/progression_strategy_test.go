@@ -0,0 +1,75 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressionStrategies(t *testing.T) {
+	rules := func(strategy models.ProgressionStrategyName, parameters map[string]float64) *models.ProgressionRules {
+		return &models.ProgressionRules{DeloadPercentage: 0.9, DoubleThreshold: 10, Strategy: strategy, Parameters: parameters}
+	}
+
+	tests := []struct {
+		name     string
+		rules    *models.ProgressionRules
+		reps     int
+		expected float64
+	}{
+		{"linear deload", rules("", nil), 4, 180},
+		{"linear increase", rules(models.LinearProgression, nil), 5, 205},
+		{"linear double", rules("", nil), 10, 210},
+		{"linear custom deload threshold", rules("", map[string]float64{"deload_below": 3}), 4, 205},
+		{"double progression holds below rep max", rules(models.DoubleProgression, nil), 7, 200},
+		{"double progression never deloads", rules(models.DoubleProgression, nil), 1, 200},
+		{"double progression increases at rep max", rules(models.DoubleProgression, map[string]float64{"rep_max": 10}), 12, 205},
+		{"percentage increase", rules(models.PercentageProgression, nil), 5, 205},
+		{"percentage uses the increment as a minimum", rules(models.PercentageProgression, map[string]float64{"increase": 0.01}), 5, 205},
+		{"percentage larger than the increment", rules(models.PercentageProgression, map[string]float64{"increase": 0.05}), 5, 210},
+		{"percentage deload", rules(models.PercentageProgression, nil), 4, 180},
+		{"ladder first rung", rules(models.RepLadderProgression, nil), 6, 205},
+		{"ladder second rung", rules(models.RepLadderProgression, nil), 7, 210},
+		{"ladder top rung", rules(models.RepLadderProgression, nil), 15, 215},
+		{"ladder deload", rules(models.RepLadderProgression, nil), 4, 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CalculateNewWeight(models.Squat, 200, tt.reps, 5, tt.rules))
+		})
+	}
+}
+
+func TestProgressionStrategyFor(t *testing.T) {
+	assert.Equal(t, LinearStrategy{}, ProgressionStrategyFor(&models.ProgressionRules{}))
+	assert.Equal(t, RepLadderStrategy{}, ProgressionStrategyFor(&models.ProgressionRules{Strategy: models.RepLadderProgression}))
+	assert.Equal(t, LinearStrategy{}, ProgressionStrategyFor(&models.ProgressionRules{Strategy: "unknown"}))
+}
+
+func TestExplainProgression(t *testing.T) {
+	completed := &models.Workout{Exercises: []models.Lift{
+		{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 4}}},
+		{LiftName: models.BenchPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+		{LiftName: models.Deadlift, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+		{LiftName: "Curls", Optional: true},
+	}}
+	weights := map[models.LiftName]float64{models.Squat: 135, models.BenchPress: 125, models.Deadlift: 185}
+	rules := &program.GreyskullLP.ProgressionRules
+
+	steps := ExplainProgression(completed, weights, rules, map[models.LiftName]int{models.Deadlift: 1})
+
+	assert.Equal(t, []ProgressionStep{
+		{Lift: models.Squat, Weight: 135, Reps: 4, Strategy: models.LinearProgression, Increment: 5, Unrounded: 121.5, Next: 120},
+		{Lift: models.BenchPress, Weight: 125, Reps: 7, Strategy: models.LinearProgression, Increment: 2.5, Unrounded: 127.5, Next: 127.5},
+	}, steps, "held lifts and accessories are left out")
+
+	newWeights, err := CalculateProgression(completed, weights, rules, map[models.LiftName]int{models.Deadlift: 1})
+	require.NoError(t, err)
+	for _, step := range steps {
+		assert.Equal(t, newWeights[step.Lift], step.Next, "steps agree with CalculateProgression")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +32,21 @@ func TestGetAMRAPReps(t *testing.T) {
 			expected:    8,
 			shouldError: false,
 		},
+		{
+			name: "lift with feeler single before AMRAP set",
+			lift: models.Lift{
+				ID:       uuid.New(),
+				LiftName: models.Squat,
+				Sets: []models.Set{
+					{Type: models.WorkingSet, ActualReps: 5},
+					{Type: models.WorkingSet, ActualReps: 5},
+					{Type: models.FeelerSet, ActualReps: 1},
+					{Type: models.AMRAPSet, ActualReps: 6},
+				},
+			},
+			expected:    6,
+			shouldError: false,
+		},
 		{
 			name: "lift without AMRAP set",
 			lift: models.Lift{
@@ -134,12 +150,64 @@ func TestCalculateNewWeight(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculateNewWeight(tt.currentWeight, tt.amrapReps, tt.baseIncrement, rules)
+			result := CalculateNewWeight(models.Squat, tt.currentWeight, tt.amrapReps, tt.baseIncrement, rules)
 			assert.Equal(t, tt.expected, result, tt.description)
 		})
 	}
 }
 
+func TestCalculateNewWeightInRange(t *testing.T) {
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	assert.Equal(t, 135.0, CalculateNewWeightInRange(models.Squat, 135, 7, 8, 5, rules), "short of the top of the range keeps the weight")
+	assert.Equal(t, 140.0, CalculateNewWeightInRange(models.Squat, 135, 8, 8, 5, rules), "the top of the range adds the increment")
+	assert.Equal(t, 120.0, CalculateNewWeightInRange(models.Squat, 135, 4, 8, 5, rules), "linear progression still deloads")
+	assert.Equal(t, 140.0, CalculateNewWeightInRange(models.Squat, 135, 7, 0, 5, rules), "fixed reps progress as before")
+
+	double := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+		Strategy:         models.DoubleProgression,
+	}
+	assert.Equal(t, 135.0, CalculateNewWeightInRange(models.Squat, 135, 10, 12, 5, double), "the range replaces rep_max")
+	assert.Equal(t, 140.0, CalculateNewWeightInRange(models.Squat, 135, 12, 12, 5, double))
+	assert.Nil(t, double.Parameters, "the rules are left untouched")
+}
+
+func TestCalculateProgression_RepRange(t *testing.T) {
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+	templates := []models.SetTemplate{
+		{MinReps: 6, MaxReps: 8, WeightPercentage: 1, Type: models.WorkingSet},
+		{MinReps: 6, MaxReps: 8, WeightPercentage: 1, Type: models.AMRAPSet},
+	}
+	sets := CalculateWorkingSets(135, templates, 2.5)
+	require.Len(t, sets, 2)
+	assert.Equal(t, 6, sets[1].TargetReps)
+	assert.Equal(t, 8, sets[1].MaxReps)
+
+	sets[1].ActualReps = 7
+	completed := &models.Workout{Exercises: []models.Lift{{LiftName: models.Squat, Sets: sets}}}
+	weights := map[models.LiftName]float64{models.Squat: 135}
+
+	newWeights, err := CalculateProgression(completed, weights, rules, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 135.0, newWeights[models.Squat])
+
+	completed.Exercises[0].Sets[1].ActualReps = 9
+	newWeights, err = CalculateProgression(completed, weights, rules, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 140.0, newWeights[models.Squat])
+}
+
 func TestCalculateProgression(t *testing.T) {
 	// Create a sample completed workout
 	workout := &models.Workout{
@@ -187,7 +255,7 @@ func TestCalculateProgression(t *testing.T) {
 		DoubleThreshold:  10,
 	}
 
-	newWeights, err := CalculateProgression(workout, currentWeights, rules)
+	newWeights, err := CalculateProgression(workout, currentWeights, rules, nil)
 	require.NoError(t, err)
 
 	// Verify progressions
@@ -226,7 +294,7 @@ func TestCalculateProgression_ErrorCases(t *testing.T) {
 			},
 		}
 
-		_, err := CalculateProgression(workout, currentWeights, rules)
+		_, err := CalculateProgression(workout, currentWeights, rules, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no AMRAP set found")
 	})
@@ -243,7 +311,7 @@ func TestCalculateProgression_ErrorCases(t *testing.T) {
 			},
 		}
 
-		_, err := CalculateProgression(workout, currentWeights, rules)
+		_, err := CalculateProgression(workout, currentWeights, rules, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no progression rule found")
 	})
@@ -268,10 +336,374 @@ func TestCalculateProgression_ErrorCases(t *testing.T) {
 			DoubleThreshold:  10,
 		}
 
-		_, err := CalculateProgression(workout, currentWeights, incompleteRules)
+		_, err := CalculateProgression(workout, currentWeights, incompleteRules, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "current weight not found")
 	})
 }
 
-// TestRoundDown2_5 is already tested in calculator_test.go
\ No newline at end of file
+// TestRoundDown2_5 is already tested in calculator_test.go
+func TestCalculateProgression_Holds(t *testing.T) {
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: models.OverheadPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 8}}},
+			{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 3}}},
+		},
+	}
+	currentWeights := map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+	}
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.OverheadPress: 2.5, models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	newWeights, err := CalculateProgression(workout, currentWeights, rules, map[models.LiftName]int{models.Squat: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 97.5, newWeights[models.OverheadPress], "unheld lift progresses")
+	assert.Equal(t, 135.0, newWeights[models.Squat], "held lift neither increases nor deloads")
+}
+
+func TestAdvanceHolds(t *testing.T) {
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: models.OverheadPress},
+			{LiftName: models.Squat},
+		},
+	}
+	holds := map[models.LiftName]int{
+		models.Squat:         2,
+		models.OverheadPress: 1,
+		models.Deadlift:      3,
+	}
+
+	AdvanceHolds(workout, holds)
+
+	assert.Equal(t, map[models.LiftName]int{
+		models.Squat:    1,
+		models.Deadlift: 3, // Not performed, so not counted down
+	}, holds)
+
+	// Nil holds are a no-op
+	assert.NotPanics(t, func() { AdvanceHolds(workout, nil) })
+}
+
+func TestNextDay(t *testing.T) {
+	assert.Equal(t, 2, NextDay(1, 6))
+	assert.Equal(t, 6, NextDay(5, 6))
+	assert.Equal(t, 1, NextDay(6, 6))
+	assert.Equal(t, 1, NextDay(2, 2))
+}
+
+func TestApplyWorkout(t *testing.T) {
+	userProgram := &models.UserProgram{
+		CurrentDay: 6,
+		CurrentWeights: map[models.LiftName]float64{
+			models.BenchPress: 125.0,
+			models.Squat:      135.0,
+		},
+		Holds: map[models.LiftName]int{models.Squat: 1},
+	}
+	completed := &models.Workout{
+		Day: 6,
+		Exercises: []models.Lift{
+			{LiftName: models.BenchPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+			{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+		},
+	}
+
+	err := ApplyWorkout(userProgram, completed, program.GreyskullLP)
+	require.NoError(t, err)
+
+	assert.Equal(t, 127.5, userProgram.CurrentWeights[models.BenchPress])
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	assert.Empty(t, userProgram.Holds)
+	assert.Equal(t, 1, userProgram.CurrentDay)
+
+	t.Run("leaves program unchanged on error", func(t *testing.T) {
+		userProgram := &models.UserProgram{
+			CurrentDay:     2,
+			CurrentWeights: map[models.LiftName]float64{models.BenchPress: 125.0},
+		}
+		missingAMRAP := &models.Workout{
+			Exercises: []models.Lift{{LiftName: models.BenchPress, Sets: []models.Set{{Type: models.WorkingSet}}}},
+		}
+
+		err := ApplyWorkout(userProgram, missingAMRAP, program.GreyskullLP)
+		assert.Error(t, err)
+		assert.Equal(t, 2, userProgram.CurrentDay)
+		assert.Equal(t, 125.0, userProgram.CurrentWeights[models.BenchPress])
+	})
+}
+
+func TestApplyWorkout_RoundingSteps(t *testing.T) {
+	userProgram := &models.UserProgram{
+		CurrentDay:     1,
+		CurrentWeights: map[models.LiftName]float64{models.OverheadPress: 102.5, models.BenchPress: 102.5},
+		RoundingSteps:  map[models.LiftName]float64{models.OverheadPress: 1.25},
+	}
+	completed := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{LiftName: models.OverheadPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 3}}},
+			{LiftName: models.BenchPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 3}}},
+		},
+	}
+
+	require.NoError(t, ApplyWorkout(userProgram, completed, program.GreyskullLP))
+	assert.Equal(t, 91.25, userProgram.CurrentWeights[models.OverheadPress], "deloads round down to the lift's step")
+	assert.Equal(t, 90.0, userProgram.CurrentWeights[models.BenchPress])
+}
+
+func TestUpdateDeloadStreaks(t *testing.T) {
+	squatSession := &models.Workout{Exercises: []models.Lift{{LiftName: models.Squat}}}
+	session := func(streaks map[models.LiftName]models.DeloadStreak, from, to float64) map[models.LiftName]models.DeloadStreak {
+		return UpdateDeloadStreaks(squatSession, streaks,
+			map[models.LiftName]float64{models.Squat: from, models.BenchPress: 100},
+			map[models.LiftName]float64{models.Squat: to, models.BenchPress: 90})
+	}
+
+	streaks := session(nil, 225, 202.5)
+	assert.Equal(t, map[models.LiftName]models.DeloadStreak{models.Squat: {Deloads: 1, Weight: 225}}, streaks,
+		"only lifts performed in the workout are tracked")
+
+	streaks = session(streaks, 202.5, 225)
+	assert.Equal(t, 1, streaks[models.Squat].Deloads, "reaching the deloaded weight again doesn't end the streak")
+
+	streaks = session(streaks, 220, 197.5)
+	assert.Equal(t, models.DeloadStreak{Deloads: 2, Weight: 225}, streaks[models.Squat], "the heaviest weight deloaded from is kept")
+
+	streaks = session(streaks, 225, 230)
+	assert.Empty(t, streaks, "getting past the heaviest weight ends the streak")
+}
+
+func TestApplyWorkout_DeloadStreaks(t *testing.T) {
+	userProgram := &models.UserProgram{
+		CurrentDay:     1,
+		CurrentWeights: map[models.LiftName]float64{models.BenchPress: 100, models.Squat: 200},
+		DeloadStreaks:  map[models.LiftName]models.DeloadStreak{models.BenchPress: {Deloads: 2, Weight: 100}},
+	}
+	completed := &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{LiftName: models.BenchPress, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 3}}},
+			{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 6}}},
+		},
+	}
+
+	require.NoError(t, ApplyWorkout(userProgram, completed, program.GreyskullLP))
+
+	assert.Equal(t, map[models.LiftName]models.DeloadStreak{models.BenchPress: {Deloads: 3, Weight: 100}}, userProgram.DeloadStreaks)
+}
+
+func TestCalculateProgression_Variants(t *testing.T) {
+	ssb := models.VariantKey(models.Squat, "SSB")
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: models.Squat, Variant: "SSB", Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+		},
+	}
+	currentWeights := map[models.LiftName]float64{
+		models.Squat: 225.0,
+		ssb:          185.0,
+	}
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	newWeights, err := CalculateProgression(workout, currentWeights, rules, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 190.0, newWeights[ssb], "variant progresses using the base lift's increment")
+	assert.Equal(t, 225.0, newWeights[models.Squat], "straight bar track is untouched")
+}
+
+func TestCalculateProgression_OptionalLift(t *testing.T) {
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+			{LiftName: "Chin-ups", Optional: true, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 3}}},
+		},
+	}
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	newWeights, err := CalculateProgression(workout, map[models.LiftName]float64{models.Squat: 225.0}, rules, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[models.LiftName]float64{models.Squat: 230.0}, newWeights, "accessories have no weight to progress")
+}
+
+func TestCalculateProgression_FixedWeight(t *testing.T) {
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, ActualReps: 7}}},
+			{LiftName: "PauseSquat", FixedWeight: true, Sets: []models.Set{{Type: models.WorkingSet, ActualReps: 3}}},
+		},
+	}
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5.0},
+		DeloadPercentage: 0.9,
+		DoubleThreshold:  10,
+	}
+
+	newWeights, err := CalculateProgression(workout, map[models.LiftName]float64{models.Squat: 225.0, "PauseSquat": 185.0}, rules, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[models.LiftName]float64{models.Squat: 230.0, "PauseSquat": 185.0}, newWeights,
+		"fixed-weight lifts need no AMRAP set or rule and keep their weight")
+}
+
+func TestValidateWorkout(t *testing.T) {
+	amrap := []models.Set{{Type: models.WorkingSet}, {Type: models.AMRAPSet}}
+	straight := []models.Set{{Type: models.WorkingSet}, {Type: models.WorkingSet}}
+
+	valid := &models.Workout{Exercises: []models.Lift{
+		{LiftName: models.Squat, Sets: amrap},
+		{LiftName: "PauseSquat", FixedWeight: true, Sets: straight},
+		{LiftName: "Curls", Optional: true, Sets: straight},
+	}}
+	assert.NoError(t, ValidateWorkout(valid, false))
+
+	missing := &models.Workout{Exercises: []models.Lift{
+		{LiftName: models.Squat, Sets: amrap},
+		{LiftName: models.BenchPress, Sets: straight},
+	}}
+	assert.EqualError(t, ValidateWorkout(missing, false),
+		"BenchPress has 0 AMRAP sets, but lifts that progress need exactly one; fix the program template or mark the lift fixed_weight")
+	assert.NoError(t, ValidateWorkout(missing, true), "deload sessions don't progress")
+}
+
+func TestApplyWorkout_Kilograms(t *testing.T) {
+	prog := program.GreyskullLP
+	userProgram := &models.UserProgram{
+		CurrentWeights: map[models.LiftName]float64{models.OverheadPress: 40, models.Squat: 60},
+		CurrentDay:     1,
+		Unit:           models.Kilograms,
+	}
+	completed := &models.Workout{Exercises: []models.Lift{
+		{LiftName: models.OverheadPress, Sets: []models.Set{{Type: models.AMRAPSet, Weight: 40, TargetReps: 5, ActualReps: 6}}},
+		{LiftName: models.Squat, Sets: []models.Set{{Type: models.AMRAPSet, Weight: 60, TargetReps: 5, ActualReps: 12}}},
+	}}
+
+	require.NoError(t, ApplyWorkout(userProgram, completed, prog))
+
+	// Pound increments become 1.25 kg upper body and 2.5 kg lower body (doubled at 10+ reps)
+	assert.Equal(t, 41.25, userProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 65.0, userProgram.CurrentWeights[models.Squat])
+}
+
+func TestCalculateWarmupSets_Kilograms(t *testing.T) {
+	templates := []models.SetTemplate{
+		{Reps: 5, WeightPercentage: 0, Type: models.WarmupSet},
+		{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+	}
+
+	assert.Empty(t, CalculateWarmupSets(40, templates, models.Kilograms))
+
+	sets := CalculateWarmupSets(61.25, templates, models.Kilograms)
+	require.Len(t, sets, 2)
+	assert.Equal(t, 20.0, sets[0].Weight)
+	assert.Equal(t, 32.5, sets[1].Weight)
+}
+
+func TestCalculateNewAddedWeight(t *testing.T) {
+	rules := &models.ProgressionRules{DeloadPercentage: 0.9, DoubleThreshold: 10}
+
+	tests := []struct {
+		name     string
+		current  float64
+		reps     int
+		expected float64
+	}{
+		{"normal increase from bodyweight", 0, 6, 5},
+		{"double increase", 25, 10, 35},
+		{"assistance shrinks", -40, 6, -35},
+		{"shortfall drops two increments", 10, 4, 0},
+		{"shortfall adds assistance", -20, 3, -30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CalculateNewAddedWeight("Chinup", tt.current, tt.reps, 5, 5.0, rules))
+		})
+	}
+}
+
+func TestCalculateRepTargets(t *testing.T) {
+	rules := &models.ProgressionRules{
+		IncreaseRules: map[models.LiftName]float64{"Dip": 5},
+		RepIncreases:  map[models.LiftName]int{"Chinup": 1, "Pushup": 2},
+	}
+	workout := &models.Workout{
+		Exercises: []models.Lift{
+			{LiftName: "Chinup", Bodyweight: true, Sets: []models.Set{{Type: models.AMRAPSet, TargetReps: 8, ActualReps: 9}}},
+			{LiftName: "Pushup", Bodyweight: true, Sets: []models.Set{{Type: models.AMRAPSet, TargetReps: 2, ActualReps: 1}}},
+			{LiftName: "Dip", Bodyweight: true, Sets: []models.Set{{Type: models.AMRAPSet, TargetReps: 5, ActualReps: 8}}},
+		},
+	}
+	current := map[models.LiftName]int{"Chinup": 8}
+
+	targets := CalculateRepTargets(workout, current, rules, nil)
+	assert.Equal(t, map[models.LiftName]int{"Chinup": 9, "Pushup": 1}, targets, "falling short never drops below 1 rep")
+	assert.Equal(t, 8, current["Chinup"], "current targets are unchanged")
+
+	held := CalculateRepTargets(workout, current, rules, map[models.LiftName]int{"Chinup": 2})
+	assert.Equal(t, 8, held["Chinup"])
+}
+
+func TestApplyWorkout_BodyweightByReps(t *testing.T) {
+	prog := &models.Program{
+		Workouts: []models.WorkoutTemplate{{Day: 1, Lifts: []models.LiftTemplate{{
+			LiftName:    "Chinup",
+			Bodyweight:  true,
+			WorkingSets: []models.SetTemplate{{Reps: 5, WeightPercentage: 1.0, Type: models.AMRAPSet}},
+		}}}},
+		ProgressionRules: models.ProgressionRules{
+			RepIncreases:     map[models.LiftName]int{"Chinup": 1},
+			DeloadPercentage: 0.9,
+			DoubleThreshold:  10,
+		},
+	}
+	user := createTestUser(1, map[models.LiftName]float64{"Chinup": -20})
+	userProgram := user.Programs[user.CurrentProgram]
+
+	next, err := CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	chinups := next.Exercises[0]
+	assert.True(t, chinups.Bodyweight)
+	require.Len(t, chinups.Sets, 1)
+	assert.Equal(t, -20.0, chinups.Sets[0].Weight)
+	assert.Equal(t, 5, chinups.Sets[0].TargetReps)
+
+	chinups.Sets[0].ActualReps = 6
+	require.NoError(t, ApplyWorkout(userProgram, next, prog))
+	assert.Equal(t, -20.0, userProgram.CurrentWeights["Chinup"], "assistance is unchanged when progressing by reps")
+	assert.Equal(t, map[models.LiftName]int{"Chinup": 6}, userProgram.RepTargets)
+
+	next, err = CalculateNextWorkout(user, prog)
+	require.NoError(t, err)
+	assert.Equal(t, 6, next.Exercises[0].Sets[0].TargetReps)
+}
+
+func TestCalculateProgression_Substituted(t *testing.T) {
+	workout := &models.Workout{Exercises: []models.Lift{{
+		LiftName:       models.Deadlift,
+		SubstitutedFor: models.Squat,
+		Sets:           []models.Set{{Weight: 185, TargetReps: 5, ActualReps: 12, Type: models.AMRAPSet}},
+	}}}
+	weights := map[models.LiftName]float64{models.Squat: 135}
+
+	newWeights, err := CalculateProgression(workout, weights, &program.GreyskullLP.ProgressionRules, nil)
+	require.NoError(t, err)
+	assert.Equal(t, weights, newWeights)
+	assert.Empty(t, ExplainProgression(workout, weights, &program.GreyskullLP.ProgressionRules, nil))
+}
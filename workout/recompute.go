@@ -0,0 +1,173 @@
+package workout
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// Recomputation is a UserProgram rebuilt by replaying its history, with counts
+// of what was replayed
+type Recomputation struct {
+	UserProgram  *models.UserProgram
+	Workouts     int
+	SkippedDays  int
+	WeightResets int
+}
+
+// WeightDivergence is a lift whose stored weight differs from the weight
+// recomputed from history
+type WeightDivergence struct {
+	Lift       models.LiftName `json:"lift"`
+	Stored     float64         `json:"stored"`
+	Recomputed float64         `json:"recomputed"`
+}
+
+// replayEvent is one recorded change to a UserProgram, applied in date order
+type replayEvent struct {
+	at    time.Time
+	apply func(*models.UserProgram) error
+}
+
+// Recompute rebuilds a UserProgram's weights, rep targets, deload streaks, and
+// current day by replaying its history from its starting weights, in date order:
+// completed workouts progress their lifts and advance the day, incomplete ones
+// repeat their day, skipped days advance it, and weight resets, including
+// weights reduced on resuming a pause, replace the weights. Lifts a workout
+// recorded as held keep their weight. Workouts without AMRAP sets, such as
+// deload sessions, only advance the day. Lifts without a starting weight start
+// at the weight they were first performed at; lifts never performed keep their
+// stored weight. userProgram itself is left unchanged.
+func Recompute(user *models.User, userProgram *models.UserProgram, program *models.Program) (*Recomputation, error) {
+	rebuilt := userProgram.Clone()
+	rebuilt.CurrentWeights = maps.Clone(userProgram.StartingWeights)
+	if rebuilt.CurrentWeights == nil {
+		rebuilt.CurrentWeights = make(map[models.LiftName]float64)
+	}
+	rebuilt.RepTargets = nil
+	rebuilt.DeloadStreaks = nil
+	rebuilt.Holds = nil
+	rebuilt.Deload = nil
+
+	result := &Recomputation{UserProgram: rebuilt}
+	rules := program.ProgressionRules.ForUserProgram(userProgram)
+	totalDays := len(program.Workouts)
+
+	var events []replayEvent
+	for _, completed := range user.HistoryFor(userProgram.ID) {
+		if completed.AdHoc {
+			continue
+		}
+		result.Workouts++
+		events = append(events, replayEvent{completed.EnteredAt, func(up *models.UserProgram) error {
+			return replayWorkout(up, &completed, rules, totalDays)
+		}})
+	}
+	for _, skipped := range user.SkippedDays {
+		if skipped.UserProgramID != userProgram.ID {
+			continue
+		}
+		result.SkippedDays++
+		events = append(events, replayEvent{skipped.SkippedAt, func(up *models.UserProgram) error {
+			up.CurrentDay = NextDay(skipped.Day, totalDays)
+			return nil
+		}})
+	}
+	for _, reset := range user.WeightResets {
+		if reset.UserProgramID != userProgram.ID {
+			continue
+		}
+		result.WeightResets++
+		events = append(events, replayEvent{reset.ResetAt, func(up *models.UserProgram) error {
+			maps.Copy(up.CurrentWeights, reset.Weights)
+			return nil
+		}})
+	}
+	slices.SortStableFunc(events, func(a, b replayEvent) int {
+		return a.at.Compare(b.at)
+	})
+
+	for _, event := range events {
+		if err := event.apply(rebuilt); err != nil {
+			return nil, err
+		}
+	}
+
+	for lift, weight := range userProgram.CurrentWeights {
+		if _, exists := rebuilt.CurrentWeights[lift]; !exists {
+			rebuilt.CurrentWeights[lift] = weight
+		}
+	}
+	return result, nil
+}
+
+// replayWorkout applies a logged workout to a UserProgram being rebuilt
+func replayWorkout(up *models.UserProgram, completed *models.Workout, rules *models.ProgressionRules, totalDays int) error {
+	if completed.Incomplete {
+		up.CurrentDay = completed.Day
+		return nil
+	}
+	if !hasAMRAPSet(completed) {
+		up.CurrentDay = NextDay(completed.Day, totalDays)
+		return nil
+	}
+
+	// Lifts without a starting weight start at the weight first performed
+	for _, lift := range completed.Exercises {
+		key := lift.WeightKey()
+		if _, exists := up.CurrentWeights[key]; exists || lift.Optional || lift.FixedWeight || lift.SubstitutedFor != "" {
+			continue
+		}
+		for _, set := range lift.Sets {
+			if set.Type == models.AMRAPSet {
+				up.CurrentWeights[key] = set.Weight
+				break
+			}
+		}
+	}
+
+	holds := make(map[models.LiftName]int, len(completed.Held))
+	for _, key := range completed.Held {
+		holds[key] = 1
+	}
+	newWeights, err := CalculateProgression(completed, up.CurrentWeights, rules, holds)
+	if err != nil {
+		return fmt.Errorf("failed to replay Day %d workout from %s: %w", completed.Day, completed.EnteredAt.Format("2006-01-02"), err)
+	}
+	up.DeloadStreaks = UpdateDeloadStreaks(completed, up.DeloadStreaks, up.CurrentWeights, newWeights)
+	up.CurrentWeights = newWeights
+	up.RepTargets = CalculateRepTargets(completed, up.RepTargets, rules, holds)
+	up.CurrentDay = NextDay(completed.Day, totalDays)
+	return nil
+}
+
+// hasAMRAPSet reports whether any set of the workout is an AMRAP set
+func hasAMRAPSet(completed *models.Workout) bool {
+	for _, lift := range completed.Exercises {
+		for _, set := range lift.Sets {
+			if set.Type == models.AMRAPSet {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WeightDivergences returns the lifts whose stored and recomputed weights
+// differ, sorted by lift name
+func WeightDivergences(stored, recomputed map[models.LiftName]float64) []WeightDivergence {
+	var divergences []WeightDivergence
+	for lift, weight := range recomputed {
+		if storedWeight := stored[lift]; storedWeight != weight {
+			divergences = append(divergences, WeightDivergence{Lift: lift, Stored: storedWeight, Recomputed: weight})
+		}
+	}
+	slices.SortFunc(divergences, func(a, b WeightDivergence) int {
+		return cmp.Compare(a.Lift, b.Lift)
+	})
+	return divergences
+}
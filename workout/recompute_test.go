@@ -0,0 +1,127 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logTestWorkout calculates the user's next workout, completes every AMRAP set
+// with reps, and applies it as 'workout log' would
+func logTestWorkout(t *testing.T, user *models.User, reps int, at time.Time) {
+	t.Helper()
+	userProgram := user.Programs[user.CurrentProgram]
+	next, err := CalculateNextWorkout(user, program.GreyskullLP)
+	require.NoError(t, err)
+	for i := range next.Exercises {
+		for j := range next.Exercises[i].Sets {
+			set := &next.Exercises[i].Sets[j]
+			set.ActualReps = set.TargetReps
+			if set.Type == models.AMRAPSet {
+				set.ActualReps = reps
+			}
+		}
+	}
+	next.EnteredAt = at
+	require.NoError(t, ApplyWorkout(userProgram, next, program.GreyskullLP))
+	user.AddWorkout(*next)
+}
+
+func TestRecompute(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+		models.BenchPress:    125.0,
+		models.Deadlift:      185.0,
+	})
+	userProgram := user.Programs[user.CurrentProgram]
+	start := time.Date(2025, 3, 3, 18, 0, 0, 0, time.UTC)
+
+	logTestWorkout(t, user, 8, start)
+	logTestWorkout(t, user, 3, start.AddDate(0, 0, 2))
+
+	// A skipped day advances the day without touching weights
+	user.SkippedDays = append(user.SkippedDays, models.SkippedDay{
+		ID: uuid.New(), UserProgramID: userProgram.ID, Day: userProgram.CurrentDay, SkippedAt: start.AddDate(0, 0, 4),
+	})
+	userProgram.CurrentDay = NextDay(userProgram.CurrentDay, len(program.GreyskullLP.Workouts))
+	logTestWorkout(t, user, 12, start.AddDate(0, 0, 7))
+
+	result, err := Recompute(user, userProgram, program.GreyskullLP)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Workouts)
+	assert.Equal(t, 1, result.SkippedDays)
+	assert.Equal(t, 0, result.WeightResets)
+	assert.Equal(t, userProgram.CurrentWeights, result.UserProgram.CurrentWeights)
+	assert.Equal(t, userProgram.CurrentDay, result.UserProgram.CurrentDay)
+	assert.Empty(t, WeightDivergences(userProgram.CurrentWeights, result.UserProgram.CurrentWeights))
+
+	// Drifted stored values are reported, and the stored program is left alone
+	userProgram.CurrentWeights[models.Squat] = 200
+	userProgram.CurrentDay = 1
+	result, err = Recompute(user, userProgram, program.GreyskullLP)
+	require.NoError(t, err)
+	assert.Equal(t, []WeightDivergence{{Lift: models.Squat, Stored: 200, Recomputed: 150}},
+		WeightDivergences(userProgram.CurrentWeights, result.UserProgram.CurrentWeights))
+	assert.Equal(t, 5, result.UserProgram.CurrentDay)
+	assert.Equal(t, 200.0, userProgram.CurrentWeights[models.Squat])
+}
+
+func TestRecompute_ResetsAndIncompleteWorkouts(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+	})
+	userProgram := user.Programs[user.CurrentProgram]
+	start := time.Date(2025, 3, 3, 18, 0, 0, 0, time.UTC)
+
+	logTestWorkout(t, user, 8, start)
+
+	// An incomplete attempt at Day 2 repeats the day
+	user.AddWorkout(models.Workout{ID: uuid.New(), UserProgramID: userProgram.ID, Day: 2, EnteredAt: start.AddDate(0, 0, 2), Incomplete: true})
+
+	// Ad hoc workouts and other programs' records are ignored
+	user.AddWorkout(models.Workout{ID: uuid.New(), Day: 0, EnteredAt: start.AddDate(0, 0, 3), AdHoc: true})
+	user.WeightResets = append(user.WeightResets,
+		models.WeightReset{UserProgramID: userProgram.ID, ResetAt: start.AddDate(0, 0, 4), Weights: map[models.LiftName]float64{models.Squat: 120}},
+		models.WeightReset{UserProgramID: uuid.New(), ResetAt: start.AddDate(0, 0, 4), Weights: map[models.LiftName]float64{models.Squat: 500}},
+	)
+
+	result, err := Recompute(user, userProgram, program.GreyskullLP)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Workouts)
+	assert.Equal(t, 1, result.WeightResets)
+	assert.Equal(t, 2, result.UserProgram.CurrentDay)
+	assert.Equal(t, 97.5, result.UserProgram.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, 120.0, result.UserProgram.CurrentWeights[models.Squat])
+}
+
+func TestRecompute_Holds(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         135.0,
+		models.BenchPress:    125.0,
+		models.Deadlift:      185.0,
+	})
+	userProgram := user.Programs[user.CurrentProgram]
+	userProgram.Holds = map[models.LiftName]int{models.Squat: 1}
+	start := time.Date(2025, 3, 3, 18, 0, 0, 0, time.UTC)
+
+	logTestWorkout(t, user, 8, start)
+	assert.Equal(t, []models.LiftName{models.Squat}, user.WorkoutHistory[0].Held)
+	assert.Equal(t, 135.0, userProgram.CurrentWeights[models.Squat])
+	logTestWorkout(t, user, 8, start.AddDate(0, 0, 2))
+	logTestWorkout(t, user, 8, start.AddDate(0, 0, 4))
+	assert.Empty(t, user.WorkoutHistory[2].Held, "the hold ran out")
+
+	// The held workout leaves the squat alone when replayed too
+	result, err := Recompute(user, userProgram, program.GreyskullLP)
+	require.NoError(t, err)
+	assert.Empty(t, WeightDivergences(userProgram.CurrentWeights, result.UserProgram.CurrentWeights))
+	assert.Equal(t, 140.0, result.UserProgram.CurrentWeights[models.Squat])
+}
@@ -0,0 +1,69 @@
+package workout
+
+import (
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// OverdueAfterDays is the longest normal gap between sessions when training three
+// days a week (e.g. Friday to Monday). A longer gap means a session was missed.
+const OverdueAfterDays = 3
+
+// LastTrainedAt returns when a UserProgram was last trained: the EnteredAt of its
+// most recent workout, or its start date if no workouts have been logged
+func LastTrainedAt(user *models.User, userProgram *models.UserProgram) time.Time {
+	history := user.HistoryFor(userProgram.ID)
+	if len(history) == 0 {
+		return userProgram.StartedAt
+	}
+	return history[len(history)-1].EnteredAt
+}
+
+// DaysSince returns the number of calendar days between last and now, in now's time zone
+func DaysSince(last, now time.Time) int {
+	last = last.In(now.Location())
+	lastDate := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	nowDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(nowDate.Sub(lastDate).Hours() / 24)
+}
+
+// IsOverdue reports whether more than OverdueAfterDays calendar days have passed since last
+func IsOverdue(last, now time.Time) bool {
+	return DaysSince(last, now) > OverdueAfterDays
+}
+
+// WeightsFromHistory derives each lift's next working weight from its most recent
+// AMRAP set in a chronologically sorted history, applying the program's progression
+// rules. Lifts without an AMRAP set or a progression rule are omitted, and
+// incomplete workouts are ignored since they never applied progression.
+func WeightsFromHistory(history []models.Workout, rules *models.ProgressionRules) map[models.LiftName]float64 {
+	weights := make(map[models.LiftName]float64)
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Incomplete {
+			continue
+		}
+		for _, lift := range history[i].Exercises {
+			key := lift.WeightKey()
+			if _, done := weights[key]; done {
+				continue
+			}
+			increment, exists := rules.IncrementFor(key)
+			if !exists {
+				continue
+			}
+			for _, set := range lift.Sets {
+				if set.Type != models.AMRAPSet {
+					continue
+				}
+				if lift.Bodyweight {
+					weights[key] = CalculateNewAddedWeight(key, set.Weight, set.ActualReps, set.TargetReps, increment, rules)
+				} else {
+					weights[key] = CalculateNewWeightInRange(key, set.Weight, set.ActualReps, set.MaxReps, increment, rules)
+				}
+				break
+			}
+		}
+	}
+	return weights
+}
@@ -0,0 +1,75 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysSince(t *testing.T) {
+	friday := time.Date(2024, 5, 3, 18, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, DaysSince(friday, friday.Add(2*time.Hour)))
+	assert.Equal(t, 1, DaysSince(friday, time.Date(2024, 5, 4, 6, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 3, DaysSince(friday, time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)))
+
+	// Calendar days are counted in now's time zone
+	est := time.FixedZone("EST", -5*3600)
+	assert.Equal(t, 0, DaysSince(time.Date(2024, 5, 4, 2, 0, 0, 0, time.UTC), time.Date(2024, 5, 3, 23, 0, 0, 0, est)))
+}
+
+func TestIsOverdue(t *testing.T) {
+	friday := time.Date(2024, 5, 3, 18, 30, 0, 0, time.UTC)
+
+	assert.False(t, IsOverdue(friday, time.Date(2024, 5, 6, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, IsOverdue(friday, time.Date(2024, 5, 7, 0, 30, 0, 0, time.UTC)))
+}
+
+func TestLastTrainedAt(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	userProgram := &models.UserProgram{ID: uuid.New(), StartedAt: started}
+	user := &models.User{}
+
+	assert.Equal(t, started, LastTrainedAt(user, userProgram))
+
+	user.WorkoutHistory = []models.Workout{
+		{UserProgramID: userProgram.ID, EnteredAt: started.AddDate(0, 0, 4)},
+		{UserProgramID: userProgram.ID, EnteredAt: started.AddDate(0, 0, 2)},
+		{UserProgramID: uuid.New(), EnteredAt: started.AddDate(0, 0, 9)},
+	}
+	assert.Equal(t, started.AddDate(0, 0, 4), LastTrainedAt(user, userProgram))
+}
+
+func TestWeightsFromHistory(t *testing.T) {
+	rules := &models.ProgressionRules{
+		IncreaseRules:    map[models.LiftName]float64{models.Squat: 5, models.BenchPress: 2.5},
+		DoubleThreshold:  10,
+		DeloadPercentage: 0.9,
+	}
+	amrap := func(name models.LiftName, variant string, weight float64, reps int) models.Lift {
+		return models.Lift{LiftName: name, Variant: variant, Sets: []models.Set{
+			{Weight: weight, TargetReps: 5, ActualReps: 5, Type: models.WorkingSet},
+			{Weight: weight, TargetReps: 5, ActualReps: reps, Type: models.AMRAPSet},
+		}}
+	}
+	history := []models.Workout{
+		{Exercises: []models.Lift{amrap(models.Squat, "", 125, 8), amrap(models.BenchPress, "", 100, 4)}},
+		{Exercises: []models.Lift{amrap(models.Squat, "", 135, 10), amrap(models.Squat, "SSB", 115, 6)}},
+		// No AMRAP set and no progression rule: ignored
+		{Exercises: []models.Lift{
+			{LiftName: models.BenchPress, Sets: []models.Set{{Weight: 200, ActualReps: 5, Type: models.WorkingSet}}},
+			amrap(models.Deadlift, "", 225, 5),
+		}},
+		// Abandoned partway: ignored
+		{Incomplete: true, Exercises: []models.Lift{amrap(models.Squat, "", 145, 0)}},
+	}
+
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat:      145,
+		"Squat:SSB":       120,
+		models.BenchPress: 90,
+	}, WeightsFromHistory(history, rules))
+}
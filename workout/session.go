@@ -0,0 +1,99 @@
+package workout
+
+import (
+	"fmt"
+
+	"github.com/mikowitz/greyskull/models"
+)
+
+// QuickWarmupSets is the number of warmup sets kept per lift in a quick session
+const QuickWarmupSets = 2
+
+// SessionModifier adjusts a calculated workout before it is displayed and logged
+type SessionModifier func(workout *models.Workout)
+
+// ApplyModifiers runs each modifier over the workout in order
+func ApplyModifiers(workout *models.Workout, modifiers ...SessionModifier) {
+	for _, modify := range modifiers {
+		modify(workout)
+	}
+}
+
+// QuickSession trims each lift's warmups to the lightest and heaviest sets and
+// flags the workout as a quick session. Working sets are left untouched.
+func QuickSession(workout *models.Workout) {
+	workout.Quick = true
+
+	for i := range workout.Exercises {
+		lift := &workout.Exercises[i]
+
+		var warmups []int
+		for j, set := range lift.Sets {
+			if set.Type == models.WarmupSet {
+				warmups = append(warmups, j)
+			}
+		}
+		if len(warmups) <= QuickWarmupSets {
+			continue
+		}
+
+		// Keep the empty bar and the warmup closest to the working weight
+		keep := map[int]bool{warmups[0]: true, warmups[len(warmups)-1]: true}
+		sets := make([]models.Set, 0, len(lift.Sets)-len(warmups)+QuickWarmupSets)
+		for j, set := range lift.Sets {
+			if set.Type == models.WarmupSet && !keep[j] {
+				continue
+			}
+			set.Order = len(sets) + 1
+			sets = append(sets, set)
+		}
+		lift.Sets = sets
+	}
+}
+
+// FindSubstitutable returns the index of the lift tracked under key that could
+// be substituted: a barbell lift rather than an accessory or bodyweight lift.
+// A plain lift name also matches its variants.
+func FindSubstitutable(workout *models.Workout, key models.LiftName) (int, error) {
+	for i, lift := range workout.Exercises {
+		if lift.WeightKey() != key && lift.LiftName != key {
+			continue
+		}
+		if lift.Optional || lift.Bodyweight {
+			return -1, fmt.Errorf("%s can't be substituted: only barbell lifts can", key)
+		}
+		return i, nil
+	}
+	return -1, fmt.Errorf("Day %d has no %s to substitute", workout.Day, key)
+}
+
+// Substitute returns a modifier that performs replacement in place of the lift
+// at index, with its heaviest sets at weight. Other sets are scaled to match,
+// with warmups no lighter than the replacement's empty bar. The lift is marked
+// as substituted so the original keeps its weight rather than progressing.
+func Substitute(index int, replacement models.LiftName, weight float64, unit models.WeightUnit) SessionModifier {
+	return func(workout *models.Workout) {
+		lift := &workout.Exercises[index]
+		top := heaviestSet(lift.Sets)
+		bar := models.BarWeightFor(replacement, unit)
+
+		sets := make([]models.Set, len(lift.Sets))
+		for i, set := range lift.Sets {
+			switch {
+			case set.Weight == top:
+				set.Weight = weight
+			case set.Type == models.WarmupSet:
+				set.Weight = max(bar, RoundDown(set.Weight*weight/top, unit))
+			default:
+				set.Weight = RoundDown(set.Weight*weight/top, unit)
+			}
+			sets[i] = set
+		}
+
+		lift.SubstitutedFor = lift.WeightKey()
+		lift.LiftName = replacement
+		lift.Variant = ""
+		lift.FixedWeight = false
+		lift.Sets = sets
+	}
+}
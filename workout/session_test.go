@@ -0,0 +1,100 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickSession(t *testing.T) {
+	warmupTemplates := []models.SetTemplate{
+		{Reps: 5, WeightPercentage: 0.0, Type: models.WarmupSet},
+		{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+		{Reps: 3, WeightPercentage: 0.70, Type: models.WarmupSet},
+		{Reps: 2, WeightPercentage: 0.85, Type: models.WarmupSet},
+	}
+	workingTemplates := []models.SetTemplate{
+		{Reps: 5, Type: models.WorkingSet},
+		{Reps: 5, Type: models.AMRAPSet},
+	}
+	lift := func(name models.LiftName, weight float64) models.Lift {
+		sets := CalculateWarmupSets(weight, warmupTemplates, models.Pounds)
+		for _, set := range CalculateWorkingSets(weight, workingTemplates, 2.5) {
+			set.Order = len(sets) + 1
+			sets = append(sets, set)
+		}
+		return models.Lift{LiftName: name, Sets: sets}
+	}
+
+	workout := &models.Workout{Exercises: []models.Lift{
+		lift(models.Squat, 135),
+		lift(models.OverheadPress, 80), // Too light for warmups
+	}}
+
+	ApplyModifiers(workout, QuickSession)
+
+	assert.True(t, workout.Quick)
+
+	squat := workout.Exercises[0].Sets
+	require.Len(t, squat, 4)
+	assert.Equal(t, models.WarmupSet, squat[0].Type)
+	assert.Equal(t, 45.0, squat[0].Weight)
+	assert.Equal(t, models.WarmupSet, squat[1].Type)
+	assert.Equal(t, 112.5, squat[1].Weight)
+	assert.Equal(t, models.WorkingSet, squat[2].Type)
+	assert.Equal(t, models.AMRAPSet, squat[3].Type)
+	for i, set := range squat {
+		assert.Equal(t, i+1, set.Order)
+	}
+
+	assert.Len(t, workout.Exercises[1].Sets, 2)
+}
+
+func TestApplyModifiers_None(t *testing.T) {
+	workout := &models.Workout{Exercises: []models.Lift{{
+		LiftName: models.Squat,
+		Sets:     CalculateWarmupSets(135, []models.SetTemplate{{Reps: 5}, {Reps: 4, WeightPercentage: 0.55}, {Reps: 3, WeightPercentage: 0.7}}, models.Pounds),
+	}}}
+
+	ApplyModifiers(workout)
+
+	assert.False(t, workout.Quick)
+	assert.Len(t, workout.Exercises[0].Sets, 3)
+}
+
+func TestSubstitute(t *testing.T) {
+	warmups := []models.SetTemplate{
+		{Reps: 5, Type: models.WarmupSet},
+		{Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet},
+		{Reps: 3, WeightPercentage: 0.7, Type: models.WarmupSet},
+	}
+	sets := CalculateWarmupSets(135, warmups, models.Pounds)
+	sets = append(sets, CalculateWorkingSets(135, []models.SetTemplate{{Reps: 5, Type: models.WorkingSet}, {Reps: 5, Type: models.AMRAPSet}}, 2.5)...)
+	workout := &models.Workout{Day: 1, Exercises: []models.Lift{
+		{LiftName: models.Squat, Variant: "SSB", Sets: sets},
+		{LiftName: "ChinUp", Optional: true},
+	}}
+
+	index, err := FindSubstitutable(workout, models.Squat)
+	require.NoError(t, err)
+	ApplyModifiers(workout, Substitute(index, models.Deadlift, 95, models.Pounds))
+
+	lift := workout.Exercises[0]
+	assert.Equal(t, models.Deadlift, lift.WeightKey())
+	assert.Equal(t, models.LiftName("Squat:SSB"), lift.SubstitutedFor)
+	require.Len(t, lift.Sets, 5)
+	assert.Equal(t, 45.0, lift.Sets[0].Weight) // Never lighter than the bar
+	for _, set := range lift.Sets[3:] {
+		assert.Equal(t, 95.0, set.Weight)
+	}
+	for i := 1; i < len(lift.Sets); i++ {
+		assert.GreaterOrEqual(t, lift.Sets[i].Weight, lift.Sets[i-1].Weight)
+	}
+
+	_, err = FindSubstitutable(workout, "ChinUp")
+	assert.ErrorContains(t, err, "only barbell lifts can")
+	_, err = FindSubstitutable(workout, models.BenchPress)
+	assert.ErrorContains(t, err, "Day 1 has no BenchPress to substitute")
+}
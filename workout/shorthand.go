@@ -0,0 +1,249 @@
+package workout
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// ShorthandEntry is one lift parsed from workout shorthand, e.g. "ohp 95x5,5,8"
+type ShorthandEntry struct {
+	// Text is the entry as written, used in error messages
+	Text string
+
+	Lift    models.LiftName
+	Variant string
+	Weight  float64
+
+	// Reps are the reps completed for each working set, including the AMRAP set
+	Reps []int
+}
+
+// ParseShorthand parses a workout shorthand string. Entries are separated by
+// semicolons, and each entry is a lift, a weight, and the reps of every working
+// set: "ohp 95x5,5,8; squat 135x5,5,9". Lifts accept the same names as
+// ParseLiftName, and a variant can be given after a colon, e.g. "squat:ssb".
+func ParseShorthand(input string) ([]ShorthandEntry, error) {
+	var entries []ShorthandEntry
+	for i, text := range strings.Split(input, ";") {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		entry, err := parseShorthandEntry(text)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d (%q): %w", i+1, text, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf(`no lifts found: expected entries like "ohp 95x5,5,8; squat 135x5,5,9"`)
+	}
+	return entries, nil
+}
+
+func parseShorthandEntry(text string) (ShorthandEntry, error) {
+	entry := ShorthandEntry{Text: text}
+
+	split := strings.LastIndexAny(text, " \t")
+	if split < 0 {
+		return entry, fmt.Errorf("expected a lift followed by sets, e.g. \"squat 135x5,5,9\"")
+	}
+	liftPart, setsPart := strings.TrimSpace(text[:split]), text[split+1:]
+
+	liftInput, variant, _ := strings.Cut(liftPart, ":")
+	lift, err := models.ParseLiftName(liftInput)
+	if err != nil {
+		return entry, err
+	}
+	entry.Lift = lift
+	entry.Variant = strings.TrimSpace(variant)
+
+	weightInput, repsInput, found := strings.Cut(strings.ToLower(setsPart), "x")
+	if !found {
+		return entry, fmt.Errorf("expected sets as <weight>x<reps>,<reps>,..., got %q", setsPart)
+	}
+
+	entry.Weight, err = strconv.ParseFloat(weightInput, 64)
+	if err != nil || entry.Weight <= 0 {
+		return entry, fmt.Errorf("invalid weight %q: must be a positive number", weightInput)
+	}
+
+	for _, repsText := range strings.Split(repsInput, ",") {
+		reps, err := strconv.Atoi(repsText)
+		if err != nil || reps < 0 {
+			return entry, fmt.Errorf("invalid reps %q: must be a whole number of 0 or more", repsText)
+		}
+		entry.Reps = append(entry.Reps, reps)
+	}
+
+	return entry, nil
+}
+
+// BuildFromShorthand creates a completed workout from shorthand entries, validated
+// against the expected session. Every lift in the session must appear exactly once
+// at its prescribed weight, with reps for each of its working sets. Warmup sets and
+// feeler singles are recorded as completed as prescribed. Optional accessories can't
+// be written in shorthand and are left out as not performed.
+func BuildFromShorthand(expected *models.Workout, entries []ShorthandEntry) (*models.Workout, error) {
+	matched := make([]*ShorthandEntry, len(expected.Exercises))
+	for i := range entries {
+		entry := &entries[i]
+		index := findShorthandLift(expected, entry)
+		if index < 0 {
+			return nil, fmt.Errorf("%q: %s is not part of the Day %d workout (%s)",
+				entry.Text, shorthandLiftName(entry).DisplayName(), expected.Day, sessionLiftNames(expected))
+		}
+		if matched[index] != nil {
+			return nil, fmt.Errorf("%q: %s is listed more than once", entry.Text, shorthandLiftName(entry).DisplayName())
+		}
+		matched[index] = entry
+	}
+
+	completed := &models.Workout{
+		ID:            uuid.Must(uuid.NewV7()),
+		UserProgramID: expected.UserProgramID,
+		Day:           expected.Day,
+		Exercises:     make([]models.Lift, 0, len(expected.Exercises)),
+		EnteredAt:     time.Now(),
+	}
+
+	for i, exercise := range expected.Exercises {
+		if exercise.Optional {
+			continue
+		}
+		entry := matched[i]
+		if entry == nil {
+			return nil, fmt.Errorf("missing %s: the Day %d workout is %s", exercise.WeightKey().DisplayName(), expected.Day, sessionLiftNames(expected))
+		}
+
+		lift, err := completeFromShorthand(&exercise, entry)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", entry.Text, err)
+		}
+		completed.Exercises = append(completed.Exercises, lift)
+	}
+
+	return completed, nil
+}
+
+// BuildAdHoc creates a workout logged outside any program from shorthand
+// entries, one lift per entry in the order written. Every set is a working set
+// with the reps completed as its target. A lift may be listed more than once,
+// e.g. to record sets at several weights.
+func BuildAdHoc(entries []ShorthandEntry, enteredAt time.Time) *models.Workout {
+	completed := &models.Workout{
+		ID:        uuid.Must(uuid.NewV7()),
+		Exercises: make([]models.Lift, 0, len(entries)),
+		EnteredAt: enteredAt,
+		AdHoc:     true,
+	}
+
+	for _, entry := range entries {
+		lift := models.Lift{
+			ID:       uuid.Must(uuid.NewV7()),
+			LiftName: entry.Lift,
+			Variant:  entry.Variant,
+			Sets:     make([]models.Set, len(entry.Reps)),
+		}
+		for i, reps := range entry.Reps {
+			lift.Sets[i] = models.Set{
+				ID:         uuid.Must(uuid.NewV7()),
+				Weight:     entry.Weight,
+				TargetReps: reps,
+				ActualReps: reps,
+				Type:       models.WorkingSet,
+				Order:      i + 1,
+			}
+		}
+		completed.Exercises = append(completed.Exercises, lift)
+	}
+
+	return completed
+}
+
+// completeFromShorthand fills in one expected lift's sets from its shorthand entry
+func completeFromShorthand(exercise *models.Lift, entry *ShorthandEntry) (models.Lift, error) {
+	var scheme []string
+	var workingWeight float64
+	for _, set := range exercise.Sets {
+		switch set.Type {
+		case models.WorkingSet:
+			scheme = append(scheme, strconv.Itoa(set.TargetReps))
+			workingWeight = set.Weight
+		case models.AMRAPSet:
+			scheme = append(scheme, strconv.Itoa(set.TargetReps)+"+")
+			workingWeight = set.Weight
+		}
+	}
+
+	if entry.Weight != workingWeight {
+		return models.Lift{}, fmt.Errorf("expected %s at %s lbs, got %s",
+			exercise.WeightKey().DisplayName(), formatShorthandWeight(workingWeight), formatShorthandWeight(entry.Weight))
+	}
+	if len(entry.Reps) != len(scheme) {
+		return models.Lift{}, fmt.Errorf("expected reps for %d working sets (%s), got %d",
+			len(scheme), strings.Join(scheme, ","), len(entry.Reps))
+	}
+
+	lift := models.Lift{
+		ID:          uuid.Must(uuid.NewV7()),
+		LiftName:    exercise.LiftName,
+		Variant:     exercise.Variant,
+		Optional:    exercise.Optional,
+		Bodyweight:  exercise.Bodyweight,
+		FixedWeight: exercise.FixedWeight,
+		Sets:        make([]models.Set, len(exercise.Sets)),
+	}
+
+	next := 0
+	for i, set := range exercise.Sets {
+		set.ID = uuid.Must(uuid.NewV7())
+		switch set.Type {
+		case models.WorkingSet, models.AMRAPSet:
+			set.ActualReps = entry.Reps[next]
+			next++
+		default:
+			set.ActualReps = set.TargetReps
+		}
+		lift.Sets[i] = set
+	}
+
+	return lift, nil
+}
+
+// findShorthandLift returns the index of the expected lift an entry refers to, or -1
+func findShorthandLift(expected *models.Workout, entry *ShorthandEntry) int {
+	for i, exercise := range expected.Exercises {
+		if exercise.Optional || exercise.LiftName != entry.Lift {
+			continue
+		}
+		if entry.Variant == "" || strings.EqualFold(entry.Variant, exercise.Variant) {
+			return i
+		}
+	}
+	return -1
+}
+
+func shorthandLiftName(entry *ShorthandEntry) models.LiftName {
+	return models.VariantKey(entry.Lift, entry.Variant)
+}
+
+func sessionLiftNames(workout *models.Workout) string {
+	var names []string
+	for _, exercise := range workout.Exercises {
+		if !exercise.Optional {
+			names = append(names, exercise.WeightKey().DisplayName())
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatShorthandWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
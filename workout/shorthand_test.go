@@ -0,0 +1,166 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShorthand(t *testing.T) {
+	entries, err := ParseShorthand("ohp 95x5,5,8; Squat:SSB 135X5,4,0 ;")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, ShorthandEntry{Text: "ohp 95x5,5,8", Lift: models.OverheadPress, Weight: 95, Reps: []int{5, 5, 8}}, entries[0])
+	assert.Equal(t, ShorthandEntry{Text: "Squat:SSB 135X5,4,0", Lift: models.Squat, Variant: "SSB", Weight: 135, Reps: []int{5, 4, 0}}, entries[1])
+
+	entries, err = ParseShorthand("overhead press 97.5x5,5,6")
+	require.NoError(t, err)
+	assert.Equal(t, models.OverheadPress, entries[0].Lift)
+	assert.Equal(t, 97.5, entries[0].Weight)
+}
+
+func TestParseShorthand_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "no lifts found"},
+		{" ; ", "no lifts found"},
+		{"squat", `entry 1 ("squat"): expected a lift followed by sets`},
+		{"ohp 95x5,5,8; curl 30x10", `entry 2 ("curl 30x10"): unknown lift "curl"`},
+		{"squat 135-5,5,5", `expected sets as <weight>x<reps>,<reps>,..., got "135-5,5,5"`},
+		{"squat abcx5,5,5", `invalid weight "abc"`},
+		{"squat 0x5,5,5", `invalid weight "0"`},
+		{"squat 135x5,,5", `invalid reps ""`},
+		{"squat 135x5,-1,5", `invalid reps "-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseShorthand(tt.input)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func shorthandSession() *models.Workout {
+	return &models.Workout{
+		Day: 1,
+		Exercises: []models.Lift{
+			{
+				LiftName: models.OverheadPress,
+				Sets: []models.Set{
+					{Weight: 45, TargetReps: 5, Type: models.WarmupSet, Order: 1},
+					{Weight: 95, TargetReps: 5, Type: models.WorkingSet, Order: 2},
+					{Weight: 95, TargetReps: 5, Type: models.WorkingSet, Order: 3},
+					{Weight: 95, TargetReps: 5, Type: models.AMRAPSet, Order: 4},
+				},
+			},
+			{
+				LiftName: models.Squat,
+				Variant:  "SSB",
+				Sets: []models.Set{
+					{Weight: 135, TargetReps: 5, Type: models.WorkingSet, Order: 1},
+					{Weight: 135, TargetReps: 5, Type: models.WorkingSet, Order: 2},
+					{Weight: 120, TargetReps: 1, Type: models.FeelerSet, Order: 3},
+					{Weight: 135, TargetReps: 5, Type: models.AMRAPSet, Order: 4},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildFromShorthand(t *testing.T) {
+	entries, err := ParseShorthand("squat 135x5,4,9; ohp 95x5,5,8")
+	require.NoError(t, err)
+
+	completed, err := BuildFromShorthand(shorthandSession(), entries)
+	require.NoError(t, err)
+
+	require.Len(t, completed.Exercises, 2)
+	assert.Equal(t, 1, completed.Day)
+
+	ohp := completed.Exercises[0]
+	assert.Equal(t, models.OverheadPress, ohp.LiftName)
+	assert.Equal(t, []int{5, 5, 5, 8}, actualReps(ohp.Sets))
+
+	squat := completed.Exercises[1]
+	assert.Equal(t, "SSB", squat.Variant)
+	assert.Equal(t, []int{5, 4, 1, 9}, actualReps(squat.Sets))
+	assert.Equal(t, models.FeelerSet, squat.Sets[2].Type)
+}
+
+func TestBuildFromShorthand_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ohp 95x5,5,8", "missing Squat (SSB): the Day 1 workout is Overhead Press, Squat (SSB)"},
+		{"ohp 95x5,5,8; squat 135x5,5,5; bench 125x5,5,5", `"bench 125x5,5,5": Bench Press is not part of the Day 1 workout (Overhead Press, Squat (SSB))`},
+		{"ohp 95x5,5,8; squat:front 135x5,5,5", "Squat (front) is not part of the Day 1 workout"},
+		{"ohp 95x5,5,8; ohp 95x5,5,8; squat 135x5,5,5", "Overhead Press is listed more than once"},
+		{"ohp 100x5,5,8; squat 135x5,5,5", `"ohp 100x5,5,8": expected Overhead Press at 95 lbs, got 100`},
+		{"ohp 95x5,8; squat 135x5,5,5", "expected reps for 3 working sets (5,5,5+), got 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			entries, err := ParseShorthand(tt.input)
+			require.NoError(t, err)
+
+			_, err = BuildFromShorthand(shorthandSession(), entries)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestBuildFromShorthand_OptionalLift(t *testing.T) {
+	session := shorthandSession()
+	session.Exercises = append(session.Exercises, models.Lift{
+		LiftName: "Chin-ups",
+		Optional: true,
+		Sets:     []models.Set{{TargetReps: 8, Type: models.AMRAPSet, Order: 1}},
+	})
+
+	entries, err := ParseShorthand("ohp 95x5,5,8; squat 135x5,5,9")
+	require.NoError(t, err)
+
+	completed, err := BuildFromShorthand(session, entries)
+	require.NoError(t, err)
+	assert.Len(t, completed.Exercises, 2, "accessories are recorded as not performed")
+}
+
+func TestBuildAdHoc(t *testing.T) {
+	entries, err := ParseShorthand("deadlift 315x3; deadlift 365x1; bench 185x3,3,2")
+	require.NoError(t, err)
+
+	enteredAt := time.Date(2024, 5, 4, 10, 0, 0, 0, time.UTC)
+	completed := BuildAdHoc(entries, enteredAt)
+
+	assert.True(t, completed.AdHoc)
+	assert.Equal(t, uuid.Nil, completed.UserProgramID)
+	assert.Zero(t, completed.Day)
+	assert.Equal(t, enteredAt, completed.EnteredAt)
+
+	require.Len(t, completed.Exercises, 3)
+	assert.Equal(t, models.Deadlift, completed.Exercises[1].LiftName)
+	assert.Equal(t, 365.0, completed.Exercises[1].Sets[0].Weight)
+
+	bench := completed.Exercises[2]
+	assert.Equal(t, models.BenchPress, bench.LiftName)
+	assert.Equal(t, []int{3, 3, 2}, actualReps(bench.Sets))
+	assert.Equal(t, models.Set{ID: bench.Sets[2].ID, Weight: 185, TargetReps: 2, ActualReps: 2, Type: models.WorkingSet, Order: 3}, bench.Sets[2])
+}
+
+func actualReps(sets []models.Set) []int {
+	reps := make([]int, len(sets))
+	for i, set := range sets {
+		reps[i] = set.ActualReps
+	}
+	return reps
+}
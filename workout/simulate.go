@@ -0,0 +1,177 @@
+package workout
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// SimulateSessions returns a copy of the user whose current program has been
+// taken through the given number of sessions, each completed as prescribed
+// with every AMRAP set reaching its target, so lifts progress as they would
+// after a run of successful workouts. Holds and deloads count down as usual.
+// The user is left unchanged.
+func SimulateSessions(user *models.User, program *models.Program, config *models.Config, sessions int) (*models.User, error) {
+	simulated, _, err := simulate(user, program, config, sessions, SimulationAssumptions{}, nil)
+	return simulated, err
+}
+
+// PreviewSessions returns the user's next workouts, one for each of the given
+// number of sessions, each calculated after the ones before it are completed
+// as in SimulateSessions. The user is left unchanged.
+func PreviewSessions(user *models.User, program *models.Program, config *models.Config, sessions int) ([]*models.Workout, error) {
+	_, workouts, err := simulate(user, program, config, sessions, SimulationAssumptions{}, nil)
+	return workouts, err
+}
+
+// SimulationAssumptions describe how the AMRAP sets of simulated sessions go.
+// The zero value reaches every set's target.
+type SimulationAssumptions struct {
+	// AMRAPAverage is the average reps of every AMRAP set. Fractions are
+	// reached by doing a rep more on that share of sets. 0 reaches each
+	// set's target, or the top of its rep range.
+	AMRAPAverage float64
+
+	// DeloadChance is the chance, from 0 to 1, that an AMRAP set falls a rep
+	// short of its target, deloading the lift
+	DeloadChance float64
+
+	// Seed drives the random choices; the same seed always gives the same projection
+	Seed int64
+}
+
+// ProjectionPoint is each lift's working weight after a simulated session
+type ProjectionPoint struct {
+	Date    time.Time                   `json:"date"`
+	Day     int                         `json:"day"`
+	Weights map[models.LiftName]float64 `json:"weights"`
+}
+
+// Projection is how a UserProgram's weights change over simulated sessions
+type Projection struct {
+	Start  map[models.LiftName]float64 `json:"start"`
+	Points []ProjectionPoint           `json:"points"`
+}
+
+// Final returns the weights after the last simulated session, or the starting
+// weights if there were none
+func (p *Projection) Final() map[models.LiftName]float64 {
+	if len(p.Points) == 0 {
+		return p.Start
+	}
+	return p.Points[len(p.Points)-1].Weights
+}
+
+// ProjectWeights simulates a session of the user's current program on each of
+// the given dates under the assumptions, recording the weights after each.
+// The user is left unchanged.
+func ProjectWeights(user *models.User, program *models.Program, config *models.Config, dates []time.Time, assumptions SimulationAssumptions) (*Projection, error) {
+	userProgram, exists := user.Programs[user.CurrentProgram]
+	if !exists {
+		return nil, fmt.Errorf("current program not found in user programs")
+	}
+
+	projection := &Projection{Start: maps.Clone(userProgram.CurrentWeights)}
+	_, _, err := simulate(user, program, config, len(dates), assumptions, func(session int, completed *models.Workout, up *models.UserProgram) {
+		projection.Points = append(projection.Points, ProjectionPoint{
+			Date:    dates[session],
+			Day:     completed.Day,
+			Weights: maps.Clone(up.CurrentWeights),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projection, nil
+}
+
+// simulate completes sessions on a copy of the user under the assumptions,
+// returning the copy and each session's workout. observe, if set, is called
+// after each session is applied.
+func simulate(user *models.User, program *models.Program, config *models.Config, sessions int, assumptions SimulationAssumptions,
+	observe func(session int, completed *models.Workout, up *models.UserProgram)) (*models.User, []*models.Workout, error) {
+	userProgram, exists := user.Programs[user.CurrentProgram]
+	if !exists {
+		return nil, nil, fmt.Errorf("current program not found in user programs")
+	}
+
+	simulated := *user
+	userProgram = userProgram.Clone()
+	simulated.Programs = map[uuid.UUID]*models.UserProgram{user.CurrentProgram: userProgram}
+	rng := rand.New(rand.NewSource(assumptions.Seed))
+
+	workouts := make([]*models.Workout, 0, sessions)
+	for session := range sessions {
+		next, err := CalculateNextWorkoutWithConfig(&simulated, program, config)
+		if err != nil {
+			return nil, nil, err
+		}
+		workouts = append(workouts, next)
+
+		completed := *next
+		completed.Exercises = make([]models.Lift, len(next.Exercises))
+		for i, lift := range next.Exercises {
+			lift.Sets = slices.Clone(lift.Sets)
+			completed.Exercises[i] = lift
+		}
+		completeAssuming(&completed, assumptions, rng)
+		if err := ApplyWorkout(userProgram, &completed, program); err != nil {
+			return nil, nil, fmt.Errorf("failed to simulate Day %d: %w", next.Day, err)
+		}
+		if observe != nil {
+			observe(session, &completed, userProgram)
+		}
+	}
+	return &simulated, workouts, nil
+}
+
+// completeAssuming fills in a workout's reps as prescribed, then sets each
+// AMRAP set's reps by the assumptions
+func completeAssuming(workout *models.Workout, assumptions SimulationAssumptions, rng *rand.Rand) {
+	CompleteAsPrescribed(workout)
+	for i := range workout.Exercises {
+		for j := range workout.Exercises[i].Sets {
+			set := &workout.Exercises[i].Sets[j]
+			if set.Type != models.AMRAPSet {
+				continue
+			}
+			if assumptions.AMRAPAverage > 0 {
+				whole, fraction := math.Modf(assumptions.AMRAPAverage)
+				set.ActualReps = int(whole)
+				if rng.Float64() < fraction {
+					set.ActualReps++
+				}
+			}
+			if rng.Float64() < assumptions.DeloadChance {
+				set.ActualReps = max(0, set.TargetReps-1)
+			}
+		}
+	}
+}
+
+// CompleteAsPrescribed fills in every set's reps as a successful session: the
+// target reps, and the top of the range for AMRAP sets with a rep range so
+// they progress too
+func CompleteAsPrescribed(workout *models.Workout) {
+	for i := range workout.Exercises {
+		for j := range workout.Exercises[i].Sets {
+			set := &workout.Exercises[i].Sets[j]
+			set.ActualReps = set.TargetReps
+			if set.Type == models.AMRAPSet {
+				set.ActualReps = max(set.TargetReps, set.MaxReps)
+			}
+		}
+	}
+}
+
+// SessionsUntil returns how many sessions come before the given program day,
+// counting from currentDay: 0 when day is the next one
+func SessionsUntil(currentDay, day, totalDays int) int {
+	return (day - GetWorkoutDay(currentDay, totalDays) + totalDays) % totalDays
+}
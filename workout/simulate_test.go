@@ -0,0 +1,141 @@
+package workout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateSessions(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.Squat: 135, models.Deadlift: 185, models.BenchPress: 125, models.OverheadPress: 95,
+	})
+	user.Programs[user.CurrentProgram].Holds = map[models.LiftName]int{models.Squat: 1}
+
+	simulated, err := SimulateSessions(user, program.GreyskullLP, nil, 3)
+	require.NoError(t, err)
+
+	up := simulated.Programs[simulated.CurrentProgram]
+	assert.Equal(t, 4, up.CurrentDay)
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat:         140, // Held on day 1, progressed on day 3
+		models.Deadlift:      190,
+		models.BenchPress:    127.5,
+		models.OverheadPress: 100,
+	}, up.CurrentWeights)
+	assert.Empty(t, up.Holds)
+
+	original := user.Programs[user.CurrentProgram]
+	assert.Equal(t, 1, original.CurrentDay, "the user is unchanged")
+	assert.Equal(t, 95.0, original.CurrentWeights[models.OverheadPress])
+	assert.Equal(t, map[models.LiftName]int{models.Squat: 1}, original.Holds)
+
+	next, err := CalculateNextWorkout(simulated, program.GreyskullLP)
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.Day)
+}
+
+func TestSimulateSessions_Deload(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.Squat: 135, models.Deadlift: 185, models.BenchPress: 125, models.OverheadPress: 95,
+	})
+	user.Programs[user.CurrentProgram].Deload = &models.DeloadPlan{Percentage: 0.8, Sets: 2, Reps: 5, SessionsRemaining: 1}
+
+	simulated, err := SimulateSessions(user, program.GreyskullLP, nil, 2)
+	require.NoError(t, err)
+
+	up := simulated.Programs[simulated.CurrentProgram]
+	assert.Nil(t, up.Deload)
+	assert.Equal(t, 95.0, up.CurrentWeights[models.OverheadPress], "deload sessions don't progress")
+	assert.Equal(t, 127.5, up.CurrentWeights[models.BenchPress])
+}
+
+func TestCompleteAsPrescribed(t *testing.T) {
+	completed := &models.Workout{Exercises: []models.Lift{{Sets: []models.Set{
+		{Type: models.WarmupSet, TargetReps: 5},
+		{Type: models.WorkingSet, TargetReps: 6, MaxReps: 8},
+		{Type: models.AMRAPSet, TargetReps: 6, MaxReps: 8},
+		{Type: models.AMRAPSet, TargetReps: 5},
+	}}}}
+
+	CompleteAsPrescribed(completed)
+	var reps []int
+	for _, set := range completed.Exercises[0].Sets {
+		reps = append(reps, set.ActualReps)
+	}
+	assert.Equal(t, []int{5, 6, 8, 5}, reps)
+}
+
+func TestSessionsUntil(t *testing.T) {
+	assert.Equal(t, 0, SessionsUntil(1, 1, 6))
+	assert.Equal(t, 3, SessionsUntil(1, 4, 6))
+	assert.Equal(t, 4, SessionsUntil(5, 3, 6), "wraps into the next cycle")
+	assert.Equal(t, 1, SessionsUntil(7, 2, 6), "current days past the cycle wrap")
+}
+
+func TestPreviewSessions(t *testing.T) {
+	user := createTestUser(5, map[models.LiftName]float64{
+		models.Squat: 135, models.Deadlift: 185, models.BenchPress: 125, models.OverheadPress: 95,
+	})
+
+	workouts, err := PreviewSessions(user, program.GreyskullLP, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, workouts, 3)
+	assert.Equal(t, []int{5, 6, 1}, []int{workouts[0].Day, workouts[1].Day, workouts[2].Day})
+
+	// Day 1's overhead press comes after day 5's
+	lastSet := func(lift models.Lift) models.Set { return lift.Sets[len(lift.Sets)-1] }
+	assert.Equal(t, 95.0, lastSet(workouts[0].Exercises[0]).Weight)
+	assert.Equal(t, 97.5, lastSet(workouts[2].Exercises[0]).Weight)
+	assert.Zero(t, lastSet(workouts[0].Exercises[0]).ActualReps, "previewed workouts aren't filled in")
+	assert.Equal(t, 5, user.Programs[user.CurrentProgram].CurrentDay)
+}
+
+func TestProjectWeights(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.Squat: 135, models.Deadlift: 185, models.BenchPress: 125, models.OverheadPress: 95,
+	})
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	dates := []time.Time{start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 4)}
+
+	projection, err := ProjectWeights(user, program.GreyskullLP, nil, dates, SimulationAssumptions{})
+	require.NoError(t, err)
+	require.Len(t, projection.Points, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{projection.Points[0].Day, projection.Points[1].Day, projection.Points[2].Day})
+	assert.Equal(t, dates[1], projection.Points[1].Date)
+	assert.Equal(t, 95.0, projection.Start[models.OverheadPress])
+	assert.Equal(t, 97.5, projection.Points[0].Weights[models.OverheadPress])
+	assert.Equal(t, map[models.LiftName]float64{
+		models.Squat: 145, models.Deadlift: 190, models.BenchPress: 127.5, models.OverheadPress: 100,
+	}, projection.Final())
+
+	// Ten reps doubles every increment
+	projection, err = ProjectWeights(user, program.GreyskullLP, nil, dates[:1], SimulationAssumptions{AMRAPAverage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, projection.Final()[models.OverheadPress])
+	assert.Equal(t, 145.0, projection.Final()[models.Squat])
+
+	// Every AMRAP set falling short deloads every lift
+	projection, err = ProjectWeights(user, program.GreyskullLP, nil, dates[:1], SimulationAssumptions{DeloadChance: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 85.0, projection.Final()[models.OverheadPress])
+	assert.Equal(t, 95.0, user.Programs[user.CurrentProgram].CurrentWeights[models.OverheadPress], "the user is unchanged")
+}
+
+func TestProjectWeights_SameSeed(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.Squat: 135, models.Deadlift: 185, models.BenchPress: 125, models.OverheadPress: 95,
+	})
+	dates := make([]time.Time, 30)
+	assumptions := SimulationAssumptions{AMRAPAverage: 7.5, DeloadChance: 0.2, Seed: 42}
+
+	first, err := ProjectWeights(user, program.GreyskullLP, nil, dates, assumptions)
+	require.NoError(t, err)
+	second, err := ProjectWeights(user, program.GreyskullLP, nil, dates, assumptions)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
@@ -0,0 +1,118 @@
+package workout
+
+import (
+	"github.com/google/uuid"
+	"github.com/mikowitz/greyskull/models"
+)
+
+// WarmupContext is what a WarmupStrategy knows about the lift it warms up
+type WarmupContext struct {
+	// Templates are the lift's warmup sets from the program, with the user's
+	// warmup percentages applied. Lifts without any are not warmed up.
+	Templates []models.SetTemplate
+	Unit      models.WeightUnit
+	Step      float64 // The lift's rounding step
+	BarWeight float64 // The lift's empty bar
+	Plate     float64 // The heaviest plate that can be loaded as a pair
+}
+
+// WarmupStrategy builds the warmup sets for a working weight
+type WarmupStrategy interface {
+	WarmupSets(weight float64, ctx WarmupContext) []models.Set
+}
+
+// PercentStrategy ramps through the lift's warmup set percentages, skipping
+// warmups entirely when the working weight is light
+type PercentStrategy struct{}
+
+// WarmupSets implements WarmupStrategy
+func (PercentStrategy) WarmupSets(weight float64, ctx WarmupContext) []models.Set {
+	return CalculateWarmupSetsWithBar(weight, ctx.Templates, ctx.Unit, ctx.BarWeight, ctx.Step)
+}
+
+// plateJumpReps are the reps of each plate jump set, the empty bar first; later
+// jumps are singles
+var plateJumpReps = []int{5, 5, 3, 2}
+
+// plateJumpLimit is the heaviest plate jump as a fraction of the working weight
+const plateJumpLimit = 0.9
+
+// PlateJumpStrategy warms up with the empty bar, then adds a pair of the
+// heaviest plates per set while staying at or below 90% of the working weight.
+// Reps drop from 5 to singles as the jumps get heavier.
+type PlateJumpStrategy struct{}
+
+// WarmupSets implements WarmupStrategy
+func (PlateJumpStrategy) WarmupSets(weight float64, ctx WarmupContext) []models.Set {
+	sets := []models.Set{}
+	if len(ctx.Templates) == 0 || weight <= ctx.BarWeight {
+		return sets
+	}
+
+	for setWeight := ctx.BarWeight; len(sets) == 0 || setWeight <= weight*plateJumpLimit; setWeight += 2 * ctx.Plate {
+		reps := 1
+		if len(sets) < len(plateJumpReps) {
+			reps = plateJumpReps[len(sets)]
+		}
+		sets = append(sets, warmupSet(setWeight, reps, len(sets)+1))
+		if ctx.Plate <= 0 {
+			break
+		}
+	}
+	return sets
+}
+
+// CustomStrategy follows the steps of a program's custom warmup scheme. Steps
+// that would be as heavy as the working weight are left out.
+type CustomStrategy struct {
+	Steps []models.WarmupStep
+}
+
+// WarmupSets implements WarmupStrategy
+func (s CustomStrategy) WarmupSets(weight float64, ctx WarmupContext) []models.Set {
+	sets := []models.Set{}
+	if len(ctx.Templates) == 0 || weight <= ctx.BarWeight {
+		return sets
+	}
+
+	for _, step := range s.Steps {
+		setWeight := ctx.BarWeight + 2*float64(step.Plates)*ctx.Plate
+		if step.Percentage > 0 {
+			setWeight = max(RoundDownTo(weight*step.Percentage, ctx.Step), ctx.BarWeight)
+		}
+		if setWeight >= weight {
+			continue
+		}
+		sets = append(sets, warmupSet(setWeight, step.Reps, len(sets)+1))
+	}
+	return sets
+}
+
+// NewWarmupStrategy returns the strategy for a program's warmup scheme, which
+// a user's chosen strategy replaces. A nil scheme ramps by percentage.
+func NewWarmupStrategy(scheme *models.WarmupScheme, userChoice models.WarmupStrategyName) WarmupStrategy {
+	strategy := userChoice
+	if strategy == "" && scheme != nil {
+		strategy = scheme.Strategy
+	}
+
+	switch strategy {
+	case models.WarmupPlateJump:
+		return PlateJumpStrategy{}
+	case models.WarmupCustom:
+		if scheme != nil {
+			return CustomStrategy{Steps: scheme.Steps}
+		}
+	}
+	return PercentStrategy{}
+}
+
+func warmupSet(weight float64, reps, order int) models.Set {
+	return models.Set{
+		ID:         uuid.Must(uuid.NewV7()),
+		Weight:     weight,
+		TargetReps: reps,
+		Type:       models.WarmupSet,
+		Order:      order,
+	}
+}
@@ -0,0 +1,93 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/mikowitz/greyskull/models"
+	"github.com/mikowitz/greyskull/program"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warmupLoads returns the weight and reps of each warmup set, checking that
+// they are numbered in order
+func warmupLoads(t *testing.T, sets []models.Set) [][2]float64 {
+	t.Helper()
+	loads := make([][2]float64, len(sets))
+	for i, set := range sets {
+		assert.Equal(t, models.WarmupSet, set.Type)
+		assert.Equal(t, i+1, set.Order)
+		loads[i] = [2]float64{set.Weight, float64(set.TargetReps)}
+	}
+	return loads
+}
+
+var barbellWarmup = WarmupContext{
+	Templates: []models.SetTemplate{{Reps: 5, Type: models.WarmupSet}, {Reps: 4, WeightPercentage: 0.55, Type: models.WarmupSet}},
+	Unit:      models.Pounds,
+	Step:      2.5,
+	BarWeight: 45,
+	Plate:     45,
+}
+
+func TestPlateJumpStrategy(t *testing.T) {
+	strategy := PlateJumpStrategy{}
+
+	assert.Equal(t, [][2]float64{{45, 5}, {135, 5}, {225, 3}, {315, 2}, {405, 1}},
+		warmupLoads(t, strategy.WarmupSets(455, barbellWarmup)))
+	// Jumps stay at or below 90% of the working weight
+	assert.Equal(t, [][2]float64{{45, 5}, {135, 5}}, warmupLoads(t, strategy.WarmupSets(240, barbellWarmup)))
+	// Light weights still get the empty bar, unlike percentage ramps
+	assert.Equal(t, [][2]float64{{45, 5}}, warmupLoads(t, strategy.WarmupSets(65, barbellWarmup)))
+	assert.Empty(t, strategy.WarmupSets(45, barbellWarmup))
+
+	// Smaller plates make smaller jumps
+	small := barbellWarmup
+	small.Plate = 25
+	assert.Equal(t, [][2]float64{{45, 5}, {95, 5}, {145, 3}}, warmupLoads(t, strategy.WarmupSets(165, small)))
+
+	// Lifts the program doesn't warm up aren't warmed up
+	none := barbellWarmup
+	none.Templates = nil
+	assert.Empty(t, strategy.WarmupSets(225, none))
+}
+
+func TestCustomStrategy(t *testing.T) {
+	strategy := CustomStrategy{Steps: []models.WarmupStep{
+		{Reps: 10},
+		{Reps: 5, Plates: 1},
+		{Reps: 3, Percentage: 0.8},
+		{Reps: 1, Plates: 2},
+	}}
+
+	assert.Equal(t, [][2]float64{{45, 10}, {135, 5}, {180, 3}}, warmupLoads(t, strategy.WarmupSets(225, barbellWarmup)))
+	// Steps as heavy as the working weight are left out
+	assert.Equal(t, [][2]float64{{45, 10}, {80, 3}}, warmupLoads(t, strategy.WarmupSets(100, barbellWarmup)))
+}
+
+func TestNewWarmupStrategy(t *testing.T) {
+	custom := &models.WarmupScheme{Strategy: models.WarmupCustom, Steps: []models.WarmupStep{{Reps: 5}}}
+
+	assert.Equal(t, PercentStrategy{}, NewWarmupStrategy(nil, ""))
+	assert.Equal(t, PlateJumpStrategy{}, NewWarmupStrategy(&models.WarmupScheme{Strategy: models.WarmupPlateJump}, ""))
+	assert.Equal(t, CustomStrategy{Steps: custom.Steps}, NewWarmupStrategy(custom, ""))
+	// A user's choice replaces the program's
+	assert.Equal(t, PlateJumpStrategy{}, NewWarmupStrategy(custom, models.WarmupPlateJump))
+	assert.Equal(t, PercentStrategy{}, NewWarmupStrategy(custom, models.WarmupPercent))
+}
+
+func TestCalculateNextWorkout_WarmupStrategy(t *testing.T) {
+	user := createTestUser(1, map[models.LiftName]float64{
+		models.OverheadPress: 95.0,
+		models.Squat:         225.0,
+	})
+
+	config := &models.Config{WarmupStrategy: models.WarmupPlateJump}
+	result, err := CalculateNextWorkoutWithConfig(user, program.GreyskullLP, config)
+	require.NoError(t, err)
+
+	squat := result.Exercises[1]
+	assert.Equal(t, [][2]float64{{45, 5}, {135, 5}}, warmupLoads(t, squat.Sets[:2]))
+	assert.Equal(t, models.WorkingSet, squat.Sets[2].Type)
+	assert.Equal(t, 3, squat.Sets[2].Order, "working sets follow the warmups")
+}